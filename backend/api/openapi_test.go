@@ -0,0 +1,154 @@
+package api
+
+import (
+	"bufio"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// routePattern matches mux registrations such as
+// mux.HandleFunc("GET /api/v1/statements/{id}", h.GetStatement).
+var routePattern = regexp.MustCompile(`HandleFunc\("([A-Z]+) ([^"]+)"`)
+
+var httpMethods = map[string]bool{
+	"get": true, "put": true, "post": true, "delete": true,
+	"patch": true, "head": true, "options": true, "trace": true,
+}
+
+// loadSpecOperations reads openapi.yaml and returns the set of operations it
+// documents, keyed as "METHOD /path". The spec is indentation-based YAML, so
+// path keys sit at two spaces under "paths:" and methods at four.
+func loadSpecOperations(t *testing.T) map[string]bool {
+	t.Helper()
+
+	f, err := os.Open("openapi.yaml")
+	if err != nil {
+		t.Fatalf("open spec: %v", err)
+	}
+	defer f.Close()
+
+	ops := make(map[string]bool)
+	inPaths := false
+	currentPath := ""
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continue
+		}
+
+		if !strings.HasPrefix(line, " ") {
+			inPaths = strings.TrimSpace(line) == "paths:"
+			currentPath = ""
+			continue
+		}
+		if !inPaths {
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(line, "  /"):
+			currentPath = strings.TrimSuffix(strings.TrimSpace(line), ":")
+		case strings.HasPrefix(line, "    ") && !strings.HasPrefix(line, "     "):
+			key := strings.TrimSuffix(strings.TrimSpace(line), ":")
+			if currentPath != "" && httpMethods[key] {
+				ops[strings.ToUpper(key)+" "+currentPath] = true
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("read spec: %v", err)
+	}
+	return ops
+}
+
+// registeredRoutes scans the backend sources for mux.HandleFunc
+// registrations and returns them keyed as "METHOD /path".
+func registeredRoutes(t *testing.T) map[string]bool {
+	t.Helper()
+
+	routes := make(map[string]bool)
+	err := filepath.WalkDir("..", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if name := d.Name(); name == "vendor" || (strings.HasPrefix(name, ".") && name != "..") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		for _, m := range routePattern.FindAllStringSubmatch(string(src), -1) {
+			routes[m[1]+" "+m[2]] = true
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("scan sources: %v", err)
+	}
+	return routes
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func TestOpenAPISpecMatchesRoutes(t *testing.T) {
+	spec := loadSpecOperations(t)
+	routes := registeredRoutes(t)
+
+	if len(routes) < 20 {
+		t.Fatalf("expected at least 20 registered routes, found %d", len(routes))
+	}
+
+	for _, route := range sortedKeys(routes) {
+		if !spec[route] {
+			t.Errorf("route %q is registered but missing from openapi.yaml", route)
+		}
+	}
+	for _, op := range sortedKeys(spec) {
+		if !routes[op] {
+			t.Errorf("openapi.yaml documents %q but no handler registers it", op)
+		}
+	}
+}
+
+func TestOpenAPISpecRefsResolve(t *testing.T) {
+	data, err := os.ReadFile("openapi.yaml")
+	if err != nil {
+		t.Fatalf("read spec: %v", err)
+	}
+	spec := string(data)
+
+	refPattern := regexp.MustCompile(`\$ref: "#/components/(\w+)/(\w+)"`)
+	for _, m := range refPattern.FindAllStringSubmatch(spec, -1) {
+		section, name := m[1], m[2]
+		sectionIdx := strings.Index(spec, "\n  "+section+":\n")
+		if sectionIdx < 0 {
+			t.Errorf("components section %q not found for $ref %s", section, m[0])
+			continue
+		}
+		if !strings.Contains(spec[sectionIdx:], "\n    "+name+":\n") {
+			t.Errorf("unresolved $ref #/components/%s/%s", section, name)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+// Command config-encrypt encrypts a plain JSON config file for use with
+// CONFIG_FILE_PATH. The master key is read from CONFIG_MASTER_KEY.
+//
+// Usage:
+//
+//	CONFIG_MASTER_KEY=... config-encrypt -in config.json -out config.enc.json
+//	CONFIG_MASTER_KEY=... config-encrypt -decrypt -in config.enc.json
+package main
+
+import (
+	"flag"
+	"io"
+	"log"
+	"os"
+
+	"github.com/controlcrud/backend/internal/config"
+)
+
+func main() {
+	in := flag.String("in", "", "input file (default stdin)")
+	out := flag.String("out", "", "output file (default stdout)")
+	decrypt := flag.Bool("decrypt", false, "decrypt an encrypted config file instead")
+	flag.Parse()
+
+	masterKey := os.Getenv("CONFIG_MASTER_KEY")
+	if masterKey == "" {
+		log.Fatal("CONFIG_MASTER_KEY is required")
+	}
+
+	var input []byte
+	var err error
+	if *in == "" {
+		input, err = io.ReadAll(os.Stdin)
+	} else {
+		input, err = os.ReadFile(*in)
+	}
+	if err != nil {
+		log.Fatalf("Failed to read input: %v", err)
+	}
+
+	var output []byte
+	if *decrypt {
+		output, err = config.DecryptConfigFile(input, masterKey)
+	} else {
+		output, err = config.EncryptConfigFile(input, masterKey)
+	}
+	if err != nil {
+		log.Fatalf("Failed to process config: %v", err)
+	}
+	output = append(output, '\n')
+
+	if *out == "" {
+		_, err = os.Stdout.Write(output)
+	} else {
+		err = os.WriteFile(*out, output, 0o600)
+	}
+	if err != nil {
+		log.Fatalf("Failed to write output: %v", err)
+	}
+}
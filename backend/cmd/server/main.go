@@ -2,26 +2,42 @@ package main
 
 import (
 	"context"
+	"crypto/ecdsa"
 	"database/sql"
-	"encoding/json"
+	"flag"
 	"fmt"
 	"log"
 	"log/slog"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
 	"time"
 
+	"google.golang.org/grpc"
+
+	"github.com/controlcrud/backend/internal/api/grpcserver"
+	adminHandler "github.com/controlcrud/backend/internal/api/handlers/admin"
 	auditHandler "github.com/controlcrud/backend/internal/api/handlers/audit"
 	connHandler "github.com/controlcrud/backend/internal/api/handlers/connection"
 	ctrlHandler "github.com/controlcrud/backend/internal/api/handlers/controls"
+	healthHandler "github.com/controlcrud/backend/internal/api/handlers/health"
 	pushHandler "github.com/controlcrud/backend/internal/api/handlers/push"
 	stmtHandler "github.com/controlcrud/backend/internal/api/handlers/statements"
 	syncHandler "github.com/controlcrud/backend/internal/api/handlers/sync"
+	auditMiddleware "github.com/controlcrud/backend/internal/api/middleware/audit"
+	"github.com/controlcrud/backend/internal/api/middleware/auth"
+	"github.com/controlcrud/backend/internal/api/middleware/bodylimit"
+	"github.com/controlcrud/backend/internal/api/middleware/cors"
+	"github.com/controlcrud/backend/internal/api/middleware/ratelimit"
+	"github.com/controlcrud/backend/internal/api/middleware/requestid"
+	"github.com/controlcrud/backend/internal/api/middleware/timeout"
 	"github.com/controlcrud/backend/internal/config"
 	"github.com/controlcrud/backend/internal/domain/audit"
 	"github.com/controlcrud/backend/internal/domain/connection"
+	"github.com/controlcrud/backend/internal/domain/control"
 	"github.com/controlcrud/backend/internal/domain/controls"
 	"github.com/controlcrud/backend/internal/domain/pull"
 	"github.com/controlcrud/backend/internal/domain/push"
@@ -29,11 +45,21 @@ import (
 	"github.com/controlcrud/backend/internal/domain/system"
 	"github.com/controlcrud/backend/internal/infrastructure/crypto"
 	"github.com/controlcrud/backend/internal/infrastructure/database"
+	"github.com/controlcrud/backend/internal/infrastructure/database/migrations"
+	"github.com/controlcrud/backend/internal/infrastructure/email"
+	"github.com/controlcrud/backend/internal/infrastructure/servicenow"
+	"github.com/controlcrud/backend/internal/infrastructure/webhook"
 
 	_ "github.com/lib/pq" // PostgreSQL driver
 )
 
 func main() {
+	migrateOnly := flag.Bool("migrate-only", false, "apply database migrations and exit")
+	flag.Parse()
+
+	// Configure logging first so every later message uses it
+	slog.SetDefault(config.LoadLogging().NewLogger(os.Stderr))
+
 	// Load configuration
 	cfg, err := config.Load()
 	if err != nil {
@@ -48,9 +74,9 @@ func main() {
 	defer db.Close()
 
 	// Configure connection pool
-	db.SetMaxOpenConns(25)
-	db.SetMaxIdleConns(5)
-	db.SetConnMaxLifetime(5 * time.Minute)
+	db.SetMaxOpenConns(cfg.Database.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.Database.MaxIdleConns)
+	db.SetConnMaxLifetime(time.Duration(cfg.Database.ConnMaxLifetimeMinutes) * time.Minute)
 
 	// Verify database connection
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -60,6 +86,15 @@ func main() {
 	}
 	log.Println("Database connection established")
 
+	// Apply pending schema migrations
+	if err := migrations.RunMigrations(db, cfg.Database.MigrationsDir); err != nil {
+		log.Fatalf("Failed to run database migrations: %v", err)
+	}
+	log.Println("Database migrations applied")
+	if *migrateOnly {
+		return
+	}
+
 	// Initialize crypto service
 	cryptoService, err := crypto.NewAESCryptoService(cfg.Encryption.Key)
 	if err != nil {
@@ -69,36 +104,138 @@ func main() {
 	// Initialize logger
 	logger := slog.Default()
 
+	// Load ServiceNow table mapping
+	tableMapping, err := servicenow.LoadTableMapping(cfg.ServiceNow.TableMappingFile)
+	if err != nil {
+		log.Fatalf("Failed to load ServiceNow table mapping: %v", err)
+	}
+	if cfg.ServiceNow.TableMappingFile != "" {
+		log.Printf("Loaded ServiceNow table mapping from %s", cfg.ServiceNow.TableMappingFile)
+	}
+
 	// Initialize repositories
-	connRepo := database.NewConnectionRepository(db)
-	systemRepo := database.NewSystemRepository(db)
-	controlRepo := database.NewControlRepository(db)
-	stmtRepo := database.NewStatementRepository(db)
-	pullRepo := database.NewPullRepository(db)
-	auditRepo := database.NewAuditRepository(db)
+	queryTimeouts := database.QueryTimeouts(cfg.Database.QueryTimeouts)
+	connRepo := database.NewConnectionRepository(db, queryTimeouts)
+	systemRepo := database.NewSystemRepository(db, queryTimeouts)
+	controlRepo := database.NewControlRepository(db, queryTimeouts)
+	stmtRepo := database.NewStatementRepository(db, queryTimeouts)
+	pullRepo := database.NewPullRepository(db, queryTimeouts)
+	auditRepo := database.NewAuditRepository(db, queryTimeouts)
+	auditBuffer := database.NewAsyncAuditBuffer(auditRepo, logger)
 
 	// Initialize services
-	connService := connection.NewService(connRepo, cryptoService)
+	connService := connection.NewService(connRepo, cryptoService, connection.Options{
+		TableMapping: tableMapping,
+		Production:   cfg.IsProduction(),
+		ProxyURL:     cfg.ServiceNow.ProxyURL,
+
+		NTLMProxyURL:      cfg.ServiceNow.NTLMProxyURL,
+		NTLMProxyUsername: cfg.ServiceNow.NTLMProxyUsername,
+		NTLMProxyPassword: cfg.ServiceNow.NTLMProxyPassword,
+		NTLMProxyDomain:   cfg.ServiceNow.NTLMProxyDomain,
+
+		ResponseCacheTTL: cfg.ServiceNow.CacheTTL,
+		UseGzip:          cfg.ServiceNow.UseGzip,
+		KeepHTMLOnPull:   !cfg.ServiceNow.StripHTMLOnPull,
+
+		OAuthTokenCacheDir: cfg.ServiceNow.OAuthTokenCacheDir,
+	})
 	controlsService := controls.NewService(connService)
-	systemService := system.NewService(systemRepo, connService, logger)
-	stmtService := statement.NewService(stmtRepo, logger)
-	pullService := pull.NewService(pullRepo, systemRepo, controlRepo, stmtRepo, connService, logger)
-	pushService := push.NewService(stmtRepo, connService, logger)
-	auditService := audit.NewService(auditRepo, logger)
+	var auditSigningKey *ecdsa.PrivateKey
+	if cfg.Audit.SigningKeyPath != "" {
+		auditSigningKey, err = audit.LoadSigningKey(cfg.Audit.SigningKeyPath)
+		if err != nil {
+			log.Fatalf("Failed to load audit signing key: %v", err)
+		}
+		log.Printf("Signing audit exports with key from %s", cfg.Audit.SigningKeyPath)
+	}
+	auditService := audit.NewService(auditBuffer, audit.Options{
+		Retention: audit.RetentionPolicy{
+			MaxAgeDays: cfg.Audit.RetentionDays,
+			MaxRows:    cfg.Audit.RetentionMaxRows,
+		},
+		SigningKey: auditSigningKey,
+	}, logger)
+	systemService := system.NewService(systemRepo, controlRepo, connService, auditService, system.Options{
+		DiscoveryCacheTTL: cfg.Sync.DiscoveryCacheTTL,
+	}, logger)
+	// A newly saved default connection likely sees different systems
+	connService.OnConfigSaved(func(ctx context.Context, label string) {
+		if label != connection.DefaultLabel {
+			return
+		}
+		if err := systemService.RefreshDiscoveryCache(ctx); err != nil {
+			logger.Warn("failed to refresh discovery cache after connection save", "error", err)
+		}
+	})
+	var conflictWebhook statement.WebhookSender
+	if cfg.Webhook.URL != "" {
+		conflictWebhook = webhook.NewClient(cfg.Webhook.Secret)
+	}
+	stmtService := statement.NewService(stmtRepo, statement.Options{
+		ReviewRequired: cfg.Review.Required,
+		ContentLimits: statement.ContentLimits{
+			MinWords: cfg.Statements.MinWords,
+			MaxChars: cfg.Statements.MaxChars,
+		},
+		Sanitization: statement.SanitizationMode(cfg.Statements.ContentSanitizationMode),
+		UnwrapOnSave: cfg.Statements.UnwrapOnSave,
+		Audit:        auditService,
+
+		SNClients:       connService,
+		AttachmentTable: tableMapping.StatementsTable,
+
+		ConflictWebhook: conflictWebhook,
+		WebhookURL:      cfg.Webhook.URL,
+	}, logger)
+	pullService := pull.NewService(pullRepo, systemRepo, controlRepo, stmtRepo, connService, pull.Options{
+		ConflictStrategy:  statement.ConflictStrategy(cfg.Sync.ConflictStrategy),
+		MaxConcurrentJobs: cfg.Sync.MaxConcurrentPulls,
+		Parallelism:       cfg.Sync.PullParallelism,
+		ValidateRoles:     cfg.Sync.ValidateRoles,
+		ConflictWebhook:   conflictWebhook,
+		WebhookURL:        cfg.Webhook.URL,
+		Retry: pull.RetryPolicy{
+			MaxRetries: cfg.Sync.MaxRetries,
+			RetryDelay: cfg.Sync.RetryDelay,
+		},
+	}, logger)
+	var alertSender email.Sender
+	if cfg.SMTP.Host != "" {
+		alertSender = email.NewSMTPSender(cfg.SMTP.Host, cfg.SMTP.Port, cfg.SMTP.Username, cfg.SMTP.Password)
+	}
+	pushService := push.NewService(stmtRepo, controlRepo, connService, push.Options{
+		ReviewRequired:    cfg.Review.Required,
+		NonPushableTypes:  cfg.Push.NonPushableTypes,
+		BulkPushThreshold: cfg.Push.BulkPushThreshold,
+		AlertSender:       alertSender,
+		AlertEmail:        cfg.SMTP.AlertEmail,
+		Retry: push.PushRetryPolicy{
+			MaxRetries:   cfg.Push.MaxRetries,
+			InitialDelay: cfg.Push.RetryDelay,
+			MaxDelay:     cfg.Push.RetryMaxDelay,
+		},
+	}, logger)
+	controlService := control.NewService(controlRepo, auditService, logger)
 
 	// Initialize handlers
-	connectionHandler := connHandler.NewHandler(connService)
-	controlsHandler := ctrlHandler.NewHandler(controlsService)
-	statementsHandler := stmtHandler.NewHandler(stmtService, logger)
-	syncAPIHandler := syncHandler.NewHandler(systemService, pullService, logger)
+	requireAdmin := auth.RequireRole(cfg.Auth.JWTSecret, auth.RoleAdmin)
+	connectionHandler := connHandler.NewHandler(connService, requireAdmin)
+	controlsHandler := ctrlHandler.NewHandler(controlsService, controlService, cfg.Pagination)
+	requireReviewer := auth.RequireRole(cfg.Auth.JWTSecret, auth.RoleReviewer)
+	statementsHandler := stmtHandler.NewHandler(stmtService, cfg.Pagination, requireReviewer, logger)
+	syncAPIHandler := syncHandler.NewHandler(systemService, pullService, controlService, stmtService, cfg.Pagination, logger)
 	pushAPIHandler := pushHandler.NewHandler(pushService, logger)
-	auditAPIHandler := auditHandler.NewHandler(auditService, logger)
+	auditAPIHandler := auditHandler.NewHandler(auditService, cfg.Pagination, requireAdmin, logger)
+	healthAPIHandler := healthHandler.NewHandler(db, connService, pullService, pushService, logger)
+	keyRotationService := crypto.NewKeyRotationService(connRepo, cryptoService)
+	adminAPIHandler := adminHandler.NewHandler(db, keyRotationService, systemRepo, connService, requireAdmin, logger)
 
 	// Create HTTP server mux
 	mux := http.NewServeMux()
 
 	// Health check endpoint
-	mux.HandleFunc("GET /health", healthHandler(db))
+	healthAPIHandler.RegisterRoutes(mux)
 
 	// Register connection routes
 	connectionHandler.RegisterRoutes(mux)
@@ -118,12 +255,25 @@ func main() {
 	// Register audit routes
 	auditAPIHandler.RegisterRoutes(mux)
 
+	// Register admin routes
+	adminAPIHandler.RegisterRoutes(mux)
+
+	// Wrap with middleware (outermost last)
+	var handler http.Handler = mux
+	handler = timeout.Middleware(cfg.Timeouts)(handler)
+	handler = bodylimit.MaxBodySize(cfg.Server.MaxRequestBodyBytes, cfg.Server.MaxUploadBodyBytes)(handler)
+	handler = auditMiddleware.AuditMiddleware(auditService)(handler)
+	handler = auth.Middleware(cfg.Auth.JWTSecret)(handler)
+	handler = ratelimit.Middleware(cfg.Server.RateLimitRPS, cfg.Server.RateLimitBurst)(handler)
+	handler = cors.Middleware(cfg.CORS)(handler)
+	handler = requestid.Middleware(handler)
+
 	// Create HTTP server
 	server := &http.Server{
 		Addr:         fmt.Sprintf(":%d", cfg.Server.Port),
-		Handler:      corsMiddleware(mux),
+		Handler:      handler,
 		ReadTimeout:  cfg.Server.ReadTimeout,
-		WriteTimeout: cfg.Server.WriteTimeout,
+		WriteTimeout: cfg.Timeouts.MaxWriteTimeout() + time.Second, // Leave room for the timeout middleware to respond
 		IdleTimeout:  cfg.Server.IdleTimeout,
 	}
 
@@ -135,65 +285,84 @@ func main() {
 		}
 	}()
 
+	// Serve the internal gRPC API for other services
+	var grpcServer *grpc.Server
+	if cfg.Server.GRPCPort != 0 {
+		grpcAddr := net.JoinHostPort(cfg.Server.GRPCHost, strconv.Itoa(cfg.Server.GRPCPort))
+		listener, err := net.Listen("tcp", grpcAddr)
+		if err != nil {
+			log.Fatalf("Failed to listen for gRPC: %v", err)
+		}
+		grpcServer = grpcserver.NewServer(systemService, stmtService, pullService, auditService, cfg.Auth.JWTSecret, logger)
+		go func() {
+			log.Printf("Starting gRPC server on %s", grpcAddr)
+			if err := grpcServer.Serve(listener); err != nil {
+				log.Fatalf("gRPC server failed: %v", err)
+			}
+		}()
+	}
+
+	// Write audit events in batches
+	bufferCtx, stopBuffer := context.WithCancel(context.Background())
+	defer stopBuffer()
+	go auditBuffer.Run(bufferCtx)
+
+	// Purge audit events outside the retention policy every night
+	retentionCtx, stopRetention := context.WithCancel(context.Background())
+	defer stopRetention()
+	go auditService.RunRetention(retentionCtx)
+
+	// Start queued pull jobs as concurrency slots free up
+	dispatchCtx, stopDispatch := context.WithCancel(context.Background())
+	defer stopDispatch()
+	go pullService.RunDispatcher(dispatchCtx)
+
 	// Wait for interrupt signal
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 	log.Println("Shutting down server...")
+	stopRetention()
+	stopDispatch()
+
+	// Stop running pull jobs before the database connection closes; they
+	// are left paused and can be resumed after a restart
+	if err := pullService.Shutdown(); err != nil {
+		log.Printf("Pull jobs did not stop cleanly: %v", err)
+	}
 
 	// Graceful shutdown with timeout
 	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer shutdownCancel()
 
-	if err := server.Shutdown(shutdownCtx); err != nil {
-		log.Fatalf("Server forced to shutdown: %v", err)
+	// Open audit streams are never closed by Shutdown, so it can time out;
+	// queued audit events are flushed either way before exiting
+	shutdownErr := server.Shutdown(shutdownCtx)
+	if shutdownErr != nil {
+		log.Printf("Server forced to shutdown: %v", shutdownErr)
+		server.Close()
 	}
-
-	log.Println("Server shutdown complete")
-}
-
-// healthHandler returns a health check handler that includes database status.
-func healthHandler(db *sql.DB) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		ctx, cancel := context.WithTimeout(r.Context(), 2*time.Second)
-		defer cancel()
-
-		status := "healthy"
-		dbStatus := "connected"
-
-		if err := db.PingContext(ctx); err != nil {
-			status = "degraded"
-			dbStatus = "disconnected"
-		}
-
-		w.Header().Set("Content-Type", "application/json")
-		if status != "healthy" {
-			w.WriteHeader(http.StatusServiceUnavailable)
-		} else {
-			w.WriteHeader(http.StatusOK)
+	if grpcServer != nil {
+		stopped := make(chan struct{})
+		go func() {
+			grpcServer.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-shutdownCtx.Done():
+			grpcServer.Stop()
 		}
-
-		json.NewEncoder(w).Encode(map[string]string{
-			"status":   status,
-			"database": dbStatus,
-		})
 	}
-}
 
-// corsMiddleware adds CORS headers for development.
-func corsMiddleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Allow requests from frontend dev server
-		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
-
-		// Handle preflight requests
-		if r.Method == "OPTIONS" {
-			w.WriteHeader(http.StatusNoContent)
-			return
-		}
+	// Write the audit events still queued once requests have finished
+	stopBuffer()
+	if err := auditBuffer.Flush(); err != nil {
+		log.Printf("Failed to flush audit events: %v", err)
+	}
 
-		next.ServeHTTP(w, r)
-	})
+	if shutdownErr != nil {
+		os.Exit(1)
+	}
+	log.Println("Server shutdown complete")
 }
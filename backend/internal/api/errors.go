@@ -0,0 +1,116 @@
+// Package api holds definitions shared by the HTTP handlers.
+package api
+
+import (
+	"errors"
+
+	"github.com/controlcrud/backend/internal/domain/connection"
+	"github.com/controlcrud/backend/internal/domain/control"
+	"github.com/controlcrud/backend/internal/domain/controls"
+	"github.com/controlcrud/backend/internal/domain/pull"
+	"github.com/controlcrud/backend/internal/domain/push"
+	"github.com/controlcrud/backend/internal/domain/statement"
+	"github.com/controlcrud/backend/internal/domain/system"
+	"github.com/controlcrud/backend/internal/infrastructure/crypto"
+	"github.com/controlcrud/backend/internal/infrastructure/servicenow"
+)
+
+// ErrorCode is the machine-readable "error" field of an API error response.
+// Clients branch on the code; the accompanying message is for people.
+type ErrorCode string
+
+const (
+	ErrCodeValidation           ErrorCode = "validation_error"
+	ErrCodeInvalidJSON          ErrorCode = "invalid_json"
+	ErrCodeInvalidID            ErrorCode = "invalid_id"
+	ErrCodeNotFound             ErrorCode = "not_found"
+	ErrCodeConflict             ErrorCode = "conflict"
+	ErrCodeUnauthorized         ErrorCode = "unauthorized"
+	ErrCodeForbidden            ErrorCode = "forbidden"
+	ErrCodeJobInProgress        ErrorCode = "job_in_progress"
+	ErrCodeNoConnection         ErrorCode = "no_connection"  // No ServiceNow connection configured
+	ErrCodeNotConfigured        ErrorCode = "not_configured" // An optional ServiceNow feature is not set up
+	ErrCodeSNUnreachable        ErrorCode = "servicenow_unreachable"
+	ErrCodeSNAuth               ErrorCode = "servicenow_auth_failed"
+	ErrCodeSNRateLimited        ErrorCode = "servicenow_rate_limited"
+	ErrCodeSNError              ErrorCode = "servicenow_error"
+	ErrCodePayloadTooLarge      ErrorCode = "request_entity_too_large"
+	ErrCodeUnsupportedMediaType ErrorCode = "unsupported_media_type"
+	ErrCodeRateLimited          ErrorCode = "rate_limit_exceeded"
+	ErrCodeTimeout              ErrorCode = "timeout"
+	ErrCodeKeyMismatch          ErrorCode = "encryption_key_mismatch"
+	ErrCodeInternal             ErrorCode = "internal_error"
+
+	// Reasons a statement cannot be pushed
+	ErrCodeNotModified     ErrorCode = "not_modified"
+	ErrCodeHasConflict     ErrorCode = "has_conflict"
+	ErrCodeNotApproved     ErrorCode = "not_approved"
+	ErrCodeTypeNotPushable ErrorCode = "type_not_pushable"
+	ErrCodeSystemReadOnly  ErrorCode = "system_read_only"
+)
+
+// errorCodes maps domain errors to their codes. Entries are checked in
+// order with errors.Is, so specific ServiceNow failures come before the
+// domain errors that may wrap them.
+var errorCodes = []struct {
+	errs []error
+	code ErrorCode
+}{
+	{[]error{servicenow.ErrAuthFailed, controls.ErrAuthFailed}, ErrCodeSNAuth},
+	{[]error{servicenow.ErrConnectionFailed, servicenow.ErrTimeout, servicenow.ErrCircuitOpen}, ErrCodeSNUnreachable},
+	{[]error{servicenow.ErrRateLimited}, ErrCodeSNRateLimited},
+	{[]error{
+		servicenow.ErrServerError, servicenow.ErrInvalidResponse, servicenow.ErrNotFound,
+		system.ErrServiceNowError, pull.ErrServiceNowError, push.ErrServiceNowError, controls.ErrServiceNowError,
+		connection.ErrServiceNowError,
+		statement.ErrAttachmentUploadFailed, statement.ErrAttachmentDownloadFailed, statement.ErrRePullFailed,
+		connection.ErrTestFailed,
+	}, ErrCodeSNError},
+	{[]error{
+		statement.ErrNotFound, statement.ErrControlNotFound, statement.ErrStatementTypeNotFound, statement.ErrTemplateNotFound,
+		statement.ErrTagNotFound,
+		control.ErrNotFound, control.ErrSystemNotFound, controls.ErrNotFound, system.ErrNotFound,
+		pull.ErrNotFound, push.ErrJobNotFound, push.ErrStatementNotFound, connection.ErrConnectionNotFound,
+	}, ErrCodeNotFound},
+	{[]error{
+		statement.ErrInvalidInput, statement.ErrContentTooShort, statement.ErrContentTooLong,
+		statement.ErrInvalidStatementType, statement.ErrInvalidTemplate, statement.ErrMissingTemplateVariables,
+		statement.ErrInvalidTag,
+		control.ErrInvalidInput, control.ErrInvalidStatus, system.ErrInvalidInput, pull.ErrInvalidInput,
+		push.ErrNoStatementsSelected, push.ErrStatementEmpty,
+		connection.ErrInstanceURLRequired, connection.ErrInvalidInstanceURL, connection.ErrInstanceHostNotAllowed,
+		connection.ErrAuthMethodRequired, connection.ErrInvalidAuthMethod, connection.ErrUsernameRequired,
+		connection.ErrPasswordRequired, connection.ErrClientIDRequired, connection.ErrClientSecretRequired,
+		connection.ErrTokenURLRequired, connection.ErrInvalidLabel, connection.ErrInvalidScriptedAPI, connection.ErrInvalidScope,
+		connection.ErrClientTLSIncomplete, connection.ErrInvalidClientTLS, connection.ErrUnsupportedExportVersion,
+		crypto.ErrInvalidKeyFormat, crypto.ErrInvalidKeyLength,
+	}, ErrCodeValidation},
+	{[]error{
+		statement.ErrConflict, statement.ErrStatementTypeExists, statement.ErrStatementTypeInUse,
+		statement.ErrCannotDeleteModified, statement.ErrReviewDisabled, statement.ErrNotReviewable,
+		statement.ErrNotInServiceNow, pull.ErrJobAlreadyComplete, pull.ErrJobNotPaused, pull.ErrJobNotRunning, connection.ErrConnectionExists,
+	}, ErrCodeConflict},
+	{[]error{statement.ErrSelfReview, statement.ErrReviewerRequired}, ErrCodeForbidden},
+	{[]error{connection.ErrEncryptionKeyMismatch}, ErrCodeKeyMismatch},
+	{[]error{pull.ErrConcurrentJob, push.ErrJobAlreadyRunning}, ErrCodeJobInProgress},
+	{[]error{system.ErrNoConnection, pull.ErrNoConnection, push.ErrNoConnection, controls.ErrNoConnection}, ErrCodeNoConnection},
+	{[]error{statement.ErrAttachmentsUnavailable, servicenow.ErrScriptedAPINotConfigured, servicenow.ErrImportSetNotConfigured}, ErrCodeNotConfigured},
+	{[]error{push.ErrStatementNotModified}, ErrCodeNotModified},
+	{[]error{push.ErrStatementHasConflict}, ErrCodeHasConflict},
+	{[]error{push.ErrStatementNotApproved}, ErrCodeNotApproved},
+	{[]error{push.ErrStatementTypeNotPushable}, ErrCodeTypeNotPushable},
+	{[]error{statement.ErrSystemReadOnly, push.ErrStatementReadOnly}, ErrCodeSystemReadOnly},
+}
+
+// ErrorCodeFor returns the code for err, or ErrCodeInternal if err is not a
+// known domain error.
+func ErrorCodeFor(err error) ErrorCode {
+	for _, entry := range errorCodes {
+		for _, target := range entry.errs {
+			if errors.Is(err, target) {
+				return entry.code
+			}
+		}
+	}
+	return ErrCodeInternal
+}
@@ -0,0 +1,42 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/controlcrud/backend/internal/domain/pull"
+	"github.com/controlcrud/backend/internal/domain/push"
+	"github.com/controlcrud/backend/internal/domain/statement"
+	"github.com/controlcrud/backend/internal/domain/system"
+	"github.com/controlcrud/backend/internal/infrastructure/servicenow"
+)
+
+func TestErrorCodeFor(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want ErrorCode
+	}{
+		{"not found", statement.ErrNotFound, ErrCodeNotFound},
+		{"wrapped validation", fmt.Errorf("%w: page must be positive", statement.ErrInvalidInput), ErrCodeValidation},
+		{"conflict", pull.ErrJobNotPaused, ErrCodeConflict},
+		{"job in progress", push.ErrJobAlreadyRunning, ErrCodeJobInProgress},
+		{"no connection", system.ErrNoConnection, ErrCodeNoConnection},
+		{"ServiceNow auth", servicenow.ErrAuthFailed, ErrCodeSNAuth},
+		{"ServiceNow unreachable", servicenow.ErrCircuitOpen, ErrCodeSNUnreachable},
+		{"specific ServiceNow failure wins over wrapper",
+			fmt.Errorf("%w: %w", system.ErrServiceNowError, servicenow.ErrTimeout), ErrCodeSNUnreachable},
+		{"ServiceNow error", system.ErrServiceNowError, ErrCodeSNError},
+		{"push block reason", push.ErrStatementTypeNotPushable, ErrCodeTypeNotPushable},
+		{"unknown", errors.New("connection refused"), ErrCodeInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ErrorCodeFor(tt.err); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
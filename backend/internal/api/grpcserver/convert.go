@@ -0,0 +1,132 @@
+package grpcserver
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/controlcrud/backend/internal/domain/pull"
+	"github.com/controlcrud/backend/internal/domain/statement"
+	"github.com/controlcrud/backend/internal/domain/system"
+	autogrcpb "github.com/controlcrud/backend/proto"
+)
+
+// parseID parses a UUID request field, naming the field in the error.
+func parseID(field, value string) (uuid.UUID, error) {
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return uuid.Nil, status.Errorf(codes.InvalidArgument, "invalid %s %q", field, value)
+	}
+	return id, nil
+}
+
+// parseIDs parses a list of UUID request fields.
+func parseIDs(field string, values []string) ([]uuid.UUID, error) {
+	ids := make([]uuid.UUID, 0, len(values))
+	for _, value := range values {
+		id, err := parseID(field, value)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// timestamp converts an optional time; nil stays unset.
+func timestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+func toSystem(s *system.System) *autogrcpb.System {
+	return &autogrcpb.System{
+		Id:                         s.ID.String(),
+		SnSysId:                    s.SNSysID,
+		Name:                       s.Name,
+		Description:                s.Description,
+		Acronym:                    s.Acronym,
+		Owner:                      s.Owner,
+		Status:                     s.Status,
+		ConflictResolutionStrategy: s.ConflictStrategy,
+		LastPullAt:                 timestamp(s.LastPullAt),
+		LastPushAt:                 timestamp(s.LastPushAt),
+		CreatedAt:                  timestamppb.New(s.CreatedAt),
+		UpdatedAt:                  timestamppb.New(s.UpdatedAt),
+		DeletedAt:                  timestamp(s.DeletedAt),
+	}
+}
+
+func toSystemSummary(s *system.SystemSummary) *autogrcpb.SystemSummary {
+	return &autogrcpb.SystemSummary{
+		SystemId:             s.SystemID.String(),
+		Name:                 s.Name,
+		TotalControls:        int32(s.TotalControls),
+		TotalStatements:      int32(s.TotalStatements),
+		SyncedCount:          int32(s.SyncedCount),
+		ModifiedCount:        int32(s.ModifiedCount),
+		ConflictCount:        int32(s.ConflictCount),
+		LastPullAt:           timestamp(s.LastPullAt),
+		CompliancePercentage: s.CompliancePercentage,
+	}
+}
+
+func toStatement(s *statement.Statement) *autogrcpb.Statement {
+	return &autogrcpb.Statement{
+		Id:              s.ID.String(),
+		ControlId:       s.ControlID.String(),
+		SnSysId:         s.SNSysID,
+		StatementType:   s.StatementType,
+		RemoteContent:   s.RemoteContent,
+		RemoteUpdatedAt: timestamp(s.RemoteUpdatedAt),
+		LocalContent:    s.LocalContent,
+		IsModified:      s.IsModified,
+		ModifiedAt:      timestamp(s.ModifiedAt),
+		SyncStatus:      string(s.SyncStatus),
+		ReviewStatus:    string(s.ReviewStatus),
+		LastPullAt:      timestamp(s.LastPullAt),
+		LastPushAt:      timestamp(s.LastPushAt),
+		CreatedAt:       timestamppb.New(s.CreatedAt),
+		UpdatedAt:       timestamppb.New(s.UpdatedAt),
+	}
+}
+
+func toStatements(stmts []statement.Statement) []*autogrcpb.Statement {
+	out := make([]*autogrcpb.Statement, len(stmts))
+	for i := range stmts {
+		out[i] = toStatement(&stmts[i])
+	}
+	return out
+}
+
+func toPullJob(j *pull.Job) *autogrcpb.PullJob {
+	systemIDs := make([]string, len(j.SystemIDs))
+	for i, id := range j.SystemIDs {
+		systemIDs[i] = id.String()
+	}
+	p := j.Progress
+	return &autogrcpb.PullJob{
+		Id:        j.ID.String(),
+		SystemIds: systemIDs,
+		Status:    string(j.Status),
+		Progress: &autogrcpb.PullProgress{
+			TotalSystems:        int32(p.TotalSystems),
+			CompletedSystems:    int32(p.CompletedSystems),
+			TotalControls:       int32(p.TotalControls),
+			CompletedControls:   int32(p.CompletedControls),
+			TotalStatements:     int32(p.TotalStatements),
+			CompletedStatements: int32(p.CompletedStatements),
+			CurrentSystem:       p.CurrentSystem,
+			Errors:              p.Errors(),
+		},
+		StartedAt:   timestamp(j.StartedAt),
+		CompletedAt: timestamp(j.CompletedAt),
+		Error:       j.Error,
+		CreatedAt:   timestamppb.New(j.CreatedAt),
+	}
+}
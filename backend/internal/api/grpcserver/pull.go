@@ -0,0 +1,85 @@
+package grpcserver
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/controlcrud/backend/internal/domain/pull"
+	autogrcpb "github.com/controlcrud/backend/proto"
+)
+
+// pullServer implements autogrcpb.PullServiceServer on pull.Service.
+type pullServer struct {
+	autogrcpb.UnimplementedPullServiceServer
+	pullService *pull.Service
+}
+
+// StartPull queues a pull of the given systems from the default
+// ServiceNow connection.
+func (s *pullServer) StartPull(ctx context.Context, req *autogrcpb.StartPullRequest) (*autogrcpb.PullJob, error) {
+	systemIDs, err := parseIDs("system_ids", req.GetSystemIds())
+	if err != nil {
+		return nil, err
+	}
+	job, err := s.pullService.StartPull(ctx, systemIDs, "")
+	if err != nil {
+		return nil, err
+	}
+	return toPullJob(job), nil
+}
+
+// GetJob returns a pull job and its progress.
+func (s *pullServer) GetJob(ctx context.Context, req *autogrcpb.GetByIDRequest) (*autogrcpb.PullJob, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	job, err := s.pullService.GetJob(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toPullJob(job), nil
+}
+
+// ListJobs returns pull job history, newest first.
+func (s *pullServer) ListJobs(ctx context.Context, req *autogrcpb.ListPullJobsRequest) (*autogrcpb.ListPullJobsResponse, error) {
+	filter := pull.PullListFilter{Limit: int(req.GetLimit())}
+	if req.GetStatus() != "" {
+		jobStatus := pull.JobStatus(req.GetStatus())
+		filter.Status = &jobStatus
+	}
+	if req.GetSystemId() != "" {
+		id, err := parseID("system_id", req.GetSystemId())
+		if err != nil {
+			return nil, err
+		}
+		filter.SystemID = &id
+	}
+	if req.GetSince() != nil {
+		since := req.GetSince().AsTime()
+		filter.Since = &since
+	}
+
+	jobs, err := s.pullService.ListJobs(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	resp := &autogrcpb.ListPullJobsResponse{Jobs: make([]*autogrcpb.PullJob, len(jobs))}
+	for i := range jobs {
+		resp.Jobs[i] = toPullJob(&jobs[i])
+	}
+	return resp, nil
+}
+
+// CancelJob cancels an active pull job.
+func (s *pullServer) CancelJob(ctx context.Context, req *autogrcpb.GetByIDRequest) (*emptypb.Empty, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.pullService.CancelJob(ctx, id); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,96 @@
+// Package grpcserver exposes the system, statement and pull services over
+// gRPC so that other processes can call them. It mirrors the surface the
+// HTTP handlers offer, without the browser-facing middleware. Calls are
+// authorized with the same bearer tokens as the HTTP API and mutations are
+// audited.
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/controlcrud/backend/internal/api"
+	auditdomain "github.com/controlcrud/backend/internal/domain/audit"
+	"github.com/controlcrud/backend/internal/domain/pull"
+	"github.com/controlcrud/backend/internal/domain/statement"
+	"github.com/controlcrud/backend/internal/domain/system"
+	autogrcpb "github.com/controlcrud/backend/proto"
+)
+
+// NewServer creates a gRPC server with the system, statement and pull
+// services registered. Calls must carry a bearer token signed with
+// jwtSecret; an empty secret rejects every call.
+func NewServer(systemService *system.Service, stmtService *statement.Service, pullService *pull.Service, auditService *auditdomain.Service, jwtSecret string, logger *slog.Logger) *grpc.Server {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	server := grpc.NewServer(grpc.ChainUnaryInterceptor(
+		errorInterceptor(logger),
+		authInterceptor(jwtSecret),
+		auditInterceptor(auditService),
+	))
+	autogrcpb.RegisterSystemServiceServer(server, &systemServer{systemService: systemService})
+	autogrcpb.RegisterStatementServiceServer(server, &statementServer{stmtService: stmtService})
+	autogrcpb.RegisterPullServiceServer(server, &pullServer{pullService: pullService})
+	return server
+}
+
+// errorInterceptor turns domain errors returned by the services into gRPC
+// statuses and logs the ones that are not the caller's fault.
+func errorInterceptor(logger *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		resp, err := handler(ctx, req)
+		if err == nil {
+			return resp, nil
+		}
+		st := statusFor(err)
+		if st.Code() == codes.Internal || st.Code() == codes.Unavailable {
+			logger.Error("gRPC call failed", "method", info.FullMethod, "error", err)
+		}
+		return nil, st.Err()
+	}
+}
+
+// grpcCodes maps API error codes to gRPC status codes.
+var grpcCodes = map[api.ErrorCode]codes.Code{
+	api.ErrCodeValidation:      codes.InvalidArgument,
+	api.ErrCodeInvalidID:       codes.InvalidArgument,
+	api.ErrCodeNotFound:        codes.NotFound,
+	api.ErrCodeConflict:        codes.FailedPrecondition,
+	api.ErrCodeForbidden:       codes.PermissionDenied,
+	api.ErrCodeJobInProgress:   codes.ResourceExhausted,
+	api.ErrCodeNoConnection:    codes.FailedPrecondition,
+	api.ErrCodeNotConfigured:   codes.FailedPrecondition,
+	api.ErrCodeSNUnreachable:   codes.Unavailable,
+	api.ErrCodeSNAuth:          codes.Unavailable,
+	api.ErrCodeSNRateLimited:   codes.Unavailable,
+	api.ErrCodeSNError:         codes.Unavailable,
+	api.ErrCodeKeyMismatch:     codes.FailedPrecondition,
+	api.ErrCodeNotModified:     codes.FailedPrecondition,
+	api.ErrCodeHasConflict:     codes.FailedPrecondition,
+	api.ErrCodeNotApproved:     codes.FailedPrecondition,
+	api.ErrCodeTypeNotPushable: codes.FailedPrecondition,
+	api.ErrCodeSystemReadOnly:  codes.FailedPrecondition,
+}
+
+// statusFor returns the gRPC status for err. Errors that are not domain
+// errors are reported as internal without their message.
+func statusFor(err error) *status.Status {
+	if st, ok := status.FromError(err); ok {
+		return st
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return status.FromContextError(err)
+	}
+	errCode := api.ErrorCodeFor(err)
+	code, ok := grpcCodes[errCode]
+	if !ok {
+		return status.New(codes.Internal, "internal error")
+	}
+	return status.New(code, err.Error())
+}
@@ -0,0 +1,501 @@
+package grpcserver
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/controlcrud/backend/internal/api/middleware/auth"
+	"github.com/controlcrud/backend/internal/api/middleware/auth/authtest"
+	auditdomain "github.com/controlcrud/backend/internal/domain/audit"
+	"github.com/controlcrud/backend/internal/domain/control"
+	"github.com/controlcrud/backend/internal/domain/pull"
+	"github.com/controlcrud/backend/internal/domain/statement"
+	"github.com/controlcrud/backend/internal/domain/system"
+	"github.com/controlcrud/backend/internal/infrastructure/servicenow"
+	autogrcpb "github.com/controlcrud/backend/proto"
+)
+
+// memoryStore keeps the systems, controls, statements and pull jobs of a
+// test in memory. Pulls run in their own goroutines, so access is locked.
+type memoryStore struct {
+	mu         sync.Mutex
+	systems    map[uuid.UUID]*system.System
+	controls   map[uuid.UUID]*control.Control
+	statements map[uuid.UUID]*statement.Statement
+	jobs       map[uuid.UUID]*pull.Job
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{
+		systems:    make(map[uuid.UUID]*system.System),
+		controls:   make(map[uuid.UUID]*control.Control),
+		statements: make(map[uuid.UUID]*statement.Statement),
+		jobs:       make(map[uuid.UUID]*pull.Job),
+	}
+}
+
+// memorySystemRepository implements the system.Repository methods used by
+// imports and pulls.
+type memorySystemRepository struct {
+	system.Repository
+	store *memoryStore
+}
+
+func (r memorySystemRepository) UpsertBatch(ctx context.Context, inputs []system.UpsertInput) ([]system.System, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	var out []system.System
+	for _, input := range inputs {
+		sys := &system.System{ID: uuid.New(), SNSysID: input.SNSysID, Name: input.Name, Status: input.Status, CreatedAt: time.Now()}
+		r.store.systems[sys.ID] = sys
+		out = append(out, *sys)
+	}
+	return out, nil
+}
+
+func (r memorySystemRepository) GetByID(ctx context.Context, id uuid.UUID) (*system.System, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	sys, ok := r.store.systems[id]
+	if !ok {
+		return nil, nil
+	}
+	found := *sys
+	return &found, nil
+}
+
+func (r memorySystemRepository) UpdateLastPullAt(ctx context.Context, id uuid.UUID, connectionLabel string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	now := time.Now()
+	r.store.systems[id].LastPullAt = &now
+	return nil
+}
+
+// memoryControlRepository implements the control.Repository methods used by pulls.
+type memoryControlRepository struct {
+	control.Repository
+	store *memoryStore
+}
+
+func (r memoryControlRepository) Upsert(ctx context.Context, input control.UpsertInput) (*control.Control, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	ctrl := &control.Control{ID: uuid.New(), SystemID: input.SystemID, SNSysID: input.SNSysID, ControlID: input.ControlID}
+	r.store.controls[ctrl.ID] = ctrl
+	return ctrl, nil
+}
+
+// memoryStatementRepository implements the statement.Repository methods
+// used by pulls and statement reads.
+type memoryStatementRepository struct {
+	statement.Repository
+	store *memoryStore
+}
+
+func (r memoryStatementRepository) Upsert(ctx context.Context, input statement.UpsertInput) (*statement.Statement, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	stmt := &statement.Statement{
+		ID:            uuid.New(),
+		ControlID:     input.ControlID,
+		SNSysID:       input.SNSysID,
+		StatementType: input.StatementType,
+		RemoteContent: input.RemoteContent,
+		SyncStatus:    statement.SyncStatusSynced,
+		CreatedAt:     time.Now(),
+	}
+	r.store.statements[stmt.ID] = stmt
+	stored := *stmt
+	return &stored, nil
+}
+
+func (r memoryStatementRepository) GetByID(ctx context.Context, id uuid.UUID) (*statement.Statement, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	stmt, ok := r.store.statements[id]
+	if !ok {
+		return nil, nil
+	}
+	found := *stmt
+	return &found, nil
+}
+
+func (r memoryStatementRepository) List(ctx context.Context, params statement.ListParams) (*statement.ListResult, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	result := &statement.ListResult{Statements: []statement.Statement{}, Page: params.Page, PageSize: params.PageSize, TotalPages: 1}
+	for _, stmt := range r.store.statements {
+		if r.store.controls[stmt.ControlID].SystemID == params.SystemID {
+			result.Statements = append(result.Statements, *stmt)
+		}
+	}
+	result.TotalCount = len(result.Statements)
+	return result, nil
+}
+
+func (r memoryStatementRepository) ListReadOnly(ctx context.Context, ids []uuid.UUID) ([]uuid.UUID, error) {
+	return nil, nil
+}
+
+func (r memoryStatementRepository) UpdateLocal(ctx context.Context, input statement.UpdateInput) (*statement.Statement, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	stmt := r.store.statements[input.ID]
+	stmt.LocalContent = input.LocalContent
+	stmt.IsModified = true
+	stmt.ModifiedBy = input.ModifiedBy
+	stmt.ModifiedByEmail = input.ModifiedByEmail
+	stmt.SyncStatus = statement.SyncStatusModified
+	updated := *stmt
+	return &updated, nil
+}
+
+// memoryPullRepository implements the pull.Repository methods used to
+// queue and run jobs.
+type memoryPullRepository struct {
+	pull.Repository
+	store *memoryStore
+}
+
+func (r memoryPullRepository) Create(ctx context.Context, input pull.CreateInput) (*pull.Job, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	job := &pull.Job{ID: uuid.New(), SystemIDs: input.SystemIDs, Status: pull.JobStatusPending, CreatedAt: time.Now()}
+	r.store.jobs[job.ID] = job
+	created := *job
+	return &created, nil
+}
+
+func (r memoryPullRepository) CountActiveJobs(ctx context.Context) (int, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	n := 0
+	for _, job := range r.store.jobs {
+		if job.Status.IsActive() {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (r memoryPullRepository) ClaimPendingJobs(ctx context.Context, limit int) ([]pull.Job, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	var claimed []pull.Job
+	for _, job := range r.store.jobs {
+		if job.Status == pull.JobStatusPending && len(claimed) < limit {
+			job.Status = pull.JobStatusRunning
+			claimed = append(claimed, *job)
+		}
+	}
+	return claimed, nil
+}
+
+func (r memoryPullRepository) GetByID(ctx context.Context, id uuid.UUID) (*pull.Job, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	job, ok := r.store.jobs[id]
+	if !ok {
+		return nil, nil
+	}
+	found := *job
+	return &found, nil
+}
+
+func (r memoryPullRepository) SetStatus(ctx context.Context, id uuid.UUID, status pull.JobStatus, errorMsg string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	r.store.jobs[id].Status = status
+	r.store.jobs[id].Error = errorMsg
+	return nil
+}
+
+func (r memoryPullRepository) UpdateProgress(ctx context.Context, id uuid.UUID, progress pull.Progress) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	r.store.jobs[id].Progress = progress
+	return nil
+}
+
+func (r memoryPullRepository) AcquireSystemLock(ctx context.Context, systemID, jobID uuid.UUID) (bool, error) {
+	return true, nil
+}
+
+func (r memoryPullRepository) ReleaseSystemLock(ctx context.Context, systemID, jobID uuid.UUID) error {
+	return nil
+}
+
+// fakeSNClient serves one system with one control and one statement.
+type fakeSNClient struct {
+	servicenow.Client
+}
+
+func (c fakeSNClient) FetchSystems(ctx context.Context, config *servicenow.PaginationConfig, onProgress servicenow.ProgressCallback) (*servicenow.PaginatedResult[servicenow.SystemRecord], error) {
+	records := []servicenow.SystemRecord{{SysID: "sn-sys-1", Name: "Payroll"}}
+	return &servicenow.PaginatedResult[servicenow.SystemRecord]{Records: records, TotalCount: len(records)}, nil
+}
+
+func (c fakeSNClient) FetchControls(ctx context.Context, systemSysID string, config *servicenow.PaginationConfig, onProgress servicenow.ProgressCallback) (*servicenow.PaginatedResult[servicenow.ControlRecord], error) {
+	records := []servicenow.ControlRecord{{SysID: "sn-ctrl-1", ControlID: "AC-1", Name: "Access Control Policy"}}
+	return &servicenow.PaginatedResult[servicenow.ControlRecord]{Records: records, TotalCount: len(records)}, nil
+}
+
+func (c fakeSNClient) FetchStatements(ctx context.Context, controlSysID string, config *servicenow.PaginationConfig, onProgress servicenow.ProgressCallback) (*servicenow.PaginatedResult[servicenow.StatementRecord], error) {
+	records := []servicenow.StatementRecord{{SysID: "sn-stmt-1", Content: "Access is reviewed quarterly.", StatementType: "implementation"}}
+	return &servicenow.PaginatedResult[servicenow.StatementRecord]{Records: records, TotalCount: len(records)}, nil
+}
+
+func (c fakeSNClient) GetInstanceTimezone(ctx context.Context) (*time.Location, error) {
+	return time.UTC, nil
+}
+
+type fakeClientProvider struct{}
+
+func (fakeClientProvider) GetSNClient(ctx context.Context) (servicenow.Client, error) {
+	return fakeSNClient{}, nil
+}
+
+func (fakeClientProvider) GetSNClientByLabel(ctx context.Context, label string) (servicenow.Client, error) {
+	return fakeSNClient{}, nil
+}
+
+// testSecret signs the bearer tokens of test calls.
+const testSecret = "grpc-test-secret"
+
+// auditRecorder captures the audit events recorded by the server.
+type auditRecorder struct {
+	auditdomain.Repository
+	events chan auditdomain.Event
+}
+
+func (r auditRecorder) Insert(ctx context.Context, event *auditdomain.Event) error {
+	r.events <- *event
+	return nil
+}
+
+// testServer is a gRPC API served over an in-memory listener.
+type testServer struct {
+	conn   *grpc.ClientConn
+	store  *memoryStore
+	events chan auditdomain.Event
+}
+
+// withToken returns a context whose calls carry a bearer token for claims.
+func withToken(ctx context.Context, claims auth.Claims) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, "authorization", authtest.Bearer(testSecret, claims))
+}
+
+// testContext returns a context whose calls carry a valid bearer token.
+func testContext() context.Context {
+	return withToken(context.Background(), auth.Claims{Subject: "tester", Email: "tester@example.com"})
+}
+
+// dialTestServer serves the gRPC API over an in-memory listener and
+// returns a connection to it.
+func dialTestServer(t *testing.T) *testServer {
+	t.Helper()
+	store := newMemoryStore()
+	systemRepo := memorySystemRepository{store: store}
+	controlRepo := memoryControlRepository{store: store}
+	stmtRepo := memoryStatementRepository{store: store}
+
+	systemService := system.NewService(systemRepo, controlRepo, fakeClientProvider{}, nil, system.Options{}, nil)
+	stmtService := statement.NewService(stmtRepo, statement.Options{}, nil)
+	pullService := pull.NewService(memoryPullRepository{store: store}, systemRepo, controlRepo, stmtRepo, fakeClientProvider{}, pull.Options{}, nil)
+
+	dispatchCtx, stopDispatch := context.WithCancel(context.Background())
+	go pullService.RunDispatcher(dispatchCtx)
+
+	recorder := auditRecorder{events: make(chan auditdomain.Event, 10)}
+	auditService := auditdomain.NewService(recorder, auditdomain.Options{}, nil)
+
+	listener := bufconn.Listen(1 << 20)
+	server := NewServer(systemService, stmtService, pullService, auditService, testSecret, nil)
+	go server.Serve(listener)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("failed to dial test server: %v", err)
+	}
+	t.Cleanup(func() {
+		conn.Close()
+		server.Stop()
+		stopDispatch()
+		pullService.Shutdown()
+	})
+	return &testServer{conn: conn, store: store, events: recorder.events}
+}
+
+// waitForEvent returns the next recorded audit event.
+func (s *testServer) waitForEvent(t *testing.T) auditdomain.Event {
+	t.Helper()
+	select {
+	case event := <-s.events:
+		return event
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for audit event")
+		return auditdomain.Event{}
+	}
+}
+
+func TestServer_ImportPullAndReadStatement(t *testing.T) {
+	conn := dialTestServer(t).conn
+	ctx := testContext()
+	systems := autogrcpb.NewSystemServiceClient(conn)
+	pulls := autogrcpb.NewPullServiceClient(conn)
+	statements := autogrcpb.NewStatementServiceClient(conn)
+
+	imported, err := systems.ImportSystems(ctx, &autogrcpb.ImportSystemsRequest{SnSysIds: []string{"sn-sys-1"}})
+	if err != nil {
+		t.Fatalf("ImportSystems failed: %v", err)
+	}
+	if len(imported.GetSystems()) != 1 {
+		t.Fatalf("expected 1 imported system, got %d", len(imported.GetSystems()))
+	}
+	systemID := imported.GetSystems()[0].GetId()
+
+	job, err := pulls.StartPull(ctx, &autogrcpb.StartPullRequest{SystemIds: []string{systemID}})
+	if err != nil {
+		t.Fatalf("StartPull failed: %v", err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for job.GetStatus() != string(pull.JobStatusCompleted) {
+		if time.Now().After(deadline) {
+			t.Fatalf("pull job did not complete, status %q", job.GetStatus())
+		}
+		time.Sleep(10 * time.Millisecond)
+		if job, err = pulls.GetJob(ctx, &autogrcpb.GetByIDRequest{Id: job.GetId()}); err != nil {
+			t.Fatalf("GetJob failed: %v", err)
+		}
+	}
+	if got := job.GetProgress().GetCompletedStatements(); got != 1 {
+		t.Errorf("expected 1 completed statement, got %d", got)
+	}
+
+	list, err := statements.ListStatements(ctx, &autogrcpb.ListStatementsRequest{SystemId: systemID})
+	if err != nil {
+		t.Fatalf("ListStatements failed: %v", err)
+	}
+	if len(list.GetStatements()) != 1 {
+		t.Fatalf("expected 1 statement, got %d", len(list.GetStatements()))
+	}
+
+	stmt, err := statements.GetStatement(ctx, &autogrcpb.GetByIDRequest{Id: list.GetStatements()[0].GetId()})
+	if err != nil {
+		t.Fatalf("GetStatement failed: %v", err)
+	}
+	if stmt.GetRemoteContent() != "Access is reviewed quarterly." {
+		t.Errorf("unexpected remote content %q", stmt.GetRemoteContent())
+	}
+	if stmt.GetSyncStatus() != string(statement.SyncStatusSynced) {
+		t.Errorf("expected sync status synced, got %q", stmt.GetSyncStatus())
+	}
+}
+
+func TestServer_ErrorCodes(t *testing.T) {
+	conn := dialTestServer(t).conn
+	ctx := testContext()
+	statements := autogrcpb.NewStatementServiceClient(conn)
+
+	tests := []struct {
+		name string
+		req  *autogrcpb.GetByIDRequest
+		want codes.Code
+	}{
+		{"invalid id", &autogrcpb.GetByIDRequest{Id: "not-a-uuid"}, codes.InvalidArgument},
+		{"not found", &autogrcpb.GetByIDRequest{Id: uuid.NewString()}, codes.NotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := statements.GetStatement(ctx, tt.req)
+			if got := status.Code(err); got != tt.want {
+				t.Errorf("expected code %s, got %s (%v)", tt.want, got, err)
+			}
+		})
+	}
+}
+
+func TestServer_RequiresBearerToken(t *testing.T) {
+	conn := dialTestServer(t).conn
+	statements := autogrcpb.NewStatementServiceClient(conn)
+	req := &autogrcpb.GetByIDRequest{Id: uuid.NewString()}
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+	}{
+		{"missing token", context.Background()},
+		{"wrong secret", metadata.AppendToOutgoingContext(context.Background(), "authorization", authtest.Bearer("other-secret", auth.Claims{Subject: "tester"}))},
+		{"expired token", withToken(context.Background(), auth.Claims{Subject: "tester", ExpiresAt: time.Now().Add(-time.Minute).Unix()})},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := statements.GetStatement(tt.ctx, req)
+			if got := status.Code(err); got != codes.Unauthenticated {
+				t.Errorf("expected code %s, got %s (%v)", codes.Unauthenticated, got, err)
+			}
+		})
+	}
+}
+
+func TestServer_UpdateLocalRecordsCaller(t *testing.T) {
+	server := dialTestServer(t)
+	statements := autogrcpb.NewStatementServiceClient(server.conn)
+
+	stmtID := uuid.New()
+	server.store.statements[stmtID] = &statement.Statement{
+		ID:            stmtID,
+		RemoteContent: "Access is reviewed quarterly.",
+		SyncStatus:    statement.SyncStatusSynced,
+	}
+	editor := uuid.New()
+	ctx := withToken(context.Background(), auth.Claims{Subject: editor.String(), Email: "editor@example.com"})
+
+	_, err := statements.UpdateLocal(ctx, &autogrcpb.UpdateLocalRequest{
+		Id:           stmtID.String(),
+		LocalContent: "Access is reviewed monthly.",
+	})
+	if err != nil {
+		t.Fatalf("UpdateLocal failed: %v", err)
+	}
+
+	stored := server.store.statements[stmtID]
+	if stored.ModifiedBy == nil || *stored.ModifiedBy != editor {
+		t.Errorf("expected modified_by %s from the token, got %v", editor, stored.ModifiedBy)
+	}
+	if stored.ModifiedByEmail != "editor@example.com" {
+		t.Errorf("expected modified_by_email from the token, got %q", stored.ModifiedByEmail)
+	}
+
+	event := server.waitForEvent(t)
+	if event.EntityType != "statement" || event.Action != "update" {
+		t.Errorf("expected statement/update, got %s/%s", event.EntityType, event.Action)
+	}
+	if event.EntityID != stmtID.String() {
+		t.Errorf("expected entity ID %s, got %s", stmtID, event.EntityID)
+	}
+	if event.Status != "success" {
+		t.Errorf("expected status success, got %s", event.Status)
+	}
+	if event.UserEmail == nil || *event.UserEmail != "editor@example.com" {
+		t.Errorf("expected user email to be captured, got %v", event.UserEmail)
+	}
+}
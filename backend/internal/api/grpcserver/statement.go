@@ -0,0 +1,157 @@
+package grpcserver
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/controlcrud/backend/internal/api/middleware/auth"
+	"github.com/controlcrud/backend/internal/domain/statement"
+	autogrcpb "github.com/controlcrud/backend/proto"
+)
+
+// maxStatementList is the most statements ListModified and ListConflicts
+// return; the statement service caps pages at this size.
+const maxStatementList = 200
+
+// statementServer implements autogrcpb.StatementServiceServer on
+// statement.Service.
+type statementServer struct {
+	autogrcpb.UnimplementedStatementServiceServer
+	stmtService *statement.Service
+}
+
+// GetStatement returns a statement by ID.
+func (s *statementServer) GetStatement(ctx context.Context, req *autogrcpb.GetByIDRequest) (*autogrcpb.Statement, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	stmt, err := s.stmtService.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toStatement(stmt), nil
+}
+
+// ListStatements returns a page of the statements of a control or a system.
+func (s *statementServer) ListStatements(ctx context.Context, req *autogrcpb.ListStatementsRequest) (*autogrcpb.ListStatementsResponse, error) {
+	params := statement.ListParams{
+		Page:       int(req.GetPage()),
+		PageSize:   int(req.GetPageSize()),
+		SyncStatus: statement.SyncStatus(req.GetSyncStatus()),
+		Search:     req.GetSearch(),
+	}
+	switch {
+	case req.GetControlId() != "" && req.GetSystemId() != "":
+		return nil, status.Error(codes.InvalidArgument, "only one of control_id and system_id may be set")
+	case req.GetControlId() != "":
+		id, err := parseID("control_id", req.GetControlId())
+		if err != nil {
+			return nil, err
+		}
+		params.ControlID = id
+	case req.GetSystemId() != "":
+		id, err := parseID("system_id", req.GetSystemId())
+		if err != nil {
+			return nil, err
+		}
+		params.SystemID = id
+	default:
+		return nil, status.Error(codes.InvalidArgument, "control_id or system_id is required")
+	}
+
+	result, err := s.stmtService.ListByControl(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return &autogrcpb.ListStatementsResponse{
+		Statements: toStatements(result.Statements),
+		TotalCount: int32(result.TotalCount),
+		Page:       int32(result.Page),
+		PageSize:   int32(result.PageSize),
+		TotalPages: int32(result.TotalPages),
+	}, nil
+}
+
+// ListModified returns the first statements with local modifications.
+func (s *statementServer) ListModified(ctx context.Context, _ *emptypb.Empty) (*autogrcpb.StatementList, error) {
+	result, err := s.stmtService.ListModified(ctx, statement.ModifiedListParams{PageSize: maxStatementList})
+	if err != nil {
+		return nil, err
+	}
+	return &autogrcpb.StatementList{Statements: toStatements(result.Statements)}, nil
+}
+
+// ListConflicts returns the first statements with sync conflicts.
+func (s *statementServer) ListConflicts(ctx context.Context, _ *emptypb.Empty) (*autogrcpb.StatementList, error) {
+	result, err := s.stmtService.ListConflicts(ctx, statement.ModifiedListParams{PageSize: maxStatementList})
+	if err != nil {
+		return nil, err
+	}
+	return &autogrcpb.StatementList{Statements: toStatements(result.Statements)}, nil
+}
+
+// UpdateLocal updates a statement's local content on behalf of the caller.
+func (s *statementServer) UpdateLocal(ctx context.Context, req *autogrcpb.UpdateLocalRequest) (*autogrcpb.Statement, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	input := statement.UpdateInput{
+		ID:           id,
+		LocalContent: req.GetLocalContent(),
+	}
+	if user := auth.FromContext(ctx); user != nil {
+		input.ModifiedBy = user.ID
+		input.ModifiedByEmail = user.Email
+	}
+	stmt, err := s.stmtService.UpdateLocal(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	return toStatement(stmt), nil
+}
+
+// ResolveConflict resolves a sync conflict on a statement on behalf of the
+// caller.
+func (s *statementServer) ResolveConflict(ctx context.Context, req *autogrcpb.ResolveConflictRequest) (*autogrcpb.Statement, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	resolution := statement.ConflictResolution(req.GetResolution())
+	switch resolution {
+	case statement.ConflictResolutionKeepLocal, statement.ConflictResolutionKeepRemote, statement.ConflictResolutionMerge:
+	default:
+		return nil, status.Error(codes.InvalidArgument, "resolution must be one of keep_local, keep_remote or merge")
+	}
+	input := statement.ResolveConflictInput{
+		ID:            id,
+		Resolution:    resolution,
+		MergedContent: req.GetMergedContent(),
+	}
+	if user := auth.FromContext(ctx); user != nil {
+		input.ResolvedBy = user.ID
+	}
+	stmt, err := s.stmtService.ResolveConflict(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	return toStatement(stmt), nil
+}
+
+// RevertToRemote discards a statement's local changes.
+func (s *statementServer) RevertToRemote(ctx context.Context, req *autogrcpb.GetByIDRequest) (*autogrcpb.Statement, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	stmt, err := s.stmtService.RevertToRemote(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toStatement(stmt), nil
+}
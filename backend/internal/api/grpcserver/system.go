@@ -0,0 +1,138 @@
+package grpcserver
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/controlcrud/backend/internal/domain/system"
+	autogrcpb "github.com/controlcrud/backend/proto"
+)
+
+// systemServer implements autogrcpb.SystemServiceServer on system.Service.
+type systemServer struct {
+	autogrcpb.UnimplementedSystemServiceServer
+	systemService *system.Service
+}
+
+// DiscoverSystems lists the systems in ServiceNow, marking imported ones.
+// The discovery cache may answer for a slow ServiceNow instance.
+func (s *systemServer) DiscoverSystems(ctx context.Context, _ *emptypb.Empty) (*autogrcpb.DiscoverSystemsResponse, error) {
+	discovered, err := s.systemService.DiscoverSystems(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &autogrcpb.DiscoverSystemsResponse{Systems: make([]*autogrcpb.DiscoveredSystem, len(discovered))}
+	for i, d := range discovered {
+		resp.Systems[i] = &autogrcpb.DiscoveredSystem{
+			SnSysId:     d.SNSysID,
+			Name:        d.Name,
+			Description: d.Description,
+			Owner:       d.Owner,
+			IsImported:  d.IsImported,
+		}
+	}
+	return resp, nil
+}
+
+// ImportSystems imports the selected ServiceNow systems.
+func (s *systemServer) ImportSystems(ctx context.Context, req *autogrcpb.ImportSystemsRequest) (*autogrcpb.ImportSystemsResponse, error) {
+	imported, err := s.systemService.ImportSystems(ctx, req.GetSnSysIds(), "")
+	if err != nil {
+		return nil, err
+	}
+	resp := &autogrcpb.ImportSystemsResponse{Systems: make([]*autogrcpb.System, len(imported))}
+	for i := range imported {
+		resp.Systems[i] = toSystem(&imported[i])
+	}
+	return resp, nil
+}
+
+// ListSystems returns a page of local systems.
+func (s *systemServer) ListSystems(ctx context.Context, req *autogrcpb.ListSystemsRequest) (*autogrcpb.ListSystemsResponse, error) {
+	result, err := s.systemService.ListSystems(ctx, system.ListParams{
+		Page:           int(req.GetPage()),
+		PageSize:       int(req.GetPageSize()),
+		Search:         req.GetSearch(),
+		Status:         req.GetStatus(),
+		IncludeDeleted: req.GetIncludeDeleted(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp := &autogrcpb.ListSystemsResponse{
+		Systems:    make([]*autogrcpb.System, len(result.Systems)),
+		TotalCount: int32(result.TotalCount),
+		Page:       int32(result.Page),
+		PageSize:   int32(result.PageSize),
+		TotalPages: int32(result.TotalPages),
+	}
+	for i := range result.Systems {
+		resp.Systems[i] = toSystem(&result.Systems[i].System)
+	}
+	return resp, nil
+}
+
+// GetSystem returns a system by ID.
+func (s *systemServer) GetSystem(ctx context.Context, req *autogrcpb.GetByIDRequest) (*autogrcpb.System, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	sys, err := s.systemService.GetSystem(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toSystem(sys), nil
+}
+
+// GetSummary returns the compliance summary of a system.
+func (s *systemServer) GetSummary(ctx context.Context, req *autogrcpb.GetByIDRequest) (*autogrcpb.SystemSummary, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	summary, err := s.systemService.GetSummary(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toSystemSummary(summary), nil
+}
+
+// DeleteSystem soft-deletes a system.
+func (s *systemServer) DeleteSystem(ctx context.Context, req *autogrcpb.GetByIDRequest) (*emptypb.Empty, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.systemService.DeleteSystem(ctx, id); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// RestoreSystem restores a soft-deleted system.
+func (s *systemServer) RestoreSystem(ctx context.Context, req *autogrcpb.GetByIDRequest) (*autogrcpb.System, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	sys, err := s.systemService.RestoreSystem(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toSystem(sys), nil
+}
+
+// SetConflictStrategy sets the conflict strategy used when pulling a system.
+func (s *systemServer) SetConflictStrategy(ctx context.Context, req *autogrcpb.SetConflictStrategyRequest) (*autogrcpb.System, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	sys, err := s.systemService.SetConflictStrategy(ctx, id, req.GetStrategy())
+	if err != nil {
+		return nil, err
+	}
+	return toSystem(sys), nil
+}
@@ -0,0 +1,180 @@
+// Package admin provides administrative endpoints restricted to the admin role.
+package admin
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"sort"
+
+	"github.com/controlcrud/backend/internal/api"
+	"github.com/controlcrud/backend/internal/api/middleware/requestid"
+	"github.com/controlcrud/backend/internal/domain/system"
+	"github.com/controlcrud/backend/internal/infrastructure/crypto"
+	"github.com/controlcrud/backend/internal/infrastructure/servicenow"
+)
+
+// DBStatsProvider reports database connection pool statistics.
+type DBStatsProvider interface {
+	Stats() sql.DBStats
+}
+
+// KeyRotator re-encrypts stored credentials from the key in use to a new key
+// and switches the running process over.
+type KeyRotator interface {
+	RotateConnectionCredentials(ctx context.Context, newKey string) error
+}
+
+// CountRecomputer rebuilds the denormalized control and statement counts.
+type CountRecomputer interface {
+	RecomputeCounts(ctx context.Context) (*system.RecomputeCountsResult, error)
+}
+
+// SNMetricsProvider reports ServiceNow request metrics per connection label.
+type SNMetricsProvider interface {
+	Metrics() map[string]servicenow.MetricsSnapshot
+}
+
+// Handler handles administrative requests.
+type Handler struct {
+	db           DBStatsProvider
+	keys         KeyRotator
+	counts       CountRecomputer
+	snMetrics    SNMetricsProvider
+	requireAdmin func(http.Handler) http.Handler
+	logger       *slog.Logger
+}
+
+// NewHandler creates a new admin handler. requireAdmin wraps every route and
+// must reject callers without the admin role.
+func NewHandler(db DBStatsProvider, keys KeyRotator, counts CountRecomputer, snMetrics SNMetricsProvider, requireAdmin func(http.Handler) http.Handler, logger *slog.Logger) *Handler {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &Handler{
+		db:           db,
+		keys:         keys,
+		counts:       counts,
+		snMetrics:    snMetrics,
+		requireAdmin: requireAdmin,
+		logger:       logger,
+	}
+}
+
+// RegisterRoutes registers admin routes with the given mux.
+func (h *Handler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /api/v1/admin/db-stats", h.adminOnly(h.GetDBStats))
+	mux.HandleFunc("POST /api/v1/admin/rotate-key", h.adminOnly(h.RotateKey))
+	mux.HandleFunc("POST /api/v1/admin/recompute-counts", h.adminOnly(h.RecomputeCounts))
+	mux.HandleFunc("GET /api/v1/admin/sn-metrics", h.adminOnly(h.GetSNMetrics))
+}
+
+// adminOnly wraps fn with the admin role check.
+func (h *Handler) adminOnly(fn http.HandlerFunc) http.HandlerFunc {
+	return h.requireAdmin(fn).ServeHTTP
+}
+
+// GetDBStats handles GET /api/v1/admin/db-stats
+func (h *Handler) GetDBStats(w http.ResponseWriter, r *http.Request) {
+	stats := h.db.Stats()
+
+	h.writeJSON(w, http.StatusOK, DBStatsResponse{
+		OpenConnections: stats.OpenConnections,
+		InUse:           stats.InUse,
+		Idle:            stats.Idle,
+		WaitCount:       stats.WaitCount,
+		WaitDurationMs:  stats.WaitDuration.Milliseconds(),
+	})
+}
+
+// RotateKey handles POST /api/v1/admin/rotate-key
+// It re-encrypts all stored connection credentials from the key in use to
+// the given key and switches the running process over. The configured key
+// must be updated before the next restart.
+func (h *Handler) RotateKey(w http.ResponseWriter, r *http.Request) {
+	var req RotateKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, http.StatusBadRequest, api.ErrCodeInvalidJSON, "Invalid JSON in request body")
+		return
+	}
+	if req.NewKey == "" {
+		h.writeError(w, http.StatusBadRequest, api.ErrCodeValidation, "new_key is required")
+		return
+	}
+
+	err := h.keys.RotateConnectionCredentials(r.Context(), req.NewKey)
+	if err != nil {
+		switch {
+		case errors.Is(err, crypto.ErrSameKey),
+			errors.Is(err, crypto.ErrInvalidKeyFormat),
+			errors.Is(err, crypto.ErrInvalidKeyLength):
+			h.writeError(w, http.StatusBadRequest, api.ErrCodeValidation, err.Error())
+		default:
+			requestid.Logger(r.Context(), h.logger).Error("failed to rotate encryption key", "error", err)
+			h.writeError(w, http.StatusInternalServerError, api.ErrorCodeFor(err), "Failed to re-encrypt credentials; no changes were made")
+		}
+		return
+	}
+
+	requestid.Logger(r.Context(), h.logger).Info("rotated credential encryption key")
+
+	h.writeJSON(w, http.StatusOK, RotateKeyResponse{
+		Message: "Credentials re-encrypted. Update the configured encryption key before restarting.",
+	})
+}
+
+// RecomputeCounts handles POST /api/v1/admin/recompute-counts
+// It rebuilds the denormalized counts on systems and controls from the
+// underlying rows, repairing any drift.
+func (h *Handler) RecomputeCounts(w http.ResponseWriter, r *http.Request) {
+	result, err := h.counts.RecomputeCounts(r.Context())
+	if err != nil {
+		requestid.Logger(r.Context(), h.logger).Error("failed to recompute counts", "error", err)
+		h.writeError(w, http.StatusInternalServerError, api.ErrorCodeFor(err), "Failed to recompute counts")
+		return
+	}
+
+	requestid.Logger(r.Context(), h.logger).Info("recomputed denormalized counts",
+		"systems_updated", result.SystemsUpdated, "controls_updated", result.ControlsUpdated)
+
+	h.writeJSON(w, http.StatusOK, RecomputeCountsResponse{
+		SystemsUpdated:  result.SystemsUpdated,
+		ControlsUpdated: result.ControlsUpdated,
+	})
+}
+
+// GetSNMetrics handles GET /api/v1/admin/sn-metrics
+// It reports the ServiceNow requests made for each connection since the
+// connection settings last changed.
+func (h *Handler) GetSNMetrics(w http.ResponseWriter, r *http.Request) {
+	metrics := h.snMetrics.Metrics()
+
+	response := SNMetricsResponse{Connections: make([]ConnectionMetricsResponse, 0, len(metrics))}
+	for label, m := range metrics {
+		response.Connections = append(response.Connections, ConnectionMetricsResponse{
+			Label:            label,
+			TotalRequests:    m.TotalRequests,
+			TotalErrors:      m.TotalErrors,
+			RateLimitHits:    m.RateLimitHits,
+			AverageLatencyMs: m.AverageLatencyMs,
+		})
+	}
+	sort.Slice(response.Connections, func(i, j int) bool {
+		return response.Connections[i].Label < response.Connections[j].Label
+	})
+
+	h.writeJSON(w, http.StatusOK, response)
+}
+
+func (h *Handler) writeJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
+
+func (h *Handler) writeError(w http.ResponseWriter, status int, code api.ErrorCode, message string) {
+	h.writeJSON(w, status, ErrorResponse{Error: string(code), Message: message})
+}
@@ -0,0 +1,201 @@
+package admin
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/controlcrud/backend/internal/api/middleware/auth"
+	"github.com/controlcrud/backend/internal/api/middleware/auth/authtest"
+	"github.com/controlcrud/backend/internal/domain/system"
+	"github.com/controlcrud/backend/internal/infrastructure/crypto"
+)
+
+const testSecret = "test-secret"
+
+type mockDB struct{ stats sql.DBStats }
+
+func (m *mockDB) Stats() sql.DBStats { return m.stats }
+
+func doGetDBStats(db DBStatsProvider, authorization string) *httptest.ResponseRecorder {
+	h := NewHandler(db, nil, nil, nil, auth.RequireRole(testSecret, auth.RoleAdmin), nil)
+	mux := http.NewServeMux()
+	h.RegisterRoutes(mux)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/db-stats", nil)
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	return w
+}
+
+func TestHandler_GetDBStats(t *testing.T) {
+	db := &mockDB{stats: sql.DBStats{
+		OpenConnections: 7,
+		InUse:           4,
+		Idle:            3,
+		WaitCount:       12,
+		WaitDuration:    1500 * time.Millisecond,
+	}}
+
+	w := doGetDBStats(db, authtest.Token(testSecret, auth.RoleAdmin))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var body map[string]json.Number
+	decoder := json.NewDecoder(w.Body)
+	decoder.UseNumber()
+	if err := decoder.Decode(&body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	want := map[string]string{
+		"open_connections": "7",
+		"in_use":           "4",
+		"idle":             "3",
+		"wait_count":       "12",
+		"wait_duration_ms": "1500",
+	}
+	for field, value := range want {
+		got, ok := body[field]
+		if !ok {
+			t.Errorf("missing field %s", field)
+			continue
+		}
+		if got.String() != value {
+			t.Errorf("%s: expected %s, got %s", field, value, got)
+		}
+	}
+}
+
+func TestHandler_GetDBStats_RequiresAdmin(t *testing.T) {
+	db := &mockDB{}
+
+	if w := doGetDBStats(db, ""); w.Code != http.StatusUnauthorized {
+		t.Errorf("expected status 401 without token, got %d", w.Code)
+	}
+	if w := doGetDBStats(db, authtest.Token(testSecret, "viewer")); w.Code != http.StatusForbidden {
+		t.Errorf("expected status 403 for non-admin, got %d", w.Code)
+	}
+}
+
+type mockKeyRotator struct {
+	newKey string
+	err    error
+}
+
+func (m *mockKeyRotator) RotateConnectionCredentials(ctx context.Context, newKey string) error {
+	m.newKey = newKey
+	return m.err
+}
+
+func doRotateKey(keys KeyRotator, body string) *httptest.ResponseRecorder {
+	h := NewHandler(&mockDB{}, keys, nil, nil, auth.RequireRole(testSecret, auth.RoleAdmin), nil)
+	mux := http.NewServeMux()
+	h.RegisterRoutes(mux)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/rotate-key", strings.NewReader(body))
+	req.Header.Set("Authorization", authtest.Token(testSecret, auth.RoleAdmin))
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	return w
+}
+
+func TestHandler_RotateKey(t *testing.T) {
+	keys := &mockKeyRotator{}
+
+	w := doRotateKey(keys, `{"new_key":"new-key"}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if keys.newKey != "new-key" {
+		t.Errorf("expected rotation to new-key, got %q", keys.newKey)
+	}
+}
+
+func TestHandler_RotateKey_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		err  error
+		want int
+	}{
+		{"invalid json", `{`, nil, http.StatusBadRequest},
+		{"missing key", `{}`, nil, http.StatusBadRequest},
+		{"invalid key", `{"new_key":"short"}`, crypto.ErrInvalidKeyLength, http.StatusBadRequest},
+		{"decryption failure", `{"new_key":"new-key"}`, crypto.ErrDecryptionFailed, http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if w := doRotateKey(&mockKeyRotator{err: tt.err}, tt.body); w.Code != tt.want {
+				t.Errorf("expected status %d, got %d", tt.want, w.Code)
+			}
+		})
+	}
+}
+
+type mockCountRecomputer struct {
+	result *system.RecomputeCountsResult
+	err    error
+	calls  int
+}
+
+func (m *mockCountRecomputer) RecomputeCounts(ctx context.Context) (*system.RecomputeCountsResult, error) {
+	m.calls++
+	return m.result, m.err
+}
+
+func doRecomputeCounts(counts CountRecomputer, authorization string) *httptest.ResponseRecorder {
+	h := NewHandler(&mockDB{}, nil, counts, nil, auth.RequireRole(testSecret, auth.RoleAdmin), nil)
+	mux := http.NewServeMux()
+	h.RegisterRoutes(mux)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/recompute-counts", nil)
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	return w
+}
+
+func TestHandler_RecomputeCounts(t *testing.T) {
+	counts := &mockCountRecomputer{result: &system.RecomputeCountsResult{SystemsUpdated: 2, ControlsUpdated: 5}}
+
+	w := doRecomputeCounts(counts, authtest.Token(testSecret, auth.RoleAdmin))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp RecomputeCountsResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.SystemsUpdated != 2 || resp.ControlsUpdated != 5 {
+		t.Errorf("expected 2 systems and 5 controls updated, got %+v", resp)
+	}
+}
+
+func TestHandler_RecomputeCounts_Errors(t *testing.T) {
+	counts := &mockCountRecomputer{}
+	if w := doRecomputeCounts(counts, authtest.Token(testSecret, "viewer")); w.Code != http.StatusForbidden {
+		t.Errorf("expected status 403 for non-admin, got %d", w.Code)
+	}
+	if counts.calls != 0 {
+		t.Errorf("expected no recompute for non-admin, got %d calls", counts.calls)
+	}
+
+	failing := &mockCountRecomputer{err: errors.New("connection reset")}
+	if w := doRecomputeCounts(failing, authtest.Token(testSecret, auth.RoleAdmin)); w.Code != http.StatusInternalServerError {
+		t.Errorf("expected status 500 on failure, got %d", w.Code)
+	}
+}
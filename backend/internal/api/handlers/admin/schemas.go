@@ -0,0 +1,47 @@
+package admin
+
+// DBStatsResponse reports database connection pool statistics.
+type DBStatsResponse struct {
+	OpenConnections int   `json:"open_connections"`
+	InUse           int   `json:"in_use"`
+	Idle            int   `json:"idle"`
+	WaitCount       int64 `json:"wait_count"`
+	WaitDurationMs  int64 `json:"wait_duration_ms"`
+}
+
+// RotateKeyRequest is the request to rotate the credential encryption key.
+type RotateKeyRequest struct {
+	NewKey string `json:"new_key"` // Base64-encoded 32-byte AES-256 key
+}
+
+// RotateKeyResponse is the response after a successful key rotation.
+type RotateKeyResponse struct {
+	Message string `json:"message"`
+}
+
+// RecomputeCountsResponse reports how many rows had stale counts.
+type RecomputeCountsResponse struct {
+	SystemsUpdated  int `json:"systems_updated"`
+	ControlsUpdated int `json:"controls_updated"`
+}
+
+// ConnectionMetricsResponse reports the ServiceNow requests made for one
+// connection.
+type ConnectionMetricsResponse struct {
+	Label            string  `json:"label"`
+	TotalRequests    int64   `json:"total_requests"`
+	TotalErrors      int64   `json:"total_errors"`
+	RateLimitHits    int64   `json:"rate_limit_hits"`
+	AverageLatencyMs float64 `json:"average_latency_ms"`
+}
+
+// SNMetricsResponse lists ServiceNow request metrics by connection label.
+type SNMetricsResponse struct {
+	Connections []ConnectionMetricsResponse `json:"connections"`
+}
+
+// ErrorResponse represents an error response.
+type ErrorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message,omitempty"`
+}
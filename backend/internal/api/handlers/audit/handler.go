@@ -2,27 +2,42 @@ package audit
 
 import (
 	"encoding/json"
+	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/controlcrud/backend/internal/api"
+	"github.com/controlcrud/backend/internal/api/middleware/requestid"
+	"github.com/controlcrud/backend/internal/config"
 	"github.com/controlcrud/backend/internal/domain/audit"
 )
 
+// streamHeartbeatInterval is how often an idle audit stream sends a
+// comment frame.
+const streamHeartbeatInterval = 30 * time.Second
+
 // Handler handles HTTP requests for audit operations.
 type Handler struct {
-	service *audit.Service
-	logger  *slog.Logger
+	service      *audit.Service
+	pagination   config.PaginationDefaults
+	requireAdmin func(http.Handler) http.Handler
+	logger       *slog.Logger
 }
 
-// NewHandler creates a new audit handler.
-func NewHandler(service *audit.Service, logger *slog.Logger) *Handler {
+// NewHandler creates a new audit handler. requireAdmin wraps the purge route
+// and must reject callers without the admin role.
+func NewHandler(service *audit.Service, pagination config.PaginationDefaults, requireAdmin func(http.Handler) http.Handler, logger *slog.Logger) *Handler {
 	return &Handler{
-		service: service,
-		logger:  logger,
+		service:      service,
+		pagination:   pagination,
+		requireAdmin: requireAdmin,
+		logger:       logger,
 	}
 }
 
@@ -31,9 +46,17 @@ func (h *Handler) RegisterRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("GET /api/v1/audit", h.QueryEvents)
 	mux.HandleFunc("GET /api/v1/audit/stats", h.GetStats)
 	mux.HandleFunc("GET /api/v1/audit/export", h.ExportEvents)
+	mux.HandleFunc("POST /api/v1/audit/signed-export", h.SignedExportEvents)
+	mux.HandleFunc("GET /api/v1/audit/stream", h.StreamEvents)
+	mux.HandleFunc("DELETE /api/v1/audit/purge", h.adminOnly(h.PurgeEvents))
 	mux.HandleFunc("GET /api/v1/audit/{id}", h.GetEvent)
 }
 
+// adminOnly wraps fn with the admin role check.
+func (h *Handler) adminOnly(fn http.HandlerFunc) http.HandlerFunc {
+	return h.requireAdmin(fn).ServeHTTP
+}
+
 // QueryEvents handles GET /api/v1/audit
 func (h *Handler) QueryEvents(w http.ResponseWriter, r *http.Request) {
 	query := r.URL.Query()
@@ -88,34 +111,20 @@ func (h *Handler) QueryEvents(w http.ResponseWriter, r *http.Request) {
 			filters.Page = p
 		}
 	}
-	if pageSize := query.Get("page_size"); pageSize != "" {
-		if ps, err := strconv.Atoi(pageSize); err == nil && ps > 0 {
-			filters.PageSize = ps
-		}
-	}
+	requestedPageSize, _ := strconv.Atoi(query.Get("page_size"))
+	filters.PageSize = h.pagination.AuditPageSize(requestedPageSize)
 
 	result, err := h.service.Query(r.Context(), filters)
 	if err != nil {
-		h.logger.Error("failed to query audit events", "error", err)
-		h.writeError(w, http.StatusInternalServerError, "internal_error", "Failed to query audit events")
+		requestid.Logger(r.Context(), h.logger).Error("failed to query audit events", "error", err)
+		h.writeError(w, http.StatusInternalServerError, api.ErrCodeInternal, "Failed to query audit events")
 		return
 	}
 
 	// Convert to response
 	events := make([]EventResponse, len(result.Events))
 	for i, e := range result.Events {
-		events[i] = EventResponse{
-			ID:         e.ID,
-			EventType:  string(e.EventType),
-			EntityType: e.EntityType,
-			EntityID:   e.EntityID,
-			Action:     e.Action,
-			Status:     e.Status,
-			Details:    e.Details,
-			UserEmail:  e.UserEmail,
-			IPAddress:  e.IPAddress,
-			CreatedAt:  e.CreatedAt,
-		}
+		events[i] = toEventResponse(e)
 	}
 
 	h.writeJSON(w, http.StatusOK, QueryEventsResponse{
@@ -132,36 +141,75 @@ func (h *Handler) GetEvent(w http.ResponseWriter, r *http.Request) {
 	idStr := r.PathValue("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		h.writeError(w, http.StatusBadRequest, "invalid_id", "Invalid event ID format")
+		h.writeError(w, http.StatusBadRequest, api.ErrCodeInvalidID, "Invalid event ID format")
 		return
 	}
 
 	event, err := h.service.GetByID(r.Context(), id)
 	if err != nil {
-		h.writeError(w, http.StatusNotFound, "not_found", "Audit event not found")
+		h.writeError(w, http.StatusNotFound, api.ErrCodeNotFound, "Audit event not found")
 		return
 	}
 
-	h.writeJSON(w, http.StatusOK, EventResponse{
-		ID:         event.ID,
-		EventType:  string(event.EventType),
-		EntityType: event.EntityType,
-		EntityID:   event.EntityID,
-		Action:     event.Action,
-		Status:     event.Status,
-		Details:    event.Details,
-		UserEmail:  event.UserEmail,
-		IPAddress:  event.IPAddress,
-		CreatedAt:  event.CreatedAt,
-	})
+	h.writeJSON(w, http.StatusOK, toEventResponse(*event))
+}
+
+// StreamEvents handles GET /api/v1/audit/stream
+// It streams newly recorded audit events as Server-Sent Events until the
+// client disconnects. Each event is sent as a data frame holding its JSON.
+func (h *Handler) StreamEvents(w http.ResponseWriter, r *http.Request) {
+	rc := http.NewResponseController(w)
+
+	events, unsubscribe := h.service.Subscribe()
+	defer unsubscribe()
+
+	// The stream outlives the server's write timeout
+	rc.SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	if err := rc.Flush(); err != nil {
+		requestid.Logger(r.Context(), h.logger).Error("audit stream not supported", "error", err)
+		return
+	}
+
+	heartbeat := time.NewTicker(streamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			// Comment frames keep proxies from closing an idle stream
+			if _, err := w.Write([]byte(": heartbeat\n\n")); err != nil {
+				return
+			}
+		case event := <-events:
+			data, err := json.Marshal(toEventResponse(event))
+			if err != nil {
+				requestid.Logger(r.Context(), h.logger).Error("failed to encode audit event", "error", err)
+				continue
+			}
+			if _, err := fmt.Fprintf(w, "data: %s\n\n", data); err != nil {
+				return
+			}
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
 }
 
 // GetStats handles GET /api/v1/audit/stats
 func (h *Handler) GetStats(w http.ResponseWriter, r *http.Request) {
 	stats, err := h.service.GetStats(r.Context())
 	if err != nil {
-		h.logger.Error("failed to get audit stats", "error", err)
-		h.writeError(w, http.StatusInternalServerError, "internal_error", "Failed to get audit stats")
+		requestid.Logger(r.Context(), h.logger).Error("failed to get audit stats", "error", err)
+		h.writeError(w, http.StatusInternalServerError, api.ErrCodeInternal, "Failed to get audit stats")
 		return
 	}
 
@@ -177,8 +225,41 @@ func (h *Handler) GetStats(w http.ResponseWriter, r *http.Request) {
 
 // ExportEvents handles GET /api/v1/audit/export
 func (h *Handler) ExportEvents(w http.ResponseWriter, r *http.Request) {
-	query := r.URL.Query()
+	csvData, err := h.service.ExportCSV(r.Context(), exportFilters(r.URL.Query()))
+	if err != nil {
+		requestid.Logger(r.Context(), h.logger).Error("failed to export audit events", "error", err)
+		h.writeError(w, http.StatusInternalServerError, api.ErrCodeInternal, "Failed to export audit events")
+		return
+	}
+
+	filename := "audit_export_" + time.Now().Format("20060102_150405") + ".csv"
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", "attachment; filename="+filename)
+	w.Write(csvData)
+}
+
+// SignedExportEvents handles POST /api/v1/audit/signed-export
+// It streams the same CSV as ExportEvents followed by a HASH row and, when
+// a signing key is configured, a SIGNATURE row. See cmd/verify-audit.
+func (h *Handler) SignedExportEvents(w http.ResponseWriter, r *http.Request) {
+	filename := "audit_export_" + time.Now().Format("20060102_150405") + ".csv"
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", "attachment; filename="+filename)
+
+	out := &countingWriter{w: w}
+	if err := h.service.ExportSignedCSV(r.Context(), exportFilters(r.URL.Query()), out); err != nil {
+		requestid.Logger(r.Context(), h.logger).Error("failed to export signed audit events", "error", err)
+		// Once rows are streamed the status is sent; the missing hash row
+		// marks the export as incomplete
+		if out.n == 0 {
+			w.Header().Del("Content-Disposition")
+			h.writeError(w, http.StatusInternalServerError, api.ErrCodeInternal, "Failed to export audit events")
+		}
+	}
+}
 
+// exportFilters parses the export query parameters.
+func exportFilters(query url.Values) audit.QueryFilters {
 	filters := audit.QueryFilters{
 		Page:     1,
 		PageSize: 10000,
@@ -208,17 +289,48 @@ func (h *Handler) ExportEvents(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	csvData, err := h.service.ExportCSV(r.Context(), filters)
+	return filters
+}
+
+// countingWriter counts the bytes written through it.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// PurgeEvents handles DELETE /api/v1/audit/purge
+// It applies the configured retention policy immediately. Admins only.
+func (h *Handler) PurgeEvents(w http.ResponseWriter, r *http.Request) {
+	deleted, err := h.service.Purge(r.Context())
 	if err != nil {
-		h.logger.Error("failed to export audit events", "error", err)
-		h.writeError(w, http.StatusInternalServerError, "internal_error", "Failed to export audit events")
+		requestid.Logger(r.Context(), h.logger).Error("failed to purge audit events", "error", err)
+		h.writeError(w, http.StatusInternalServerError, api.ErrCodeInternal, "Failed to purge audit events")
 		return
 	}
 
-	filename := "audit_export_" + time.Now().Format("20060102_150405") + ".csv"
-	w.Header().Set("Content-Type", "text/csv")
-	w.Header().Set("Content-Disposition", "attachment; filename="+filename)
-	w.Write(csvData)
+	h.writeJSON(w, http.StatusOK, PurgeResponse{Deleted: deleted})
+}
+
+// toEventResponse converts an audit event to its API representation.
+func toEventResponse(event audit.Event) EventResponse {
+	return EventResponse{
+		ID:         event.ID,
+		EventType:  string(event.EventType),
+		EntityType: event.EntityType,
+		EntityID:   event.EntityID,
+		Action:     event.Action,
+		Status:     event.Status,
+		Details:    event.Details,
+		UserEmail:  event.UserEmail,
+		IPAddress:  event.IPAddress,
+		CreatedAt:  event.CreatedAt,
+	}
 }
 
 // writeJSON writes a JSON response.
@@ -229,9 +341,9 @@ func (h *Handler) writeJSON(w http.ResponseWriter, status int, data interface{})
 }
 
 // writeError writes an error response.
-func (h *Handler) writeError(w http.ResponseWriter, status int, code, message string) {
+func (h *Handler) writeError(w http.ResponseWriter, status int, code api.ErrorCode, message string) {
 	h.writeJSON(w, status, ErrorResponse{
-		Error:   code,
+		Error:   string(code),
 		Message: message,
 	})
 }
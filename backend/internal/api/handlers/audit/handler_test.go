@@ -0,0 +1,212 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/controlcrud/backend/internal/api/middleware/auth"
+	"github.com/controlcrud/backend/internal/api/middleware/auth/authtest"
+	"github.com/controlcrud/backend/internal/config"
+	"github.com/controlcrud/backend/internal/domain/audit"
+)
+
+// mockRepository implements the audit.Repository methods used by purge.
+type mockRepository struct {
+	audit.Repository
+	purgeCalls int
+}
+
+func (m *mockRepository) Insert(ctx context.Context, event *audit.Event) error {
+	return nil
+}
+
+func (m *mockRepository) Purge(ctx context.Context, policy audit.RetentionPolicy) (int64, error) {
+	m.purgeCalls++
+	return 7, nil
+}
+
+const testSecret = "test-secret"
+
+// newTestHandler registers the audit routes behind the admin role check.
+func newTestHandler(svc *audit.Service, logger *slog.Logger) *http.ServeMux {
+	mux := http.NewServeMux()
+	NewHandler(svc, config.PaginationDefaults{}, auth.RequireRole(testSecret, auth.RoleAdmin), logger).RegisterRoutes(mux)
+	return mux
+}
+
+func newPurgeRequest(role string) *http.Request {
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/audit/purge", nil)
+	if role != "" {
+		req.Header.Set("Authorization", authtest.Token(testSecret, role))
+	}
+	return req
+}
+
+func TestHandler_PurgeEvents(t *testing.T) {
+	repo := &mockRepository{}
+	svc := audit.NewService(repo, audit.Options{Retention: audit.RetentionPolicy{MaxAgeDays: 90}}, nil)
+	mux := newTestHandler(svc, nil)
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, newPurgeRequest("admin"))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp PurgeResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Deleted != 7 {
+		t.Errorf("expected 7 deleted, got %d", resp.Deleted)
+	}
+}
+
+func TestHandler_PurgeEvents_RequiresAdmin(t *testing.T) {
+	for role, want := range map[string]int{"": http.StatusUnauthorized, "editor": http.StatusForbidden} {
+		repo := &mockRepository{}
+		svc := audit.NewService(repo, audit.Options{Retention: audit.RetentionPolicy{MaxAgeDays: 90}}, nil)
+		mux := newTestHandler(svc, nil)
+
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, newPurgeRequest(role))
+
+		if w.Code != want {
+			t.Errorf("role %q: expected status %d, got %d", role, want, w.Code)
+		}
+		if repo.purgeCalls != 0 {
+			t.Errorf("role %q: expected no purge", role)
+		}
+	}
+}
+
+func TestHandler_StreamEvents(t *testing.T) {
+	broadcaster := audit.NewEventBroadcaster()
+	svc := audit.NewService(&mockRepository{}, audit.Options{Broadcaster: broadcaster}, nil)
+	mux := newTestHandler(svc, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/audit/stream", nil).WithContext(ctx)
+	w := httptest.NewRecorder()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		mux.ServeHTTP(w, req)
+	}()
+
+	// Wait for the handler to subscribe before recording events
+	deadline := time.Now().Add(time.Second)
+	for broadcaster.Subscribers() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("handler did not subscribe")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	for _, entityID := range []string{"s-1", "s-2"} {
+		if err := svc.Record(context.Background(), audit.Event{EventType: audit.EventTypeEdit, EntityID: entityID}); err != nil {
+			t.Fatalf("failed to record event: %v", err)
+		}
+	}
+
+	// Let the handler drain both events before disconnecting
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	<-done
+
+	if got := w.Header().Get("Content-Type"); got != "text/event-stream" {
+		t.Errorf("expected text/event-stream, got %q", got)
+	}
+	if !w.Flushed {
+		t.Error("expected response to be flushed")
+	}
+
+	var frames []EventResponse
+	for _, frame := range strings.Split(strings.TrimSpace(w.Body.String()), "\n\n") {
+		data, ok := strings.CutPrefix(frame, "data: ")
+		if !ok {
+			t.Fatalf("unexpected frame: %q", frame)
+		}
+		var event EventResponse
+		if err := json.Unmarshal([]byte(data), &event); err != nil {
+			t.Fatalf("invalid frame data %q: %v", data, err)
+		}
+		frames = append(frames, event)
+	}
+	if len(frames) != 2 || frames[0].EntityID != "s-1" || frames[1].EntityID != "s-2" {
+		t.Errorf("unexpected frames: %+v", frames)
+	}
+	if broadcaster.Subscribers() != 0 {
+		t.Error("expected handler to unsubscribe on disconnect")
+	}
+}
+
+// exportRepository serves events to exports, or fails the query.
+type exportRepository struct {
+	audit.Repository
+	events   []audit.Event
+	queryErr error
+	filters  audit.QueryFilters
+}
+
+func (m *exportRepository) Query(ctx context.Context, filters audit.QueryFilters) (*audit.QueryResult, error) {
+	m.filters = filters
+	if m.queryErr != nil {
+		return nil, m.queryErr
+	}
+	return &audit.QueryResult{Events: m.events, TotalCount: len(m.events)}, nil
+}
+
+func TestHandler_SignedExportEvents(t *testing.T) {
+	repo := &exportRepository{events: []audit.Event{
+		{EventType: audit.EventTypeEdit, EntityType: "statement", EntityID: "s-1", Action: "update", Status: "success"},
+	}}
+	svc := audit.NewService(repo, audit.Options{}, nil)
+	mux := newTestHandler(svc, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/audit/signed-export?entity_types=statement", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if got := w.Header().Get("Content-Type"); got != "text/csv" {
+		t.Errorf("expected text/csv, got %q", got)
+	}
+	if len(repo.filters.EntityTypes) != 1 || repo.filters.EntityTypes[0] != "statement" {
+		t.Errorf("expected entity type filter, got %+v", repo.filters)
+	}
+	lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[2], `"HASH","sha256:`) {
+		t.Errorf("expected header, row and hash, got %q", lines)
+	}
+	if err := audit.VerifySignedCSV(w.Body.Bytes(), nil); err != nil {
+		t.Errorf("expected export to verify, got %v", err)
+	}
+}
+
+func TestHandler_SignedExportEvents_QueryError(t *testing.T) {
+	svc := audit.NewService(&exportRepository{queryErr: errors.New("db down")}, audit.Options{}, nil)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	mux := newTestHandler(svc, logger)
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/audit/signed-export", nil))
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("expected status 500, got %d", w.Code)
+	}
+	if got := w.Header().Get("Content-Type"); got != "application/json" {
+		t.Errorf("expected a JSON error, got %q", got)
+	}
+}
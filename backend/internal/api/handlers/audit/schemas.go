@@ -39,6 +39,11 @@ type StatsResponse struct {
 	EventsThisMonth int            `json:"events_this_month"`
 }
 
+// PurgeResponse is the response for purging audit events.
+type PurgeResponse struct {
+	Deleted int64 `json:"deleted"`
+}
+
 // ErrorResponse represents an error response.
 type ErrorResponse struct {
 	Error   string `json:"error"`
@@ -3,21 +3,29 @@ package connection
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
 
+	"github.com/controlcrud/backend/internal/api"
+	"github.com/controlcrud/backend/internal/api/middleware/auth"
 	"github.com/controlcrud/backend/internal/domain/connection"
+	"github.com/controlcrud/backend/internal/infrastructure/servicenow"
 	"github.com/google/uuid"
 )
 
 // Handler handles HTTP requests for connection management.
 type Handler struct {
-	service *connection.Service
+	service      *connection.Service
+	requireAdmin func(http.Handler) http.Handler
 }
 
-// NewHandler creates a new connection handler.
-func NewHandler(service *connection.Service) *Handler {
+// NewHandler creates a new connection handler. requireAdmin wraps the export
+// and import routes and must reject callers without the admin role.
+func NewHandler(service *connection.Service, requireAdmin func(http.Handler) http.Handler) *Handler {
 	return &Handler{
-		service: service,
+		service:      service,
+		requireAdmin: requireAdmin,
 	}
 }
 
@@ -27,7 +35,17 @@ func (h *Handler) RegisterRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("GET /api/v1/connection/status", h.GetStatus)
 	mux.HandleFunc("POST /api/v1/connection/config", h.SaveConfig)
 	mux.HandleFunc("POST /api/v1/connection/test", h.TestConnection)
+	mux.HandleFunc("POST /api/v1/connection/scripted-api-test", h.TestScriptedAPI)
+	mux.HandleFunc("GET /api/v1/connection/scopes", h.ListScopes)
+	mux.HandleFunc("PATCH /api/v1/connection/credentials", h.RotateCredentials)
 	mux.HandleFunc("DELETE /api/v1/connection", h.DeleteConnection)
+	mux.HandleFunc("GET /api/v1/connection/export", h.adminOnly(h.ExportConnection))
+	mux.HandleFunc("POST /api/v1/connection/import", h.adminOnly(h.ImportConnection))
+}
+
+// adminOnly wraps fn with the admin role check.
+func (h *Handler) adminOnly(fn http.HandlerFunc) http.HandlerFunc {
+	return h.requireAdmin(fn).ServeHTTP
 }
 
 // GetStatus handles GET /api/v1/connection/status
@@ -35,9 +53,13 @@ func (h *Handler) RegisterRoutes(mux *http.ServeMux) {
 func (h *Handler) GetStatus(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	status, err := h.service.GetStatus(ctx)
+	status, err := h.service.GetStatus(ctx, r.URL.Query().Get("label"))
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, "internal_error", "Failed to retrieve connection status")
+		if errors.Is(err, connection.ErrInvalidLabel) {
+			handleDomainError(w, err)
+			return
+		}
+		writeError(w, http.StatusInternalServerError, api.ErrCodeInternal, "Failed to retrieve connection status")
 		return
 	}
 
@@ -51,7 +73,7 @@ func (h *Handler) SaveConfig(w http.ResponseWriter, r *http.Request) {
 
 	var req ConfigRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeError(w, http.StatusBadRequest, "invalid_json", "Invalid JSON in request body")
+		writeError(w, http.StatusBadRequest, api.ErrCodeInvalidJSON, "Invalid JSON in request body")
 		return
 	}
 
@@ -63,35 +85,85 @@ func (h *Handler) SaveConfig(w http.ResponseWriter, r *http.Request) {
 
 	// Get user ID from context (set by auth middleware)
 	var userID *uuid.UUID
-	if uid, ok := ctx.Value("user_id").(uuid.UUID); ok {
-		userID = &uid
+	if user := auth.FromContext(ctx); user != nil {
+		userID = user.ID
+	}
+
+	// Optionally test the connection right after saving (?test=true)
+	var opts connection.SaveOptions
+	if test, err := strconv.ParseBool(r.URL.Query().Get("test")); err == nil {
+		opts.TestOnSave = test
 	}
 
 	// Save configuration
-	conn, err := h.service.SaveConfig(ctx, req.ToConfigInput(), userID)
+	conn, testResult, err := h.service.SaveConfig(ctx, req.ToConfigInput(), userID, opts)
 	if err != nil {
 		handleDomainError(w, err)
 		return
 	}
 
-	writeJSON(w, http.StatusOK, &ConfigResponse{
+	resp := &ConfigResponse{
 		ID:          conn.ID.String(),
 		InstanceURL: conn.InstanceURL,
 		AuthMethod:  string(conn.AuthMethod),
 		Status:      string(conn.LastTestStatus),
 		Message:     "Configuration saved successfully",
+
+		Label: conn.Label,
+	}
+	if testResult != nil {
+		resp.TestResult = NewTestResponse(testResult)
+		if !testResult.Success {
+			resp.Message = "Configuration saved, but the connection test failed"
+		}
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// RotateCredentials handles PATCH /api/v1/connection/credentials?label=
+// Replaces the credentials of the connection without recreating it.
+func (h *Handler) RotateCredentials(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req CredentialsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, api.ErrCodeInvalidJSON, "Invalid JSON in request body")
+		return
+	}
+
+	if req.Username == "" && req.Password == "" && req.OAuthClientSecret == "" {
+		writeValidationError(w, &validationErrorList{
+			errors: []ValidationError{{Field: "credentials", Message: "At least one credential field is required"}},
+		})
+		return
+	}
+
+	result, err := h.service.RotateCredentials(ctx, r.URL.Query().Get("label"), req.ToCredentialsInput())
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, &RotateCredentialsResponse{
+		Message: "Credentials rotated successfully",
+		Test:    NewTestResponse(result),
 	})
 }
 
-// TestConnection handles POST /api/v1/connection/test
-// Tests the current ServiceNow connection.
+// TestConnection handles POST /api/v1/connection/test?label=
+// Tests the labelled ServiceNow connection.
 func (h *Handler) TestConnection(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	result, err := h.service.TestConnection(ctx)
+	result, err := h.service.TestConnection(ctx, r.URL.Query().Get("label"))
 	if err != nil {
+		if errors.Is(err, connection.ErrInvalidLabel) {
+			handleDomainError(w, err)
+			return
+		}
 		if errors.Is(err, connection.ErrConnectionNotFound) {
-			writeError(w, http.StatusNotFound, "not_configured", "No connection configured. Please save configuration first.")
+			writeError(w, http.StatusNotFound, api.ErrCodeNoConnection, "No connection configured. Please save configuration first.")
 			return
 		}
 		// Return the result with error details from ServiceNow test
@@ -99,21 +171,87 @@ func (h *Handler) TestConnection(w http.ResponseWriter, r *http.Request) {
 			writeJSON(w, http.StatusOK, NewTestResponse(result))
 			return
 		}
-		writeError(w, http.StatusInternalServerError, "test_failed", "Failed to test connection")
+		writeError(w, http.StatusInternalServerError, api.ErrorCodeFor(err), "Failed to test connection")
 		return
 	}
 
 	writeJSON(w, http.StatusOK, NewTestResponse(result))
 }
 
-// DeleteConnection handles DELETE /api/v1/connection
-// Deletes the current ServiceNow connection configuration.
+// TestScriptedAPI handles POST /api/v1/connection/scripted-api-test?label=
+// Calls a resource of the configured Scripted REST API to verify it is reachable.
+func (h *Handler) TestScriptedAPI(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req ScriptedAPITestRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, api.ErrCodeInvalidJSON, "Invalid JSON in request body")
+		return
+	}
+
+	input := connection.ScriptedAPITestInput{
+		Path:   req.Path,
+		Method: req.Method,
+	}
+	if len(req.Body) > 0 {
+		input.Body = req.Body
+	}
+
+	result, err := h.service.TestScriptedAPI(ctx, r.URL.Query().Get("label"), input)
+	if err != nil {
+		switch {
+		case errors.Is(err, connection.ErrInvalidLabel):
+			handleDomainError(w, err)
+		case errors.Is(err, connection.ErrConnectionNotFound):
+			writeError(w, http.StatusNotFound, api.ErrCodeNoConnection, "No connection configured. Please save configuration first.")
+		case errors.Is(err, servicenow.ErrScriptedAPINotConfigured):
+			writeError(w, http.StatusBadRequest, api.ErrCodeNotConfigured, "Scripted REST API namespace and API ID are not configured for this connection.")
+		default:
+			writeError(w, http.StatusInternalServerError, api.ErrorCodeFor(err), "Failed to test scripted REST API")
+		}
+		return
+	}
+
+	writeJSON(w, http.StatusOK, NewScriptedAPITestResponse(result))
+}
+
+// ListScopes handles GET /api/v1/connection/scopes?label=
+// Lists the application scopes of the labelled connection's instance.
+func (h *Handler) ListScopes(w http.ResponseWriter, r *http.Request) {
+	scopes, err := h.service.ListScopes(r.Context(), r.URL.Query().Get("label"))
+	if err != nil {
+		switch {
+		case errors.Is(err, connection.ErrInvalidLabel):
+			handleDomainError(w, err)
+		case errors.Is(err, connection.ErrConnectionNotFound):
+			writeError(w, http.StatusNotFound, api.ErrCodeNoConnection, "No connection configured. Please save configuration first.")
+		case errors.Is(err, connection.ErrServiceNowError):
+			writeError(w, http.StatusBadGateway, api.ErrorCodeFor(err), "Failed to list scopes from ServiceNow")
+		default:
+			writeError(w, http.StatusInternalServerError, api.ErrorCodeFor(err), "Failed to list scopes")
+		}
+		return
+	}
+
+	response := make([]ScopeResponse, len(scopes))
+	for i, scope := range scopes {
+		response[i] = ScopeResponse{SysID: scope.SysID, Name: scope.Name, Namespace: scope.Namespace}
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// DeleteConnection handles DELETE /api/v1/connection?label=
+// Deletes the labelled ServiceNow connection configuration.
 func (h *Handler) DeleteConnection(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	err := h.service.DeleteConnection(ctx)
+	err := h.service.DeleteConnection(ctx, r.URL.Query().Get("label"))
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, "delete_failed", "Failed to delete connection")
+		if errors.Is(err, connection.ErrInvalidLabel) {
+			handleDomainError(w, err)
+			return
+		}
+		writeError(w, http.StatusInternalServerError, api.ErrorCodeFor(err), "Failed to delete connection")
 		return
 	}
 
@@ -122,6 +260,58 @@ func (h *Handler) DeleteConnection(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// ExportConnection handles GET /api/v1/connection/export?label=
+// Returns a backup bundle of the labelled connection with its credentials
+// still encrypted.
+func (h *Handler) ExportConnection(w http.ResponseWriter, r *http.Request) {
+	bundle, err := h.service.ExportConnection(r.Context(), r.URL.Query().Get("label"))
+	if err != nil {
+		switch {
+		case errors.Is(err, connection.ErrInvalidLabel), errors.Is(err, connection.ErrConnectionNotFound):
+			handleDomainError(w, err)
+		default:
+			writeError(w, http.StatusInternalServerError, api.ErrorCodeFor(err), "Failed to export connection")
+		}
+		return
+	}
+
+	writeJSON(w, http.StatusOK, bundle)
+}
+
+// ImportConnection handles POST /api/v1/connection/import
+// Restores a connection from a bundle produced by ExportConnection. The
+// bundle must have been exported under the current encryption key.
+func (h *Handler) ImportConnection(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var bundle connection.ExportBundle
+	if err := json.NewDecoder(r.Body).Decode(&bundle); err != nil {
+		writeError(w, http.StatusBadRequest, api.ErrCodeInvalidJSON, "Invalid JSON in request body")
+		return
+	}
+
+	var userID *uuid.UUID
+	if user := auth.FromContext(ctx); user != nil {
+		userID = user.ID
+	}
+
+	conn, err := h.service.ImportConnection(ctx, &bundle, userID)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, &ConfigResponse{
+		ID:          conn.ID.String(),
+		InstanceURL: conn.InstanceURL,
+		AuthMethod:  string(conn.AuthMethod),
+		Status:      string(conn.LastTestStatus),
+		Message:     "Connection imported successfully",
+
+		Label: conn.Label,
+	})
+}
+
 // validateConfigRequest validates the configuration request.
 func validateConfigRequest(req *ConfigRequest) error {
 	var validationErrors []ValidationError
@@ -203,6 +393,14 @@ func handleDomainError(w http.ResponseWriter, err error) {
 		writeValidationError(w, &validationErrorList{
 			errors: []ValidationError{{Field: "instance_url", Message: "Instance URL is required"}},
 		})
+	case errors.Is(err, connection.ErrInvalidInstanceURL):
+		writeValidationError(w, &validationErrorList{
+			errors: []ValidationError{{Field: "instance_url", Message: "Instance URL must be a ServiceNow instance root such as https://example.service-now.com"}},
+		})
+	case errors.Is(err, connection.ErrInstanceHostNotAllowed):
+		writeValidationError(w, &validationErrorList{
+			errors: []ValidationError{{Field: "instance_url", Message: "Instance URL must not point to localhost or a private network"}},
+		})
 	case errors.Is(err, connection.ErrAuthMethodRequired):
 		writeValidationError(w, &validationErrorList{
 			errors: []ValidationError{{Field: "auth_method", Message: "Authentication method is required"}},
@@ -231,10 +429,36 @@ func handleDomainError(w http.ResponseWriter, err error) {
 		writeValidationError(w, &validationErrorList{
 			errors: []ValidationError{{Field: "oauth_token_url", Message: "OAuth Token URL is required"}},
 		})
+	case errors.Is(err, connection.ErrInvalidLabel):
+		writeValidationError(w, &validationErrorList{
+			errors: []ValidationError{{Field: "label", Message: "Label must be 1-50 letters, digits, '_' or '-'"}},
+		})
+	case errors.Is(err, connection.ErrInvalidScriptedAPI):
+		writeValidationError(w, &validationErrorList{
+			errors: []ValidationError{{Field: "scripted_api_namespace", Message: "Scripted API namespace and API ID must be set together and contain only letters, digits, '_' or '-'"}},
+		})
+	case errors.Is(err, connection.ErrInvalidScope):
+		writeValidationError(w, &validationErrorList{
+			errors: []ValidationError{{Field: "scope", Message: "Scope must be an application scope sys_id of letters, digits or '_'"}},
+		})
+	case errors.Is(err, connection.ErrClientTLSIncomplete):
+		writeValidationError(w, &validationErrorList{
+			errors: []ValidationError{{Field: "client_tls_key", Message: "Client TLS certificate and key must be provided together"}},
+		})
+	case errors.Is(err, connection.ErrInvalidClientTLS):
+		writeValidationError(w, &validationErrorList{
+			errors: []ValidationError{{Field: "client_tls_cert", Message: "Client TLS certificate and key must be a matching PEM-encoded pair"}},
+		})
+	case errors.Is(err, connection.ErrUnsupportedExportVersion):
+		writeValidationError(w, &validationErrorList{
+			errors: []ValidationError{{Field: "version", Message: fmt.Sprintf("Export version must be %d", connection.ExportVersion)}},
+		})
+	case errors.Is(err, connection.ErrEncryptionKeyMismatch):
+		writeError(w, http.StatusUnprocessableEntity, api.ErrCodeKeyMismatch, "The export was encrypted with a different encryption key")
 	case errors.Is(err, connection.ErrConnectionNotFound):
-		writeError(w, http.StatusNotFound, "not_found", "Connection not found")
+		writeError(w, http.StatusNotFound, api.ErrCodeNotFound, "Connection not found")
 	default:
-		writeError(w, http.StatusInternalServerError, "internal_error", "An internal error occurred")
+		writeError(w, http.StatusInternalServerError, api.ErrCodeInternal, "An internal error occurred")
 	}
 }
 
@@ -246,9 +470,9 @@ func writeJSON(w http.ResponseWriter, status int, data interface{}) {
 }
 
 // writeError writes an error response.
-func writeError(w http.ResponseWriter, status int, errorCode, message string) {
+func writeError(w http.ResponseWriter, status int, code api.ErrorCode, message string) {
 	writeJSON(w, status, &ErrorResponse{
-		Error:   errorCode,
+		Error:   string(code),
 		Message: message,
 	})
 }
@@ -257,11 +481,11 @@ func writeError(w http.ResponseWriter, status int, errorCode, message string) {
 func writeValidationError(w http.ResponseWriter, err error) {
 	if ve, ok := err.(*validationErrorList); ok {
 		writeJSON(w, http.StatusBadRequest, &ValidationErrorResponse{
-			Error:   "validation_error",
+			Error:   string(api.ErrCodeValidation),
 			Message: "Request validation failed",
 			Fields:  ve.errors,
 		})
 		return
 	}
-	writeError(w, http.StatusBadRequest, "validation_error", err.Error())
+	writeError(w, http.StatusBadRequest, api.ErrCodeValidation, err.Error())
 }
@@ -3,13 +3,17 @@ package connection
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
 
+	"github.com/controlcrud/backend/internal/api/middleware/auth"
+	"github.com/controlcrud/backend/internal/api/middleware/auth/authtest"
 	"github.com/controlcrud/backend/internal/domain/connection"
+	"github.com/controlcrud/backend/internal/infrastructure/crypto"
 	"github.com/google/uuid"
 )
 
@@ -28,11 +32,14 @@ func (m *mockConnectionService) GetStatus(ctx context.Context) (*connection.Stat
 	return m.status, nil
 }
 
-func (m *mockConnectionService) SaveConfig(ctx context.Context, input *connection.ConfigInput, userID *uuid.UUID) (*connection.Connection, error) {
+func (m *mockConnectionService) SaveConfig(ctx context.Context, input *connection.ConfigInput, userID *uuid.UUID, opts connection.SaveOptions) (*connection.Connection, *connection.TestResult, error) {
 	if m.err != nil {
-		return nil, m.err
+		return nil, nil, m.err
+	}
+	if opts.TestOnSave {
+		return m.conn, m.testResult, nil
 	}
-	return m.conn, nil
+	return m.conn, nil, nil
 }
 
 func (m *mockConnectionService) TestConnection(ctx context.Context) (*connection.TestResult, error) {
@@ -114,6 +121,37 @@ func TestHandler_GetStatus_Configured(t *testing.T) {
 	}
 }
 
+func TestHandler_GetStatus_FailedTestDetails(t *testing.T) {
+	testTime := time.Now()
+	status := &connection.Status{
+		IsConfigured:           true,
+		InstanceURL:            "https://test.service-now.com",
+		AuthMethod:             connection.AuthMethodBasic,
+		LastTestAt:             &testTime,
+		LastTestStatus:         connection.StatusFailure,
+		LastTestMessage:        "authentication failed: invalid credentials",
+		LastTestResponseTimeMs: 412,
+	}
+
+	w := httptest.NewRecorder()
+	writeJSON(w, http.StatusOK, NewStatusResponse(status))
+
+	var body map[string]interface{}
+	if err := json.NewDecoder(w.Result().Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if body["last_test_status"] != "failure" {
+		t.Errorf("expected status 'failure', got %v", body["last_test_status"])
+	}
+	if body["last_test_message"] != status.LastTestMessage {
+		t.Errorf("expected last_test_message %q, got %v", status.LastTestMessage, body["last_test_message"])
+	}
+	if body["response_time_ms"] != float64(412) {
+		t.Errorf("expected response_time_ms 412, got %v", body["response_time_ms"])
+	}
+}
+
 func TestConfigRequest_Validation_BasicAuth(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -312,6 +350,7 @@ func TestConfigRequest_ToConfigInput(t *testing.T) {
 		OAuthClientID:     "client123",
 		OAuthClientSecret: "secret456",
 		OAuthTokenURL:     "https://test.service-now.com/oauth_token.do",
+		Scope:             "a1b2c3",
 	}
 
 	input := req.ToConfigInput()
@@ -331,6 +370,9 @@ func TestConfigRequest_ToConfigInput(t *testing.T) {
 	if input.OAuthTokenURL != req.OAuthTokenURL {
 		t.Errorf("unexpected token URL: %s", input.OAuthTokenURL)
 	}
+	if input.Scope != req.Scope {
+		t.Errorf("unexpected scope: %s", input.Scope)
+	}
 }
 
 func TestTestResponse_Conversion(t *testing.T) {
@@ -544,3 +586,128 @@ func TestNewStatusResponse(t *testing.T) {
 		t.Errorf("unexpected version: %s", resp.InstanceVersion)
 	}
 }
+
+// memoryRepository stores connections in memory for export/import tests.
+type memoryRepository struct {
+	connection.Repository
+	conns map[string]*connection.Connection
+}
+
+func (m *memoryRepository) GetByLabel(ctx context.Context, label string) (*connection.Connection, error) {
+	conn, ok := m.conns[label]
+	if !ok {
+		return nil, connection.ErrConnectionNotFound
+	}
+	return conn, nil
+}
+
+func (m *memoryRepository) Upsert(ctx context.Context, conn *connection.Connection) error {
+	m.conns[conn.Label] = conn
+	return nil
+}
+
+const testJWTSecret = "test-secret"
+
+// newExportServer returns a mux backed by an in-memory repository and an
+// AES key derived from keyByte.
+func newExportServer(t *testing.T, keyByte byte) (*http.ServeMux, *memoryRepository) {
+	t.Helper()
+	cryptoSvc, err := crypto.NewAESCryptoService(base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{keyByte}, 32)))
+	if err != nil {
+		t.Fatalf("failed to create crypto service: %v", err)
+	}
+	repo := &memoryRepository{conns: make(map[string]*connection.Connection)}
+	mux := http.NewServeMux()
+	NewHandler(connection.NewService(repo, cryptoSvc, connection.Options{}), auth.RequireRole(testJWTSecret, auth.RoleAdmin)).RegisterRoutes(mux)
+	return mux, repo
+}
+
+func serve(mux *http.ServeMux, method, target, role string, body []byte) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, bytes.NewReader(body))
+	if role != "" {
+		req.Header.Set("Authorization", authtest.Token(testJWTSecret, role))
+	}
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	return w
+}
+
+func TestHandler_ExportImport_RoundTrip(t *testing.T) {
+	source, _ := newExportServer(t, 1)
+	config := []byte(`{"instance_url":"https://prod.service-now.com","auth_method":"basic","label":"prod","username":"admin","password":"s3cret"}`)
+	if w := serve(source, http.MethodPost, "/api/v1/connection/config", "", config); w.Code != http.StatusOK {
+		t.Fatalf("failed to save config: %d %s", w.Code, w.Body.String())
+	}
+
+	w := serve(source, http.MethodGet, "/api/v1/connection/export?label=prod", auth.RoleAdmin, nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	bundle := w.Body.Bytes()
+
+	var raw map[string]interface{}
+	if err := json.Unmarshal(bundle, &raw); err != nil {
+		t.Fatalf("failed to decode bundle: %v", err)
+	}
+	if raw["version"] != float64(connection.ExportVersion) {
+		t.Errorf("expected version %d, got %v", connection.ExportVersion, raw["version"])
+	}
+	exported, _ := raw["connection"].(map[string]interface{})
+	if _, ok := exported["password_encrypted"].(string); !ok {
+		t.Errorf("expected base64 password_encrypted in bundle, got %v", exported["password_encrypted"])
+	}
+
+	target, targetRepo := newExportServer(t, 1)
+	w = serve(target, http.MethodPost, "/api/v1/connection/import", auth.RoleAdmin, bundle)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if conn := targetRepo.conns["prod"]; conn == nil || conn.Username != "admin" {
+		t.Errorf("expected the connection to be imported, got %+v", conn)
+	}
+}
+
+func TestHandler_ImportConnection_Errors(t *testing.T) {
+	source, _ := newExportServer(t, 1)
+	config := []byte(`{"instance_url":"https://prod.service-now.com","auth_method":"basic","username":"admin","password":"s3cret"}`)
+	if w := serve(source, http.MethodPost, "/api/v1/connection/config", "", config); w.Code != http.StatusOK {
+		t.Fatalf("failed to save config: %d %s", w.Code, w.Body.String())
+	}
+	bundle := serve(source, http.MethodGet, "/api/v1/connection/export", auth.RoleAdmin, nil).Body.Bytes()
+
+	tests := []struct {
+		name    string
+		keyByte byte
+		role    string
+		body    []byte
+		want    int
+	}{
+		{"different key", 2, auth.RoleAdmin, bundle, http.StatusUnprocessableEntity},
+		{"unsupported version", 1, auth.RoleAdmin, bytes.Replace(bundle, []byte(`"version":1`), []byte(`"version":2`), 1), http.StatusBadRequest},
+		{"invalid json", 1, auth.RoleAdmin, []byte(`{`), http.StatusBadRequest},
+		{"not admin", 1, "viewer", bundle, http.StatusForbidden},
+		{"no token", 1, "", bundle, http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target, repo := newExportServer(t, tt.keyByte)
+			w := serve(target, http.MethodPost, "/api/v1/connection/import", tt.role, tt.body)
+			if w.Code != tt.want {
+				t.Errorf("expected status %d, got %d: %s", tt.want, w.Code, w.Body.String())
+			}
+			if len(repo.conns) != 0 {
+				t.Error("expected nothing to be imported")
+			}
+		})
+	}
+}
+
+func TestHandler_ExportConnection_RequiresAdmin(t *testing.T) {
+	mux, _ := newExportServer(t, 1)
+	if w := serve(mux, http.MethodGet, "/api/v1/connection/export", "viewer", nil); w.Code != http.StatusForbidden {
+		t.Errorf("expected status 403 for non-admin, got %d", w.Code)
+	}
+	if w := serve(mux, http.MethodGet, "/api/v1/connection/export", auth.RoleAdmin, nil); w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404 without a connection, got %d", w.Code)
+	}
+}
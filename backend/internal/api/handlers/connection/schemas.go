@@ -1,6 +1,7 @@
 package connection
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/controlcrud/backend/internal/domain/connection"
@@ -15,6 +16,16 @@ type ConfigRequest struct {
 	OAuthClientID     string `json:"oauth_client_id,omitempty" validate:"required_if=AuthMethod oauth"`
 	OAuthClientSecret string `json:"oauth_client_secret,omitempty" validate:"required_if=AuthMethod oauth"`
 	OAuthTokenURL     string `json:"oauth_token_url,omitempty" validate:"required_if=AuthMethod oauth,omitempty,url"`
+
+	ScriptedAPINamespace string `json:"scripted_api_namespace,omitempty"`
+	ScriptedAPIID        string `json:"scripted_api_id,omitempty"`
+
+	Scope string `json:"scope,omitempty"` // Application scope sys_id limiting GRC table queries
+
+	ClientTLSCert string `json:"client_tls_cert,omitempty"`
+	ClientTLSKey  string `json:"client_tls_key,omitempty"`
+
+	Label string `json:"label,omitempty"` // Connection to save; defaults to "default"
 }
 
 // ToConfigInput converts the request to domain ConfigInput.
@@ -27,28 +38,70 @@ func (r *ConfigRequest) ToConfigInput() *connection.ConfigInput {
 		OAuthClientID:     r.OAuthClientID,
 		OAuthClientSecret: r.OAuthClientSecret,
 		OAuthTokenURL:     r.OAuthTokenURL,
+
+		ScriptedAPINamespace: r.ScriptedAPINamespace,
+		ScriptedAPIID:        r.ScriptedAPIID,
+
+		Scope: r.Scope,
+
+		ClientTLSCert: r.ClientTLSCert,
+		ClientTLSKey:  r.ClientTLSKey,
+
+		Label: r.Label,
+	}
+}
+
+// CredentialsRequest represents the request body for rotating connection credentials.
+type CredentialsRequest struct {
+	Username          string `json:"username,omitempty"`
+	Password          string `json:"password,omitempty"`
+	OAuthClientSecret string `json:"oauth_client_secret,omitempty"`
+}
+
+// ToCredentialsInput converts the request to domain CredentialsInput.
+func (r *CredentialsRequest) ToCredentialsInput() *connection.CredentialsInput {
+	return &connection.CredentialsInput{
+		Username:          r.Username,
+		Password:          r.Password,
+		OAuthClientSecret: r.OAuthClientSecret,
 	}
 }
 
 // StatusResponse represents the response for connection status.
 type StatusResponse struct {
+	Label           string     `json:"label"`
 	IsConfigured    bool       `json:"is_configured"`
 	InstanceURL     string     `json:"instance_url,omitempty"`
 	AuthMethod      string     `json:"auth_method,omitempty"`
 	LastTestAt      *time.Time `json:"last_test_at,omitempty"`
 	LastTestStatus  string     `json:"last_test_status"`
+	LastTestMessage string     `json:"last_test_message,omitempty"`
+	ResponseTimeMs  int64      `json:"response_time_ms,omitempty"`
 	InstanceVersion string     `json:"instance_version,omitempty"`
+
+	ScriptedAPINamespace string `json:"scripted_api_namespace,omitempty"`
+	ScriptedAPIID        string `json:"scripted_api_id,omitempty"`
+
+	Scope string `json:"scope,omitempty"`
 }
 
 // NewStatusResponse creates a StatusResponse from domain Status.
 func NewStatusResponse(status *connection.Status) *StatusResponse {
 	return &StatusResponse{
+		Label:           status.Label,
 		IsConfigured:    status.IsConfigured,
 		InstanceURL:     status.InstanceURL,
 		AuthMethod:      string(status.AuthMethod),
 		LastTestAt:      status.LastTestAt,
 		LastTestStatus:  string(status.LastTestStatus),
+		LastTestMessage: status.LastTestMessage,
+		ResponseTimeMs:  status.LastTestResponseTimeMs,
 		InstanceVersion: status.LastTestInstanceVersion,
+
+		ScriptedAPINamespace: status.ScriptedAPINamespace,
+		ScriptedAPIID:        status.ScriptedAPIID,
+
+		Scope: status.Scope,
 	}
 }
 
@@ -72,13 +125,47 @@ func NewTestResponse(result *connection.TestResult) *TestResponse {
 	}
 }
 
+// ScriptedAPITestRequest represents the request body for testing a Scripted REST API resource.
+type ScriptedAPITestRequest struct {
+	Path   string          `json:"path"`
+	Method string          `json:"method,omitempty"`
+	Body   json.RawMessage `json:"body,omitempty"`
+}
+
+// ScriptedAPITestResponse represents the response for a Scripted REST API test.
+type ScriptedAPITestResponse struct {
+	Success        bool            `json:"success"`
+	Message        string          `json:"message,omitempty"`
+	Response       json.RawMessage `json:"response,omitempty"`
+	ResponseTimeMs int64           `json:"response_time_ms"`
+}
+
+// NewScriptedAPITestResponse creates a ScriptedAPITestResponse from the domain result.
+func NewScriptedAPITestResponse(result *connection.ScriptedAPITestResult) *ScriptedAPITestResponse {
+	return &ScriptedAPITestResponse{
+		Success:        result.Success,
+		Message:        result.ErrorMessage,
+		Response:       result.Response,
+		ResponseTimeMs: result.ResponseTimeMs,
+	}
+}
+
 // ConfigResponse represents the response after saving configuration.
 type ConfigResponse struct {
-	ID          string `json:"id"`
-	InstanceURL string `json:"instance_url"`
-	AuthMethod  string `json:"auth_method"`
-	Status      string `json:"status"`
-	Message     string `json:"message"`
+	ID          string        `json:"id"`
+	InstanceURL string        `json:"instance_url"`
+	AuthMethod  string        `json:"auth_method"`
+	Status      string        `json:"status"`
+	Message     string        `json:"message"`
+	TestResult  *TestResponse `json:"test_result,omitempty"` // Present when saved with ?test=true
+
+	Label string `json:"label"`
+}
+
+// RotateCredentialsResponse represents the response after rotating credentials.
+type RotateCredentialsResponse struct {
+	Message string        `json:"message"`
+	Test    *TestResponse `json:"test"`
 }
 
 // ErrorResponse represents an error response.
@@ -100,3 +187,10 @@ type ValidationError struct {
 	Field   string `json:"field"`
 	Message string `json:"message"`
 }
+
+// ScopeResponse represents an application scope of the instance.
+type ScopeResponse struct {
+	SysID     string `json:"sys_id"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+}
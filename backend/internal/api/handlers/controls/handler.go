@@ -3,21 +3,31 @@ package controls
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 
+	"github.com/google/uuid"
+
+	"github.com/controlcrud/backend/internal/api"
+	"github.com/controlcrud/backend/internal/config"
+	"github.com/controlcrud/backend/internal/domain/control"
 	"github.com/controlcrud/backend/internal/domain/controls"
 )
 
 // Handler handles HTTP requests for controls management.
 type Handler struct {
-	service *controls.Service
+	service        *controls.Service
+	controlService *control.Service
+	pagination     config.PaginationDefaults
 }
 
 // NewHandler creates a new controls handler.
-func NewHandler(service *controls.Service) *Handler {
+func NewHandler(service *controls.Service, controlService *control.Service, pagination config.PaginationDefaults) *Handler {
 	return &Handler{
-		service: service,
+		service:        service,
+		controlService: controlService,
+		pagination:     pagination,
 	}
 }
 
@@ -25,6 +35,8 @@ func NewHandler(service *controls.Service) *Handler {
 func (h *Handler) RegisterRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("GET /api/v1/controls/policy-statements", h.ListPolicyStatements)
 	mux.HandleFunc("GET /api/v1/controls/policy-statements/{id}", h.GetPolicyStatement)
+	mux.HandleFunc("GET /api/v1/controls/search", h.Search)
+	mux.HandleFunc("PUT /api/v1/controls/{id}/status", h.UpdateStatus)
 }
 
 // ListPolicyStatements handles GET /api/v1/controls/policy-statements
@@ -35,7 +47,7 @@ func (h *Handler) ListPolicyStatements(w http.ResponseWriter, r *http.Request) {
 	// Parse query parameters
 	params := &controls.ListParams{
 		Page:     parseIntParam(r, "page", 1),
-		PageSize: parseIntParam(r, "page_size", 20),
+		PageSize: h.pagination.ControlsPageSize(parseIntParam(r, "page_size", 0)),
 		Search:   r.URL.Query().Get("search"),
 		SortBy:   r.URL.Query().Get("sort_by"),
 		SortDir:  r.URL.Query().Get("sort_dir"),
@@ -60,7 +72,7 @@ func (h *Handler) GetPolicyStatement(w http.ResponseWriter, r *http.Request) {
 	// Get ID from path
 	id := r.PathValue("id")
 	if id == "" {
-		writeError(w, http.StatusBadRequest, "invalid_id", "Policy statement ID is required")
+		writeError(w, http.StatusBadRequest, api.ErrCodeInvalidID, "Policy statement ID is required")
 		return
 	}
 
@@ -75,6 +87,78 @@ func (h *Handler) GetPolicyStatement(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, NewPolicyStatementDTO(ps))
 }
 
+// Search handles GET /api/v1/controls/search
+// Finds local controls by control ID or name across all systems. With
+// resolve_roles=true, responsible roles holding a user group ID are
+// resolved to the group name from ServiceNow.
+func (h *Handler) Search(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	params := control.SearchParams{
+		Query:         query.Get("q"),
+		ControlFamily: query.Get("control_family"),
+		Page:          parseIntParam(r, "page", 1),
+		PageSize:      h.pagination.ControlsPageSize(parseIntParam(r, "page_size", 0)),
+	}
+
+	result, err := h.controlService.Search(r.Context(), params)
+	if err != nil {
+		if errors.Is(err, control.ErrInvalidInput) {
+			writeError(w, http.StatusBadRequest, api.ErrCodeValidation,
+				fmt.Sprintf("q must be at least %d characters", control.MinSearchQueryLength))
+			return
+		}
+		writeError(w, http.StatusInternalServerError, api.ErrCodeInternal, "An internal error occurred")
+		return
+	}
+
+	if query.Get("resolve_roles") == "true" {
+		found := make([]*control.ControlWithStats, len(result.Controls))
+		for i := range result.Controls {
+			found[i] = &result.Controls[i].ControlWithStats
+		}
+		if err := h.service.ResolveResponsibleRoles(r.Context(), found); err != nil {
+			handleError(w, err)
+			return
+		}
+	}
+
+	writeJSON(w, http.StatusOK, NewSearchControlsResponse(result))
+}
+
+// UpdateStatus handles PUT /api/v1/controls/{id}/status
+// Updates the local implementation status of a control.
+func (h *Handler) UpdateStatus(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, api.ErrCodeInvalidID, "Invalid control ID")
+		return
+	}
+
+	var req UpdateStatusRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, api.ErrCodeInvalidJSON, "Invalid JSON in request body")
+		return
+	}
+
+	c, err := h.controlService.UpdateStatus(ctx, id, req.ImplementationStatus)
+	if err != nil {
+		switch {
+		case errors.Is(err, control.ErrInvalidStatus):
+			writeError(w, http.StatusBadRequest, api.ErrorCodeFor(err),
+				"implementation_status must be one of: implemented, partially_implemented, not_implemented, not_applicable")
+		case errors.Is(err, control.ErrNotFound):
+			writeError(w, http.StatusNotFound, api.ErrorCodeFor(err), "Control not found")
+		default:
+			writeError(w, http.StatusInternalServerError, api.ErrCodeInternal, "An internal error occurred")
+		}
+		return
+	}
+
+	writeJSON(w, http.StatusOK, NewControlDTO(c))
+}
+
 // parseIntParam parses an integer query parameter with a default value.
 func parseIntParam(r *http.Request, name string, defaultValue int) int {
 	value := r.URL.Query().Get(name)
@@ -92,15 +176,15 @@ func parseIntParam(r *http.Request, name string, defaultValue int) int {
 func handleError(w http.ResponseWriter, err error) {
 	switch {
 	case errors.Is(err, controls.ErrNoConnection):
-		writeError(w, http.StatusPreconditionFailed, "no_connection", "No ServiceNow connection configured. Please configure a connection first.")
+		writeError(w, http.StatusPreconditionFailed, api.ErrorCodeFor(err), "No ServiceNow connection configured. Please configure a connection first.")
 	case errors.Is(err, controls.ErrAuthFailed):
-		writeError(w, http.StatusUnauthorized, "auth_failed", "ServiceNow authentication failed. Please check your credentials.")
+		writeError(w, http.StatusUnauthorized, api.ErrorCodeFor(err), "ServiceNow authentication failed. Please check your credentials.")
 	case errors.Is(err, controls.ErrNotFound):
-		writeError(w, http.StatusNotFound, "not_found", "Policy statement not found")
+		writeError(w, http.StatusNotFound, api.ErrorCodeFor(err), "Policy statement not found")
 	case errors.Is(err, controls.ErrServiceNowError):
-		writeError(w, http.StatusBadGateway, "servicenow_error", "Failed to communicate with ServiceNow")
+		writeError(w, http.StatusBadGateway, api.ErrorCodeFor(err), "Failed to communicate with ServiceNow")
 	default:
-		writeError(w, http.StatusInternalServerError, "internal_error", "An internal error occurred")
+		writeError(w, http.StatusInternalServerError, api.ErrCodeInternal, "An internal error occurred")
 	}
 }
 
@@ -112,9 +196,9 @@ func writeJSON(w http.ResponseWriter, status int, data interface{}) {
 }
 
 // writeError writes an error response.
-func writeError(w http.ResponseWriter, status int, errorCode, message string) {
+func writeError(w http.ResponseWriter, status int, code api.ErrorCode, message string) {
 	writeJSON(w, status, &ErrorResponse{
-		Error:   errorCode,
+		Error:   string(code),
 		Message: message,
 	})
 }
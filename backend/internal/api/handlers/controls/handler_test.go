@@ -0,0 +1,138 @@
+package controls
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/controlcrud/backend/internal/config"
+	"github.com/controlcrud/backend/internal/domain/control"
+	"github.com/controlcrud/backend/internal/domain/controls"
+)
+
+// searchRepository implements control.Repository's Search over controls
+// belonging to several systems.
+type searchRepository struct {
+	control.Repository
+	entries []control.SearchEntry
+	params  control.SearchParams
+}
+
+func (m *searchRepository) Search(ctx context.Context, params control.SearchParams) (*control.SearchResult, error) {
+	m.params = params
+	result := &control.SearchResult{Controls: []control.SearchEntry{}, Page: params.Page, PageSize: params.PageSize}
+	for _, e := range m.entries {
+		if !strings.Contains(strings.ToLower(e.ControlID+" "+e.ControlName), strings.ToLower(params.Query)) {
+			continue
+		}
+		if params.ControlFamily != "" && e.ControlFamily != params.ControlFamily {
+			continue
+		}
+		result.Controls = append(result.Controls, e)
+	}
+	result.TotalCount = len(result.Controls)
+	result.TotalPages = 1
+	return result, nil
+}
+
+func searchEntry(systemID uuid.UUID, systemName, controlID, family string) control.SearchEntry {
+	var e control.SearchEntry
+	e.ID = uuid.New()
+	e.SystemID = systemID
+	e.SystemName = systemName
+	e.ControlID = controlID
+	e.ControlName = "Control " + controlID
+	e.ControlFamily = family
+	return e
+}
+
+func TestHandler_Search(t *testing.T) {
+	payroll, hr := uuid.New(), uuid.New()
+	repo := &searchRepository{entries: []control.SearchEntry{
+		searchEntry(payroll, "Payroll", "AC-1", "AC"),
+		searchEntry(hr, "HR", "AC-1", "AC"),
+		searchEntry(hr, "HR", "SC-7", "SC"),
+	}}
+	mux := http.NewServeMux()
+	NewHandler(nil, control.NewService(repo, nil, nil), config.PaginationDefaults{}).RegisterRoutes(mux)
+
+	tests := []struct {
+		name        string
+		query       string
+		wantStatus  int
+		wantSystems []string
+	}{
+		{"across systems", "q=AC-&page_size=10", http.StatusOK, []string{"Payroll", "HR"}},
+		{"family filter", "q=control&control_family=sc", http.StatusOK, []string{"HR"}},
+		{"query too short", "q=A", http.StatusBadRequest, nil},
+		{"query only whitespace", "q=%20%20%20", http.StatusBadRequest, nil},
+		{"query missing", "", http.StatusBadRequest, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/controls/search?"+tt.query, nil))
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, rec.Code, rec.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var resp SearchControlsResponse
+			if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if resp.Pagination.TotalCount != len(tt.wantSystems) || len(resp.Items) != len(tt.wantSystems) {
+				t.Fatalf("expected %d results, got %+v", len(tt.wantSystems), resp)
+			}
+			for i, item := range resp.Items {
+				if item.SystemName != tt.wantSystems[i] {
+					t.Errorf("result %d: expected system %q, got %q", i, tt.wantSystems[i], item.SystemName)
+				}
+				if item.SystemID == "" {
+					t.Errorf("result %d: expected a system_id", i)
+				}
+			}
+		})
+	}
+
+	if repo.params.ControlFamily != "SC" {
+		t.Errorf("expected family to be normalized, got %q", repo.params.ControlFamily)
+	}
+}
+
+func TestHandler_Search_ResolveRoles(t *testing.T) {
+	entry := searchEntry(uuid.New(), "HR", "AC-1", "AC")
+	entry.ResponsibleRole = "Security Operations"
+	repo := &searchRepository{entries: []control.SearchEntry{entry}}
+	mux := http.NewServeMux()
+	NewHandler(controls.NewService(nil), control.NewService(repo, nil, nil), config.PaginationDefaults{}).RegisterRoutes(mux)
+
+	for _, query := range []string{"q=AC-1", "q=AC-1&resolve_roles=true"} {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/controls/search?"+query, nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: expected status 200, got %d: %s", query, rec.Code, rec.Body.String())
+		}
+
+		var resp SearchControlsResponse
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		want := ""
+		if strings.Contains(query, "resolve_roles") {
+			want = "Security Operations"
+		}
+		if len(resp.Items) != 1 || resp.Items[0].ResolvedResponsibleRole != want {
+			t.Errorf("%s: expected resolved role %q, got %+v", query, want, resp.Items)
+		}
+	}
+}
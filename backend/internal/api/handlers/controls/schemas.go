@@ -1,6 +1,9 @@
 package controls
 
-import "github.com/controlcrud/backend/internal/domain/controls"
+import (
+	"github.com/controlcrud/backend/internal/domain/control"
+	"github.com/controlcrud/backend/internal/domain/controls"
+)
 
 // PolicyStatementDTO represents a policy statement in API responses.
 type PolicyStatementDTO struct {
@@ -76,3 +79,79 @@ func NewListPolicyStatementsResponse(result *controls.ListResult) *ListPolicySta
 		},
 	}
 }
+
+// UpdateStatusRequest represents the request body for updating a control's implementation status.
+type UpdateStatusRequest struct {
+	ImplementationStatus string `json:"implementation_status"`
+}
+
+// ControlDTO represents a local control in API responses.
+type ControlDTO struct {
+	ID                   string `json:"id"`
+	SystemID             string `json:"system_id"`
+	SNSysID              string `json:"sn_sys_id"`
+	ControlID            string `json:"control_id"`
+	ControlName          string `json:"control_name"`
+	ControlFamily        string `json:"control_family,omitempty"`
+	Description          string `json:"description,omitempty"`
+	ImplementationStatus string `json:"implementation_status"`
+	ResponsibleRole      string `json:"responsible_role,omitempty"`
+	UpdatedAt            string `json:"updated_at"`
+}
+
+// NewControlDTO creates a DTO from a local control.
+func NewControlDTO(c *control.Control) ControlDTO {
+	return ControlDTO{
+		ID:                   c.ID.String(),
+		SystemID:             c.SystemID.String(),
+		SNSysID:              c.SNSysID,
+		ControlID:            c.ControlID,
+		ControlName:          c.ControlName,
+		ControlFamily:        c.ControlFamily,
+		Description:          c.Description,
+		ImplementationStatus: c.ImplementationStatus,
+		ResponsibleRole:      c.ResponsibleRole,
+		UpdatedAt:            c.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+}
+
+// ControlSearchDTO represents a control found by a cross-system search.
+type ControlSearchDTO struct {
+	ControlDTO
+	SystemName     string `json:"system_name"`
+	StatementCount int    `json:"statement_count"`
+	ModifiedCount  int    `json:"modified_count"`
+
+	ResolvedResponsibleRole string `json:"resolved_responsible_role,omitempty"`
+}
+
+// SearchControlsResponse represents the response for searching controls.
+type SearchControlsResponse struct {
+	Items      []ControlSearchDTO `json:"items"`
+	Pagination PaginationDTO      `json:"pagination"`
+}
+
+// NewSearchControlsResponse creates a response from a control search result.
+func NewSearchControlsResponse(result *control.SearchResult) *SearchControlsResponse {
+	items := make([]ControlSearchDTO, len(result.Controls))
+	for i, entry := range result.Controls {
+		items[i] = ControlSearchDTO{
+			ControlDTO:     NewControlDTO(&entry.Control),
+			SystemName:     entry.SystemName,
+			StatementCount: entry.StatementCount,
+			ModifiedCount:  entry.ModifiedCount,
+
+			ResolvedResponsibleRole: entry.ResolvedResponsibleRole,
+		}
+	}
+
+	return &SearchControlsResponse{
+		Items: items,
+		Pagination: PaginationDTO{
+			Page:       result.Page,
+			PageSize:   result.PageSize,
+			TotalCount: result.TotalCount,
+			TotalPages: result.TotalPages,
+		},
+	}
+}
@@ -0,0 +1,161 @@
+// Package health provides the component-level health check endpoint.
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/controlcrud/backend/internal/api/middleware/requestid"
+	"github.com/controlcrud/backend/internal/domain/connection"
+	"github.com/controlcrud/backend/internal/infrastructure/servicenow"
+)
+
+// checkTimeout bounds the time spent on all component checks.
+const checkTimeout = 2 * time.Second
+
+// Pinger checks database connectivity.
+type Pinger interface {
+	PingContext(ctx context.Context) error
+}
+
+// ConnectionStatusProvider returns the stored status of a labelled ServiceNow
+// connection and the state of its circuit breaker.
+type ConnectionStatusProvider interface {
+	GetStatus(ctx context.Context, label string) (*connection.Status, error)
+	CircuitState(label string) servicenow.CircuitState
+}
+
+// PullJobCounter counts active pull jobs.
+type PullJobCounter interface {
+	CountActiveJobs(ctx context.Context) (int, error)
+}
+
+// PushJobCounter counts active push jobs.
+type PushJobCounter interface {
+	ActiveJobCount() int
+}
+
+// Handler handles health check requests.
+type Handler struct {
+	db          Pinger
+	connections ConnectionStatusProvider
+	pullJobs    PullJobCounter
+	pushJobs    PushJobCounter
+	logger      *slog.Logger
+}
+
+// NewHandler creates a new health handler.
+func NewHandler(
+	db Pinger,
+	connections ConnectionStatusProvider,
+	pullJobs PullJobCounter,
+	pushJobs PushJobCounter,
+	logger *slog.Logger,
+) *Handler {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &Handler{
+		db:          db,
+		connections: connections,
+		pullJobs:    pullJobs,
+		pushJobs:    pushJobs,
+		logger:      logger,
+	}
+}
+
+// RegisterRoutes registers the health route with the given mux.
+func (h *Handler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /health", h.Check)
+}
+
+// Check handles GET /health
+// It returns 200 when healthy, 206 when degraded and 503 when unhealthy.
+// ServiceNow health comes from the last stored connection test; no live
+// request is made. An open ServiceNow circuit breaker degrades the status.
+func (h *Handler) Check(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), checkTimeout)
+	defer cancel()
+
+	resp := Response{Status: StatusHealthy}
+
+	// Database
+	start := time.Now()
+	if err := h.db.PingContext(ctx); err != nil {
+		requestid.Logger(r.Context(), h.logger).Error("health check: database ping failed", "error", err)
+		resp.Components.Database.Status = ComponentError
+		resp.Status = StatusUnhealthy
+	} else {
+		resp.Components.Database.Status = ComponentOK
+	}
+	resp.Components.Database.LatencyMs = time.Since(start).Milliseconds()
+
+	// ServiceNow
+	resp.Components.ServiceNow = h.checkServiceNow(ctx, r)
+	if resp.Components.ServiceNow.Status == ComponentError {
+		resp.degrade()
+	}
+	circuit := h.connections.CircuitState(connection.DefaultLabel)
+	resp.ServiceNowCircuit = string(circuit)
+	if circuit != servicenow.CircuitClosed {
+		resp.degrade()
+	}
+
+	// Active jobs
+	pullCount, err := h.pullJobs.CountActiveJobs(ctx)
+	if err != nil {
+		requestid.Logger(r.Context(), h.logger).Error("health check: failed to count pull jobs", "error", err)
+		resp.degrade()
+	}
+	resp.Components.ActiveJobs = ActiveJobsStatus{
+		Pull: pullCount,
+		Push: h.pushJobs.ActiveJobCount(),
+	}
+
+	status := http.StatusOK
+	switch resp.Status {
+	case StatusDegraded:
+		status = http.StatusPartialContent
+	case StatusUnhealthy:
+		status = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// checkServiceNow maps the stored connection test result to a component status.
+func (h *Handler) checkServiceNow(ctx context.Context, r *http.Request) ServiceNowStatus {
+	status, err := h.connections.GetStatus(ctx, connection.DefaultLabel)
+	if err != nil {
+		requestid.Logger(r.Context(), h.logger).Error("health check: failed to get connection status", "error", err)
+		return ServiceNowStatus{Status: ComponentError, Message: "Failed to read connection status"}
+	}
+	if !status.IsConfigured {
+		return ServiceNowStatus{Status: ComponentUnconfigured}
+	}
+
+	result := ServiceNowStatus{LastTestAt: status.LastTestAt}
+	switch status.LastTestStatus {
+	case connection.StatusSuccess:
+		result.Status = ComponentOK
+	case connection.StatusFailure:
+		result.Status = ComponentError
+		result.Message = status.LastTestMessage
+	default:
+		// Configured but not tested yet
+		result.Status = ComponentUnknown
+	}
+	return result
+}
+
+// degrade lowers a healthy status to degraded; unhealthy stays unhealthy.
+func (r *Response) degrade() {
+	if r.Status == StatusHealthy {
+		r.Status = StatusDegraded
+	}
+}
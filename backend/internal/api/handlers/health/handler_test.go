@@ -0,0 +1,128 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/controlcrud/backend/internal/domain/connection"
+	"github.com/controlcrud/backend/internal/infrastructure/servicenow"
+)
+
+type mockPinger struct{ err error }
+
+func (m *mockPinger) PingContext(ctx context.Context) error { return m.err }
+
+type mockConnections struct {
+	status  *connection.Status
+	err     error
+	circuit servicenow.CircuitState
+}
+
+func (m *mockConnections) GetStatus(ctx context.Context, label string) (*connection.Status, error) {
+	return m.status, m.err
+}
+
+func (m *mockConnections) CircuitState(label string) servicenow.CircuitState {
+	if m.circuit == "" {
+		return servicenow.CircuitClosed
+	}
+	return m.circuit
+}
+
+type mockPullJobs struct {
+	count int
+	err   error
+}
+
+func (m *mockPullJobs) CountActiveJobs(ctx context.Context) (int, error) { return m.count, m.err }
+
+type mockPushJobs struct{ count int }
+
+func (m *mockPushJobs) ActiveJobCount() int { return m.count }
+
+func doCheck(t *testing.T, h *Handler) (*httptest.ResponseRecorder, Response) {
+	t.Helper()
+	mux := http.NewServeMux()
+	h.RegisterRoutes(mux)
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	var resp Response
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	return w, resp
+}
+
+func TestHandler_Check(t *testing.T) {
+	testedAt := time.Now().Add(-time.Hour)
+	tested := &connection.Status{IsConfigured: true, LastTestStatus: connection.StatusSuccess, LastTestAt: &testedAt}
+
+	tests := []struct {
+		name           string
+		dbErr          error
+		conn           *mockConnections
+		pullErr        error
+		wantCode       int
+		wantStatus     string
+		wantServiceNow string
+	}{
+		{"healthy", nil, &mockConnections{status: tested}, nil, http.StatusOK, StatusHealthy, ComponentOK},
+		{"unconfigured servicenow", nil, &mockConnections{status: &connection.Status{}}, nil, http.StatusOK, StatusHealthy, ComponentUnconfigured},
+		{"untested servicenow", nil, &mockConnections{status: &connection.Status{IsConfigured: true, LastTestStatus: connection.StatusPending}}, nil, http.StatusOK, StatusHealthy, ComponentUnknown},
+		{"failed servicenow test", nil, &mockConnections{status: &connection.Status{IsConfigured: true, LastTestStatus: connection.StatusFailure, LastTestMessage: "401"}}, nil, http.StatusPartialContent, StatusDegraded, ComponentError},
+		{"connection status error", nil, &mockConnections{err: errors.New("boom")}, nil, http.StatusPartialContent, StatusDegraded, ComponentError},
+		{"pull count error", nil, &mockConnections{status: tested}, errors.New("boom"), http.StatusPartialContent, StatusDegraded, ComponentOK},
+		{"database down", errors.New("connection refused"), &mockConnections{status: tested}, nil, http.StatusServiceUnavailable, StatusUnhealthy, ComponentOK},
+		{"circuit open", nil, &mockConnections{status: tested, circuit: servicenow.CircuitOpen}, nil, http.StatusPartialContent, StatusDegraded, ComponentOK},
+		{"database down and servicenow failing", errors.New("connection refused"), &mockConnections{status: &connection.Status{IsConfigured: true, LastTestStatus: connection.StatusFailure}}, nil, http.StatusServiceUnavailable, StatusUnhealthy, ComponentError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHandler(&mockPinger{err: tt.dbErr}, tt.conn, &mockPullJobs{count: 1, err: tt.pullErr}, &mockPushJobs{}, nil)
+			w, resp := doCheck(t, h)
+
+			if w.Code != tt.wantCode {
+				t.Errorf("expected status code %d, got %d", tt.wantCode, w.Code)
+			}
+			if resp.Status != tt.wantStatus {
+				t.Errorf("expected status %s, got %s", tt.wantStatus, resp.Status)
+			}
+			if resp.Components.ServiceNow.Status != tt.wantServiceNow {
+				t.Errorf("expected servicenow status %s, got %s", tt.wantServiceNow, resp.Components.ServiceNow.Status)
+			}
+		})
+	}
+}
+
+func TestHandler_Check_Components(t *testing.T) {
+	testedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	h := NewHandler(
+		&mockPinger{},
+		&mockConnections{status: &connection.Status{IsConfigured: true, LastTestStatus: connection.StatusSuccess, LastTestAt: &testedAt}},
+		&mockPullJobs{count: 1},
+		&mockPushJobs{count: 2},
+		nil,
+	)
+	_, resp := doCheck(t, h)
+
+	if resp.Components.Database.Status != ComponentOK {
+		t.Errorf("expected database ok, got %s", resp.Components.Database.Status)
+	}
+	if resp.Components.ServiceNow.LastTestAt == nil || !resp.Components.ServiceNow.LastTestAt.Equal(testedAt) {
+		t.Errorf("expected last_test_at %s, got %v", testedAt, resp.Components.ServiceNow.LastTestAt)
+	}
+	if resp.Components.ActiveJobs.Pull != 1 || resp.Components.ActiveJobs.Push != 2 {
+		t.Errorf("expected 1 pull and 2 push jobs, got %+v", resp.Components.ActiveJobs)
+	}
+	if resp.ServiceNowCircuit != string(servicenow.CircuitClosed) {
+		t.Errorf("expected servicenow_circuit closed, got %q", resp.ServiceNowCircuit)
+	}
+}
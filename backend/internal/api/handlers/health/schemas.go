@@ -0,0 +1,51 @@
+package health
+
+import "time"
+
+// Overall and component health states.
+const (
+	StatusHealthy   = "healthy"
+	StatusDegraded  = "degraded"
+	StatusUnhealthy = "unhealthy"
+
+	ComponentOK           = "ok"
+	ComponentError        = "error"
+	ComponentUnconfigured = "unconfigured"
+	ComponentUnknown      = "unknown"
+)
+
+// Response is the response for the health check endpoint.
+type Response struct {
+	Status     string     `json:"status"`
+	Components Components `json:"components"`
+
+	// ServiceNowCircuit is the circuit breaker state for the default
+	// connection: closed, open or half-open.
+	ServiceNowCircuit string `json:"servicenow_circuit"`
+}
+
+// Components holds the health of each dependency.
+type Components struct {
+	Database   DatabaseStatus   `json:"database"`
+	ServiceNow ServiceNowStatus `json:"servicenow"`
+	ActiveJobs ActiveJobsStatus `json:"active_jobs"`
+}
+
+// DatabaseStatus reports database connectivity.
+type DatabaseStatus struct {
+	Status    string `json:"status"`
+	LatencyMs int64  `json:"latency_ms"`
+}
+
+// ServiceNowStatus reports the cached result of the last connection test.
+type ServiceNowStatus struct {
+	Status     string     `json:"status"`
+	LastTestAt *time.Time `json:"last_test_at,omitempty"`
+	Message    string     `json:"message,omitempty"`
+}
+
+// ActiveJobsStatus reports the number of pending or running jobs.
+type ActiveJobsStatus struct {
+	Pull int `json:"pull"`
+	Push int `json:"push"`
+}
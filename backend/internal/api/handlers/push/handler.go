@@ -5,8 +5,12 @@ import (
 	"errors"
 	"log/slog"
 	"net/http"
+	"strconv"
 
 	"github.com/google/uuid"
+	"github.com/controlcrud/backend/internal/api"
+	"github.com/controlcrud/backend/internal/api/middleware/requestid"
+	"github.com/controlcrud/backend/internal/domain/connection"
 	"github.com/controlcrud/backend/internal/domain/push"
 )
 
@@ -35,29 +39,54 @@ func (h *Handler) RegisterRoutes(mux *http.ServeMux) {
 func (h *Handler) StartPush(w http.ResponseWriter, r *http.Request) {
 	var req StartPushRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.writeError(w, http.StatusBadRequest, "invalid_request", "Invalid JSON body")
+		h.writeError(w, http.StatusBadRequest, api.ErrCodeInvalidJSON, "Invalid JSON body")
 		return
 	}
 
 	if len(req.StatementIDs) == 0 {
-		h.writeError(w, http.StatusBadRequest, "invalid_request", "At least one statement ID is required")
+		h.writeError(w, http.StatusBadRequest, api.ErrCodeValidation, "At least one statement ID is required")
+		return
+	}
+
+	// ?dry_run=true validates the selection synchronously without pushing
+	if dryRun, err := strconv.ParseBool(r.URL.Query().Get("dry_run")); err == nil && dryRun {
+		result, err := h.service.DryRun(r.Context(), push.StartRequest{
+			StatementIDs: req.StatementIDs,
+		})
+		if err != nil {
+			requestid.Logger(r.Context(), h.logger).Error("failed to run push dry run", "error", err)
+			h.writeError(w, http.StatusInternalServerError, api.ErrorCodeFor(err), "Failed to validate push")
+			return
+		}
+		h.writeJSON(w, http.StatusOK, DryRunResponse{DryRun: result})
 		return
 	}
 
 	job, err := h.service.StartPush(r.Context(), push.StartRequest{
-		StatementIDs: req.StatementIDs,
+		StatementIDs:    req.StatementIDs,
+		ConnectionLabel: req.ConnectionLabel,
 	})
 	if err != nil {
 		switch {
+		case errors.Is(err, push.ErrStatementNotFound):
+			h.writeError(w, http.StatusNotFound, api.ErrorCodeFor(err), err.Error())
 		case errors.Is(err, push.ErrNoConnection):
-			h.writeError(w, http.StatusBadRequest, "no_connection", "No ServiceNow connection configured")
+			h.writeError(w, http.StatusBadRequest, api.ErrorCodeFor(err), "No ServiceNow connection configured")
+		case errors.Is(err, connection.ErrInvalidLabel):
+			h.writeError(w, http.StatusBadRequest, api.ErrorCodeFor(err), err.Error())
 		case errors.Is(err, push.ErrStatementNotModified):
-			h.writeError(w, http.StatusBadRequest, "not_modified", err.Error())
+			h.writeError(w, http.StatusBadRequest, api.ErrorCodeFor(err), err.Error())
 		case errors.Is(err, push.ErrStatementHasConflict):
-			h.writeError(w, http.StatusBadRequest, "has_conflict", err.Error())
+			h.writeError(w, http.StatusBadRequest, api.ErrorCodeFor(err), err.Error())
+		case errors.Is(err, push.ErrStatementNotApproved):
+			h.writeError(w, http.StatusBadRequest, api.ErrorCodeFor(err), err.Error())
+		case errors.Is(err, push.ErrStatementTypeNotPushable):
+			h.writeError(w, http.StatusBadRequest, api.ErrorCodeFor(err), err.Error())
+		case errors.Is(err, push.ErrStatementReadOnly):
+			h.writeError(w, http.StatusForbidden, api.ErrorCodeFor(err), err.Error())
 		default:
-			h.logger.Error("failed to start push", "error", err)
-			h.writeError(w, http.StatusInternalServerError, "internal_error", "Failed to start push")
+			requestid.Logger(r.Context(), h.logger).Error("failed to start push", "error", err)
+			h.writeError(w, http.StatusInternalServerError, api.ErrorCodeFor(err), "Failed to start push")
 		}
 		return
 	}
@@ -72,18 +101,18 @@ func (h *Handler) GetPushStatus(w http.ResponseWriter, r *http.Request) {
 	idStr := r.PathValue("id")
 	jobID, err := uuid.Parse(idStr)
 	if err != nil {
-		h.writeError(w, http.StatusBadRequest, "invalid_id", "Invalid job ID format")
+		h.writeError(w, http.StatusBadRequest, api.ErrCodeInvalidID, "Invalid job ID format")
 		return
 	}
 
 	job, err := h.service.GetJob(r.Context(), jobID)
 	if err != nil {
 		if errors.Is(err, push.ErrJobNotFound) {
-			h.writeError(w, http.StatusNotFound, "not_found", "Push job not found")
+			h.writeError(w, http.StatusNotFound, api.ErrorCodeFor(err), "Push job not found")
 			return
 		}
-		h.logger.Error("failed to get push job", "error", err)
-		h.writeError(w, http.StatusInternalServerError, "internal_error", "Failed to get push job")
+		requestid.Logger(r.Context(), h.logger).Error("failed to get push job", "error", err)
+		h.writeError(w, http.StatusInternalServerError, api.ErrorCodeFor(err), "Failed to get push job")
 		return
 	}
 
@@ -97,18 +126,18 @@ func (h *Handler) CancelPush(w http.ResponseWriter, r *http.Request) {
 	idStr := r.PathValue("id")
 	jobID, err := uuid.Parse(idStr)
 	if err != nil {
-		h.writeError(w, http.StatusBadRequest, "invalid_id", "Invalid job ID format")
+		h.writeError(w, http.StatusBadRequest, api.ErrCodeInvalidID, "Invalid job ID format")
 		return
 	}
 
 	err = h.service.CancelJob(r.Context(), jobID)
 	if err != nil {
 		if errors.Is(err, push.ErrJobNotFound) {
-			h.writeError(w, http.StatusNotFound, "not_found", "Push job not found")
+			h.writeError(w, http.StatusNotFound, api.ErrorCodeFor(err), "Push job not found")
 			return
 		}
-		h.logger.Error("failed to cancel push job", "error", err)
-		h.writeError(w, http.StatusInternalServerError, "internal_error", "Failed to cancel push job")
+		requestid.Logger(r.Context(), h.logger).Error("failed to cancel push job", "error", err)
+		h.writeError(w, http.StatusInternalServerError, api.ErrorCodeFor(err), "Failed to cancel push job")
 		return
 	}
 
@@ -124,6 +153,7 @@ func (h *Handler) toJobResponse(job *push.Job) JobResponse {
 			Success:     r.Success,
 			Error:       r.Error,
 			PushedAt:    r.PushedAt,
+			RetryCount:  r.RetryCount,
 		}
 	}
 
@@ -138,6 +168,9 @@ func (h *Handler) toJobResponse(job *push.Job) JobResponse {
 		StartedAt:   job.StartedAt,
 		CompletedAt: job.CompletedAt,
 		CreatedAt:   job.CreatedAt,
+
+		ConnectionLabel: job.ConnectionLabel,
+		Errors:          job.Errors,
 	}
 }
 
@@ -149,9 +182,9 @@ func (h *Handler) writeJSON(w http.ResponseWriter, status int, data interface{})
 }
 
 // writeError writes an error response.
-func (h *Handler) writeError(w http.ResponseWriter, status int, code, message string) {
+func (h *Handler) writeError(w http.ResponseWriter, status int, code api.ErrorCode, message string) {
 	h.writeJSON(w, status, ErrorResponse{
-		Error:   code,
+		Error:   string(code),
 		Message: message,
 	})
 }
@@ -4,11 +4,14 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/controlcrud/backend/internal/domain/push"
 )
 
 // StartPushRequest is the request to start a push job.
 type StartPushRequest struct {
-	StatementIDs []uuid.UUID `json:"statement_ids"`
+	StatementIDs    []uuid.UUID `json:"statement_ids"`
+	ConnectionLabel string      `json:"connection_label,omitempty"` // Defaults to "default"
 }
 
 // StartPushResponse is the response after starting a push job.
@@ -16,6 +19,11 @@ type StartPushResponse struct {
 	Job JobResponse `json:"job"`
 }
 
+// DryRunResponse is the response for a push dry run (?dry_run=true).
+type DryRunResponse struct {
+	DryRun *push.DryRunResult `json:"dry_run"`
+}
+
 // JobResponse represents a push job in API responses.
 type JobResponse struct {
 	ID          uuid.UUID              `json:"id"`
@@ -28,6 +36,9 @@ type JobResponse struct {
 	StartedAt   *time.Time             `json:"started_at,omitempty"`
 	CompletedAt *time.Time             `json:"completed_at,omitempty"`
 	CreatedAt   time.Time              `json:"created_at"`
+
+	ConnectionLabel string   `json:"connection_label"`
+	Errors          []string `json:"errors,omitempty"`
 }
 
 // StatementResultResp represents a push result for a single statement.
@@ -36,6 +47,7 @@ type StatementResultResp struct {
 	Success     bool       `json:"success"`
 	Error       *string    `json:"error,omitempty"`
 	PushedAt    *time.Time `json:"pushed_at,omitempty"`
+	RetryCount  int        `json:"retry_count"`
 }
 
 // PushStatusResponse is the response for getting push job status.
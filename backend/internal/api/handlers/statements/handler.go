@@ -1,30 +1,111 @@
 package statements
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
+	"io"
 	"log/slog"
+	"mime"
 	"net/http"
+	"path/filepath"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 
+	"github.com/controlcrud/backend/internal/api"
+	"github.com/controlcrud/backend/internal/api/middleware/auth"
+	"github.com/controlcrud/backend/internal/api/middleware/requestid"
+	"github.com/controlcrud/backend/internal/config"
 	"github.com/controlcrud/backend/internal/domain/statement"
+	"github.com/controlcrud/backend/internal/infrastructure/servicenow"
 )
 
+// maxAttachmentSize is the largest evidence file accepted for upload.
+const maxAttachmentSize = 10 << 20
+
+// multipartOverhead is the extra request body allowed for multipart framing
+// and other form fields around an attachment.
+const multipartOverhead = 1 << 20
+
+// attachmentDownloadTimeout bounds streaming an evidence file to the client.
+// Downloads are exempt from the write timeout, which would buffer them.
+const attachmentDownloadTimeout = 30 * time.Minute
+
+// allowedAttachmentTypes lists the MIME types accepted for evidence files.
+var allowedAttachmentTypes = map[string]bool{
+	"application/pdf":    true,
+	"image/png":          true,
+	"image/jpeg":         true,
+	"image/gif":          true,
+	"text/plain":         true,
+	"text/csv":           true,
+	"application/msword": true,
+	"application/vnd.openxmlformats-officedocument.wordprocessingml.document": true,
+	"application/vnd.ms-excel": true,
+	"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet": true,
+}
+
+// oleSignature starts legacy Word and Excel files, which
+// http.DetectContentType reports as application/octet-stream.
+var oleSignature = []byte("\xd0\xcf\x11\xe0\xa1\xb1\x1a\xe1")
+
+// containerTypes resolves sniffed container formats by file extension, as
+// the content alone only identifies the container.
+var containerTypes = map[string]map[string]string{
+	"application/zip": {
+		".docx": "application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+		".xlsx": "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	},
+	"application/x-ole-storage": {
+		".doc": "application/msword",
+		".xls": "application/vnd.ms-excel",
+	},
+}
+
+// detectAttachmentType returns the MIME type of an evidence file from its
+// first bytes, or "" when the content is not a recognised type. The file
+// name only tells apart CSV from plain text and formats sharing a
+// container.
+func detectAttachmentType(head []byte, fileName string) string {
+	detected, _, _ := mime.ParseMediaType(http.DetectContentType(head))
+	if bytes.HasPrefix(head, oleSignature) {
+		detected = "application/x-ole-storage"
+	}
+
+	ext := strings.ToLower(filepath.Ext(fileName))
+	if detected == "text/plain" && ext == ".csv" {
+		return "text/csv"
+	}
+	if byExt, ok := containerTypes[detected]; ok {
+		return byExt[ext]
+	}
+	return detected
+}
+
 // Handler handles statement-related HTTP requests.
 type Handler struct {
-	stmtService *statement.Service
-	logger      *slog.Logger
+	stmtService     *statement.Service
+	pagination      config.PaginationDefaults
+	requireReviewer func(http.Handler) http.Handler
+	logger          *slog.Logger
 }
 
-// NewHandler creates a new statement handler.
-func NewHandler(stmtService *statement.Service, logger *slog.Logger) *Handler {
+// NewHandler creates a new statement handler. requireReviewer wraps the
+// approve and reject routes and must reject callers without the reviewer
+// role.
+func NewHandler(stmtService *statement.Service, pagination config.PaginationDefaults, requireReviewer func(http.Handler) http.Handler, logger *slog.Logger) *Handler {
 	if logger == nil {
 		logger = slog.Default()
 	}
 	return &Handler{
-		stmtService: stmtService,
-		logger:      logger,
+		stmtService:     stmtService,
+		pagination:      pagination,
+		requireReviewer: requireReviewer,
+		logger:          logger,
 	}
 }
 
@@ -34,10 +115,33 @@ func (h *Handler) RegisterRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("GET /api/v1/statements", h.ListStatements)
 	mux.HandleFunc("GET /api/v1/statements/modified", h.ListModified)
 	mux.HandleFunc("GET /api/v1/statements/conflicts", h.ListConflicts)
+	mux.HandleFunc("DELETE /api/v1/statements/bulk", h.BulkDeleteStatements)
 	mux.HandleFunc("GET /api/v1/statements/{id}", h.GetStatement)
 	mux.HandleFunc("PUT /api/v1/statements/{id}", h.UpdateStatement)
 	mux.HandleFunc("POST /api/v1/statements/{id}/resolve", h.ResolveConflict)
 	mux.HandleFunc("POST /api/v1/statements/{id}/revert", h.RevertToRemote)
+	mux.HandleFunc("GET /api/v1/statements/{id}/revert-preview", h.PreviewRevert)
+	mux.HandleFunc("GET /api/v1/statements/{id}/freshness", h.GetFreshness)
+	mux.HandleFunc("POST /api/v1/statements/{id}/re-pull", h.RePullStatement)
+	mux.HandleFunc("GET /api/v1/statements/{id}/readability", h.GetReadability)
+	mux.HandleFunc("GET /api/v1/statements/{id}/similar", h.ListSimilar)
+	mux.HandleFunc("POST /api/v1/statements/{id}/attachments", h.UploadAttachment)
+	mux.HandleFunc("GET /api/v1/statements/{id}/attachments/{attachment_id}", h.DownloadAttachment)
+	mux.HandleFunc("POST /api/v1/statements/{id}/tags", h.AddTags)
+	mux.HandleFunc("DELETE /api/v1/statements/{id}/tags/{tag}", h.RemoveTag)
+	mux.HandleFunc("POST /api/v1/statements/from-template", h.ApplyTemplate)
+
+	// Review workflow
+	mux.HandleFunc("POST /api/v1/statements/{id}/approve", h.reviewerOnly(h.ApproveStatement))
+	mux.HandleFunc("POST /api/v1/statements/{id}/reject", h.reviewerOnly(h.RejectStatement))
+
+	// Statement types
+	mux.HandleFunc("GET /api/v1/statement-types", h.ListStatementTypes)
+	mux.HandleFunc("POST /api/v1/statement-types", h.CreateStatementType)
+	mux.HandleFunc("DELETE /api/v1/statement-types/{name}", h.DeleteStatementType)
+
+	// Statement templates
+	mux.HandleFunc("POST /api/v1/statement-templates", h.CreateStatementTemplate)
 }
 
 // ListStatements returns statements with pagination. Accepts control_id OR system_id filter.
@@ -55,14 +159,14 @@ func (h *Handler) ListStatements(w http.ResponseWriter, r *http.Request) {
 	systemIDStr := r.URL.Query().Get("system_id")
 
 	if controlIDStr == "" && systemIDStr == "" {
-		h.writeError(w, http.StatusBadRequest, "Either control_id or system_id is required")
+		h.writeError(w, http.StatusBadRequest, api.ErrCodeValidation, "Either control_id or system_id is required")
 		return
 	}
 
 	if controlIDStr != "" {
 		controlID, err := uuid.Parse(controlIDStr)
 		if err != nil {
-			h.writeError(w, http.StatusBadRequest, "Invalid control_id format")
+			h.writeError(w, http.StatusBadRequest, api.ErrCodeInvalidID, "Invalid control_id format")
 			return
 		}
 		params.ControlID = controlID
@@ -71,7 +175,7 @@ func (h *Handler) ListStatements(w http.ResponseWriter, r *http.Request) {
 	if systemIDStr != "" {
 		systemID, err := uuid.Parse(systemIDStr)
 		if err != nil {
-			h.writeError(w, http.StatusBadRequest, "Invalid system_id format")
+			h.writeError(w, http.StatusBadRequest, api.ErrCodeInvalidID, "Invalid system_id format")
 			return
 		}
 		params.SystemID = systemID
@@ -81,39 +185,36 @@ func (h *Handler) ListStatements(w http.ResponseWriter, r *http.Request) {
 		params.SyncStatus = statement.SyncStatus(syncStatus)
 	}
 
+	// Tags may be repeated or comma-separated
+	for _, tags := range r.URL.Query()["tags"] {
+		for _, tag := range strings.Split(tags, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				params.Tags = append(params.Tags, tag)
+			}
+		}
+	}
+
 	if page := r.URL.Query().Get("page"); page != "" {
 		if p, err := strconv.Atoi(page); err == nil && p > 0 {
 			params.Page = p
 		}
 	}
 
-	if pageSize := r.URL.Query().Get("page_size"); pageSize != "" {
-		if ps, err := strconv.Atoi(pageSize); err == nil && ps > 0 {
-			params.PageSize = ps
-		}
-	}
+	requestedPageSize, _ := strconv.Atoi(r.URL.Query().Get("page_size"))
+	params.PageSize = h.pagination.StatementsPageSize(requestedPageSize)
 
 	result, err := h.stmtService.ListByControl(ctx, params)
 	if err != nil {
-		h.logger.Error("failed to list statements", "error", err)
-		h.writeError(w, http.StatusInternalServerError, "Failed to list statements")
+		if errors.Is(err, statement.ErrInvalidInput) || errors.Is(err, statement.ErrInvalidTag) {
+			h.writeError(w, http.StatusBadRequest, api.ErrorCodeFor(err), err.Error())
+			return
+		}
+		requestid.Logger(r.Context(), h.logger).Error("failed to list statements", "error", err)
+		h.writeError(w, http.StatusInternalServerError, api.ErrorCodeFor(err), "Failed to list statements")
 		return
 	}
 
-	// Transform to response
-	response := ListStatementsResponse{
-		Statements: make([]StatementResponse, 0, len(result.Statements)),
-		TotalCount: result.TotalCount,
-		Page:       result.Page,
-		PageSize:   result.PageSize,
-		TotalPages: result.TotalPages,
-	}
-
-	for _, s := range result.Statements {
-		response.Statements = append(response.Statements, h.transformStatement(&s))
-	}
-
-	h.writeJSON(w, http.StatusOK, response)
+	h.writeJSON(w, http.StatusOK, h.transformListResult(result))
 }
 
 // GetStatement returns a single statement by ID.
@@ -122,24 +223,24 @@ func (h *Handler) GetStatement(w http.ResponseWriter, r *http.Request) {
 
 	idStr := r.PathValue("id")
 	if idStr == "" {
-		h.writeError(w, http.StatusBadRequest, "Statement ID is required")
+		h.writeError(w, http.StatusBadRequest, api.ErrCodeInvalidID, "Statement ID is required")
 		return
 	}
 
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		h.writeError(w, http.StatusBadRequest, "Invalid statement ID format")
+		h.writeError(w, http.StatusBadRequest, api.ErrCodeInvalidID, "Invalid statement ID format")
 		return
 	}
 
 	stmt, err := h.stmtService.GetByID(ctx, id)
 	if err != nil {
-		h.logger.Error("failed to get statement", "error", err, "id", idStr)
+		requestid.Logger(r.Context(), h.logger).Error("failed to get statement", "error", err, "id", idStr)
 		if err == statement.ErrNotFound {
-			h.writeError(w, http.StatusNotFound, "Statement not found")
+			h.writeError(w, http.StatusNotFound, api.ErrorCodeFor(err), "Statement not found")
 			return
 		}
-		h.writeError(w, http.StatusInternalServerError, "Failed to get statement")
+		h.writeError(w, http.StatusInternalServerError, api.ErrorCodeFor(err), "Failed to get statement")
 		return
 	}
 
@@ -152,83 +253,135 @@ func (h *Handler) UpdateStatement(w http.ResponseWriter, r *http.Request) {
 
 	idStr := r.PathValue("id")
 	if idStr == "" {
-		h.writeError(w, http.StatusBadRequest, "Statement ID is required")
+		h.writeError(w, http.StatusBadRequest, api.ErrCodeInvalidID, "Statement ID is required")
 		return
 	}
 
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		h.writeError(w, http.StatusBadRequest, "Invalid statement ID format")
+		h.writeError(w, http.StatusBadRequest, api.ErrCodeInvalidID, "Invalid statement ID format")
 		return
 	}
 
 	var req UpdateStatementRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.writeError(w, http.StatusBadRequest, "Invalid request body")
+		h.writeError(w, http.StatusBadRequest, api.ErrCodeInvalidJSON, "Invalid request body")
 		return
 	}
 
-	stmt, err := h.stmtService.UpdateLocal(ctx, statement.UpdateInput{
+	// Get the editor's identity from context (set by auth middleware)
+	input := statement.UpdateInput{
 		ID:           id,
 		LocalContent: req.LocalContent,
-	})
+	}
+	if user := auth.FromContext(ctx); user != nil {
+		input.ModifiedBy = user.ID
+		input.ModifiedByEmail = user.Email
+	}
+
+	stmt, err := h.stmtService.UpdateLocal(ctx, input)
 	if err != nil {
-		h.logger.Error("failed to update statement", "error", err, "id", idStr)
+		requestid.Logger(r.Context(), h.logger).Error("failed to update statement", "error", err, "id", idStr)
 		if err == statement.ErrNotFound {
-			h.writeError(w, http.StatusNotFound, "Statement not found")
+			h.writeError(w, http.StatusNotFound, api.ErrorCodeFor(err), "Statement not found")
+			return
+		}
+		if errors.Is(err, statement.ErrContentTooShort) || errors.Is(err, statement.ErrContentTooLong) {
+			h.writeError(w, http.StatusUnprocessableEntity, api.ErrorCodeFor(err), err.Error())
+			return
+		}
+		if errors.Is(err, statement.ErrInvalidInput) {
+			h.writeError(w, http.StatusBadRequest, api.ErrorCodeFor(err), err.Error())
+			return
+		}
+		if errors.Is(err, statement.ErrSystemReadOnly) {
+			h.writeError(w, http.StatusForbidden, api.ErrorCodeFor(err), err.Error())
 			return
 		}
-		h.writeError(w, http.StatusInternalServerError, "Failed to update statement")
+		h.writeError(w, http.StatusInternalServerError, api.ErrorCodeFor(err), "Failed to update statement")
 		return
 	}
 
 	h.writeJSON(w, http.StatusOK, h.transformStatement(stmt))
 }
 
-// ListModified returns all statements with local modifications.
+// ListModified returns a page of statements with local modifications.
+// Accepts optional system_id, page and page_size query parameters.
 func (h *Handler) ListModified(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
+	params, ok := h.parseModifiedListParams(w, r)
+	if !ok {
+		return
+	}
 
-	stmts, err := h.stmtService.ListModified(ctx)
+	result, err := h.stmtService.ListModified(r.Context(), params)
 	if err != nil {
-		h.logger.Error("failed to list modified statements", "error", err)
-		h.writeError(w, http.StatusInternalServerError, "Failed to list modified statements")
+		requestid.Logger(r.Context(), h.logger).Error("failed to list modified statements", "error", err)
+		h.writeError(w, http.StatusInternalServerError, api.ErrorCodeFor(err), "Failed to list modified statements")
 		return
 	}
 
-	response := ModifiedStatementsResponse{
-		Statements: make([]StatementResponse, 0, len(stmts)),
-		Count:      len(stmts),
+	h.writeJSON(w, http.StatusOK, h.transformListResult(result))
+}
+
+// ListConflicts returns a page of statements with sync conflicts.
+// Accepts optional system_id, page and page_size query parameters.
+func (h *Handler) ListConflicts(w http.ResponseWriter, r *http.Request) {
+	params, ok := h.parseModifiedListParams(w, r)
+	if !ok {
+		return
 	}
 
-	for _, s := range stmts {
-		response.Statements = append(response.Statements, h.transformStatement(&s))
+	result, err := h.stmtService.ListConflicts(r.Context(), params)
+	if err != nil {
+		requestid.Logger(r.Context(), h.logger).Error("failed to list conflict statements", "error", err)
+		h.writeError(w, http.StatusInternalServerError, api.ErrorCodeFor(err), "Failed to list conflict statements")
+		return
 	}
 
-	h.writeJSON(w, http.StatusOK, response)
+	h.writeJSON(w, http.StatusOK, h.transformListResult(result))
 }
 
-// ListConflicts returns all statements with sync conflicts.
-func (h *Handler) ListConflicts(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
+// parseModifiedListParams reads the system_id, page and page_size query
+// parameters, writing a 400 response and returning false if system_id is invalid.
+func (h *Handler) parseModifiedListParams(w http.ResponseWriter, r *http.Request) (statement.ModifiedListParams, bool) {
+	var params statement.ModifiedListParams
 
-	stmts, err := h.stmtService.ListConflicts(ctx)
-	if err != nil {
-		h.logger.Error("failed to list conflict statements", "error", err)
-		h.writeError(w, http.StatusInternalServerError, "Failed to list conflict statements")
-		return
+	if systemIDStr := r.URL.Query().Get("system_id"); systemIDStr != "" {
+		systemID, err := uuid.Parse(systemIDStr)
+		if err != nil {
+			h.writeError(w, http.StatusBadRequest, api.ErrCodeInvalidID, "Invalid system_id format")
+			return params, false
+		}
+		params.SystemID = &systemID
+	}
+
+	if page := r.URL.Query().Get("page"); page != "" {
+		if p, err := strconv.Atoi(page); err == nil && p > 0 {
+			params.Page = p
+		}
 	}
 
-	response := ConflictStatementsResponse{
-		Statements: make([]StatementResponse, 0, len(stmts)),
-		Count:      len(stmts),
+	requestedPageSize, _ := strconv.Atoi(r.URL.Query().Get("page_size"))
+	params.PageSize = h.pagination.StatementsPageSize(requestedPageSize)
+
+	return params, true
+}
+
+// transformListResult converts a page of statements to its API response.
+func (h *Handler) transformListResult(result *statement.ListResult) ListStatementsResponse {
+	response := ListStatementsResponse{
+		Statements: make([]StatementResponse, 0, len(result.Statements)),
+		TotalCount: result.TotalCount,
+		Page:       result.Page,
+		PageSize:   result.PageSize,
+		TotalPages: result.TotalPages,
 	}
 
-	for _, s := range stmts {
+	for _, s := range result.Statements {
 		response.Statements = append(response.Statements, h.transformStatement(&s))
 	}
 
-	h.writeJSON(w, http.StatusOK, response)
+	return response
 }
 
 // ResolveConflict resolves a sync conflict on a statement.
@@ -237,19 +390,19 @@ func (h *Handler) ResolveConflict(w http.ResponseWriter, r *http.Request) {
 
 	idStr := r.PathValue("id")
 	if idStr == "" {
-		h.writeError(w, http.StatusBadRequest, "Statement ID is required")
+		h.writeError(w, http.StatusBadRequest, api.ErrCodeInvalidID, "Statement ID is required")
 		return
 	}
 
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		h.writeError(w, http.StatusBadRequest, "Invalid statement ID format")
+		h.writeError(w, http.StatusBadRequest, api.ErrCodeInvalidID, "Invalid statement ID format")
 		return
 	}
 
 	var req ResolveConflictRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.writeError(w, http.StatusBadRequest, "Invalid request body")
+		h.writeError(w, http.StatusBadRequest, api.ErrCodeInvalidJSON, "Invalid request body")
 		return
 	}
 
@@ -263,7 +416,7 @@ func (h *Handler) ResolveConflict(w http.ResponseWriter, r *http.Request) {
 	case "merge":
 		resolution = statement.ConflictResolutionMerge
 	default:
-		h.writeError(w, http.StatusBadRequest, "Invalid resolution type. Use: keep_local, keep_remote, or merge")
+		h.writeError(w, http.StatusBadRequest, api.ErrCodeValidation, "Invalid resolution type. Use: keep_local, keep_remote, or merge")
 		return
 	}
 
@@ -273,12 +426,12 @@ func (h *Handler) ResolveConflict(w http.ResponseWriter, r *http.Request) {
 		MergedContent: req.MergedContent,
 	})
 	if err != nil {
-		h.logger.Error("failed to resolve conflict", "error", err, "id", idStr)
+		requestid.Logger(r.Context(), h.logger).Error("failed to resolve conflict", "error", err, "id", idStr)
 		if err == statement.ErrNotFound {
-			h.writeError(w, http.StatusNotFound, "Statement not found")
+			h.writeError(w, http.StatusNotFound, api.ErrorCodeFor(err), "Statement not found")
 			return
 		}
-		h.writeError(w, http.StatusBadRequest, err.Error())
+		h.writeError(w, http.StatusBadRequest, api.ErrCodeValidation, err.Error())
 		return
 	}
 
@@ -291,24 +444,672 @@ func (h *Handler) RevertToRemote(w http.ResponseWriter, r *http.Request) {
 
 	idStr := r.PathValue("id")
 	if idStr == "" {
-		h.writeError(w, http.StatusBadRequest, "Statement ID is required")
+		h.writeError(w, http.StatusBadRequest, api.ErrCodeInvalidID, "Statement ID is required")
 		return
 	}
 
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		h.writeError(w, http.StatusBadRequest, "Invalid statement ID format")
+		h.writeError(w, http.StatusBadRequest, api.ErrCodeInvalidID, "Invalid statement ID format")
 		return
 	}
 
 	stmt, err := h.stmtService.RevertToRemote(ctx, id)
 	if err != nil {
-		h.logger.Error("failed to revert statement", "error", err, "id", idStr)
+		requestid.Logger(r.Context(), h.logger).Error("failed to revert statement", "error", err, "id", idStr)
 		if err == statement.ErrNotFound {
-			h.writeError(w, http.StatusNotFound, "Statement not found")
+			h.writeError(w, http.StatusNotFound, api.ErrorCodeFor(err), "Statement not found")
+			return
+		}
+		h.writeError(w, http.StatusInternalServerError, api.ErrorCodeFor(err), "Failed to revert statement")
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, h.transformStatement(stmt))
+}
+
+// PreviewRevert handles GET /api/v1/statements/{id}/revert-preview
+// It reports what reverting to the remote content would discard without
+// modifying the statement.
+func (h *Handler) PreviewRevert(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	idStr := r.PathValue("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, api.ErrCodeInvalidID, "Invalid statement ID format")
+		return
+	}
+
+	preview, err := h.stmtService.PreviewRevert(ctx, id)
+	if err != nil {
+		if err == statement.ErrNotFound {
+			h.writeError(w, http.StatusNotFound, api.ErrorCodeFor(err), "Statement not found")
+			return
+		}
+		requestid.Logger(r.Context(), h.logger).Error("failed to preview statement revert", "error", err, "id", idStr)
+		h.writeError(w, http.StatusInternalServerError, api.ErrorCodeFor(err), "Failed to preview revert")
+		return
+	}
+
+	if preview.NoChanges {
+		h.writeJSON(w, http.StatusOK, RevertPreviewNoChangesResponse{NoChanges: true})
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, RevertPreviewResponse{
+		CurrentLocalContent: preview.CurrentLocalContent,
+		RemoteContent:       preview.RemoteContent,
+		CharsLost:           preview.CharsLost,
+		WordsLost:           preview.WordsLost,
+	})
+}
+
+// GetFreshness reports whether the statement's ServiceNow record has been
+// updated since the last pull.
+func (h *Handler) GetFreshness(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	idStr := r.PathValue("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, api.ErrCodeInvalidID, "Invalid statement ID format")
+		return
+	}
+
+	freshness, err := h.stmtService.CheckFreshness(ctx, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, statement.ErrNotFound):
+			h.writeError(w, http.StatusNotFound, api.ErrorCodeFor(err), "Statement not found")
+		case errors.Is(err, statement.ErrNotInServiceNow):
+			h.writeError(w, http.StatusConflict, api.ErrorCodeFor(err), "Statement has no ServiceNow record")
+		default:
+			requestid.Logger(ctx, h.logger).Error("failed to check statement freshness", "error", err, "id", idStr)
+			h.writeError(w, http.StatusInternalServerError, api.ErrorCodeFor(err), "Failed to check freshness")
+		}
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, FreshnessResponse{
+		IsStale:          freshness.IsStale,
+		SNUpdatedOn:      freshness.SNUpdatedOn,
+		LocalSNUpdatedOn: freshness.LocalSNUpdatedOn,
+		Error:            freshness.Error,
+	})
+}
+
+// RePullStatement handles POST /api/v1/statements/{id}/re-pull
+// It refreshes the statement from its ServiceNow record and returns it,
+// in conflict if the remote content changed under local modifications.
+func (h *Handler) RePullStatement(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	idStr := r.PathValue("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, api.ErrCodeInvalidID, "Invalid statement ID format")
+		return
+	}
+
+	stmt, err := h.stmtService.RePull(ctx, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, statement.ErrNotFound):
+			h.writeError(w, http.StatusNotFound, api.ErrorCodeFor(err), "Statement not found")
+		case errors.Is(err, statement.ErrNotInServiceNow):
+			h.writeError(w, http.StatusConflict, api.ErrorCodeFor(err), "Statement has no ServiceNow record")
+		case errors.Is(err, servicenow.ErrAuthFailed):
+			h.writeError(w, http.StatusUnauthorized, api.ErrorCodeFor(err), "ServiceNow authentication failed. Please check your credentials.")
+		case errors.Is(err, statement.ErrRePullFailed):
+			requestid.Logger(ctx, h.logger).Error("failed to re-pull statement", "error", err, "id", idStr)
+			h.writeError(w, http.StatusBadGateway, api.ErrorCodeFor(err), "Failed to fetch statement from ServiceNow")
+		default:
+			requestid.Logger(ctx, h.logger).Error("failed to re-pull statement", "error", err, "id", idStr)
+			h.writeError(w, http.StatusInternalServerError, api.ErrorCodeFor(err), "Failed to re-pull statement")
+		}
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, h.transformStatement(stmt))
+}
+
+// GetReadability returns the Flesch reading-ease score of the statement's
+// current content.
+func (h *Handler) GetReadability(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	idStr := r.PathValue("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, api.ErrCodeInvalidID, "Invalid statement ID format")
+		return
+	}
+
+	readability, err := h.stmtService.GetReadability(ctx, id)
+	if err != nil {
+		if errors.Is(err, statement.ErrNotFound) {
+			h.writeError(w, http.StatusNotFound, api.ErrorCodeFor(err), "Statement not found")
+			return
+		}
+		requestid.Logger(ctx, h.logger).Error("failed to score statement readability", "error", err, "id", idStr)
+		h.writeError(w, http.StatusInternalServerError, api.ErrorCodeFor(err), "Failed to score readability")
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, ReadabilityResponse{
+		Score:         readability.Score,
+		GradeLevel:    readability.GradeLevel,
+		WordCount:     readability.WordCount,
+		SentenceCount: readability.SentenceCount,
+	})
+}
+
+// ListSimilar returns the statements of the same control whose content
+// resembles the statement's, most similar first. The optional threshold
+// query parameter sets the lowest similarity score returned.
+func (h *Handler) ListSimilar(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	idStr := r.PathValue("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, api.ErrCodeInvalidID, "Invalid statement ID format")
+		return
+	}
+
+	threshold := statement.DefaultSimilarityThreshold
+	if raw := r.URL.Query().Get("threshold"); raw != "" {
+		threshold, err = strconv.ParseFloat(raw, 64)
+		if err != nil {
+			h.writeError(w, http.StatusBadRequest, api.ErrCodeValidation, "threshold must be a number between 0 and 1")
+			return
+		}
+	}
+
+	similar, err := h.stmtService.FindSimilar(ctx, id, threshold)
+	if err != nil {
+		switch {
+		case errors.Is(err, statement.ErrInvalidInput):
+			h.writeError(w, http.StatusBadRequest, api.ErrorCodeFor(err), err.Error())
+		case errors.Is(err, statement.ErrNotFound):
+			h.writeError(w, http.StatusNotFound, api.ErrorCodeFor(err), "Statement not found")
+		default:
+			requestid.Logger(ctx, h.logger).Error("failed to find similar statements", "error", err, "id", idStr)
+			h.writeError(w, http.StatusInternalServerError, api.ErrorCodeFor(err), "Failed to find similar statements")
+		}
+		return
+	}
+
+	response := make([]SimilarStatementResponse, len(similar))
+	for i, s := range similar {
+		response[i] = SimilarStatementResponse{
+			StatementID:     s.StatementID,
+			SimilarityScore: s.Score,
+			Preview:         s.Preview,
+		}
+	}
+	h.writeJSON(w, http.StatusOK, response)
+}
+
+// reviewerOnly wraps fn with the reviewer role check.
+func (h *Handler) reviewerOnly(fn http.HandlerFunc) http.HandlerFunc {
+	return h.requireReviewer(fn).ServeHTTP
+}
+
+// ApproveStatement approves a statement's local changes for push.
+func (h *Handler) ApproveStatement(w http.ResponseWriter, r *http.Request) {
+	h.reviewStatement(w, r, h.stmtService.Approve)
+}
+
+// RejectStatement rejects a statement's local changes.
+func (h *Handler) RejectStatement(w http.ResponseWriter, r *http.Request) {
+	h.reviewStatement(w, r, h.stmtService.Reject)
+}
+
+// reviewStatement handles the shared request flow for approve and reject.
+func (h *Handler) reviewStatement(
+	w http.ResponseWriter,
+	r *http.Request,
+	review func(ctx context.Context, id uuid.UUID, reviewedBy *uuid.UUID, reviewerEmail, comment string) (*statement.Statement, error),
+) {
+	ctx := r.Context()
+
+	idStr := r.PathValue("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, api.ErrCodeInvalidID, "Invalid statement ID format")
+		return
+	}
+
+	// Body is optional
+	var req ReviewStatementRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+			h.writeError(w, http.StatusBadRequest, api.ErrCodeInvalidJSON, "Invalid request body")
 			return
 		}
-		h.writeError(w, http.StatusInternalServerError, "Failed to revert statement")
+	}
+
+	// Get the reviewer's identity from context (set by auth middleware)
+	var reviewedBy *uuid.UUID
+	var reviewerEmail string
+	if user := auth.FromContext(ctx); user != nil {
+		reviewedBy = user.ID
+		reviewerEmail = user.Email
+	}
+
+	stmt, err := review(ctx, id, reviewedBy, reviewerEmail, req.Comment)
+	if err != nil {
+		requestid.Logger(r.Context(), h.logger).Error("failed to review statement", "error", err, "id", idStr)
+		switch err {
+		case statement.ErrNotFound:
+			h.writeError(w, http.StatusNotFound, api.ErrorCodeFor(err), "Statement not found")
+		case statement.ErrReviewDisabled:
+			h.writeError(w, http.StatusConflict, api.ErrorCodeFor(err), "Statement review is not enabled")
+		case statement.ErrNotReviewable:
+			h.writeError(w, http.StatusConflict, api.ErrorCodeFor(err), "Statement has no local changes to review")
+		case statement.ErrSelfReview:
+			h.writeError(w, http.StatusForbidden, api.ErrorCodeFor(err), "Statement changes cannot be reviewed by their author")
+		case statement.ErrReviewerRequired:
+			h.writeError(w, http.StatusForbidden, api.ErrorCodeFor(err), "The reviewer's token carries no user ID or email")
+		default:
+			h.writeError(w, http.StatusInternalServerError, api.ErrorCodeFor(err), "Failed to review statement")
+		}
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, h.transformStatement(stmt))
+}
+
+// ListStatementTypes returns all registered statement types.
+func (h *Handler) ListStatementTypes(w http.ResponseWriter, r *http.Request) {
+	types, err := h.stmtService.ListTypes(r.Context())
+	if err != nil {
+		requestid.Logger(r.Context(), h.logger).Error("failed to list statement types", "error", err)
+		h.writeError(w, http.StatusInternalServerError, api.ErrorCodeFor(err), "Failed to list statement types")
+		return
+	}
+
+	response := ListStatementTypesResponse{
+		Types: make([]StatementTypeResponse, 0, len(types)),
+	}
+	for i := range types {
+		response.Types = append(response.Types, h.transformStatementType(&types[i]))
+	}
+
+	h.writeJSON(w, http.StatusOK, response)
+}
+
+// CreateStatementType registers a new statement type.
+func (h *Handler) CreateStatementType(w http.ResponseWriter, r *http.Request) {
+	var req CreateStatementTypeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, http.StatusBadRequest, api.ErrCodeInvalidJSON, "Invalid request body")
+		return
+	}
+
+	t, err := h.stmtService.CreateType(r.Context(), statement.CreateTypeInput{
+		Name:        req.Name,
+		Description: req.Description,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, statement.ErrInvalidInput):
+			h.writeError(w, http.StatusBadRequest, api.ErrorCodeFor(err), err.Error())
+		case errors.Is(err, statement.ErrStatementTypeExists):
+			h.writeError(w, http.StatusConflict, api.ErrorCodeFor(err), "Statement type already exists")
+		default:
+			requestid.Logger(r.Context(), h.logger).Error("failed to create statement type", "error", err, "name", req.Name)
+			h.writeError(w, http.StatusInternalServerError, api.ErrorCodeFor(err), "Failed to create statement type")
+		}
+		return
+	}
+
+	h.writeJSON(w, http.StatusCreated, h.transformStatementType(t))
+}
+
+// DeleteStatementType removes a statement type that is not in use.
+func (h *Handler) DeleteStatementType(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+
+	if err := h.stmtService.DeleteType(r.Context(), name); err != nil {
+		switch {
+		case errors.Is(err, statement.ErrStatementTypeNotFound):
+			h.writeError(w, http.StatusNotFound, api.ErrorCodeFor(err), "Statement type not found")
+		case errors.Is(err, statement.ErrStatementTypeInUse):
+			h.writeError(w, http.StatusConflict, api.ErrorCodeFor(err), "Statement type is used by existing statements")
+		default:
+			requestid.Logger(r.Context(), h.logger).Error("failed to delete statement type", "error", err, "name", name)
+			h.writeError(w, http.StatusInternalServerError, api.ErrorCodeFor(err), "Failed to delete statement type")
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// AddTags labels a statement with the tags in the request body.
+func (h *Handler) AddTags(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, api.ErrCodeInvalidID, "Invalid statement ID format")
+		return
+	}
+
+	var req AddTagsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, http.StatusBadRequest, api.ErrCodeInvalidJSON, "Invalid request body")
+		return
+	}
+
+	var createdBy *uuid.UUID
+	if user := auth.FromContext(ctx); user != nil {
+		createdBy = user.ID
+	}
+
+	stmt, err := h.stmtService.AddTags(ctx, id, req.Tags, createdBy)
+	if err != nil {
+		switch {
+		case errors.Is(err, statement.ErrNotFound):
+			h.writeError(w, http.StatusNotFound, api.ErrorCodeFor(err), "Statement not found")
+		case errors.Is(err, statement.ErrInvalidTag):
+			h.writeError(w, http.StatusBadRequest, api.ErrorCodeFor(err), err.Error())
+		default:
+			requestid.Logger(r.Context(), h.logger).Error("failed to add statement tags", "error", err, "id", id)
+			h.writeError(w, http.StatusInternalServerError, api.ErrorCodeFor(err), "Failed to add tags")
+		}
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, h.transformStatement(stmt))
+}
+
+// RemoveTag removes a tag from a statement.
+func (h *Handler) RemoveTag(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, api.ErrCodeInvalidID, "Invalid statement ID format")
+		return
+	}
+
+	if err := h.stmtService.RemoveTag(r.Context(), id, r.PathValue("tag")); err != nil {
+		switch {
+		case errors.Is(err, statement.ErrNotFound):
+			h.writeError(w, http.StatusNotFound, api.ErrorCodeFor(err), "Statement not found")
+		case errors.Is(err, statement.ErrTagNotFound):
+			h.writeError(w, http.StatusNotFound, api.ErrorCodeFor(err), "Statement does not have the tag")
+		case errors.Is(err, statement.ErrInvalidTag):
+			h.writeError(w, http.StatusBadRequest, api.ErrorCodeFor(err), err.Error())
+		default:
+			requestid.Logger(r.Context(), h.logger).Error("failed to remove statement tag", "error", err, "id", id)
+			h.writeError(w, http.StatusInternalServerError, api.ErrorCodeFor(err), "Failed to remove tag")
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// UploadAttachment uploads an evidence file from the "file" field of a
+// multipart form to the statement's ServiceNow record.
+func (h *Handler) UploadAttachment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, api.ErrCodeInvalidID, "Invalid statement ID format")
+		return
+	}
+
+	// Allow for multipart framing around the file itself
+	r.Body = http.MaxBytesReader(w, r.Body, maxAttachmentSize+multipartOverhead)
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			h.writeError(w, http.StatusRequestEntityTooLarge, api.ErrCodePayloadTooLarge, "File exceeds the 10 MB limit")
+			return
+		}
+		h.writeError(w, http.StatusBadRequest, api.ErrCodeValidation, "A multipart form with a file field is required")
+		return
+	}
+	defer file.Close()
+
+	if header.Size > maxAttachmentSize {
+		h.writeError(w, http.StatusRequestEntityTooLarge, api.ErrCodePayloadTooLarge, "File exceeds the 10 MB limit")
+		return
+	}
+
+	// Sniff the type from the content rather than trusting the client
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		h.writeError(w, http.StatusBadRequest, api.ErrCodeValidation, "Failed to read the uploaded file")
+		return
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		requestid.Logger(ctx, h.logger).Error("failed to rewind attachment", "error", err, "id", id)
+		h.writeError(w, http.StatusInternalServerError, api.ErrCodeInternal, "Failed to read the uploaded file")
+		return
+	}
+	contentType := detectAttachmentType(head[:n], header.Filename)
+	if !allowedAttachmentTypes[contentType] {
+		h.writeError(w, http.StatusUnsupportedMediaType, api.ErrCodeUnsupportedMediaType, "File type is not allowed")
+		return
+	}
+
+	attachment, err := h.stmtService.AttachEvidence(ctx, statement.AttachEvidenceInput{
+		StatementID: id,
+		FileName:    filepath.Base(header.Filename),
+		ContentType: contentType,
+		Data:        file,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, statement.ErrNotFound):
+			h.writeError(w, http.StatusNotFound, api.ErrorCodeFor(err), "Statement not found")
+		case errors.Is(err, statement.ErrInvalidInput):
+			h.writeError(w, http.StatusBadRequest, api.ErrorCodeFor(err), "A file name is required")
+		case errors.Is(err, statement.ErrNotInServiceNow):
+			h.writeError(w, http.StatusConflict, api.ErrorCodeFor(err), "Statement has no ServiceNow record to attach to")
+		case errors.Is(err, statement.ErrSystemReadOnly):
+			h.writeError(w, http.StatusForbidden, api.ErrorCodeFor(err), err.Error())
+		case errors.Is(err, statement.ErrAttachmentsUnavailable):
+			h.writeError(w, http.StatusServiceUnavailable, api.ErrorCodeFor(err), "ServiceNow attachments are not configured")
+		case errors.Is(err, statement.ErrAttachmentUploadFailed):
+			requestid.Logger(ctx, h.logger).Error("failed to upload attachment", "error", err, "id", id)
+			h.writeError(w, http.StatusBadGateway, api.ErrorCodeFor(err), "Failed to upload attachment to ServiceNow")
+		default:
+			requestid.Logger(ctx, h.logger).Error("failed to record attachment", "error", err, "id", id)
+			h.writeError(w, http.StatusInternalServerError, api.ErrorCodeFor(err), "Failed to record attachment")
+		}
+		return
+	}
+
+	h.writeJSON(w, http.StatusCreated, AttachmentResponse{
+		ID:          attachment.ID,
+		StatementID: attachment.StatementID,
+		SNSysID:     attachment.SNSysID,
+		FileName:    attachment.FileName,
+		UploadedAt:  attachment.UploadedAt,
+	})
+}
+
+// DownloadAttachment streams an evidence file attached to the statement
+// from ServiceNow to the client without buffering it.
+func (h *Handler) DownloadAttachment(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), attachmentDownloadTimeout)
+	defer cancel()
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, api.ErrCodeInvalidID, "Invalid statement ID format")
+		return
+	}
+	attachmentID, err := uuid.Parse(r.PathValue("attachment_id"))
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, api.ErrCodeInvalidID, "Invalid attachment ID format")
+		return
+	}
+
+	attachment, content, contentType, err := h.stmtService.DownloadEvidence(ctx, id, attachmentID)
+	if err != nil {
+		switch {
+		case errors.Is(err, statement.ErrNotFound):
+			h.writeError(w, http.StatusNotFound, api.ErrorCodeFor(err), "Attachment not found")
+		case errors.Is(err, statement.ErrAttachmentsUnavailable):
+			h.writeError(w, http.StatusServiceUnavailable, api.ErrorCodeFor(err), "ServiceNow attachments are not configured")
+		case errors.Is(err, statement.ErrAttachmentDownloadFailed):
+			requestid.Logger(ctx, h.logger).Error("failed to download attachment", "error", err, "id", id, "attachment_id", attachmentID)
+			h.writeError(w, http.StatusBadGateway, api.ErrorCodeFor(err), "Failed to download attachment from ServiceNow")
+		default:
+			requestid.Logger(ctx, h.logger).Error("failed to get attachment", "error", err, "id", id, "attachment_id", attachmentID)
+			h.writeError(w, http.StatusInternalServerError, api.ErrorCodeFor(err), "Failed to get attachment")
+		}
+		return
+	}
+	defer content.Close()
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName}))
+	if _, err := io.Copy(flushWriter{w: w, rc: http.NewResponseController(w)}, content); err != nil {
+		// The status is already sent; the client sees a truncated body
+		requestid.Logger(ctx, h.logger).Error("failed to stream attachment", "error", err, "id", id, "attachment_id", attachmentID)
+	}
+}
+
+// flushWriter flushes each write to the client, so a streamed download is
+// not held back in the server's response buffer.
+type flushWriter struct {
+	w  io.Writer
+	rc *http.ResponseController
+}
+
+func (f flushWriter) Write(p []byte) (int, error) {
+	n, err := f.w.Write(p)
+	if err == nil {
+		// A writer that cannot flush still gets the whole body; a client
+		// that went away fails the next write
+		f.rc.Flush()
+	}
+	return n, err
+}
+
+// BulkDeleteStatements deletes the given statements, or every statement of a
+// control, in one transaction.
+func (h *Handler) BulkDeleteStatements(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req BulkDeleteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, http.StatusBadRequest, api.ErrCodeInvalidJSON, "Invalid request body")
+		return
+	}
+
+	deleted, err := h.stmtService.BulkDelete(ctx, statement.BulkDeleteInput{
+		StatementIDs: req.StatementIDs,
+		ControlID:    req.ControlID,
+		Force:        req.Force,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, statement.ErrInvalidInput):
+			h.writeError(w, http.StatusBadRequest, api.ErrorCodeFor(err), "Exactly one of statement_ids and control_id is required")
+		case errors.Is(err, statement.ErrCannotDeleteModified):
+			h.writeError(w, http.StatusConflict, api.ErrorCodeFor(err), "Statements with local modifications cannot be deleted without force")
+		default:
+			requestid.Logger(ctx, h.logger).Error("failed to bulk delete statements", "error", err)
+			h.writeError(w, http.StatusInternalServerError, api.ErrorCodeFor(err), "Failed to delete statements")
+		}
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, BulkDeleteResponse{DeletedCount: deleted})
+}
+
+// CreateStatementTemplate stores a reusable statement template.
+func (h *Handler) CreateStatementTemplate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req CreateStatementTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, http.StatusBadRequest, api.ErrCodeInvalidJSON, "Invalid request body")
+		return
+	}
+
+	var createdBy *uuid.UUID
+	if user := auth.FromContext(ctx); user != nil {
+		createdBy = user.ID
+	}
+
+	t, err := h.stmtService.CreateTemplate(ctx, statement.CreateTemplateInput{
+		Name:            req.Name,
+		ContentTemplate: req.ContentTemplate,
+		Variables:       req.Variables,
+		CreatedBy:       createdBy,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, statement.ErrInvalidInput), errors.Is(err, statement.ErrInvalidTemplate):
+			h.writeError(w, http.StatusBadRequest, api.ErrorCodeFor(err), err.Error())
+		default:
+			requestid.Logger(ctx, h.logger).Error("failed to create statement template", "error", err, "name", req.Name)
+			h.writeError(w, http.StatusInternalServerError, api.ErrorCodeFor(err), "Failed to create statement template")
+		}
+		return
+	}
+
+	h.writeJSON(w, http.StatusCreated, h.transformStatementTemplate(t))
+}
+
+// ApplyTemplate renders a template and saves it as a statement's local content.
+func (h *Handler) ApplyTemplate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req ApplyTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, http.StatusBadRequest, api.ErrCodeInvalidJSON, "Invalid request body")
+		return
+	}
+	if req.StatementID == uuid.Nil || req.TemplateID == uuid.Nil {
+		h.writeError(w, http.StatusBadRequest, api.ErrCodeValidation, "statement_id and template_id are required")
+		return
+	}
+
+	input := statement.ApplyTemplateInput{
+		StatementID: req.StatementID,
+		TemplateID:  req.TemplateID,
+		Variables:   req.Variables,
+	}
+	if user := auth.FromContext(ctx); user != nil {
+		input.ModifiedBy = user.ID
+		input.ModifiedByEmail = user.Email
+	}
+
+	stmt, err := h.stmtService.ApplyTemplate(ctx, input)
+	if err != nil {
+		var missing *statement.MissingVariablesError
+		switch {
+		case errors.As(err, &missing):
+			h.writeJSON(w, http.StatusUnprocessableEntity, MissingVariablesResponse{
+				Error:            err.Error(),
+				MissingVariables: missing.Names,
+			})
+		case errors.Is(err, statement.ErrTemplateNotFound):
+			h.writeError(w, http.StatusNotFound, api.ErrorCodeFor(err), "Statement template not found")
+		case errors.Is(err, statement.ErrNotFound):
+			h.writeError(w, http.StatusNotFound, api.ErrorCodeFor(err), "Statement not found")
+		case errors.Is(err, statement.ErrContentTooShort), errors.Is(err, statement.ErrContentTooLong),
+			errors.Is(err, statement.ErrInvalidTemplate):
+			h.writeError(w, http.StatusUnprocessableEntity, api.ErrorCodeFor(err), err.Error())
+		default:
+			requestid.Logger(ctx, h.logger).Error("failed to apply statement template", "error", err,
+				"id", req.StatementID, "template_id", req.TemplateID)
+			h.writeError(w, http.StatusInternalServerError, api.ErrorCodeFor(err), "Failed to apply statement template")
+		}
 		return
 	}
 
@@ -318,6 +1119,10 @@ func (h *Handler) RevertToRemote(w http.ResponseWriter, r *http.Request) {
 // Helper methods
 
 func (h *Handler) transformStatement(s *statement.Statement) StatementResponse {
+	tags := s.Tags
+	if tags == nil {
+		tags = []string{}
+	}
 	return StatementResponse{
 		ID:                 s.ID,
 		ControlID:          s.ControlID,
@@ -328,13 +1133,39 @@ func (h *Handler) transformStatement(s *statement.Statement) StatementResponse {
 		LocalContent:       s.LocalContent,
 		IsModified:         s.IsModified,
 		ModifiedAt:         s.ModifiedAt,
+		ModifiedByEmail:    s.ModifiedByEmail,
 		SyncStatus:         string(s.SyncStatus),
 		ConflictResolvedAt: s.ConflictResolvedAt,
+		ReviewStatus:       string(s.ReviewStatus),
+		ReviewedBy:         s.ReviewedBy,
+		ReviewedAt:         s.ReviewedAt,
+		ReviewComment:      s.ReviewComment,
 		EffectiveContent:   s.GetContent(),
+		Tags:               tags,
 		LastPullAt:         s.LastPullAt,
 		LastPushAt:         s.LastPushAt,
 		CreatedAt:          s.CreatedAt,
 		UpdatedAt:          s.UpdatedAt,
+		LastPushJobID:      s.LastPushBy,
+	}
+}
+
+func (h *Handler) transformStatementType(t *statement.Type) StatementTypeResponse {
+	return StatementTypeResponse{
+		Name:        t.Name,
+		Description: t.Description,
+		CreatedAt:   t.CreatedAt,
+	}
+}
+
+func (h *Handler) transformStatementTemplate(t *statement.Template) StatementTemplateResponse {
+	return StatementTemplateResponse{
+		ID:              t.ID,
+		Name:            t.Name,
+		ContentTemplate: t.ContentTemplate,
+		Variables:       t.Variables,
+		CreatedBy:       t.CreatedBy,
+		CreatedAt:       t.CreatedAt,
 	}
 }
 
@@ -344,9 +1175,9 @@ func (h *Handler) writeJSON(w http.ResponseWriter, status int, data interface{})
 	json.NewEncoder(w).Encode(data)
 }
 
-func (h *Handler) writeError(w http.ResponseWriter, status int, message string) {
+func (h *Handler) writeError(w http.ResponseWriter, status int, code api.ErrorCode, message string) {
 	h.writeJSON(w, status, ErrorResponse{
-		Error:   http.StatusText(status),
+		Error:   string(code),
 		Message: message,
 	})
 }
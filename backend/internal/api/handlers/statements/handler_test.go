@@ -0,0 +1,1366 @@
+package statements
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/controlcrud/backend/internal/api/middleware/auth"
+	"github.com/controlcrud/backend/internal/api/middleware/auth/authtest"
+	"github.com/controlcrud/backend/internal/api/middleware/bodylimit"
+	"github.com/controlcrud/backend/internal/api/middleware/requestid"
+	"github.com/controlcrud/backend/internal/api/middleware/timeout"
+	"github.com/controlcrud/backend/internal/config"
+	"github.com/controlcrud/backend/internal/domain/statement"
+	"github.com/controlcrud/backend/internal/infrastructure/servicenow"
+)
+
+// allowAll stands in for the reviewer role check in tests of other routes.
+func allowAll(next http.Handler) http.Handler { return next }
+
+// mockRepository implements the statement.Repository methods used by the
+// statement type endpoints, with the foreign key semantics of the database.
+type mockRepository struct {
+	statement.Repository
+	types []statement.Type
+	inUse map[string]bool
+}
+
+func (m *mockRepository) ListTypes(ctx context.Context) ([]statement.Type, error) {
+	return m.types, nil
+}
+
+func (m *mockRepository) CreateType(ctx context.Context, input statement.CreateTypeInput) (*statement.Type, error) {
+	for _, t := range m.types {
+		if t.Name == input.Name {
+			return nil, statement.ErrStatementTypeExists
+		}
+	}
+	t := statement.Type{Name: input.Name, Description: input.Description, CreatedAt: time.Now()}
+	m.types = append(m.types, t)
+	return &t, nil
+}
+
+func (m *mockRepository) DeleteType(ctx context.Context, name string) error {
+	if m.inUse[name] {
+		return statement.ErrStatementTypeInUse
+	}
+	for i, t := range m.types {
+		if t.Name == name {
+			m.types = append(m.types[:i], m.types[i+1:]...)
+			return nil
+		}
+	}
+	return statement.ErrStatementTypeNotFound
+}
+
+func newTypesTestHandler(repo *mockRepository) http.Handler {
+	mux := http.NewServeMux()
+	NewHandler(statement.NewService(repo, statement.Options{}, nil), config.PaginationDefaults{}, allowAll, nil).RegisterRoutes(mux)
+	return mux
+}
+
+func TestHandler_StatementTypes(t *testing.T) {
+	repo := &mockRepository{
+		types: []statement.Type{{Name: "implementation"}, {Name: "evidence"}},
+		inUse: map[string]bool{"implementation": true},
+	}
+	handler := newTypesTestHandler(repo)
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		wantStatus int
+	}{
+		{"create", http.MethodPost, "/api/v1/statement-types", `{"name":"risk_acceptance","description":"Accepted risk"}`, http.StatusCreated},
+		{"create duplicate", http.MethodPost, "/api/v1/statement-types", `{"name":"evidence"}`, http.StatusConflict},
+		{"create invalid name", http.MethodPost, "/api/v1/statement-types", `{"name":"Test Procedure"}`, http.StatusBadRequest},
+		{"delete in use", http.MethodDelete, "/api/v1/statement-types/implementation", "", http.StatusConflict},
+		{"delete unused", http.MethodDelete, "/api/v1/statement-types/evidence", "", http.StatusNoContent},
+		{"delete unknown", http.MethodDelete, "/api/v1/statement-types/unknown", "", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		if w.Code != tt.wantStatus {
+			t.Errorf("%s: expected status %d, got %d: %s", tt.name, tt.wantStatus, w.Code, w.Body.String())
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/statement-types", nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	var resp ListStatementTypesResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Types) != 2 || resp.Types[0].Name != "implementation" || resp.Types[1].Name != "risk_acceptance" {
+		t.Errorf("unexpected types after changes: %+v", resp.Types)
+	}
+}
+
+// templateRepository implements the statement.Repository methods used by
+// the template endpoints.
+type templateRepository struct {
+	statement.Repository
+	stmt      *statement.Statement
+	templates map[uuid.UUID]*statement.Template
+}
+
+func (m *templateRepository) GetByID(ctx context.Context, id uuid.UUID) (*statement.Statement, error) {
+	if m.stmt == nil || m.stmt.ID != id {
+		return nil, nil
+	}
+	return m.stmt, nil
+}
+
+func (m *templateRepository) ListReadOnly(ctx context.Context, ids []uuid.UUID) ([]uuid.UUID, error) {
+	return nil, nil
+}
+
+func (m *templateRepository) UpdateLocal(ctx context.Context, input statement.UpdateInput) (*statement.Statement, error) {
+	updated := *m.stmt
+	updated.LocalContent = input.LocalContent
+	updated.IsModified = true
+	return &updated, nil
+}
+
+func (m *templateRepository) CreateTemplate(ctx context.Context, input statement.CreateTemplateInput) (*statement.Template, error) {
+	t := &statement.Template{
+		ID:              uuid.New(),
+		Name:            input.Name,
+		ContentTemplate: input.ContentTemplate,
+		Variables:       input.Variables,
+		CreatedAt:       time.Now(),
+	}
+	m.templates[t.ID] = t
+	return t, nil
+}
+
+func (m *templateRepository) GetTemplate(ctx context.Context, id uuid.UUID) (*statement.Template, error) {
+	return m.templates[id], nil
+}
+
+func TestHandler_StatementTemplates(t *testing.T) {
+	repo := &templateRepository{
+		stmt:      &statement.Statement{ID: uuid.New()},
+		templates: make(map[uuid.UUID]*statement.Template),
+	}
+	mux := http.NewServeMux()
+	NewHandler(statement.NewService(repo, statement.Options{}, nil), config.PaginationDefaults{}, allowAll, nil).RegisterRoutes(mux)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/statement-templates",
+		strings.NewReader(`{"name":"Access review","content_template":"{{system_name}} is owned by {{owner}}."}`))
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create: expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var created StatementTemplateResponse
+	if err := json.NewDecoder(w.Body).Decode(&created); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	apply := func(variables string) *httptest.ResponseRecorder {
+		body := `{"statement_id":"` + repo.stmt.ID.String() + `","template_id":"` + created.ID.String() + `","variables":` + variables + `}`
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/statements/from-template", strings.NewReader(body))
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	w = apply(`{"system_name":"ACME"}`)
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("missing variable: expected 422, got %d: %s", w.Code, w.Body.String())
+	}
+	var missing MissingVariablesResponse
+	if err := json.NewDecoder(w.Body).Decode(&missing); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(missing.MissingVariables) != 1 || missing.MissingVariables[0] != "owner" {
+		t.Errorf("expected missing [owner], got %v", missing.MissingVariables)
+	}
+
+	w = apply(`{"system_name":"ACME","owner":"Alice"}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("apply: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var stmt StatementResponse
+	if err := json.NewDecoder(w.Body).Decode(&stmt); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if stmt.LocalContent != "ACME is owned by Alice." {
+		t.Errorf("unexpected content %q", stmt.LocalContent)
+	}
+}
+
+func TestHandler_PreviewRevert(t *testing.T) {
+	repo := &templateRepository{stmt: &statement.Statement{
+		ID:            uuid.New(),
+		IsModified:    true,
+		LocalContent:  "Access is reviewed every quarter.",
+		RemoteContent: "Access is reviewed.",
+	}}
+	mux := http.NewServeMux()
+	NewHandler(statement.NewService(repo, statement.Options{}, nil), config.PaginationDefaults{}, allowAll, nil).RegisterRoutes(mux)
+
+	preview := func(id uuid.UUID) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/statements/"+id.String()+"/revert-preview", nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	w := preview(repo.stmt.ID)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp RevertPreviewResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.CharsLost != 14 || resp.WordsLost != 2 || resp.RemoteContent != "Access is reviewed." {
+		t.Errorf("unexpected preview %+v", resp)
+	}
+
+	repo.stmt.IsModified = false
+	w = preview(repo.stmt.ID)
+	if body := strings.TrimSpace(w.Body.String()); body != `{"no_changes":true}` {
+		t.Errorf("expected only no_changes for unmodified statement, got %s", body)
+	}
+
+	if w := preview(uuid.New()); w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for unknown statement, got %d", w.Code)
+	}
+}
+
+func TestHandler_GetStatement_LastPush(t *testing.T) {
+	pushedAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	jobID := uuid.New()
+	repo := &templateRepository{stmt: &statement.Statement{
+		ID:            uuid.New(),
+		RemoteContent: "Access is reviewed.",
+		SyncStatus:    statement.SyncStatusSynced,
+		LastPushAt:    &pushedAt,
+		LastPushBy:    &jobID,
+	}}
+	mux := http.NewServeMux()
+	NewHandler(statement.NewService(repo, statement.Options{}, nil), config.PaginationDefaults{}, allowAll, nil).RegisterRoutes(mux)
+
+	get := func() map[string]any {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/statements/"+repo.stmt.ID.String(), nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+		var resp map[string]any
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		return resp
+	}
+
+	resp := get()
+	if resp["last_push_at"] != "2024-03-01T12:00:00Z" {
+		t.Errorf("expected last_push_at 2024-03-01T12:00:00Z, got %v", resp["last_push_at"])
+	}
+	if resp["last_push_job_id"] != jobID.String() {
+		t.Errorf("expected last_push_job_id %s, got %v", jobID, resp["last_push_job_id"])
+	}
+
+	// Statements that were never pushed omit both fields
+	repo.stmt.LastPushAt, repo.stmt.LastPushBy = nil, nil
+	resp = get()
+	for _, field := range []string{"last_push_at", "last_push_job_id"} {
+		if _, ok := resp[field]; ok {
+			t.Errorf("expected %s omitted for a statement never pushed, got %v", field, resp[field])
+		}
+	}
+}
+
+// similarRepository serves the statements of a single control.
+type similarRepository struct {
+	statement.Repository
+	stmts []statement.Statement
+}
+
+func (m *similarRepository) GetByID(ctx context.Context, id uuid.UUID) (*statement.Statement, error) {
+	for _, s := range m.stmts {
+		if s.ID == id {
+			return &s, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *similarRepository) ListByControl(ctx context.Context, controlID uuid.UUID) ([]statement.Statement, error) {
+	return m.stmts, nil
+}
+
+func TestHandler_ListSimilar(t *testing.T) {
+	content := "User access is reviewed quarterly by the system owner."
+	target := statement.Statement{ID: uuid.New(), RemoteContent: content}
+	duplicate := statement.Statement{ID: uuid.New(), RemoteContent: content}
+	unrelated := statement.Statement{ID: uuid.New(), RemoteContent: "Backups are encrypted and stored offsite."}
+	repo := &similarRepository{stmts: []statement.Statement{target, unrelated, duplicate}}
+	mux := http.NewServeMux()
+	NewHandler(statement.NewService(repo, statement.Options{}, nil), config.PaginationDefaults{}, allowAll, nil).RegisterRoutes(mux)
+
+	get := func(query string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/statements/"+target.ID.String()+"/similar"+query, nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	w := get("")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp []SimilarStatementResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp) != 1 || resp[0].StatementID != duplicate.ID || resp[0].SimilarityScore != 1 || resp[0].Preview != content {
+		t.Errorf("expected only the duplicate, got %+v", resp)
+	}
+
+	// A zero threshold returns every other statement, most similar first
+	w = get("?threshold=0")
+	resp = nil
+	json.NewDecoder(w.Body).Decode(&resp)
+	if len(resp) != 2 || resp[0].StatementID != duplicate.ID || resp[1].StatementID != unrelated.ID {
+		t.Errorf("expected both statements ordered by score, got %+v", resp)
+	}
+
+	for _, query := range []string{"?threshold=high", "?threshold=1.5"} {
+		if w := get(query); w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", query, w.Code)
+		}
+	}
+}
+
+// bulkDeleteRepository records DeleteBatch calls and rejects modified
+// statements unless forced.
+type bulkDeleteRepository struct {
+	statement.Repository
+	modified map[uuid.UUID]bool
+	deleted  []uuid.UUID
+}
+
+func (m *bulkDeleteRepository) DeleteBatch(ctx context.Context, ids []uuid.UUID, force bool) (int, error) {
+	for _, id := range ids {
+		if m.modified[id] && !force {
+			return 0, statement.ErrCannotDeleteModified
+		}
+	}
+	m.deleted = append(m.deleted, ids...)
+	return len(ids), nil
+}
+
+func TestHandler_BulkDeleteStatements(t *testing.T) {
+	clean, modified := uuid.New(), uuid.New()
+	repo := &bulkDeleteRepository{modified: map[uuid.UUID]bool{modified: true}}
+	mux := http.NewServeMux()
+	NewHandler(statement.NewService(repo, statement.Options{}, nil), config.PaginationDefaults{}, allowAll, nil).RegisterRoutes(mux)
+
+	bulkDelete := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/statements/bulk", strings.NewReader(body))
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+	ids := `["` + clean.String() + `","` + modified.String() + `"]`
+
+	if w := bulkDelete(`{}`); w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 without selector, got %d", w.Code)
+	}
+	if w := bulkDelete(`{"statement_ids":` + ids + `}`); w.Code != http.StatusConflict {
+		t.Errorf("expected 409 for modified statements, got %d", w.Code)
+	}
+	if len(repo.deleted) != 0 {
+		t.Fatalf("expected nothing deleted, got %v", repo.deleted)
+	}
+
+	w := bulkDelete(`{"statement_ids":` + ids + `,"force":true}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp BulkDeleteResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.DeletedCount != 2 || len(repo.deleted) != 2 {
+		t.Errorf("expected 2 statements deleted, got %d (%v)", resp.DeletedCount, repo.deleted)
+	}
+}
+
+// modifiedListRepository records the params passed to ListModified and
+// ListConflicts.
+type modifiedListRepository struct {
+	statement.Repository
+	params statement.ModifiedListParams
+}
+
+func (m *modifiedListRepository) ListModified(ctx context.Context, params statement.ModifiedListParams) (*statement.ListResult, error) {
+	m.params = params
+	return &statement.ListResult{
+		Statements: []statement.Statement{{ID: uuid.New(), IsModified: true}},
+		TotalCount: 41,
+		Page:       params.Page,
+		PageSize:   params.PageSize,
+		TotalPages: (41 + params.PageSize - 1) / params.PageSize,
+	}, nil
+}
+
+func (m *modifiedListRepository) ListConflicts(ctx context.Context, params statement.ModifiedListParams) (*statement.ListResult, error) {
+	return m.ListModified(ctx, params)
+}
+
+func TestHandler_ListModified_Pagination(t *testing.T) {
+	repo := &modifiedListRepository{}
+	mux := http.NewServeMux()
+	NewHandler(statement.NewService(repo, statement.Options{}, nil), config.PaginationDefaults{}, allowAll, nil).RegisterRoutes(mux)
+
+	list := func(target string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, nil))
+		return w
+	}
+
+	w := list("/api/v1/statements/modified")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp ListStatementsResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Page != 1 || resp.PageSize != 20 || resp.TotalCount != 41 || resp.TotalPages != 3 {
+		t.Errorf("expected defaults page 1/20 with 3 pages, got %+v", resp)
+	}
+	if repo.params.SystemID != nil {
+		t.Errorf("expected no system filter, got %v", repo.params.SystemID)
+	}
+
+	systemID := uuid.New()
+	w = list("/api/v1/statements/conflicts?system_id=" + systemID.String() + "&page=2&page_size=500")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if repo.params.Page != 2 || repo.params.PageSize != config.MaxStatementsPageSize {
+		t.Errorf("expected page 2 with page size capped at the maximum, got %+v", repo.params)
+	}
+	if repo.params.SystemID == nil || *repo.params.SystemID != systemID {
+		t.Errorf("expected system filter %s, got %v", systemID, repo.params.SystemID)
+	}
+
+	if w := list("/api/v1/statements/modified?system_id=nope"); w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for invalid system_id, got %d", w.Code)
+	}
+}
+
+// attachmentRepository serves one statement and records created attachments.
+type attachmentRepository struct {
+	statement.Repository
+	stmt        *statement.Statement
+	readOnly    bool
+	attachments []statement.Attachment
+}
+
+func (m *attachmentRepository) GetSystemRef(ctx context.Context, id uuid.UUID) (*statement.SystemRef, error) {
+	if m.stmt == nil || m.stmt.ID != id {
+		return nil, nil
+	}
+	return &statement.SystemRef{ID: uuid.New(), Name: "ACME", ConnectionLabel: "staging"}, nil
+}
+
+func (m *attachmentRepository) ListReadOnly(ctx context.Context, ids []uuid.UUID) ([]uuid.UUID, error) {
+	if !m.readOnly {
+		return nil, nil
+	}
+	return ids, nil
+}
+
+func (m *attachmentRepository) GetByID(ctx context.Context, id uuid.UUID) (*statement.Statement, error) {
+	if m.stmt.ID != id {
+		return nil, nil
+	}
+	return m.stmt, nil
+}
+
+func (m *attachmentRepository) CreateAttachment(ctx context.Context, statementID uuid.UUID, snSysID, fileName string) (*statement.Attachment, error) {
+	a := statement.Attachment{ID: uuid.New(), StatementID: statementID, SNSysID: snSysID, FileName: fileName, UploadedAt: time.Now()}
+	m.attachments = append(m.attachments, a)
+	return &a, nil
+}
+
+func (m *attachmentRepository) GetAttachment(ctx context.Context, statementID, attachmentID uuid.UUID) (*statement.Attachment, error) {
+	for _, a := range m.attachments {
+		if a.ID == attachmentID && a.StatementID == statementID {
+			return &a, nil
+		}
+	}
+	return nil, nil
+}
+
+// uploadClient records the table and record files are attached to.
+type uploadClient struct {
+	servicenow.Client
+	table, sysID, contentType string
+}
+
+func (c *uploadClient) UploadAttachment(ctx context.Context, tableName, sysID, fileName string, contentType string, data io.Reader) (string, error) {
+	c.table, c.sysID, c.contentType = tableName, sysID, contentType
+	return "att123", nil
+}
+
+type uploadClientProvider struct{ client *uploadClient }
+
+func (p *uploadClientProvider) GetSNClient(ctx context.Context) (servicenow.Client, error) {
+	return p.client, nil
+}
+
+func (p *uploadClientProvider) GetSNClientByLabel(ctx context.Context, label string) (servicenow.Client, error) {
+	return p.client, nil
+}
+
+func TestHandler_UploadAttachment(t *testing.T) {
+	repo := &attachmentRepository{stmt: &statement.Statement{ID: uuid.New(), SNSysID: "stmt456"}}
+	client := &uploadClient{}
+	mux := http.NewServeMux()
+	NewHandler(statement.NewService(repo, statement.Options{
+		SNClients:       &uploadClientProvider{client: client},
+		AttachmentTable: "incident",
+	}, nil), config.PaginationDefaults{}, allowAll, nil).RegisterRoutes(mux)
+
+	pdf := func(size int) []byte {
+		return append([]byte("%PDF-1.7\n"), bytes.Repeat([]byte("a"), size)...)
+	}
+	upload := func(fileName, contentType string, data []byte) *httptest.ResponseRecorder {
+		var body bytes.Buffer
+		mw := multipart.NewWriter(&body)
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Disposition", `form-data; name="file"; filename="`+fileName+`"`)
+		header.Set("Content-Type", contentType)
+		part, _ := mw.CreatePart(header)
+		part.Write(data)
+		mw.Close()
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/statements/"+repo.stmt.ID.String()+"/attachments", &body)
+		req.Header.Set("Content-Type", mw.FormDataContentType())
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	w := upload("evidence.pdf", "application/octet-stream", pdf(1024))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp AttachmentResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.SNSysID != "att123" || resp.FileName != "evidence.pdf" || resp.StatementID != repo.stmt.ID {
+		t.Errorf("unexpected attachment %+v", resp)
+	}
+	if client.table != "incident" || client.sysID != "stmt456" {
+		t.Errorf("expected upload to incident/stmt456, got %s/%s", client.table, client.sysID)
+	}
+	if client.contentType != "application/pdf" {
+		t.Errorf("expected sniffed content type 'application/pdf', got '%s'", client.contentType)
+	}
+
+	if w := upload("page.pdf", "application/pdf", []byte("<html><script>alert(1)</script></html>")); w.Code != http.StatusUnsupportedMediaType {
+		t.Errorf("expected 415 for HTML claiming to be a PDF, got %d", w.Code)
+	}
+	if w := upload("big.pdf", "application/pdf", pdf(maxAttachmentSize+1)); w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("expected 413 for oversized file, got %d", w.Code)
+	}
+	if len(repo.attachments) != 1 {
+		t.Errorf("expected 1 recorded attachment, got %d", len(repo.attachments))
+	}
+
+	repo.readOnly = true
+	client.table, client.sysID = "", ""
+	if w := upload("evidence.pdf", "application/pdf", pdf(1024)); w.Code != http.StatusForbidden {
+		t.Errorf("expected 403 for read-only system, got %d", w.Code)
+	}
+	if client.sysID != "" || len(repo.attachments) != 1 {
+		t.Error("expected nothing to be uploaded for read-only system")
+	}
+}
+
+func TestDetectAttachmentType(t *testing.T) {
+	zip := []byte("PK\x03\x04\x14\x00\x06\x00")
+	ole := append([]byte{0xd0, 0xcf, 0x11, 0xe0, 0xa1, 0xb1, 0x1a, 0xe1}, make([]byte, 32)...)
+
+	tests := []struct {
+		name     string
+		head     []byte
+		fileName string
+		want     string
+	}{
+		{"pdf", []byte("%PDF-1.7\n"), "evidence.pdf", "application/pdf"},
+		{"png", []byte("\x89PNG\r\n\x1a\n"), "diagram.png", "image/png"},
+		{"text", []byte("Access is reviewed quarterly."), "notes.txt", "text/plain"},
+		{"csv", []byte("user,role\nalice,admin\n"), "users.CSV", "text/csv"},
+		{"docx", zip, "policy.docx", "application/vnd.openxmlformats-officedocument.wordprocessingml.document"},
+		{"xlsx", zip, "inventory.xlsx", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"},
+		{"plain zip", zip, "archive.zip", ""},
+		{"doc", ole, "policy.doc", "application/msword"},
+		{"xls", ole, "inventory.xls", "application/vnd.ms-excel"},
+		{"html", []byte("<!DOCTYPE html><html></html>"), "evidence.pdf", "text/html"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := detectAttachmentType(tt.head, tt.fileName); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+// freshnessClient serves one policy statement record, or err, and records
+// the connection label it was requested for.
+type freshnessClient struct {
+	servicenow.Client
+	updatedOn string
+	err       error
+	calls     int
+	label     string
+}
+
+func (c *freshnessClient) GetPolicyStatement(ctx context.Context, sysID string) (*servicenow.PolicyStatementRecord, error) {
+	c.calls++
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &servicenow.PolicyStatementRecord{SysID: sysID, SysUpdatedOn: c.updatedOn}, nil
+}
+
+func (c *freshnessClient) GetInstanceTimezone(ctx context.Context) (*time.Location, error) {
+	return time.UTC, nil
+}
+
+type freshnessClientProvider struct{ client *freshnessClient }
+
+func (p freshnessClientProvider) GetSNClient(ctx context.Context) (servicenow.Client, error) {
+	return p.client, nil
+}
+
+func (p freshnessClientProvider) GetSNClientByLabel(ctx context.Context, label string) (servicenow.Client, error) {
+	p.client.label = label
+	return p.client, nil
+}
+
+func TestHandler_GetFreshness(t *testing.T) {
+	pulledAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		client    *freshnessClient
+		wantStale *bool
+		wantError string
+	}{
+		{"fresh", &freshnessClient{updatedOn: "2024-03-01 12:00:00"}, boolPtr(false), ""},
+		{"stale", &freshnessClient{updatedOn: "2024-03-02 08:30:00"}, boolPtr(true), ""},
+		{"unreachable", &freshnessClient{err: servicenow.ErrConnectionFailed}, nil, "ServiceNow unreachable"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &attachmentRepository{stmt: &statement.Statement{ID: uuid.New(), SNSysID: "stmt456", SNUpdatedOn: &pulledAt}}
+			mux := http.NewServeMux()
+			NewHandler(statement.NewService(repo, statement.Options{
+				SNClients: freshnessClientProvider{client: tt.client},
+			}, nil), config.PaginationDefaults{}, allowAll, nil).RegisterRoutes(mux)
+
+			check := func() map[string]any {
+				req := httptest.NewRequest(http.MethodGet, "/api/v1/statements/"+repo.stmt.ID.String()+"/freshness", nil)
+				w := httptest.NewRecorder()
+				mux.ServeHTTP(w, req)
+				if w.Code != http.StatusOK {
+					t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+				}
+				var resp map[string]any
+				if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+					t.Fatalf("failed to decode response: %v", err)
+				}
+				return resp
+			}
+
+			resp := check()
+			isStale, present := resp["is_stale"]
+			if !present {
+				t.Fatal("expected is_stale to be present")
+			}
+			if tt.wantStale == nil {
+				if isStale != nil {
+					t.Errorf("expected is_stale null, got %v", isStale)
+				}
+			} else if isStale != *tt.wantStale {
+				t.Errorf("expected is_stale %v, got %v", *tt.wantStale, isStale)
+			}
+			if got, _ := resp["error"].(string); got != tt.wantError {
+				t.Errorf("expected error %q, got %q", tt.wantError, got)
+			}
+			if resp["local_sn_updated_on"] != pulledAt.Format(time.RFC3339) {
+				t.Errorf("expected local_sn_updated_on %s, got %v", pulledAt.Format(time.RFC3339), resp["local_sn_updated_on"])
+			}
+			if tt.client.label != "staging" {
+				t.Errorf("expected the system's connection to be checked, got %q", tt.client.label)
+			}
+
+			// Successful checks are cached; failures are retried
+			check()
+			wantCalls := 1
+			if tt.client.err != nil {
+				wantCalls = 2
+			}
+			if tt.client.calls != wantCalls {
+				t.Errorf("expected %d ServiceNow calls, got %d", wantCalls, tt.client.calls)
+			}
+		})
+	}
+}
+
+func boolPtr(b bool) *bool { return &b }
+
+// attributionRepository records the update input so tests can check who a
+// change is attributed to.
+type attributionRepository struct {
+	statement.Repository
+	stmt    *statement.Statement
+	updated *statement.UpdateInput
+}
+
+func (m *attributionRepository) GetByID(ctx context.Context, id uuid.UUID) (*statement.Statement, error) {
+	if m.stmt.ID != id {
+		return nil, nil
+	}
+	return m.stmt, nil
+}
+
+func (m *attributionRepository) ListReadOnly(ctx context.Context, ids []uuid.UUID) ([]uuid.UUID, error) {
+	return nil, nil
+}
+
+func (m *attributionRepository) UpdateLocal(ctx context.Context, input statement.UpdateInput) (*statement.Statement, error) {
+	m.updated = &input
+	updated := *m.stmt
+	updated.LocalContent = input.LocalContent
+	updated.IsModified = true
+	updated.ModifiedBy = input.ModifiedBy
+	updated.ModifiedByEmail = input.ModifiedByEmail
+	return &updated, nil
+}
+
+func TestHandler_UpdateStatement_ModifiedByEmail(t *testing.T) {
+	tests := []struct {
+		name  string
+		email string
+	}{
+		{"email in context", "alice@example.com"},
+		{"no email in context", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &attributionRepository{stmt: &statement.Statement{ID: uuid.New(), RemoteContent: "Remote text."}}
+			mux := http.NewServeMux()
+			NewHandler(statement.NewService(repo, statement.Options{}, nil), config.PaginationDefaults{}, allowAll, nil).RegisterRoutes(mux)
+
+			req := httptest.NewRequest(http.MethodPut, "/api/v1/statements/"+repo.stmt.ID.String(),
+				strings.NewReader(`{"local_content":"Access is reviewed quarterly by the system owner."}`))
+			if tt.email != "" {
+				req = req.WithContext(auth.NewContext(req.Context(), &auth.User{Email: tt.email}))
+			}
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+			}
+			if repo.updated == nil {
+				t.Fatal("expected statement to be updated")
+			}
+			if repo.updated.ModifiedByEmail != tt.email {
+				t.Errorf("expected ModifiedByEmail %q, got %q", tt.email, repo.updated.ModifiedByEmail)
+			}
+
+			var resp map[string]interface{}
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			got, present := resp["modified_by_email"]
+			if tt.email == "" && present {
+				t.Errorf("expected modified_by_email to be omitted, got %v", got)
+			}
+			if tt.email != "" && got != tt.email {
+				t.Errorf("expected modified_by_email %q, got %v", tt.email, got)
+			}
+		})
+	}
+}
+
+// tagRepository keeps statement tags in memory and filters List by them the
+// way the database does.
+type tagRepository struct {
+	statement.Repository
+	stmts map[uuid.UUID]*statement.Statement
+}
+
+func (m *tagRepository) GetByID(ctx context.Context, id uuid.UUID) (*statement.Statement, error) {
+	return m.stmts[id], nil
+}
+
+func (m *tagRepository) AddTags(ctx context.Context, statementID uuid.UUID, tags []string, createdBy *uuid.UUID) error {
+	stmt := m.stmts[statementID]
+	for _, tag := range tags {
+		if !slices.Contains(stmt.Tags, tag) {
+			stmt.Tags = append(stmt.Tags, tag)
+		}
+	}
+	slices.Sort(stmt.Tags)
+	return nil
+}
+
+func (m *tagRepository) RemoveTag(ctx context.Context, statementID uuid.UUID, tag string) (bool, error) {
+	stmt := m.stmts[statementID]
+	i := slices.Index(stmt.Tags, tag)
+	if i < 0 {
+		return false, nil
+	}
+	stmt.Tags = slices.Delete(stmt.Tags, i, i+1)
+	return true, nil
+}
+
+func (m *tagRepository) List(ctx context.Context, params statement.ListParams) (*statement.ListResult, error) {
+	result := &statement.ListResult{Statements: []statement.Statement{}, Page: params.Page, PageSize: params.PageSize}
+	for _, stmt := range m.stmts {
+		matches := true
+		for _, tag := range params.Tags {
+			matches = matches && slices.Contains(stmt.Tags, tag)
+		}
+		if matches {
+			result.Statements = append(result.Statements, *stmt)
+		}
+	}
+	result.TotalCount = len(result.Statements)
+	return result, nil
+}
+
+func TestHandler_StatementTags(t *testing.T) {
+	controlID := uuid.New()
+	gdpr := &statement.Statement{ID: uuid.New(), ControlID: controlID}
+	other := &statement.Statement{ID: uuid.New(), ControlID: controlID, Tags: []string{"hipaa"}}
+	repo := &tagRepository{stmts: map[uuid.UUID]*statement.Statement{gdpr.ID: gdpr, other.ID: other}}
+	mux := http.NewServeMux()
+	NewHandler(statement.NewService(repo, statement.Options{}, nil), config.PaginationDefaults{}, allowAll, nil).RegisterRoutes(mux)
+
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+	tagsPath := "/api/v1/statements/" + gdpr.ID.String() + "/tags"
+
+	// Add: tags are normalized and deduplicated
+	w := serve(http.MethodPost, tagsPath, `{"tags":["GDPR"," hipaa ","gdpr"]}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var stmt StatementResponse
+	if err := json.NewDecoder(w.Body).Decode(&stmt); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if !slices.Equal(stmt.Tags, []string{"gdpr", "hipaa"}) {
+		t.Errorf("expected tags [gdpr hipaa], got %v", stmt.Tags)
+	}
+
+	if w := serve(http.MethodPost, tagsPath, `{"tags":[]}`); w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 without tags, got %d", w.Code)
+	}
+	if w := serve(http.MethodPost, tagsPath, `{"tags":["a/b"]}`); w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for tag with slash, got %d", w.Code)
+	}
+	if w := serve(http.MethodPost, "/api/v1/statements/"+uuid.NewString()+"/tags", `{"tags":["gdpr"]}`); w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for unknown statement, got %d", w.Code)
+	}
+
+	// Filter: only statements carrying every requested tag
+	list := func(query string) []StatementResponse {
+		t.Helper()
+		w := serve(http.MethodGet, "/api/v1/statements?control_id="+controlID.String()+query, "")
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+		var resp ListStatementsResponse
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		return resp.Statements
+	}
+	if got := list("&tags=hipaa"); len(got) != 2 {
+		t.Errorf("expected 2 statements tagged hipaa, got %d", len(got))
+	}
+	if got := list("&tags=GDPR,hipaa"); len(got) != 1 || got[0].ID != gdpr.ID {
+		t.Errorf("expected only the gdpr statement, got %+v", got)
+	}
+
+	// Remove
+	if w := serve(http.MethodDelete, tagsPath+"/gdpr", ""); w.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d: %s", w.Code, w.Body.String())
+	}
+	if !slices.Equal(gdpr.Tags, []string{"hipaa"}) {
+		t.Errorf("expected tags [hipaa] after removal, got %v", gdpr.Tags)
+	}
+	if w := serve(http.MethodDelete, tagsPath+"/gdpr", ""); w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 removing a missing tag, got %d", w.Code)
+	}
+	if got := list("&tags=gdpr"); len(got) != 0 {
+		t.Errorf("expected no statements tagged gdpr, got %d", len(got))
+	}
+}
+
+// staticClientProvider serves one ServiceNow client and records the
+// connection labels it was asked for.
+type staticClientProvider struct {
+	client servicenow.Client
+	labels []string
+}
+
+func (p *staticClientProvider) GetSNClient(ctx context.Context) (servicenow.Client, error) {
+	return p.client, nil
+}
+
+func (p *staticClientProvider) GetSNClientByLabel(ctx context.Context, label string) (servicenow.Client, error) {
+	p.labels = append(p.labels, label)
+	return p.client, nil
+}
+
+func TestHandler_DownloadAttachment(t *testing.T) {
+	pdf := []byte("%PDF-1.4\n1 0 obj << /Type /Catalog >> endobj\ntrailer << /Root 1 0 R >>\n%%EOF\n")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/now/attachment/att123/file" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/pdf")
+		w.Write(pdf)
+	}))
+	defer server.Close()
+	client, err := servicenow.NewSNClient(servicenow.DefaultConfig(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	stmt := &statement.Statement{ID: uuid.New(), SNSysID: "stmt456"}
+	attachment := statement.Attachment{ID: uuid.New(), StatementID: stmt.ID, SNSysID: "att123", FileName: "access review.pdf"}
+	removed := statement.Attachment{ID: uuid.New(), StatementID: stmt.ID, SNSysID: "att999", FileName: "old.pdf"}
+	repo := &attachmentRepository{stmt: stmt, attachments: []statement.Attachment{attachment, removed}}
+	mux := http.NewServeMux()
+	NewHandler(statement.NewService(repo, statement.Options{
+		SNClients: &staticClientProvider{client: client},
+	}, nil), config.PaginationDefaults{}, allowAll, nil).RegisterRoutes(mux)
+
+	download := func(stmtID, attachmentID string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/statements/"+stmtID+"/attachments/"+attachmentID, nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	w := download(stmt.ID.String(), attachment.ID.String())
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if !bytes.Equal(w.Body.Bytes(), pdf) {
+		t.Errorf("expected the PDF bytes, got %q", w.Body.Bytes())
+	}
+	if got := w.Header().Get("Content-Type"); got != "application/pdf" {
+		t.Errorf("expected application/pdf, got %q", got)
+	}
+	if got := w.Header().Get("Content-Disposition"); got != `attachment; filename="access review.pdf"` {
+		t.Errorf("unexpected Content-Disposition %q", got)
+	}
+
+	if w := download(uuid.NewString(), attachment.ID.String()); w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for another statement's attachment, got %d", w.Code)
+	}
+	if w := download(stmt.ID.String(), uuid.NewString()); w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for unknown attachment, got %d", w.Code)
+	}
+	if w := download(stmt.ID.String(), removed.ID.String()); w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for attachment removed from ServiceNow, got %d", w.Code)
+	}
+	if w := download(stmt.ID.String(), "not-a-uuid"); w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for invalid attachment ID, got %d", w.Code)
+	}
+}
+
+// TestHandler_DownloadAttachment_Streams serves a download through the
+// server's middleware chain with timeouts shorter than the download. The
+// first chunk must reach the client before ServiceNow sends the rest, and
+// the whole file must arrive.
+func TestHandler_DownloadAttachment_Streams(t *testing.T) {
+	first, last := bytes.Repeat([]byte("a"), 64<<10), bytes.Repeat([]byte("b"), 64<<10)
+	release := make(chan struct{})
+	snServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/pdf")
+		w.Write(first)
+		w.(http.Flusher).Flush()
+		select {
+		case <-release:
+		case <-time.After(5 * time.Second):
+		}
+		time.Sleep(100 * time.Millisecond)
+		w.Write(last)
+	}))
+	defer snServer.Close()
+
+	snConfig := servicenow.DefaultConfig(snServer.URL)
+	snConfig.Timeout = 50 * time.Millisecond
+	client, err := servicenow.NewSNClient(snConfig)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	stmt := &statement.Statement{ID: uuid.New(), SNSysID: "stmt456"}
+	attachment := statement.Attachment{ID: uuid.New(), StatementID: stmt.ID, SNSysID: "att123", FileName: "evidence.pdf"}
+	repo := &attachmentRepository{stmt: stmt, attachments: []statement.Attachment{attachment}}
+	mux := http.NewServeMux()
+	NewHandler(statement.NewService(repo, statement.Options{
+		SNClients: &staticClientProvider{client: client},
+	}, nil), config.PaginationDefaults{}, allowAll, nil).RegisterRoutes(mux)
+
+	var handler http.Handler = mux
+	handler = timeout.Middleware(config.TimeoutsConfig{
+		DefaultWriteTimeout: 50 * time.Millisecond,
+		PullWriteTimeout:    50 * time.Millisecond,
+		PushWriteTimeout:    50 * time.Millisecond,
+	})(handler)
+	handler = bodylimit.MaxBodySize(1<<10, 1<<10)(handler)
+	handler = requestid.Middleware(handler)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/api/v1/statements/" + stmt.ID.String() + "/attachments/" + attachment.ID.String())
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+
+	got := make([]byte, len(first))
+	if _, err := io.ReadFull(resp.Body, got); err != nil {
+		t.Fatalf("failed to read the first chunk: %v", err)
+	}
+	close(release)
+	rest, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("failed to read the rest of the file: %v", err)
+	}
+	if !bytes.Equal(append(got, rest...), append(first, last...)) {
+		t.Errorf("expected the whole file, got %d bytes", len(got)+len(rest))
+	}
+}
+
+// rePullRepository stores statements by ID and detects conflicts on upsert
+// the way the database repository does.
+type rePullRepository struct {
+	statement.Repository
+	stmts map[uuid.UUID]*statement.Statement
+}
+
+func (m *rePullRepository) GetByID(ctx context.Context, id uuid.UUID) (*statement.Statement, error) {
+	return m.stmts[id], nil
+}
+
+func (m *rePullRepository) GetSystemRef(ctx context.Context, id uuid.UUID) (*statement.SystemRef, error) {
+	if m.stmts[id] == nil {
+		return nil, nil
+	}
+	return &statement.SystemRef{ID: uuid.New(), Name: "ACME", ConnectionLabel: "staging"}, nil
+}
+
+// conflictRecorder captures conflict webhook deliveries.
+type conflictRecorder struct {
+	events chan statement.ConflictEvent
+}
+
+func (r conflictRecorder) Send(ctx context.Context, url string, payload interface{}) error {
+	r.events <- payload.(statement.ConflictEvent)
+	return nil
+}
+
+func (m *rePullRepository) Upsert(ctx context.Context, input statement.UpsertInput) (*statement.Statement, error) {
+	for _, s := range m.stmts {
+		if s.ControlID != input.ControlID || s.SNSysID != input.SNSysID {
+			continue
+		}
+		if s.IsModified {
+			if s.RemoteContent != input.RemoteContent {
+				s.RemoteContent = input.RemoteContent
+				s.SyncStatus = statement.SyncStatusConflict
+			}
+			return s, nil
+		}
+		s.RemoteContent = input.RemoteContent
+		s.SNUpdatedOn = input.SNUpdatedOn
+		return s, nil
+	}
+	return nil, statement.ErrNotFound
+}
+
+func TestHandler_RePullStatement(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/now/table/incident/sn-synced", "/api/now/table/incident/sn-modified":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"result":{"sys_id":"x","short_description":"Access is reviewed","description":"Quarterly.","sys_updated_on":"2026-03-01 10:00:00"}}`))
+		case "/api/now/table/incident/sn-unauthorized":
+			w.WriteHeader(http.StatusUnauthorized)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	client, err := servicenow.NewSNClient(servicenow.DefaultConfig(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	controlID := uuid.New()
+	newStmt := func(snSysID string, modified bool) *statement.Statement {
+		status := statement.SyncStatusSynced
+		if modified {
+			status = statement.SyncStatusModified
+		}
+		return &statement.Statement{
+			ID: uuid.New(), ControlID: controlID, SNSysID: snSysID,
+			RemoteContent: "Access is reviewed", IsModified: modified, SyncStatus: status,
+		}
+	}
+	synced := newStmt("sn-synced", false)
+	modified := newStmt("sn-modified", true)
+	removed := newStmt("sn-removed", false)
+	unauthorized := newStmt("sn-unauthorized", false)
+	local := newStmt("", false)
+	repo := &rePullRepository{stmts: map[uuid.UUID]*statement.Statement{}}
+	for _, s := range []*statement.Statement{synced, modified, removed, unauthorized, local} {
+		repo.stmts[s.ID] = s
+	}
+
+	mux := http.NewServeMux()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	provider := &staticClientProvider{client: client}
+	conflicts := conflictRecorder{events: make(chan statement.ConflictEvent, 1)}
+	NewHandler(statement.NewService(repo, statement.Options{
+		SNClients:       provider,
+		ConflictWebhook: conflicts,
+		WebhookURL:      "https://hooks.example.com/conflicts",
+	}, logger), config.PaginationDefaults{}, allowAll, logger).RegisterRoutes(mux)
+
+	rePull := func(id string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/statements/"+id+"/re-pull", nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+	decode := func(w *httptest.ResponseRecorder) StatementResponse {
+		t.Helper()
+		var resp StatementResponse
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		return resp
+	}
+
+	w := rePull(synced.ID.String())
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if resp := decode(w); resp.SyncStatus != string(statement.SyncStatusSynced) || resp.RemoteContent != "Access is reviewed\n\nQuarterly." {
+		t.Errorf("expected synced statement with the remote content, got %+v", resp)
+	}
+	if synced.SNUpdatedOn == nil {
+		t.Error("expected sn_updated_on to be recorded")
+	}
+	if !slices.Equal(provider.labels, []string{"staging"}) {
+		t.Errorf("expected the system's connection to be used, got %v", provider.labels)
+	}
+	select {
+	case event := <-conflicts.events:
+		t.Errorf("expected no conflict webhook for a synced statement, got %+v", event)
+	default:
+	}
+
+	w = rePull(modified.ID.String())
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if resp := decode(w); resp.SyncStatus != string(statement.SyncStatusConflict) {
+		t.Errorf("expected conflict for a modified statement, got %s", resp.SyncStatus)
+	}
+	select {
+	case event := <-conflicts.events:
+		if event.StatementID != modified.ID || event.SystemName != "ACME" {
+			t.Errorf("unexpected conflict event %+v", event)
+		}
+	case <-time.After(2 * time.Second):
+		t.Error("expected a conflict webhook for the new conflict")
+	}
+
+	if w := rePull(modified.ID.String()); w.Code != http.StatusOK {
+		t.Fatalf("expected 200 re-pulling a conflict, got %d", w.Code)
+	}
+	select {
+	case event := <-conflicts.events:
+		t.Errorf("expected no webhook for a statement already in conflict, got %+v", event)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	if w := rePull(removed.ID.String()); w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for statement removed from ServiceNow, got %d", w.Code)
+	}
+	if w := rePull(unauthorized.ID.String()); w.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401 for ServiceNow auth failure, got %d", w.Code)
+	}
+	if w := rePull(local.ID.String()); w.Code != http.StatusConflict {
+		t.Errorf("expected 409 for statement without a ServiceNow record, got %d", w.Code)
+	}
+	if w := rePull(uuid.NewString()); w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for unknown statement, got %d", w.Code)
+	}
+	if w := rePull("not-a-uuid"); w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for invalid ID, got %d", w.Code)
+	}
+}
+
+// identityRepository records the user IDs the handler passes for reviews,
+// tags, templates and template edits.
+type identityRepository struct {
+	statement.Repository
+	stmt     *statement.Statement
+	tmpl     *statement.Template
+	reviewed *statement.ReviewInput
+	tagger   *uuid.UUID
+	created  *statement.CreateTemplateInput
+	updated  *statement.UpdateInput
+}
+
+func (m *identityRepository) GetByID(ctx context.Context, id uuid.UUID) (*statement.Statement, error) {
+	return m.stmt, nil
+}
+
+func (m *identityRepository) ListReadOnly(ctx context.Context, ids []uuid.UUID) ([]uuid.UUID, error) {
+	return nil, nil
+}
+
+func (m *identityRepository) SetReview(ctx context.Context, input statement.ReviewInput) (*statement.Statement, error) {
+	m.reviewed = &input
+	return m.stmt, nil
+}
+
+func (m *identityRepository) AddTags(ctx context.Context, statementID uuid.UUID, tags []string, createdBy *uuid.UUID) error {
+	m.tagger = createdBy
+	return nil
+}
+
+func (m *identityRepository) CreateTemplate(ctx context.Context, input statement.CreateTemplateInput) (*statement.Template, error) {
+	m.created = &input
+	return m.tmpl, nil
+}
+
+func (m *identityRepository) GetTemplate(ctx context.Context, id uuid.UUID) (*statement.Template, error) {
+	return m.tmpl, nil
+}
+
+func (m *identityRepository) UpdateLocal(ctx context.Context, input statement.UpdateInput) (*statement.Statement, error) {
+	m.updated = &input
+	return m.stmt, nil
+}
+
+func TestHandler_RecordsBearerTokenUser(t *testing.T) {
+	const secret = "test-secret"
+	userID := uuid.New()
+	author := uuid.New()
+	repo := &identityRepository{
+		stmt: &statement.Statement{ID: uuid.New(), RemoteContent: "Remote text.", IsModified: true, ModifiedBy: &author},
+		tmpl: &statement.Template{ID: uuid.New(), Name: "owner", ContentTemplate: "Access is reviewed quarterly by {{owner}}.", Variables: []string{"owner"}},
+	}
+	mux := http.NewServeMux()
+	NewHandler(statement.NewService(repo, statement.Options{ReviewRequired: true}, nil), config.PaginationDefaults{},
+		auth.RequireRole(secret, auth.RoleReviewer), nil).RegisterRoutes(mux)
+	handler := auth.Middleware(secret)(mux)
+	authorization := authtest.Bearer(secret, auth.Claims{Subject: userID.String(), Email: "alice@example.com", Role: auth.RoleReviewer})
+
+	send := func(target, body string) {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+		req.Header.Set("Authorization", authorization)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		if w.Code != http.StatusOK && w.Code != http.StatusCreated {
+			t.Fatalf("POST %s: expected success, got %d: %s", target, w.Code, w.Body.String())
+		}
+	}
+	stmtPath := "/api/v1/statements/" + repo.stmt.ID.String()
+
+	req := httptest.NewRequest(http.MethodPost, stmtPath+"/approve", nil)
+	req.Header.Set("Authorization", authtest.Bearer(secret, auth.Claims{Subject: userID.String()}))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	if w.Code != http.StatusForbidden || repo.reviewed != nil {
+		t.Fatalf("expected 403 without the reviewer role, got %d", w.Code)
+	}
+
+	send(stmtPath+"/approve", `{}`)
+	if repo.reviewed == nil || repo.reviewed.ReviewedBy == nil || *repo.reviewed.ReviewedBy != userID {
+		t.Errorf("expected reviewed_by %s, got %+v", userID, repo.reviewed)
+	}
+
+	send(stmtPath+"/tags", `{"tags":["access"]}`)
+	if repo.tagger == nil || *repo.tagger != userID {
+		t.Errorf("expected tag created_by %s, got %v", userID, repo.tagger)
+	}
+
+	send("/api/v1/statement-templates", `{"name":"owner","content_template":"Access is reviewed quarterly by {{owner}}."}`)
+	if repo.created == nil || repo.created.CreatedBy == nil || *repo.created.CreatedBy != userID {
+		t.Errorf("expected template created_by %s, got %+v", userID, repo.created)
+	}
+
+	send("/api/v1/statements/from-template", `{"statement_id":"`+repo.stmt.ID.String()+`","template_id":"`+repo.tmpl.ID.String()+`","variables":{"owner":"Alice"}}`)
+	if repo.updated == nil || repo.updated.ModifiedBy == nil || *repo.updated.ModifiedBy != userID {
+		t.Fatalf("expected modified_by %s, got %+v", userID, repo.updated)
+	}
+	if repo.updated.ModifiedByEmail != "alice@example.com" {
+		t.Errorf("expected modified_by_email 'alice@example.com', got %q", repo.updated.ModifiedByEmail)
+	}
+}
@@ -19,19 +19,31 @@ type StatementResponse struct {
 	LocalContent    string     `json:"local_content,omitempty"`
 	IsModified      bool       `json:"is_modified"`
 	ModifiedAt      *time.Time `json:"modified_at,omitempty"`
+	ModifiedByEmail string     `json:"modified_by_email,omitempty"`
 
 	// Sync status
 	SyncStatus         string     `json:"sync_status"`
 	ConflictResolvedAt *time.Time `json:"conflict_resolved_at,omitempty"`
 
+	// Review workflow
+	ReviewStatus  string     `json:"review_status,omitempty"`
+	ReviewedBy    *uuid.UUID `json:"reviewed_by,omitempty"`
+	ReviewedAt    *time.Time `json:"reviewed_at,omitempty"`
+	ReviewComment string     `json:"review_comment,omitempty"`
+
 	// Computed field for display
 	EffectiveContent string `json:"effective_content"`
 
+	Tags []string `json:"tags"`
+
 	// Timestamps
 	LastPullAt *time.Time `json:"last_pull_at,omitempty"`
 	LastPushAt *time.Time `json:"last_push_at,omitempty"`
 	CreatedAt  time.Time  `json:"created_at"`
 	UpdatedAt  time.Time  `json:"updated_at"`
+
+	// LastPushJobID is the push job that last pushed the statement.
+	LastPushJobID *uuid.UUID `json:"last_push_job_id,omitempty"`
 }
 
 // ListStatementsResponse is the response for listing statements.
@@ -48,26 +60,133 @@ type UpdateStatementRequest struct {
 	LocalContent string `json:"local_content"`
 }
 
+// AddTagsRequest is the request to tag a statement.
+type AddTagsRequest struct {
+	Tags []string `json:"tags"`
+}
+
 // ResolveConflictRequest is the request to resolve a sync conflict.
 type ResolveConflictRequest struct {
 	Resolution    string `json:"resolution"` // "keep_local", "keep_remote", "merge"
 	MergedContent string `json:"merged_content,omitempty"`
 }
 
-// ErrorResponse represents an error response.
-type ErrorResponse struct {
-	Error   string `json:"error"`
-	Message string `json:"message,omitempty"`
+// ReviewStatementRequest is the optional request body to approve or reject a statement.
+type ReviewStatementRequest struct {
+	Comment string `json:"comment,omitempty"`
 }
 
-// ModifiedStatementsResponse is the response for listing modified statements.
-type ModifiedStatementsResponse struct {
-	Statements []StatementResponse `json:"statements"`
-	Count      int                 `json:"count"`
+// StatementTypeResponse represents a registered statement type.
+type StatementTypeResponse struct {
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
 }
 
-// ConflictStatementsResponse is the response for listing conflict statements.
-type ConflictStatementsResponse struct {
-	Statements []StatementResponse `json:"statements"`
-	Count      int                 `json:"count"`
+// ListStatementTypesResponse is the response for listing statement types.
+type ListStatementTypesResponse struct {
+	Types []StatementTypeResponse `json:"types"`
+}
+
+// CreateStatementTypeRequest is the request to register a statement type.
+type CreateStatementTypeRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// StatementTemplateResponse represents a statement template in API responses.
+type StatementTemplateResponse struct {
+	ID              uuid.UUID  `json:"id"`
+	Name            string     `json:"name"`
+	ContentTemplate string     `json:"content_template"`
+	Variables       []string   `json:"variables"`
+	CreatedBy       *uuid.UUID `json:"created_by,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+// CreateStatementTemplateRequest is the request body for creating a statement template.
+type CreateStatementTemplateRequest struct {
+	Name            string   `json:"name"`
+	ContentTemplate string   `json:"content_template"`
+	Variables       []string `json:"variables,omitempty"`
+}
+
+// ApplyTemplateRequest is the request body for rendering a template into a statement.
+type ApplyTemplateRequest struct {
+	StatementID uuid.UUID         `json:"statement_id"`
+	TemplateID  uuid.UUID         `json:"template_id"`
+	Variables   map[string]string `json:"variables"`
+}
+
+// MissingVariablesResponse is returned when template variables were not supplied.
+type MissingVariablesResponse struct {
+	Error            string   `json:"error"`
+	MissingVariables []string `json:"missing_variables"`
+}
+
+// RevertPreviewResponse shows what reverting a modified statement would discard.
+type RevertPreviewResponse struct {
+	CurrentLocalContent string `json:"current_local_content"`
+	RemoteContent       string `json:"remote_content"`
+	CharsLost           int    `json:"chars_lost"`
+	WordsLost           int    `json:"words_lost"`
+}
+
+// RevertPreviewNoChangesResponse is returned for statements without local changes.
+type RevertPreviewNoChangesResponse struct {
+	NoChanges bool `json:"no_changes"`
+}
+
+// FreshnessResponse reports whether a statement's ServiceNow record changed
+// after the last pull. IsStale is null when ServiceNow could not be asked.
+type FreshnessResponse struct {
+	IsStale          *bool      `json:"is_stale"`
+	SNUpdatedOn      *time.Time `json:"sn_updated_on,omitempty"`
+	LocalSNUpdatedOn *time.Time `json:"local_sn_updated_on,omitempty"`
+	Error            string     `json:"error,omitempty"`
+}
+
+// ReadabilityResponse is the Flesch reading-ease score of a statement's
+// content. GradeLevel is empty when the content has no words.
+type ReadabilityResponse struct {
+	Score         float64 `json:"score"`
+	GradeLevel    string  `json:"grade_level"`
+	WordCount     int     `json:"word_count"`
+	SentenceCount int     `json:"sentence_count"`
+}
+
+// SimilarStatementResponse is a statement whose content resembles the
+// requested statement's.
+type SimilarStatementResponse struct {
+	StatementID     uuid.UUID `json:"statement_id"`
+	SimilarityScore float64   `json:"similarity_score"`
+	Preview         string    `json:"preview"`
+}
+
+// AttachmentResponse represents an evidence file attached to a statement.
+type AttachmentResponse struct {
+	ID          uuid.UUID `json:"id"`
+	StatementID uuid.UUID `json:"statement_id"`
+	SNSysID     string    `json:"sn_sys_id"`
+	FileName    string    `json:"file_name"`
+	UploadedAt  time.Time `json:"uploaded_at"`
+}
+
+// BulkDeleteRequest selects statements to delete. Exactly one of
+// StatementIDs and ControlID must be set.
+type BulkDeleteRequest struct {
+	StatementIDs []uuid.UUID `json:"statement_ids,omitempty"`
+	ControlID    *uuid.UUID  `json:"control_id,omitempty"`
+	Force        bool        `json:"force"` // Also delete locally modified statements
+}
+
+// BulkDeleteResponse reports how many statements were deleted.
+type BulkDeleteResponse struct {
+	DeletedCount int `json:"deleted_count"`
+}
+
+// ErrorResponse represents an error response.
+type ErrorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message,omitempty"`
 }
@@ -2,32 +2,46 @@ package sync
 
 import (
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 
+	"github.com/controlcrud/backend/internal/api"
+	"github.com/controlcrud/backend/internal/api/middleware/requestid"
+	"github.com/controlcrud/backend/internal/config"
+	"github.com/controlcrud/backend/internal/domain/control"
 	"github.com/controlcrud/backend/internal/domain/pull"
+	"github.com/controlcrud/backend/internal/domain/statement"
 	"github.com/controlcrud/backend/internal/domain/system"
 )
 
 // Handler handles sync-related HTTP requests.
 type Handler struct {
-	systemService *system.Service
-	pullService   *pull.Service
-	logger        *slog.Logger
+	systemService  *system.Service
+	pullService    *pull.Service
+	controlService *control.Service
+	stmtService    *statement.Service
+	pagination     config.PaginationDefaults
+	logger         *slog.Logger
 }
 
 // NewHandler creates a new sync handler.
-func NewHandler(systemService *system.Service, pullService *pull.Service, logger *slog.Logger) *Handler {
+func NewHandler(systemService *system.Service, pullService *pull.Service, controlService *control.Service, stmtService *statement.Service, pagination config.PaginationDefaults, logger *slog.Logger) *Handler {
 	if logger == nil {
 		logger = slog.Default()
 	}
 	return &Handler{
-		systemService: systemService,
-		pullService:   pullService,
-		logger:        logger,
+		systemService:  systemService,
+		pullService:    pullService,
+		controlService: controlService,
+		stmtService:    stmtService,
+		pagination:     pagination,
+		logger:         logger,
 	}
 }
 
@@ -37,26 +51,42 @@ func (h *Handler) RegisterRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("GET /api/v1/sync/systems/discover", h.DiscoverSystems)
 	mux.HandleFunc("GET /api/v1/sync/systems", h.ListSystems)
 	mux.HandleFunc("POST /api/v1/sync/systems/import", h.ImportSystems)
+	mux.HandleFunc("GET /api/v1/sync/systems/compare", h.CompareSystems)
+	mux.HandleFunc("GET /api/v1/sync/systems/{id}/summary", h.GetSystemSummary)
+	mux.HandleFunc("GET /api/v1/sync/systems/{id}/control-families", h.GetControlFamilyStats)
+	mux.HandleFunc("GET /api/v1/sync/systems/{id}/coverage-gaps", h.GetCoverageGaps)
+	mux.HandleFunc("GET /api/v1/sync/systems/{id}/controls/{control_id}/statements", h.ListControlStatements)
+	mux.HandleFunc("PATCH /api/v1/sync/systems/batch-status", h.BatchUpdateStatus)
+	mux.HandleFunc("PATCH /api/v1/sync/systems/{id}", h.UpdateSystem)
 	mux.HandleFunc("DELETE /api/v1/sync/systems/{id}", h.DeleteSystem)
+	mux.HandleFunc("POST /api/v1/sync/systems/{id}/restore", h.RestoreSystem)
+	mux.HandleFunc("PUT /api/v1/sync/systems/{id}/conflict-strategy", h.SetConflictStrategy)
+	mux.HandleFunc("GET /api/v1/dashboard/sync-status", h.GetSyncStatusDashboard)
 
 	// Pull operations
 	mux.HandleFunc("POST /api/v1/sync/pull", h.StartPull)
+	mux.HandleFunc("GET /api/v1/sync/pull", h.ListPullJobs)
 	mux.HandleFunc("GET /api/v1/sync/pull/{id}", h.GetPullStatus)
+	mux.HandleFunc("GET /api/v1/sync/pull/{id}/events", h.GetPullEvents)
 	mux.HandleFunc("DELETE /api/v1/sync/pull/{id}", h.CancelPull)
+	mux.HandleFunc("POST /api/v1/sync/pull/{id}/pause", h.PausePull)
+	mux.HandleFunc("POST /api/v1/sync/pull/{id}/resume", h.ResumePull)
 }
 
 // DiscoverSystems fetches systems from ServiceNow and marks imported ones.
+// force=true bypasses the discovery cache.
 func (h *Handler) DiscoverSystems(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	discovered, err := h.systemService.DiscoverSystems(ctx)
+	force, _ := strconv.ParseBool(r.URL.Query().Get("force"))
+	discovered, err := h.systemService.DiscoverSystems(ctx, force)
 	if err != nil {
-		h.logger.Error("failed to discover systems", "error", err)
+		requestid.Logger(r.Context(), h.logger).Error("failed to discover systems", "error", err)
 		if err == system.ErrNoConnection {
-			h.writeError(w, http.StatusBadRequest, "ServiceNow connection not configured")
+			h.writeError(w, http.StatusBadRequest, api.ErrorCodeFor(err), "ServiceNow connection not configured")
 			return
 		}
-		h.writeError(w, http.StatusInternalServerError, "Failed to discover systems")
+		h.writeError(w, http.StatusInternalServerError, api.ErrorCodeFor(err), "Failed to discover systems")
 		return
 	}
 
@@ -85,10 +115,9 @@ func (h *Handler) ListSystems(w http.ResponseWriter, r *http.Request) {
 
 	// Parse query params
 	params := system.ListParams{
-		Page:     1,
-		PageSize: 20,
-		Search:   r.URL.Query().Get("search"),
-		Status:   r.URL.Query().Get("status"),
+		Page:   1,
+		Search: r.URL.Query().Get("search"),
+		Status: r.URL.Query().Get("status"),
 	}
 
 	if page := r.URL.Query().Get("page"); page != "" {
@@ -97,16 +126,18 @@ func (h *Handler) ListSystems(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	if pageSize := r.URL.Query().Get("page_size"); pageSize != "" {
-		if ps, err := strconv.Atoi(pageSize); err == nil && ps > 0 {
-			params.PageSize = ps
-		}
+	requestedPageSize, _ := strconv.Atoi(r.URL.Query().Get("page_size"))
+	params.PageSize = h.pagination.SystemsPageSize(requestedPageSize)
+
+	// Soft-deleted systems are only listed on request (admin view)
+	if includeDeleted, err := strconv.ParseBool(r.URL.Query().Get("include_deleted")); err == nil {
+		params.IncludeDeleted = includeDeleted
 	}
 
 	result, err := h.systemService.ListSystems(ctx, params)
 	if err != nil {
-		h.logger.Error("failed to list systems", "error", err)
-		h.writeError(w, http.StatusInternalServerError, "Failed to list systems")
+		requestid.Logger(r.Context(), h.logger).Error("failed to list systems", "error", err)
+		h.writeError(w, http.StatusInternalServerError, api.ErrorCodeFor(err), "Failed to list systems")
 		return
 	}
 
@@ -121,20 +152,23 @@ func (h *Handler) ListSystems(w http.ResponseWriter, r *http.Request) {
 
 	for _, s := range result.Systems {
 		response.Systems = append(response.Systems, LocalSystemResponse{
-			ID:             s.ID,
-			SNSysID:        s.SNSysID,
-			Name:           s.Name,
-			Description:    s.Description,
-			Acronym:        s.Acronym,
-			Owner:          s.Owner,
-			Status:         s.Status,
-			ControlCount:   s.ControlCount,
-			StatementCount: s.StatementCount,
-			ModifiedCount:  s.ModifiedCount,
-			LastPullAt:     s.LastPullAt,
-			LastPushAt:     s.LastPushAt,
-			CreatedAt:      s.CreatedAt,
-			UpdatedAt:      s.UpdatedAt,
+			ID:               s.ID,
+			SNSysID:          s.SNSysID,
+			Name:             s.Name,
+			Description:      s.Description,
+			Acronym:          s.Acronym,
+			Owner:            s.Owner,
+			Status:           s.Status,
+			ConflictStrategy: s.ConflictStrategy,
+			ImportMode:       s.ImportMode,
+			ControlCount:     s.ControlCount,
+			StatementCount:   s.StatementCount,
+			ModifiedCount:    s.ModifiedCount,
+			LastPullAt:       s.LastPullAt,
+			LastPushAt:       s.LastPushAt,
+			CreatedAt:        s.CreatedAt,
+			UpdatedAt:        s.UpdatedAt,
+			DeletedAt:        s.DeletedAt,
 		})
 	}
 
@@ -147,28 +181,33 @@ func (h *Handler) ImportSystems(w http.ResponseWriter, r *http.Request) {
 
 	var req ImportSystemsRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.writeError(w, http.StatusBadRequest, "Invalid request body")
+		h.writeError(w, http.StatusBadRequest, api.ErrCodeInvalidJSON, "Invalid request body")
 		return
 	}
 
 	if len(req.SNSysIDs) == 0 {
-		h.writeError(w, http.StatusBadRequest, "At least one system ID is required")
+		h.writeError(w, http.StatusBadRequest, api.ErrCodeValidation, "At least one system ID is required")
 		return
 	}
 
 	if len(req.SNSysIDs) > 10 {
-		h.writeError(w, http.StatusBadRequest, "Maximum 10 systems can be imported at once")
+		h.writeError(w, http.StatusBadRequest, api.ErrCodeValidation, "Maximum 10 systems can be imported at once")
+		return
+	}
+
+	if req.ImportMode != "" && !system.IsValidImportMode(req.ImportMode) {
+		h.writeError(w, http.StatusBadRequest, api.ErrCodeValidation, "import_mode must be editable or read_only")
 		return
 	}
 
-	imported, err := h.systemService.ImportSystems(ctx, req.SNSysIDs)
+	imported, err := h.systemService.ImportSystems(ctx, req.SNSysIDs, req.ImportMode)
 	if err != nil {
-		h.logger.Error("failed to import systems", "error", err)
+		requestid.Logger(r.Context(), h.logger).Error("failed to import systems", "error", err)
 		if err == system.ErrNoConnection {
-			h.writeError(w, http.StatusBadRequest, "ServiceNow connection not configured")
+			h.writeError(w, http.StatusBadRequest, api.ErrorCodeFor(err), "ServiceNow connection not configured")
 			return
 		}
-		h.writeError(w, http.StatusInternalServerError, "Failed to import systems")
+		h.writeError(w, http.StatusInternalServerError, api.ErrorCodeFor(err), "Failed to import systems")
 		return
 	}
 
@@ -187,11 +226,29 @@ func (h *Handler) ImportSystems(w http.ResponseWriter, r *http.Request) {
 			Acronym:     s.Acronym,
 			Owner:       s.Owner,
 			Status:      s.Status,
+			ImportMode:  s.ImportMode,
 			CreatedAt:   s.CreatedAt,
 			UpdatedAt:   s.UpdatedAt,
 		})
 	}
 
+	// Optionally queue a pull for the imported systems. The import itself
+	// has already succeeded, so a failure here is reported, not fatal.
+	if req.EagerFetch && len(imported) > 0 {
+		systemIDs := make([]uuid.UUID, 0, len(imported))
+		for _, s := range imported {
+			systemIDs = append(systemIDs, s.ID)
+		}
+
+		job, err := h.pullService.StartPull(ctx, systemIDs, "")
+		if err != nil {
+			requestid.Logger(r.Context(), h.logger).Error("failed to start pull after import", "error", err)
+			response.PullError = err.Error()
+		} else {
+			response.PullJobID = &job.ID
+		}
+	}
+
 	h.writeJSON(w, http.StatusCreated, response)
 }
 
@@ -201,23 +258,23 @@ func (h *Handler) DeleteSystem(w http.ResponseWriter, r *http.Request) {
 
 	idStr := r.PathValue("id")
 	if idStr == "" {
-		h.writeError(w, http.StatusBadRequest, "System ID is required")
+		h.writeError(w, http.StatusBadRequest, api.ErrCodeInvalidID, "System ID is required")
 		return
 	}
 
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		h.writeError(w, http.StatusBadRequest, "Invalid system ID format")
+		h.writeError(w, http.StatusBadRequest, api.ErrCodeInvalidID, "Invalid system ID format")
 		return
 	}
 
 	if err := h.systemService.DeleteSystem(ctx, id); err != nil {
-		h.logger.Error("failed to delete system", "error", err, "id", idStr)
+		requestid.Logger(r.Context(), h.logger).Error("failed to delete system", "error", err, "id", idStr)
 		if err == system.ErrNotFound {
-			h.writeError(w, http.StatusNotFound, "System not found")
+			h.writeError(w, http.StatusNotFound, api.ErrorCodeFor(err), "System not found")
 			return
 		}
-		h.writeError(w, http.StatusInternalServerError, "Failed to delete system")
+		h.writeError(w, http.StatusInternalServerError, api.ErrorCodeFor(err), "Failed to delete system")
 		return
 	}
 
@@ -226,6 +283,401 @@ func (h *Handler) DeleteSystem(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// RestoreSystem restores a soft-deleted system.
+func (h *Handler) RestoreSystem(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	idStr := r.PathValue("id")
+	if idStr == "" {
+		h.writeError(w, http.StatusBadRequest, api.ErrCodeInvalidID, "System ID is required")
+		return
+	}
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, api.ErrCodeInvalidID, "Invalid system ID format")
+		return
+	}
+
+	if _, err := h.systemService.RestoreSystem(ctx, id); err != nil {
+		requestid.Logger(r.Context(), h.logger).Error("failed to restore system", "error", err, "id", idStr)
+		if err == system.ErrNotFound {
+			h.writeError(w, http.StatusNotFound, api.ErrorCodeFor(err), "System not found")
+			return
+		}
+		h.writeError(w, http.StatusInternalServerError, api.ErrorCodeFor(err), "Failed to restore system")
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, map[string]string{
+		"message": "System restored successfully",
+	})
+}
+
+// UpdateSystem handles PATCH /api/v1/sync/systems/{id}
+// It applies local edits to the system's acronym, owner and description.
+// Fields omitted from the body are left unchanged.
+func (h *Handler) UpdateSystem(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	idStr := r.PathValue("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, api.ErrCodeInvalidID, "Invalid system ID format")
+		return
+	}
+
+	var req UpdateSystemRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, http.StatusBadRequest, api.ErrCodeInvalidJSON, "Invalid request body")
+		return
+	}
+
+	sys, err := h.systemService.UpdateMetadata(ctx, id, system.MetadataPatch{
+		Acronym:     req.Acronym,
+		Owner:       req.Owner,
+		Description: req.Description,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, system.ErrInvalidInput):
+			h.writeError(w, http.StatusBadRequest, api.ErrorCodeFor(err), err.Error())
+		case errors.Is(err, system.ErrNotFound):
+			h.writeError(w, http.StatusNotFound, api.ErrorCodeFor(err), "System not found")
+		default:
+			requestid.Logger(r.Context(), h.logger).Error("failed to update system", "error", err, "id", idStr)
+			h.writeError(w, http.StatusInternalServerError, api.ErrorCodeFor(err), "Failed to update system")
+		}
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, LocalSystemResponse{
+		ID:               sys.ID,
+		SNSysID:          sys.SNSysID,
+		Name:             sys.Name,
+		Description:      sys.Description,
+		Acronym:          sys.Acronym,
+		Owner:            sys.Owner,
+		Status:           sys.Status,
+		ConflictStrategy: sys.ConflictStrategy,
+		ImportMode:       sys.ImportMode,
+		LastPullAt:       sys.LastPullAt,
+		LastPushAt:       sys.LastPushAt,
+		CreatedAt:        sys.CreatedAt,
+		UpdatedAt:        sys.UpdatedAt,
+	})
+}
+
+// BatchUpdateStatus sets the status of several systems at once.
+func (h *Handler) BatchUpdateStatus(w http.ResponseWriter, r *http.Request) {
+	var req BatchStatusRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, http.StatusBadRequest, api.ErrCodeInvalidJSON, "Invalid request body")
+		return
+	}
+
+	updated, err := h.systemService.BatchUpdateStatus(r.Context(), req.SystemIDs, req.Status)
+	if err != nil {
+		if errors.Is(err, system.ErrInvalidInput) {
+			h.writeError(w, http.StatusBadRequest, api.ErrorCodeFor(err), err.Error())
+			return
+		}
+		requestid.Logger(r.Context(), h.logger).Error("failed to update system status", "error", err)
+		h.writeError(w, http.StatusInternalServerError, api.ErrorCodeFor(err), "Failed to update system status")
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, BatchStatusResponse{Updated: updated})
+}
+
+// SetConflictStrategy sets the conflict strategy used when pulling a system.
+func (h *Handler) SetConflictStrategy(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	idStr := r.PathValue("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, api.ErrCodeInvalidID, "Invalid system ID format")
+		return
+	}
+
+	var req SetConflictStrategyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, http.StatusBadRequest, api.ErrCodeInvalidJSON, "Invalid request body")
+		return
+	}
+
+	sys, err := h.systemService.SetConflictStrategy(ctx, id, req.Strategy)
+	if err != nil {
+		switch {
+		case errors.Is(err, system.ErrInvalidInput):
+			h.writeError(w, http.StatusBadRequest, api.ErrorCodeFor(err), "Strategy must be manual, keep_local or keep_remote")
+		case errors.Is(err, system.ErrNotFound):
+			h.writeError(w, http.StatusNotFound, api.ErrorCodeFor(err), "System not found")
+		default:
+			requestid.Logger(r.Context(), h.logger).Error("failed to set conflict strategy", "error", err, "id", idStr)
+			h.writeError(w, http.StatusInternalServerError, api.ErrorCodeFor(err), "Failed to set conflict strategy")
+		}
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, LocalSystemResponse{
+		ID:               sys.ID,
+		SNSysID:          sys.SNSysID,
+		Name:             sys.Name,
+		Description:      sys.Description,
+		Acronym:          sys.Acronym,
+		Owner:            sys.Owner,
+		Status:           sys.Status,
+		ConflictStrategy: sys.ConflictStrategy,
+		ImportMode:       sys.ImportMode,
+		LastPullAt:       sys.LastPullAt,
+		LastPushAt:       sys.LastPushAt,
+		CreatedAt:        sys.CreatedAt,
+		UpdatedAt:        sys.UpdatedAt,
+	})
+}
+
+// GetSystemSummary returns aggregate compliance figures for a system.
+func (h *Handler) GetSystemSummary(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	idStr := r.PathValue("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, api.ErrCodeInvalidID, "Invalid system ID format")
+		return
+	}
+
+	summary, err := h.systemService.GetSummary(ctx, id)
+	if err != nil {
+		if err == system.ErrNotFound {
+			h.writeError(w, http.StatusNotFound, api.ErrorCodeFor(err), "System not found")
+			return
+		}
+		requestid.Logger(r.Context(), h.logger).Error("failed to get system summary", "error", err, "id", idStr)
+		h.writeError(w, http.StatusInternalServerError, api.ErrorCodeFor(err), "Failed to get system summary")
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, SystemSummaryResponse{
+		SystemID:             summary.SystemID,
+		Name:                 summary.Name,
+		TotalControls:        summary.TotalControls,
+		TotalStatements:      summary.TotalStatements,
+		SyncedCount:          summary.SyncedCount,
+		ModifiedCount:        summary.ModifiedCount,
+		ConflictCount:        summary.ConflictCount,
+		LastPullAt:           summary.LastPullAt,
+		CompliancePercentage: summary.CompliancePercentage,
+	})
+}
+
+// GetControlFamilyStats returns control and statement counts per control
+// family of a system, ordered by family.
+func (h *Handler) GetControlFamilyStats(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	idStr := r.PathValue("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, api.ErrCodeInvalidID, "Invalid system ID format")
+		return
+	}
+
+	stats, err := h.systemService.GetControlFamilyStats(ctx, id)
+	if err != nil {
+		if err == system.ErrNotFound {
+			h.writeError(w, http.StatusNotFound, api.ErrorCodeFor(err), "System not found")
+			return
+		}
+		requestid.Logger(r.Context(), h.logger).Error("failed to get control family stats", "error", err, "id", idStr)
+		h.writeError(w, http.StatusInternalServerError, api.ErrorCodeFor(err), "Failed to get control family stats")
+		return
+	}
+
+	response := make([]ControlFamilyStatsResponse, 0, len(stats))
+	for _, s := range stats {
+		response = append(response, ControlFamilyStatsResponse{
+			Family:             s.Family,
+			TotalControls:      s.TotalControls,
+			SyncedStatements:   s.SyncedStatements,
+			ModifiedStatements: s.ModifiedStatements,
+			ConflictStatements: s.ConflictStatements,
+			CoveragePct:        s.CoveragePct,
+		})
+	}
+
+	h.writeJSON(w, http.StatusOK, response)
+}
+
+// GetCoverageGaps lists the controls of a system that have no statements,
+// optionally restricted to one control family.
+func (h *Handler) GetCoverageGaps(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	idStr := r.PathValue("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, api.ErrCodeInvalidID, "Invalid system ID format")
+		return
+	}
+
+	gaps, err := h.systemService.GetCoverageGaps(ctx, id)
+	if err != nil {
+		if err == system.ErrNotFound {
+			h.writeError(w, http.StatusNotFound, api.ErrorCodeFor(err), "System not found")
+			return
+		}
+		requestid.Logger(r.Context(), h.logger).Error("failed to get coverage gaps", "error", err, "id", idStr)
+		h.writeError(w, http.StatusInternalServerError, api.ErrorCodeFor(err), "Failed to get coverage gaps")
+		return
+	}
+
+	family := r.URL.Query().Get("control_family")
+
+	response := make([]CoverageGapResponse, 0, len(gaps))
+	for _, c := range gaps {
+		if family != "" && !strings.EqualFold(c.ControlFamily, family) {
+			continue
+		}
+		response = append(response, CoverageGapResponse{
+			ControlID:     c.ControlID,
+			ControlName:   c.ControlName,
+			ControlFamily: c.ControlFamily,
+		})
+	}
+
+	h.writeJSON(w, http.StatusOK, response)
+}
+
+// CompareSystems compares the controls of the systems given by the left_id
+// and right_id query parameters.
+func (h *Handler) CompareSystems(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	query := r.URL.Query()
+
+	leftID, err := uuid.Parse(query.Get("left_id"))
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, api.ErrCodeInvalidID, "left_id must be a valid system ID")
+		return
+	}
+	rightID, err := uuid.Parse(query.Get("right_id"))
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, api.ErrCodeInvalidID, "right_id must be a valid system ID")
+		return
+	}
+
+	comparison, err := h.systemService.CompareControls(ctx, leftID, rightID)
+	if err != nil {
+		if errors.Is(err, system.ErrNotFound) {
+			h.writeError(w, http.StatusNotFound, api.ErrorCodeFor(err), err.Error())
+			return
+		}
+		requestid.Logger(r.Context(), h.logger).Error("failed to compare systems", "error", err, "left_id", leftID, "right_id", rightID)
+		h.writeError(w, http.StatusInternalServerError, api.ErrorCodeFor(err), "Failed to compare systems")
+		return
+	}
+
+	response := CompareSystemsResponse{
+		OnlyInLeft:      comparison.OnlyInLeft,
+		OnlyInRight:     comparison.OnlyInRight,
+		InBothDifferent: make([]ControlDifferenceResponse, len(comparison.InBothDifferent)),
+		Identical:       comparison.Identical,
+	}
+	for i, diff := range comparison.InBothDifferent {
+		response.InBothDifferent[i] = ControlDifferenceResponse{
+			Left:  transformComparedControl(diff.Left),
+			Right: transformComparedControl(diff.Right),
+		}
+	}
+
+	h.writeJSON(w, http.StatusOK, response)
+}
+
+// ListControlStatements returns the statements of a system's control,
+// identified by its ServiceNow control ID (e.g. "AC-1") rather than the
+// internal UUID.
+func (h *Handler) ListControlStatements(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	idStr := r.PathValue("id")
+	systemID, err := uuid.Parse(idStr)
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, api.ErrCodeInvalidID, "Invalid system ID format")
+		return
+	}
+	controlIDStr := r.PathValue("control_id")
+
+	ctrl, err := h.controlService.GetByControlIDString(ctx, systemID, controlIDStr)
+	if err != nil {
+		if errors.Is(err, control.ErrNotFound) {
+			h.writeError(w, http.StatusNotFound, api.ErrorCodeFor(err), "Control not found")
+			return
+		}
+		requestid.Logger(ctx, h.logger).Error("failed to get control", "error", err, "system_id", idStr, "control_id", controlIDStr)
+		h.writeError(w, http.StatusInternalServerError, api.ErrorCodeFor(err), "Failed to get control")
+		return
+	}
+
+	stmts, err := h.stmtService.ListAllByControl(ctx, ctrl.ID)
+	if err != nil {
+		requestid.Logger(ctx, h.logger).Error("failed to list control statements", "error", err, "control_id", ctrl.ID)
+		h.writeError(w, http.StatusInternalServerError, api.ErrorCodeFor(err), "Failed to list statements")
+		return
+	}
+
+	response := make([]ControlStatementResponse, 0, len(stmts))
+	for _, s := range stmts {
+		tags := s.Tags
+		if tags == nil {
+			tags = []string{}
+		}
+		response = append(response, ControlStatementResponse{
+			ID:               s.ID,
+			ControlID:        s.ControlID,
+			SNSysID:          s.SNSysID,
+			StatementType:    s.StatementType,
+			RemoteContent:    s.RemoteContent,
+			LocalContent:     s.LocalContent,
+			IsModified:       s.IsModified,
+			SyncStatus:       string(s.SyncStatus),
+			EffectiveContent: s.GetContent(),
+			Tags:             tags,
+			LastPullAt:       s.LastPullAt,
+			LastPushAt:       s.LastPushAt,
+			CreatedAt:        s.CreatedAt,
+			UpdatedAt:        s.UpdatedAt,
+		})
+	}
+
+	h.writeJSON(w, http.StatusOK, response)
+}
+
+// GetSyncStatusDashboard returns statement sync status counts across all systems.
+func (h *Handler) GetSyncStatusDashboard(w http.ResponseWriter, r *http.Request) {
+	dashboard, err := h.systemService.GetDashboard(r.Context())
+	if err != nil {
+		requestid.Logger(r.Context(), h.logger).Error("failed to get sync status dashboard", "error", err)
+		h.writeError(w, http.StatusInternalServerError, api.ErrorCodeFor(err), "Failed to get sync status dashboard")
+		return
+	}
+
+	resp := SyncStatusDashboardResponse{
+		Systems: make([]SystemSyncStatusResponse, len(dashboard.Systems)),
+		Global:  SyncStatusCountsResponse(dashboard.Global),
+	}
+	for i, sys := range dashboard.Systems {
+		resp.Systems[i] = SystemSyncStatusResponse{
+			SystemID:                 sys.SystemID,
+			Name:                     sys.Name,
+			SyncStatusCountsResponse: SyncStatusCountsResponse(sys.SyncStatusCounts),
+		}
+	}
+
+	h.writeJSON(w, http.StatusOK, resp)
+}
+
 // =============================================================================
 // PULL OPERATIONS
 // =============================================================================
@@ -236,32 +688,32 @@ func (h *Handler) StartPull(w http.ResponseWriter, r *http.Request) {
 
 	var req StartPullRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.writeError(w, http.StatusBadRequest, "Invalid request body")
+		h.writeError(w, http.StatusBadRequest, api.ErrCodeInvalidJSON, "Invalid request body")
 		return
 	}
 
 	if len(req.SystemIDs) == 0 {
-		h.writeError(w, http.StatusBadRequest, "At least one system ID is required")
+		h.writeError(w, http.StatusBadRequest, api.ErrCodeValidation, "At least one system ID is required")
 		return
 	}
 
 	if len(req.SystemIDs) > 10 {
-		h.writeError(w, http.StatusBadRequest, "Maximum 10 systems can be pulled at once")
+		h.writeError(w, http.StatusBadRequest, api.ErrCodeValidation, "Maximum 10 systems can be pulled at once")
 		return
 	}
 
-	job, err := h.pullService.StartPull(ctx, req.SystemIDs)
+	job, err := h.pullService.StartPull(ctx, req.SystemIDs, req.ConnectionLabel)
 	if err != nil {
-		h.logger.Error("failed to start pull", "error", err)
-		switch err {
-		case pull.ErrNoConnection:
-			h.writeError(w, http.StatusBadRequest, "ServiceNow connection not configured")
-		case pull.ErrConcurrentJob:
-			h.writeError(w, http.StatusConflict, "Another pull operation is already in progress")
-		case pull.ErrInvalidInput:
-			h.writeError(w, http.StatusBadRequest, "Invalid system IDs")
+		requestid.Logger(r.Context(), h.logger).Error("failed to start pull", "error", err)
+		switch {
+		case errors.Is(err, pull.ErrNoConnection):
+			h.writeError(w, http.StatusBadRequest, api.ErrorCodeFor(err), "ServiceNow connection not configured")
+		case errors.Is(err, pull.ErrConcurrentJob):
+			h.writeError(w, http.StatusConflict, api.ErrorCodeFor(err), "Pull job concurrency limit reached")
+		case errors.Is(err, pull.ErrInvalidInput):
+			h.writeError(w, http.StatusBadRequest, api.ErrorCodeFor(err), "Invalid system IDs or connection label")
 		default:
-			h.writeError(w, http.StatusInternalServerError, "Failed to start pull operation")
+			h.writeError(w, http.StatusInternalServerError, api.ErrorCodeFor(err), "Failed to start pull operation")
 		}
 		return
 	}
@@ -277,24 +729,24 @@ func (h *Handler) GetPullStatus(w http.ResponseWriter, r *http.Request) {
 
 	idStr := r.PathValue("id")
 	if idStr == "" {
-		h.writeError(w, http.StatusBadRequest, "Job ID is required")
+		h.writeError(w, http.StatusBadRequest, api.ErrCodeInvalidID, "Job ID is required")
 		return
 	}
 
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		h.writeError(w, http.StatusBadRequest, "Invalid job ID format")
+		h.writeError(w, http.StatusBadRequest, api.ErrCodeInvalidID, "Invalid job ID format")
 		return
 	}
 
 	job, err := h.pullService.GetJob(ctx, id)
 	if err != nil {
-		h.logger.Error("failed to get pull job", "error", err, "id", idStr)
+		requestid.Logger(r.Context(), h.logger).Error("failed to get pull job", "error", err, "id", idStr)
 		if err == pull.ErrNotFound {
-			h.writeError(w, http.StatusNotFound, "Pull job not found")
+			h.writeError(w, http.StatusNotFound, api.ErrorCodeFor(err), "Pull job not found")
 			return
 		}
-		h.writeError(w, http.StatusInternalServerError, "Failed to get pull job")
+		h.writeError(w, http.StatusInternalServerError, api.ErrorCodeFor(err), "Failed to get pull job")
 		return
 	}
 
@@ -303,31 +755,132 @@ func (h *Handler) GetPullStatus(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// GetPullEvents returns the event log of a pull job, optionally filtered by
+// system name and event type.
+func (h *Handler) GetPullEvents(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, api.ErrCodeInvalidID, "Invalid job ID format")
+		return
+	}
+
+	query := r.URL.Query()
+	filter := pull.EventFilter{
+		SystemName: query.Get("system_name"),
+		EventType:  query.Get("event_type"),
+	}
+
+	events, err := h.pullService.GetJobEvents(ctx, id, filter)
+	if err != nil {
+		switch {
+		case errors.Is(err, pull.ErrInvalidInput):
+			h.writeError(w, http.StatusBadRequest, api.ErrorCodeFor(err), err.Error())
+		case errors.Is(err, pull.ErrNotFound):
+			h.writeError(w, http.StatusNotFound, api.ErrorCodeFor(err), "Pull job not found")
+		default:
+			requestid.Logger(r.Context(), h.logger).Error("failed to get pull job events", "error", err, "id", id)
+			h.writeError(w, http.StatusInternalServerError, api.ErrorCodeFor(err), "Failed to get pull job events")
+		}
+		return
+	}
+
+	resp := PullEventsResponse{
+		Events: make([]PullEventResponse, len(events)),
+		Count:  len(events),
+	}
+	for i, event := range events {
+		resp.Events[i] = PullEventResponse(event)
+	}
+	h.writeJSON(w, http.StatusOK, resp)
+}
+
+// ListPullJobs returns pull job history, optionally filtered by status, system and start time.
+func (h *Handler) ListPullJobs(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	query := r.URL.Query()
+
+	filter := pull.PullListFilter{
+		Limit: 50,
+	}
+
+	if statusStr := query.Get("status"); statusStr != "" {
+		status := pull.JobStatus(statusStr)
+		if !status.IsValid() {
+			h.writeError(w, http.StatusBadRequest, api.ErrCodeValidation, "Invalid status filter")
+			return
+		}
+		filter.Status = &status
+	}
+
+	if systemIDStr := query.Get("system_id"); systemIDStr != "" {
+		systemID, err := uuid.Parse(systemIDStr)
+		if err != nil {
+			h.writeError(w, http.StatusBadRequest, api.ErrCodeInvalidID, "Invalid system ID format")
+			return
+		}
+		filter.SystemID = &systemID
+	}
+
+	if sinceStr := query.Get("since"); sinceStr != "" {
+		since, err := time.Parse(time.RFC3339, sinceStr)
+		if err != nil {
+			h.writeError(w, http.StatusBadRequest, api.ErrCodeValidation, "Invalid since timestamp, expected RFC 3339")
+			return
+		}
+		filter.Since = &since
+	}
+
+	if limitStr := query.Get("limit"); limitStr != "" {
+		if limit, err := strconv.Atoi(limitStr); err == nil {
+			filter.Limit = limit
+		}
+	}
+
+	jobs, err := h.pullService.ListJobs(ctx, filter)
+	if err != nil {
+		requestid.Logger(r.Context(), h.logger).Error("failed to list pull jobs", "error", err)
+		h.writeError(w, http.StatusInternalServerError, api.ErrorCodeFor(err), "Failed to list pull jobs")
+		return
+	}
+
+	response := ListPullJobsResponse{
+		Jobs:  make([]PullJobResponse, 0, len(jobs)),
+		Count: len(jobs),
+	}
+	for i := range jobs {
+		response.Jobs = append(response.Jobs, h.transformJob(&jobs[i]))
+	}
+
+	h.writeJSON(w, http.StatusOK, response)
+}
+
 // CancelPull cancels an active pull job.
 func (h *Handler) CancelPull(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	idStr := r.PathValue("id")
 	if idStr == "" {
-		h.writeError(w, http.StatusBadRequest, "Job ID is required")
+		h.writeError(w, http.StatusBadRequest, api.ErrCodeInvalidID, "Job ID is required")
 		return
 	}
 
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		h.writeError(w, http.StatusBadRequest, "Invalid job ID format")
+		h.writeError(w, http.StatusBadRequest, api.ErrCodeInvalidID, "Invalid job ID format")
 		return
 	}
 
 	if err := h.pullService.CancelJob(ctx, id); err != nil {
-		h.logger.Error("failed to cancel pull job", "error", err, "id", idStr)
+		requestid.Logger(r.Context(), h.logger).Error("failed to cancel pull job", "error", err, "id", idStr)
 		switch err {
 		case pull.ErrNotFound:
-			h.writeError(w, http.StatusNotFound, "Pull job not found")
+			h.writeError(w, http.StatusNotFound, api.ErrorCodeFor(err), "Pull job not found")
 		case pull.ErrJobAlreadyComplete:
-			h.writeError(w, http.StatusConflict, "Job has already completed")
+			h.writeError(w, http.StatusConflict, api.ErrorCodeFor(err), "Job has already completed")
 		default:
-			h.writeError(w, http.StatusInternalServerError, "Failed to cancel pull job")
+			h.writeError(w, http.StatusInternalServerError, api.ErrorCodeFor(err), "Failed to cancel pull job")
 		}
 		return
 	}
@@ -337,6 +890,74 @@ func (h *Handler) CancelPull(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// PausePull asks a pull job to pause after the system it is pulling.
+func (h *Handler) PausePull(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, api.ErrCodeInvalidID, "Invalid job ID format")
+		return
+	}
+
+	if err := h.pullService.PauseJob(r.Context(), id); err != nil {
+		switch {
+		case errors.Is(err, pull.ErrNotFound):
+			h.writeError(w, http.StatusNotFound, api.ErrorCodeFor(err), "Pull job not found")
+		case errors.Is(err, pull.ErrJobAlreadyComplete):
+			h.writeError(w, http.StatusConflict, api.ErrorCodeFor(err), "Job has already completed")
+		case errors.Is(err, pull.ErrJobNotRunning):
+			h.writeError(w, http.StatusConflict, api.ErrorCodeFor(err), "Job started before it could be paused; try again")
+		default:
+			requestid.Logger(r.Context(), h.logger).Error("failed to pause pull job", "error", err, "id", id)
+			h.writeError(w, http.StatusInternalServerError, api.ErrorCodeFor(err), "Failed to pause pull job")
+		}
+		return
+	}
+
+	h.writeJSON(w, http.StatusAccepted, map[string]string{
+		"message": "Pull job pausing",
+	})
+}
+
+// ResumePull queues a paused pull job to continue with its remaining systems.
+func (h *Handler) ResumePull(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, api.ErrCodeInvalidID, "Invalid job ID format")
+		return
+	}
+
+	job, err := h.pullService.ResumeJob(r.Context(), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, pull.ErrNotFound):
+			h.writeError(w, http.StatusNotFound, api.ErrorCodeFor(err), "Pull job not found")
+		case errors.Is(err, pull.ErrJobNotPaused):
+			h.writeError(w, http.StatusConflict, api.ErrorCodeFor(err), "Job is not paused")
+		case errors.Is(err, pull.ErrConcurrentJob):
+			h.writeError(w, http.StatusConflict, api.ErrorCodeFor(err), "Pull job concurrency limit reached")
+		default:
+			requestid.Logger(r.Context(), h.logger).Error("failed to resume pull job", "error", err, "id", id)
+			h.writeError(w, http.StatusInternalServerError, api.ErrorCodeFor(err), "Failed to resume pull job")
+		}
+		return
+	}
+
+	h.writeJSON(w, http.StatusAccepted, map[string]interface{}{
+		"job": h.transformJob(job),
+	})
+}
+
+// transformComparedControl converts one system's version of a compared
+// control to its response.
+func transformComparedControl(c control.Control) ComparedControlResponse {
+	return ComparedControlResponse{
+		ID:                   c.ID,
+		ControlID:            c.ControlID,
+		ControlName:          c.ControlName,
+		ImplementationStatus: c.ImplementationStatus,
+	}
+}
+
 // transformJob converts a pull.Job to PullJobResponse.
 func (h *Handler) transformJob(job *pull.Job) PullJobResponse {
 	return PullJobResponse{
@@ -351,12 +972,16 @@ func (h *Handler) transformJob(job *pull.Job) PullJobResponse {
 			TotalStatements:     job.Progress.TotalStatements,
 			CompletedStatements: job.Progress.CompletedStatements,
 			CurrentSystem:       job.Progress.CurrentSystem,
-			Errors:              job.Progress.Errors,
+			Errors:              job.Progress.Errors(),
+			CompletedSystemIDs:  job.Progress.CompletedSystemIDs,
+			RetryAttempts:       job.Progress.RetryAttempts,
 		},
 		StartedAt:   job.StartedAt,
 		CompletedAt: job.CompletedAt,
 		Error:       job.Error,
 		CreatedAt:   job.CreatedAt,
+
+		ConnectionLabel: job.ConnectionLabel,
 	}
 }
 
@@ -368,9 +993,9 @@ func (h *Handler) writeJSON(w http.ResponseWriter, status int, data interface{})
 	json.NewEncoder(w).Encode(data)
 }
 
-func (h *Handler) writeError(w http.ResponseWriter, status int, message string) {
+func (h *Handler) writeError(w http.ResponseWriter, status int, code api.ErrorCode, message string) {
 	h.writeJSON(w, status, ErrorResponse{
-		Error:   http.StatusText(status),
+		Error:   string(code),
 		Message: message,
 	})
 }
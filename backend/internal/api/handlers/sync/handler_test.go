@@ -0,0 +1,1378 @@
+package sync
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/controlcrud/backend/internal/api"
+	"github.com/controlcrud/backend/internal/config"
+	"github.com/controlcrud/backend/internal/domain/audit"
+	"github.com/controlcrud/backend/internal/domain/control"
+	"github.com/controlcrud/backend/internal/domain/pull"
+	"github.com/controlcrud/backend/internal/domain/statement"
+	"github.com/controlcrud/backend/internal/domain/system"
+	"github.com/controlcrud/backend/internal/infrastructure/servicenow"
+)
+
+// mockPullRepository implements pull.Repository for testing.
+type mockPullRepository struct {
+	jobs       []pull.Job
+	err        error
+	lastFilter pull.PullListFilter
+	created    []pull.CreateInput
+}
+
+func (m *mockPullRepository) Create(ctx context.Context, input pull.CreateInput) (*pull.Job, error) {
+	m.created = append(m.created, input)
+	job := pull.Job{ID: uuid.New(), Status: pull.JobStatusPending, SystemIDs: input.SystemIDs}
+	m.jobs = append(m.jobs, job)
+	return &job, nil
+}
+
+func (m *mockPullRepository) GetByID(ctx context.Context, id uuid.UUID) (*pull.Job, error) {
+	for _, job := range m.jobs {
+		if job.ID == id {
+			return &job, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockPullRepository) Update(ctx context.Context, input pull.UpdateInput) (*pull.Job, error) {
+	return nil, nil
+}
+
+func (m *mockPullRepository) UpdateProgress(ctx context.Context, id uuid.UUID, progress pull.Progress) error {
+	return nil
+}
+
+func (m *mockPullRepository) SetStatus(ctx context.Context, id uuid.UUID, status pull.JobStatus, errorMsg string) error {
+	return nil
+}
+
+func (m *mockPullRepository) PausePendingJob(ctx context.Context, id uuid.UUID) (bool, error) {
+	return true, nil
+}
+
+func (m *mockPullRepository) CountActiveJobs(ctx context.Context) (int, error) {
+	count := 0
+	for _, job := range m.jobs {
+		if job.Status.IsActive() {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (m *mockPullRepository) ClaimPendingJobs(ctx context.Context, limit int) ([]pull.Job, error) {
+	return nil, nil
+}
+
+func (m *mockPullRepository) AcquireSystemLock(ctx context.Context, systemID, jobID uuid.UUID) (bool, error) {
+	return true, nil
+}
+
+func (m *mockPullRepository) ReleaseSystemLock(ctx context.Context, systemID, jobID uuid.UUID) error {
+	return nil
+}
+
+// List applies the status and system filters so tests can exercise varying job states.
+func (m *mockPullRepository) List(ctx context.Context, filter pull.PullListFilter) ([]pull.Job, error) {
+	m.lastFilter = filter
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	var jobs []pull.Job
+	for _, job := range m.jobs {
+		if filter.Status != nil && job.Status != *filter.Status {
+			continue
+		}
+		if filter.SystemID != nil && !containsID(job.SystemIDs, *filter.SystemID) {
+			continue
+		}
+		jobs = append(jobs, job)
+		if len(jobs) == filter.Limit {
+			break
+		}
+	}
+	return jobs, nil
+}
+
+func containsID(ids []uuid.UUID, id uuid.UUID) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
+
+func newTestHandler(repo *mockPullRepository) http.Handler {
+	pullService := pull.NewService(repo, nil, nil, nil, nil, pull.Options{}, nil)
+	mux := http.NewServeMux()
+	NewHandler(nil, pullService, nil, nil, config.PaginationDefaults{}, nil).RegisterRoutes(mux)
+	return mux
+}
+
+func testJobs(systemID uuid.UUID) []pull.Job {
+	now := time.Now()
+	return []pull.Job{
+		{ID: uuid.New(), SystemIDs: []uuid.UUID{systemID}, Status: pull.JobStatusCompleted, CreatedAt: now},
+		{ID: uuid.New(), SystemIDs: []uuid.UUID{uuid.New()}, Status: pull.JobStatusFailed, Error: "ServiceNow timeout", CreatedAt: now.Add(-time.Hour)},
+		{ID: uuid.New(), SystemIDs: []uuid.UUID{systemID}, Status: pull.JobStatusCancelled, CreatedAt: now.Add(-2 * time.Hour)},
+		{ID: uuid.New(), SystemIDs: []uuid.UUID{systemID}, Status: pull.JobStatusRunning, CreatedAt: now.Add(-3 * time.Hour)},
+	}
+}
+
+func decodeJobs(t *testing.T, w *httptest.ResponseRecorder) ListPullJobsResponse {
+	t.Helper()
+	var resp ListPullJobsResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	return resp
+}
+
+func TestHandler_ListPullJobs_All(t *testing.T) {
+	repo := &mockPullRepository{jobs: testJobs(uuid.New())}
+	handler := newTestHandler(repo)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/sync/pull", nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+
+	resp := decodeJobs(t, w)
+	if resp.Count != 4 || len(resp.Jobs) != 4 {
+		t.Errorf("expected 4 jobs, got %d", resp.Count)
+	}
+	if repo.lastFilter.Limit != 50 {
+		t.Errorf("expected default limit 50, got %d", repo.lastFilter.Limit)
+	}
+	if repo.lastFilter.Status != nil {
+		t.Error("expected no status filter")
+	}
+}
+
+func TestHandler_ListPullJobs_StatusFilter(t *testing.T) {
+	tests := []struct {
+		status pull.JobStatus
+		error  string
+	}{
+		{pull.JobStatusCompleted, ""},
+		{pull.JobStatusFailed, "ServiceNow timeout"},
+		{pull.JobStatusCancelled, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.status), func(t *testing.T) {
+			repo := &mockPullRepository{jobs: testJobs(uuid.New())}
+			handler := newTestHandler(repo)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/sync/pull?status="+string(tt.status), nil)
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("expected status 200, got %d", w.Code)
+			}
+
+			resp := decodeJobs(t, w)
+			if resp.Count != 1 {
+				t.Fatalf("expected 1 job, got %d", resp.Count)
+			}
+			if resp.Jobs[0].Status != string(tt.status) {
+				t.Errorf("expected status '%s', got '%s'", tt.status, resp.Jobs[0].Status)
+			}
+			if resp.Jobs[0].Error != tt.error {
+				t.Errorf("expected error '%s', got '%s'", tt.error, resp.Jobs[0].Error)
+			}
+		})
+	}
+}
+
+func TestHandler_ListPullJobs_SystemAndLimit(t *testing.T) {
+	systemID := uuid.New()
+	repo := &mockPullRepository{jobs: testJobs(systemID)}
+	handler := newTestHandler(repo)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/sync/pull?system_id="+systemID.String()+"&limit=2", nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+
+	resp := decodeJobs(t, w)
+	if resp.Count != 2 {
+		t.Errorf("expected 2 jobs, got %d", resp.Count)
+	}
+	if repo.lastFilter.SystemID == nil || *repo.lastFilter.SystemID != systemID {
+		t.Error("expected system ID filter to be passed to repository")
+	}
+	if repo.lastFilter.Limit != 2 {
+		t.Errorf("expected limit 2, got %d", repo.lastFilter.Limit)
+	}
+}
+
+func TestHandler_ListPullJobs_Empty(t *testing.T) {
+	repo := &mockPullRepository{}
+	handler := newTestHandler(repo)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/sync/pull?status=failed", nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	resp := decodeJobs(t, w)
+	if resp.Jobs == nil || resp.Count != 0 {
+		t.Errorf("expected empty job list, got %+v", resp)
+	}
+}
+
+func TestHandler_ListPullJobs_InvalidParams(t *testing.T) {
+	tests := []struct {
+		url  string
+		code api.ErrorCode
+	}{
+		{"/api/v1/sync/pull?status=unknown", api.ErrCodeValidation},
+		{"/api/v1/sync/pull?system_id=not-a-uuid", api.ErrCodeInvalidID},
+		{"/api/v1/sync/pull?since=yesterday", api.ErrCodeValidation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			handler := newTestHandler(&mockPullRepository{})
+
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("expected status 400, got %d", w.Code)
+			}
+			assertErrorCode(t, w, tt.code)
+		})
+	}
+}
+
+// assertErrorCode checks the "error" field of an error response.
+func assertErrorCode(t *testing.T, w *httptest.ResponseRecorder, want api.ErrorCode) {
+	t.Helper()
+	var resp ErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode error response: %v", err)
+	}
+	if resp.Error != string(want) {
+		t.Errorf("expected error code %q, got %q", want, resp.Error)
+	}
+}
+
+func TestHandler_GetPullEvents(t *testing.T) {
+	job := pull.Job{ID: uuid.New(), Status: pull.JobStatusCompleted}
+	job.Progress.Events = []pull.PullEventLog{
+		{SystemName: "HR", ControlID: "AC-1", EventType: pull.EventControlUpserted, Message: "control AC-1 upserted"},
+		{SystemName: "HR", ControlID: "AC-1", StatementNumber: "STMT0001", EventType: pull.EventStatementUpserted, Message: "statement STMT0001 upserted as synced"},
+		{SystemName: "Payroll", ControlID: "AC-1", EventType: pull.EventControlUpserted, Message: "control AC-1 upserted"},
+	}
+	handler := newTestHandler(&mockPullRepository{jobs: []pull.Job{job}})
+
+	tests := []struct {
+		name       string
+		url        string
+		wantStatus int
+		wantCount  int
+	}{
+		{"all", "/api/v1/sync/pull/" + job.ID.String() + "/events", http.StatusOK, 3},
+		{"by system", "/api/v1/sync/pull/" + job.ID.String() + "/events?system_name=HR", http.StatusOK, 2},
+		{"by system and type", "/api/v1/sync/pull/" + job.ID.String() + "/events?system_name=HR&event_type=control_upserted", http.StatusOK, 1},
+		{"unknown type", "/api/v1/sync/pull/" + job.ID.String() + "/events?event_type=deleted", http.StatusBadRequest, 0},
+		{"invalid id", "/api/v1/sync/pull/not-a-uuid/events", http.StatusBadRequest, 0},
+		{"unknown job", "/api/v1/sync/pull/" + uuid.New().String() + "/events", http.StatusNotFound, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.url, nil))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			var resp PullEventsResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if resp.Count != tt.wantCount || len(resp.Events) != tt.wantCount {
+				t.Errorf("expected %d events, got %+v", tt.wantCount, resp)
+			}
+		})
+	}
+}
+
+func TestHandler_ListPullJobs_RepositoryError(t *testing.T) {
+	handler := newTestHandler(&mockPullRepository{err: errors.New("connection refused")})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/sync/pull", nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("expected status 500, got %d", w.Code)
+	}
+	assertErrorCode(t, w, api.ErrCodeInternal)
+}
+
+// mockSystemRepository implements system.Repository with soft-delete semantics.
+type mockSystemRepository struct {
+	systems map[uuid.UUID]*system.System
+
+	syncCounts      []system.SystemSyncStatus
+	syncCountsCalls int
+	familyStats     []system.ControlFamilyStats
+	metadataUpdates int
+
+	// discoveryCache is written by background discovery fetches.
+	discoveryMu    sync.Mutex
+	discoveryCache *system.DiscoveryCache
+}
+
+func newMockSystemRepository(systems ...system.System) *mockSystemRepository {
+	m := &mockSystemRepository{systems: make(map[uuid.UUID]*system.System)}
+	for i := range systems {
+		m.systems[systems[i].ID] = &systems[i]
+	}
+	return m
+}
+
+func (m *mockSystemRepository) GetByID(ctx context.Context, id uuid.UUID) (*system.System, error) {
+	s, ok := m.systems[id]
+	if !ok || s.DeletedAt != nil {
+		return nil, nil
+	}
+	return s, nil
+}
+
+func (m *mockSystemRepository) GetBySNSysID(ctx context.Context, snSysID string) (*system.System, error) {
+	return nil, nil
+}
+
+func (m *mockSystemRepository) List(ctx context.Context, params system.ListParams) (*system.ListResult, error) {
+	result := &system.ListResult{Page: params.Page, PageSize: params.PageSize}
+	for _, s := range m.systems {
+		if s.DeletedAt != nil && !params.IncludeDeleted {
+			continue
+		}
+		result.Systems = append(result.Systems, system.SystemWithStats{System: *s})
+	}
+	result.TotalCount = len(result.Systems)
+	return result, nil
+}
+
+func (m *mockSystemRepository) ListAll(ctx context.Context) ([]system.System, error) {
+	return nil, nil
+}
+
+func (m *mockSystemRepository) Upsert(ctx context.Context, input system.UpsertInput) (*system.System, error) {
+	return nil, nil
+}
+
+func (m *mockSystemRepository) UpdateStatusBatch(ctx context.Context, ids []uuid.UUID, status string) ([]uuid.UUID, error) {
+	var updated []uuid.UUID
+	for _, id := range ids {
+		if s, ok := m.systems[id]; ok && s.DeletedAt == nil {
+			s.Status = status
+			updated = append(updated, id)
+		}
+	}
+	return updated, nil
+}
+
+func (m *mockSystemRepository) UpsertBatch(ctx context.Context, inputs []system.UpsertInput) ([]system.System, error) {
+	systems := make([]system.System, 0, len(inputs))
+	for _, input := range inputs {
+		s := system.System{ID: uuid.New(), SNSysID: input.SNSysID, Name: input.Name}
+		m.systems[s.ID] = &s
+		systems = append(systems, s)
+	}
+	return systems, nil
+}
+
+func (m *mockSystemRepository) GetSummary(ctx context.Context, id uuid.UUID) (*system.SystemSummary, error) {
+	return nil, nil
+}
+
+func (m *mockSystemRepository) GetControlFamilyStats(ctx context.Context, systemID uuid.UUID) ([]system.ControlFamilyStats, error) {
+	return m.familyStats, nil
+}
+
+func (m *mockSystemRepository) GetSyncStatusCounts(ctx context.Context) ([]system.SystemSyncStatus, error) {
+	m.syncCountsCalls++
+	return m.syncCounts, nil
+}
+
+func (m *mockSystemRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	s, ok := m.systems[id]
+	if !ok || s.DeletedAt != nil {
+		return system.ErrNotFound
+	}
+	now := time.Now()
+	s.DeletedAt = &now
+	return nil
+}
+
+func (m *mockSystemRepository) Restore(ctx context.Context, id uuid.UUID) (*system.System, error) {
+	s, ok := m.systems[id]
+	if !ok {
+		return nil, nil
+	}
+	s.DeletedAt = nil
+	return s, nil
+}
+
+func (m *mockSystemRepository) SetConflictStrategy(ctx context.Context, id uuid.UUID, strategy string) (*system.System, error) {
+	s, ok := m.systems[id]
+	if !ok || s.DeletedAt != nil {
+		return nil, nil
+	}
+	s.ConflictStrategy = strategy
+	return s, nil
+}
+
+func (m *mockSystemRepository) UpdateMetadata(ctx context.Context, id uuid.UUID, patch system.MetadataPatch) error {
+	m.metadataUpdates++
+	s, ok := m.systems[id]
+	if !ok || s.DeletedAt != nil {
+		return system.ErrNotFound
+	}
+	if patch.Acronym != nil {
+		s.Acronym = *patch.Acronym
+	}
+	if patch.Owner != nil {
+		s.Owner = *patch.Owner
+	}
+	if patch.Description != nil {
+		s.Description = *patch.Description
+	}
+	return nil
+}
+
+func (m *mockSystemRepository) UpdateLastPullAt(ctx context.Context, id uuid.UUID, connectionLabel string) error {
+	return nil
+}
+
+func (m *mockSystemRepository) GetAllSNSysIDs(ctx context.Context) ([]string, error) {
+	var ids []string
+	for _, s := range m.systems {
+		if s.DeletedAt == nil {
+			ids = append(ids, s.SNSysID)
+		}
+	}
+	return ids, nil
+}
+
+func (m *mockSystemRepository) GetDiscoveryCache(ctx context.Context) (*system.DiscoveryCache, error) {
+	m.discoveryMu.Lock()
+	defer m.discoveryMu.Unlock()
+	return m.discoveryCache, nil
+}
+
+func (m *mockSystemRepository) SaveDiscoveryCache(ctx context.Context, systems []system.DiscoveredSystem) error {
+	m.discoveryMu.Lock()
+	defer m.discoveryMu.Unlock()
+	m.discoveryCache = &system.DiscoveryCache{RunAt: time.Now(), Systems: systems}
+	return nil
+}
+
+func newSystemTestHandler(repo *mockSystemRepository) http.Handler {
+	systemService := system.NewService(repo, nil, nil, nil, system.Options{}, nil)
+	mux := http.NewServeMux()
+	NewHandler(systemService, nil, nil, nil, config.PaginationDefaults{}, nil).RegisterRoutes(mux)
+	return mux
+}
+
+func listSystems(t *testing.T, handler http.Handler, url string) ListSystemsResponse {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, url, nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+	var resp ListSystemsResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	return resp
+}
+
+func TestHandler_ListSystems_PageSize(t *testing.T) {
+	mux := http.NewServeMux()
+	pagination := config.PaginationDefaults{SystemsDefaultPageSize: 25}
+	NewHandler(system.NewService(&mockSystemRepository{}, nil, nil, nil, system.Options{}, nil), nil, nil, nil, pagination, nil).RegisterRoutes(mux)
+
+	tests := []struct {
+		query string
+		want  int
+	}{
+		{"", 25},
+		{"?page_size=0", 25},
+		{"?page_size=40", 40},
+		{"?page_size=500", config.MaxSystemsPageSize},
+	}
+	for _, tt := range tests {
+		if got := listSystems(t, mux, "/api/v1/sync/systems"+tt.query).PageSize; got != tt.want {
+			t.Errorf("%q: expected page size %d, got %d", tt.query, tt.want, got)
+		}
+	}
+}
+
+func TestHandler_SystemSoftDeleteAndRestore(t *testing.T) {
+	id := uuid.New()
+	repo := newMockSystemRepository(system.System{ID: id, SNSysID: "sys1", Name: "Payroll", Status: "active"})
+	handler := newSystemTestHandler(repo)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/sync/systems/"+id.String(), nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200 on delete, got %d", w.Code)
+	}
+	if _, ok := repo.systems[id]; !ok {
+		t.Fatal("expected system row to be kept after delete")
+	}
+
+	if resp := listSystems(t, handler, "/api/v1/sync/systems"); resp.TotalCount != 0 {
+		t.Errorf("expected deleted system to be hidden, got %d systems", resp.TotalCount)
+	}
+
+	resp := listSystems(t, handler, "/api/v1/sync/systems?include_deleted=true")
+	if resp.TotalCount != 1 || resp.Systems[0].DeletedAt == nil {
+		t.Fatalf("expected deleted system with deleted_at when include_deleted=true, got %+v", resp.Systems)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/sync/systems/"+id.String()+"/restore", nil)
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200 on restore, got %d", w.Code)
+	}
+
+	resp = listSystems(t, handler, "/api/v1/sync/systems")
+	if resp.TotalCount != 1 || resp.Systems[0].DeletedAt != nil {
+		t.Errorf("expected restored system to be listed, got %+v", resp.Systems)
+	}
+}
+
+func TestHandler_RestoreSystem_NotFound(t *testing.T) {
+	handler := newSystemTestHandler(newMockSystemRepository())
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/sync/systems/"+uuid.New().String()+"/restore", nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404, got %d", w.Code)
+	}
+	assertErrorCode(t, w, api.ErrCodeNotFound)
+}
+
+func TestHandler_SetConflictStrategy(t *testing.T) {
+	id := uuid.New()
+	repo := newMockSystemRepository(system.System{ID: id, SNSysID: "sys1", Name: "Payroll", Status: "active"})
+	handler := newSystemTestHandler(repo)
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		want       string
+	}{
+		{"set override", `{"strategy":"keep_remote"}`, http.StatusOK, "keep_remote"},
+		{"invalid strategy", `{"strategy":"merge"}`, http.StatusBadRequest, "keep_remote"},
+		{"clear override", `{"strategy":""}`, http.StatusOK, ""},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodPut, "/api/v1/sync/systems/"+id.String()+"/conflict-strategy", strings.NewReader(tt.body))
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		if w.Code != tt.wantStatus {
+			t.Fatalf("%s: expected status %d, got %d", tt.name, tt.wantStatus, w.Code)
+		}
+		if got := repo.systems[id].ConflictStrategy; got != tt.want {
+			t.Errorf("%s: expected stored strategy %q, got %q", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestHandler_UpdateSystem(t *testing.T) {
+	id := uuid.New()
+	repo := newMockSystemRepository(system.System{
+		ID: id, SNSysID: "sys1", Name: "Payroll", Status: "active",
+		Acronym: "PAY", Owner: "Finance", Description: "Payroll processing",
+	})
+	handler := newSystemTestHandler(repo)
+
+	tests := []struct {
+		name        string
+		body        string
+		wantStatus  int
+		wantUpdates int
+		want        system.System
+	}{
+		{"acronym only", `{"acronym":"PRL"}`, http.StatusOK, 1, system.System{Acronym: "PRL", Owner: "Finance", Description: "Payroll processing"}},
+		{"owner and description", `{"owner":"HR","description":"Payroll and benefits"}`, http.StatusOK, 2, system.System{Acronym: "PRL", Owner: "HR", Description: "Payroll and benefits"}},
+		{"empty patch is a no-op", `{}`, http.StatusOK, 2, system.System{Acronym: "PRL", Owner: "HR", Description: "Payroll and benefits"}},
+		{"acronym too long", `{"acronym":"` + strings.Repeat("A", system.MaxAcronymLength+1) + `"}`, http.StatusBadRequest, 2, system.System{Acronym: "PRL", Owner: "HR", Description: "Payroll and benefits"}},
+		{"invalid body", `{"acronym":`, http.StatusBadRequest, 2, system.System{Acronym: "PRL", Owner: "HR", Description: "Payroll and benefits"}},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodPatch, "/api/v1/sync/systems/"+id.String(), strings.NewReader(tt.body))
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		if w.Code != tt.wantStatus {
+			t.Fatalf("%s: expected status %d, got %d: %s", tt.name, tt.wantStatus, w.Code, w.Body.String())
+		}
+		if repo.metadataUpdates != tt.wantUpdates {
+			t.Errorf("%s: expected %d repository updates, got %d", tt.name, tt.wantUpdates, repo.metadataUpdates)
+		}
+		got := repo.systems[id]
+		if got.Acronym != tt.want.Acronym || got.Owner != tt.want.Owner || got.Description != tt.want.Description {
+			t.Errorf("%s: unexpected metadata %q/%q/%q", tt.name, got.Acronym, got.Owner, got.Description)
+		}
+		if w.Code == http.StatusOK {
+			var resp LocalSystemResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("%s: failed to decode response: %v", tt.name, err)
+			}
+			if resp.Acronym != tt.want.Acronym || resp.Name != "Payroll" {
+				t.Errorf("%s: unexpected response %+v", tt.name, resp)
+			}
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodPatch, "/api/v1/sync/systems/"+uuid.New().String(), strings.NewReader(`{"owner":"HR"}`))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404 for unknown system, got %d", w.Code)
+	}
+}
+
+func TestHandler_GetSyncStatusDashboard(t *testing.T) {
+	repo := newMockSystemRepository()
+	repo.syncCounts = []system.SystemSyncStatus{
+		{SystemID: uuid.New(), Name: "HR", SyncStatusCounts: system.SyncStatusCounts{Synced: 42, Modified: 7, Conflict: 2, Total: 51}},
+		{SystemID: uuid.New(), Name: "Payroll", SyncStatusCounts: system.SyncStatusCounts{Synced: 3, Modified: 1, Total: 4}},
+	}
+	handler := newSystemTestHandler(repo)
+
+	var resp SyncStatusDashboardResponse
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/dashboard/sync-status", nil)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", w.Code)
+		}
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+	}
+
+	if len(resp.Systems) != 2 || resp.Systems[0].Name != "HR" || resp.Systems[0].Conflict != 2 {
+		t.Errorf("unexpected systems: %+v", resp.Systems)
+	}
+	want := SyncStatusCountsResponse{Synced: 45, Modified: 8, Conflict: 2, Total: 55}
+	if resp.Global != want {
+		t.Errorf("expected global %+v, got %+v", want, resp.Global)
+	}
+	if repo.syncCountsCalls != 1 {
+		t.Errorf("expected cached aggregation to query once, got %d queries", repo.syncCountsCalls)
+	}
+}
+
+func TestHandler_GetControlFamilyStats(t *testing.T) {
+	sys := system.System{ID: uuid.New(), Name: "HR"}
+	repo := newMockSystemRepository(sys)
+	repo.familyStats = []system.ControlFamilyStats{
+		{Family: "AC", TotalControls: 12, SyncedStatements: 8, ModifiedStatements: 3, ConflictStatements: 1},
+		{Family: "AU", TotalControls: 3, SyncedStatements: 1},
+		{Family: "CM", TotalControls: 3, SyncedStatements: 2, ModifiedStatements: 1},
+		{Family: "IA", TotalControls: 4, SyncedStatements: 6},
+		{Family: "SC", TotalControls: 5},
+	}
+	handler := newSystemTestHandler(repo)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/sync/systems/"+sys.ID.String()+"/control-families", nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp []ControlFamilyStatsResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	// Percentages round down; more synced statements than controls caps at 100
+	want := map[string]int{"AC": 66, "AU": 33, "CM": 66, "IA": 100, "SC": 0}
+	if len(resp) != len(want) {
+		t.Fatalf("expected %d families, got %d", len(want), len(resp))
+	}
+	for i, f := range resp {
+		if f.CoveragePct != want[f.Family] {
+			t.Errorf("%s: expected coverage %d, got %d", f.Family, want[f.Family], f.CoveragePct)
+		}
+		if i > 0 && resp[i-1].Family > f.Family {
+			t.Errorf("expected families ordered ascending, got %s before %s", resp[i-1].Family, f.Family)
+		}
+	}
+	if resp[0].ModifiedStatements != 3 || resp[0].ConflictStatements != 1 {
+		t.Errorf("unexpected AC counts: %+v", resp[0])
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/sync/systems/"+uuid.New().String()+"/control-families", nil)
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404 for unknown system, got %d", w.Code)
+	}
+}
+
+// importClient serves a fixed set of ServiceNow systems for import tests.
+type importClient struct {
+	servicenow.Client
+	records []servicenow.SystemRecord
+}
+
+func (c *importClient) FetchSystems(ctx context.Context, config *servicenow.PaginationConfig, onProgress servicenow.ProgressCallback) (*servicenow.PaginatedResult[servicenow.SystemRecord], error) {
+	return &servicenow.PaginatedResult[servicenow.SystemRecord]{Records: c.records, TotalCount: len(c.records)}, nil
+}
+
+func (c *importClient) GetInstanceTimezone(ctx context.Context) (*time.Location, error) {
+	return time.UTC, nil
+}
+
+type importClientProvider struct {
+	client servicenow.Client
+}
+
+func (p importClientProvider) GetSNClient(ctx context.Context) (servicenow.Client, error) {
+	return p.client, nil
+}
+
+func TestHandler_ImportSystems_EagerFetch(t *testing.T) {
+	client := &importClient{records: []servicenow.SystemRecord{
+		{SysID: "sn-1", Name: "Payroll"},
+		{SysID: "sn-2", Name: "Billing"},
+		{SysID: "sn-3", Name: "Unrequested"},
+	}}
+
+	tests := []struct {
+		name       string
+		body       string
+		wantPulled bool
+	}{
+		{"eager fetch", `{"sn_sys_ids":["sn-1","sn-2"],"eager_fetch":true}`, true},
+		{"default", `{"sn_sys_ids":["sn-1","sn-2"]}`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			systemRepo := newMockSystemRepository()
+			pullRepo := &mockPullRepository{}
+			systemService := system.NewService(systemRepo, nil, importClientProvider{client: client}, nil, system.Options{}, nil)
+			pullService := pull.NewService(pullRepo, systemRepo, nil, nil, nil, pull.Options{}, nil)
+			mux := http.NewServeMux()
+			NewHandler(systemService, pullService, nil, nil, config.PaginationDefaults{}, nil).RegisterRoutes(mux)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/sync/systems/import", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+
+			if w.Code != http.StatusCreated {
+				t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+			}
+
+			var resp ImportSystemsResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if resp.Count != 2 {
+				t.Fatalf("expected 2 imported systems, got %d", resp.Count)
+			}
+
+			if !tt.wantPulled {
+				if resp.PullJobID != nil || len(pullRepo.created) != 0 {
+					t.Fatalf("expected no pull job, got %v (%d created)", resp.PullJobID, len(pullRepo.created))
+				}
+				return
+			}
+
+			if resp.PullError != "" {
+				t.Fatalf("unexpected pull error: %s", resp.PullError)
+			}
+			if len(pullRepo.created) != 1 {
+				t.Fatalf("expected 1 pull job created, got %d", len(pullRepo.created))
+			}
+			if resp.PullJobID == nil || *resp.PullJobID != pullRepo.jobs[0].ID {
+				t.Errorf("expected pull_job_id %s, got %v", pullRepo.jobs[0].ID, resp.PullJobID)
+			}
+
+			pulled := pullRepo.created[0].SystemIDs
+			if len(pulled) != len(resp.Imported) {
+				t.Fatalf("expected %d pulled systems, got %d", len(resp.Imported), len(pulled))
+			}
+			for i, s := range resp.Imported {
+				if pulled[i] != s.ID {
+					t.Errorf("pulled system %d: expected %s, got %s", i, s.ID, pulled[i])
+				}
+			}
+		})
+	}
+}
+
+// slowDiscoveryClient holds FetchSystems until release is closed.
+type slowDiscoveryClient struct {
+	importClient
+	release chan struct{}
+}
+
+func (c *slowDiscoveryClient) FetchSystems(ctx context.Context, config *servicenow.PaginationConfig, onProgress servicenow.ProgressCallback) (*servicenow.PaginatedResult[servicenow.SystemRecord], error) {
+	<-c.release
+	return c.importClient.FetchSystems(ctx, config, onProgress)
+}
+
+func TestHandler_DiscoverSystems_Cache(t *testing.T) {
+	imported := system.System{ID: uuid.New(), SNSysID: "sn-1", Name: "Payroll"}
+	opts := system.Options{DiscoveryCacheTTL: 5 * time.Minute, DiscoverySlowThreshold: 20 * time.Millisecond}
+
+	discover := func(t *testing.T, repo *mockSystemRepository, client servicenow.Client, query string) DiscoverSystemsResponse {
+		t.Helper()
+		systemService := system.NewService(repo, nil, importClientProvider{client: client}, nil, opts, nil)
+		mux := http.NewServeMux()
+		NewHandler(systemService, nil, nil, nil, config.PaginationDefaults{}, nil).RegisterRoutes(mux)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/sync/systems/discover"+query, nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+		}
+		var resp DiscoverSystemsResponse
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		return resp
+	}
+
+	cachedSystems := []system.DiscoveredSystem{{SNSysID: "sn-1", Name: "Payroll (cached)"}}
+	remote := []servicenow.SystemRecord{{SysID: "sn-1", Name: "Payroll"}, {SysID: "sn-2", Name: "Billing"}}
+
+	t.Run("slow ServiceNow serves fresh cache", func(t *testing.T) {
+		repo := newMockSystemRepository(imported)
+		repo.discoveryCache = &system.DiscoveryCache{RunAt: time.Now(), Systems: cachedSystems}
+		client := &slowDiscoveryClient{importClient: importClient{records: remote}, release: make(chan struct{})}
+
+		resp := discover(t, repo, client, "")
+		if resp.Count != 1 || resp.Systems[0].Name != "Payroll (cached)" {
+			t.Fatalf("expected the cached system, got %+v", resp.Systems)
+		}
+		if !resp.Systems[0].IsImported {
+			t.Error("expected cached system to be marked imported")
+		}
+
+		// The background fetch refreshes the cache once ServiceNow answers
+		close(client.release)
+		deadline := time.Now().Add(time.Second)
+		for {
+			cache, _ := repo.GetDiscoveryCache(context.Background())
+			if len(cache.Systems) == len(remote) {
+				break
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("expected cache to be refreshed, got %+v", cache.Systems)
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+	})
+
+	t.Run("force waits for ServiceNow", func(t *testing.T) {
+		repo := newMockSystemRepository(imported)
+		repo.discoveryCache = &system.DiscoveryCache{RunAt: time.Now(), Systems: cachedSystems}
+		client := &slowDiscoveryClient{importClient: importClient{records: remote}, release: make(chan struct{})}
+		time.AfterFunc(50*time.Millisecond, func() { close(client.release) })
+
+		resp := discover(t, repo, client, "?force=true")
+		if resp.Count != 2 {
+			t.Fatalf("expected 2 systems from ServiceNow, got %+v", resp.Systems)
+		}
+	})
+
+	t.Run("stale cache is not served", func(t *testing.T) {
+		repo := newMockSystemRepository(imported)
+		repo.discoveryCache = &system.DiscoveryCache{RunAt: time.Now().Add(-time.Hour), Systems: cachedSystems}
+		client := &slowDiscoveryClient{importClient: importClient{records: remote}, release: make(chan struct{})}
+		time.AfterFunc(50*time.Millisecond, func() { close(client.release) })
+
+		resp := discover(t, repo, client, "")
+		if resp.Count != 2 {
+			t.Fatalf("expected 2 systems from ServiceNow, got %+v", resp.Systems)
+		}
+		if repo.discoveryCache.Systems[1].SNSysID != "sn-2" {
+			t.Errorf("expected cache to hold the fetched systems, got %+v", repo.discoveryCache.Systems)
+		}
+	})
+}
+
+// mockControlRepository implements the control.Repository methods used for
+// coverage gaps. withStatements holds the IDs of controls that have statements.
+type mockControlRepository struct {
+	control.Repository
+	controls       []control.Control
+	withStatements map[uuid.UUID]bool
+}
+
+func (m *mockControlRepository) ListWithoutStatements(ctx context.Context, systemID uuid.UUID) ([]control.Control, error) {
+	var gaps []control.Control
+	for _, c := range m.controls {
+		if c.SystemID == systemID && !m.withStatements[c.ID] {
+			gaps = append(gaps, c)
+		}
+	}
+	return gaps, nil
+}
+
+func TestHandler_GetCoverageGaps(t *testing.T) {
+	sys := system.System{ID: uuid.New(), Name: "Payroll"}
+	covered := control.Control{ID: uuid.New(), SystemID: sys.ID, ControlID: "AC-1", ControlName: "Policy", ControlFamily: "AC"}
+	gapAC := control.Control{ID: uuid.New(), SystemID: sys.ID, ControlID: "AC-2", ControlName: "Account Management", ControlFamily: "AC"}
+	gapSC := control.Control{ID: uuid.New(), SystemID: sys.ID, ControlID: "SC-7", ControlName: "Boundary Protection", ControlFamily: "SC"}
+
+	controlRepo := &mockControlRepository{
+		controls:       []control.Control{covered, gapAC, gapSC},
+		withStatements: map[uuid.UUID]bool{covered.ID: true},
+	}
+	systemService := system.NewService(newMockSystemRepository(sys), controlRepo, nil, nil, system.Options{}, nil)
+	mux := http.NewServeMux()
+	NewHandler(systemService, nil, nil, nil, config.PaginationDefaults{}, nil).RegisterRoutes(mux)
+
+	tests := []struct {
+		name       string
+		url        string
+		wantStatus int
+		wantIDs    []string
+	}{
+		{"all families", "/api/v1/sync/systems/" + sys.ID.String() + "/coverage-gaps", http.StatusOK, []string{"AC-2", "SC-7"}},
+		{"family filter", "/api/v1/sync/systems/" + sys.ID.String() + "/coverage-gaps?control_family=sc", http.StatusOK, []string{"SC-7"}},
+		{"unknown system", "/api/v1/sync/systems/" + uuid.New().String() + "/coverage-gaps", http.StatusNotFound, nil},
+		{"invalid id", "/api/v1/sync/systems/not-a-uuid/coverage-gaps", http.StatusBadRequest, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.url, nil))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var gaps []CoverageGapResponse
+			if err := json.NewDecoder(w.Body).Decode(&gaps); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if len(gaps) != len(tt.wantIDs) {
+				t.Fatalf("expected %d gaps, got %d", len(tt.wantIDs), len(gaps))
+			}
+			for i, id := range tt.wantIDs {
+				if gaps[i].ControlID != id {
+					t.Errorf("gap %d: expected %s, got %s", i, id, gaps[i].ControlID)
+				}
+				if gaps[i].StatementCount != 0 {
+					t.Errorf("gap %d: expected statement_count 0, got %d", i, gaps[i].StatementCount)
+				}
+			}
+		})
+	}
+}
+
+func (m *mockControlRepository) ListBySystem(ctx context.Context, systemID uuid.UUID) ([]control.Control, error) {
+	var controls []control.Control
+	for _, c := range m.controls {
+		if c.SystemID == systemID {
+			controls = append(controls, c)
+		}
+	}
+	return controls, nil
+}
+
+func TestHandler_CompareSystems(t *testing.T) {
+	prod := system.System{ID: uuid.New(), Name: "Payroll"}
+	staging := system.System{ID: uuid.New(), Name: "Payroll Staging"}
+	ctrl := func(sys system.System, controlID, name, status string) control.Control {
+		return control.Control{ID: uuid.New(), SystemID: sys.ID, ControlID: controlID, ControlName: name, ImplementationStatus: status}
+	}
+	controlRepo := &mockControlRepository{controls: []control.Control{
+		ctrl(prod, "SC-7", "Boundary Protection", control.StatusImplemented),
+		ctrl(prod, "AC-1", "Policy", control.StatusImplemented),
+		ctrl(prod, "AC-2", "Account Management", control.StatusImplemented),
+		ctrl(prod, "AU-2", "Event Logging", control.StatusImplemented),
+		ctrl(prod, "IA-2", "Identification", control.StatusImplemented),
+		ctrl(staging, "AC-1", "Policy", control.StatusImplemented),
+		ctrl(staging, "AC-2", "Account Management", control.StatusPartiallyImplemented),
+		ctrl(staging, "AU-2", "Audit Events", control.StatusImplemented),
+		ctrl(staging, "CM-6", "Configuration Settings", control.StatusNotImplemented),
+	}}
+	systemService := system.NewService(newMockSystemRepository(prod, staging), controlRepo, nil, nil, system.Options{}, nil)
+	mux := http.NewServeMux()
+	NewHandler(systemService, nil, nil, nil, config.PaginationDefaults{}, nil).RegisterRoutes(mux)
+
+	compare := func(query string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/sync/systems/compare?"+query, nil))
+		return w
+	}
+
+	w := compare("left_id=" + prod.ID.String() + "&right_id=" + staging.ID.String())
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp CompareSystemsResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if got := strings.Join(resp.OnlyInLeft, ","); got != "IA-2,SC-7" {
+		t.Errorf("only_in_left: expected IA-2,SC-7, got %s", got)
+	}
+	if got := strings.Join(resp.OnlyInRight, ","); got != "CM-6" {
+		t.Errorf("only_in_right: expected CM-6, got %s", got)
+	}
+	if got := strings.Join(resp.Identical, ","); got != "AC-1" {
+		t.Errorf("identical: expected AC-1, got %s", got)
+	}
+	if len(resp.InBothDifferent) != 2 {
+		t.Fatalf("expected 2 differing controls, got %+v", resp.InBothDifferent)
+	}
+	status := resp.InBothDifferent[0]
+	if status.Left.ControlID != "AC-2" || status.Left.ImplementationStatus != control.StatusImplemented ||
+		status.Right.ImplementationStatus != control.StatusPartiallyImplemented {
+		t.Errorf("expected AC-2 to differ in status, got %+v", status)
+	}
+	name := resp.InBothDifferent[1]
+	if name.Left.ControlID != "AU-2" || name.Left.ControlName != "Event Logging" || name.Right.ControlName != "Audit Events" {
+		t.Errorf("expected AU-2 to differ in name, got %+v", name)
+	}
+
+	// Empty categories are empty lists, not null
+	w = compare("left_id=" + prod.ID.String() + "&right_id=" + prod.ID.String())
+	if body := w.Body.String(); w.Code != http.StatusOK || !strings.Contains(body, `"only_in_left":[]`) || !strings.Contains(body, `"in_both_different":[]`) {
+		t.Errorf("expected a system to be identical to itself, got %d: %s", w.Code, body)
+	}
+
+	errorTests := []struct {
+		name       string
+		query      string
+		wantStatus int
+	}{
+		{"unknown right system", "left_id=" + prod.ID.String() + "&right_id=" + uuid.New().String(), http.StatusNotFound},
+		{"missing right id", "left_id=" + prod.ID.String(), http.StatusBadRequest},
+		{"invalid left id", "left_id=prod&right_id=" + staging.ID.String(), http.StatusBadRequest},
+	}
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			if w := compare(tt.query); w.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+// auditRepository accepts audit events so recorded events reach subscribers.
+type auditRepository struct {
+	audit.Repository
+}
+
+func (auditRepository) Insert(ctx context.Context, event *audit.Event) error {
+	return nil
+}
+
+func TestHandler_BatchUpdateStatus(t *testing.T) {
+	deletedAt := time.Now()
+	active1 := system.System{ID: uuid.New(), Name: "Payroll", Status: system.StatusActive}
+	active2 := system.System{ID: uuid.New(), Name: "Billing", Status: system.StatusActive}
+	deleted := system.System{ID: uuid.New(), Name: "Legacy", Status: system.StatusActive, DeletedAt: &deletedAt}
+
+	tooMany := make([]string, system.MaxBatchStatusIDs+1)
+	for i := range tooMany {
+		tooMany[i] = `"` + uuid.New().String() + `"`
+	}
+
+	tests := []struct {
+		name        string
+		body        string
+		wantStatus  int
+		wantUpdated int
+	}{
+		{"partial match", `{"system_ids":["` + active1.ID.String() + `","` + active2.ID.String() + `","` +
+			deleted.ID.String() + `","` + uuid.New().String() + `"],"status":"decommissioned"}`, http.StatusOK, 2},
+		{"over limit", `{"system_ids":[` + strings.Join(tooMany, ",") + `],"status":"inactive"}`, http.StatusBadRequest, 0},
+		{"invalid status", `{"system_ids":["` + active1.ID.String() + `"],"status":"retired"}`, http.StatusBadRequest, 0},
+		{"no ids", `{"system_ids":[],"status":"inactive"}`, http.StatusBadRequest, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newMockSystemRepository(active1, active2, deleted)
+			auditService := audit.NewService(auditRepository{}, audit.Options{}, nil)
+			events, unsubscribe := auditService.Subscribe()
+			defer unsubscribe()
+
+			mux := http.NewServeMux()
+			NewHandler(system.NewService(repo, nil, nil, auditService, system.Options{}, nil), nil, nil, nil, config.PaginationDefaults{}, nil).RegisterRoutes(mux)
+
+			req := httptest.NewRequest(http.MethodPatch, "/api/v1/sync/systems/batch-status", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				if active1.Status != repo.systems[active1.ID].Status {
+					t.Errorf("expected no status change, got %q", repo.systems[active1.ID].Status)
+				}
+				return
+			}
+
+			var resp BatchStatusResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if resp.Updated != tt.wantUpdated {
+				t.Errorf("expected %d updated, got %d", tt.wantUpdated, resp.Updated)
+			}
+			for _, id := range []uuid.UUID{active1.ID, active2.ID} {
+				if got := repo.systems[id].Status; got != system.StatusDecommissioned {
+					t.Errorf("system %s: expected status decommissioned, got %q", id, got)
+				}
+			}
+			if got := repo.systems[deleted.ID].Status; got != system.StatusActive {
+				t.Errorf("deleted system: expected status unchanged, got %q", got)
+			}
+
+			audited := map[string]bool{}
+			for len(audited) < tt.wantUpdated {
+				select {
+				case e := <-events:
+					if e.EntityType != "system" || e.Action != "update_status" {
+						t.Fatalf("unexpected audit event %s/%s", e.EntityType, e.Action)
+					}
+					audited[e.EntityID] = true
+				case <-time.After(time.Second):
+					t.Fatalf("expected %d audit events, got %d", tt.wantUpdated, len(audited))
+				}
+			}
+			if !audited[active1.ID.String()] || !audited[active2.ID.String()] {
+				t.Errorf("expected audit events for updated systems, got %v", audited)
+			}
+		})
+	}
+}
+
+func TestHandler_UpdateSystem_FieldChangeAudit(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want map[string][2]string // field -> old, new
+	}{
+		{"acronym only", `{"acronym":"PRL"}`, map[string][2]string{"acronym": {"PAY", "PRL"}}},
+		{"owner", `{"owner":"HR","acronym":"PAY"}`, map[string][2]string{"owner": {"Finance", "HR"}}},
+		{"unchanged", `{"owner":"Finance"}`, map[string][2]string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id := uuid.New()
+			repo := newMockSystemRepository(system.System{
+				ID: id, SNSysID: "sys1", Name: "Payroll", Status: "active",
+				Acronym: "PAY", Owner: "Finance", Description: "Payroll processing",
+			})
+			auditService := audit.NewService(auditRepository{}, audit.Options{}, nil)
+			events, unsubscribe := auditService.Subscribe()
+			defer unsubscribe()
+
+			mux := http.NewServeMux()
+			NewHandler(system.NewService(repo, nil, nil, auditService, system.Options{}, nil), nil, nil, nil, config.PaginationDefaults{}, nil).RegisterRoutes(mux)
+
+			req := httptest.NewRequest(http.MethodPatch, "/api/v1/sync/systems/"+id.String(), strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+			if w.Code != http.StatusOK {
+				t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+			}
+
+			// Collect events until none arrive for a short while
+			got := map[string][2]string{}
+			for {
+				select {
+				case e := <-events:
+					if e.EventType != audit.EventTypeFieldChange || e.EntityType != "system" || e.EntityID != id.String() {
+						t.Fatalf("unexpected audit event %s %s/%s", e.EventType, e.EntityType, e.EntityID)
+					}
+					field, _ := e.Details["field"].(string)
+					oldValue, _ := e.Details["old_value"].(string)
+					newValue, _ := e.Details["new_value"].(string)
+					got[field] = [2]string{oldValue, newValue}
+					continue
+				case <-time.After(100 * time.Millisecond):
+				}
+				break
+			}
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("expected field changes %v, got %v", tt.want, got)
+			}
+			for field, want := range tt.want {
+				if got[field] != want {
+					t.Errorf("%s: expected %v, got %v", field, want, got[field])
+				}
+			}
+		})
+	}
+}
+
+// controlLookupRepository serves controls by system and control ID.
+type controlLookupRepository struct {
+	control.Repository
+	controls []control.Control
+}
+
+func (m *controlLookupRepository) GetByControlIDString(ctx context.Context, systemID uuid.UUID, controlID string) (*control.Control, error) {
+	for _, c := range m.controls {
+		if c.SystemID == systemID && strings.EqualFold(c.ControlID, controlID) {
+			return &c, nil
+		}
+	}
+	return nil, nil
+}
+
+// controlStatementRepository serves statements by control.
+type controlStatementRepository struct {
+	statement.Repository
+	stmts []statement.Statement
+}
+
+func (m *controlStatementRepository) ListByControl(ctx context.Context, controlID uuid.UUID) ([]statement.Statement, error) {
+	var result []statement.Statement
+	for _, s := range m.stmts {
+		if s.ControlID == controlID {
+			result = append(result, s)
+		}
+	}
+	return result, nil
+}
+
+func TestHandler_ListControlStatements(t *testing.T) {
+	systemID := uuid.New()
+	ac1 := control.Control{ID: uuid.New(), SystemID: systemID, ControlID: "AC-1"}
+	ac2 := control.Control{ID: uuid.New(), SystemID: systemID, ControlID: "AC-2"}
+	other := control.Control{ID: uuid.New(), SystemID: uuid.New(), ControlID: "AU-2"}
+	stmts := []statement.Statement{
+		{ID: uuid.New(), ControlID: ac1.ID, RemoteContent: "Accounts are reviewed.", SyncStatus: statement.SyncStatusSynced},
+		{ID: uuid.New(), ControlID: ac1.ID, RemoteContent: "Old.", LocalContent: "New.", IsModified: true, SyncStatus: statement.SyncStatusModified},
+		{ID: uuid.New(), ControlID: ac2.ID, RemoteContent: "Other control."},
+	}
+
+	controlService := control.NewService(&controlLookupRepository{controls: []control.Control{ac1, ac2, other}}, nil, nil)
+	stmtService := statement.NewService(&controlStatementRepository{stmts: stmts}, statement.Options{}, nil)
+	mux := http.NewServeMux()
+	NewHandler(nil, nil, controlService, stmtService, config.PaginationDefaults{}, nil).RegisterRoutes(mux)
+
+	get := func(systemID, controlID string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/sync/systems/"+systemID+"/controls/"+controlID+"/statements", nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("existing control", func(t *testing.T) {
+		w := get(systemID.String(), "ac-1")
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+		var resp []ControlStatementResponse
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(resp) != 2 || resp[0].ID != stmts[0].ID || resp[1].ID != stmts[1].ID {
+			t.Fatalf("expected the two AC-1 statements, got %+v", resp)
+		}
+		if resp[1].EffectiveContent != "New." || resp[1].SyncStatus != "modified" {
+			t.Errorf("expected local content of modified statement, got %+v", resp[1])
+		}
+	})
+
+	t.Run("control without statements", func(t *testing.T) {
+		controlService := control.NewService(&controlLookupRepository{controls: []control.Control{ac1}}, nil, nil)
+		stmtService := statement.NewService(&controlStatementRepository{}, statement.Options{}, nil)
+		mux := http.NewServeMux()
+		NewHandler(nil, nil, controlService, stmtService, config.PaginationDefaults{}, nil).RegisterRoutes(mux)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/sync/systems/"+systemID.String()+"/controls/AC-1/statements", nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		if body := strings.TrimSpace(w.Body.String()); w.Code != http.StatusOK || body != "[]" {
+			t.Errorf("expected an empty list, got %d %s", w.Code, body)
+		}
+	})
+
+	t.Run("non-existing control", func(t *testing.T) {
+		for _, tc := range []struct{ name, systemID, controlID string }{
+			{"unknown control ID", systemID.String(), "SC-7"},
+			{"control of another system", systemID.String(), "AU-2"},
+			{"unknown system", uuid.NewString(), "AC-1"},
+		} {
+			w := get(tc.systemID, tc.controlID)
+			if w.Code != http.StatusNotFound {
+				t.Errorf("%s: expected 404, got %d", tc.name, w.Code)
+				continue
+			}
+			var resp ErrorResponse
+			json.NewDecoder(w.Body).Decode(&resp)
+			if resp.Error != string(api.ErrCodeNotFound) {
+				t.Errorf("%s: expected %s, got %s", tc.name, api.ErrCodeNotFound, resp.Error)
+			}
+		}
+	})
+
+	if w := get("not-a-uuid", "AC-1"); w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for invalid system ID, got %d", w.Code)
+	}
+}
@@ -23,20 +23,48 @@ type DiscoverSystemsResponse struct {
 
 // LocalSystemResponse represents an imported system.
 type LocalSystemResponse struct {
-	ID             uuid.UUID  `json:"id"`
-	SNSysID        string     `json:"sn_sys_id"`
-	Name           string     `json:"name"`
-	Description    string     `json:"description,omitempty"`
-	Acronym        string     `json:"acronym,omitempty"`
-	Owner          string     `json:"owner,omitempty"`
-	Status         string     `json:"status"`
-	ControlCount   int        `json:"control_count"`
-	StatementCount int        `json:"statement_count"`
-	ModifiedCount  int        `json:"modified_count"`
-	LastPullAt     *time.Time `json:"last_pull_at,omitempty"`
-	LastPushAt     *time.Time `json:"last_push_at,omitempty"`
-	CreatedAt      time.Time  `json:"created_at"`
-	UpdatedAt      time.Time  `json:"updated_at"`
+	ID               uuid.UUID  `json:"id"`
+	SNSysID          string     `json:"sn_sys_id"`
+	Name             string     `json:"name"`
+	Description      string     `json:"description,omitempty"`
+	Acronym          string     `json:"acronym,omitempty"`
+	Owner            string     `json:"owner,omitempty"`
+	Status           string     `json:"status"`
+	ConflictStrategy string     `json:"conflict_resolution_strategy,omitempty"`
+	ImportMode       string     `json:"import_mode,omitempty"`
+	ControlCount     int        `json:"control_count"`
+	StatementCount   int        `json:"statement_count"`
+	ModifiedCount    int        `json:"modified_count"`
+	LastPullAt       *time.Time `json:"last_pull_at,omitempty"`
+	LastPushAt       *time.Time `json:"last_push_at,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+	DeletedAt        *time.Time `json:"deleted_at,omitempty"`
+}
+
+// UpdateSystemRequest is the request body for editing a system's metadata.
+// Omitted fields are left unchanged; an empty string clears a field.
+type UpdateSystemRequest struct {
+	Acronym     *string `json:"acronym,omitempty"`
+	Owner       *string `json:"owner,omitempty"`
+	Description *string `json:"description,omitempty"`
+}
+
+// BatchStatusRequest is the request body for setting the status of several systems.
+type BatchStatusRequest struct {
+	SystemIDs []uuid.UUID `json:"system_ids"`
+	Status    string      `json:"status"`
+}
+
+// BatchStatusResponse reports how many systems a batch status update changed.
+type BatchStatusResponse struct {
+	Updated int `json:"updated"`
+}
+
+// SetConflictStrategyRequest is the request body for setting a system's conflict strategy.
+// An empty strategy reverts the system to the global default.
+type SetConflictStrategyRequest struct {
+	Strategy string `json:"strategy"`
 }
 
 // ListSystemsResponse is the response for listing local systems.
@@ -48,20 +76,119 @@ type ListSystemsResponse struct {
 	TotalPages int                   `json:"total_pages"`
 }
 
+// SystemSummaryResponse is the compliance summary for a system.
+type SystemSummaryResponse struct {
+	SystemID             uuid.UUID  `json:"system_id"`
+	Name                 string     `json:"name"`
+	TotalControls        int        `json:"total_controls"`
+	TotalStatements      int        `json:"total_statements"`
+	SyncedCount          int        `json:"synced_count"`
+	ModifiedCount        int        `json:"modified_count"`
+	ConflictCount        int        `json:"conflict_count"`
+	LastPullAt           *time.Time `json:"last_pull_at"`
+	CompliancePercentage float64    `json:"compliance_percentage"`
+}
+
+// ControlFamilyStatsResponse holds control and statement counts for one control family.
+type ControlFamilyStatsResponse struct {
+	Family             string `json:"family"`
+	TotalControls      int    `json:"total_controls"`
+	SyncedStatements   int    `json:"synced_statements"`
+	ModifiedStatements int    `json:"modified_statements"`
+	ConflictStatements int    `json:"conflict_statements"`
+	CoveragePct        int    `json:"coverage_pct"`
+}
+
+// CoverageGapResponse describes a control that has no statements.
+type CoverageGapResponse struct {
+	ControlID      string `json:"control_id"`
+	ControlName    string `json:"control_name"`
+	ControlFamily  string `json:"control_family,omitempty"`
+	StatementCount int    `json:"statement_count"`
+}
+
+// CompareSystemsResponse is the result of comparing two systems' controls.
+type CompareSystemsResponse struct {
+	OnlyInLeft      []string                    `json:"only_in_left"`
+	OnlyInRight     []string                    `json:"only_in_right"`
+	InBothDifferent []ControlDifferenceResponse `json:"in_both_different"`
+	Identical       []string                    `json:"identical"`
+}
+
+// ControlDifferenceResponse holds the two versions of a control that
+// differ between the compared systems.
+type ControlDifferenceResponse struct {
+	Left  ComparedControlResponse `json:"left"`
+	Right ComparedControlResponse `json:"right"`
+}
+
+// ComparedControlResponse is one system's version of a compared control.
+type ComparedControlResponse struct {
+	ID                   uuid.UUID `json:"id"`
+	ControlID            string    `json:"control_id"`
+	ControlName          string    `json:"control_name"`
+	ImplementationStatus string    `json:"implementation_status"`
+}
+
+// ControlStatementResponse is a statement of a control looked up by its
+// ServiceNow control ID.
+type ControlStatementResponse struct {
+	ID               uuid.UUID  `json:"id"`
+	ControlID        uuid.UUID  `json:"control_id"`
+	SNSysID          string     `json:"sn_sys_id"`
+	StatementType    string     `json:"statement_type"`
+	RemoteContent    string     `json:"remote_content,omitempty"`
+	LocalContent     string     `json:"local_content,omitempty"`
+	IsModified       bool       `json:"is_modified"`
+	SyncStatus       string     `json:"sync_status"`
+	EffectiveContent string     `json:"effective_content"`
+	Tags             []string   `json:"tags"`
+	LastPullAt       *time.Time `json:"last_pull_at,omitempty"`
+	LastPushAt       *time.Time `json:"last_push_at,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+// SyncStatusCountsResponse holds statement counts per sync status.
+type SyncStatusCountsResponse struct {
+	Synced   int `json:"synced"`
+	Modified int `json:"modified"`
+	Conflict int `json:"conflict"`
+	Total    int `json:"total"`
+}
+
+// SystemSyncStatusResponse holds the sync status counts of one system.
+type SystemSyncStatusResponse struct {
+	SystemID uuid.UUID `json:"system_id"`
+	Name     string    `json:"name"`
+	SyncStatusCountsResponse
+}
+
+// SyncStatusDashboardResponse aggregates sync status across all systems.
+type SyncStatusDashboardResponse struct {
+	Systems []SystemSyncStatusResponse `json:"systems"`
+	Global  SyncStatusCountsResponse   `json:"global"`
+}
+
 // ImportSystemsRequest is the request to import systems.
 type ImportSystemsRequest struct {
-	SNSysIDs []string `json:"sn_sys_ids"`
+	SNSysIDs   []string `json:"sn_sys_ids"`
+	EagerFetch bool     `json:"eager_fetch,omitempty"` // Start a pull for the imported systems
+	ImportMode string   `json:"import_mode,omitempty"` // editable (default) or read_only
 }
 
 // ImportSystemsResponse is the response after importing systems.
 type ImportSystemsResponse struct {
-	Imported []LocalSystemResponse `json:"imported"`
-	Count    int                   `json:"count"`
+	Imported  []LocalSystemResponse `json:"imported"`
+	Count     int                   `json:"count"`
+	PullJobID *uuid.UUID            `json:"pull_job_id,omitempty"`
+	PullError string                `json:"pull_error,omitempty"`
 }
 
 // StartPullRequest is the request to start a pull operation.
 type StartPullRequest struct {
-	SystemIDs []uuid.UUID `json:"system_ids"`
+	SystemIDs       []uuid.UUID `json:"system_ids"`
+	ConnectionLabel string      `json:"connection_label,omitempty"` // Defaults to "default"
 }
 
 // PullJobResponse represents a pull job.
@@ -74,6 +201,14 @@ type PullJobResponse struct {
 	CompletedAt *time.Time        `json:"completed_at,omitempty"`
 	Error       string            `json:"error,omitempty"`
 	CreatedAt   time.Time         `json:"created_at"`
+
+	ConnectionLabel string `json:"connection_label"`
+}
+
+// ListPullJobsResponse is the response for listing pull job history.
+type ListPullJobsResponse struct {
+	Jobs  []PullJobResponse `json:"jobs"`
+	Count int               `json:"count"`
 }
 
 // PullProgressResponse represents pull operation progress.
@@ -86,6 +221,26 @@ type PullProgressResponse struct {
 	CompletedStatements int    `json:"completed_statements"`
 	CurrentSystem     string   `json:"current_system,omitempty"`
 	Errors            []string `json:"errors,omitempty"`
+
+	CompletedSystemIDs []uuid.UUID `json:"completed_system_ids,omitempty"`
+
+	RetryAttempts map[uuid.UUID]int `json:"retry_attempts,omitempty"`
+}
+
+// PullEventResponse represents one entry of a pull job's event log.
+type PullEventResponse struct {
+	Timestamp       time.Time `json:"timestamp"`
+	SystemName      string    `json:"system_name,omitempty"`
+	ControlID       string    `json:"control_id,omitempty"`
+	StatementNumber string    `json:"statement_number,omitempty"`
+	EventType       string    `json:"event_type"`
+	Message         string    `json:"message"`
+}
+
+// PullEventsResponse is the response for a pull job's event log.
+type PullEventsResponse struct {
+	Events []PullEventResponse `json:"events"`
+	Count  int                 `json:"count"`
 }
 
 // ErrorResponse represents an error response.
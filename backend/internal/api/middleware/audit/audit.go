@@ -0,0 +1,227 @@
+// Package audit provides HTTP middleware that records API mutations to the audit log.
+package audit
+
+import (
+	"bytes"
+	"io"
+	"net"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/controlcrud/backend/internal/api/middleware/auth"
+	"github.com/controlcrud/backend/internal/api/middleware/requestid"
+	auditdomain "github.com/controlcrud/backend/internal/domain/audit"
+)
+
+// maxBodyBytes is the maximum number of request body bytes captured per event.
+const maxBodyBytes = 4 * 1024
+
+// apiPrefix is stripped from request paths before deriving entity and action.
+const apiPrefix = "/api/v1/"
+
+// sensitiveField matches JSON string fields whose values must not reach the audit log,
+// including values left unterminated by truncation. Key material is matched on a
+// whole "key" name segment (key, new_key, client_tls_key) so keyword-like names
+// are left alone.
+var sensitiveField = regexp.MustCompile(`("(?i:[A-Za-z_]*(?:password|secret|token|private)[A-Za-z_]*|(?:[A-Za-z_]*_)?key(?:_[A-Za-z_]*)?)"\s*:\s*)"(?:[^"\\]|\\.)*(?:"|\\?$)`)
+
+// AuditMiddleware returns middleware that records an audit event for every
+// POST, PUT, PATCH and DELETE request. Events are recorded asynchronously;
+// failures are logged by the audit service and never affect the response.
+func AuditMiddleware(auditService *auditdomain.Service) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !isMutation(r.Method) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			start := time.Now()
+
+			// Capture the start of the body and hand the full body on to the handler
+			body, truncated := captureBody(r)
+
+			rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(rec, r)
+
+			auditService.RecordAsync(buildEvent(r, rec.status, time.Since(start), body, truncated))
+		})
+	}
+}
+
+// isMutation returns true for methods that change server state.
+func isMutation(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// captureBody reads up to maxBodyBytes of the request body without consuming it.
+func captureBody(r *http.Request) (string, bool) {
+	if r.Body == nil || r.Body == http.NoBody {
+		return "", false
+	}
+
+	buf, err := io.ReadAll(io.LimitReader(r.Body, maxBodyBytes+1))
+	r.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(buf), r.Body), r.Body}
+	if err != nil {
+		return "", false
+	}
+
+	// Redact before truncating so a secret cut at the limit is still caught
+	truncated := len(buf) > maxBodyBytes
+	body := sensitiveField.ReplaceAllString(string(buf), `$1"[REDACTED]"`)
+	if len(body) > maxBodyBytes {
+		body = body[:maxBodyBytes]
+	}
+
+	return body, truncated
+}
+
+// buildEvent assembles the audit event for a completed request.
+func buildEvent(r *http.Request, status int, latency time.Duration, body string, truncated bool) auditdomain.Event {
+	entityType, entityID, action := classify(r.Method, r.URL.Path)
+
+	eventStatus := "success"
+	if status >= http.StatusBadRequest {
+		eventStatus = "failure"
+	}
+
+	details := map[string]interface{}{
+		"method":      r.Method,
+		"path":        r.URL.Path,
+		"status_code": status,
+		"latency_ms":  latency.Milliseconds(),
+	}
+	if rid := requestid.FromContext(r.Context()); rid != "" {
+		details["request_id"] = rid
+	}
+	if body != "" {
+		details["request_body"] = body
+		if truncated {
+			details["request_body_truncated"] = true
+		}
+	}
+
+	event := auditdomain.Event{
+		EventType:  auditdomain.EventTypeAPIMutation,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Action:     action,
+		Status:     eventStatus,
+		Details:    details,
+	}
+
+	// Get user email from context (set by auth middleware)
+	if user := auth.FromContext(r.Context()); user != nil && user.Email != "" {
+		email := user.Email
+		event.UserEmail = &email
+	}
+
+	if ip := clientIP(r); ip != "" {
+		event.IPAddress = &ip
+	}
+
+	return event
+}
+
+// classify derives entity type, entity ID and action from the request.
+// For example PUT /api/v1/statements/{id} yields ("statement", id, "update")
+// and POST /api/v1/statements/{id}/resolve yields ("statement", id, "resolve").
+func classify(method, path string) (entityType, entityID, action string) {
+	action = methodAction(method)
+
+	trimmed := strings.Trim(strings.TrimPrefix(path, apiPrefix), "/")
+	if trimmed == "" {
+		return "api", "", action
+	}
+
+	segments := strings.Split(trimmed, "/")
+
+	// Sync routes are grouped under /sync; the entity is the next segment
+	if segments[0] == "sync" && len(segments) > 1 {
+		segments = segments[1:]
+	}
+
+	entityType = singular(segments[0])
+	rest := segments[1:]
+
+	if len(rest) > 0 {
+		if _, err := uuid.Parse(rest[0]); err == nil {
+			entityID = rest[0]
+			rest = rest[1:]
+		}
+	}
+
+	// Sub-resources name the action: POST .../resolve -> resolve,
+	// PATCH .../credentials -> update_credentials
+	if len(rest) > 0 {
+		sub := strings.ReplaceAll(rest[len(rest)-1], "-", "_")
+		if method == http.MethodPost {
+			action = sub
+		} else {
+			action = action + "_" + sub
+		}
+	}
+
+	return entityType, entityID, action
+}
+
+// methodAction maps an HTTP method to an audit action.
+func methodAction(method string) string {
+	switch method {
+	case http.MethodPost:
+		return "create"
+	case http.MethodPut, http.MethodPatch:
+		return "update"
+	case http.MethodDelete:
+		return "delete"
+	default:
+		return strings.ToLower(method)
+	}
+}
+
+// singular converts a collection path segment to an entity type.
+func singular(segment string) string {
+	return strings.TrimSuffix(segment, "s")
+}
+
+// clientIP returns the remote host of the request.
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// statusRecorder captures the status code written by the wrapped handler.
+type statusRecorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
+
+// WriteHeader records the status code before delegating.
+func (r *statusRecorder) WriteHeader(status int) {
+	if !r.wroteHeader {
+		r.status = status
+		r.wroteHeader = true
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Write marks the header as written with an implicit 200.
+func (r *statusRecorder) Write(b []byte) (int, error) {
+	r.wroteHeader = true
+	return r.ResponseWriter.Write(b)
+}
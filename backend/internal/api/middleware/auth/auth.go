@@ -0,0 +1,199 @@
+// Package auth provides HTTP middleware authorizing requests with HS256-signed
+// JWT bearer tokens.
+package auth
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/controlcrud/backend/internal/api"
+)
+
+// Role claims required by guarded endpoints.
+const (
+	// RoleAdmin is required for administrative endpoints.
+	RoleAdmin = "admin"
+	// RoleReviewer is required to approve or reject statement changes.
+	RoleReviewer = "reviewer"
+)
+
+var (
+	// ErrInvalidToken is returned when a token is malformed or its signature does not verify.
+	ErrInvalidToken = errors.New("invalid token")
+
+	// ErrTokenExpired is returned when a token is outside its validity window.
+	ErrTokenExpired = errors.New("token expired")
+)
+
+// Claims are the JWT claims used for authorization. Roles may be given as a
+// single "role" claim or a "roles" array.
+type Claims struct {
+	Subject   string   `json:"sub,omitempty"`
+	Email     string   `json:"email,omitempty"`
+	Role      string   `json:"role,omitempty"`
+	Roles     []string `json:"roles,omitempty"`
+	ExpiresAt int64    `json:"exp,omitempty"`
+	NotBefore int64    `json:"nbf,omitempty"`
+}
+
+// HasRole returns true if the claims grant role.
+func (c *Claims) HasRole(role string) bool {
+	if c.Role == role {
+		return true
+	}
+	for _, r := range c.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseToken verifies an HS256-signed token with secret and returns its
+// claims. Tokens without an "exp" claim do not expire.
+func ParseToken(token string, secret []byte, now time.Time) (*Claims, error) {
+	if len(secret) == 0 {
+		return nil, ErrInvalidToken
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrInvalidToken
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil || header.Alg != "HS256" {
+		return nil, ErrInvalidToken
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return nil, ErrInvalidToken
+	}
+
+	var claims Claims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, ErrInvalidToken
+	}
+	if claims.ExpiresAt != 0 && now.Unix() >= claims.ExpiresAt {
+		return nil, ErrTokenExpired
+	}
+	if claims.NotBefore != 0 && now.Unix() < claims.NotBefore {
+		return nil, ErrTokenExpired
+	}
+	return &claims, nil
+}
+
+// decodeSegment decodes a base64url JSON token segment into v.
+func decodeSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// User identifies the caller of a request carrying a valid bearer token.
+type User struct {
+	// ID is the token subject when it is a UUID, nil otherwise.
+	ID    *uuid.UUID
+	Email string
+}
+
+// User returns the caller identified by the claims.
+func (c *Claims) User() *User {
+	user := &User{Email: c.Email}
+	if id, err := uuid.Parse(c.Subject); err == nil {
+		user.ID = &id
+	}
+	return user
+}
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying user.
+func NewContext(ctx context.Context, user *User) context.Context {
+	return context.WithValue(ctx, contextKey{}, user)
+}
+
+// FromContext returns the user stored in ctx, or nil if there is none.
+func FromContext(ctx context.Context) *User {
+	user, _ := ctx.Value(contextKey{}).(*User)
+	return user
+}
+
+// Middleware stores the user of requests carrying a valid bearer token in
+// the request context. Other requests pass through without a user;
+// RequireRole rejects them on routes that need one.
+func Middleware(secret string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok || token == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			claims, err := ParseToken(token, []byte(secret), time.Now())
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(NewContext(r.Context(), claims.User())))
+		})
+	}
+}
+
+// RequireRole returns middleware that only lets through requests carrying a
+// valid bearer token with the given role. It responds 401 when the token is
+// missing or invalid and 403 when the role is missing. An empty secret
+// rejects every request.
+func RequireRole(secret, role string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok || token == "" {
+				writeUnauthorized(w)
+				return
+			}
+
+			claims, err := ParseToken(token, []byte(secret), time.Now())
+			if err != nil {
+				writeUnauthorized(w)
+				return
+			}
+			if !claims.HasRole(role) {
+				writeError(w, http.StatusForbidden, api.ErrCodeForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func writeUnauthorized(w http.ResponseWriter) {
+	w.Header().Set("WWW-Authenticate", `Bearer realm="api"`)
+	writeError(w, http.StatusUnauthorized, api.ErrCodeUnauthorized)
+}
+
+func writeError(w http.ResponseWriter, status int, code api.ErrorCode) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": string(code)})
+}
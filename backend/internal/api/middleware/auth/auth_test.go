@@ -0,0 +1,106 @@
+package auth_test
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/controlcrud/backend/internal/api/middleware/auth"
+	"github.com/controlcrud/backend/internal/api/middleware/auth/authtest"
+)
+
+const testSecret = "test-secret"
+
+func doRequest(secret, authorization string) *httptest.ResponseRecorder {
+	handler := auth.RequireRole(secret, auth.RoleAdmin)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/db-stats", nil)
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	return w
+}
+
+func TestRequireRole(t *testing.T) {
+	future := time.Now().Add(time.Hour).Unix()
+
+	tests := []struct {
+		name          string
+		secret        string
+		authorization string
+		want          int
+	}{
+		{"admin role", testSecret, authtest.Bearer(testSecret, auth.Claims{Role: "admin", ExpiresAt: future}), http.StatusOK},
+		{"admin in roles", testSecret, authtest.Bearer(testSecret, auth.Claims{Roles: []string{"viewer", "admin"}}), http.StatusOK},
+		{"missing header", testSecret, "", http.StatusUnauthorized},
+		{"not bearer", testSecret, "Basic YWRtaW46cGFzcw==", http.StatusUnauthorized},
+		{"wrong secret", testSecret, authtest.Bearer("other-secret", auth.Claims{Role: "admin"}), http.StatusUnauthorized},
+		{"expired", testSecret, authtest.Bearer(testSecret, auth.Claims{Role: "admin", ExpiresAt: time.Now().Add(-time.Minute).Unix()}), http.StatusUnauthorized},
+		{"no secret configured", "", authtest.Bearer("", auth.Claims{Role: "admin"}), http.StatusUnauthorized},
+		{"missing role", testSecret, authtest.Bearer(testSecret, auth.Claims{Role: "viewer"}), http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := doRequest(tt.secret, tt.authorization)
+			if w.Code != tt.want {
+				t.Errorf("expected status %d, got %d", tt.want, w.Code)
+			}
+		})
+	}
+}
+
+func TestMiddleware_StoresUser(t *testing.T) {
+	id := uuid.New()
+
+	tests := []struct {
+		name          string
+		authorization string
+		want          *auth.User
+	}{
+		{"uuid subject", authtest.Bearer(testSecret, auth.Claims{Subject: id.String(), Email: "alice@example.com"}), &auth.User{ID: &id, Email: "alice@example.com"}},
+		{"other subject", authtest.Bearer(testSecret, auth.Claims{Subject: "alice", Email: "alice@example.com"}), &auth.User{Email: "alice@example.com"}},
+		{"missing header", "", nil},
+		{"wrong secret", authtest.Bearer("other-secret", auth.Claims{Subject: id.String()}), nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *auth.User
+			handler := auth.Middleware(testSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = auth.FromContext(r.Context())
+				w.WriteHeader(http.StatusOK)
+			}))
+			req := httptest.NewRequest(http.MethodPut, "/api/v1/statements/"+uuid.NewString(), nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("expected status 200, got %d", w.Code)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected user %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestParseToken_RejectsOtherAlgorithms(t *testing.T) {
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none"}`))
+	payload := base64.RawURLEncoding.EncodeToString([]byte(`{"role":"admin"}`))
+
+	if _, err := auth.ParseToken(header+"."+payload+".", []byte(testSecret), time.Now()); err != auth.ErrInvalidToken {
+		t.Errorf("expected auth.ErrInvalidToken, got %v", err)
+	}
+}
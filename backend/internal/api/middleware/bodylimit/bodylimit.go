@@ -0,0 +1,55 @@
+// Package bodylimit provides HTTP middleware capping request body sizes.
+package bodylimit
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/controlcrud/backend/internal/api"
+)
+
+// MaxBodySize returns middleware that rejects request bodies larger than
+// limit bytes with 413 Request Entity Too Large. Bodies declaring a larger
+// Content-Length are rejected up front; other bodies are wrapped with
+// http.MaxBytesReader so reads fail once the limit is passed.
+//
+// Evidence uploads to POST /api/v1/statements/{id}/attachments are capped at
+// uploadLimit instead. A limit of zero or less disables the middleware.
+func MaxBodySize(limit, uploadLimit int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if limit <= 0 {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			maxBytes := limit
+			if isAttachmentUpload(r) {
+				maxBytes = uploadLimit
+			}
+			if r.ContentLength > maxBytes {
+				w.Header().Set("Content-Type", "application/json")
+				w.Header().Set("Connection", "close")
+				w.WriteHeader(http.StatusRequestEntityTooLarge)
+				json.NewEncoder(w).Encode(map[string]string{"error": string(api.ErrCodePayloadTooLarge)})
+				return
+			}
+			r.Body = http.MaxBytesReader(w, r.Body, maxBytes)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// isAttachmentUpload reports whether the request is an evidence upload,
+// matching the route by method and path rather than by Content-Type, which
+// the client controls.
+func isAttachmentUpload(r *http.Request) bool {
+	if r.Method != http.MethodPost {
+		return false
+	}
+	id, ok := strings.CutPrefix(r.URL.Path, "/api/v1/statements/")
+	if !ok {
+		return false
+	}
+	id, ok = strings.CutSuffix(id, "/attachments")
+	return ok && id != "" && !strings.Contains(id, "/")
+}
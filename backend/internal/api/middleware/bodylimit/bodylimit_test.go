@@ -0,0 +1,141 @@
+package bodylimit
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	connHandler "github.com/controlcrud/backend/internal/api/handlers/connection"
+	"github.com/controlcrud/backend/internal/api/middleware/auth"
+	"github.com/controlcrud/backend/internal/domain/connection"
+	"github.com/controlcrud/backend/internal/infrastructure/crypto"
+)
+
+// memoryRepository stores saved connections for the config-save endpoint.
+type memoryRepository struct {
+	connection.Repository
+	saved []*connection.Connection
+}
+
+func (m *memoryRepository) GetByLabel(ctx context.Context, label string) (*connection.Connection, error) {
+	return nil, nil
+}
+
+func (m *memoryRepository) Upsert(ctx context.Context, conn *connection.Connection) error {
+	m.saved = append(m.saved, conn)
+	return nil
+}
+
+const configBody = `{"instance_url":"https://dev12345.service-now.com","auth_method":"basic","username":"admin","password":"secret"}`
+
+// paddedConfig returns a valid config-save body padded with trailing
+// whitespace to exactly size bytes.
+func paddedConfig(size int) []byte {
+	return []byte(configBody + strings.Repeat(" ", size-len(configBody)))
+}
+
+func newConfigServer(t *testing.T, limit int64) (http.Handler, *memoryRepository) {
+	t.Helper()
+	cryptoSvc, err := crypto.NewAESCryptoService(base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, 32)))
+	if err != nil {
+		t.Fatalf("failed to create crypto service: %v", err)
+	}
+	repo := &memoryRepository{}
+	mux := http.NewServeMux()
+	connHandler.NewHandler(connection.NewService(repo, cryptoSvc, connection.Options{}), auth.RequireRole("test-secret", auth.RoleAdmin)).RegisterRoutes(mux)
+	return MaxBodySize(limit, limit)(mux), repo
+}
+
+func TestMaxBodySize_ConfigSave(t *testing.T) {
+	const limit = 1024
+
+	tests := []struct {
+		name      string
+		size      int
+		want      int
+		wantSaved bool
+	}{
+		{"exactly the limit", limit, http.StatusOK, true},
+		{"one byte over the limit", limit + 1, http.StatusRequestEntityTooLarge, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, repo := newConfigServer(t, limit)
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/connection/config", bytes.NewReader(paddedConfig(tt.size)))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tt.want {
+				t.Fatalf("expected status %d, got %d: %s", tt.want, w.Code, w.Body.String())
+			}
+			if saved := len(repo.saved) > 0; saved != tt.wantSaved {
+				t.Errorf("expected config saved %v, got %v", tt.wantSaved, saved)
+			}
+			if tt.want == http.StatusRequestEntityTooLarge && !strings.Contains(w.Body.String(), `"error":"request_entity_too_large"`) {
+				t.Errorf("unexpected body %s", w.Body.String())
+			}
+		})
+	}
+}
+
+func TestMaxBodySize_UnknownLength(t *testing.T) {
+	var readErr error
+	handler := MaxBodySize(16, 16)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, readErr = io.ReadAll(r.Body)
+	}))
+
+	// Chunked bodies have no Content-Length, so the limit applies while reading
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/statements", io.NopCloser(strings.NewReader(strings.Repeat("x", 17))))
+	req.ContentLength = -1
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	var maxErr *http.MaxBytesError
+	if !errors.As(readErr, &maxErr) {
+		t.Errorf("expected MaxBytesError reading an oversized body, got %v", readErr)
+	}
+}
+
+func TestMaxBodySize_AttachmentUpload(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		size   int
+		want   int
+	}{
+		{"upload under its own limit", http.MethodPost, "/api/v1/statements/1/attachments", 64, http.StatusOK},
+		{"upload over its own limit", http.MethodPost, "/api/v1/statements/1/attachments", 65, http.StatusRequestEntityTooLarge},
+		{"multipart elsewhere uses the default limit", http.MethodPost, "/api/v1/statements/1", 17, http.StatusRequestEntityTooLarge},
+		{"nested path uses the default limit", http.MethodPost, "/api/v1/statements/1/x/attachments", 17, http.StatusRequestEntityTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var read int
+			handler := MaxBodySize(16, 64)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				read = len(body)
+			}))
+
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(strings.Repeat("x", tt.size)))
+			req.Header.Set("Content-Type", "multipart/form-data; boundary=xyz")
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tt.want {
+				t.Fatalf("expected status %d, got %d", tt.want, w.Code)
+			}
+			if tt.want == http.StatusOK && read != tt.size {
+				t.Errorf("expected %d bytes passed through, got %d", tt.size, read)
+			}
+		})
+	}
+}
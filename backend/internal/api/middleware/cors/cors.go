@@ -0,0 +1,68 @@
+// Package cors provides HTTP middleware enforcing the configured CORS policy.
+package cors
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/controlcrud/backend/internal/config"
+)
+
+// Middleware returns middleware that applies the given CORS policy.
+// Requests from origins outside the allowlist receive no CORS headers,
+// which causes browsers to block the cross-origin response.
+func Middleware(cfg config.CORSConfig) func(http.Handler) http.Handler {
+	allowAny := cfg.AllowsAnyOrigin()
+
+	allowed := make(map[string]bool, len(cfg.AllowedOrigins))
+	for _, origin := range cfg.AllowedOrigins {
+		allowed[origin] = true
+	}
+
+	methods := strings.Join(cfg.AllowedMethods, ", ")
+	headers := strings.Join(cfg.AllowedHeaders, ", ")
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+
+			if origin != "" {
+				switch {
+				case allowAny && !cfg.AllowCredentials:
+					// Wildcard is only safe when credentials are not allowed
+					w.Header().Set("Access-Control-Allow-Origin", "*")
+					setPolicyHeaders(w, methods, headers)
+				case allowed[origin]:
+					w.Header().Set("Access-Control-Allow-Origin", origin)
+					w.Header().Add("Vary", "Origin")
+					if cfg.AllowCredentials {
+						w.Header().Set("Access-Control-Allow-Credentials", "true")
+					}
+					setPolicyHeaders(w, methods, headers)
+				}
+			}
+
+			// Handle preflight requests
+			if r.Method == http.MethodOptions {
+				if cfg.MaxAge > 0 && w.Header().Get("Access-Control-Allow-Origin") != "" {
+					w.Header().Set("Access-Control-Max-Age", strconv.Itoa(cfg.MaxAge))
+				}
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// setPolicyHeaders sets the allowed methods and headers.
+func setPolicyHeaders(w http.ResponseWriter, methods, headers string) {
+	if methods != "" {
+		w.Header().Set("Access-Control-Allow-Methods", methods)
+	}
+	if headers != "" {
+		w.Header().Set("Access-Control-Allow-Headers", headers)
+	}
+}
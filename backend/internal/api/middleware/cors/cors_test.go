@@ -0,0 +1,127 @@
+package cors
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/controlcrud/backend/internal/config"
+)
+
+func newTestHandler(cfg config.CORSConfig) http.Handler {
+	return Middleware(cfg)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+}
+
+func TestMiddleware_AllowedOrigin(t *testing.T) {
+	handler := newTestHandler(config.CORSConfig{
+		AllowedOrigins:   []string{"https://autogrc.example.com"},
+		AllowedMethods:   []string{"GET", "POST"},
+		AllowedHeaders:   []string{"Content-Type"},
+		AllowCredentials: true,
+		MaxAge:           300,
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/connection/status", nil)
+	req.Header.Set("Origin", "https://autogrc.example.com")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d", w.Code)
+	}
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "https://autogrc.example.com" {
+		t.Errorf("expected origin to be echoed, got '%s'", got)
+	}
+	if got := w.Header().Get("Access-Control-Allow-Credentials"); got != "true" {
+		t.Errorf("expected credentials header 'true', got '%s'", got)
+	}
+	if got := w.Header().Get("Access-Control-Allow-Methods"); got != "GET, POST" {
+		t.Errorf("unexpected methods header: '%s'", got)
+	}
+	if got := w.Header().Get("Vary"); got != "Origin" {
+		t.Errorf("expected Vary: Origin, got '%s'", got)
+	}
+}
+
+func TestMiddleware_DisallowedOrigin(t *testing.T) {
+	handler := newTestHandler(config.CORSConfig{
+		AllowedOrigins: []string{"https://autogrc.example.com"},
+		AllowedMethods: []string{"GET"},
+		AllowedHeaders: []string{"Content-Type"},
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/connection/status", nil)
+	req.Header.Set("Origin", "https://evil.example.com")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	for _, header := range []string{
+		"Access-Control-Allow-Origin",
+		"Access-Control-Allow-Methods",
+		"Access-Control-Allow-Headers",
+		"Access-Control-Allow-Credentials",
+	} {
+		if got := w.Header().Get(header); got != "" {
+			t.Errorf("expected no %s header, got '%s'", header, got)
+		}
+	}
+}
+
+func TestMiddleware_Wildcard(t *testing.T) {
+	handler := newTestHandler(config.CORSConfig{
+		AllowedOrigins: []string{"*"},
+		AllowedMethods: []string{"GET"},
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/health", nil)
+	req.Header.Set("Origin", "https://anywhere.example.com")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "*" {
+		t.Errorf("expected wildcard origin, got '%s'", got)
+	}
+}
+
+func TestMiddleware_WildcardWithCredentials(t *testing.T) {
+	handler := newTestHandler(config.CORSConfig{
+		AllowCredentials: true,
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/health", nil)
+	req.Header.Set("Origin", "https://anywhere.example.com")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "" {
+		t.Errorf("expected no wildcard when credentials are allowed, got '%s'", got)
+	}
+}
+
+func TestMiddleware_Preflight(t *testing.T) {
+	called := false
+	handler := Middleware(config.CORSConfig{
+		AllowedOrigins: []string{"https://autogrc.example.com"},
+		AllowedMethods: []string{"GET", "PATCH"},
+		MaxAge:         600,
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+
+	req := httptest.NewRequest(http.MethodOptions, "/api/v1/connection/credentials", nil)
+	req.Header.Set("Origin", "https://autogrc.example.com")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNoContent {
+		t.Errorf("expected status 204, got %d", w.Code)
+	}
+	if called {
+		t.Error("expected preflight request not to reach the next handler")
+	}
+	if got := w.Header().Get("Access-Control-Max-Age"); got != "600" {
+		t.Errorf("expected max age '600', got '%s'", got)
+	}
+}
@@ -0,0 +1,147 @@
+// Package ratelimit provides HTTP middleware enforcing a per-IP rate limit.
+package ratelimit
+
+import (
+	"encoding/json"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/controlcrud/backend/internal/api"
+)
+
+const (
+	// cleanupInterval is how often idle client buckets are evicted.
+	cleanupInterval = time.Minute
+
+	// maxIdle is how long a client bucket may go unused before eviction.
+	maxIdle = 10 * time.Minute
+)
+
+// exemptPaths are never rate limited so probes and scrapers keep working.
+var exemptPaths = map[string]bool{
+	"/health":  true,
+	"/metrics": true,
+}
+
+// bucket is the rate limiter for a single client.
+type bucket struct {
+	limiter  *rate.Limiter
+	mu       sync.Mutex
+	lastSeen time.Time
+}
+
+// Limiter tracks a rate.Limiter per client IP.
+type Limiter struct {
+	rps     float64
+	burst   int
+	buckets sync.Map // map[string]*bucket keyed by client IP
+	now     func() time.Time
+}
+
+// NewLimiter creates a limiter allowing rps sustained requests per second
+// per client IP, with bursts of up to burst requests.
+func NewLimiter(rps float64, burst int) *Limiter {
+	return &Limiter{
+		rps:   rps,
+		burst: burst,
+		now:   time.Now,
+	}
+}
+
+// Middleware returns middleware that rate limits requests per client IP and
+// evicts idle clients in the background. A non-positive rps disables limiting.
+func Middleware(rps float64, burst int) func(http.Handler) http.Handler {
+	if rps <= 0 {
+		return func(next http.Handler) http.Handler { return next }
+	}
+
+	limiter := NewLimiter(rps, burst)
+	go func() {
+		ticker := time.NewTicker(cleanupInterval)
+		defer ticker.Stop()
+		for range ticker.C {
+			limiter.Cleanup(maxIdle)
+		}
+	}()
+
+	return limiter.Middleware
+}
+
+// Middleware rejects requests with 429 once the client's bucket is empty.
+func (l *Limiter) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if exemptPaths[r.URL.Path] {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		allowed, retryAfter := l.Allow(clientIP(r))
+		if !allowed {
+			seconds := int(math.Ceil(retryAfter.Seconds()))
+			if seconds < 1 {
+				seconds = 1
+			}
+			w.Header().Set("Retry-After", strconv.Itoa(seconds))
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusTooManyRequests)
+			json.NewEncoder(w).Encode(map[string]string{"error": string(api.ErrCodeRateLimited)})
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Allow consumes a token for key. When the bucket is empty it returns false
+// and how long until the next token becomes available.
+func (l *Limiter) Allow(key string) (bool, time.Duration) {
+	now := l.now()
+
+	value, _ := l.buckets.LoadOrStore(key, &bucket{limiter: rate.NewLimiter(rate.Limit(l.rps), l.burst)})
+	b := value.(*bucket)
+
+	b.mu.Lock()
+	b.lastSeen = now
+	b.mu.Unlock()
+
+	reservation := b.limiter.ReserveN(now, 1)
+	if !reservation.OK() {
+		// A zero burst never admits a request
+		return false, time.Duration(float64(time.Second) / l.rps)
+	}
+	if delay := reservation.DelayFrom(now); delay > 0 {
+		reservation.CancelAt(now)
+		return false, delay
+	}
+	return true, 0
+}
+
+// Cleanup evicts buckets that have not been used for longer than idle.
+func (l *Limiter) Cleanup(idle time.Duration) {
+	cutoff := l.now().Add(-idle)
+	l.buckets.Range(func(key, value interface{}) bool {
+		b := value.(*bucket)
+		b.mu.Lock()
+		stale := b.lastSeen.Before(cutoff)
+		b.mu.Unlock()
+		if stale {
+			l.buckets.Delete(key)
+		}
+		return true
+	})
+}
+
+// clientIP returns the host part of the request's remote address.
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,122 @@
+package ratelimit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestLimiter(rps float64, burst int, now *time.Time) (*Limiter, http.Handler) {
+	limiter := NewLimiter(rps, burst)
+	limiter.now = func() time.Time { return *now }
+	handler := limiter.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	return limiter, handler
+}
+
+func doRequest(handler http.Handler, path, remoteAddr string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.RemoteAddr = remoteAddr
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	return w
+}
+
+func TestMiddleware_UnderBurstAllowed(t *testing.T) {
+	now := time.Now()
+	_, handler := newTestLimiter(1, 3, &now)
+
+	for i := 0; i < 3; i++ {
+		w := doRequest(handler, "/api/v1/statements", "10.0.0.1:5000")
+		if w.Code != http.StatusOK {
+			t.Fatalf("request %d: expected status 200, got %d", i+1, w.Code)
+		}
+	}
+}
+
+func TestMiddleware_ExceedingBurstReturns429(t *testing.T) {
+	now := time.Now()
+	_, handler := newTestLimiter(0.5, 2, &now)
+
+	for i := 0; i < 2; i++ {
+		doRequest(handler, "/api/v1/statements", "10.0.0.1:5000")
+	}
+
+	// A new source port on the same IP shares the bucket
+	w := doRequest(handler, "/api/v1/statements", "10.0.0.1:5001")
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected status 429, got %d", w.Code)
+	}
+	if got := w.Header().Get("Retry-After"); got != "2" {
+		t.Errorf("expected Retry-After '2', got '%s'", got)
+	}
+	if !strings.Contains(w.Body.String(), `"error":"rate_limit_exceeded"`) {
+		t.Errorf("unexpected body: %s", w.Body.String())
+	}
+
+	// Other clients are unaffected
+	if w := doRequest(handler, "/api/v1/statements", "10.0.0.2:5000"); w.Code != http.StatusOK {
+		t.Errorf("expected other IP to get 200, got %d", w.Code)
+	}
+}
+
+func TestMiddleware_TokensRefill(t *testing.T) {
+	now := time.Now()
+	_, handler := newTestLimiter(1, 1, &now)
+
+	doRequest(handler, "/api/v1/statements", "10.0.0.1:5000")
+	if w := doRequest(handler, "/api/v1/statements", "10.0.0.1:5000"); w.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected status 429, got %d", w.Code)
+	}
+
+	now = now.Add(time.Second)
+	if w := doRequest(handler, "/api/v1/statements", "10.0.0.1:5000"); w.Code != http.StatusOK {
+		t.Errorf("expected status 200 after refill, got %d", w.Code)
+	}
+}
+
+func TestMiddleware_ExemptPaths(t *testing.T) {
+	now := time.Now()
+	_, handler := newTestLimiter(1, 1, &now)
+
+	for _, path := range []string{"/health", "/metrics"} {
+		for i := 0; i < 5; i++ {
+			if w := doRequest(handler, path, "10.0.0.1:5000"); w.Code != http.StatusOK {
+				t.Fatalf("%s request %d: expected status 200, got %d", path, i+1, w.Code)
+			}
+		}
+	}
+}
+
+func TestLimiter_Cleanup(t *testing.T) {
+	now := time.Now()
+	limiter, handler := newTestLimiter(1, 1, &now)
+
+	doRequest(handler, "/api/v1/statements", "10.0.0.1:5000")
+	now = now.Add(5 * time.Minute)
+	doRequest(handler, "/api/v1/statements", "10.0.0.2:5000")
+
+	limiter.Cleanup(time.Minute)
+
+	if _, ok := limiter.buckets.Load("10.0.0.1"); ok {
+		t.Error("expected idle client to be evicted")
+	}
+	if _, ok := limiter.buckets.Load("10.0.0.2"); !ok {
+		t.Error("expected active client to be kept")
+	}
+}
+
+func TestMiddleware_Disabled(t *testing.T) {
+	handler := Middleware(0, 0)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	for i := 0; i < 10; i++ {
+		if w := doRequest(handler, "/api/v1/statements", "10.0.0.1:5000"); w.Code != http.StatusOK {
+			t.Fatalf("expected status 200 with rate limiting disabled, got %d", w.Code)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+// Package requestid provides HTTP middleware that tags each request with a
+// correlation ID for tracing log lines back to a single API call.
+package requestid
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// HeaderName is the response header carrying the request ID.
+const HeaderName = "X-Request-ID"
+
+type contextKey struct{}
+
+// Middleware generates a request ID, exposes it via the X-Request-ID response
+// header and stores it in the request context.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rid := uuid.New().String()
+		w.Header().Set(HeaderName, rid)
+		next.ServeHTTP(w, r.WithContext(NewContext(r.Context(), rid)))
+	})
+}
+
+// NewContext returns a copy of ctx carrying the request ID.
+func NewContext(ctx context.Context, rid string) context.Context {
+	return context.WithValue(ctx, contextKey{}, rid)
+}
+
+// FromContext returns the request ID stored in ctx, or "" if there is none.
+func FromContext(ctx context.Context) string {
+	rid, _ := ctx.Value(contextKey{}).(string)
+	return rid
+}
+
+// Logger returns logger annotated with the request ID from ctx.
+// The logger is returned unchanged when ctx carries no request ID.
+func Logger(ctx context.Context, logger *slog.Logger) *slog.Logger {
+	if rid := FromContext(ctx); rid != "" {
+		return logger.With("request_id", rid)
+	}
+	return logger
+}
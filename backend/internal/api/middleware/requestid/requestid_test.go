@@ -0,0 +1,70 @@
+package requestid
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestMiddleware_HeaderMatchesLoggedID(t *testing.T) {
+	var buf bytes.Buffer
+	base := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	handler := Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		Logger(r.Context(), base).Error("failed to list statements")
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/statements", nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	header := w.Header().Get(HeaderName)
+	if _, err := uuid.Parse(header); err != nil {
+		t.Fatalf("expected UUID request ID header, got %q", header)
+	}
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("failed to decode log entry: %v", err)
+	}
+	if entry["request_id"] != header {
+		t.Errorf("expected logged request_id %q, got %v", header, entry["request_id"])
+	}
+}
+
+func TestMiddleware_UniquePerRequest(t *testing.T) {
+	handler := Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	seen := make(map[string]bool)
+	for i := 0; i < 3; i++ {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health", nil))
+		rid := w.Header().Get(HeaderName)
+		if seen[rid] {
+			t.Fatalf("duplicate request ID %q", rid)
+		}
+		seen[rid] = true
+	}
+}
+
+func TestLogger_WithoutRequestID(t *testing.T) {
+	var buf bytes.Buffer
+	base := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	Logger(context.Background(), base).Info("startup")
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("failed to decode log entry: %v", err)
+	}
+	if _, ok := entry["request_id"]; ok {
+		t.Error("expected no request_id attribute")
+	}
+}
@@ -0,0 +1,69 @@
+// Package timeout provides HTTP middleware applying per-category response
+// timeouts.
+package timeout
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/controlcrud/backend/internal/api"
+	"github.com/controlcrud/backend/internal/config"
+)
+
+// Path prefixes of the route groups with their own timeouts.
+const (
+	pullPrefix = "/api/v1/sync/"
+	pushPrefix = "/api/v1/push"
+)
+
+// streamPaths are long-lived streaming routes. http.TimeoutHandler buffers
+// the response, so these are served without a timeout.
+var streamPaths = map[string]bool{
+	"/api/v1/audit/stream": true,
+}
+
+// isStreamRoute reports whether the request is served by a streaming route:
+// one of streamPaths or an evidence download, which bounds itself.
+func isStreamRoute(r *http.Request) bool {
+	if streamPaths[r.URL.Path] {
+		return true
+	}
+	if r.Method != http.MethodGet {
+		return false
+	}
+	rest, ok := strings.CutPrefix(r.URL.Path, "/api/v1/statements/")
+	if !ok {
+		return false
+	}
+	parts := strings.Split(rest, "/")
+	return len(parts) == 3 && parts[0] != "" && parts[1] == "attachments" && parts[2] != ""
+}
+
+// timeoutBody is the response body sent when a handler times out.
+const timeoutBody = `{"error":"` + string(api.ErrCodeTimeout) + `"}`
+
+// Middleware returns middleware that cancels requests running longer than
+// their category's write timeout and responds 503 Service Unavailable.
+// Sync and pull routes use PullWriteTimeout, push routes PushWriteTimeout
+// and all other routes DefaultWriteTimeout. Streaming routes, including
+// evidence downloads, are exempt.
+func Middleware(cfg config.TimeoutsConfig) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		pull := http.TimeoutHandler(next, cfg.PullWriteTimeout, timeoutBody)
+		push := http.TimeoutHandler(next, cfg.PushWriteTimeout, timeoutBody)
+		fallback := http.TimeoutHandler(next, cfg.DefaultWriteTimeout, timeoutBody)
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch path := r.URL.Path; {
+			case isStreamRoute(r):
+				next.ServeHTTP(w, r)
+			case strings.HasPrefix(path, pullPrefix):
+				pull.ServeHTTP(w, r)
+			case path == pushPrefix || strings.HasPrefix(path, pushPrefix+"/"):
+				push.ServeHTTP(w, r)
+			default:
+				fallback.ServeHTTP(w, r)
+			}
+		})
+	}
+}
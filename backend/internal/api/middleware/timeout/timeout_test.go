@@ -0,0 +1,77 @@
+package timeout
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/controlcrud/backend/internal/config"
+)
+
+// slowHandler responds after delay unless the request is cancelled first.
+func slowHandler(delay time.Duration) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(delay):
+			w.WriteHeader(http.StatusOK)
+		case <-r.Context().Done():
+		}
+	})
+}
+
+func TestMiddleware_CategoryTimeouts(t *testing.T) {
+	cfg := config.TimeoutsConfig{
+		DefaultWriteTimeout: 20 * time.Millisecond,
+		PullWriteTimeout:    time.Second,
+		PushWriteTimeout:    20 * time.Millisecond,
+	}
+	handler := Middleware(cfg)(slowHandler(100 * time.Millisecond))
+
+	tests := []struct {
+		path string
+		want int
+	}{
+		{"/api/v1/sync/pull/123", http.StatusOK},
+		{"/api/v1/push/123", http.StatusServiceUnavailable},
+		{"/api/v1/pushes", http.StatusServiceUnavailable},
+		{"/api/v1/statements", http.StatusServiceUnavailable},
+		{"/api/v1/audit/stream", http.StatusOK},
+		{"/api/v1/statements/1/attachments/2", http.StatusOK},
+		{"/api/v1/statements/1/attachments", http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			if w.Code != tt.want {
+				t.Errorf("expected status %d, got %d", tt.want, w.Code)
+			}
+		})
+	}
+}
+
+func TestMiddleware_PullTimesOut(t *testing.T) {
+	cfg := config.TimeoutsConfig{
+		DefaultWriteTimeout: time.Second,
+		PullWriteTimeout:    50 * time.Millisecond,
+		PushWriteTimeout:    time.Second,
+	}
+	handler := Middleware(cfg)(slowHandler(time.Second))
+
+	start := time.Now()
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/sync/pull/123", nil))
+	elapsed := time.Since(start)
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected status 503, got %d", w.Code)
+	}
+	if w.Body.String() != timeoutBody {
+		t.Errorf("unexpected body: %s", w.Body.String())
+	}
+	if elapsed < cfg.PullWriteTimeout || elapsed >= cfg.DefaultWriteTimeout {
+		t.Errorf("expected timeout after %v, took %v", cfg.PullWriteTimeout, elapsed)
+	}
+}
@@ -4,25 +4,51 @@ package config
 import (
 	"errors"
 	"fmt"
+	"net/url"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
 // Config holds all configuration for the backend server.
 type Config struct {
+	Environment string // Deployment environment; "production" enables stricter validation
+
 	Server      ServerConfig
 	Database    DatabaseConfig
 	Encryption  EncryptionConfig
 	ServiceNow  ServiceNowConfig
+	CORS        CORSConfig
+	Review      ReviewConfig
+	Statements  StatementsConfig
+	Audit       AuditConfig
+	Sync        SyncConfig
+	Auth        AuthConfig
+	Push        PushConfig
+	Webhook     WebhookConfig
+	SMTP        SMTPConfig
+
+	Logging  LoggingConfig
+	Timeouts TimeoutsConfig
+
+	Pagination PaginationDefaults
 }
 
 // ServerConfig holds HTTP server configuration.
 type ServerConfig struct {
-	Port         int
-	ReadTimeout  time.Duration
-	WriteTimeout time.Duration
-	IdleTimeout  time.Duration
+	Port        int
+	ReadTimeout time.Duration
+	IdleTimeout time.Duration
+
+	RateLimitRPS   float64 // Sustained requests per second per client IP; 0 disables rate limiting
+	RateLimitBurst int     // Maximum requests a client IP may burst above the sustained rate
+
+	MaxRequestBodyBytes int64 // Largest accepted request body outside evidence uploads
+	MaxUploadBodyBytes  int64 // Largest accepted evidence upload body, multipart framing included
+
+	GRPCPort int    // Port of the internal gRPC API; 0 disables it
+	GRPCHost string // Interface the gRPC API listens on; loopback unless set
 }
 
 // DatabaseConfig holds database connection configuration.
@@ -33,6 +59,24 @@ type DatabaseConfig struct {
 	Password string
 	Name     string
 	SSLMode  string
+
+	MigrationsDir string // Override for the embedded migrations; empty uses embedded
+
+	URL string // Full connection string; overrides the fields above when set
+
+	MaxOpenConns           int // Maximum open connections in the pool
+	MaxIdleConns           int // Maximum idle connections kept in the pool; must not exceed MaxOpenConns
+	ConnMaxLifetimeMinutes int // Connections older than this are closed and replaced; 0 keeps them forever
+
+	QueryTimeouts QueryTimeoutsConfig
+}
+
+// QueryTimeoutsConfig holds the per-operation database query timeouts.
+// A zero timeout leaves that kind of operation unbounded.
+type QueryTimeoutsConfig struct {
+	SingleRow time.Duration // Lookups and writes of a single row
+	List      time.Duration // Paginated lists and aggregations
+	Batch     time.Duration // Batch upserts and other multi-row writes
 }
 
 // EncryptionConfig holds encryption key configuration.
@@ -42,18 +86,205 @@ type EncryptionConfig struct {
 
 // ServiceNowConfig holds ServiceNow client configuration.
 type ServiceNowConfig struct {
-	Timeout    time.Duration
-	MaxRetries int
+	Timeout          time.Duration
+	MaxRetries       int
+	TableMappingFile string // JSON table/field mapping; empty uses the demo mapping
+	ProxyURL         string // Forward proxy for ServiceNow requests; empty uses HTTP_PROXY/HTTPS_PROXY
+
+	// Forward proxy requiring NTLM authentication; takes precedence over ProxyURL
+	NTLMProxyURL      string
+	NTLMProxyUsername string
+	NTLMProxyPassword string
+	NTLMProxyDomain   string
+
+	CacheTTL time.Duration // Systems list response cache lifetime; 0 disables caching
+	UseGzip  bool          // Compress ServiceNow request and response bodies
+
+	StripHTMLOnPull bool // Convert HTML in pulled statement content to plain text
+
+	OAuthTokenCacheDir string // Directory persisting OAuth tokens across restarts; empty keeps them in memory
+}
+
+// CORSConfig holds cross-origin resource sharing policy configuration.
+type CORSConfig struct {
+	AllowedOrigins   []string // Empty or ["*"] allows any origin (only without credentials)
+	AllowedMethods   []string
+	AllowedHeaders   []string
+	AllowCredentials bool
+	MaxAge           int // Preflight cache duration in seconds
+}
+
+// AllowsAnyOrigin returns true if the policy is configured with a wildcard origin.
+func (c *CORSConfig) AllowsAnyOrigin() bool {
+	if len(c.AllowedOrigins) == 0 {
+		return true
+	}
+	for _, origin := range c.AllowedOrigins {
+		if origin == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// ReviewConfig holds statement approval workflow configuration.
+type ReviewConfig struct {
+	Required bool // Require reviewer approval before modified statements can be pushed
+}
+
+// StatementsConfig holds statement content policy configuration.
+type StatementsConfig struct {
+	MinWords int // Minimum words in edited content; 0 disables the check
+	MaxChars int // Maximum edited content size in bytes; 0 disables the check
+
+	ContentSanitizationMode string // strict, ugc or none; HTML kept in edited content
+	UnwrapOnSave            bool   // Join hard-wrapped lines of edited content into paragraphs
+}
+
+// AuditConfig holds audit log retention configuration.
+type AuditConfig struct {
+	RetentionDays    int // Delete audit events older than this many days; 0 keeps them forever
+	RetentionMaxRows int // Keep at most this many audit events; 0 disables the limit
+
+	SigningKeyPath string // PEM ECDSA private key signing audit exports; empty exports carry only a hash
+}
+
+// TimeoutsConfig holds the response write timeouts for each endpoint
+// category. Pull and push routes get their own timeouts because their
+// status polling can legitimately take longer than the statement reads.
+type TimeoutsConfig struct {
+	DefaultWriteTimeout time.Duration
+	PullWriteTimeout    time.Duration
+	PushWriteTimeout    time.Duration
+}
+
+// MaxWriteTimeout returns the longest of the category write timeouts.
+func (c TimeoutsConfig) MaxWriteTimeout() time.Duration {
+	return max(c.DefaultWriteTimeout, c.PullWriteTimeout, c.PushWriteTimeout)
+}
+
+// Maximum page sizes per entity type. Requests above these are clamped.
+const (
+	MaxStatementsPageSize = 200
+	MaxControlsPageSize   = 100
+	MaxSystemsPageSize    = 100
+	MaxAuditPageSize      = 500
+)
+
+// PaginationDefaults holds the page size used by each list endpoint when a
+// request omits page_size. Zero values fall back to the built-in defaults.
+type PaginationDefaults struct {
+	StatementsDefaultPageSize int
+	ControlsDefaultPageSize   int
+	SystemsDefaultPageSize    int
+	AuditDefaultPageSize      int
+}
+
+// DefaultPagination returns the built-in page size defaults.
+func DefaultPagination() PaginationDefaults {
+	return PaginationDefaults{
+		StatementsDefaultPageSize: 20,
+		ControlsDefaultPageSize:   20,
+		SystemsDefaultPageSize:    20,
+		AuditDefaultPageSize:      50,
+	}
+}
+
+// StatementsPageSize resolves a requested statements page size.
+func (p PaginationDefaults) StatementsPageSize(requested int) int {
+	return resolvePageSize(requested, p.StatementsDefaultPageSize, DefaultPagination().StatementsDefaultPageSize, MaxStatementsPageSize)
+}
+
+// ControlsPageSize resolves a requested controls page size.
+func (p PaginationDefaults) ControlsPageSize(requested int) int {
+	return resolvePageSize(requested, p.ControlsDefaultPageSize, DefaultPagination().ControlsDefaultPageSize, MaxControlsPageSize)
+}
+
+// SystemsPageSize resolves a requested systems page size.
+func (p PaginationDefaults) SystemsPageSize(requested int) int {
+	return resolvePageSize(requested, p.SystemsDefaultPageSize, DefaultPagination().SystemsDefaultPageSize, MaxSystemsPageSize)
+}
+
+// AuditPageSize resolves a requested audit events page size.
+func (p PaginationDefaults) AuditPageSize(requested int) int {
+	return resolvePageSize(requested, p.AuditDefaultPageSize, DefaultPagination().AuditDefaultPageSize, MaxAuditPageSize)
+}
+
+// resolvePageSize returns the configured default for requested sizes below 1
+// and clamps the result to maxSize.
+func resolvePageSize(requested, configured, builtin, maxSize int) int {
+	size := requested
+	if size < 1 {
+		size = configured
+	}
+	if size < 1 {
+		size = builtin
+	}
+	return min(size, maxSize)
+}
+
+// SyncConfig holds pull synchronization configuration.
+type SyncConfig struct {
+	ConflictStrategy   string // manual, keep_local or keep_remote; systems may override
+	MaxConcurrentPulls int    // Pull jobs allowed to be pending or running at once
+	PullParallelism    int    // Systems of one pull job pulled at once
+
+	ValidateRoles bool // Warn when a pulled control's responsible role is not a ServiceNow group
+
+	MaxRetries int           // Retries of a system whose pull failed
+	RetryDelay time.Duration // Delay before the first retry; doubles for each further retry
+
+	DiscoveryCacheTTL time.Duration // Age up to which cached discovery results stand in for a slow ServiceNow; 0 disables
+}
+
+// PushConfig holds push configuration.
+type PushConfig struct {
+	NonPushableTypes []string // Statement types never pushed to ServiceNow
+
+	BulkPushThreshold int // Jobs with more statements use one import set request; 0 disables
+
+	MaxRetries    int           // Retries of a statement update that failed with a ServiceNow server error
+	RetryDelay    time.Duration // Delay before the first retry; doubles for each further retry
+	RetryMaxDelay time.Duration // Upper bound of the retry delay
+}
+
+// WebhookConfig holds outbound webhook configuration.
+type WebhookConfig struct {
+	URL    string // Receives conflict notifications; empty disables webhooks
+	Secret string // HMAC-SHA256 key signing each webhook body
+}
+
+// SMTPConfig holds the mail server used for admin alerts.
+type SMTPConfig struct {
+	Host       string // Empty disables email alerts
+	Port       int
+	Username   string // Also used as the sender address; empty sends unauthenticated
+	Password   string
+	AlertEmail string // Receives push failure alerts
+}
+
+// AuthConfig holds JWT bearer token configuration.
+type AuthConfig struct {
+	JWTSecret string // HMAC key for HS256 tokens; empty rejects all tokens, disabling admin endpoints
 }
 
 // Load loads configuration from environment variables.
 func Load() (*Config, error) {
 	config := &Config{
+		Environment: getEnvString("APP_ENV", "development"),
 		Server: ServerConfig{
-			Port:         getEnvInt("SERVER_PORT", 8080),
-			ReadTimeout:  time.Duration(getEnvInt("SERVER_READ_TIMEOUT_SECONDS", 30)) * time.Second,
-			WriteTimeout: time.Duration(getEnvInt("SERVER_WRITE_TIMEOUT_SECONDS", 30)) * time.Second,
-			IdleTimeout:  time.Duration(getEnvInt("SERVER_IDLE_TIMEOUT_SECONDS", 60)) * time.Second,
+			Port:        getEnvInt("SERVER_PORT", 8080),
+			ReadTimeout: time.Duration(getEnvInt("SERVER_READ_TIMEOUT_SECONDS", 30)) * time.Second,
+			IdleTimeout: time.Duration(getEnvInt("SERVER_IDLE_TIMEOUT_SECONDS", 60)) * time.Second,
+
+			RateLimitRPS:   getEnvFloat("RATE_LIMIT_RPS", 10),
+			RateLimitBurst: getEnvInt("RATE_LIMIT_BURST", 20),
+
+			MaxRequestBodyBytes: int64(getEnvInt("SERVER_MAX_REQUEST_BODY_BYTES", 1<<20)),
+			MaxUploadBodyBytes:  int64(getEnvInt("SERVER_MAX_UPLOAD_BODY_BYTES", 11<<20)),
+
+			GRPCPort: getEnvInt("GRPC_PORT", 0),
+			GRPCHost: getEnvString("GRPC_HOST", "127.0.0.1"),
 		},
 		Database: DatabaseConfig{
 			Host:     getEnvString("DB_HOST", "localhost"),
@@ -62,14 +293,121 @@ func Load() (*Config, error) {
 			Password: getEnvString("DB_PASSWORD", ""),
 			Name:     getEnvString("DB_NAME", "autogrc"),
 			SSLMode:  getEnvString("DB_SSLMODE", "disable"),
+
+			MigrationsDir: getEnvString("DB_MIGRATIONS_DIR", ""),
+
+			URL: getEnvString("DATABASE_URL", ""),
+
+			MaxOpenConns:           getEnvInt("DB_MAX_OPEN_CONNS", 25),
+			MaxIdleConns:           getEnvInt("DB_MAX_IDLE_CONNS", 5),
+			ConnMaxLifetimeMinutes: getEnvInt("DB_CONN_MAX_LIFETIME_MINUTES", 5),
+
+			QueryTimeouts: QueryTimeoutsConfig{
+				SingleRow: time.Duration(getEnvInt("DB_SINGLE_ROW_TIMEOUT_SECONDS", 5)) * time.Second,
+				List:      time.Duration(getEnvInt("DB_LIST_TIMEOUT_SECONDS", 30)) * time.Second,
+				Batch:     time.Duration(getEnvInt("DB_BATCH_TIMEOUT_SECONDS", 60)) * time.Second,
+			},
 		},
 		Encryption: EncryptionConfig{
 			Key: getEnvString("ENCRYPTION_KEY", ""),
 		},
 		ServiceNow: ServiceNowConfig{
-			Timeout:    time.Duration(getEnvInt("SERVICENOW_TIMEOUT_SECONDS", 30)) * time.Second,
-			MaxRetries: getEnvInt("SERVICENOW_MAX_RETRIES", 3),
+			Timeout:          time.Duration(getEnvInt("SERVICENOW_TIMEOUT_SECONDS", 30)) * time.Second,
+			MaxRetries:       getEnvInt("SERVICENOW_MAX_RETRIES", 3),
+			TableMappingFile: getEnvString("SN_TABLE_MAPPING_FILE", ""),
+			ProxyURL:         getEnvString("SN_PROXY_URL", ""),
+
+			NTLMProxyURL:      getEnvString("SN_NTLM_PROXY_URL", ""),
+			NTLMProxyUsername: getEnvString("SN_NTLM_PROXY_USERNAME", ""),
+			NTLMProxyPassword: getEnvString("SN_NTLM_PROXY_PASSWORD", ""),
+			NTLMProxyDomain:   getEnvString("SN_NTLM_PROXY_DOMAIN", ""),
+
+			CacheTTL: time.Duration(getEnvInt("SN_CACHE_TTL_SECONDS", 60)) * time.Second,
+			UseGzip:  getEnvBool("SN_USE_GZIP", false),
+
+			StripHTMLOnPull: getEnvBool("SN_STRIP_HTML_ON_PULL", true),
+
+			OAuthTokenCacheDir: getEnvString("SN_OAUTH_TOKEN_CACHE_DIR", ""),
 		},
+		CORS: CORSConfig{
+			AllowedOrigins:   getEnvStringSlice("CORS_ALLOWED_ORIGINS", nil),
+			AllowedMethods:   getEnvStringSlice("CORS_ALLOWED_METHODS", []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}),
+			AllowedHeaders:   getEnvStringSlice("CORS_ALLOWED_HEADERS", []string{"Content-Type", "Authorization"}),
+			AllowCredentials: getEnvBool("CORS_ALLOW_CREDENTIALS", false),
+			MaxAge:           getEnvInt("CORS_MAX_AGE_SECONDS", 600),
+		},
+		Review: ReviewConfig{
+			Required: getEnvBool("REVIEW_REQUIRED", true),
+		},
+		Statements: StatementsConfig{
+			MinWords: getEnvInt("STATEMENT_MIN_WORDS", 0),
+			MaxChars: getEnvInt("STATEMENT_MAX_CHARS", 0),
+
+			ContentSanitizationMode: getEnvString("STATEMENT_SANITIZATION_MODE", "ugc"),
+			UnwrapOnSave:            getEnvBool("STATEMENT_UNWRAP_ON_SAVE", false),
+		},
+		Audit: AuditConfig{
+			RetentionDays:    getEnvInt("AUDIT_RETENTION_DAYS", 0),
+			RetentionMaxRows: getEnvInt("AUDIT_RETENTION_MAX_ROWS", 0),
+
+			SigningKeyPath: getEnvString("AUDIT_SIGNING_KEY_PATH", ""),
+		},
+		Sync: SyncConfig{
+			ConflictStrategy:   getEnvString("CONFLICT_STRATEGY", "manual"),
+			MaxConcurrentPulls: getEnvInt("PULL_MAX_CONCURRENT_JOBS", 1),
+			PullParallelism:    getEnvInt("PULL_PARALLELISM", 1),
+
+			ValidateRoles: getEnvBool("PULL_VALIDATE_ROLES", false),
+
+			MaxRetries: getEnvInt("PULL_MAX_RETRIES", 2),
+			RetryDelay: time.Duration(getEnvInt("PULL_RETRY_DELAY_SECONDS", 5)) * time.Second,
+
+			DiscoveryCacheTTL: time.Duration(getEnvInt("DISCOVERY_CACHE_TTL_SECONDS", 300)) * time.Second,
+		},
+		Timeouts: TimeoutsConfig{
+			DefaultWriteTimeout: time.Duration(getEnvInt("SERVER_WRITE_TIMEOUT_SECONDS", 30)) * time.Second,
+			PullWriteTimeout:    time.Duration(getEnvInt("PULL_WRITE_TIMEOUT_SECONDS", 120)) * time.Second,
+			PushWriteTimeout:    time.Duration(getEnvInt("PUSH_WRITE_TIMEOUT_SECONDS", 120)) * time.Second,
+		},
+		Auth: AuthConfig{
+			JWTSecret: getEnvString("AUTH_JWT_SECRET", ""),
+		},
+		Webhook: WebhookConfig{
+			URL:    getEnvString("WEBHOOK_URL", ""),
+			Secret: getEnvString("WEBHOOK_SECRET", ""),
+		},
+		SMTP: SMTPConfig{
+			Host:       getEnvString("SMTP_HOST", ""),
+			Port:       getEnvInt("SMTP_PORT", 587),
+			Username:   getEnvString("SMTP_USERNAME", ""),
+			Password:   getEnvString("SMTP_PASSWORD", ""),
+			AlertEmail: getEnvString("ALERT_EMAIL", ""),
+		},
+		Push: PushConfig{
+			NonPushableTypes: getEnvStringSlice("PUSH_NON_PUSHABLE_TYPES", []string{"evidence"}),
+
+			BulkPushThreshold: getEnvInt("PUSH_BULK_THRESHOLD", 10),
+
+			MaxRetries:    getEnvInt("PUSH_MAX_RETRIES", 3),
+			RetryDelay:    time.Duration(getEnvInt("PUSH_RETRY_DELAY_MS", 500)) * time.Millisecond,
+			RetryMaxDelay: time.Duration(getEnvInt("PUSH_RETRY_MAX_DELAY_MS", 10000)) * time.Millisecond,
+		},
+		Logging: LoadLogging(),
+		Pagination: PaginationDefaults{
+			StatementsDefaultPageSize: getEnvInt("STATEMENTS_DEFAULT_PAGE_SIZE", DefaultPagination().StatementsDefaultPageSize),
+			ControlsDefaultPageSize:   getEnvInt("CONTROLS_DEFAULT_PAGE_SIZE", DefaultPagination().ControlsDefaultPageSize),
+			SystemsDefaultPageSize:    getEnvInt("SYSTEMS_DEFAULT_PAGE_SIZE", DefaultPagination().SystemsDefaultPageSize),
+			AuditDefaultPageSize:      getEnvInt("AUDIT_DEFAULT_PAGE_SIZE", DefaultPagination().AuditDefaultPageSize),
+		},
+	}
+
+	// Values from an encrypted config file override the environment
+	if path := getEnvString("CONFIG_FILE_PATH", ""); path != "" {
+		values, err := LoadConfigFile(path, os.Getenv("CONFIG_MASTER_KEY"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load CONFIG_FILE_PATH: %w", err)
+		}
+		values.apply(config)
 	}
 
 	// Validate required configuration
@@ -82,17 +420,141 @@ func Load() (*Config, error) {
 
 // Validate validates the configuration.
 func (c *Config) Validate() error {
-	if c.Database.Password == "" {
-		return errors.New("DB_PASSWORD is required")
+	if c.Database.Password == "" && c.Database.URL == "" {
+		return errors.New("DB_PASSWORD or DATABASE_URL is required")
 	}
 	if c.Encryption.Key == "" {
 		return errors.New("ENCRYPTION_KEY is required")
 	}
+	if err := c.Logging.Validate(); err != nil {
+		return err
+	}
+	if c.Database.MaxOpenConns < 1 {
+		return errors.New("DB_MAX_OPEN_CONNS must be at least 1")
+	}
+	if c.Database.MaxIdleConns < 0 || c.Database.MaxIdleConns > c.Database.MaxOpenConns {
+		return errors.New("DB_MAX_IDLE_CONNS must be between 0 and DB_MAX_OPEN_CONNS")
+	}
+	if c.Database.ConnMaxLifetimeMinutes < 0 {
+		return errors.New("DB_CONN_MAX_LIFETIME_MINUTES must not be negative")
+	}
+	if t := c.Database.QueryTimeouts; t.SingleRow < 0 || t.List < 0 || t.Batch < 0 {
+		return errors.New("DB_SINGLE_ROW_TIMEOUT_SECONDS, DB_LIST_TIMEOUT_SECONDS and DB_BATCH_TIMEOUT_SECONDS must not be negative")
+	}
+	if c.Push.BulkPushThreshold < 0 {
+		return errors.New("PUSH_BULK_THRESHOLD must not be negative")
+	}
+	if c.Server.MaxRequestBodyBytes < 1 {
+		return errors.New("SERVER_MAX_REQUEST_BODY_BYTES must be at least 1")
+	}
+	if c.Server.MaxUploadBodyBytes < c.Server.MaxRequestBodyBytes {
+		return errors.New("SERVER_MAX_UPLOAD_BODY_BYTES must be at least SERVER_MAX_REQUEST_BODY_BYTES")
+	}
+	if c.Server.GRPCPort < 0 || c.Server.GRPCPort > 65535 {
+		return errors.New("GRPC_PORT must be between 0 and 65535")
+	}
+	if c.Server.GRPCPort != 0 && c.Server.GRPCPort == c.Server.Port {
+		return errors.New("GRPC_PORT must differ from SERVER_PORT")
+	}
+	if c.Server.RateLimitRPS > 0 && c.Server.RateLimitBurst < 1 {
+		return errors.New("RATE_LIMIT_BURST must be at least 1 when rate limiting is enabled")
+	}
+	if c.Audit.RetentionDays < 0 || c.Audit.RetentionMaxRows < 0 {
+		return errors.New("AUDIT_RETENTION_DAYS and AUDIT_RETENTION_MAX_ROWS must not be negative")
+	}
+	switch c.Statements.ContentSanitizationMode {
+	case "strict", "ugc", "none":
+	default:
+		return errors.New("STATEMENT_SANITIZATION_MODE must be one of strict, ugc or none")
+	}
+	switch c.Sync.ConflictStrategy {
+	case "manual", "keep_local", "keep_remote":
+	default:
+		return errors.New("CONFLICT_STRATEGY must be one of manual, keep_local or keep_remote")
+	}
+	if c.ServiceNow.ProxyURL != "" {
+		if u, err := url.Parse(c.ServiceNow.ProxyURL); err != nil || u.Host == "" {
+			return errors.New("SN_PROXY_URL must be an absolute URL such as http://proxy:3128")
+		}
+	}
+	if c.ServiceNow.NTLMProxyURL != "" {
+		if u, err := url.Parse(c.ServiceNow.NTLMProxyURL); err != nil || u.Scheme != "http" || u.Host == "" {
+			return errors.New("SN_NTLM_PROXY_URL must be an absolute http URL such as http://proxy:8080")
+		}
+		if c.ServiceNow.NTLMProxyUsername == "" {
+			return errors.New("SN_NTLM_PROXY_USERNAME is required when SN_NTLM_PROXY_URL is set")
+		}
+	}
+	if c.Webhook.URL != "" {
+		if u, err := url.Parse(c.Webhook.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return errors.New("WEBHOOK_URL must be an absolute http or https URL")
+		}
+		if c.Webhook.Secret == "" {
+			return errors.New("WEBHOOK_SECRET is required when WEBHOOK_URL is set")
+		}
+	}
+	if c.SMTP.Host != "" {
+		if c.SMTP.Port < 1 || c.SMTP.Port > 65535 {
+			return errors.New("SMTP_PORT must be between 1 and 65535")
+		}
+		if c.SMTP.AlertEmail == "" {
+			return errors.New("ALERT_EMAIL is required when SMTP_HOST is set")
+		}
+	}
+	if c.ServiceNow.CacheTTL < 0 {
+		return errors.New("SN_CACHE_TTL_SECONDS must not be negative")
+	}
+	if c.Timeouts.DefaultWriteTimeout <= 0 || c.Timeouts.PullWriteTimeout <= 0 || c.Timeouts.PushWriteTimeout <= 0 {
+		return errors.New("SERVER_WRITE_TIMEOUT_SECONDS, PULL_WRITE_TIMEOUT_SECONDS and PUSH_WRITE_TIMEOUT_SECONDS must be positive")
+	}
+	if c.Sync.MaxRetries < 0 || c.Sync.RetryDelay < 0 {
+		return errors.New("PULL_MAX_RETRIES and PULL_RETRY_DELAY_SECONDS must not be negative")
+	}
+	if c.Push.MaxRetries < 0 || c.Push.RetryDelay < 0 || c.Push.RetryMaxDelay < 0 {
+		return errors.New("PUSH_MAX_RETRIES, PUSH_RETRY_DELAY_MS and PUSH_RETRY_MAX_DELAY_MS must not be negative")
+	}
+	if c.Sync.MaxConcurrentPulls < 1 {
+		return errors.New("PULL_MAX_CONCURRENT_JOBS must be at least 1")
+	}
+	if err := c.Pagination.Validate(); err != nil {
+		return err
+	}
+	if c.CORS.AllowCredentials && c.CORS.AllowsAnyOrigin() {
+		return errors.New("CORS_ALLOWED_ORIGINS must list explicit origins when CORS_ALLOW_CREDENTIALS is enabled")
+	}
+	return nil
+}
+
+// Validate checks that each default page size is between 1 and the
+// maximum for its entity type.
+func (p PaginationDefaults) Validate() error {
+	checks := []struct {
+		name          string
+		value, maxVal int
+	}{
+		{"STATEMENTS_DEFAULT_PAGE_SIZE", p.StatementsDefaultPageSize, MaxStatementsPageSize},
+		{"CONTROLS_DEFAULT_PAGE_SIZE", p.ControlsDefaultPageSize, MaxControlsPageSize},
+		{"SYSTEMS_DEFAULT_PAGE_SIZE", p.SystemsDefaultPageSize, MaxSystemsPageSize},
+		{"AUDIT_DEFAULT_PAGE_SIZE", p.AuditDefaultPageSize, MaxAuditPageSize},
+	}
+	for _, check := range checks {
+		if check.value < 1 || check.value > check.maxVal {
+			return fmt.Errorf("%s must be between 1 and %d", check.name, check.maxVal)
+		}
+	}
 	return nil
 }
 
+// IsProduction returns true when running in the production environment.
+func (c *Config) IsProduction() bool {
+	return strings.EqualFold(c.Environment, "production")
+}
+
 // DSN returns the PostgreSQL connection string.
 func (c *DatabaseConfig) DSN() string {
+	if c.URL != "" {
+		return c.URL
+	}
 	return fmt.Sprintf(
 		"host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
 		c.Host, c.Port, c.User, c.Password, c.Name, c.SSLMode,
@@ -116,3 +578,39 @@ func getEnvInt(key string, defaultValue int) int {
 	}
 	return defaultValue
 }
+
+// getEnvFloat gets a float environment variable or returns a default.
+func getEnvFloat(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatValue, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatValue
+		}
+	}
+	return defaultValue
+}
+
+// getEnvBool gets a boolean environment variable or returns a default.
+func getEnvBool(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if boolValue, err := strconv.ParseBool(value); err == nil {
+			return boolValue
+		}
+	}
+	return defaultValue
+}
+
+// getEnvStringSlice gets a comma-separated environment variable or returns a default.
+func getEnvStringSlice(key string, defaultValue []string) []string {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	var values []string
+	for _, part := range strings.Split(value, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			values = append(values, part)
+		}
+	}
+	return values
+}
@@ -0,0 +1,56 @@
+package config
+
+import "testing"
+
+func TestPaginationDefaults(t *testing.T) {
+	p := PaginationDefaults{
+		StatementsDefaultPageSize: 30,
+		ControlsDefaultPageSize:   15,
+		SystemsDefaultPageSize:    25,
+		AuditDefaultPageSize:      75,
+	}
+
+	tests := []struct {
+		name      string
+		resolve   func(int) int
+		requested int
+		want      int
+	}{
+		{"statements default", p.StatementsPageSize, 0, 30},
+		{"statements requested", p.StatementsPageSize, 150, 150},
+		{"statements clamped", p.StatementsPageSize, 1000, MaxStatementsPageSize},
+		{"controls default", p.ControlsPageSize, 0, 15},
+		{"controls clamped", p.ControlsPageSize, 101, MaxControlsPageSize},
+		{"systems default", p.SystemsPageSize, -1, 25},
+		{"systems clamped", p.SystemsPageSize, 1000, MaxSystemsPageSize},
+		{"audit default", p.AuditPageSize, 0, 75},
+		{"audit requested", p.AuditPageSize, 400, 400},
+		{"audit clamped", p.AuditPageSize, 1000, MaxAuditPageSize},
+		{"unset default", PaginationDefaults{}.AuditPageSize, 0, DefaultPagination().AuditDefaultPageSize},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.resolve(tt.requested); got != tt.want {
+				t.Errorf("expected page size %d, got %d", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestPaginationDefaults_Validate(t *testing.T) {
+	if err := DefaultPagination().Validate(); err != nil {
+		t.Fatalf("expected built-in defaults to be valid, got %v", err)
+	}
+
+	tooLarge := DefaultPagination()
+	tooLarge.ControlsDefaultPageSize = MaxControlsPageSize + 1
+	if err := tooLarge.Validate(); err == nil {
+		t.Error("expected default above the maximum to be rejected")
+	}
+
+	zero := DefaultPagination()
+	zero.AuditDefaultPageSize = 0
+	if err := zero.Validate(); err == nil {
+		t.Error("expected zero default to be rejected")
+	}
+}
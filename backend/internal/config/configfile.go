@@ -0,0 +1,166 @@
+package config
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// Encrypted config file key derivation parameters.
+const (
+	configFileKDF        = "pbkdf2-sha256"
+	configFileIterations = 600000
+	configFileSaltSize   = 16
+)
+
+// ErrConfigFileDecrypt is returned when an encrypted config file cannot be
+// decrypted, usually because CONFIG_MASTER_KEY is wrong.
+var ErrConfigFileDecrypt = errors.New("failed to decrypt config file")
+
+// FileValues are the settings that may be loaded from an encrypted config
+// file instead of plaintext environment variables.
+type FileValues struct {
+	DatabaseDSN   string `json:"database_dsn,omitempty"`
+	EncryptionKey string `json:"encryption_key,omitempty"`
+	ServerPort    int    `json:"server_port,omitempty"`
+}
+
+// encryptedConfigFile is the on-disk format of an encrypted config file.
+// Each value is the base64 encoding of an AES-256-GCM nonce followed by the
+// ciphertext of the value's JSON encoding. The AES key is derived from the
+// master key with PBKDF2-HMAC-SHA256.
+type encryptedConfigFile struct {
+	KDF        string            `json:"kdf"`
+	Iterations int               `json:"iterations"`
+	Salt       string            `json:"salt"`
+	Values     map[string]string `json:"values"`
+}
+
+// EncryptConfigFile encrypts every value of a plain JSON object config with
+// a key derived from masterKey and returns the encrypted file contents.
+func EncryptConfigFile(plain []byte, masterKey string) ([]byte, error) {
+	if masterKey == "" {
+		return nil, errors.New("master key is required")
+	}
+
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal(plain, &values); err != nil {
+		return nil, fmt.Errorf("config must be a JSON object: %w", err)
+	}
+
+	salt := make([]byte, configFileSaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+	gcm, err := configFileCipher(masterKey, salt, configFileIterations)
+	if err != nil {
+		return nil, err
+	}
+
+	file := encryptedConfigFile{
+		KDF:        configFileKDF,
+		Iterations: configFileIterations,
+		Salt:       base64.StdEncoding.EncodeToString(salt),
+		Values:     make(map[string]string, len(values)),
+	}
+	for name, value := range values {
+		nonce := make([]byte, gcm.NonceSize())
+		if _, err := rand.Read(nonce); err != nil {
+			return nil, fmt.Errorf("failed to generate nonce: %w", err)
+		}
+		// Bind each ciphertext to its key so values cannot be swapped
+		sealed := gcm.Seal(nonce, nonce, value, []byte(name))
+		file.Values[name] = base64.StdEncoding.EncodeToString(sealed)
+	}
+
+	return json.MarshalIndent(file, "", "  ")
+}
+
+// DecryptConfigFile decrypts an encrypted config file and returns the plain
+// JSON object config.
+func DecryptConfigFile(data []byte, masterKey string) ([]byte, error) {
+	if masterKey == "" {
+		return nil, errors.New("master key is required")
+	}
+
+	var file encryptedConfigFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("invalid encrypted config file: %w", err)
+	}
+	if file.KDF != configFileKDF || file.Iterations < 1 {
+		return nil, fmt.Errorf("unsupported config file key derivation %q", file.KDF)
+	}
+	salt, err := base64.StdEncoding.DecodeString(file.Salt)
+	if err != nil {
+		return nil, fmt.Errorf("invalid config file salt: %w", err)
+	}
+	gcm, err := configFileCipher(masterKey, salt, file.Iterations)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]json.RawMessage, len(file.Values))
+	for name, encoded := range file.Values {
+		sealed, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil || len(sealed) < gcm.NonceSize() {
+			return nil, fmt.Errorf("%w: malformed value %q", ErrConfigFileDecrypt, name)
+		}
+		nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+		value, err := gcm.Open(nil, nonce, ciphertext, []byte(name))
+		if err != nil {
+			return nil, fmt.Errorf("%w: value %q", ErrConfigFileDecrypt, name)
+		}
+		values[name] = value
+	}
+
+	return json.Marshal(values)
+}
+
+// LoadConfigFile reads and decrypts the encrypted config file at path.
+func LoadConfigFile(path, masterKey string) (*FileValues, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	plain, err := DecryptConfigFile(data, masterKey)
+	if err != nil {
+		return nil, err
+	}
+
+	var values FileValues
+	if err := json.Unmarshal(plain, &values); err != nil {
+		return nil, fmt.Errorf("invalid config file values: %w", err)
+	}
+	return &values, nil
+}
+
+// apply overrides the configuration with the values set in the file.
+func (v *FileValues) apply(c *Config) {
+	if v.DatabaseDSN != "" {
+		c.Database.URL = v.DatabaseDSN
+	}
+	if v.EncryptionKey != "" {
+		c.Encryption.Key = v.EncryptionKey
+	}
+	if v.ServerPort != 0 {
+		c.Server.Port = v.ServerPort
+	}
+}
+
+// configFileCipher derives the AES-256-GCM cipher for a config file.
+func configFileCipher(masterKey string, salt []byte, iterations int) (cipher.AEAD, error) {
+	key := pbkdf2.Key([]byte(masterKey), salt, iterations, 32, sha256.New)
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,76 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestConfigFile_RoundTrip(t *testing.T) {
+	plain := []byte(`{"database_dsn":"postgres://app:secret@db/app","encryption_key":"k3y","server_port":9090}`)
+
+	encrypted, err := EncryptConfigFile(plain, "master")
+	if err != nil {
+		t.Fatalf("EncryptConfigFile failed: %v", err)
+	}
+	if strings.Contains(string(encrypted), "secret") || strings.Contains(string(encrypted), "k3y") {
+		t.Fatalf("encrypted file contains plaintext: %s", encrypted)
+	}
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, encrypted, 0o600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	values, err := LoadConfigFile(path, "master")
+	if err != nil {
+		t.Fatalf("LoadConfigFile failed: %v", err)
+	}
+	if values.DatabaseDSN != "postgres://app:secret@db/app" {
+		t.Errorf("DatabaseDSN = %q", values.DatabaseDSN)
+	}
+	if values.EncryptionKey != "k3y" {
+		t.Errorf("EncryptionKey = %q", values.EncryptionKey)
+	}
+	if values.ServerPort != 9090 {
+		t.Errorf("ServerPort = %d", values.ServerPort)
+	}
+
+	if _, err := LoadConfigFile(path, "wrong"); !errors.Is(err, ErrConfigFileDecrypt) {
+		t.Errorf("expected ErrConfigFileDecrypt with wrong master key, got %v", err)
+	}
+}
+
+func TestLoad_ConfigFile(t *testing.T) {
+	encrypted, err := EncryptConfigFile([]byte(`{"database_dsn":"postgres://db/app","encryption_key":"from-file"}`), "master")
+	if err != nil {
+		t.Fatalf("EncryptConfigFile failed: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, encrypted, 0o600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	t.Setenv("DB_PASSWORD", "")
+	t.Setenv("ENCRYPTION_KEY", "from-env")
+	t.Setenv("CONFIG_FILE_PATH", path)
+	t.Setenv("CONFIG_MASTER_KEY", "master")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.Database.DSN() != "postgres://db/app" {
+		t.Errorf("DSN = %q", cfg.Database.DSN())
+	}
+	if cfg.Encryption.Key != "from-file" {
+		t.Errorf("Encryption.Key = %q", cfg.Encryption.Key)
+	}
+
+	t.Setenv("CONFIG_MASTER_KEY", "")
+	if _, err := Load(); err == nil {
+		t.Error("expected error without CONFIG_MASTER_KEY")
+	}
+}
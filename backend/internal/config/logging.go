@@ -0,0 +1,57 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+)
+
+// LoggingConfig holds structured logging configuration.
+type LoggingConfig struct {
+	Level  string // debug, info, warn or error
+	Format string // text or json
+}
+
+// LoadLogging reads the logging configuration from LOG_LEVEL and LOG_FORMAT.
+// It is separate from Load so the logger can be set up before the rest of
+// the configuration is read.
+func LoadLogging() LoggingConfig {
+	return LoggingConfig{
+		Level:  strings.ToLower(getEnvString("LOG_LEVEL", "info")),
+		Format: strings.ToLower(getEnvString("LOG_FORMAT", "text")),
+	}
+}
+
+// logLevels maps LOG_LEVEL values to slog levels.
+var logLevels = map[string]slog.Level{
+	"debug": slog.LevelDebug,
+	"info":  slog.LevelInfo,
+	"warn":  slog.LevelWarn,
+	"error": slog.LevelError,
+}
+
+// Validate checks that the level and format are known.
+func (c LoggingConfig) Validate() error {
+	if _, ok := logLevels[c.Level]; !ok {
+		return fmt.Errorf("LOG_LEVEL must be one of debug, info, warn or error, got %q", c.Level)
+	}
+	if c.Format != "text" && c.Format != "json" {
+		return fmt.Errorf("LOG_FORMAT must be text or json, got %q", c.Format)
+	}
+	return nil
+}
+
+// NewLogger creates a logger writing to w at the configured level and
+// format. Unknown values fall back to info and text.
+func (c LoggingConfig) NewLogger(w io.Writer) *slog.Logger {
+	level, ok := logLevels[c.Level]
+	if !ok {
+		level = slog.LevelInfo
+	}
+	opts := &slog.HandlerOptions{Level: level}
+	if c.Format == "json" {
+		return slog.New(slog.NewJSONHandler(w, opts))
+	}
+	return slog.New(slog.NewTextHandler(w, opts))
+}
@@ -0,0 +1,54 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestLoggingConfig_NewLogger(t *testing.T) {
+	tests := []struct {
+		name      string
+		config    LoggingConfig
+		level     slog.Level
+		wantEntry bool
+		wantJSON  bool
+	}{
+		{"debug enabled", LoggingConfig{Level: "debug", Format: "text"}, slog.LevelDebug, true, false},
+		{"debug filtered at info", LoggingConfig{Level: "info", Format: "text"}, slog.LevelDebug, false, false},
+		{"warn passes warn", LoggingConfig{Level: "warn", Format: "json"}, slog.LevelWarn, true, true},
+		{"info filtered at error", LoggingConfig{Level: "error", Format: "json"}, slog.LevelInfo, false, true},
+		{"unknown falls back to info text", LoggingConfig{Level: "verbose", Format: "xml"}, slog.LevelInfo, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			tt.config.NewLogger(&buf).Log(context.Background(), tt.level, "hello")
+
+			out := buf.String()
+			if got := out != ""; got != tt.wantEntry {
+				t.Fatalf("expected entry %v, got %q", tt.wantEntry, out)
+			}
+			if tt.wantEntry && strings.HasPrefix(out, "{") != tt.wantJSON {
+				t.Errorf("expected JSON %v, got %q", tt.wantJSON, out)
+			}
+		})
+	}
+}
+
+func TestLoggingConfig_Validate(t *testing.T) {
+	t.Setenv("LOG_LEVEL", "DEBUG")
+	t.Setenv("LOG_FORMAT", "JSON")
+	if err := LoadLogging().Validate(); err != nil {
+		t.Errorf("expected upper-case values to be accepted, got %v", err)
+	}
+
+	for _, c := range []LoggingConfig{{Level: "trace", Format: "text"}, {Level: "info", Format: "logfmt"}} {
+		if err := c.Validate(); err == nil {
+			t.Errorf("expected %+v to be rejected", c)
+		}
+	}
+}
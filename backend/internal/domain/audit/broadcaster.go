@@ -0,0 +1,48 @@
+package audit
+
+import "sync"
+
+// subscriberBuffer is the number of events queued for a slow subscriber
+// before further events are dropped for it.
+const subscriberBuffer = 64
+
+// EventBroadcaster fans recorded audit events out to live subscribers.
+type EventBroadcaster struct {
+	subscribers sync.Map // chan Event -> struct{}
+}
+
+// NewEventBroadcaster creates a broadcaster with no subscribers.
+func NewEventBroadcaster() *EventBroadcaster {
+	return &EventBroadcaster{}
+}
+
+// Subscribe registers a new subscriber and returns its event channel and a
+// function that unsubscribes it. The channel is never closed; callers stop
+// reading once they unsubscribe.
+func (b *EventBroadcaster) Subscribe() (<-chan Event, func()) {
+	ch := make(chan Event, subscriberBuffer)
+	b.subscribers.Store(ch, struct{}{})
+	return ch, func() { b.subscribers.Delete(ch) }
+}
+
+// Broadcast sends an event to every subscriber without blocking. A
+// subscriber whose buffer is full misses the event.
+func (b *EventBroadcaster) Broadcast(e Event) {
+	b.subscribers.Range(func(key, _ any) bool {
+		select {
+		case key.(chan Event) <- e:
+		default:
+		}
+		return true
+	})
+}
+
+// Subscribers returns the number of active subscribers.
+func (b *EventBroadcaster) Subscribers() int {
+	n := 0
+	b.subscribers.Range(func(_, _ any) bool {
+		n++
+		return true
+	})
+	return n
+}
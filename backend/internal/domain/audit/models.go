@@ -1,6 +1,7 @@
 package audit
 
 import (
+	"crypto/ecdsa"
 	"time"
 
 	"github.com/google/uuid"
@@ -19,6 +20,9 @@ const (
 	EventTypeConnectionConfig EventType = "connection_config"
 	EventTypeSystemImport     EventType = "system_import"
 	EventTypeSystemDelete     EventType = "system_delete"
+	EventTypeStatementDelete  EventType = "statement_delete"
+	EventTypeAPIMutation      EventType = "api_mutation"
+	EventTypeFieldChange      EventType = "field_change"
 )
 
 // Event represents an audit log entry.
@@ -35,6 +39,42 @@ type Event struct {
 	CreatedAt  time.Time              `json:"created_at"`
 }
 
+// FieldChangeDetails returns the Details of a field_change event recording
+// that field went from oldValue to newValue.
+func FieldChangeDetails(field, oldValue, newValue string) map[string]interface{} {
+	return map[string]interface{}{
+		"field":     field,
+		"old_value": oldValue,
+		"new_value": newValue,
+	}
+}
+
+// RetentionPolicy controls how long audit events are kept.
+// A zero value for either limit disables that limit.
+type RetentionPolicy struct {
+	MaxAgeDays int // Delete events older than this many days
+	MaxRows    int // Delete the oldest events beyond this many rows
+}
+
+// Enabled returns true if the policy limits event age or count.
+func (p RetentionPolicy) Enabled() bool {
+	return p.MaxAgeDays > 0 || p.MaxRows > 0
+}
+
+// Options configures optional audit service behaviour.
+type Options struct {
+	// Retention is applied by Purge and the nightly retention job.
+	Retention RetentionPolicy
+
+	// Broadcaster receives every recorded event for live streaming.
+	// NewService creates one when nil.
+	Broadcaster *EventBroadcaster
+
+	// SigningKey signs the hash of signed exports. Exports carry only the
+	// hash when nil.
+	SigningKey *ecdsa.PrivateKey
+}
+
 // QueryFilters holds parameters for filtering audit events.
 type QueryFilters struct {
 	EventTypes  []EventType `json:"event_types,omitempty"`
@@ -19,4 +19,7 @@ type Repository interface {
 
 	// GetStats retrieves audit statistics.
 	GetStats(ctx context.Context) (*Stats, error)
+
+	// Purge deletes events outside the retention policy and returns how many were deleted.
+	Purge(ctx context.Context, policy RetentionPolicy) (int64, error)
 }
@@ -6,6 +6,7 @@ import (
 	"encoding/csv"
 	"encoding/json"
 	"fmt"
+	"io"
 	"log/slog"
 	"time"
 
@@ -15,13 +16,21 @@ import (
 // Service provides business logic for audit operations.
 type Service struct {
 	repo   Repository
+	opts   Options
 	logger *slog.Logger
 }
 
 // NewService creates a new audit service.
-func NewService(repo Repository, logger *slog.Logger) *Service {
+func NewService(repo Repository, opts Options, logger *slog.Logger) *Service {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	if opts.Broadcaster == nil {
+		opts.Broadcaster = NewEventBroadcaster()
+	}
 	return &Service{
 		repo:   repo,
+		opts:   opts,
 		logger: logger,
 	}
 }
@@ -45,9 +54,16 @@ func (s *Service) Record(ctx context.Context, event Event) error {
 		"entity_type", event.EntityType,
 		"action", event.Action)
 
+	s.opts.Broadcaster.Broadcast(event)
 	return nil
 }
 
+// Subscribe registers a live subscriber for newly recorded events. The
+// returned function must be called to unsubscribe.
+func (s *Service) Subscribe() (<-chan Event, func()) {
+	return s.opts.Broadcaster.Subscribe()
+}
+
 // RecordAsync records an audit event without blocking.
 // Errors are logged but not returned.
 func (s *Service) RecordAsync(event Event) {
@@ -71,8 +87,8 @@ func (s *Service) Query(ctx context.Context, filters QueryFilters) (*QueryResult
 	if filters.PageSize <= 0 {
 		filters.PageSize = 50
 	}
-	if filters.PageSize > 100 {
-		filters.PageSize = 100
+	if filters.PageSize > 500 {
+		filters.PageSize = 500
 	}
 	if filters.Page < 1 {
 		filters.Page = 1
@@ -98,7 +114,15 @@ func (s *Service) ExportCSV(ctx context.Context, filters QueryFilters) ([]byte,
 	}
 
 	var buf bytes.Buffer
-	writer := csv.NewWriter(&buf)
+	if err := writeEventsCSV(&buf, result.Events); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// writeEventsCSV writes a header row and one row per event to w.
+func writeEventsCSV(w io.Writer, events []Event) error {
+	writer := csv.NewWriter(w)
 
 	// Header
 	header := []string{
@@ -106,11 +130,11 @@ func (s *Service) ExportCSV(ctx context.Context, filters QueryFilters) ([]byte,
 		"Action", "Status", "User Email", "Details",
 	}
 	if err := writer.Write(header); err != nil {
-		return nil, fmt.Errorf("failed to write header: %w", err)
+		return fmt.Errorf("failed to write header: %w", err)
 	}
 
 	// Data rows
-	for _, event := range result.Events {
+	for _, event := range events {
 		detailsJSON := ""
 		if len(event.Details) > 0 {
 			b, _ := json.Marshal(event.Details)
@@ -129,16 +153,65 @@ func (s *Service) ExportCSV(ctx context.Context, filters QueryFilters) ([]byte,
 			detailsJSON,
 		}
 		if err := writer.Write(row); err != nil {
-			return nil, fmt.Errorf("failed to write row: %w", err)
+			return fmt.Errorf("failed to write row: %w", err)
 		}
 	}
 
 	writer.Flush()
 	if err := writer.Error(); err != nil {
-		return nil, fmt.Errorf("csv write error: %w", err)
+		return fmt.Errorf("csv write error: %w", err)
 	}
+	return nil
+}
 
-	return buf.Bytes(), nil
+// Purge deletes audit events outside the configured retention policy and
+// returns how many were deleted. It is a no-op when no policy is configured.
+func (s *Service) Purge(ctx context.Context) (int64, error) {
+	if !s.opts.Retention.Enabled() {
+		return 0, nil
+	}
+
+	deleted, err := s.repo.Purge(ctx, s.opts.Retention)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge audit events: %w", err)
+	}
+
+	s.logger.Info("audit events purged",
+		"deleted", deleted,
+		"max_age_days", s.opts.Retention.MaxAgeDays,
+		"max_rows", s.opts.Retention.MaxRows)
+
+	return deleted, nil
+}
+
+// RunRetention purges audit events every night at midnight until ctx is
+// cancelled. It returns immediately when no retention policy is configured.
+func (s *Service) RunRetention(ctx context.Context) {
+	if !s.opts.Retention.Enabled() {
+		return
+	}
+
+	for {
+		timer := time.NewTimer(time.Until(nextMidnight(time.Now())))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		purgeCtx, cancel := context.WithTimeout(ctx, 10*time.Minute)
+		if _, err := s.Purge(purgeCtx); err != nil {
+			s.logger.Error("scheduled audit purge failed", "error", err)
+		}
+		cancel()
+	}
+}
+
+// nextMidnight returns the start of the day after t in t's location.
+func nextMidnight(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day+1, 0, 0, 0, 0, t.Location())
 }
 
 func safeString(s *string) string {
@@ -0,0 +1,143 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// mockRepository implements the Repository methods used by Purge.
+type mockRepository struct {
+	Repository
+	purged   []RetentionPolicy
+	deleted  int64
+	purgeErr error
+
+	insertErr error
+}
+
+func (m *mockRepository) Insert(ctx context.Context, event *Event) error {
+	return m.insertErr
+}
+
+func (m *mockRepository) Purge(ctx context.Context, policy RetentionPolicy) (int64, error) {
+	m.purged = append(m.purged, policy)
+	return m.deleted, m.purgeErr
+}
+
+func TestService_Purge(t *testing.T) {
+	repo := &mockRepository{deleted: 42}
+	policy := RetentionPolicy{MaxAgeDays: 90, MaxRows: 1000}
+	svc := NewService(repo, Options{Retention: policy}, nil)
+
+	deleted, err := svc.Purge(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if deleted != 42 {
+		t.Errorf("expected 42 deleted, got %d", deleted)
+	}
+	if len(repo.purged) != 1 || repo.purged[0] != policy {
+		t.Errorf("expected repository purge with %+v, got %+v", policy, repo.purged)
+	}
+}
+
+func TestService_Purge_NoPolicy(t *testing.T) {
+	repo := &mockRepository{deleted: 42}
+	svc := NewService(repo, Options{}, nil)
+
+	deleted, err := svc.Purge(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if deleted != 0 {
+		t.Errorf("expected 0 deleted, got %d", deleted)
+	}
+	if len(repo.purged) != 0 {
+		t.Error("expected repository not to be called without a retention policy")
+	}
+}
+
+func TestService_Purge_RepositoryError(t *testing.T) {
+	repoErr := errors.New("connection reset")
+	repo := &mockRepository{purgeErr: repoErr}
+	svc := NewService(repo, Options{Retention: RetentionPolicy{MaxRows: 10}}, nil)
+
+	if _, err := svc.Purge(context.Background()); !errors.Is(err, repoErr) {
+		t.Errorf("expected wrapped repository error, got %v", err)
+	}
+}
+
+func TestService_RunRetention_StopsOnCancel(t *testing.T) {
+	svc := NewService(&mockRepository{}, Options{Retention: RetentionPolicy{MaxAgeDays: 30}}, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan struct{})
+	go func() {
+		svc.RunRetention(ctx)
+		close(done)
+	}()
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expected RunRetention to return after cancel")
+	}
+}
+
+func TestNextMidnight(t *testing.T) {
+	loc := time.FixedZone("test", 2*60*60)
+	tests := []struct {
+		now  time.Time
+		want time.Time
+	}{
+		{time.Date(2024, 3, 10, 15, 30, 0, 0, loc), time.Date(2024, 3, 11, 0, 0, 0, 0, loc)},
+		{time.Date(2024, 3, 10, 0, 0, 0, 0, loc), time.Date(2024, 3, 11, 0, 0, 0, 0, loc)},
+		{time.Date(2024, 12, 31, 23, 59, 0, 0, loc), time.Date(2025, 1, 1, 0, 0, 0, 0, loc)},
+	}
+
+	for _, tt := range tests {
+		if got := nextMidnight(tt.now); !got.Equal(tt.want) {
+			t.Errorf("nextMidnight(%s) = %s, want %s", tt.now, got, tt.want)
+		}
+	}
+}
+
+func TestService_Record_Broadcasts(t *testing.T) {
+	repo := &mockRepository{}
+	svc := NewService(repo, Options{}, nil)
+
+	events, unsubscribe := svc.Subscribe()
+	if err := svc.Record(context.Background(), Event{EventType: EventTypeEdit, EntityID: "s-1"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	select {
+	case e := <-events:
+		if e.EventType != EventTypeEdit || e.EntityID != "s-1" || e.ID.String() == "" {
+			t.Errorf("unexpected event: %+v", e)
+		}
+	default:
+		t.Fatal("expected event to be broadcast")
+	}
+
+	// Failed inserts are not broadcast
+	repo.insertErr = errors.New("connection reset")
+	svc.Record(context.Background(), Event{EventType: EventTypeEdit})
+	select {
+	case e := <-events:
+		t.Errorf("unexpected event after failed insert: %+v", e)
+	default:
+	}
+
+	unsubscribe()
+	repo.insertErr = nil
+	svc.Record(context.Background(), Event{EventType: EventTypeEdit})
+	select {
+	case e := <-events:
+		t.Errorf("unexpected event after unsubscribe: %+v", e)
+	default:
+	}
+}
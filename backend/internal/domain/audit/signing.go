@@ -0,0 +1,184 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Trailer rows appended to a signed export. The HASH row holds the SHA-256
+// of every byte before it; the optional SIGNATURE row holds an ASN.1 ECDSA
+// signature of that same digest.
+const (
+	hashRowPrefix      = `"HASH","sha256:`
+	signatureRowPrefix = `"SIGNATURE","`
+)
+
+// Signed export verification errors.
+var (
+	ErrHashRowMissing    = errors.New("export has no HASH row")
+	ErrHashMismatch      = errors.New("export content does not match its hash")
+	ErrSignatureMissing  = errors.New("export has no SIGNATURE row")
+	ErrSignatureMismatch = errors.New("export signature is not valid")
+)
+
+// ExportSignedCSV streams audit events to w as CSV followed by a HASH row
+// and, when a signing key is configured, a SIGNATURE row. Nothing is written
+// if the events cannot be queried.
+func (s *Service) ExportSignedCSV(ctx context.Context, filters QueryFilters, w io.Writer) error {
+	filters.Page = 1
+	filters.PageSize = 10000 // Max export limit
+
+	result, err := s.repo.Query(ctx, filters)
+	if err != nil {
+		return fmt.Errorf("failed to query events: %w", err)
+	}
+
+	hash := sha256.New()
+	if err := writeEventsCSV(io.MultiWriter(w, hash), result.Events); err != nil {
+		return err
+	}
+	digest := hash.Sum(nil)
+	if _, err := fmt.Fprintf(w, "%s%x\"\n", hashRowPrefix, digest); err != nil {
+		return fmt.Errorf("failed to write hash: %w", err)
+	}
+
+	if s.opts.SigningKey == nil {
+		return nil
+	}
+	signature, err := ecdsa.SignASN1(rand.Reader, s.opts.SigningKey, digest)
+	if err != nil {
+		return fmt.Errorf("failed to sign export: %w", err)
+	}
+	if _, err := fmt.Fprintf(w, "%s%s\"\n", signatureRowPrefix, base64.StdEncoding.EncodeToString(signature)); err != nil {
+		return fmt.Errorf("failed to write signature: %w", err)
+	}
+	return nil
+}
+
+// VerifySignedCSV checks the HASH row of a signed export against the content
+// before it. When key is not nil the SIGNATURE row must also be present and
+// valid for key; otherwise any SIGNATURE row is ignored.
+func VerifySignedCSV(data []byte, key *ecdsa.PublicKey) error {
+	rest, last := cutLastLine(data)
+
+	var signature []byte
+	if strings.HasPrefix(last, signatureRowPrefix) {
+		encoded, ok := strings.CutSuffix(strings.TrimPrefix(last, signatureRowPrefix), `"`)
+		if !ok {
+			return fmt.Errorf("%w: malformed SIGNATURE row", ErrSignatureMismatch)
+		}
+		var err error
+		if signature, err = base64.StdEncoding.DecodeString(encoded); err != nil {
+			return fmt.Errorf("%w: %v", ErrSignatureMismatch, err)
+		}
+		rest, last = cutLastLine(rest)
+	}
+
+	if !strings.HasPrefix(last, hashRowPrefix) {
+		return ErrHashRowMissing
+	}
+	encoded, ok := strings.CutSuffix(strings.TrimPrefix(last, hashRowPrefix), `"`)
+	if !ok {
+		return fmt.Errorf("%w: malformed HASH row", ErrHashRowMissing)
+	}
+	want, err := hex.DecodeString(encoded)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrHashRowMissing, err)
+	}
+	digest := sha256.Sum256(rest)
+	if !bytes.Equal(digest[:], want) {
+		return ErrHashMismatch
+	}
+
+	if key == nil {
+		return nil
+	}
+	if signature == nil {
+		return ErrSignatureMissing
+	}
+	if !ecdsa.VerifyASN1(key, digest[:], signature) {
+		return ErrSignatureMismatch
+	}
+	return nil
+}
+
+// cutLastLine splits data before its last line, returning the preceding
+// bytes, including their trailing newline, and the last line without it.
+func cutLastLine(data []byte) ([]byte, string) {
+	data = bytes.TrimSuffix(data, []byte("\n"))
+	data = bytes.TrimSuffix(data, []byte("\r"))
+	i := bytes.LastIndexByte(data, '\n')
+	return data[:i+1], string(data[i+1:])
+}
+
+// LoadSigningKey reads a PEM-encoded ECDSA private key from path, in either
+// SEC 1 ("EC PRIVATE KEY") or PKCS #8 ("PRIVATE KEY") form.
+func LoadSigningKey(path string) (*ecdsa.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signing key: %w", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("signing key is not PEM encoded")
+	}
+
+	switch block.Type {
+	case "EC PRIVATE KEY":
+		return x509.ParseECPrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		key, ok := parsed.(*ecdsa.PrivateKey)
+		if !ok {
+			return nil, errors.New("signing key is not an ECDSA key")
+		}
+		return key, nil
+	default:
+		return nil, fmt.Errorf("unsupported signing key PEM type %q", block.Type)
+	}
+}
+
+// LoadVerifyingKey reads a PEM-encoded ECDSA public key ("PUBLIC KEY") from
+// path. A private key file is also accepted and its public half used.
+func LoadVerifyingKey(path string) (*ecdsa.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read verifying key: %w", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("verifying key is not PEM encoded")
+	}
+	if block.Type != "PUBLIC KEY" {
+		key, err := LoadSigningKey(path)
+		if err != nil {
+			return nil, err
+		}
+		return &key.PublicKey, nil
+	}
+
+	parsed, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	key, ok := parsed.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, errors.New("verifying key is not an ECDSA key")
+	}
+	return key, nil
+}
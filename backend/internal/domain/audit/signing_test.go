@@ -0,0 +1,180 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// exportRepository serves a fixed set of events to exports.
+type exportRepository struct {
+	Repository
+	events []Event
+}
+
+func (m *exportRepository) Query(ctx context.Context, filters QueryFilters) (*QueryResult, error) {
+	return &QueryResult{Events: m.events, TotalCount: len(m.events)}, nil
+}
+
+func newExportRepository() *exportRepository {
+	email := "isso@example.com"
+	return &exportRepository{events: []Event{
+		{
+			ID: uuid.New(), EventType: EventType("statement.update"), EntityType: "statement",
+			EntityID: uuid.NewString(), Action: "update", Status: "success", UserEmail: &email,
+			Details: map[string]interface{}{"note": "said \"HASH\",\nthen stopped"}, CreatedAt: time.Now(),
+		},
+		{
+			ID: uuid.New(), EventType: EventType("push.complete"), EntityType: "push_job",
+			EntityID: uuid.NewString(), Action: "push", Status: "failure", CreatedAt: time.Now(),
+		},
+	}}
+}
+
+func generateKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	return key
+}
+
+func TestService_ExportSignedCSV_Hash(t *testing.T) {
+	svc := NewService(newExportRepository(), Options{}, nil)
+
+	var buf bytes.Buffer
+	if err := svc.ExportSignedCSV(context.Background(), QueryFilters{}, &buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data := buf.Bytes()
+
+	content, last := cutLastLine(data)
+	want := fmt.Sprintf(`"HASH","sha256:%x"`, sha256.Sum256(content))
+	if last != want {
+		t.Errorf("expected final row %s, got %s", want, last)
+	}
+	if !bytes.HasPrefix(content, []byte("Event ID,Timestamp,")) {
+		t.Errorf("expected the CSV header first, got %q", content)
+	}
+	if strings.Contains(string(data), "SIGNATURE") {
+		t.Error("expected no signature without a signing key")
+	}
+
+	if err := VerifySignedCSV(data, nil); err != nil {
+		t.Errorf("expected export to verify, got %v", err)
+	}
+}
+
+func TestVerifySignedCSV_Tampered(t *testing.T) {
+	svc := NewService(newExportRepository(), Options{}, nil)
+	var buf bytes.Buffer
+	if err := svc.ExportSignedCSV(context.Background(), QueryFilters{}, &buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tampered := bytes.Replace(buf.Bytes(), []byte("failure"), []byte("success"), 1)
+	if err := VerifySignedCSV(tampered, nil); !errors.Is(err, ErrHashMismatch) {
+		t.Errorf("expected ErrHashMismatch for edited row, got %v", err)
+	}
+
+	content, _ := cutLastLine(buf.Bytes())
+	if err := VerifySignedCSV(content, nil); !errors.Is(err, ErrHashRowMissing) {
+		t.Errorf("expected ErrHashRowMissing without the hash row, got %v", err)
+	}
+	if err := VerifySignedCSV(nil, nil); !errors.Is(err, ErrHashRowMissing) {
+		t.Errorf("expected ErrHashRowMissing for empty input, got %v", err)
+	}
+}
+
+func TestService_ExportSignedCSV_Signature(t *testing.T) {
+	key := generateKey(t)
+	svc := NewService(newExportRepository(), Options{SigningKey: key}, nil)
+
+	var buf bytes.Buffer
+	if err := svc.ExportSignedCSV(context.Background(), QueryFilters{}, &buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data := buf.Bytes()
+
+	rest, last := cutLastLine(data)
+	if !strings.HasPrefix(last, `"SIGNATURE","`) {
+		t.Fatalf("expected a final SIGNATURE row, got %s", last)
+	}
+	if _, hashRow := cutLastLine(rest); !strings.HasPrefix(hashRow, `"HASH","sha256:`) {
+		t.Errorf("expected the HASH row before the signature, got %s", hashRow)
+	}
+
+	if err := VerifySignedCSV(data, &key.PublicKey); err != nil {
+		t.Errorf("expected signature to verify, got %v", err)
+	}
+	// Without a key only the hash is checked
+	if err := VerifySignedCSV(data, nil); err != nil {
+		t.Errorf("expected hash to verify, got %v", err)
+	}
+	if err := VerifySignedCSV(data, &generateKey(t).PublicKey); !errors.Is(err, ErrSignatureMismatch) {
+		t.Errorf("expected ErrSignatureMismatch for another key, got %v", err)
+	}
+	if err := VerifySignedCSV(rest, &key.PublicKey); !errors.Is(err, ErrSignatureMissing) {
+		t.Errorf("expected ErrSignatureMissing without the signature row, got %v", err)
+	}
+}
+
+func TestLoadSigningKey(t *testing.T) {
+	key := generateKey(t)
+	dir := t.TempDir()
+	writePEM := func(name, blockType string, der []byte) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600); err != nil {
+			t.Fatalf("failed to write key: %v", err)
+		}
+		return path
+	}
+
+	sec1, _ := x509.MarshalECPrivateKey(key)
+	pkcs8, _ := x509.MarshalPKCS8PrivateKey(key)
+	pkix, _ := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	sec1Path := writePEM("sec1.pem", "EC PRIVATE KEY", sec1)
+	pkcs8Path := writePEM("pkcs8.pem", "PRIVATE KEY", pkcs8)
+	publicPath := writePEM("public.pem", "PUBLIC KEY", pkix)
+
+	for _, path := range []string{sec1Path, pkcs8Path} {
+		loaded, err := LoadSigningKey(path)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", filepath.Base(path), err)
+		}
+		if !loaded.Equal(key) {
+			t.Errorf("%s: loaded a different key", filepath.Base(path))
+		}
+	}
+	for _, path := range []string{publicPath, sec1Path} {
+		loaded, err := LoadVerifyingKey(path)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", filepath.Base(path), err)
+		}
+		if !loaded.Equal(&key.PublicKey) {
+			t.Errorf("%s: loaded a different public key", filepath.Base(path))
+		}
+	}
+
+	if _, err := LoadSigningKey(publicPath); err == nil {
+		t.Error("expected an error loading a public key as the signing key")
+	}
+	if _, err := LoadSigningKey(filepath.Join(dir, "missing.pem")); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
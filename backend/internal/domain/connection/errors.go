@@ -5,14 +5,21 @@ import "errors"
 // Domain errors for connection operations.
 var (
 	// Validation errors
-	ErrInstanceURLRequired  = errors.New("instance URL is required")
-	ErrAuthMethodRequired   = errors.New("authentication method is required")
-	ErrInvalidAuthMethod    = errors.New("invalid authentication method: must be 'basic' or 'oauth'")
-	ErrUsernameRequired     = errors.New("username is required for basic authentication")
-	ErrPasswordRequired     = errors.New("password is required for basic authentication")
-	ErrClientIDRequired     = errors.New("client ID is required for OAuth authentication")
-	ErrClientSecretRequired = errors.New("client secret is required for OAuth authentication")
-	ErrTokenURLRequired     = errors.New("token URL is required for OAuth authentication")
+	ErrInstanceURLRequired    = errors.New("instance URL is required")
+	ErrInvalidInstanceURL     = errors.New("invalid instance URL")
+	ErrInstanceHostNotAllowed = errors.New("instance URL must not point to localhost or a private network")
+	ErrAuthMethodRequired     = errors.New("authentication method is required")
+	ErrInvalidAuthMethod      = errors.New("invalid authentication method: must be 'basic' or 'oauth'")
+	ErrUsernameRequired       = errors.New("username is required for basic authentication")
+	ErrPasswordRequired       = errors.New("password is required for basic authentication")
+	ErrClientIDRequired       = errors.New("client ID is required for OAuth authentication")
+	ErrClientSecretRequired   = errors.New("client secret is required for OAuth authentication")
+	ErrTokenURLRequired       = errors.New("token URL is required for OAuth authentication")
+	ErrInvalidLabel           = errors.New("connection label must be 1-50 letters, digits, '_' or '-'")
+	ErrInvalidScriptedAPI     = errors.New("scripted REST API namespace and API ID must be set together and contain only lowercase letters, digits or '_'")
+	ErrInvalidScope           = errors.New("scope must be an application scope sys_id of letters, digits or '_'")
+	ErrClientTLSIncomplete    = errors.New("client TLS certificate and key must be provided together")
+	ErrInvalidClientTLS       = errors.New("client TLS certificate and key must be a matching PEM-encoded pair")
 
 	// Repository errors
 	ErrConnectionNotFound = errors.New("connection not found")
@@ -22,4 +29,9 @@ var (
 	ErrEncryptionFailed = errors.New("failed to encrypt credentials")
 	ErrDecryptionFailed = errors.New("failed to decrypt credentials")
 	ErrTestFailed       = errors.New("connection test failed")
+	ErrServiceNowError  = errors.New("ServiceNow request failed")
+
+	// Export and import errors
+	ErrUnsupportedExportVersion = errors.New("unsupported connection export version")
+	ErrEncryptionKeyMismatch    = errors.New("export was encrypted with a different encryption key")
 )
@@ -0,0 +1,196 @@
+package connection
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ExportVersion is the schema version of export bundles written by
+// ExportConnection. ImportConnection rejects any other version.
+const ExportVersion = 1
+
+// keyCheckPlaintext is encrypted into every export bundle so an import can
+// tell whether it runs under the same encryption key before storing
+// credentials it cannot decrypt.
+var keyCheckPlaintext = []byte("controlcrud-connection-export")
+
+// ExportBundle is a backup of one connection, including its encrypted
+// credentials. Byte fields are base64-encoded in JSON.
+type ExportBundle struct {
+	Version    int                `json:"version"`
+	KeyCheck   EncryptedValue     `json:"key_check"`
+	Connection ExportedConnection `json:"connection"`
+}
+
+// EncryptedValue is a ciphertext together with its nonce.
+type EncryptedValue struct {
+	Ciphertext []byte `json:"ciphertext"`
+	Nonce      []byte `json:"nonce"`
+}
+
+// ExportedConnection holds every stored column of a connection.
+type ExportedConnection struct {
+	ID          uuid.UUID  `json:"id"`
+	InstanceURL string     `json:"instance_url"`
+	AuthMethod  AuthMethod `json:"auth_method"`
+	Label       string     `json:"label"`
+
+	Username          string `json:"username,omitempty"`
+	PasswordEncrypted []byte `json:"password_encrypted,omitempty"`
+	PasswordNonce     []byte `json:"password_nonce,omitempty"`
+
+	OAuthClientID              string `json:"oauth_client_id,omitempty"`
+	OAuthClientSecretEncrypted []byte `json:"oauth_client_secret_encrypted,omitempty"`
+	OAuthClientSecretNonce     []byte `json:"oauth_client_secret_nonce,omitempty"`
+	OAuthTokenURL              string `json:"oauth_token_url,omitempty"`
+
+	ScriptedAPINamespace string `json:"scripted_api_namespace,omitempty"`
+	ScriptedAPIID        string `json:"scripted_api_id,omitempty"`
+	Scope                string `json:"scope,omitempty"`
+
+	ClientTLSCertEncrypted []byte `json:"client_cert_encrypted,omitempty"`
+	ClientTLSCertNonce     []byte `json:"client_cert_nonce,omitempty"`
+	ClientTLSKeyEncrypted  []byte `json:"client_key_encrypted,omitempty"`
+	ClientTLSKeyNonce      []byte `json:"client_key_nonce,omitempty"`
+
+	IsActive                bool             `json:"is_active"`
+	LastTestAt              *time.Time       `json:"last_test_at,omitempty"`
+	LastTestStatus          ConnectionStatus `json:"last_test_status"`
+	LastTestMessage         string           `json:"last_test_message,omitempty"`
+	LastTestInstanceVersion string           `json:"last_test_instance_version,omitempty"`
+	LastTestResponseTimeMs  int64            `json:"last_test_response_time_ms,omitempty"`
+
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	CreatedBy *uuid.UUID `json:"created_by,omitempty"`
+	UpdatedBy *uuid.UUID `json:"updated_by,omitempty"`
+}
+
+// ExportConnection returns a backup bundle of the labelled connection. The
+// credentials stay encrypted under the current key.
+func (s *Service) ExportConnection(ctx context.Context, label string) (*ExportBundle, error) {
+	conn, err := s.getByLabel(ctx, label)
+	if err != nil {
+		return nil, err
+	}
+
+	ciphertext, nonce, err := s.crypto.Encrypt(keyCheckPlaintext)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrEncryptionFailed, err)
+	}
+
+	return &ExportBundle{
+		Version:  ExportVersion,
+		KeyCheck: EncryptedValue{Ciphertext: ciphertext, Nonce: nonce},
+		Connection: ExportedConnection{
+			ID:                         conn.ID,
+			InstanceURL:                conn.InstanceURL,
+			AuthMethod:                 conn.AuthMethod,
+			Label:                      conn.Label,
+			Username:                   conn.Username,
+			PasswordEncrypted:          conn.PasswordEncrypted,
+			PasswordNonce:              conn.PasswordNonce,
+			OAuthClientID:              conn.OAuthClientID,
+			OAuthClientSecretEncrypted: conn.OAuthClientSecretEncrypted,
+			OAuthClientSecretNonce:     conn.OAuthClientSecretNonce,
+			OAuthTokenURL:              conn.OAuthTokenURL,
+			ScriptedAPINamespace:       conn.ScriptedAPINamespace,
+			ScriptedAPIID:              conn.ScriptedAPIID,
+			Scope:                      conn.Scope,
+			ClientTLSCertEncrypted:     conn.ClientTLSCertEncrypted,
+			ClientTLSCertNonce:         conn.ClientTLSCertNonce,
+			ClientTLSKeyEncrypted:      conn.ClientTLSKeyEncrypted,
+			ClientTLSKeyNonce:          conn.ClientTLSKeyNonce,
+			IsActive:                   conn.IsActive,
+			LastTestAt:                 conn.LastTestAt,
+			LastTestStatus:             conn.LastTestStatus,
+			LastTestMessage:            conn.LastTestMessage,
+			LastTestInstanceVersion:    conn.LastTestInstanceVersion,
+			LastTestResponseTimeMs:     conn.LastTestResponseTimeMs,
+			CreatedAt:                  conn.CreatedAt,
+			UpdatedAt:                  conn.UpdatedAt,
+			CreatedBy:                  conn.CreatedBy,
+			UpdatedBy:                  conn.UpdatedBy,
+		},
+	}, nil
+}
+
+// ImportConnection stores the connection from a backup bundle. The bundle
+// must have been exported under the current encryption key. An existing
+// connection with the same label is replaced but keeps its ID. The imported
+// connection is marked pending until it is tested again.
+func (s *Service) ImportConnection(ctx context.Context, bundle *ExportBundle, userID *uuid.UUID) (*Connection, error) {
+	if bundle.Version != ExportVersion {
+		return nil, fmt.Errorf("%w: got %d, want %d", ErrUnsupportedExportVersion, bundle.Version, ExportVersion)
+	}
+
+	plaintext, err := s.crypto.Decrypt(bundle.KeyCheck.Ciphertext, bundle.KeyCheck.Nonce)
+	if err != nil || !bytes.Equal(plaintext, keyCheckPlaintext) {
+		return nil, ErrEncryptionKeyMismatch
+	}
+
+	exported := bundle.Connection
+	label, err := NormalizeLabel(exported.Label)
+	if err != nil {
+		return nil, err
+	}
+	if exported.AuthMethod != AuthMethodBasic && exported.AuthMethod != AuthMethodOAuth {
+		return nil, ErrInvalidAuthMethod
+	}
+	instanceURL, err := normalizeInstanceURL(exported.InstanceURL, s.opts.Production)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateScriptedAPI(exported.ScriptedAPINamespace, exported.ScriptedAPIID); err != nil {
+		return nil, err
+	}
+
+	id := exported.ID
+	if id == uuid.Nil {
+		id = uuid.New()
+	}
+	conn := &Connection{
+		ID:                         id,
+		InstanceURL:                instanceURL,
+		AuthMethod:                 exported.AuthMethod,
+		Label:                      label,
+		Username:                   exported.Username,
+		PasswordEncrypted:          exported.PasswordEncrypted,
+		PasswordNonce:              exported.PasswordNonce,
+		OAuthClientID:              exported.OAuthClientID,
+		OAuthClientSecretEncrypted: exported.OAuthClientSecretEncrypted,
+		OAuthClientSecretNonce:     exported.OAuthClientSecretNonce,
+		OAuthTokenURL:              exported.OAuthTokenURL,
+		ScriptedAPINamespace:       exported.ScriptedAPINamespace,
+		ScriptedAPIID:              exported.ScriptedAPIID,
+		Scope:                      exported.Scope,
+		ClientTLSCertEncrypted:     exported.ClientTLSCertEncrypted,
+		ClientTLSCertNonce:         exported.ClientTLSCertNonce,
+		ClientTLSKeyEncrypted:      exported.ClientTLSKeyEncrypted,
+		ClientTLSKeyNonce:          exported.ClientTLSKeyNonce,
+		IsActive:                   exported.IsActive,
+		LastTestStatus:             StatusPending,
+		CreatedAt:                  exported.CreatedAt,
+		CreatedBy:                  exported.CreatedBy,
+		UpdatedBy:                  userID,
+	}
+
+	existing, err := s.repo.GetByLabel(ctx, label)
+	if err != nil && err != ErrConnectionNotFound {
+		return nil, fmt.Errorf("failed to get connection %q: %w", label, err)
+	}
+	if existing != nil {
+		conn.ID = existing.ID
+	}
+
+	if err := s.repo.Upsert(ctx, conn); err != nil {
+		return nil, fmt.Errorf("failed to import connection: %w", err)
+	}
+
+	s.invalidateClients()
+	return conn, nil
+}
@@ -0,0 +1,192 @@
+package connection
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/controlcrud/backend/internal/infrastructure/crypto"
+)
+
+func newAESService(t *testing.T, repo Repository, keyByte byte) *Service {
+	t.Helper()
+	cryptoSvc, err := crypto.NewAESCryptoService(base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{keyByte}, 32)))
+	if err != nil {
+		t.Fatalf("failed to create crypto service: %v", err)
+	}
+	return NewService(repo, cryptoSvc, Options{})
+}
+
+func TestService_ExportImport_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	source := newAESService(t, newMockRepository(), 1)
+
+	saved, _, err := source.SaveConfig(ctx, &ConfigInput{
+		InstanceURL:          "https://prod.service-now.com",
+		AuthMethod:           AuthMethodBasic,
+		Label:                "prod",
+		Username:             "admin",
+		Password:             "s3cret",
+		ScriptedAPINamespace: "x_acme_grc",
+		ScriptedAPIID:        "statements",
+	}, nil, SaveOptions{})
+	if err != nil {
+		t.Fatalf("failed to save config: %v", err)
+	}
+
+	bundle, err := source.ExportConnection(ctx, "prod")
+	if err != nil {
+		t.Fatalf("failed to export: %v", err)
+	}
+	if bundle.Version != ExportVersion {
+		t.Errorf("expected version %d, got %d", ExportVersion, bundle.Version)
+	}
+
+	// Send the bundle through JSON as a backup file would
+	data, err := json.Marshal(bundle)
+	if err != nil {
+		t.Fatalf("failed to marshal bundle: %v", err)
+	}
+	var restored ExportBundle
+	if err := json.Unmarshal(data, &restored); err != nil {
+		t.Fatalf("failed to unmarshal bundle: %v", err)
+	}
+
+	// A fresh instance running under the same key
+	targetRepo := newMockRepository()
+	target := newAESService(t, targetRepo, 1)
+	userID := uuid.New()
+
+	imported, err := target.ImportConnection(ctx, &restored, &userID)
+	if err != nil {
+		t.Fatalf("failed to import: %v", err)
+	}
+	if imported.ID != saved.ID || imported.Label != "prod" || imported.InstanceURL != saved.InstanceURL {
+		t.Errorf("imported connection does not match the export: %+v", imported)
+	}
+	if imported.ScriptedAPINamespace != "x_acme_grc" || imported.ScriptedAPIID != "statements" {
+		t.Errorf("expected scripted API settings to survive, got %q/%q", imported.ScriptedAPINamespace, imported.ScriptedAPIID)
+	}
+	if imported.LastTestStatus != StatusPending {
+		t.Errorf("expected imported connection to be pending, got %s", imported.LastTestStatus)
+	}
+	if imported.UpdatedBy == nil || *imported.UpdatedBy != userID {
+		t.Errorf("expected importer to be recorded, got %v", imported.UpdatedBy)
+	}
+
+	stored, err := targetRepo.GetByLabel(ctx, "prod")
+	if err != nil {
+		t.Fatalf("imported connection not stored: %v", err)
+	}
+	password, err := target.crypto.Decrypt(stored.PasswordEncrypted, stored.PasswordNonce)
+	if err != nil {
+		t.Fatalf("failed to decrypt imported password: %v", err)
+	}
+	if string(password) != "s3cret" {
+		t.Errorf("expected password to round-trip, got %q", password)
+	}
+}
+
+func TestService_ImportConnection_ReplacesSameLabel(t *testing.T) {
+	ctx := context.Background()
+	repo := newMockRepository()
+	svc := newAESService(t, repo, 1)
+
+	existingID := uuid.New()
+	repo.add(&Connection{ID: existingID, Label: "prod", InstanceURL: "https://old.service-now.com", AuthMethod: AuthMethodBasic})
+
+	source := newAESService(t, newMockRepository(), 1)
+	if _, _, err := source.SaveConfig(ctx, &ConfigInput{
+		InstanceURL:       "https://new.service-now.com",
+		AuthMethod:        AuthMethodOAuth,
+		Label:             "prod",
+		OAuthClientID:     "client",
+		OAuthClientSecret: "secret",
+		OAuthTokenURL:     "https://new.service-now.com/oauth_token.do",
+	}, nil, SaveOptions{}); err != nil {
+		t.Fatalf("failed to save config: %v", err)
+	}
+	bundle, err := source.ExportConnection(ctx, "prod")
+	if err != nil {
+		t.Fatalf("failed to export: %v", err)
+	}
+
+	imported, err := svc.ImportConnection(ctx, bundle, nil)
+	if err != nil {
+		t.Fatalf("failed to import: %v", err)
+	}
+	if imported.ID != existingID {
+		t.Errorf("expected the existing connection ID to be kept, got %s", imported.ID)
+	}
+	if len(repo.conns) != 1 || repo.conns[existingID].InstanceURL != "https://new.service-now.com" {
+		t.Errorf("expected the existing connection to be replaced, got %+v", repo.conns)
+	}
+}
+
+func TestService_ImportConnection_Errors(t *testing.T) {
+	ctx := context.Background()
+	source := newAESService(t, newMockRepository(), 1)
+	if _, _, err := source.SaveConfig(ctx, &ConfigInput{
+		InstanceURL: "https://prod.service-now.com",
+		AuthMethod:  AuthMethodBasic,
+		Username:    "admin",
+		Password:    "s3cret",
+	}, nil, SaveOptions{}); err != nil {
+		t.Fatalf("failed to save config: %v", err)
+	}
+	bundle, err := source.ExportConnection(ctx, "")
+	if err != nil {
+		t.Fatalf("failed to export: %v", err)
+	}
+
+	t.Run("different key", func(t *testing.T) {
+		repo := newMockRepository()
+		_, err := newAESService(t, repo, 2).ImportConnection(ctx, bundle, nil)
+		if !errors.Is(err, ErrEncryptionKeyMismatch) {
+			t.Errorf("expected ErrEncryptionKeyMismatch, got %v", err)
+		}
+		if len(repo.conns) != 0 {
+			t.Error("expected nothing to be stored")
+		}
+	})
+
+	t.Run("unsupported version", func(t *testing.T) {
+		future := *bundle
+		future.Version = ExportVersion + 1
+		_, err := newAESService(t, newMockRepository(), 1).ImportConnection(ctx, &future, nil)
+		if !errors.Is(err, ErrUnsupportedExportVersion) {
+			t.Errorf("expected ErrUnsupportedExportVersion, got %v", err)
+		}
+	})
+
+	t.Run("invalid auth method", func(t *testing.T) {
+		invalid := *bundle
+		invalid.Connection.AuthMethod = "kerberos"
+		_, err := newAESService(t, newMockRepository(), 1).ImportConnection(ctx, &invalid, nil)
+		if !errors.Is(err, ErrInvalidAuthMethod) {
+			t.Errorf("expected ErrInvalidAuthMethod, got %v", err)
+		}
+	})
+
+	t.Run("invalid scripted API", func(t *testing.T) {
+		invalid := *bundle
+		invalid.Connection.ScriptedAPINamespace = "x_acme_grc"
+		invalid.Connection.ScriptedAPIID = "../table/sys_user"
+		_, err := newAESService(t, newMockRepository(), 1).ImportConnection(ctx, &invalid, nil)
+		if !errors.Is(err, ErrInvalidScriptedAPI) {
+			t.Errorf("expected ErrInvalidScriptedAPI, got %v", err)
+		}
+	})
+}
+
+func TestService_ExportConnection_NotFound(t *testing.T) {
+	_, err := newAESService(t, newMockRepository(), 1).ExportConnection(context.Background(), "missing")
+	if !errors.Is(err, ErrConnectionNotFound) {
+		t.Errorf("expected ErrConnectionNotFound, got %v", err)
+	}
+}
@@ -2,9 +2,14 @@
 package connection
 
 import (
+	"crypto/tls"
+	"encoding/json"
+	"regexp"
 	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/controlcrud/backend/internal/infrastructure/servicenow"
 )
 
 // AuthMethod represents the authentication method for ServiceNow.
@@ -25,12 +30,19 @@ const (
 	StatusUnknown ConnectionStatus = "unknown"
 )
 
+// DefaultLabel is the label of the connection used when none is given.
+const DefaultLabel = "default"
+
 // Connection represents a ServiceNow connection configuration.
 type Connection struct {
 	ID          uuid.UUID        `json:"id"`
 	InstanceURL string           `json:"instance_url"`
 	AuthMethod  AuthMethod       `json:"auth_method"`
 
+	// Label distinguishes connections to different instances, e.g. "prod"
+	// and "staging". Unique across connections.
+	Label string `json:"label"`
+
 	// Basic Auth credentials (encrypted in storage)
 	Username          string `json:"username,omitempty"`
 	PasswordEncrypted []byte `json:"-"`
@@ -42,12 +54,26 @@ type Connection struct {
 	OAuthClientSecretNonce     []byte `json:"-"`
 	OAuthTokenURL              string `json:"oauth_token_url,omitempty"`
 
+	// Optional Scripted REST API served at /api/<namespace>/<api_id>
+	ScriptedAPINamespace string `json:"scripted_api_namespace,omitempty"`
+	ScriptedAPIID        string `json:"scripted_api_id,omitempty"`
+
+	// Optional application scope sys_id limiting GRC table queries
+	Scope string `json:"scope,omitempty"`
+
+	// Optional PEM client certificate and key for mutual TLS (encrypted in storage)
+	ClientTLSCertEncrypted []byte `json:"-"`
+	ClientTLSCertNonce     []byte `json:"-"`
+	ClientTLSKeyEncrypted  []byte `json:"-"`
+	ClientTLSKeyNonce      []byte `json:"-"`
+
 	// Status tracking
 	IsActive               bool             `json:"is_active"`
 	LastTestAt             *time.Time       `json:"last_test_at,omitempty"`
 	LastTestStatus         ConnectionStatus `json:"last_test_status"`
 	LastTestMessage        string           `json:"last_test_message,omitempty"`
 	LastTestInstanceVersion string           `json:"last_test_instance_version,omitempty"`
+	LastTestResponseTimeMs  int64            `json:"last_test_response_time_ms,omitempty"`
 
 	// Audit fields
 	CreatedAt time.Time  `json:"created_at"`
@@ -57,9 +83,11 @@ type Connection struct {
 }
 
 // ConfigInput represents input for creating or updating a connection.
+// Saving replaces the connection with the same label.
 type ConfigInput struct {
 	InstanceURL string     `json:"instance_url" validate:"required,url"`
 	AuthMethod  AuthMethod `json:"auth_method" validate:"required,oneof=basic oauth"`
+	Label       string     `json:"label,omitempty"` // Defaults to DefaultLabel
 
 	// Basic Auth
 	Username string `json:"username,omitempty" validate:"required_if=AuthMethod basic"`
@@ -69,10 +97,33 @@ type ConfigInput struct {
 	OAuthClientID     string `json:"oauth_client_id,omitempty" validate:"required_if=AuthMethod oauth"`
 	OAuthClientSecret string `json:"oauth_client_secret,omitempty" validate:"required_if=AuthMethod oauth"`
 	OAuthTokenURL     string `json:"oauth_token_url,omitempty" validate:"required_if=AuthMethod oauth,omitempty,url"`
+
+	// Scripted REST API (optional)
+	ScriptedAPINamespace string `json:"scripted_api_namespace,omitempty"`
+	ScriptedAPIID        string `json:"scripted_api_id,omitempty"`
+
+	// Application scope sys_id limiting GRC table queries (optional)
+	Scope string `json:"scope,omitempty"`
+
+	// PEM client certificate and private key for mutual TLS (optional)
+	ClientTLSCert string `json:"client_tls_cert,omitempty"`
+	ClientTLSKey  string `json:"client_tls_key,omitempty"`
+}
+
+// CredentialsInput represents input for rotating the credentials of the active connection.
+// Only the fields relevant to the connection's auth method are used.
+type CredentialsInput struct {
+	// Basic Auth
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+
+	// OAuth
+	OAuthClientSecret string `json:"oauth_client_secret,omitempty"`
 }
 
 // ConnectionStatus represents the current connection status for display.
 type Status struct {
+	Label                  string           `json:"label"`
 	IsConfigured           bool             `json:"is_configured"`
 	InstanceURL            string           `json:"instance_url,omitempty"`
 	AuthMethod             AuthMethod       `json:"auth_method,omitempty"`
@@ -80,6 +131,26 @@ type Status struct {
 	LastTestStatus         ConnectionStatus `json:"last_test_status"`
 	LastTestMessage        string           `json:"last_test_message,omitempty"`
 	LastTestInstanceVersion string           `json:"last_test_instance_version,omitempty"`
+	LastTestResponseTimeMs  int64            `json:"last_test_response_time_ms,omitempty"`
+	ScriptedAPINamespace    string           `json:"scripted_api_namespace,omitempty"`
+	ScriptedAPIID           string           `json:"scripted_api_id,omitempty"`
+	Scope                   string           `json:"scope,omitempty"`
+}
+
+// ScriptedAPITestInput describes a request to a Scripted REST API resource.
+type ScriptedAPITestInput struct {
+	Path   string      // Resource path below /api/<namespace>/<api_id>
+	Method string      // HTTP method; defaults to GET
+	Body   interface{} // Optional JSON request body
+}
+
+// ScriptedAPITestResult represents the result of a Scripted REST API call.
+type ScriptedAPITestResult struct {
+	Success        bool            `json:"success"`
+	ErrorMessage   string          `json:"error_message,omitempty"`
+	Response       json.RawMessage `json:"response,omitempty"`
+	ResponseTimeMs int64           `json:"response_time_ms"`
+	TestedAt       time.Time       `json:"tested_at"`
 }
 
 // TestResult represents the result of a connection test.
@@ -92,11 +163,67 @@ type TestResult struct {
 	TestedAt        time.Time `json:"tested_at"`
 }
 
-// Validate validates the ConfigInput.
-func (c *ConfigInput) Validate() error {
+// Options configures optional connection service behaviour.
+type Options struct {
+	// TableMapping selects the ServiceNow tables and fields used by clients.
+	// Nil uses the built-in demo mapping.
+	TableMapping *servicenow.TableMapping
+
+	// Production rejects instance URLs on localhost or private networks.
+	Production bool
+
+	// ProxyURL routes ServiceNow requests through a forward proxy. Empty
+	// uses the HTTP_PROXY and HTTPS_PROXY environment variables.
+	ProxyURL string
+
+	// NTLMProxyURL routes ServiceNow requests through a forward proxy that
+	// requires NTLM authentication with the given credentials. It takes
+	// precedence over ProxyURL.
+	NTLMProxyURL      string
+	NTLMProxyUsername string
+	NTLMProxyPassword string
+	NTLMProxyDomain   string
+
+	// UseGzip compresses ServiceNow request and response bodies.
+	UseGzip bool
+
+	// KeepHTMLOnPull stores pulled statement content as ServiceNow returns
+	// it instead of converting HTML to plain text.
+	KeepHTMLOnPull bool
+
+	// ResponseCacheTTL is how long ServiceNow systems list pages are reused
+	// before being fetched again. Zero disables response caching.
+	ResponseCacheTTL time.Duration
+
+	// OAuthTokenCacheDir stores each connection's OAuth access token in a
+	// file so restarts reuse a still valid token. Empty keeps tokens in
+	// memory only.
+	OAuthTokenCacheDir string
+}
+
+// SaveOptions controls optional behaviour when saving a connection configuration.
+type SaveOptions struct {
+	// TestOnSave tests the connection right after it is saved.
+	TestOnSave bool
+}
+
+// Validate validates the ConfigInput and normalizes its instance URL in
+// place. In production, instance URLs pointing at local hosts are rejected.
+func (c *ConfigInput) Validate(production bool) error {
+	label, err := NormalizeLabel(c.Label)
+	if err != nil {
+		return err
+	}
+	c.Label = label
+
 	if c.InstanceURL == "" {
 		return ErrInstanceURLRequired
 	}
+	instanceURL, err := normalizeInstanceURL(c.InstanceURL, production)
+	if err != nil {
+		return err
+	}
+	c.InstanceURL = instanceURL
 	if c.AuthMethod == "" {
 		return ErrAuthMethodRequired
 	}
@@ -124,5 +251,77 @@ func (c *ConfigInput) Validate() error {
 		}
 	}
 
+	if err := validateScriptedAPI(c.ScriptedAPINamespace, c.ScriptedAPIID); err != nil {
+		return err
+	}
+
+	if c.Scope != "" && !scopePattern.MatchString(c.Scope) {
+		return ErrInvalidScope
+	}
+
+	if (c.ClientTLSCert == "") != (c.ClientTLSKey == "") {
+		return ErrClientTLSIncomplete
+	}
+	if c.ClientTLSCert != "" {
+		if _, err := tls.X509KeyPair([]byte(c.ClientTLSCert), []byte(c.ClientTLSKey)); err != nil {
+			return ErrInvalidClientTLS
+		}
+	}
+
+	return nil
+}
+
+// labelPattern matches connection labels such as "prod" or "staging-eu".
+var labelPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,50}$`)
+
+// NormalizeLabel returns the label to use for a connection lookup, mapping
+// an empty label to DefaultLabel.
+func NormalizeLabel(label string) (string, error) {
+	if label == "" {
+		return DefaultLabel, nil
+	}
+	if !labelPattern.MatchString(label) {
+		return "", ErrInvalidLabel
+	}
+	return label, nil
+}
+
+// scriptedAPIPattern matches Scripted REST API namespaces and IDs such as
+// "x_acme_grc" and "statements".
+var scriptedAPIPattern = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// validateScriptedAPI checks that a Scripted REST API namespace and API ID
+// are either both unset or both match scriptedAPIPattern.
+func validateScriptedAPI(namespace, apiID string) error {
+	if namespace == "" && apiID == "" {
+		return nil
+	}
+	if !scriptedAPIPattern.MatchString(namespace) || !scriptedAPIPattern.MatchString(apiID) {
+		return ErrInvalidScriptedAPI
+	}
+	return nil
+}
+
+// scopePattern matches application scope sys_ids, including "global".
+var scopePattern = regexp.MustCompile(`^[A-Za-z0-9_]{1,100}$`)
+
+// Validate validates the CredentialsInput against the given auth method.
+func (c *CredentialsInput) Validate(method AuthMethod) error {
+	switch method {
+	case AuthMethodBasic:
+		if c.Username == "" {
+			return ErrUsernameRequired
+		}
+		if c.Password == "" {
+			return ErrPasswordRequired
+		}
+	case AuthMethodOAuth:
+		if c.OAuthClientSecret == "" {
+			return ErrClientSecretRequired
+		}
+	default:
+		return ErrInvalidAuthMethod
+	}
+
 	return nil
 }
@@ -8,25 +8,27 @@ import (
 
 // Repository defines the interface for connection data persistence.
 type Repository interface {
-	// GetActive returns the currently active connection, if any.
-	// Returns ErrConnectionNotFound if no active connection exists.
-	GetActive(ctx context.Context) (*Connection, error)
+	// GetByLabel returns the connection with the given label.
+	// Returns ErrConnectionNotFound if no connection has the label.
+	GetByLabel(ctx context.Context, label string) (*Connection, error)
 
 	// GetByID returns a connection by its ID.
 	// Returns ErrConnectionNotFound if the connection does not exist.
 	GetByID(ctx context.Context, id uuid.UUID) (*Connection, error)
 
-	// Upsert creates or updates a connection.
-	// If an active connection exists, it will be deactivated first.
+	// Upsert creates or updates a connection by ID. Labels are unique, so
+	// replacing a labelled connection must reuse its ID.
 	Upsert(ctx context.Context, conn *Connection) error
 
-	// UpdateTestStatus updates the connection's test status fields.
-	UpdateTestStatus(ctx context.Context, id uuid.UUID, status ConnectionStatus, message string, version string) error
+	// UpdateTestStatus updates the connection's test status fields, including
+	// how long the test request took.
+	UpdateTestStatus(ctx context.Context, id uuid.UUID, status ConnectionStatus, message string, version string, responseTimeMs int64) error
+
+	// UpdateCredentials updates the connection's encrypted credential fields in place.
+	// Returns ErrConnectionNotFound if the connection does not exist.
+	UpdateCredentials(ctx context.Context, conn *Connection) error
 
 	// Delete removes a connection by its ID.
 	// Returns ErrConnectionNotFound if the connection does not exist.
 	Delete(ctx context.Context, id uuid.UUID) error
-
-	// DeactivateAll deactivates all connections.
-	DeactivateAll(ctx context.Context) error
 }
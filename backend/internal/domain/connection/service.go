@@ -2,7 +2,11 @@ package connection
 
 import (
 	"context"
+	"crypto/sha256"
+	"crypto/tls"
 	"fmt"
+	"path/filepath"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
@@ -12,33 +16,72 @@ import (
 
 // Service provides business logic for connection management.
 type Service struct {
-	repo     Repository
-	crypto   crypto.CryptoService
-	snClient servicenow.Client
+	repo   Repository
+	crypto crypto.CryptoService
+	opts   Options
+
+	// responseCache holds ServiceNow Table API pages shared by all clients.
+	// Nil when response caching is disabled.
+	responseCache servicenow.Cache
+
+	// clients caches authenticated ServiceNow clients by connection ID so
+	// their HTTP connections are reused across requests.
+	clientsMu sync.RWMutex
+	clients   map[uuid.UUID]*servicenow.SNClient
+
+	// breakers holds one circuit breaker per connection label, shared by
+	// every client created for that connection.
+	breakersMu sync.Mutex
+	breakers   map[string]*servicenow.CircuitBreaker
+
+	// metrics holds the request counts per connection label, shared by
+	// every client created for that connection.
+	metricsMu sync.Mutex
+	metrics   map[string]*servicenow.Metrics
+
+	// savedHooks run after SaveConfig stores a connection.
+	savedHooks []func(ctx context.Context, label string)
 }
 
 // NewService creates a new connection service.
-func NewService(repo Repository, cryptoSvc crypto.CryptoService) *Service {
-	return &Service{
-		repo:   repo,
-		crypto: cryptoSvc,
+func NewService(repo Repository, cryptoSvc crypto.CryptoService, opts Options) *Service {
+	s := &Service{
+		repo:    repo,
+		crypto:  cryptoSvc,
+		opts:    opts,
+		clients: make(map[uuid.UUID]*servicenow.SNClient),
+
+		breakers: make(map[string]*servicenow.CircuitBreaker),
+		metrics:  make(map[string]*servicenow.Metrics),
 	}
+	if opts.ResponseCacheTTL > 0 {
+		s.responseCache = servicenow.NewMemoryCache()
+	}
+	return s
 }
 
-// GetStatus returns the current connection status.
-func (s *Service) GetStatus(ctx context.Context) (*Status, error) {
-	conn, err := s.repo.GetActive(ctx)
+// GetStatus returns the status of the connection with the given label.
+// An empty label selects DefaultLabel.
+func (s *Service) GetStatus(ctx context.Context, label string) (*Status, error) {
+	label, err := NormalizeLabel(label)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := s.repo.GetByLabel(ctx, label)
 	if err == ErrConnectionNotFound {
 		return &Status{
+			Label:          label,
 			IsConfigured:   false,
 			LastTestStatus: StatusUnknown,
 		}, nil
 	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to get active connection: %w", err)
+		return nil, fmt.Errorf("failed to get connection %q: %w", label, err)
 	}
 
 	return &Status{
+		Label:                   conn.Label,
 		IsConfigured:            true,
 		InstanceURL:             conn.InstanceURL,
 		AuthMethod:              conn.AuthMethod,
@@ -46,14 +89,23 @@ func (s *Service) GetStatus(ctx context.Context) (*Status, error) {
 		LastTestStatus:          conn.LastTestStatus,
 		LastTestMessage:         conn.LastTestMessage,
 		LastTestInstanceVersion: conn.LastTestInstanceVersion,
+		LastTestResponseTimeMs:  conn.LastTestResponseTimeMs,
+		ScriptedAPINamespace:    conn.ScriptedAPINamespace,
+		ScriptedAPIID:           conn.ScriptedAPIID,
+		Scope:                   conn.Scope,
 	}, nil
 }
 
-// SaveConfig saves a new connection configuration.
-func (s *Service) SaveConfig(ctx context.Context, input *ConfigInput, userID *uuid.UUID) (*Connection, error) {
+// SaveConfig saves a connection configuration, replacing the connection
+// with the same label if there is one.
+// With opts.TestOnSave the saved connection is tested and its test status
+// recorded before returning; a failed test does not roll back the save and
+// is reported through the returned TestResult. The TestResult is nil when
+// no test was requested.
+func (s *Service) SaveConfig(ctx context.Context, input *ConfigInput, userID *uuid.UUID, opts SaveOptions) (*Connection, *TestResult, error) {
 	// Validate input
-	if err := input.Validate(); err != nil {
-		return nil, err
+	if err := input.Validate(s.opts.Production); err != nil {
+		return nil, nil, err
 	}
 
 	// Create new connection
@@ -61,12 +113,18 @@ func (s *Service) SaveConfig(ctx context.Context, input *ConfigInput, userID *uu
 		ID:             uuid.New(),
 		InstanceURL:    input.InstanceURL,
 		AuthMethod:     input.AuthMethod,
+		Label:          input.Label,
 		IsActive:       true,
+
 		LastTestStatus: StatusPending,
 		CreatedAt:      time.Now(),
 		UpdatedAt:      time.Now(),
 		CreatedBy:      userID,
 		UpdatedBy:      userID,
+
+		ScriptedAPINamespace: input.ScriptedAPINamespace,
+		ScriptedAPIID:        input.ScriptedAPIID,
+		Scope:                input.Scope,
 	}
 
 	// Encrypt credentials based on auth method
@@ -77,7 +135,7 @@ func (s *Service) SaveConfig(ctx context.Context, input *ConfigInput, userID *uu
 		// Encrypt password
 		encrypted, nonce, err := s.crypto.Encrypt([]byte(input.Password))
 		if err != nil {
-			return nil, fmt.Errorf("%w: %v", ErrEncryptionFailed, err)
+			return nil, nil, fmt.Errorf("%w: %v", ErrEncryptionFailed, err)
 		}
 		conn.PasswordEncrypted = encrypted
 		conn.PasswordNonce = nonce
@@ -89,28 +147,142 @@ func (s *Service) SaveConfig(ctx context.Context, input *ConfigInput, userID *uu
 		// Encrypt client secret
 		encrypted, nonce, err := s.crypto.Encrypt([]byte(input.OAuthClientSecret))
 		if err != nil {
-			return nil, fmt.Errorf("%w: %v", ErrEncryptionFailed, err)
+			return nil, nil, fmt.Errorf("%w: %v", ErrEncryptionFailed, err)
 		}
 		conn.OAuthClientSecretEncrypted = encrypted
 		conn.OAuthClientSecretNonce = nonce
 	}
 
-	// Deactivate existing connections and save new one
-	if err := s.repo.DeactivateAll(ctx); err != nil {
-		return nil, fmt.Errorf("failed to deactivate existing connections: %w", err)
+	// Encrypt the optional client TLS certificate pair
+	if input.ClientTLSCert != "" {
+		encrypted, nonce, err := s.crypto.Encrypt([]byte(input.ClientTLSCert))
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: %v", ErrEncryptionFailed, err)
+		}
+		conn.ClientTLSCertEncrypted = encrypted
+		conn.ClientTLSCertNonce = nonce
+
+		encrypted, nonce, err = s.crypto.Encrypt([]byte(input.ClientTLSKey))
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: %v", ErrEncryptionFailed, err)
+		}
+		conn.ClientTLSKeyEncrypted = encrypted
+		conn.ClientTLSKeyNonce = nonce
+	}
+
+	// Replace the existing connection with this label, keeping its identity
+	existing, err := s.repo.GetByLabel(ctx, conn.Label)
+	if err != nil && err != ErrConnectionNotFound {
+		return nil, nil, fmt.Errorf("failed to get connection %q: %w", conn.Label, err)
+	}
+	if existing != nil {
+		conn.ID = existing.ID
+		conn.CreatedAt = existing.CreatedAt
+		conn.CreatedBy = existing.CreatedBy
 	}
 
 	if err := s.repo.Upsert(ctx, conn); err != nil {
-		return nil, fmt.Errorf("failed to save connection: %w", err)
+		return nil, nil, fmt.Errorf("failed to save connection: %w", err)
 	}
 
-	return conn, nil
+	s.invalidateClients()
+	s.runSavedHooks(ctx, conn.Label)
+
+	if !opts.TestOnSave {
+		return conn, nil, nil
+	}
+
+	result, err := s.TestConnection(ctx, conn.Label)
+	if result == nil {
+		// The test could not run (e.g. credentials could not be decrypted);
+		// report it as a failed test since the config is already saved.
+		result = &TestResult{
+			Success:      false,
+			ErrorMessage: err.Error(),
+			TestedAt:     time.Now(),
+		}
+	}
+
+	conn.LastTestAt = &result.TestedAt
+	conn.LastTestMessage = result.ErrorMessage
+	conn.LastTestInstanceVersion = result.InstanceVersion
+	conn.LastTestResponseTimeMs = result.ResponseTimeMs
+	conn.LastTestStatus = StatusSuccess
+	if !result.Success {
+		conn.LastTestStatus = StatusFailure
+	}
+
+	return conn, result, nil
+}
+
+// OnConfigSaved registers fn to run in its own goroutine, with the label of
+// the saved connection, each time SaveConfig stores a connection. The
+// context passed to fn is not cancelled when the request ends. Hooks must be
+// registered before the service handles requests.
+func (s *Service) OnConfigSaved(fn func(ctx context.Context, label string)) {
+	s.savedHooks = append(s.savedHooks, fn)
+}
+
+func (s *Service) runSavedHooks(ctx context.Context, label string) {
+	ctx = context.WithoutCancel(ctx)
+	for _, fn := range s.savedHooks {
+		go fn(ctx, label)
+	}
 }
 
-// TestConnection tests the active connection and updates its status.
-func (s *Service) TestConnection(ctx context.Context) (*TestResult, error) {
-	// Get active connection
-	conn, err := s.repo.GetActive(ctx)
+// RotateCredentials replaces the credentials of the labelled connection in
+// place and re-tests the connection with the new credentials.
+// The connection's ID, instance URL, auth method and creation audit fields are preserved.
+func (s *Service) RotateCredentials(ctx context.Context, label string, input *CredentialsInput) (*TestResult, error) {
+	conn, err := s.getByLabel(ctx, label)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate input against the existing auth method
+	if err := input.Validate(conn.AuthMethod); err != nil {
+		return nil, err
+	}
+
+	// Re-encrypt the new secret
+	switch conn.AuthMethod {
+	case AuthMethodBasic:
+		encrypted, nonce, err := s.crypto.Encrypt([]byte(input.Password))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrEncryptionFailed, err)
+		}
+		conn.Username = input.Username
+		conn.PasswordEncrypted = encrypted
+		conn.PasswordNonce = nonce
+
+	case AuthMethodOAuth:
+		encrypted, nonce, err := s.crypto.Encrypt([]byte(input.OAuthClientSecret))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrEncryptionFailed, err)
+		}
+		conn.OAuthClientSecretEncrypted = encrypted
+		conn.OAuthClientSecretNonce = nonce
+	}
+
+	conn.LastTestStatus = StatusPending
+	if err := s.repo.UpdateCredentials(ctx, conn); err != nil {
+		return nil, fmt.Errorf("failed to update credentials: %w", err)
+	}
+
+	s.invalidateClients()
+
+	// Verify the new credentials; a failed test is reported in the result, not as an error
+	result, err := s.TestConnection(ctx, conn.Label)
+	if result == nil {
+		return nil, fmt.Errorf("credentials rotated but connection test could not run: %w", err)
+	}
+
+	return result, nil
+}
+
+// TestConnection tests the labelled connection and updates its status.
+func (s *Service) TestConnection(ctx context.Context, label string) (*TestResult, error) {
+	conn, err := s.getByLabel(ctx, label)
 	if err == ErrConnectionNotFound {
 		return &TestResult{
 			Success:      false,
@@ -119,22 +291,13 @@ func (s *Service) TestConnection(ctx context.Context) (*TestResult, error) {
 		}, ErrConnectionNotFound
 	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to get active connection: %w", err)
-	}
-
-	// Create ServiceNow client
-	snConfig := servicenow.DefaultConfig(conn.InstanceURL)
-	snClient, err := servicenow.NewSNClient(snConfig)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create ServiceNow client: %w", err)
+		return nil, err
 	}
 
-	// Set authentication
-	auth, err := s.getAuthProvider(conn)
+	snClient, err := s.clientFor(conn)
 	if err != nil {
 		return nil, err
 	}
-	snClient.SetAuth(auth)
 
 	// Test connection
 	result, err := snClient.TestConnection(ctx)
@@ -159,7 +322,7 @@ func (s *Service) TestConnection(ctx context.Context) (*TestResult, error) {
 		status = StatusFailure
 	}
 
-	updateErr := s.repo.UpdateTestStatus(ctx, conn.ID, status, result.ErrorMessage, result.InstanceInfo.Version)
+	updateErr := s.repo.UpdateTestStatus(ctx, conn.ID, status, result.ErrorMessage, result.InstanceInfo.Version, result.ResponseTimeMs)
 	if updateErr != nil {
 		// Log but don't fail the test result
 		// TODO: Add proper logging
@@ -168,33 +331,131 @@ func (s *Service) TestConnection(ctx context.Context) (*TestResult, error) {
 	return testResult, err
 }
 
-// DeleteConnection deletes the active connection.
-func (s *Service) DeleteConnection(ctx context.Context) error {
-	conn, err := s.repo.GetActive(ctx)
+// TestScriptedAPI calls a resource of the labelled connection's Scripted REST
+// API to verify that it is reachable. Call failures are reported through the
+// result; the error is only set when the call could not be attempted.
+func (s *Service) TestScriptedAPI(ctx context.Context, label string, input ScriptedAPITestInput) (*ScriptedAPITestResult, error) {
+	conn, err := s.getByLabel(ctx, label)
+	if err != nil {
+		return nil, err
+	}
+	if conn.ScriptedAPINamespace == "" || conn.ScriptedAPIID == "" {
+		return nil, servicenow.ErrScriptedAPINotConfigured
+	}
+
+	snClient, err := s.clientFor(conn)
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	response, err := snClient.CallScriptedAPI(ctx, input.Path, input.Method, input.Body)
+	result := &ScriptedAPITestResult{
+		Success:        err == nil,
+		Response:       response,
+		ResponseTimeMs: time.Since(start).Milliseconds(),
+		TestedAt:       time.Now(),
+	}
+	if err != nil {
+		result.ErrorMessage = err.Error()
+	}
+
+	return result, nil
+}
+
+// ListScopes returns the application scopes of the labelled connection's
+// instance, from which a scope for the connection can be chosen.
+func (s *Service) ListScopes(ctx context.Context, label string) ([]servicenow.ScopeRecord, error) {
+	conn, err := s.getByLabel(ctx, label)
+	if err != nil {
+		return nil, err
+	}
+
+	snClient, err := s.clientFor(conn)
+	if err != nil {
+		return nil, err
+	}
+
+	scopes, err := snClient.ListScopes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrServiceNowError, err)
+	}
+	return scopes, nil
+}
+
+// DeleteConnection deletes the labelled connection.
+func (s *Service) DeleteConnection(ctx context.Context, label string) error {
+	conn, err := s.getByLabel(ctx, label)
 	if err == ErrConnectionNotFound {
 		return nil // Already deleted
 	}
 	if err != nil {
-		return fmt.Errorf("failed to get active connection: %w", err)
+		return err
+	}
+
+	if err := s.repo.Delete(ctx, conn.ID); err != nil {
+		return err
 	}
 
-	return s.repo.Delete(ctx, conn.ID)
+	s.invalidateClients()
+	return nil
 }
 
-// GetSNClient returns a configured ServiceNow client for the active connection.
+// GetSNClient returns a configured ServiceNow client for the default connection.
 // This method is used by other services that need to interact with ServiceNow.
 func (s *Service) GetSNClient(ctx context.Context) (servicenow.Client, error) {
-	conn, err := s.repo.GetActive(ctx)
+	return s.GetSNClientByLabel(ctx, DefaultLabel)
+}
+
+// GetSNClientByLabel returns a configured ServiceNow client for the labelled
+// connection. An empty label selects DefaultLabel.
+func (s *Service) GetSNClientByLabel(ctx context.Context, label string) (servicenow.Client, error) {
+	conn, err := s.getByLabel(ctx, label)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.clientFor(conn)
+}
+
+// getByLabel looks up a connection by label, mapping an empty label to
+// DefaultLabel. Returns ErrConnectionNotFound unwrapped.
+func (s *Service) getByLabel(ctx context.Context, label string) (*Connection, error) {
+	label, err := NormalizeLabel(label)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := s.repo.GetByLabel(ctx, label)
 	if err == ErrConnectionNotFound {
 		return nil, ErrConnectionNotFound
 	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to get active connection: %w", err)
+		return nil, fmt.Errorf("failed to get connection %q: %w", label, err)
+	}
+	return conn, nil
+}
+
+// clientFor returns the cached ServiceNow client for the connection,
+// creating and authenticating one on first use.
+func (s *Service) clientFor(conn *Connection) (*servicenow.SNClient, error) {
+	s.clientsMu.RLock()
+	snClient, ok := s.clients[conn.ID]
+	s.clientsMu.RUnlock()
+	if ok {
+		return snClient, nil
+	}
+
+	s.clientsMu.Lock()
+	defer s.clientsMu.Unlock()
+
+	// Another caller may have created the client while we waited for the lock
+	if snClient, ok := s.clients[conn.ID]; ok {
+		return snClient, nil
 	}
 
 	// Create ServiceNow client
-	snConfig := servicenow.DefaultConfig(conn.InstanceURL)
-	snClient, err := servicenow.NewSNClient(snConfig)
+	snClient, err := s.newSNClient(conn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create ServiceNow client: %w", err)
 	}
@@ -206,9 +467,157 @@ func (s *Service) GetSNClient(ctx context.Context) (servicenow.Client, error) {
 	}
 	snClient.SetAuth(auth)
 
+	s.clients[conn.ID] = snClient
 	return snClient, nil
 }
 
+// invalidateClients drops all cached clients so the next call picks up
+// changed connection settings or credentials.
+func (s *Service) invalidateClients() {
+	s.clientsMu.Lock()
+	defer s.clientsMu.Unlock()
+	s.clients = make(map[uuid.UUID]*servicenow.SNClient)
+
+	// New settings deserve a fresh chance to reach the instance
+	s.breakersMu.Lock()
+	defer s.breakersMu.Unlock()
+	s.breakers = make(map[string]*servicenow.CircuitBreaker)
+
+	// Metrics describe the settings they were gathered under
+	s.metricsMu.Lock()
+	defer s.metricsMu.Unlock()
+	s.metrics = make(map[string]*servicenow.Metrics)
+}
+
+// breaker returns the circuit breaker for a connection label, creating a
+// closed one on first use.
+func (s *Service) breaker(label string) *servicenow.CircuitBreaker {
+	if label == "" {
+		label = DefaultLabel
+	}
+
+	s.breakersMu.Lock()
+	defer s.breakersMu.Unlock()
+	b, ok := s.breakers[label]
+	if !ok {
+		b = servicenow.NewCircuitBreaker(servicenow.DefaultCircuitFailureThreshold, servicenow.DefaultCircuitCooldown)
+		s.breakers[label] = b
+	}
+	return b
+}
+
+// CircuitState returns the state of the circuit breaker guarding requests
+// for a connection label. Connections that have not been used are closed.
+func (s *Service) CircuitState(label string) servicenow.CircuitState {
+	if label == "" {
+		label = DefaultLabel
+	}
+
+	s.breakersMu.Lock()
+	b, ok := s.breakers[label]
+	s.breakersMu.Unlock()
+	if !ok {
+		return servicenow.CircuitClosed
+	}
+	return b.State()
+}
+
+// connectionMetrics returns the request metrics for a connection label,
+// creating empty ones on first use.
+func (s *Service) connectionMetrics(label string) *servicenow.Metrics {
+	if label == "" {
+		label = DefaultLabel
+	}
+
+	s.metricsMu.Lock()
+	defer s.metricsMu.Unlock()
+	m, ok := s.metrics[label]
+	if !ok {
+		m = servicenow.NewMetrics()
+		s.metrics[label] = m
+	}
+	return m
+}
+
+// Metrics returns the ServiceNow request metrics of each connection label
+// used since the connections were last changed.
+func (s *Service) Metrics() map[string]servicenow.MetricsSnapshot {
+	s.metricsMu.Lock()
+	defer s.metricsMu.Unlock()
+
+	snapshots := make(map[string]servicenow.MetricsSnapshot, len(s.metrics))
+	for label, m := range s.metrics {
+		snapshots[label] = m.Snapshot()
+	}
+	return snapshots
+}
+
+// newSNClient creates an unauthenticated ServiceNow client for the connection
+// using the configured table mapping.
+func (s *Service) newSNClient(conn *Connection) (*servicenow.SNClient, error) {
+	snConfig := servicenow.DefaultConfig(conn.InstanceURL)
+	if s.opts.TableMapping != nil {
+		snConfig.TableMapping = s.opts.TableMapping
+	}
+	snConfig.ScriptedAPINamespace = conn.ScriptedAPINamespace
+	snConfig.ScriptedAPIID = conn.ScriptedAPIID
+	snConfig.Scope = conn.Scope
+	snConfig.ProxyURL = s.opts.ProxyURL
+	snConfig.NTLMProxyURL = s.opts.NTLMProxyURL
+	snConfig.NTLMProxyUsername = s.opts.NTLMProxyUsername
+	snConfig.NTLMProxyPassword = s.opts.NTLMProxyPassword
+	snConfig.NTLMProxyDomain = s.opts.NTLMProxyDomain
+	snConfig.UseGzip = s.opts.UseGzip
+	snConfig.StripHTMLOnPull = !s.opts.KeepHTMLOnPull
+	if s.responseCache != nil {
+		snConfig.Cache = s.responseCache
+		snConfig.CacheTTL = s.opts.ResponseCacheTTL
+	}
+	snConfig.CircuitBreaker = s.breaker(conn.Label)
+	snConfig.Metrics = s.connectionMetrics(conn.Label)
+
+	cert, err := s.clientCertificate(conn)
+	if err != nil {
+		return nil, err
+	}
+	snConfig.ClientCertificate = cert
+
+	return servicenow.NewSNClient(snConfig)
+}
+
+// clientCertificate decrypts and parses the connection's client TLS
+// certificate pair. Returns nil if the connection has none.
+func (s *Service) clientCertificate(conn *Connection) (*tls.Certificate, error) {
+	if len(conn.ClientTLSCertEncrypted) == 0 {
+		return nil, nil
+	}
+
+	certPEM, err := s.crypto.Decrypt(conn.ClientTLSCertEncrypted, conn.ClientTLSCertNonce)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDecryptionFailed, err)
+	}
+	keyPEM, err := s.crypto.Decrypt(conn.ClientTLSKeyEncrypted, conn.ClientTLSKeyNonce)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDecryptionFailed, err)
+	}
+
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidClientTLS, err)
+	}
+	return &cert, nil
+}
+
+// tokenCachePath returns the OAuth token cache file of a connection. The
+// name includes a hash of the token URL and client ID so a token is never
+// reused after the connection is pointed at another instance or client.
+// Labels are restricted to [A-Za-z0-9_-], so they are safe in file names.
+func (s *Service) tokenCachePath(conn *Connection) string {
+	sum := sha256.Sum256([]byte(conn.OAuthTokenURL + "\x00" + conn.OAuthClientID))
+	name := fmt.Sprintf("oauth_token_%s_%x.json", conn.Label, sum[:8])
+	return filepath.Join(s.opts.OAuthTokenCacheDir, name)
+}
+
 // getAuthProvider creates an auth provider for the connection.
 func (s *Service) getAuthProvider(conn *Connection) (servicenow.AuthProvider, error) {
 	switch conn.AuthMethod {
@@ -229,11 +638,15 @@ func (s *Service) getAuthProvider(conn *Connection) (servicenow.AuthProvider, er
 		if err != nil {
 			return nil, fmt.Errorf("%w: %v", ErrDecryptionFailed, err)
 		}
-		return &servicenow.OAuthProvider{
+		provider := &servicenow.OAuthProvider{
 			ClientID:     conn.OAuthClientID,
 			ClientSecret: string(secret),
 			TokenURL:     conn.OAuthTokenURL,
-		}, nil
+		}
+		if s.opts.OAuthTokenCacheDir != "" {
+			provider.Cache = servicenow.NewFileTokenCache(s.tokenCachePath(conn))
+		}
+		return provider, nil
 
 	default:
 		return nil, ErrInvalidAuthMethod
@@ -1,7 +1,19 @@
 package connection
 
 import (
+	"bytes"
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -10,9 +22,8 @@ import (
 
 // mockRepository implements Repository for testing.
 type mockRepository struct {
-	activeConn *Connection
-	conns      map[uuid.UUID]*Connection
-	err        error
+	conns map[uuid.UUID]*Connection
+	err   error
 }
 
 func newMockRepository() *mockRepository {
@@ -21,14 +32,21 @@ func newMockRepository() *mockRepository {
 	}
 }
 
-func (m *mockRepository) GetActive(ctx context.Context) (*Connection, error) {
+func (m *mockRepository) GetByLabel(ctx context.Context, label string) (*Connection, error) {
 	if m.err != nil {
 		return nil, m.err
 	}
-	if m.activeConn == nil {
-		return nil, ErrConnectionNotFound
+	for _, conn := range m.conns {
+		if conn.Label == label {
+			return conn, nil
+		}
 	}
-	return m.activeConn, nil
+	return nil, ErrConnectionNotFound
+}
+
+// add stores conn directly, bypassing the service.
+func (m *mockRepository) add(conn *Connection) {
+	m.conns[conn.ID] = conn
 }
 
 func (m *mockRepository) GetByID(ctx context.Context, id uuid.UUID) (*Connection, error) {
@@ -47,13 +65,10 @@ func (m *mockRepository) Upsert(ctx context.Context, conn *Connection) error {
 		return m.err
 	}
 	m.conns[conn.ID] = conn
-	if conn.IsActive {
-		m.activeConn = conn
-	}
 	return nil
 }
 
-func (m *mockRepository) UpdateTestStatus(ctx context.Context, id uuid.UUID, status ConnectionStatus, message string, version string) error {
+func (m *mockRepository) UpdateTestStatus(ctx context.Context, id uuid.UUID, status ConnectionStatus, message string, version string, responseTimeMs int64) error {
 	if m.err != nil {
 		return m.err
 	}
@@ -66,28 +81,27 @@ func (m *mockRepository) UpdateTestStatus(ctx context.Context, id uuid.UUID, sta
 	conn.LastTestStatus = status
 	conn.LastTestMessage = message
 	conn.LastTestInstanceVersion = version
+	conn.LastTestResponseTimeMs = responseTimeMs
 	return nil
 }
 
-func (m *mockRepository) Delete(ctx context.Context, id uuid.UUID) error {
+func (m *mockRepository) UpdateCredentials(ctx context.Context, conn *Connection) error {
 	if m.err != nil {
 		return m.err
 	}
-	delete(m.conns, id)
-	if m.activeConn != nil && m.activeConn.ID == id {
-		m.activeConn = nil
+	if _, ok := m.conns[conn.ID]; !ok {
+		return ErrConnectionNotFound
 	}
+	stored := *conn
+	m.conns[conn.ID] = &stored
 	return nil
 }
 
-func (m *mockRepository) DeactivateAll(ctx context.Context) error {
+func (m *mockRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	if m.err != nil {
 		return m.err
 	}
-	for _, conn := range m.conns {
-		conn.IsActive = false
-	}
-	m.activeConn = nil
+	delete(m.conns, id)
 	return nil
 }
 
@@ -125,10 +139,10 @@ func (m *mockCrypto) Decrypt(ciphertext []byte, nonce []byte) ([]byte, error) {
 func TestService_GetStatus_NoConnection(t *testing.T) {
 	repo := newMockRepository()
 	crypto := &mockCrypto{}
-	svc := NewService(repo, crypto)
+	svc := NewService(repo, crypto, Options{})
 
 	ctx := context.Background()
-	status, err := svc.GetStatus(ctx)
+	status, err := svc.GetStatus(ctx, "")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -144,22 +158,23 @@ func TestService_GetStatus_NoConnection(t *testing.T) {
 func TestService_GetStatus_WithConnection(t *testing.T) {
 	repo := newMockRepository()
 	crypto := &mockCrypto{}
-	svc := NewService(repo, crypto)
+	svc := NewService(repo, crypto, Options{})
 
 	// Set up active connection
 	testTime := time.Now()
-	repo.activeConn = &Connection{
+	repo.add(&Connection{
 		ID:                      uuid.New(),
+		Label:                   DefaultLabel,
 		InstanceURL:             "https://test.service-now.com",
 		AuthMethod:              AuthMethodBasic,
 		IsActive:                true,
 		LastTestAt:              &testTime,
 		LastTestStatus:          StatusSuccess,
 		LastTestInstanceVersion: "Tokyo",
-	}
+	})
 
 	ctx := context.Background()
-	status, err := svc.GetStatus(ctx)
+	status, err := svc.GetStatus(ctx, "")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -181,7 +196,7 @@ func TestService_GetStatus_WithConnection(t *testing.T) {
 func TestService_SaveConfig_BasicAuth(t *testing.T) {
 	repo := newMockRepository()
 	crypto := &mockCrypto{}
-	svc := NewService(repo, crypto)
+	svc := NewService(repo, crypto, Options{})
 
 	input := &ConfigInput{
 		InstanceURL: "https://test.service-now.com",
@@ -192,7 +207,7 @@ func TestService_SaveConfig_BasicAuth(t *testing.T) {
 
 	ctx := context.Background()
 	userID := uuid.New()
-	conn, err := svc.SaveConfig(ctx, input, &userID)
+	conn, _, err := svc.SaveConfig(ctx, input, &userID, SaveOptions{})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -223,7 +238,7 @@ func TestService_SaveConfig_BasicAuth(t *testing.T) {
 func TestService_SaveConfig_OAuth(t *testing.T) {
 	repo := newMockRepository()
 	crypto := &mockCrypto{}
-	svc := NewService(repo, crypto)
+	svc := NewService(repo, crypto, Options{})
 
 	input := &ConfigInput{
 		InstanceURL:       "https://test.service-now.com",
@@ -234,7 +249,7 @@ func TestService_SaveConfig_OAuth(t *testing.T) {
 	}
 
 	ctx := context.Background()
-	conn, err := svc.SaveConfig(ctx, input, nil)
+	conn, _, err := svc.SaveConfig(ctx, input, nil, SaveOptions{})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -256,7 +271,7 @@ func TestService_SaveConfig_OAuth(t *testing.T) {
 func TestService_SaveConfig_ValidationErrors(t *testing.T) {
 	repo := newMockRepository()
 	crypto := &mockCrypto{}
-	svc := NewService(repo, crypto)
+	svc := NewService(repo, crypto, Options{})
 	ctx := context.Background()
 
 	tests := []struct {
@@ -308,7 +323,7 @@ func TestService_SaveConfig_ValidationErrors(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			_, err := svc.SaveConfig(ctx, tt.input, nil)
+			_, _, err := svc.SaveConfig(ctx, tt.input, nil, SaveOptions{})
 			if err != tt.wantErr {
 				t.Errorf("expected error %v, got %v", tt.wantErr, err)
 			}
@@ -319,24 +334,24 @@ func TestService_SaveConfig_ValidationErrors(t *testing.T) {
 func TestService_DeleteConnection(t *testing.T) {
 	repo := newMockRepository()
 	crypto := &mockCrypto{}
-	svc := NewService(repo, crypto)
+	svc := NewService(repo, crypto, Options{})
 
 	// Set up active connection
 	connID := uuid.New()
-	repo.activeConn = &Connection{
+	repo.add(&Connection{
 		ID:       connID,
+		Label:    DefaultLabel,
 		IsActive: true,
-	}
-	repo.conns[connID] = repo.activeConn
+	})
 
 	ctx := context.Background()
-	err := svc.DeleteConnection(ctx)
+	err := svc.DeleteConnection(ctx, "")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
 	// Verify connection is deleted
-	_, err = repo.GetActive(ctx)
+	_, err = repo.GetByLabel(ctx, DefaultLabel)
 	if err != ErrConnectionNotFound {
 		t.Error("expected connection to be deleted")
 	}
@@ -345,10 +360,10 @@ func TestService_DeleteConnection(t *testing.T) {
 func TestService_DeleteConnection_NoConnection(t *testing.T) {
 	repo := newMockRepository()
 	crypto := &mockCrypto{}
-	svc := NewService(repo, crypto)
+	svc := NewService(repo, crypto, Options{})
 
 	ctx := context.Background()
-	err := svc.DeleteConnection(ctx)
+	err := svc.DeleteConnection(ctx, "")
 	if err != nil {
 		t.Errorf("expected no error when deleting non-existent connection, got %v", err)
 	}
@@ -362,7 +377,7 @@ func TestConfigInput_Validate(t *testing.T) {
 		Password:    "password",
 	}
 
-	if err := validBasic.Validate(); err != nil {
+	if err := validBasic.Validate(false); err != nil {
 		t.Errorf("valid basic config should not have error: %v", err)
 	}
 
@@ -374,7 +389,780 @@ func TestConfigInput_Validate(t *testing.T) {
 		OAuthTokenURL:     "https://test.service-now.com/oauth_token.do",
 	}
 
-	if err := validOAuth.Validate(); err != nil {
+	if err := validOAuth.Validate(false); err != nil {
 		t.Errorf("valid oauth config should not have error: %v", err)
 	}
 }
+
+// newTestInstance starts a mock ServiceNow instance that answers connection tests.
+func newTestInstance(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"result":[{"name":"glide.product.version","value":"Washington"}]}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestService_RotateCredentials_BasicAuth(t *testing.T) {
+	server := newTestInstance(t)
+	repo := newMockRepository()
+	crypto := &mockCrypto{}
+	svc := NewService(repo, crypto, Options{})
+	ctx := context.Background()
+
+	creatorID := uuid.New()
+	conn, _, err := svc.SaveConfig(ctx, &ConfigInput{
+		InstanceURL: server.URL,
+		AuthMethod:  AuthMethodBasic,
+		Username:    "admin",
+		Password:    "expired-password",
+	}, &creatorID, SaveOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	oldCiphertext := append([]byte(nil), conn.PasswordEncrypted...)
+	createdAt := conn.CreatedAt
+
+	result, err := svc.RotateCredentials(ctx, "", &CredentialsInput{
+		Username: "admin",
+		Password: "fresh-password",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.Success {
+		t.Errorf("expected post-rotation test to succeed, got %q", result.ErrorMessage)
+	}
+
+	rotated, _ := repo.GetByLabel(ctx, DefaultLabel)
+	if rotated.ID != conn.ID {
+		t.Error("expected connection ID to be preserved")
+	}
+	if bytes.Equal(rotated.PasswordEncrypted, oldCiphertext) {
+		t.Error("expected new ciphertext to differ from the old one")
+	}
+	if !rotated.CreatedAt.Equal(createdAt) {
+		t.Error("expected created_at to be preserved")
+	}
+	if rotated.CreatedBy == nil || *rotated.CreatedBy != creatorID {
+		t.Error("expected created_by to be preserved")
+	}
+	if rotated.LastTestStatus != StatusSuccess {
+		t.Errorf("expected status success after rotation, got %s", rotated.LastTestStatus)
+	}
+}
+
+func TestService_RotateCredentials_OAuth(t *testing.T) {
+	server := newTestInstance(t)
+	repo := newMockRepository()
+	crypto := &mockCrypto{}
+	svc := NewService(repo, crypto, Options{})
+	ctx := context.Background()
+
+	conn, _, err := svc.SaveConfig(ctx, &ConfigInput{
+		InstanceURL:       server.URL,
+		AuthMethod:        AuthMethodOAuth,
+		OAuthClientID:     "client123",
+		OAuthClientSecret: "old-secret",
+		OAuthTokenURL:     server.URL + "/oauth_token.do",
+	}, nil, SaveOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	oldCiphertext := append([]byte(nil), conn.OAuthClientSecretEncrypted...)
+
+	if _, err := svc.RotateCredentials(ctx, "", &CredentialsInput{OAuthClientSecret: "new-secret"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	rotated, _ := repo.GetByLabel(ctx, DefaultLabel)
+	if bytes.Equal(rotated.OAuthClientSecretEncrypted, oldCiphertext) {
+		t.Error("expected new ciphertext to differ from the old one")
+	}
+	if rotated.OAuthClientID != "client123" {
+		t.Errorf("expected client ID to be preserved, got %s", rotated.OAuthClientID)
+	}
+}
+
+func TestService_RotateCredentials_Errors(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("no connection", func(t *testing.T) {
+		svc := NewService(newMockRepository(), &mockCrypto{}, Options{})
+		_, err := svc.RotateCredentials(ctx, "", &CredentialsInput{Username: "admin", Password: "pass"})
+		if err != ErrConnectionNotFound {
+			t.Errorf("expected ErrConnectionNotFound, got %v", err)
+		}
+	})
+
+	t.Run("basic auth missing password", func(t *testing.T) {
+		repo := newMockRepository()
+		svc := NewService(repo, &mockCrypto{}, Options{})
+		repo.Upsert(ctx, &Connection{ID: uuid.New(), Label: DefaultLabel, AuthMethod: AuthMethodBasic, IsActive: true})
+
+		_, err := svc.RotateCredentials(ctx, "", &CredentialsInput{Username: "admin"})
+		if err != ErrPasswordRequired {
+			t.Errorf("expected ErrPasswordRequired, got %v", err)
+		}
+	})
+
+	t.Run("oauth missing secret", func(t *testing.T) {
+		repo := newMockRepository()
+		svc := NewService(repo, &mockCrypto{}, Options{})
+		repo.Upsert(ctx, &Connection{ID: uuid.New(), Label: DefaultLabel, AuthMethod: AuthMethodOAuth, IsActive: true})
+
+		_, err := svc.RotateCredentials(ctx, "", &CredentialsInput{Password: "pass"})
+		if err != ErrClientSecretRequired {
+			t.Errorf("expected ErrClientSecretRequired, got %v", err)
+		}
+	})
+}
+
+func TestService_GetSNClient_ReusesCachedClient(t *testing.T) {
+	server := newTestInstance(t)
+	repo := newMockRepository()
+	svc := NewService(repo, &mockCrypto{}, Options{})
+	ctx := context.Background()
+
+	if _, _, err := svc.SaveConfig(ctx, &ConfigInput{
+		InstanceURL: server.URL,
+		AuthMethod:  AuthMethodBasic,
+		Username:    "admin",
+		Password:    "old-password",
+	}, nil, SaveOptions{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	first, err := svc.GetSNClient(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second, err := svc.GetSNClient(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if first != second {
+		t.Error("expected the same client instance on repeated calls")
+	}
+
+	if _, err := svc.RotateCredentials(ctx, "", &CredentialsInput{Username: "admin", Password: "new-password"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	rotated, err := svc.GetSNClient(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rotated == first {
+		t.Error("expected a new client instance after credential rotation")
+	}
+}
+
+func TestService_GetSNClient_InvalidatedOnSaveAndDelete(t *testing.T) {
+	server := newTestInstance(t)
+	repo := newMockRepository()
+	svc := NewService(repo, &mockCrypto{}, Options{})
+	ctx := context.Background()
+
+	input := &ConfigInput{
+		InstanceURL: server.URL,
+		AuthMethod:  AuthMethodBasic,
+		Username:    "admin",
+		Password:    "password",
+	}
+	if _, _, err := svc.SaveConfig(ctx, input, nil, SaveOptions{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	first, _ := svc.GetSNClient(ctx)
+
+	if _, _, err := svc.SaveConfig(ctx, input, nil, SaveOptions{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second, _ := svc.GetSNClient(ctx)
+	if second == first {
+		t.Error("expected a new client instance after saving a new config")
+	}
+
+	if err := svc.DeleteConnection(ctx, ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(svc.clients) != 0 {
+		t.Errorf("expected client cache to be cleared, got %d entries", len(svc.clients))
+	}
+	if _, err := svc.GetSNClient(ctx); err != ErrConnectionNotFound {
+		t.Errorf("expected ErrConnectionNotFound after delete, got %v", err)
+	}
+}
+
+func TestService_OnConfigSaved(t *testing.T) {
+	svc := NewService(newMockRepository(), &mockCrypto{}, Options{})
+	saved := make(chan string, 1)
+	svc.OnConfigSaved(func(ctx context.Context, label string) {
+		saved <- label
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if _, _, err := svc.SaveConfig(ctx, &ConfigInput{
+		InstanceURL: "https://example.service-now.com",
+		AuthMethod:  AuthMethodBasic,
+		Label:       "secondary",
+		Username:    "admin",
+		Password:    "password",
+	}, nil, SaveOptions{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Hooks outlive the request that saved the connection
+	cancel()
+
+	select {
+	case label := <-saved:
+		if label != "secondary" {
+			t.Errorf("expected label secondary, got %q", label)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the saved hook to run")
+	}
+}
+
+func TestService_Metrics_ResetOnSave(t *testing.T) {
+	svc := NewService(newMockRepository(), &mockCrypto{}, Options{})
+	svc.connectionMetrics("")
+
+	if _, ok := svc.Metrics()[DefaultLabel]; !ok {
+		t.Fatal("expected metrics for the default connection")
+	}
+
+	if _, _, err := svc.SaveConfig(context.Background(), &ConfigInput{
+		InstanceURL: "https://example.service-now.com",
+		AuthMethod:  AuthMethodBasic,
+		Username:    "admin",
+		Password:    "password",
+	}, nil, SaveOptions{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := svc.Metrics(); len(got) != 0 {
+		t.Errorf("expected metrics to be reset, got %+v", got)
+	}
+}
+
+func TestService_SaveConfig_TestOnSave(t *testing.T) {
+	server := newTestInstance(t)
+	repo := newMockRepository()
+	svc := NewService(repo, &mockCrypto{}, Options{})
+	ctx := context.Background()
+
+	conn, result, err := svc.SaveConfig(ctx, &ConfigInput{
+		InstanceURL: server.URL,
+		AuthMethod:  AuthMethodBasic,
+		Username:    "admin",
+		Password:    "password",
+	}, nil, SaveOptions{TestOnSave: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result == nil || !result.Success {
+		t.Fatalf("expected successful test result, got %+v", result)
+	}
+	if conn.LastTestStatus != StatusSuccess {
+		t.Errorf("expected returned status success, got %s", conn.LastTestStatus)
+	}
+	if stored, _ := repo.GetByLabel(ctx, DefaultLabel); stored.LastTestStatus != StatusSuccess {
+		t.Errorf("expected stored status success, got %s", stored.LastTestStatus)
+	}
+}
+
+func TestService_SaveConfig_TestOnSaveFailureKeepsConfig(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	t.Cleanup(server.Close)
+
+	repo := newMockRepository()
+	svc := NewService(repo, &mockCrypto{}, Options{})
+	ctx := context.Background()
+
+	conn, result, err := svc.SaveConfig(ctx, &ConfigInput{
+		InstanceURL: server.URL,
+		AuthMethod:  AuthMethodBasic,
+		Username:    "admin",
+		Password:    "wrong-password",
+	}, nil, SaveOptions{TestOnSave: true})
+	if err != nil {
+		t.Fatalf("expected save to succeed despite failed test, got %v", err)
+	}
+
+	if result == nil || result.Success {
+		t.Fatalf("expected failed test result, got %+v", result)
+	}
+	if result.ErrorMessage == "" {
+		t.Error("expected failure reason in test result")
+	}
+	if conn.LastTestStatus != StatusFailure {
+		t.Errorf("expected returned status failure, got %s", conn.LastTestStatus)
+	}
+
+	stored, err := repo.GetByLabel(ctx, DefaultLabel)
+	if err != nil {
+		t.Fatalf("expected config to be persisted, got %v", err)
+	}
+	if stored.ID != conn.ID || stored.LastTestStatus != StatusFailure {
+		t.Errorf("expected stored connection with failure status, got %+v", stored)
+	}
+}
+
+func TestService_TestConnection_StoresFailureDetails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	t.Cleanup(server.Close)
+
+	repo := newMockRepository()
+	svc := NewService(repo, &mockCrypto{}, Options{})
+	ctx := context.Background()
+
+	if _, _, err := svc.SaveConfig(ctx, &ConfigInput{
+		InstanceURL: server.URL,
+		AuthMethod:  AuthMethodBasic,
+		Username:    "admin",
+		Password:    "wrong-password",
+	}, nil, SaveOptions{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	result, _ := svc.TestConnection(ctx, DefaultLabel)
+	if result == nil || result.Success {
+		t.Fatalf("expected failed test result, got %+v", result)
+	}
+
+	status, err := svc.GetStatus(ctx, DefaultLabel)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if status.LastTestStatus != StatusFailure {
+		t.Errorf("expected status failure, got %s", status.LastTestStatus)
+	}
+	if status.LastTestMessage == "" || status.LastTestMessage != result.ErrorMessage {
+		t.Errorf("expected stored message %q, got %q", result.ErrorMessage, status.LastTestMessage)
+	}
+	if status.LastTestResponseTimeMs != result.ResponseTimeMs {
+		t.Errorf("expected stored response time %d, got %d", result.ResponseTimeMs, status.LastTestResponseTimeMs)
+	}
+}
+
+func TestService_SaveConfig_WithoutTest(t *testing.T) {
+	svc := NewService(newMockRepository(), &mockCrypto{}, Options{})
+
+	conn, result, err := svc.SaveConfig(context.Background(), &ConfigInput{
+		InstanceURL: "https://test.service-now.com",
+		AuthMethod:  AuthMethodBasic,
+		Username:    "admin",
+		Password:    "password",
+	}, nil, SaveOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result != nil {
+		t.Errorf("expected no test result, got %+v", result)
+	}
+	if conn.LastTestStatus != StatusPending {
+		t.Errorf("expected status pending, got %s", conn.LastTestStatus)
+	}
+}
+
+func TestService_SaveConfig_NormalizesInstanceURL(t *testing.T) {
+	repo := newMockRepository()
+	svc := NewService(repo, &mockCrypto{}, Options{Production: true})
+	ctx := context.Background()
+
+	conn, _, err := svc.SaveConfig(ctx, &ConfigInput{
+		InstanceURL: "http://Acme.service-now.com/",
+		AuthMethod:  AuthMethodBasic,
+		Username:    "admin",
+		Password:    "password",
+	}, nil, SaveOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := "https://acme.service-now.com"
+	if conn.InstanceURL != want {
+		t.Errorf("expected returned URL %q, got %q", want, conn.InstanceURL)
+	}
+	if stored, _ := repo.GetByLabel(ctx, DefaultLabel); stored.InstanceURL != want {
+		t.Errorf("expected stored URL %q, got %q", want, stored.InstanceURL)
+	}
+}
+
+func TestService_SaveConfig_RejectsPrivateHostInProduction(t *testing.T) {
+	repo := newMockRepository()
+	svc := NewService(repo, &mockCrypto{}, Options{Production: true})
+	ctx := context.Background()
+
+	_, _, err := svc.SaveConfig(ctx, &ConfigInput{
+		InstanceURL: "https://192.168.1.20",
+		AuthMethod:  AuthMethodBasic,
+		Username:    "admin",
+		Password:    "password",
+	}, nil, SaveOptions{})
+	if !errors.Is(err, ErrInstanceHostNotAllowed) {
+		t.Fatalf("expected ErrInstanceHostNotAllowed, got %v", err)
+	}
+	if _, err := repo.GetByLabel(ctx, DefaultLabel); err != ErrConnectionNotFound {
+		t.Errorf("expected no connection to be stored, got %v", err)
+	}
+}
+
+func TestConfigInput_Validate_ScriptedAPI(t *testing.T) {
+	tests := []struct {
+		name      string
+		namespace string
+		apiID     string
+		wantErr   bool
+	}{
+		{"not set", "", "", false},
+		{"both set", "x_acme_grc", "statements", false},
+		{"namespace only", "x_acme_grc", "", true},
+		{"api id only", "", "statements", true},
+		{"path characters", "x_acme_grc", "statements/v1", true},
+		{"uppercase", "X_ACME_GRC", "statements", true},
+		{"hyphen", "x_acme_grc", "policy-statements", true},
+		{"encoded characters", "x_acme_grc", "statements%2Fv1", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := &ConfigInput{
+				InstanceURL:          "https://test.service-now.com",
+				AuthMethod:           AuthMethodBasic,
+				Username:             "admin",
+				Password:             "password",
+				ScriptedAPINamespace: tt.namespace,
+				ScriptedAPIID:        tt.apiID,
+			}
+			err := input.Validate(false)
+			if tt.wantErr && !errors.Is(err, ErrInvalidScriptedAPI) {
+				t.Errorf("expected ErrInvalidScriptedAPI, got %v", err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestService_TestScriptedAPI(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/x_acme_grc/statements/health" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"result":"ok"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	repo := newMockRepository()
+	svc := NewService(repo, &mockCrypto{}, Options{})
+	ctx := context.Background()
+
+	if _, err := svc.TestScriptedAPI(ctx, "", ScriptedAPITestInput{Path: "health"}); !errors.Is(err, ErrConnectionNotFound) {
+		t.Fatalf("expected ErrConnectionNotFound, got %v", err)
+	}
+
+	_, _, err := svc.SaveConfig(ctx, &ConfigInput{
+		InstanceURL:          server.URL,
+		AuthMethod:           AuthMethodBasic,
+		Username:             "admin",
+		Password:             "password",
+		ScriptedAPINamespace: "x_acme_grc",
+		ScriptedAPIID:        "statements",
+	}, nil, SaveOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	result, err := svc.TestScriptedAPI(ctx, "", ScriptedAPITestInput{Path: "health"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.Success || string(result.Response) != `{"result":"ok"}` {
+		t.Errorf("unexpected result: %+v", result)
+	}
+
+	result, err = svc.TestScriptedAPI(ctx, "", ScriptedAPITestInput{Path: "unknown"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Success || result.ErrorMessage == "" {
+		t.Errorf("expected failed result with message, got %+v", result)
+	}
+}
+
+// newAuthRecordingInstance returns a test instance that records the basic
+// auth username of every request it receives.
+func newAuthRecordingInstance(t *testing.T, users *[]string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, _, _ := r.BasicAuth()
+		*users = append(*users, user)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"result":[{"name":"glide.product.version","value":"Washington"}]}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestService_GetSNClientByLabel_UsesLabelledConnection(t *testing.T) {
+	var prodUsers, stagingUsers []string
+	prod := newAuthRecordingInstance(t, &prodUsers)
+	staging := newAuthRecordingInstance(t, &stagingUsers)
+
+	repo := newMockRepository()
+	svc := NewService(repo, &mockCrypto{}, Options{})
+	ctx := context.Background()
+
+	for _, input := range []*ConfigInput{
+		{Label: "prod", InstanceURL: prod.URL, AuthMethod: AuthMethodBasic, Username: "prod-user", Password: "prod-pass"},
+		{Label: "staging", InstanceURL: staging.URL, AuthMethod: AuthMethodBasic, Username: "staging-user", Password: "staging-pass"},
+	} {
+		if _, _, err := svc.SaveConfig(ctx, input, nil, SaveOptions{}); err != nil {
+			t.Fatalf("unexpected error saving %s: %v", input.Label, err)
+		}
+	}
+
+	client, err := svc.GetSNClientByLabel(ctx, "staging")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result, _ := client.TestConnection(ctx); !result.Success {
+		t.Fatalf("expected staging test to succeed: %s", result.ErrorMessage)
+	}
+
+	if len(prodUsers) != 0 {
+		t.Errorf("expected no requests to prod, got %d", len(prodUsers))
+	}
+	if len(stagingUsers) == 0 {
+		t.Fatal("expected requests to staging")
+	}
+	for _, user := range stagingUsers {
+		if user != "staging-user" {
+			t.Errorf("expected staging credentials, got user %q", user)
+		}
+	}
+
+	if _, err := svc.GetSNClientByLabel(ctx, "dev"); err != ErrConnectionNotFound {
+		t.Errorf("expected ErrConnectionNotFound for unknown label, got %v", err)
+	}
+	if _, err := svc.GetSNClientByLabel(ctx, "no spaces"); err != ErrInvalidLabel {
+		t.Errorf("expected ErrInvalidLabel, got %v", err)
+	}
+}
+
+func TestService_SaveConfig_ReplacesSameLabelOnly(t *testing.T) {
+	server := newTestInstance(t)
+	repo := newMockRepository()
+	svc := NewService(repo, &mockCrypto{}, Options{})
+	ctx := context.Background()
+
+	save := func(label, user string) *Connection {
+		conn, _, err := svc.SaveConfig(ctx, &ConfigInput{
+			Label: label, InstanceURL: server.URL, AuthMethod: AuthMethodBasic, Username: user, Password: "pass",
+		}, nil, SaveOptions{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return conn
+	}
+
+	prod := save("prod", "admin")
+	save("", "admin")
+	updated := save("prod", "other")
+
+	if updated.ID != prod.ID {
+		t.Error("expected saving an existing label to keep its connection ID")
+	}
+	if len(repo.conns) != 2 {
+		t.Errorf("expected 2 connections, got %d", len(repo.conns))
+	}
+	if conn, _ := repo.GetByLabel(ctx, DefaultLabel); conn == nil {
+		t.Error("expected empty label to be saved as the default connection")
+	}
+}
+
+// clientTLSPair returns a PEM-encoded self-signed client certificate and key.
+func clientTLSPair(t *testing.T) (certPEM, keyPEM string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "controlcrud-client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("failed to marshal key: %v", err)
+	}
+
+	certPEM = string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	keyPEM = string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+	return certPEM, keyPEM
+}
+
+func TestConfigInput_Validate_ClientTLS(t *testing.T) {
+	certPEM, keyPEM := clientTLSPair(t)
+	_, otherKeyPEM := clientTLSPair(t)
+
+	tests := []struct {
+		name    string
+		cert    string
+		key     string
+		wantErr error
+	}{
+		{"not set", "", "", nil},
+		{"both set", certPEM, keyPEM, nil},
+		{"cert only", certPEM, "", ErrClientTLSIncomplete},
+		{"key only", "", keyPEM, ErrClientTLSIncomplete},
+		{"not PEM", "not a certificate", "not a key", ErrInvalidClientTLS},
+		{"mismatched key", certPEM, otherKeyPEM, ErrInvalidClientTLS},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := &ConfigInput{
+				InstanceURL:   "https://test.service-now.com",
+				AuthMethod:    AuthMethodBasic,
+				Username:      "admin",
+				Password:      "password",
+				ClientTLSCert: tt.cert,
+				ClientTLSKey:  tt.key,
+			}
+			err := input.Validate(false)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestService_SaveConfig_ClientTLS(t *testing.T) {
+	certPEM, keyPEM := clientTLSPair(t)
+	repo := newMockRepository()
+	service := NewService(repo, &mockCrypto{}, Options{})
+
+	input := &ConfigInput{
+		InstanceURL:   "https://test.service-now.com",
+		AuthMethod:    AuthMethodBasic,
+		Username:      "admin",
+		Password:      "password",
+		ClientTLSCert: certPEM,
+		ClientTLSKey:  keyPEM,
+	}
+	conn, _, err := service.SaveConfig(context.Background(), input, nil, SaveOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(conn.ClientTLSCertEncrypted) == 0 || len(conn.ClientTLSKeyEncrypted) == 0 {
+		t.Fatal("expected client TLS certificate and key to be stored")
+	}
+	if bytes.Equal(conn.ClientTLSKeyEncrypted, []byte(keyPEM)) {
+		t.Error("expected client TLS key to be encrypted")
+	}
+
+	cert, err := service.clientCertificate(conn)
+	if err != nil {
+		t.Fatalf("failed to load client certificate: %v", err)
+	}
+	if cert == nil || len(cert.Certificate) != 1 {
+		t.Fatalf("expected one certificate in chain, got %+v", cert)
+	}
+
+	if _, err := service.GetSNClient(context.Background()); err != nil {
+		t.Errorf("expected client with certificate to be created, got %v", err)
+	}
+}
+
+func TestConfigInput_Validate_Scope(t *testing.T) {
+	tests := []struct {
+		scope   string
+		wantErr error
+	}{
+		{"", nil},
+		{"global", nil},
+		{"a1b2c3d4e5f60718293a4b5c6d7e8f90", nil},
+		{"x_acme grc", ErrInvalidScope},
+		{"sys_scope=global^ORactive=true", ErrInvalidScope},
+	}
+
+	for _, tt := range tests {
+		input := &ConfigInput{
+			InstanceURL: "https://test.service-now.com",
+			AuthMethod:  AuthMethodBasic,
+			Username:    "admin",
+			Password:    "password",
+			Scope:       tt.scope,
+		}
+		if err := input.Validate(false); !errors.Is(err, tt.wantErr) {
+			t.Errorf("scope %q: expected %v, got %v", tt.scope, tt.wantErr, err)
+		}
+	}
+}
+
+func TestService_SaveConfig_ScopesClientQueries(t *testing.T) {
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Query().Get("sysparm_query"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"result":[{"sys_id":"a1b2c3","name":"Acme GRC","scope":"x_acme_grc"}]}`))
+	}))
+	defer server.Close()
+
+	svc := NewService(newMockRepository(), &mockCrypto{}, Options{})
+	ctx := context.Background()
+	input := &ConfigInput{InstanceURL: server.URL, AuthMethod: AuthMethodBasic, Username: "admin", Password: "password", Scope: "a1b2c3"}
+	if _, _, err := svc.SaveConfig(ctx, input, nil, SaveOptions{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	status, err := svc.GetStatus(ctx, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if status.Scope != "a1b2c3" {
+		t.Errorf("expected status scope a1b2c3, got %q", status.Scope)
+	}
+
+	scopes, err := svc.ListScopes(ctx, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(scopes) != 1 || scopes[0].Namespace != "x_acme_grc" {
+		t.Errorf("unexpected scopes: %+v", scopes)
+	}
+
+	client, err := svc.GetSNClient(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	queries = nil
+	if _, err := client.FetchStatements(ctx, "ctrl1", nil, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(queries) != 1 || !strings.HasSuffix(queries[0], "^sys_scope=a1b2c3") {
+		t.Errorf("expected statements query scoped to a1b2c3, got %v", queries)
+	}
+}
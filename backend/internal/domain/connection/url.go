@@ -0,0 +1,74 @@
+package connection
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+)
+
+// normalizeInstanceURL cleans up a user-supplied ServiceNow instance URL and
+// returns it in the canonical form "https://host[:port]".
+//
+// A missing scheme defaults to HTTPS, "http://" is upgraded to HTTPS and
+// trailing slashes are dropped. Paths, queries, fragments and credentials
+// are rejected. In production the host must not be localhost or a private,
+// loopback or link-local IP. Outside production such local hosts are
+// accepted as given, including plain HTTP, so mock instances keep working.
+func normalizeInstanceURL(raw string, production bool) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", ErrInstanceURLRequired
+	}
+	if !strings.Contains(raw, "://") {
+		raw = "https://" + raw
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidInstanceURL, err)
+	}
+
+	scheme := strings.ToLower(u.Scheme)
+	if scheme != "http" && scheme != "https" {
+		return "", fmt.Errorf("%w: unsupported scheme %q", ErrInvalidInstanceURL, u.Scheme)
+	}
+	if u.Host == "" || u.Hostname() == "" {
+		return "", fmt.Errorf("%w: missing host", ErrInvalidInstanceURL)
+	}
+	if u.User != nil {
+		return "", fmt.Errorf("%w: credentials are not allowed in the URL", ErrInvalidInstanceURL)
+	}
+	if strings.Trim(u.Path, "/") != "" {
+		return "", fmt.Errorf("%w: path %q is not allowed", ErrInvalidInstanceURL, u.Path)
+	}
+	if u.RawQuery != "" || u.Fragment != "" {
+		return "", fmt.Errorf("%w: query and fragment are not allowed", ErrInvalidInstanceURL)
+	}
+
+	host := strings.ToLower(u.Host)
+	if isLocalHost(u.Hostname()) {
+		if production {
+			return "", fmt.Errorf("%w: %s", ErrInstanceHostNotAllowed, u.Hostname())
+		}
+		return scheme + "://" + host, nil
+	}
+
+	return "https://" + host, nil
+}
+
+// isLocalHost reports whether host is localhost or a loopback, private,
+// link-local or unspecified IP address. Hostnames are not resolved.
+func isLocalHost(host string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return true
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast()
+}
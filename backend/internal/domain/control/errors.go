@@ -7,4 +7,5 @@ var (
 	ErrNotFound        = errors.New("control not found")
 	ErrInvalidInput    = errors.New("invalid input")
 	ErrSystemNotFound  = errors.New("system not found")
+	ErrInvalidStatus   = errors.New("invalid implementation status")
 )
@@ -1,6 +1,7 @@
 package control
 
 import (
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -31,11 +32,36 @@ type Control struct {
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
+// Implementation status values for a control.
+const (
+	StatusImplemented          = "implemented"
+	StatusPartiallyImplemented = "partially_implemented"
+	StatusNotImplemented       = "not_implemented"
+	StatusNotApplicable        = "not_applicable"
+)
+
+// validImplementationStatuses is the whitelist of accepted implementation statuses.
+var validImplementationStatuses = map[string]bool{
+	StatusImplemented:          true,
+	StatusPartiallyImplemented: true,
+	StatusNotImplemented:       true,
+	StatusNotApplicable:        true,
+}
+
+// IsValidImplementationStatus returns true if the status is an accepted value.
+func IsValidImplementationStatus(status string) bool {
+	return validImplementationStatuses[status]
+}
+
 // ControlWithStats includes statement counts.
 type ControlWithStats struct {
 	Control
 	StatementCount int `json:"statement_count"`
 	ModifiedCount  int `json:"modified_count"`
+
+	// ResolvedResponsibleRole is the name of the user group named by
+	// ResponsibleRole. It is only set when roles are resolved on request.
+	ResolvedResponsibleRole string `json:"resolved_responsible_role,omitempty"`
 }
 
 // ListParams holds parameters for listing controls.
@@ -56,6 +82,33 @@ type ListResult struct {
 	TotalPages int                `json:"total_pages"`
 }
 
+// MinSearchQueryLength is the shortest query accepted by a cross-system
+// control search, so that a search cannot return every control.
+const MinSearchQueryLength = 2
+
+// SearchParams holds parameters for searching controls across all systems.
+type SearchParams struct {
+	Query         string `json:"q"` // Matched against control ID and name
+	ControlFamily string `json:"control_family,omitempty"`
+	Page          int    `json:"page"`
+	PageSize      int    `json:"page_size"`
+}
+
+// SearchEntry is a control found by a search, with the name of its system.
+type SearchEntry struct {
+	ControlWithStats
+	SystemName string `json:"system_name"`
+}
+
+// SearchResult holds the result of searching controls.
+type SearchResult struct {
+	Controls   []SearchEntry `json:"controls"`
+	TotalCount int           `json:"total_count"`
+	Page       int           `json:"page"`
+	PageSize   int           `json:"page_size"`
+	TotalPages int           `json:"total_pages"`
+}
+
 // UpsertInput holds data for creating or updating a control.
 type UpsertInput struct {
 	SystemID             uuid.UUID
@@ -93,6 +146,19 @@ var NIST800_53Families = map[string]string{
 	"SR": "Supply Chain Risk Management",
 }
 
+// NormalizeFamily returns a control family code trimmed and uppercased,
+// e.g. " ac " -> "AC".
+func NormalizeFamily(s string) string {
+	return strings.ToUpper(strings.TrimSpace(s))
+}
+
+// IsKnownFamily returns true if s, once normalized, is a NIST SP 800-53
+// family code.
+func IsKnownFamily(s string) bool {
+	_, ok := NIST800_53Families[NormalizeFamily(s)]
+	return ok
+}
+
 // ExtractControlFamily extracts the family prefix from a control ID.
 // e.g., "AC-1" -> "AC", "SC-7(1)" -> "SC"
 func ExtractControlFamily(controlID string) string {
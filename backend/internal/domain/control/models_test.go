@@ -0,0 +1,45 @@
+package control
+
+import "testing"
+
+func TestNormalizeFamily(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"ac", "AC"},
+		{"Ac", "AC"},
+		{"AC", "AC"},
+		{"  sc\t", "SC"},
+		{"", ""},
+		{"   ", ""},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeFamily(tt.input); got != tt.want {
+			t.Errorf("NormalizeFamily(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestIsKnownFamily(t *testing.T) {
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{"AC", true},
+		{"pt", true},
+		{" Sr ", true},
+		{"", false},
+		{"  ", false},
+		{"A", false},
+		{"XX", false},
+		{"AC-2", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsKnownFamily(tt.input); got != tt.want {
+			t.Errorf("IsKnownFamily(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
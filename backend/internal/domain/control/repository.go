@@ -14,18 +14,33 @@ type Repository interface {
 	// GetBySNSysID retrieves a control by its ServiceNow sys_id and system_id.
 	GetBySNSysID(ctx context.Context, systemID uuid.UUID, snSysID string) (*Control, error)
 
+	// GetByControlIDString retrieves a control of a system by its ServiceNow
+	// control ID, e.g. "AC-1", ignoring case.
+	GetByControlIDString(ctx context.Context, systemID uuid.UUID, controlID string) (*Control, error)
+
 	// List retrieves controls for a system with pagination.
 	List(ctx context.Context, params ListParams) (*ListResult, error)
 
+	// Search finds controls of all systems by control ID or name, ordered by
+	// control ID and system name.
+	Search(ctx context.Context, params SearchParams) (*SearchResult, error)
+
 	// ListBySystem retrieves all controls for a system.
 	ListBySystem(ctx context.Context, systemID uuid.UUID) ([]Control, error)
 
+	// ListWithoutStatements retrieves the controls of a system that have no
+	// statements, ordered by control ID.
+	ListWithoutStatements(ctx context.Context, systemID uuid.UUID) ([]Control, error)
+
 	// Upsert creates or updates a control.
 	Upsert(ctx context.Context, input UpsertInput) (*Control, error)
 
 	// UpsertBatch creates or updates multiple controls.
 	UpsertBatch(ctx context.Context, inputs []UpsertInput) ([]Control, error)
 
+	// UpdateStatus updates only the implementation status of a control.
+	UpdateStatus(ctx context.Context, id uuid.UUID, status string) error
+
 	// Delete removes a control and its statements.
 	Delete(ctx context.Context, id uuid.UUID) error
 
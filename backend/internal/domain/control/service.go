@@ -0,0 +1,118 @@
+package control
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"github.com/controlcrud/backend/internal/domain/audit"
+)
+
+// Service provides business logic for control operations.
+type Service struct {
+	repo         Repository
+	auditService *audit.Service
+	logger       *slog.Logger
+}
+
+// NewService creates a new control service.
+func NewService(repo Repository, auditService *audit.Service, logger *slog.Logger) *Service {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &Service{
+		repo:         repo,
+		auditService: auditService,
+		logger:       logger,
+	}
+}
+
+// GetByID retrieves a control by its ID.
+func (s *Service) GetByID(ctx context.Context, id uuid.UUID) (*Control, error) {
+	c, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if c == nil {
+		return nil, ErrNotFound
+	}
+	return c, nil
+}
+
+// GetByControlIDString retrieves a control of a system by its ServiceNow
+// control ID, e.g. "AC-1". The match ignores case.
+func (s *Service) GetByControlIDString(ctx context.Context, systemID uuid.UUID, controlID string) (*Control, error) {
+	controlID = strings.TrimSpace(controlID)
+	if controlID == "" {
+		return nil, ErrNotFound
+	}
+	c, err := s.repo.GetByControlIDString(ctx, systemID, controlID)
+	if err != nil {
+		return nil, err
+	}
+	if c == nil {
+		return nil, ErrNotFound
+	}
+	return c, nil
+}
+
+// Search finds controls across all systems. The query must be at least
+// MinSearchQueryLength characters.
+func (s *Service) Search(ctx context.Context, params SearchParams) (*SearchResult, error) {
+	params.Query = strings.TrimSpace(params.Query)
+	if utf8.RuneCountInString(params.Query) < MinSearchQueryLength {
+		return nil, fmt.Errorf("%w: q must be at least %d characters", ErrInvalidInput, MinSearchQueryLength)
+	}
+	params.ControlFamily = NormalizeFamily(params.ControlFamily)
+	if params.Page < 1 {
+		params.Page = 1
+	}
+	if params.PageSize < 1 {
+		params.PageSize = 20
+	}
+	return s.repo.Search(ctx, params)
+}
+
+// UpdateStatus sets the local implementation status of a control and
+// records the change in the audit log.
+func (s *Service) UpdateStatus(ctx context.Context, id uuid.UUID, status string) (*Control, error) {
+	if !IsValidImplementationStatus(status) {
+		return nil, ErrInvalidStatus
+	}
+
+	existing, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdateStatus(ctx, id, status); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("updated control status",
+		"id", id,
+		"control_id", existing.ControlID,
+		"from", existing.ImplementationStatus,
+		"to", status)
+
+	if s.auditService != nil {
+		s.auditService.RecordAsync(audit.Event{
+			EventType:  audit.EventTypeEdit,
+			EntityType: "control",
+			EntityID:   id.String(),
+			Action:     "update_status",
+			Status:     "success",
+			Details: map[string]interface{}{
+				"control_id":      existing.ControlID,
+				"previous_status": existing.ImplementationStatus,
+				"new_status":      status,
+			},
+		})
+	}
+
+	return s.GetByID(ctx, id)
+}
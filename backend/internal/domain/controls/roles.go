@@ -0,0 +1,83 @@
+package controls
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/controlcrud/backend/internal/domain/connection"
+	"github.com/controlcrud/backend/internal/domain/control"
+	"github.com/controlcrud/backend/internal/infrastructure/servicenow"
+)
+
+// ResolveResponsibleRoles sets the ResolvedResponsibleRole of each control.
+// A responsible role that looks like a UUID or ServiceNow sys_id is taken
+// as a user group ID and resolved to the group's name; any other role is
+// already a name and is used as is. Roles naming a group that does not
+// exist are left unresolved.
+func (s *Service) ResolveResponsibleRoles(ctx context.Context, controls []*control.ControlWithStats) error {
+	if !hasGroupIDRole(controls) {
+		resolveResponsibleRoles(ctx, nil, controls)
+		return nil
+	}
+
+	snClient, err := s.connService.GetSNClient(ctx)
+	if err != nil {
+		if errors.Is(err, connection.ErrConnectionNotFound) {
+			return ErrNoConnection
+		}
+		return fmt.Errorf("%w: %v", ErrServiceNowError, err)
+	}
+	return resolveResponsibleRoles(ctx, snClient, controls)
+}
+
+// resolveResponsibleRoles resolves the roles of controls, looking each
+// group ID up once. snClient may be nil if no role is a group ID.
+func resolveResponsibleRoles(ctx context.Context, snClient servicenow.Client, controls []*control.ControlWithStats) error {
+	names := make(map[string]string)
+	for _, c := range controls {
+		role := c.ResponsibleRole
+		if !isGroupID(role) {
+			c.ResolvedResponsibleRole = role
+			continue
+		}
+
+		name, ok := names[role]
+		if !ok {
+			group, err := snClient.GetUserGroupByID(ctx, role)
+			switch {
+			case err == nil:
+				name = group.Name
+			case errors.Is(err, servicenow.ErrNotFound):
+				// Leave the role unresolved
+			case errors.Is(err, servicenow.ErrAuthFailed):
+				return ErrAuthFailed
+			default:
+				return fmt.Errorf("%w: %v", ErrServiceNowError, err)
+			}
+			names[role] = name
+		}
+		c.ResolvedResponsibleRole = name
+	}
+	return nil
+}
+
+// hasGroupIDRole returns true if any control's responsible role is a
+// user group ID.
+func hasGroupIDRole(controls []*control.ControlWithStats) bool {
+	for _, c := range controls {
+		if isGroupID(c.ResponsibleRole) {
+			return true
+		}
+	}
+	return false
+}
+
+// isGroupID returns true if role looks like a UUID or a 32 character
+// ServiceNow sys_id rather than a group name.
+func isGroupID(role string) bool {
+	_, err := uuid.Parse(role)
+	return err == nil
+}
@@ -0,0 +1,127 @@
+package controls
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/controlcrud/backend/internal/domain/control"
+	"github.com/controlcrud/backend/internal/infrastructure/servicenow"
+)
+
+// groupClient serves user groups by sys_id and counts the lookups.
+type groupClient struct {
+	servicenow.Client
+	groups  map[string]string
+	err     error
+	lookups int
+}
+
+func (c *groupClient) GetUserGroupByID(ctx context.Context, sysID string) (*servicenow.UserGroup, error) {
+	c.lookups++
+	if c.err != nil {
+		return nil, c.err
+	}
+	name, ok := c.groups[sysID]
+	if !ok {
+		return nil, fmt.Errorf("user group %s: %w", sysID, servicenow.ErrNotFound)
+	}
+	return &servicenow.UserGroup{SysID: sysID, Name: name}, nil
+}
+
+func controlsWithRoles(roles ...string) []*control.ControlWithStats {
+	controls := make([]*control.ControlWithStats, len(roles))
+	for i, role := range roles {
+		controls[i] = &control.ControlWithStats{}
+		controls[i].ResponsibleRole = role
+	}
+	return controls
+}
+
+func TestResolveResponsibleRoles(t *testing.T) {
+	const (
+		secOps  = "a1b2c3d4e5f6a7b8c9d0e1f2a3b4c5d6"
+		network = "6f1d2c3b-4a59-4e8f-9a7b-1c2d3e4f5a6b"
+		missing = "ffffffffffffffffffffffffffffffff"
+	)
+	client := &groupClient{groups: map[string]string{secOps: "Security Operations", network: "Network Team"}}
+
+	tests := []struct {
+		name string
+		role string
+		want string
+	}{
+		{"sys_id", secOps, "Security Operations"},
+		{"uuid", network, "Network Team"},
+		{"name", "Facilities", "Facilities"},
+		{"hex-like name", "deadbeef", "deadbeef"},
+		{"missing group", missing, ""},
+		{"no role", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controls := controlsWithRoles(tt.role)
+			if err := resolveResponsibleRoles(context.Background(), client, controls); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := controls[0].ResolvedResponsibleRole; got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+			if controls[0].ResponsibleRole != tt.role {
+				t.Errorf("expected the raw role kept, got %q", controls[0].ResponsibleRole)
+			}
+		})
+	}
+}
+
+func TestResolveResponsibleRoles_LooksUpEachGroupOnce(t *testing.T) {
+	const secOps = "a1b2c3d4e5f6a7b8c9d0e1f2a3b4c5d6"
+	client := &groupClient{groups: map[string]string{secOps: "Security Operations"}}
+
+	controls := controlsWithRoles(secOps, "Facilities", secOps, secOps)
+	if err := resolveResponsibleRoles(context.Background(), client, controls); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if client.lookups != 1 {
+		t.Errorf("expected one lookup, got %d", client.lookups)
+	}
+	for i, want := range []string{"Security Operations", "Facilities", "Security Operations", "Security Operations"} {
+		if got := controls[i].ResolvedResponsibleRole; got != want {
+			t.Errorf("control %d: expected %q, got %q", i, want, got)
+		}
+	}
+}
+
+func TestResolveResponsibleRoles_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"auth failed", fmt.Errorf("fetch user group: %w", servicenow.ErrAuthFailed), ErrAuthFailed},
+		{"unreachable", fmt.Errorf("fetch user group: %w", servicenow.ErrConnectionFailed), ErrServiceNowError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &groupClient{err: tt.err}
+			err := resolveResponsibleRoles(context.Background(), client, controlsWithRoles("a1b2c3d4e5f6a7b8c9d0e1f2a3b4c5d6"))
+			if !errors.Is(err, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestService_ResolveResponsibleRoles_NamesOnly(t *testing.T) {
+	// Names need no ServiceNow connection
+	controls := controlsWithRoles("Facilities", "")
+	if err := NewService(nil).ResolveResponsibleRoles(context.Background(), controls); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := controls[0].ResolvedResponsibleRole; got != "Facilities" {
+		t.Errorf("expected the name used as is, got %q", got)
+	}
+}
@@ -61,9 +61,10 @@ func (s *Service) ListPolicyStatements(ctx context.Context, params *ListParams)
 	}
 
 	// Transform to domain models
+	loc := instanceTimezone(ctx, snClient)
 	items := make([]PolicyStatement, len(response.Records))
 	for i, record := range response.Records {
-		items[i] = transformPolicyStatement(record)
+		items[i] = transformPolicyStatement(record, loc)
 	}
 
 	// Calculate pagination
@@ -104,7 +105,7 @@ func (s *Service) GetPolicyStatement(ctx context.Context, id string) (*PolicySta
 		return nil, fmt.Errorf("%w: %v", ErrServiceNowError, err)
 	}
 
-	result := transformPolicyStatement(*record)
+	result := transformPolicyStatement(*record, instanceTimezone(ctx, snClient))
 	return &result, nil
 }
 
@@ -122,7 +123,7 @@ func (s *Service) GetPolicyStatement(ctx context.Context, id string) (*PolicySta
 // TO SWITCH TO IRM: Remove the fallback logic below - IRM records have proper values
 // See: 0xcc/docs/INCIDENT_TO_IRM_MIGRATION.md for complete migration guide
 // =============================================================================
-func transformPolicyStatement(record servicenow.PolicyStatementRecord) PolicyStatement {
+func transformPolicyStatement(record servicenow.PolicyStatementRecord, loc *time.Location) PolicyStatement {
 	// ==========================================================================
 	// DEMO FALLBACK #1: Name
 	// Incidents don't have "name" field - use short_description instead
@@ -157,12 +158,12 @@ func transformPolicyStatement(record servicenow.PolicyStatementRecord) PolicySta
 
 	// Parse timestamps
 	if record.SysCreatedOn != "" {
-		if t, err := parseServiceNowTime(record.SysCreatedOn); err == nil {
+		if t, err := servicenow.ParseSNTime(record.SysCreatedOn, loc); err == nil {
 			ps.CreatedAt = t
 		}
 	}
 	if record.SysUpdatedOn != "" {
-		if t, err := parseServiceNowTime(record.SysUpdatedOn); err == nil {
+		if t, err := servicenow.ParseSNTime(record.SysUpdatedOn, loc); err == nil {
 			ps.UpdatedAt = t
 		}
 	}
@@ -170,20 +171,13 @@ func transformPolicyStatement(record servicenow.PolicyStatementRecord) PolicySta
 	return ps
 }
 
-// parseServiceNowTime parses a ServiceNow timestamp string.
-func parseServiceNowTime(s string) (time.Time, error) {
-	// ServiceNow uses format: 2024-01-15 10:30:00
-	layouts := []string{
-		"2006-01-02 15:04:05",
-		"2006-01-02T15:04:05Z",
-		time.RFC3339,
-	}
-
-	for _, layout := range layouts {
-		if t, err := time.Parse(layout, s); err == nil {
-			return t, nil
-		}
+// instanceTimezone returns the timezone of the instance behind snClient.
+// Timestamps are only displayed here, so a failed lookup falls back to UTC
+// instead of failing the request.
+func instanceTimezone(ctx context.Context, snClient servicenow.Client) *time.Location {
+	loc, err := snClient.GetInstanceTimezone(ctx)
+	if err != nil {
+		return time.UTC
 	}
-
-	return time.Time{}, fmt.Errorf("unable to parse time: %s", s)
+	return loc
 }
@@ -21,6 +21,21 @@ var (
 	// ErrJobCancelled is returned when a job is cancelled during execution.
 	ErrJobCancelled = errors.New("job cancelled")
 
-	// ErrConcurrentJob is returned when another pull job is already running.
-	ErrConcurrentJob = errors.New("another pull job is already running")
+	// ErrJobNotPaused is returned when resuming a job that is not paused.
+	ErrJobNotPaused = errors.New("job is not paused")
+
+	// ErrJobNotRunning is returned when pausing a job that is neither
+	// running on this server nor pending, such as a job claimed by a
+	// dispatcher while the pause was being applied.
+	ErrJobNotRunning = errors.New("job is not running")
+
+	// ErrSystemLocked is returned when another pull job is pulling a system.
+	ErrSystemLocked = errors.New("system is being pulled by another job")
+
+	// ErrConcurrentJob is returned when the pull job concurrency limit is reached.
+	ErrConcurrentJob = errors.New("pull job concurrency limit reached")
+
+	// ErrShutdownTimeout is returned when running jobs do not stop within
+	// the shutdown timeout.
+	ErrShutdownTimeout = errors.New("timed out waiting for pull jobs to stop")
 )
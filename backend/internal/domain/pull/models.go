@@ -1,9 +1,13 @@
 package pull
 
 import (
+	"encoding/json"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/controlcrud/backend/internal/domain/statement"
 )
 
 // JobStatus represents the current state of a pull job.
@@ -15,6 +19,7 @@ const (
 	JobStatusCompleted JobStatus = "completed"
 	JobStatusFailed    JobStatus = "failed"
 	JobStatusCancelled JobStatus = "cancelled"
+	JobStatusPaused    JobStatus = "paused"
 )
 
 // IsActive returns true if the job is still in progress.
@@ -31,7 +36,112 @@ type Progress struct {
 	TotalStatements    int      `json:"total_statements"`
 	CompletedStatements int     `json:"completed_statements"`
 	CurrentSystem      string   `json:"current_system,omitempty"`
-	Errors             []string `json:"errors,omitempty"`
+
+	// Events logs each step of the job in order, up to MaxPullEvents.
+	// DroppedEvents counts the upsert events dropped to stay within it.
+	Events        []PullEventLog `json:"events,omitempty"`
+	DroppedEvents int            `json:"dropped_events,omitempty"`
+
+	// CompletedSystemIDs lists the systems already pulled, so a resumed
+	// job continues with the first system not in this list.
+	CompletedSystemIDs []uuid.UUID `json:"completed_system_ids,omitempty"`
+
+	// RetryAttempts counts the retries made for each system whose pull
+	// failed at least once.
+	RetryAttempts map[uuid.UUID]int `json:"retry_attempts,omitempty"`
+}
+
+// Errors returns the messages of the error and warning events, in order.
+func (p *Progress) Errors() []string {
+	var errs []string
+	for _, event := range p.Events {
+		switch event.EventType {
+		case EventError:
+			errs = append(errs, event.Message)
+		case EventWarning:
+			errs = append(errs, "warning: "+event.Message)
+		}
+	}
+	return errs
+}
+
+// appendEvent adds an event to the log. A full log drops its oldest upsert
+// event, or its oldest event when it holds only errors and warnings.
+func (p *Progress) appendEvent(event PullEventLog) {
+	if len(p.Events) >= MaxPullEvents {
+		drop := 0
+		for i, e := range p.Events {
+			if e.EventType != EventError && e.EventType != EventWarning {
+				drop = i
+				break
+			}
+		}
+		p.Events = slices.Delete(p.Events, drop, drop+1)
+		p.DroppedEvents++
+	}
+	p.Events = append(p.Events, event)
+}
+
+// UnmarshalJSON reads progress saved before the event log, turning its
+// flat error list into error events.
+func (p *Progress) UnmarshalJSON(data []byte) error {
+	type progress Progress
+	var v struct {
+		progress
+		LegacyErrors []string `json:"errors"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*p = Progress(v.progress)
+	for _, msg := range v.LegacyErrors {
+		p.Events = append(p.Events, PullEventLog{EventType: EventError, Message: msg})
+	}
+	return nil
+}
+
+// MaxPullEvents caps the event log kept in a job's progress.
+const MaxPullEvents = 1000
+
+// Pull event types.
+const (
+	EventControlUpserted   = "control_upserted"
+	EventStatementUpserted = "statement_upserted"
+	EventConflictDetected  = "conflict_detected"
+	EventWarning           = "warning"
+	EventError             = "error"
+)
+
+// IsValidEventType returns true if t is a known pull event type.
+func IsValidEventType(t string) bool {
+	switch t {
+	case EventControlUpserted, EventStatementUpserted, EventConflictDetected, EventWarning, EventError:
+		return true
+	}
+	return false
+}
+
+// PullEventLog is one step of a pull job. ControlID and StatementNumber are
+// the ServiceNow identifiers of the record concerned, when there is one.
+type PullEventLog struct {
+	Timestamp       time.Time `json:"timestamp"`
+	SystemName      string    `json:"system_name,omitempty"`
+	ControlID       string    `json:"control_id,omitempty"`
+	StatementNumber string    `json:"statement_number,omitempty"`
+	EventType       string    `json:"event_type"`
+	Message         string    `json:"message"`
+}
+
+// EventFilter selects pull job events. Empty fields match every event.
+type EventFilter struct {
+	SystemName string
+	EventType  string
+}
+
+// Matches returns true if the event passes the filter.
+func (f EventFilter) Matches(event PullEventLog) bool {
+	return (f.SystemName == "" || event.SystemName == f.SystemName) &&
+		(f.EventType == "" || event.EventType == f.EventType)
 }
 
 // Job represents a background pull operation.
@@ -45,12 +155,15 @@ type Job struct {
 	CompletedAt *time.Time `json:"completed_at,omitempty"`
 	CreatedAt   time.Time  `json:"created_at"`
 	CreatedBy   *uuid.UUID `json:"created_by,omitempty"`
+
+	ConnectionLabel string `json:"connection_label"` // ServiceNow connection pulled from
 }
 
 // CreateInput holds data for creating a new pull job.
 type CreateInput struct {
-	SystemIDs []uuid.UUID
-	CreatedBy *uuid.UUID
+	SystemIDs       []uuid.UUID
+	CreatedBy       *uuid.UUID
+	ConnectionLabel string
 }
 
 // UpdateInput holds data for updating job status and progress.
@@ -61,6 +174,23 @@ type UpdateInput struct {
 	Error    string
 }
 
+// PullListFilter holds parameters for listing pull jobs.
+type PullListFilter struct {
+	Status   *JobStatus
+	SystemID *uuid.UUID // Jobs that included this system
+	Since    *time.Time // Jobs created at or after this time
+	Limit    int
+}
+
+// IsValid returns true if the status is a known job status.
+func (s JobStatus) IsValid() bool {
+	switch s {
+	case JobStatusPending, JobStatusRunning, JobStatusCompleted, JobStatusFailed, JobStatusCancelled, JobStatusPaused:
+		return true
+	}
+	return false
+}
+
 // CalculateOverallProgress returns the completion percentage.
 func (p *Progress) CalculateOverallProgress() int {
 	total := p.TotalSystems + p.TotalControls + p.TotalStatements
@@ -71,3 +201,74 @@ func (p *Progress) CalculateOverallProgress() int {
 	}
 	return int(float64(completed) / float64(total) * 100)
 }
+
+// Options configures optional pull behaviour.
+type Options struct {
+	// ConflictStrategy is the default handling of conflicts detected while
+	// pulling statements. Systems may override it. Empty means manual.
+	ConflictStrategy statement.ConflictStrategy
+
+	// MaxConcurrentJobs caps how many pull jobs may be pending or running
+	// at once. Zero or less means 1.
+	MaxConcurrentJobs int
+
+	// ValidateRoles checks each pulled control's responsible role against
+	// the ServiceNow user groups and adds a warning to the job progress
+	// for roles that are not found.
+	ValidateRoles bool
+
+	// ConflictWebhook delivers a statement.ConflictEvent to WebhookURL
+	// whenever a pull puts a statement in conflict. Nil disables
+	// notifications.
+	ConflictWebhook statement.WebhookSender
+	WebhookURL      string
+
+	// Retry controls how often a system whose pull failed is tried again
+	// before its error is recorded. The zero value does not retry.
+	Retry RetryPolicy
+
+	// Parallelism is how many systems of a job are pulled at once. Zero or
+	// less means 1.
+	Parallelism int
+
+	// ShutdownTimeout is how long Shutdown waits for running jobs to stop.
+	// Zero or less means DefaultShutdownTimeout.
+	ShutdownTimeout time.Duration
+}
+
+// DefaultShutdownTimeout is how long Shutdown waits for running jobs when
+// Options.ShutdownTimeout is not set.
+const DefaultShutdownTimeout = 10 * time.Second
+
+// RetryPolicy retries a failed system pull up to MaxRetries times. The
+// first retry waits RetryDelay and each further retry waits twice as long.
+type RetryPolicy struct {
+	MaxRetries int
+	RetryDelay time.Duration
+}
+
+// backoff returns the delay before the given retry, counting from 1.
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	return p.RetryDelay << (retry - 1)
+}
+
+func (o Options) parallelism() int {
+	if o.Parallelism < 1 {
+		return 1
+	}
+	return o.Parallelism
+}
+
+func (o Options) shutdownTimeout() time.Duration {
+	if o.ShutdownTimeout <= 0 {
+		return DefaultShutdownTimeout
+	}
+	return o.ShutdownTimeout
+}
+
+func (o Options) maxConcurrentJobs() int {
+	if o.MaxConcurrentJobs < 1 {
+		return 1
+	}
+	return o.MaxConcurrentJobs
+}
@@ -0,0 +1,101 @@
+package pull
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// jobProgress guards the Progress of a running job, which every system
+// pulled in parallel updates.
+type jobProgress struct {
+	mu       sync.Mutex
+	progress Progress
+
+	// saveMu orders saves so an older snapshot never overwrites a newer one.
+	saveMu sync.Mutex
+}
+
+// update applies fn to the progress under the lock.
+func (p *jobProgress) update(fn func(progress *Progress)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	fn(&p.progress)
+}
+
+// addEvent appends an event, stamped with the current time, to the job's
+// event log.
+func (p *jobProgress) addEvent(event PullEventLog) {
+	event.Timestamp = time.Now().UTC()
+	p.update(func(progress *Progress) {
+		progress.appendEvent(event)
+	})
+}
+
+// addError logs an error of the named system; systemName may be empty.
+func (p *jobProgress) addError(systemName, msg string) {
+	p.addEvent(PullEventLog{SystemName: systemName, EventType: EventError, Message: msg})
+}
+
+// addWarning logs a warning of the named system.
+func (p *jobProgress) addWarning(systemName, msg string) {
+	p.addEvent(PullEventLog{SystemName: systemName, EventType: EventWarning, Message: msg})
+}
+
+// snapshot returns a copy of the progress that shares no memory with it.
+func (p *jobProgress) snapshot() Progress {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	snap := p.progress
+	snap.Events = append([]PullEventLog(nil), p.progress.Events...)
+	snap.CompletedSystemIDs = append([]uuid.UUID(nil), p.progress.CompletedSystemIDs...)
+	if p.progress.RetryAttempts != nil {
+		snap.RetryAttempts = make(map[uuid.UUID]int, len(p.progress.RetryAttempts))
+		for id, n := range p.progress.RetryAttempts {
+			snap.RetryAttempts[id] = n
+		}
+	}
+	return snap
+}
+
+// systemCounts are the control and statement counts that one attempt at
+// pulling a system added to the job's progress.
+type systemCounts struct {
+	totalControls       int
+	completedControls   int
+	totalStatements     int
+	completedStatements int
+}
+
+// applyTo adds the counts to progress, or subtracts them when sign is -1.
+func (c systemCounts) applyTo(progress *Progress, sign int) {
+	progress.TotalControls += sign * c.totalControls
+	progress.CompletedControls += sign * c.completedControls
+	progress.TotalStatements += sign * c.totalStatements
+	progress.CompletedStatements += sign * c.completedStatements
+}
+
+// addCounts adds delta to the progress and to the attempt's counts.
+func (p *jobProgress) addCounts(attempt *systemCounts, delta systemCounts) {
+	attempt.totalControls += delta.totalControls
+	attempt.completedControls += delta.completedControls
+	attempt.totalStatements += delta.totalStatements
+	attempt.completedStatements += delta.completedStatements
+	p.update(func(progress *Progress) { delta.applyTo(progress, 1) })
+}
+
+// removeCounts takes back the counts of a failed attempt before the system
+// is pulled again.
+func (p *jobProgress) removeCounts(attempt systemCounts) {
+	p.update(func(progress *Progress) { attempt.applyTo(progress, -1) })
+}
+
+// saveProgress stores the current progress of the job.
+func (s *Service) saveProgress(ctx context.Context, jobID uuid.UUID, progress *jobProgress) {
+	progress.saveMu.Lock()
+	defer progress.saveMu.Unlock()
+	s.updateProgress(ctx, jobID, progress.snapshot())
+}
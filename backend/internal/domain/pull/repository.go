@@ -23,9 +23,25 @@ type Repository interface {
 	// SetStatus sets the job status with optional error message.
 	SetStatus(ctx context.Context, id uuid.UUID, status JobStatus, errorMsg string) error
 
-	// HasActiveJob returns true if there's an active (pending/running) job.
-	HasActiveJob(ctx context.Context) (bool, error)
+	// PausePendingJob sets a pending job to paused. Returns false if the
+	// job is no longer pending.
+	PausePendingJob(ctx context.Context, id uuid.UUID) (bool, error)
 
-	// List retrieves pull jobs with optional status filter.
-	List(ctx context.Context, status *JobStatus, limit int) ([]Job, error)
+	// ClaimPendingJobs marks up to limit pending jobs as running and returns
+	// them oldest first. A claimed job is not returned to other callers.
+	ClaimPendingJobs(ctx context.Context, limit int) ([]Job, error)
+
+	// CountActiveJobs returns the number of pending or running jobs.
+	CountActiveJobs(ctx context.Context) (int, error)
+
+	// AcquireSystemLock locks a system for the given job. Returns false if
+	// another job holds a lock on the system that has not expired.
+	AcquireSystemLock(ctx context.Context, systemID, jobID uuid.UUID) (bool, error)
+
+	// ReleaseSystemLock releases the job's lock on a system. It does nothing
+	// if the lock expired and was taken over by another job.
+	ReleaseSystemLock(ctx context.Context, systemID, jobID uuid.UUID) error
+
+	// List retrieves pull jobs matching the filter, newest first.
+	List(ctx context.Context, filter PullListFilter) ([]Job, error)
 }
@@ -4,20 +4,23 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/google/uuid"
+	"golang.org/x/sync/errgroup"
 
+	"github.com/controlcrud/backend/internal/domain/connection"
 	"github.com/controlcrud/backend/internal/domain/control"
 	"github.com/controlcrud/backend/internal/domain/statement"
 	"github.com/controlcrud/backend/internal/domain/system"
 	"github.com/controlcrud/backend/internal/infrastructure/servicenow"
 )
 
-// SNClientProvider provides a ServiceNow client dynamically.
+// SNClientProvider provides a ServiceNow client for a labelled connection.
 type SNClientProvider interface {
-	GetSNClient(ctx context.Context) (servicenow.Client, error)
+	GetSNClientByLabel(ctx context.Context, label string) (servicenow.Client, error)
 }
 
 // Service provides business logic for pull operations.
@@ -27,13 +30,30 @@ type Service struct {
 	controlRepo  control.Repository
 	stmtRepo     statement.Repository
 	snClientGetter SNClientProvider
+	opts         Options
 	logger       *slog.Logger
 
 	// Active job tracking for cancellation
 	mu           sync.RWMutex
 	cancelFuncs  map[uuid.UUID]context.CancelFunc
+
+	// pauseSignals holds a channel per running job that PauseJob closes to
+	// stop the job after the systems in progress. Guarded by mu.
+	pauseSignals map[uuid.UUID]chan struct{}
+
+	// running counts the jobs executing on this instance. Jobs are added
+	// under mu, and only while shuttingDown is unset.
+	running      sync.WaitGroup
+	shuttingDown bool
+
+	// wake nudges the dispatcher when a job is queued or finishes.
+	wake chan struct{}
 }
 
+// dispatchInterval is how often the dispatcher polls for pending jobs when
+// it has not been woken, so jobs queued by another instance still start.
+const dispatchInterval = 5 * time.Second
+
 // NewService creates a new pull service.
 func NewService(
 	pullRepo Repository,
@@ -41,6 +61,7 @@ func NewService(
 	controlRepo control.Repository,
 	stmtRepo statement.Repository,
 	snClientGetter SNClientProvider,
+	opts Options,
 	logger *slog.Logger,
 ) *Service {
 	if logger == nil {
@@ -52,23 +73,33 @@ func NewService(
 		controlRepo:    controlRepo,
 		stmtRepo:       stmtRepo,
 		snClientGetter: snClientGetter,
+		opts:           opts,
 		logger:         logger,
 		cancelFuncs:    make(map[uuid.UUID]context.CancelFunc),
+		pauseSignals:   make(map[uuid.UUID]chan struct{}),
+		wake:           make(chan struct{}, 1),
 	}
 }
 
-// StartPull creates a new pull job and starts execution asynchronously.
-func (s *Service) StartPull(ctx context.Context, systemIDs []uuid.UUID) (*Job, error) {
+// StartPull queues a new pull job from the labelled ServiceNow connection.
+// The dispatcher started by RunDispatcher runs it once a concurrency slot
+// is free.
+func (s *Service) StartPull(ctx context.Context, systemIDs []uuid.UUID, connectionLabel string) (*Job, error) {
 	if len(systemIDs) == 0 {
 		return nil, ErrInvalidInput
 	}
 
-	// Check for existing active jobs
-	hasActive, err := s.pullRepo.HasActiveJob(ctx)
+	connectionLabel, err := connection.NormalizeLabel(connectionLabel)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidInput, err)
+	}
+
+	// Enforce the concurrency limit on pending and running jobs
+	active, err := s.pullRepo.CountActiveJobs(ctx)
 	if err != nil {
 		return nil, err
 	}
-	if hasActive {
+	if active >= s.opts.maxConcurrentJobs() {
 		return nil, ErrConcurrentJob
 	}
 
@@ -85,20 +116,124 @@ func (s *Service) StartPull(ctx context.Context, systemIDs []uuid.UUID) (*Job, e
 
 	// Create the job
 	job, err := s.pullRepo.Create(ctx, CreateInput{
-		SystemIDs: systemIDs,
+		SystemIDs:       systemIDs,
+		ConnectionLabel: connectionLabel,
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	s.logger.Info("created pull job", "job_id", job.ID, "system_count", len(systemIDs))
-
-	// Start execution asynchronously
-	go s.executePull(job.ID, systemIDs)
+	s.logger.Info("queued pull job", "job_id", job.ID, "system_count", len(systemIDs), "connection", connectionLabel)
+	s.notify()
 
 	return job, nil
 }
 
+// RunDispatcher starts pending pull jobs, oldest first, keeping at most
+// Options.MaxConcurrentJobs running at once. It blocks until ctx is done.
+func (s *Service) RunDispatcher(ctx context.Context) {
+	ticker := time.NewTicker(dispatchInterval)
+	defer ticker.Stop()
+
+	for {
+		s.dispatch(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// dispatch claims as many pending jobs as there are free slots and starts them.
+func (s *Service) dispatch(ctx context.Context) {
+	s.mu.RLock()
+	slots := s.opts.maxConcurrentJobs() - len(s.cancelFuncs)
+	if s.shuttingDown {
+		slots = 0
+	}
+	s.mu.RUnlock()
+	if slots <= 0 {
+		return
+	}
+
+	jobs, err := s.pullRepo.ClaimPendingJobs(ctx, slots)
+	if err != nil {
+		s.logger.Error("failed to claim pending pull jobs", "error", err)
+		return
+	}
+
+	for _, job := range jobs {
+		jobCtx, cancel := context.WithCancel(context.Background())
+
+		// Register before starting so the next dispatch sees the slot as taken
+		s.mu.Lock()
+		if s.shuttingDown {
+			// Claimed while Shutdown began; the job runs after a restart
+			s.mu.Unlock()
+			cancel()
+			if err := s.pullRepo.SetStatus(ctx, job.ID, JobStatusPending, ""); err != nil {
+				s.logger.Error("failed to requeue pull job", "job_id", job.ID, "error", err)
+			}
+			continue
+		}
+		s.cancelFuncs[job.ID] = cancel
+		s.pauseSignals[job.ID] = make(chan struct{})
+		s.running.Add(1)
+		s.mu.Unlock()
+
+		s.logger.Info("starting pull job", "job_id", job.ID, "system_count", len(job.SystemIDs))
+		go func() {
+			defer s.running.Done()
+			s.executePull(jobCtx, job)
+		}()
+	}
+}
+
+// Shutdown stops the pull jobs running on this instance and waits up to
+// Options.ShutdownTimeout for them to finish, so none outlives the
+// database connection. Systems being pulled are interrupted; each job
+// saves its progress and is left paused, to be resumed after a restart.
+// No jobs are started once Shutdown is called.
+func (s *Service) Shutdown() error {
+	s.mu.Lock()
+	s.shuttingDown = true
+	for _, cancel := range s.cancelFuncs {
+		cancel()
+	}
+	s.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		s.running.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-time.After(s.opts.shutdownTimeout()):
+		return ErrShutdownTimeout
+	}
+}
+
+// isShuttingDown reports whether Shutdown has been called.
+func (s *Service) isShuttingDown() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.shuttingDown
+}
+
+// notify wakes the dispatcher without blocking.
+func (s *Service) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
 // GetJob retrieves a pull job by ID.
 func (s *Service) GetJob(ctx context.Context, id uuid.UUID) (*Job, error) {
 	job, err := s.pullRepo.GetByID(ctx, id)
@@ -111,6 +246,51 @@ func (s *Service) GetJob(ctx context.Context, id uuid.UUID) (*Job, error) {
 	return job, nil
 }
 
+// GetJobEvents returns the events logged by a pull job that match the
+// filter, in the order they occurred.
+func (s *Service) GetJobEvents(ctx context.Context, id uuid.UUID, filter EventFilter) ([]PullEventLog, error) {
+	if filter.EventType != "" && !IsValidEventType(filter.EventType) {
+		return nil, fmt.Errorf("%w: unknown event type %q", ErrInvalidInput, filter.EventType)
+	}
+
+	job, err := s.GetJob(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	events := []PullEventLog{}
+	for _, event := range job.Progress.Events {
+		if filter.Matches(event) {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+// ListJobs retrieves pull job history, newest first.
+func (s *Service) ListJobs(ctx context.Context, filter PullListFilter) ([]Job, error) {
+	if filter.Status != nil && !filter.Status.IsValid() {
+		return nil, fmt.Errorf("%w: unknown status %q", ErrInvalidInput, *filter.Status)
+	}
+
+	// Set defaults
+	if filter.Limit < 1 {
+		filter.Limit = 50
+	}
+	if filter.Limit > 200 {
+		filter.Limit = 200
+	}
+
+	jobs, err := s.pullRepo.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	if jobs == nil {
+		jobs = []Job{}
+	}
+	return jobs, nil
+}
+
 // CancelJob cancels an active pull job.
 func (s *Service) CancelJob(ctx context.Context, id uuid.UUID) error {
 	job, err := s.pullRepo.GetByID(ctx, id)
@@ -138,89 +318,181 @@ func (s *Service) CancelJob(ctx context.Context, id uuid.UUID) error {
 	return s.pullRepo.SetStatus(ctx, id, JobStatusCancelled, "cancelled by user")
 }
 
-// executePull runs the pull operation for the given systems.
-func (s *Service) executePull(jobID uuid.UUID, systemIDs []uuid.UUID) {
-	// Create cancellable context
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
+// PauseJob asks an active pull job to pause. A job running on this
+// instance stops after the systems it is pulling and saves its progress; other
+// active jobs are marked paused straight away. Pausing a paused job is a
+// no-op.
+func (s *Service) PauseJob(ctx context.Context, id uuid.UUID) error {
+	job, err := s.pullRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if job == nil {
+		return ErrNotFound
+	}
+
+	if job.Status == JobStatusPaused {
+		return nil
+	}
+	if !job.Status.IsActive() {
+		return ErrJobAlreadyComplete
+	}
 
-	// Register cancel function
 	s.mu.Lock()
-	s.cancelFuncs[jobID] = cancel
+	pause, running := s.pauseSignals[id]
+	if running {
+		select {
+		case <-pause:
+		default:
+			close(pause)
+		}
+	}
 	s.mu.Unlock()
 
-	// Cleanup on exit
+	if running {
+		s.logger.Info("pause requested for pull job", "job_id", id)
+		return nil
+	}
+
+	// The job may be claimed between the checks above and this update, so
+	// only a job that is still pending is paused here
+	paused, err := s.pullRepo.PausePendingJob(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !paused {
+		return ErrJobNotRunning
+	}
+	return nil
+}
+
+// ResumeJob queues a paused pull job again. Once dispatched it continues
+// with the first system that was not completed before the pause.
+func (s *Service) ResumeJob(ctx context.Context, id uuid.UUID) (*Job, error) {
+	job, err := s.pullRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if job == nil {
+		return nil, ErrNotFound
+	}
+	if job.Status != JobStatusPaused {
+		return nil, ErrJobNotPaused
+	}
+
+	active, err := s.pullRepo.CountActiveJobs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if active >= s.opts.maxConcurrentJobs() {
+		return nil, ErrConcurrentJob
+	}
+
+	if err := s.pullRepo.SetStatus(ctx, id, JobStatusPending, ""); err != nil {
+		return nil, err
+	}
+	job.Status = JobStatusPending
+
+	s.logger.Info("resumed pull job", "job_id", id,
+		"completed_systems", len(job.Progress.CompletedSystemIDs), "system_count", len(job.SystemIDs))
+	s.notify()
+
+	return job, nil
+}
+
+// executePull runs a claimed pull job. The job's cancel function and pause
+// signal must already be registered. Systems listed in the job's
+// CompletedSystemIDs are skipped, so a resumed job picks up where it paused.
+func (s *Service) executePull(ctx context.Context, job Job) {
+	jobID, systemIDs := job.ID, job.SystemIDs
+
+	s.mu.RLock()
+	pause := s.pauseSignals[jobID]
+	s.mu.RUnlock()
+
+	// Cleanup on exit and free the slot for the next queued job
 	defer func() {
 		s.mu.Lock()
-		delete(s.cancelFuncs, jobID)
+		if cancel, ok := s.cancelFuncs[jobID]; ok {
+			cancel()
+			delete(s.cancelFuncs, jobID)
+		}
+		delete(s.pauseSignals, jobID)
 		s.mu.Unlock()
+		s.notify()
 	}()
 
 	// Get ServiceNow client
-	snClient, err := s.snClientGetter.GetSNClient(ctx)
+	snClient, err := s.snClientGetter.GetSNClientByLabel(ctx, job.ConnectionLabel)
 	if err != nil {
-		s.logger.Error("failed to get ServiceNow client", "job_id", jobID, "error", err)
+		s.logger.Error("failed to get ServiceNow client", "job_id", jobID, "connection", job.ConnectionLabel, "error", err)
 		s.pullRepo.SetStatus(ctx, jobID, JobStatusFailed, "ServiceNow connection not available")
 		return
 	}
 
-	// Initialize progress
-	progress := Progress{
-		TotalSystems: len(systemIDs),
-		Errors:       make([]string, 0),
-	}
-
-	// Update status to running
-	if err := s.pullRepo.SetStatus(ctx, jobID, JobStatusRunning, ""); err != nil {
-		s.logger.Error("failed to set job status", "job_id", jobID, "error", err)
-		return
+	// Initialize progress, keeping what a paused run already completed
+	initial := job.Progress
+	initial.TotalSystems = len(systemIDs)
+	initial.CurrentSystem = ""
+	completed := make(map[uuid.UUID]bool, len(initial.CompletedSystemIDs))
+	for _, id := range initial.CompletedSystemIDs {
+		completed[id] = true
 	}
+	progress := &jobProgress{progress: initial}
 
-	// Process each system
-	for _, systemID := range systemIDs {
-		// Check for cancellation
+	// stopped reports whether the job was cancelled or asked to pause
+	stopped := func() bool {
 		select {
 		case <-ctx.Done():
-			s.logger.Info("pull job cancelled", "job_id", jobID)
-			return
+			return true
+		case <-pause:
+			return true
 		default:
+			return false
 		}
+	}
 
-		// Get system details
-		sys, err := s.systemRepo.GetByID(ctx, systemID)
-		if err != nil || sys == nil {
-			progress.Errors = append(progress.Errors, fmt.Sprintf("system %s not found", systemID))
+	// Pull up to opts.Parallelism systems at a time. Systems waiting for a
+	// slot when the job stops are skipped; pulls in flight finish first.
+	var g errgroup.Group
+	g.SetLimit(s.opts.parallelism())
+	for _, systemID := range systemIDs {
+		if completed[systemID] {
 			continue
 		}
-
-		progress.CurrentSystem = sys.Name
-		s.updateProgress(ctx, jobID, progress)
-
-		// Pull controls and statements for this system
-		if err := s.pullSystemData(ctx, snClient, sys, &progress); err != nil {
-			s.logger.Error("failed to pull system data", "system", sys.Name, "error", err)
-			progress.Errors = append(progress.Errors, fmt.Sprintf("%s: %v", sys.Name, err))
-		}
-
-		// Update system's last pull timestamp
-		if err := s.systemRepo.UpdateLastPullAt(ctx, systemID); err != nil {
-			s.logger.Warn("failed to update last_pull_at", "system_id", systemID, "error", err)
+		if stopped() {
+			break
 		}
-
-		progress.CompletedSystems++
-		progress.CurrentSystem = ""
-		s.updateProgress(ctx, jobID, progress)
+		g.Go(func() error {
+			if !stopped() {
+				s.pullSystem(ctx, jobID, job.ConnectionLabel, snClient, systemID, progress)
+			}
+			return nil
+		})
 	}
+	g.Wait()
 
-	// Final status
 	if ctx.Err() != nil {
-		// Was cancelled
+		if s.isShuttingDown() {
+			s.pauseForShutdown(context.WithoutCancel(ctx), jobID, progress)
+			return
+		}
+		s.logger.Info("pull job cancelled", "job_id", jobID)
+		return
+	}
+	final := progress.snapshot()
+	if stopped() {
+		s.saveProgress(ctx, jobID, progress)
+		if err := s.pullRepo.SetStatus(ctx, jobID, JobStatusPaused, ""); err != nil {
+			s.logger.Error("failed to pause pull job", "job_id", jobID, "error", err)
+		}
+		s.logger.Info("pull job paused", "job_id", jobID, "completed_systems", len(final.CompletedSystemIDs))
 		return
 	}
 
 	status := JobStatusCompleted
 	errorMsg := ""
-	if len(progress.Errors) > 0 && progress.CompletedSystems == 0 {
+	if len(final.Errors()) > 0 && final.CompletedSystems == 0 {
 		status = JobStatusFailed
 		errorMsg = "all systems failed"
 	}
@@ -228,19 +500,150 @@ func (s *Service) executePull(jobID uuid.UUID, systemIDs []uuid.UUID) {
 	s.pullRepo.SetStatus(ctx, jobID, status, errorMsg)
 	s.logger.Info("pull job completed",
 		"job_id", jobID,
-		"systems", progress.CompletedSystems,
-		"controls", progress.CompletedControls,
-		"statements", progress.CompletedStatements,
-		"errors", len(progress.Errors),
+		"systems", final.CompletedSystems,
+		"controls", final.CompletedControls,
+		"statements", final.CompletedStatements,
+		"errors", len(final.Errors()),
 	)
 }
 
-// pullSystemData fetches controls and statements for a single system.
+// pauseForShutdown saves the progress of a job interrupted by Shutdown and
+// marks it paused so it can be resumed.
+func (s *Service) pauseForShutdown(ctx context.Context, jobID uuid.UUID, progress *jobProgress) {
+	s.saveProgress(ctx, jobID, progress)
+	if err := s.pullRepo.SetStatus(ctx, jobID, JobStatusPaused, ""); err != nil {
+		s.logger.Error("failed to pause pull job for shutdown", "job_id", jobID, "error", err)
+		return
+	}
+	s.logger.Info("pull job paused for shutdown", "job_id", jobID,
+		"completed_systems", len(progress.snapshot().CompletedSystemIDs))
+}
+
+// pullSystem pulls one system of a job and records it as completed, along
+// with the connection it was pulled from.
+func (s *Service) pullSystem(
+	ctx context.Context,
+	jobID uuid.UUID,
+	connectionLabel string,
+	snClient servicenow.Client,
+	systemID uuid.UUID,
+	progress *jobProgress,
+) {
+	// Get system details
+	sys, err := s.systemRepo.GetByID(ctx, systemID)
+	if err != nil || sys == nil {
+		progress.addError("", fmt.Sprintf("system %s not found", systemID))
+		return
+	}
+
+	// Another job pulling the same system would upsert the same rows
+	acquired, err := s.acquireLock(ctx, systemID, jobID)
+	if err != nil {
+		s.logger.Error("failed to lock system for pull", "system", sys.Name, "job_id", jobID, "error", err)
+		progress.addError(sys.Name, fmt.Sprintf("%s: failed to lock system: %v", sys.Name, err))
+		s.saveProgress(ctx, jobID, progress)
+		return
+	}
+	if !acquired {
+		s.logger.Warn("system is locked by another pull job", "system", sys.Name, "job_id", jobID)
+		progress.addError(sys.Name, fmt.Sprintf("%s: %v", sys.Name, ErrSystemLocked))
+		s.saveProgress(ctx, jobID, progress)
+		return
+	}
+	defer s.releaseLock(context.WithoutCancel(ctx), systemID, jobID)
+
+	progress.update(func(p *Progress) { p.CurrentSystem = sys.Name })
+	s.saveProgress(ctx, jobID, progress)
+
+	// Pull controls and statements for this system
+	if err := s.pullSystemWithRetry(ctx, jobID, snClient, sys, progress); err != nil {
+		if ctx.Err() != nil {
+			// Not completed, so a resumed job pulls the system again
+			return
+		}
+		s.logger.Error("failed to pull system data", "system", sys.Name, "error", err)
+		progress.addError(sys.Name, fmt.Sprintf("%s: %v", sys.Name, err))
+	}
+
+	// Update system's last pull timestamp
+	if err := s.systemRepo.UpdateLastPullAt(ctx, systemID, connectionLabel); err != nil {
+		s.logger.Warn("failed to update last_pull_at", "system_id", systemID, "error", err)
+	}
+
+	progress.update(func(p *Progress) {
+		p.CompletedSystems++
+		p.CompletedSystemIDs = append(p.CompletedSystemIDs, systemID)
+		// Another system may have started since
+		if p.CurrentSystem == sys.Name {
+			p.CurrentSystem = ""
+		}
+	})
+	s.saveProgress(ctx, jobID, progress)
+}
+
+// acquireLock locks a system for a pull job. Returns false if another job
+// is pulling the system; locks expire after 30 minutes.
+func (s *Service) acquireLock(ctx context.Context, systemID, jobID uuid.UUID) (bool, error) {
+	return s.pullRepo.AcquireSystemLock(ctx, systemID, jobID)
+}
+
+// releaseLock releases a system lock taken by acquireLock.
+func (s *Service) releaseLock(ctx context.Context, systemID, jobID uuid.UUID) {
+	if err := s.pullRepo.ReleaseSystemLock(ctx, systemID, jobID); err != nil {
+		s.logger.Warn("failed to release system pull lock", "system_id", systemID, "job_id", jobID, "error", err)
+	}
+}
+
+// pullSystemWithRetry pulls a system, retrying a failed pull as configured
+// by the retry policy. Retries are counted in progress.RetryAttempts. The
+// last error is returned once the retries are used up or the job is
+// cancelled.
+func (s *Service) pullSystemWithRetry(
+	ctx context.Context,
+	jobID uuid.UUID,
+	snClient servicenow.Client,
+	sys *system.System,
+	progress *jobProgress,
+) error {
+	policy := s.opts.Retry
+	var attempt systemCounts
+	err := s.pullSystemData(ctx, snClient, sys, progress, &attempt)
+	for retry := 1; err != nil && retry <= policy.MaxRetries && ctx.Err() == nil; retry++ {
+		delay := policy.backoff(retry)
+		s.logger.Warn("retrying system pull",
+			"job_id", jobID, "system", sys.Name, "retry", retry, "delay", delay, "error", err)
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+
+		// The retry pulls the system from the start, so it must not add to
+		// the counts of the failed attempt
+		progress.removeCounts(attempt)
+		attempt = systemCounts{}
+		progress.update(func(p *Progress) {
+			if p.RetryAttempts == nil {
+				p.RetryAttempts = make(map[uuid.UUID]int)
+			}
+			p.RetryAttempts[sys.ID] = retry
+		})
+		s.saveProgress(ctx, jobID, progress)
+
+		err = s.pullSystemData(ctx, snClient, sys, progress, &attempt)
+	}
+	return err
+}
+
+// pullSystemData fetches controls and statements for a single system. The
+// counts it adds to progress are also added to attempt.
 func (s *Service) pullSystemData(
 	ctx context.Context,
 	snClient servicenow.Client,
 	sys *system.System,
-	progress *Progress,
+	progress *jobProgress,
+	attempt *systemCounts,
 ) error {
 	// Fetch controls from ServiceNow
 	controlResult, err := snClient.FetchControls(ctx, sys.SNSysID, nil, nil)
@@ -248,7 +651,14 @@ func (s *Service) pullSystemData(
 		return fmt.Errorf("fetch controls: %w", err)
 	}
 
-	progress.TotalControls += len(controlResult.Records)
+	progress.addCounts(attempt, systemCounts{totalControls: len(controlResult.Records)})
+	strategy := s.conflictStrategy(sys)
+
+	var groups map[string]bool
+	if s.opts.ValidateRoles {
+		groups = s.userGroups(ctx, snClient, sys, progress)
+	}
+	loc := s.instanceTimezone(ctx, snClient, sys, progress)
 
 	// Process each control
 	for _, snControl := range controlResult.Records {
@@ -262,11 +672,15 @@ func (s *Service) pullSystemData(
 		// Parse timestamps
 		var snUpdatedOn *time.Time
 		if snControl.SysUpdatedOn != "" {
-			if t, err := time.Parse("2006-01-02 15:04:05", snControl.SysUpdatedOn); err == nil {
+			if t, err := servicenow.ParseSNTime(snControl.SysUpdatedOn, loc); err == nil {
 				snUpdatedOn = &t
 			}
 		}
 
+		if family := snControl.ControlFamily; strings.TrimSpace(family) != "" && !control.IsKnownFamily(family) {
+			s.logger.Warn("control has unknown family", "control_id", snControl.ControlID, "family", family)
+		}
+
 		// Upsert control
 		ctrl, err := s.controlRepo.Upsert(ctx, control.UpsertInput{
 			SystemID:             sys.ID,
@@ -276,52 +690,196 @@ func (s *Service) pullSystemData(
 			ControlFamily:        snControl.ControlFamily,
 			Description:          snControl.Description,
 			ImplementationStatus: snControl.ImplementationStatus,
+			ResponsibleRole:      snControl.ResponsibleRole,
 			SNUpdatedOn:          snUpdatedOn,
 		})
 		if err != nil {
-			progress.Errors = append(progress.Errors, fmt.Sprintf("control %s: %v", snControl.ControlID, err))
+			progress.addEvent(PullEventLog{
+				SystemName: sys.Name, ControlID: snControl.ControlID, EventType: EventError,
+				Message: fmt.Sprintf("control %s: %v", snControl.ControlID, err),
+			})
 			continue
 		}
+		progress.addEvent(PullEventLog{
+			SystemName: sys.Name, ControlID: snControl.ControlID, EventType: EventControlUpserted,
+			Message: fmt.Sprintf("control %s upserted", snControl.ControlID),
+		})
+
+		if role := snControl.ResponsibleRole; groups != nil && role != "" && !groups[strings.ToLower(role)] {
+			progress.addEvent(PullEventLog{
+				SystemName: sys.Name, ControlID: snControl.ControlID, EventType: EventWarning,
+				Message: fmt.Sprintf("control %s: responsible role %q is not a ServiceNow user group", snControl.ControlID, role),
+			})
+		}
 
-		progress.CompletedControls++
+		progress.addCounts(attempt, systemCounts{completedControls: 1})
 
 		// Fetch statements for this control
 		stmtResult, err := snClient.FetchStatements(ctx, snControl.SysID, nil, nil)
 		if err != nil {
-			progress.Errors = append(progress.Errors, fmt.Sprintf("statements for %s: %v", snControl.ControlID, err))
+			progress.addEvent(PullEventLog{
+				SystemName: sys.Name, ControlID: snControl.ControlID, EventType: EventError,
+				Message: fmt.Sprintf("statements for %s: %v", snControl.ControlID, err),
+			})
 			continue
 		}
 
-		progress.TotalStatements += len(stmtResult.Records)
+		progress.addCounts(attempt, systemCounts{totalStatements: len(stmtResult.Records)})
 
 		// Process each statement
 		for _, snStmt := range stmtResult.Records {
 			var stmtUpdatedOn *time.Time
 			if snStmt.SysUpdatedOn != "" {
-				if t, err := time.Parse("2006-01-02 15:04:05", snStmt.SysUpdatedOn); err == nil {
+				if t, err := servicenow.ParseSNTime(snStmt.SysUpdatedOn, loc); err == nil {
 					stmtUpdatedOn = &t
 				}
 			}
 
-			_, err := s.stmtRepo.Upsert(ctx, statement.UpsertInput{
+			event := PullEventLog{SystemName: sys.Name, ControlID: snControl.ControlID, StatementNumber: snStmt.Number}
+			stmt, conflicted, err := s.upsertStatement(ctx, sys, statement.UpsertInput{
 				ControlID:     ctrl.ID,
 				SNSysID:       snStmt.SysID,
 				StatementType: snStmt.StatementType,
 				RemoteContent: snStmt.Content,
 				SNUpdatedOn:   stmtUpdatedOn,
-			})
+			}, strategy)
 			if err != nil {
-				progress.Errors = append(progress.Errors, fmt.Sprintf("statement %s: %v", snStmt.Number, err))
+				event.EventType = EventError
+				event.Message = fmt.Sprintf("statement %s: %v", snStmt.Number, err)
+				progress.addEvent(event)
 				continue
 			}
 
-			progress.CompletedStatements++
+			event.EventType = EventStatementUpserted
+			event.Message = fmt.Sprintf("statement %s upserted as %s", snStmt.Number, stmt.SyncStatus)
+			progress.addEvent(event)
+			if conflicted {
+				event.EventType = EventConflictDetected
+				event.Message = fmt.Sprintf("statement %s: remote content conflicts with local changes", snStmt.Number)
+				if resolution, ok := strategy.Resolution(); ok {
+					event.Message += fmt.Sprintf("; resolved with %s", resolution)
+				}
+				progress.addEvent(event)
+			}
+
+			progress.addCounts(attempt, systemCounts{completedStatements: 1})
 		}
 	}
 
 	return nil
 }
 
+// userGroups returns the lower-cased names of the ServiceNow user groups
+// for validating responsible roles. If the groups cannot be fetched, a
+// warning is logged for the system and nil is returned so the pull goes on
+// without validation.
+func (s *Service) userGroups(ctx context.Context, snClient servicenow.Client, sys *system.System, progress *jobProgress) map[string]bool {
+	names, err := snClient.GetUserGroups(ctx)
+	if err != nil {
+		progress.addWarning(sys.Name, fmt.Sprintf("responsible roles not validated: %v", err))
+		return nil
+	}
+
+	groups := make(map[string]bool, len(names))
+	for _, name := range names {
+		groups[strings.ToLower(name)] = true
+	}
+	return groups
+}
+
+// instanceTimezone returns the timezone the instance reports timestamps in.
+// If it cannot be read, timestamps are taken as UTC and a warning is logged
+// for the system.
+func (s *Service) instanceTimezone(ctx context.Context, snClient servicenow.Client, sys *system.System, progress *jobProgress) *time.Location {
+	loc, err := snClient.GetInstanceTimezone(ctx)
+	if err != nil {
+		progress.addWarning(sys.Name, fmt.Sprintf("instance timezone unknown, timestamps read as UTC: %v", err))
+		return time.UTC
+	}
+	return loc
+}
+
+// conflictStrategy returns the conflict strategy for a system, falling back
+// to the global default when the system has no override.
+func (s *Service) conflictStrategy(sys *system.System) statement.ConflictStrategy {
+	if sys.ConflictStrategy != "" {
+		return statement.ConflictStrategy(sys.ConflictStrategy)
+	}
+	return s.opts.ConflictStrategy
+}
+
+// upsertStatement stores a pulled statement. If the pull puts it in conflict
+// and the strategy resolves conflicts automatically, the conflict is
+// resolved straight away instead of being left for a user. conflicted
+// reports whether the statement was in conflict after the upsert, even if
+// the conflict was then resolved.
+func (s *Service) upsertStatement(
+	ctx context.Context,
+	sys *system.System,
+	input statement.UpsertInput,
+	strategy statement.ConflictStrategy,
+) (stmt *statement.Statement, conflicted bool, err error) {
+	// Statements already in conflict were reported when it was detected
+	var wasConflict bool
+	if s.opts.ConflictWebhook != nil {
+		if prev, err := s.stmtRepo.GetBySNSysID(ctx, input.ControlID, input.SNSysID); err == nil && prev != nil {
+			wasConflict = prev.SyncStatus == statement.SyncStatusConflict
+		}
+	}
+
+	stmt, err = s.stmtRepo.Upsert(ctx, input)
+	if err != nil {
+		return nil, false, err
+	}
+	if stmt.SyncStatus != statement.SyncStatusConflict {
+		return stmt, false, nil
+	}
+	if !wasConflict {
+		s.publishConflict(statement.ConflictEvent{
+			StatementID: stmt.ID,
+			ControlID:   stmt.ControlID,
+			SystemName:  sys.Name,
+			DetectedAt:  time.Now().UTC(),
+		})
+	}
+
+	resolution, ok := strategy.Resolution()
+	if !ok {
+		return stmt, true, nil
+	}
+
+	resolved, err := s.stmtRepo.ResolveConflict(ctx, statement.ResolveConflictInput{
+		ID:         stmt.ID,
+		Resolution: resolution,
+	})
+	if err != nil {
+		return nil, true, fmt.Errorf("auto-resolve conflict: %w", err)
+	}
+
+	s.logger.Info("auto-resolved statement conflict", "id", stmt.ID, "resolution", resolution)
+	return resolved, true, nil
+}
+
+// publishConflict sends event to the conflict webhook in the background so
+// that slow receivers and retries do not hold up the pull.
+func (s *Service) publishConflict(event statement.ConflictEvent) {
+	if s.opts.ConflictWebhook == nil {
+		return
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		defer cancel()
+		if err := s.opts.ConflictWebhook.Send(ctx, s.opts.WebhookURL, event); err != nil {
+			s.logger.Warn("failed to send conflict webhook", "statement_id", event.StatementID, "error", err)
+		}
+	}()
+}
+
+// CountActiveJobs returns the number of pending or running pull jobs.
+func (s *Service) CountActiveJobs(ctx context.Context) (int, error) {
+	return s.pullRepo.CountActiveJobs(ctx)
+}
+
 // updateProgress updates the job progress in the database.
 func (s *Service) updateProgress(ctx context.Context, jobID uuid.UUID, progress Progress) {
 	if err := s.pullRepo.UpdateProgress(ctx, jobID, progress); err != nil {
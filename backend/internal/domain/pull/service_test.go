@@ -0,0 +1,1251 @@
+package pull
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/controlcrud/backend/internal/domain/control"
+	"github.com/controlcrud/backend/internal/domain/statement"
+	"github.com/controlcrud/backend/internal/domain/system"
+	"github.com/controlcrud/backend/internal/infrastructure/servicenow"
+	"github.com/controlcrud/backend/internal/infrastructure/webhook"
+)
+
+// mockStatementRepository implements the statement.Repository methods used
+// when storing pulled statements, with the conflict semantics of the
+// database repository.
+type mockStatementRepository struct {
+	statement.Repository
+	stmt     *statement.Statement
+	resolved []statement.ConflictResolution
+}
+
+func (m *mockStatementRepository) Upsert(ctx context.Context, input statement.UpsertInput) (*statement.Statement, error) {
+	if m.stmt.IsModified && m.stmt.RemoteContent != input.RemoteContent {
+		m.stmt.SyncStatus = statement.SyncStatusConflict
+	}
+	m.stmt.RemoteContent = input.RemoteContent
+	updated := *m.stmt
+	return &updated, nil
+}
+
+func (m *mockStatementRepository) GetBySNSysID(ctx context.Context, controlID uuid.UUID, snSysID string) (*statement.Statement, error) {
+	existing := *m.stmt
+	return &existing, nil
+}
+
+func (m *mockStatementRepository) ResolveConflict(ctx context.Context, input statement.ResolveConflictInput) (*statement.Statement, error) {
+	m.resolved = append(m.resolved, input.Resolution)
+	switch input.Resolution {
+	case statement.ConflictResolutionKeepLocal:
+		m.stmt.SyncStatus = statement.SyncStatusModified
+	case statement.ConflictResolutionKeepRemote:
+		m.stmt.LocalContent = m.stmt.RemoteContent
+		m.stmt.IsModified = false
+		m.stmt.SyncStatus = statement.SyncStatusSynced
+	}
+	updated := *m.stmt
+	return &updated, nil
+}
+
+func newModifiedStatement() *statement.Statement {
+	return &statement.Statement{
+		ID:            uuid.New(),
+		RemoteContent: "Original remote text.",
+		LocalContent:  "Local edit.",
+		IsModified:    true,
+		SyncStatus:    statement.SyncStatusModified,
+	}
+}
+
+func TestService_UpsertStatement_ConflictStrategies(t *testing.T) {
+	tests := []struct {
+		name        string
+		strategy    statement.ConflictStrategy
+		wantStatus  statement.SyncStatus
+		wantContent string
+		wantResolve bool
+	}{
+		{"manual", statement.ConflictStrategyManual, statement.SyncStatusConflict, "Local edit.", false},
+		{"unset defaults to manual", "", statement.SyncStatusConflict, "Local edit.", false},
+		{"keep local", statement.ConflictStrategyKeepLocal, statement.SyncStatusModified, "Local edit.", true},
+		{"keep remote", statement.ConflictStrategyKeepRemote, statement.SyncStatusSynced, "Changed remote text.", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockStatementRepository{stmt: newModifiedStatement()}
+			svc := NewService(nil, nil, nil, repo, nil, Options{}, nil)
+
+			stmt, conflicted, err := svc.upsertStatement(context.Background(), &system.System{}, statement.UpsertInput{
+				RemoteContent: "Changed remote text.",
+			}, tt.strategy)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !conflicted {
+				t.Error("expected the conflict to be reported")
+			}
+
+			if stmt.SyncStatus != tt.wantStatus {
+				t.Errorf("expected sync status %s, got %s", tt.wantStatus, stmt.SyncStatus)
+			}
+			if got := stmt.GetContent(); got != tt.wantContent {
+				t.Errorf("expected content %q, got %q", tt.wantContent, got)
+			}
+			if resolved := len(repo.resolved) > 0; resolved != tt.wantResolve {
+				t.Errorf("expected conflict resolved %v, got %v", tt.wantResolve, resolved)
+			}
+		})
+	}
+}
+
+func TestService_UpsertStatement_NoConflict(t *testing.T) {
+	repo := &mockStatementRepository{stmt: newModifiedStatement()}
+	svc := NewService(nil, nil, nil, repo, nil, Options{}, nil)
+
+	// Remote unchanged, so there is nothing to resolve
+	stmt, conflicted, err := svc.upsertStatement(context.Background(), &system.System{}, statement.UpsertInput{
+		RemoteContent: "Original remote text.",
+	}, statement.ConflictStrategyKeepRemote)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if conflicted {
+		t.Error("expected no conflict")
+	}
+	if stmt.SyncStatus != statement.SyncStatusModified {
+		t.Errorf("expected sync status modified, got %s", stmt.SyncStatus)
+	}
+	if len(repo.resolved) != 0 {
+		t.Error("expected no conflict resolution")
+	}
+}
+
+func TestService_UpsertStatement_ConflictWebhook(t *testing.T) {
+	events := make(chan statement.ConflictEvent, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if r.Header.Get(webhook.SignatureHeader) != webhook.Sign([]byte("s3cret"), body) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var event statement.ConflictEvent
+		if err := json.Unmarshal(body, &event); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		events <- event
+	}))
+	defer server.Close()
+
+	stmt := newModifiedStatement()
+	stmt.ControlID = uuid.New()
+	repo := &mockStatementRepository{stmt: stmt}
+	svc := NewService(nil, nil, nil, repo, nil, Options{
+		ConflictWebhook: webhook.NewClient("s3cret"),
+		WebhookURL:      server.URL,
+	}, nil)
+	sys := &system.System{Name: "Payroll"}
+
+	// Remote unchanged: no conflict, no event
+	if _, _, err := svc.upsertStatement(context.Background(), sys, statement.UpsertInput{RemoteContent: "Original remote text."}, ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Remote changed under a local edit: conflict detected
+	if _, _, err := svc.upsertStatement(context.Background(), sys, statement.UpsertInput{RemoteContent: "Changed remote text."}, ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	select {
+	case event := <-events:
+		if event.StatementID != stmt.ID || event.ControlID != stmt.ControlID || event.SystemName != "Payroll" {
+			t.Errorf("unexpected event %+v", event)
+		}
+		if event.DetectedAt.IsZero() {
+			t.Error("expected detection time to be set")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for conflict webhook")
+	}
+
+	// Pulling again while still in conflict does not report it twice
+	if _, _, err := svc.upsertStatement(context.Background(), sys, statement.UpsertInput{RemoteContent: "Changed remote text."}, ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	select {
+	case event := <-events:
+		t.Errorf("expected a single event, also got %+v", event)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestService_ConflictStrategy_SystemOverride(t *testing.T) {
+	svc := NewService(nil, nil, nil, nil, nil, Options{ConflictStrategy: statement.ConflictStrategyKeepRemote}, nil)
+
+	if got := svc.conflictStrategy(&system.System{}); got != statement.ConflictStrategyKeepRemote {
+		t.Errorf("expected global strategy keep_remote, got %s", got)
+	}
+	if got := svc.conflictStrategy(&system.System{ConflictStrategy: "manual"}); got != statement.ConflictStrategyManual {
+		t.Errorf("expected system override manual, got %s", got)
+	}
+}
+
+// mockPullRepository keeps pull jobs in memory with the claim semantics of
+// the database repository.
+type mockPullRepository struct {
+	Repository
+	mu      sync.Mutex
+	jobs    []*Job
+	claimed []uuid.UUID
+	locks   map[uuid.UUID]uuid.UUID // System ID to the job holding its lock
+}
+
+func (m *mockPullRepository) Create(ctx context.Context, input CreateInput) (*Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	job := &Job{
+		ID:        uuid.New(),
+		SystemIDs: input.SystemIDs,
+		Status:    JobStatusPending,
+		CreatedAt: time.Now().Add(time.Duration(len(m.jobs)) * time.Millisecond),
+
+		ConnectionLabel: input.ConnectionLabel,
+	}
+	m.jobs = append(m.jobs, job)
+	copied := *job
+	return &copied, nil
+}
+
+func (m *mockPullRepository) CountActiveJobs(ctx context.Context) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	count := 0
+	for _, job := range m.jobs {
+		if job.Status.IsActive() {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (m *mockPullRepository) ClaimPendingJobs(ctx context.Context, limit int) ([]Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var pending []*Job
+	for _, job := range m.jobs {
+		if job.Status == JobStatusPending {
+			pending = append(pending, job)
+		}
+	}
+	sort.Slice(pending, func(i, j int) bool { return pending[i].CreatedAt.Before(pending[j].CreatedAt) })
+
+	var claimed []Job
+	for _, job := range pending {
+		if len(claimed) == limit {
+			break
+		}
+		job.Status = JobStatusRunning
+		m.claimed = append(m.claimed, job.ID)
+		claimed = append(claimed, *job)
+	}
+	return claimed, nil
+}
+
+func (m *mockPullRepository) SetStatus(ctx context.Context, id uuid.UUID, status JobStatus, errorMsg string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, job := range m.jobs {
+		if job.ID == id {
+			job.Status = status
+		}
+	}
+	return nil
+}
+
+func (m *mockPullRepository) PausePendingJob(ctx context.Context, id uuid.UUID) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, job := range m.jobs {
+		if job.ID == id && job.Status == JobStatusPending {
+			job.Status = JobStatusPaused
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *mockPullRepository) GetByID(ctx context.Context, id uuid.UUID) (*Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, job := range m.jobs {
+		if job.ID == id {
+			copied := *job
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockPullRepository) UpdateProgress(ctx context.Context, id uuid.UUID, progress Progress) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, job := range m.jobs {
+		if job.ID == id {
+			progress.CompletedSystemIDs = append([]uuid.UUID(nil), progress.CompletedSystemIDs...)
+			job.Progress = progress
+		}
+	}
+	return nil
+}
+
+func (m *mockPullRepository) AcquireSystemLock(ctx context.Context, systemID, jobID uuid.UUID) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.locks[systemID]; ok {
+		return false, nil
+	}
+	if m.locks == nil {
+		m.locks = make(map[uuid.UUID]uuid.UUID)
+	}
+	m.locks[systemID] = jobID
+	return true, nil
+}
+
+func (m *mockPullRepository) ReleaseSystemLock(ctx context.Context, systemID, jobID uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.locks[systemID] == jobID {
+		delete(m.locks, systemID)
+	}
+	return nil
+}
+
+func (m *mockPullRepository) lockCount() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.locks)
+}
+
+func (m *mockPullRepository) status(id uuid.UUID) JobStatus {
+	job, _ := m.GetByID(context.Background(), id)
+	return job.Status
+}
+
+func (m *mockPullRepository) running() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	count := 0
+	for _, job := range m.jobs {
+		if job.Status == JobStatusRunning {
+			count++
+		}
+	}
+	return count
+}
+
+// blockingClientProvider holds every job in GetSNClientByLabel until
+// released, then fails it so the job finishes without touching other
+// repositories. It records the connection labels it was asked for.
+type blockingClientProvider struct {
+	release chan struct{}
+	mu      sync.Mutex
+	labels  []string
+}
+
+func (p *blockingClientProvider) GetSNClientByLabel(ctx context.Context, label string) (servicenow.Client, error) {
+	p.mu.Lock()
+	p.labels = append(p.labels, label)
+	p.mu.Unlock()
+	<-p.release
+	return nil, errors.New("not configured")
+}
+
+func queuePendingJobs(repo *mockPullRepository, n int) []uuid.UUID {
+	ids := make([]uuid.UUID, n)
+	for i := range ids {
+		job, _ := repo.Create(context.Background(), CreateInput{SystemIDs: []uuid.UUID{uuid.New()}})
+		ids[i] = job.ID
+	}
+	return ids
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for condition")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestService_Dispatch_RespectsLimit(t *testing.T) {
+	repo := &mockPullRepository{}
+	provider := &blockingClientProvider{release: make(chan struct{})}
+	svc := NewService(repo, nil, nil, nil, provider, Options{MaxConcurrentJobs: 2}, nil)
+	ids := queuePendingJobs(repo, 3)
+
+	svc.dispatch(context.Background())
+	if got := repo.running(); got != 2 {
+		t.Fatalf("expected 2 running jobs, got %d", got)
+	}
+
+	// No slot is free, so the third job stays queued
+	svc.dispatch(context.Background())
+	if got := repo.running(); got != 2 {
+		t.Fatalf("expected 2 running jobs after second dispatch, got %d", got)
+	}
+
+	// Finishing one job frees a slot for the third
+	provider.release <- struct{}{}
+	waitFor(t, func() bool { return repo.running() == 1 })
+	svc.dispatch(context.Background())
+	if got := repo.running(); got != 2 {
+		t.Fatalf("expected 2 running jobs after slot freed, got %d", got)
+	}
+
+	close(provider.release)
+	waitFor(t, func() bool { return repo.running() == 0 })
+
+	if len(repo.claimed) != 3 || repo.claimed[2] != ids[2] {
+		t.Errorf("expected third job claimed last, got %v", repo.claimed)
+	}
+}
+
+func TestService_RunDispatcher_DrainsQueueInOrder(t *testing.T) {
+	repo := &mockPullRepository{}
+	provider := &blockingClientProvider{release: make(chan struct{})}
+	svc := NewService(repo, nil, nil, nil, provider, Options{}, nil)
+	ids := queuePendingJobs(repo, 4)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go svc.RunDispatcher(ctx)
+
+	// Release jobs one at a time; with the default limit of 1 only one may run
+	for range ids {
+		waitFor(t, func() bool { return repo.running() == 1 })
+		provider.release <- struct{}{}
+	}
+	waitFor(t, func() bool {
+		count, _ := repo.CountActiveJobs(context.Background())
+		return count == 0
+	})
+
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	if len(repo.claimed) != len(ids) {
+		t.Fatalf("expected %d jobs claimed, got %d", len(ids), len(repo.claimed))
+	}
+	for i, id := range ids {
+		if repo.claimed[i] != id {
+			t.Errorf("job %d claimed out of order", i)
+		}
+	}
+}
+
+func TestService_StartPull_ConcurrencyLimit(t *testing.T) {
+	repo := &mockPullRepository{}
+	svc := NewService(repo, nil, nil, nil, nil, Options{MaxConcurrentJobs: 2}, nil)
+	queuePendingJobs(repo, 2)
+
+	_, err := svc.StartPull(context.Background(), []uuid.UUID{uuid.New()}, "")
+	if !errors.Is(err, ErrConcurrentJob) {
+		t.Errorf("expected ErrConcurrentJob, got %v", err)
+	}
+}
+
+func TestService_StartPull_InvalidConnectionLabel(t *testing.T) {
+	svc := NewService(&mockPullRepository{}, nil, nil, nil, nil, Options{}, nil)
+
+	_, err := svc.StartPull(context.Background(), []uuid.UUID{uuid.New()}, "prod/eu")
+	if !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput, got %v", err)
+	}
+}
+
+func TestService_Dispatch_UsesJobConnectionLabel(t *testing.T) {
+	repo := &mockPullRepository{}
+	provider := &blockingClientProvider{release: make(chan struct{})}
+	svc := NewService(repo, nil, nil, nil, provider, Options{MaxConcurrentJobs: 2}, nil)
+	repo.Create(context.Background(), CreateInput{SystemIDs: []uuid.UUID{uuid.New()}, ConnectionLabel: "staging"})
+
+	svc.dispatch(context.Background())
+	waitFor(t, func() bool { return repo.running() == 1 })
+	provider.release <- struct{}{}
+	waitFor(t, func() bool { return repo.running() == 0 })
+
+	provider.mu.Lock()
+	defer provider.mu.Unlock()
+	if len(provider.labels) != 1 || provider.labels[0] != "staging" {
+		t.Errorf("expected client for staging connection, got %v", provider.labels)
+	}
+}
+
+// mockControlRepository implements the control.Repository methods used when
+// storing pulled controls.
+type mockControlRepository struct {
+	control.Repository
+}
+
+func (m *mockControlRepository) Upsert(ctx context.Context, input control.UpsertInput) (*control.Control, error) {
+	return &control.Control{ID: uuid.New(), ControlID: input.ControlID, ResponsibleRole: input.ResponsibleRole}, nil
+}
+
+// roleClient serves controls with responsible roles and a user group list.
+type roleClient struct {
+	servicenow.Client
+	controls  []servicenow.ControlRecord
+	groups    []string
+	groupsErr error
+	timezone  *time.Location
+}
+
+func (c *roleClient) FetchControls(ctx context.Context, systemSysID string, config *servicenow.PaginationConfig, onProgress servicenow.ProgressCallback) (*servicenow.PaginatedResult[servicenow.ControlRecord], error) {
+	return &servicenow.PaginatedResult[servicenow.ControlRecord]{Records: c.controls}, nil
+}
+
+func (c *roleClient) FetchStatements(ctx context.Context, controlSysID string, config *servicenow.PaginationConfig, onProgress servicenow.ProgressCallback) (*servicenow.PaginatedResult[servicenow.StatementRecord], error) {
+	return &servicenow.PaginatedResult[servicenow.StatementRecord]{}, nil
+}
+
+func (c *roleClient) GetUserGroups(ctx context.Context) ([]string, error) {
+	return c.groups, c.groupsErr
+}
+
+func (c *roleClient) GetInstanceTimezone(ctx context.Context) (*time.Location, error) {
+	if c.timezone == nil {
+		return time.UTC, nil
+	}
+	return c.timezone, nil
+}
+
+// recordingControlRepository records the controls upserted by a pull.
+type recordingControlRepository struct {
+	control.Repository
+	inputs []control.UpsertInput
+}
+
+func (m *recordingControlRepository) Upsert(ctx context.Context, input control.UpsertInput) (*control.Control, error) {
+	m.inputs = append(m.inputs, input)
+	return &control.Control{ID: uuid.New(), ControlID: input.ControlID}, nil
+}
+
+func TestService_PullSystemData_InstanceTimezone(t *testing.T) {
+	est := time.FixedZone("UTC-05:00", -5*3600)
+	client := &roleClient{
+		controls: []servicenow.ControlRecord{{SysID: "c1", ControlID: "AC-1", SysUpdatedOn: "2026-03-01 09:30:00"}},
+		timezone: est,
+	}
+	repo := &recordingControlRepository{}
+	svc := NewService(nil, nil, repo, nil, nil, Options{}, nil)
+
+	if err := svc.pullSystemData(context.Background(), client, &system.System{ID: uuid.New()}, &jobProgress{}, &systemCounts{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(repo.inputs) != 1 || repo.inputs[0].SNUpdatedOn == nil {
+		t.Fatalf("expected one control with sn_updated_on, got %+v", repo.inputs)
+	}
+	want := time.Date(2026, 3, 1, 14, 30, 0, 0, time.UTC)
+	if got := repo.inputs[0].SNUpdatedOn; !got.Equal(want) {
+		t.Errorf("expected sn_updated_on %s, got %s", want, got.UTC())
+	}
+}
+
+func TestService_PullSystemData_ValidateRoles(t *testing.T) {
+	controls := []servicenow.ControlRecord{
+		{SysID: "c1", ControlID: "AC-1", ResponsibleRole: "security operations"},
+		{SysID: "c2", ControlID: "AC-2", ResponsibleRole: "Facilities"},
+		{SysID: "c3", ControlID: "AC-3"},
+	}
+
+	tests := []struct {
+		name      string
+		validate  bool
+		groupsErr error
+		want      []string
+	}{
+		{"disabled", false, nil, nil},
+		{"unknown role warned", true, nil, []string{`warning: control AC-2: responsible role "Facilities" is not a ServiceNow user group`}},
+		{"groups unavailable", true, errors.New("connection failed"), []string{"warning: responsible roles not validated: connection failed"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &roleClient{controls: controls, groups: []string{"Security Operations", "Network Team"}, groupsErr: tt.groupsErr}
+			svc := NewService(nil, nil, &mockControlRepository{}, nil, nil, Options{ValidateRoles: tt.validate}, nil)
+
+			jp := &jobProgress{}
+			if err := svc.pullSystemData(context.Background(), client, &system.System{ID: uuid.New()}, jp, &systemCounts{}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			progress := jp.snapshot()
+			warnings := progress.Errors()
+
+			if len(warnings) != len(tt.want) {
+				t.Fatalf("expected warnings %v, got %v", tt.want, warnings)
+			}
+			for i := range tt.want {
+				if warnings[i] != tt.want[i] {
+					t.Errorf("expected warning %q, got %q", tt.want[i], warnings[i])
+				}
+			}
+			if progress.CompletedControls != len(controls) {
+				t.Errorf("expected all controls stored, got %d", progress.CompletedControls)
+			}
+		})
+	}
+}
+
+// mockSystemRepository serves any system ID as a system whose ServiceNow
+// sys_id is the ID itself.
+type mockSystemRepository struct {
+	system.Repository
+}
+
+func (m *mockSystemRepository) GetByID(ctx context.Context, id uuid.UUID) (*system.System, error) {
+	return &system.System{ID: id, Name: id.String(), SNSysID: id.String()}, nil
+}
+
+func (m *mockSystemRepository) UpdateLastPullAt(ctx context.Context, id uuid.UUID, connectionLabel string) error {
+	return nil
+}
+
+// gatedClient records the systems whose controls are fetched and holds
+// each fetch until a value is sent on proceed or proceed is closed.
+type gatedClient struct {
+	servicenow.Client
+	proceed chan struct{}
+	mu      sync.Mutex
+	fetched []string
+}
+
+func (c *gatedClient) FetchControls(ctx context.Context, systemSysID string, config *servicenow.PaginationConfig, onProgress servicenow.ProgressCallback) (*servicenow.PaginatedResult[servicenow.ControlRecord], error) {
+	c.mu.Lock()
+	c.fetched = append(c.fetched, systemSysID)
+	c.mu.Unlock()
+	<-c.proceed
+	return &servicenow.PaginatedResult[servicenow.ControlRecord]{}, nil
+}
+
+func (c *gatedClient) GetInstanceTimezone(ctx context.Context) (*time.Location, error) {
+	return time.UTC, nil
+}
+
+func (c *gatedClient) fetchedSystems() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]string(nil), c.fetched...)
+}
+
+type staticClientProvider struct {
+	client servicenow.Client
+}
+
+func (p staticClientProvider) GetSNClientByLabel(ctx context.Context, label string) (servicenow.Client, error) {
+	return p.client, nil
+}
+
+func TestService_PauseAndResumeJob(t *testing.T) {
+	repo := &mockPullRepository{}
+	client := &gatedClient{proceed: make(chan struct{})}
+	svc := NewService(repo, &mockSystemRepository{}, nil, nil, staticClientProvider{client: client}, Options{}, nil)
+
+	systemIDs := []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+	job, err := repo.Create(context.Background(), CreateInput{SystemIDs: systemIDs})
+	if err != nil {
+		t.Fatalf("failed to create job: %v", err)
+	}
+
+	// Pause while the first system is being pulled
+	svc.dispatch(context.Background())
+	waitFor(t, func() bool { return len(client.fetchedSystems()) == 1 })
+	if err := svc.PauseJob(context.Background(), job.ID); err != nil {
+		t.Fatalf("failed to pause job: %v", err)
+	}
+	client.proceed <- struct{}{}
+	waitFor(t, func() bool { return repo.status(job.ID) == JobStatusPaused })
+
+	paused, _ := repo.GetByID(context.Background(), job.ID)
+	if got := paused.Progress.CompletedSystemIDs; len(got) != 1 || got[0] != systemIDs[0] {
+		t.Fatalf("expected first system completed before pause, got %v", got)
+	}
+	if got := client.fetchedSystems(); len(got) != 1 {
+		t.Fatalf("expected pull to stop after first system, fetched %v", got)
+	}
+
+	// A paused job does not hold a concurrency slot, so others can start
+	if count, _ := repo.CountActiveJobs(context.Background()); count != 0 {
+		t.Errorf("expected no active jobs while paused, got %d", count)
+	}
+	if err := svc.PauseJob(context.Background(), job.ID); err != nil {
+		t.Errorf("expected pausing a paused job to be a no-op, got %v", err)
+	}
+
+	// Resume continues with the remaining systems
+	resumed, err := svc.ResumeJob(context.Background(), job.ID)
+	if err != nil {
+		t.Fatalf("failed to resume job: %v", err)
+	}
+	if resumed.Status != JobStatusPending {
+		t.Errorf("expected resumed job to be pending, got %s", resumed.Status)
+	}
+	close(client.proceed)
+	svc.dispatch(context.Background())
+	waitFor(t, func() bool { return repo.status(job.ID) == JobStatusCompleted })
+
+	fetched := client.fetchedSystems()
+	want := []string{systemIDs[0].String(), systemIDs[1].String(), systemIDs[2].String()}
+	if len(fetched) != len(want) {
+		t.Fatalf("expected systems %v pulled once each, got %v", want, fetched)
+	}
+	for i := range want {
+		if fetched[i] != want[i] {
+			t.Errorf("pull %d: expected system %s, got %s", i, want[i], fetched[i])
+		}
+	}
+
+	done, _ := repo.GetByID(context.Background(), job.ID)
+	if done.Progress.CompletedSystems != len(systemIDs) || len(done.Progress.CompletedSystemIDs) != len(systemIDs) {
+		t.Errorf("expected all %d systems completed, got %d (%v)",
+			len(systemIDs), done.Progress.CompletedSystems, done.Progress.CompletedSystemIDs)
+	}
+
+	if _, err := svc.ResumeJob(context.Background(), job.ID); !errors.Is(err, ErrJobNotPaused) {
+		t.Errorf("expected ErrJobNotPaused for completed job, got %v", err)
+	}
+}
+
+func TestService_PauseJob_Pending(t *testing.T) {
+	repo := &mockPullRepository{}
+	svc := NewService(repo, nil, nil, nil, nil, Options{}, nil)
+	ids := queuePendingJobs(repo, 1)
+
+	if err := svc.PauseJob(context.Background(), ids[0]); err != nil {
+		t.Fatalf("failed to pause job: %v", err)
+	}
+	if got := repo.status(ids[0]); got != JobStatusPaused {
+		t.Errorf("expected pending job paused, got %s", got)
+	}
+
+	// Paused jobs are not claimed by the dispatcher
+	svc.dispatch(context.Background())
+	if len(repo.claimed) != 0 {
+		t.Errorf("expected paused job not to be claimed, got %v", repo.claimed)
+	}
+}
+
+// claimingRepository claims every pending job when it is looked up, as a
+// dispatcher on another goroutine would between PauseJob's checks.
+type claimingRepository struct {
+	*mockPullRepository
+}
+
+func (r claimingRepository) GetByID(ctx context.Context, id uuid.UUID) (*Job, error) {
+	job, err := r.mockPullRepository.GetByID(ctx, id)
+	r.mockPullRepository.ClaimPendingJobs(ctx, len(r.jobs))
+	return job, err
+}
+
+func TestService_PauseJob_ClaimedWhilePausing(t *testing.T) {
+	repo := &mockPullRepository{}
+	svc := NewService(claimingRepository{repo}, nil, nil, nil, nil, Options{}, nil)
+	ids := queuePendingJobs(repo, 1)
+
+	if err := svc.PauseJob(context.Background(), ids[0]); !errors.Is(err, ErrJobNotRunning) {
+		t.Fatalf("expected ErrJobNotRunning, got %v", err)
+	}
+	if got := repo.status(ids[0]); got != JobStatusRunning {
+		t.Errorf("expected claimed job to stay running, got %s", got)
+	}
+}
+
+// flakyClient fails the first failures[sysID] control fetches of a system;
+// a negative count fails every fetch.
+type flakyClient struct {
+	servicenow.Client
+	mu       sync.Mutex
+	failures map[string]int
+	attempts map[string]int
+}
+
+func (c *flakyClient) FetchControls(ctx context.Context, systemSysID string, config *servicenow.PaginationConfig, onProgress servicenow.ProgressCallback) (*servicenow.PaginatedResult[servicenow.ControlRecord], error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.attempts[systemSysID]++
+	if n := c.failures[systemSysID]; n < 0 || c.attempts[systemSysID] <= n {
+		return nil, errors.New("503 service unavailable")
+	}
+	return &servicenow.PaginatedResult[servicenow.ControlRecord]{}, nil
+}
+
+func (c *flakyClient) GetInstanceTimezone(ctx context.Context) (*time.Location, error) {
+	return time.UTC, nil
+}
+
+func TestService_ExecutePull_RetriesFailedSystem(t *testing.T) {
+	flaky, broken := uuid.New(), uuid.New()
+	client := &flakyClient{
+		failures: map[string]int{flaky.String(): 1, broken.String(): -1},
+		attempts: map[string]int{},
+	}
+	repo := &mockPullRepository{}
+	svc := NewService(repo, &mockSystemRepository{}, nil, nil, staticClientProvider{client: client}, Options{
+		Retry: RetryPolicy{MaxRetries: 2, RetryDelay: time.Millisecond},
+	}, nil)
+
+	job, err := repo.Create(context.Background(), CreateInput{SystemIDs: []uuid.UUID{flaky, broken}})
+	if err != nil {
+		t.Fatalf("failed to create job: %v", err)
+	}
+	svc.dispatch(context.Background())
+	waitFor(t, func() bool { return repo.status(job.ID) == JobStatusCompleted })
+
+	done, _ := repo.GetByID(context.Background(), job.ID)
+	if client.attempts[flaky.String()] != 2 || done.Progress.RetryAttempts[flaky] != 1 {
+		t.Errorf("expected flaky system to succeed on second attempt, got %d attempts and %d retries",
+			client.attempts[flaky.String()], done.Progress.RetryAttempts[flaky])
+	}
+	if client.attempts[broken.String()] != 3 || done.Progress.RetryAttempts[broken] != 2 {
+		t.Errorf("expected broken system tried 3 times, got %d attempts and %d retries",
+			client.attempts[broken.String()], done.Progress.RetryAttempts[broken])
+	}
+	if len(done.Progress.Errors()) != 1 || !strings.Contains(done.Progress.Errors()[0], broken.String()) {
+		t.Errorf("expected only the broken system's error recorded, got %v", done.Progress.Errors())
+	}
+}
+
+// concurrencyClient holds each control fetch briefly and records the most
+// fetches that were in flight at once.
+type concurrencyClient struct {
+	servicenow.Client
+	sem chan struct{}
+
+	mu       sync.Mutex
+	inFlight int
+	peak     int
+}
+
+func (c *concurrencyClient) FetchControls(ctx context.Context, systemSysID string, config *servicenow.PaginationConfig, onProgress servicenow.ProgressCallback) (*servicenow.PaginatedResult[servicenow.ControlRecord], error) {
+	// The semaphore fails the test if more fetches run than it has room for
+	select {
+	case c.sem <- struct{}{}:
+	default:
+		return nil, errors.New("parallelism limit exceeded")
+	}
+	defer func() { <-c.sem }()
+
+	c.mu.Lock()
+	c.inFlight++
+	c.peak = max(c.peak, c.inFlight)
+	c.mu.Unlock()
+
+	time.Sleep(20 * time.Millisecond)
+
+	c.mu.Lock()
+	c.inFlight--
+	c.mu.Unlock()
+	return &servicenow.PaginatedResult[servicenow.ControlRecord]{
+		Records: []servicenow.ControlRecord{{SysID: systemSysID, ControlID: "AC-1"}},
+	}, nil
+}
+
+func (c *concurrencyClient) FetchStatements(ctx context.Context, controlSysID string, config *servicenow.PaginationConfig, onProgress servicenow.ProgressCallback) (*servicenow.PaginatedResult[servicenow.StatementRecord], error) {
+	return &servicenow.PaginatedResult[servicenow.StatementRecord]{}, nil
+}
+
+func (c *concurrencyClient) GetInstanceTimezone(ctx context.Context) (*time.Location, error) {
+	return time.UTC, nil
+}
+
+func TestService_ExecutePull_Parallelism(t *testing.T) {
+	const parallelism = 3
+	client := &concurrencyClient{sem: make(chan struct{}, parallelism)}
+	repo := &mockPullRepository{}
+	svc := NewService(repo, &mockSystemRepository{}, &mockControlRepository{}, nil, staticClientProvider{client: client}, Options{
+		Parallelism: parallelism,
+	}, nil)
+
+	systemIDs := []uuid.UUID{uuid.New(), uuid.New(), uuid.New(), uuid.New(), uuid.New()}
+	job, err := repo.Create(context.Background(), CreateInput{SystemIDs: systemIDs})
+	if err != nil {
+		t.Fatalf("failed to create job: %v", err)
+	}
+	svc.dispatch(context.Background())
+	waitFor(t, func() bool { return repo.status(job.ID) == JobStatusCompleted })
+
+	done, _ := repo.GetByID(context.Background(), job.ID)
+	if len(done.Progress.Errors()) != 0 {
+		t.Fatalf("unexpected errors: %v", done.Progress.Errors())
+	}
+	if done.Progress.CompletedSystems != len(systemIDs) || len(done.Progress.CompletedSystemIDs) != len(systemIDs) {
+		t.Errorf("expected all %d systems completed, got %d (%v)",
+			len(systemIDs), done.Progress.CompletedSystems, done.Progress.CompletedSystemIDs)
+	}
+	if done.Progress.CompletedControls != len(systemIDs) {
+		t.Errorf("expected %d controls, got %d", len(systemIDs), done.Progress.CompletedControls)
+	}
+	if client.peak < 2 || client.peak > parallelism {
+		t.Errorf("expected between 2 and %d concurrent pulls, got %d", parallelism, client.peak)
+	}
+}
+
+func TestService_ExecutePull_SystemLock(t *testing.T) {
+	repo := &mockPullRepository{}
+	client := &gatedClient{proceed: make(chan struct{})}
+	svc := NewService(repo, &mockSystemRepository{}, nil, nil, staticClientProvider{client: client}, Options{
+		MaxConcurrentJobs: 2,
+	}, nil)
+
+	systemID := uuid.New()
+	first, _ := repo.Create(context.Background(), CreateInput{SystemIDs: []uuid.UUID{systemID}})
+	second, _ := repo.Create(context.Background(), CreateInput{SystemIDs: []uuid.UUID{systemID}})
+
+	// Both jobs start; whichever locks the system first holds it in the fetch
+	svc.dispatch(context.Background())
+	waitFor(t, func() bool { return len(client.fetchedSystems()) == 1 })
+
+	var blocked uuid.UUID
+	waitFor(t, func() bool {
+		for _, id := range []uuid.UUID{first.ID, second.ID} {
+			if repo.status(id) == JobStatusFailed {
+				blocked = id
+				return true
+			}
+		}
+		return false
+	})
+	job, _ := repo.GetByID(context.Background(), blocked)
+	if len(job.Progress.Errors()) != 1 || !strings.Contains(job.Progress.Errors()[0], ErrSystemLocked.Error()) {
+		t.Errorf("expected a system locked error, got %v", job.Progress.Errors())
+	}
+	if job.Progress.CompletedSystems != 0 {
+		t.Errorf("expected the locked system not to be completed, got %d", job.Progress.CompletedSystems)
+	}
+
+	close(client.proceed)
+	holder := first.ID
+	if blocked == first.ID {
+		holder = second.ID
+	}
+	waitFor(t, func() bool { return repo.status(holder) == JobStatusCompleted })
+	waitFor(t, func() bool { return repo.lockCount() == 0 })
+
+	if got := client.fetchedSystems(); len(got) != 1 {
+		t.Errorf("expected the system pulled once, got %v", got)
+	}
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := RetryPolicy{MaxRetries: 3, RetryDelay: time.Second}
+	for retry, want := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 4 * time.Second} {
+		if got := policy.backoff(retry); got != want {
+			t.Errorf("retry %d: expected %v, got %v", retry, want, got)
+		}
+	}
+}
+
+// eventClient serves five controls for every system, each with one
+// statement. Controls are identified as "<system sys_id>/AC-<n>".
+type eventClient struct {
+	servicenow.Client
+}
+
+func (c *eventClient) FetchControls(ctx context.Context, systemSysID string, config *servicenow.PaginationConfig, onProgress servicenow.ProgressCallback) (*servicenow.PaginatedResult[servicenow.ControlRecord], error) {
+	var records []servicenow.ControlRecord
+	for n := 1; n <= 5; n++ {
+		id := fmt.Sprintf("%s/AC-%d", systemSysID, n)
+		records = append(records, servicenow.ControlRecord{SysID: id, ControlID: id})
+	}
+	return &servicenow.PaginatedResult[servicenow.ControlRecord]{Records: records}, nil
+}
+
+func (c *eventClient) FetchStatements(ctx context.Context, controlSysID string, config *servicenow.PaginationConfig, onProgress servicenow.ProgressCallback) (*servicenow.PaginatedResult[servicenow.StatementRecord], error) {
+	return &servicenow.PaginatedResult[servicenow.StatementRecord]{
+		Records: []servicenow.StatementRecord{{SysID: controlSysID, Number: "STMT-" + controlSysID}},
+	}, nil
+}
+
+func (c *eventClient) GetInstanceTimezone(ctx context.Context) (*time.Location, error) {
+	return time.UTC, nil
+}
+
+// conflictingStatementRepository puts the statements whose ServiceNow
+// sys_id is in conflicts in conflict and stores the rest as synced.
+type conflictingStatementRepository struct {
+	statement.Repository
+	conflicts map[string]bool
+}
+
+func (m *conflictingStatementRepository) Upsert(ctx context.Context, input statement.UpsertInput) (*statement.Statement, error) {
+	status := statement.SyncStatusSynced
+	if m.conflicts[input.SNSysID] {
+		status = statement.SyncStatusConflict
+	}
+	return &statement.Statement{ID: uuid.New(), SNSysID: input.SNSysID, SyncStatus: status}, nil
+}
+
+func TestService_ExecutePull_EventLog(t *testing.T) {
+	systemIDs := []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+	conflicted := systemIDs[1].String() + "/AC-3"
+	stmtRepo := &conflictingStatementRepository{conflicts: map[string]bool{conflicted: true}}
+	repo := &mockPullRepository{}
+	svc := NewService(repo, &mockSystemRepository{}, &mockControlRepository{}, stmtRepo, staticClientProvider{client: &eventClient{}}, Options{}, nil)
+
+	job, err := repo.Create(context.Background(), CreateInput{SystemIDs: systemIDs})
+	if err != nil {
+		t.Fatalf("failed to create job: %v", err)
+	}
+	svc.dispatch(context.Background())
+	waitFor(t, func() bool { return repo.status(job.ID) == JobStatusCompleted })
+
+	// Systems are pulled one at a time, each control followed by its statement
+	var want []PullEventLog
+	for _, id := range systemIDs {
+		for n := 1; n <= 5; n++ {
+			ctrl := fmt.Sprintf("%s/AC-%d", id, n)
+			stmt := "STMT-" + ctrl
+			want = append(want,
+				PullEventLog{SystemName: id.String(), ControlID: ctrl, EventType: EventControlUpserted, Message: "control " + ctrl + " upserted"},
+			)
+			if ctrl == conflicted {
+				want = append(want,
+					PullEventLog{SystemName: id.String(), ControlID: ctrl, StatementNumber: stmt, EventType: EventStatementUpserted, Message: "statement " + stmt + " upserted as conflict"},
+					PullEventLog{SystemName: id.String(), ControlID: ctrl, StatementNumber: stmt, EventType: EventConflictDetected, Message: "statement " + stmt + ": remote content conflicts with local changes"},
+				)
+				continue
+			}
+			want = append(want,
+				PullEventLog{SystemName: id.String(), ControlID: ctrl, StatementNumber: stmt, EventType: EventStatementUpserted, Message: "statement " + stmt + " upserted as synced"},
+			)
+		}
+	}
+
+	events, err := svc.GetJobEvents(context.Background(), job.ID, EventFilter{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(events) != len(want) {
+		t.Fatalf("expected %d events, got %d: %+v", len(want), len(events), events)
+	}
+	var last time.Time
+	for i, event := range events {
+		if event.Timestamp.IsZero() || event.Timestamp.Before(last) {
+			t.Errorf("event %d: expected timestamps in order, got %v after %v", i, event.Timestamp, last)
+		}
+		last = event.Timestamp
+		event.Timestamp = time.Time{}
+		if event != want[i] {
+			t.Errorf("event %d: expected %+v, got %+v", i, want[i], event)
+		}
+	}
+
+	filtered, err := svc.GetJobEvents(context.Background(), job.ID, EventFilter{
+		SystemName: systemIDs[2].String(),
+		EventType:  EventControlUpserted,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(filtered) != 5 {
+		t.Fatalf("expected the third system's 5 control events, got %+v", filtered)
+	}
+	for i, event := range filtered {
+		if want := fmt.Sprintf("%s/AC-%d", systemIDs[2], i+1); event.ControlID != want {
+			t.Errorf("event %d: expected control %s, got %s", i, want, event.ControlID)
+		}
+	}
+}
+
+func TestService_GetJobEvents_Errors(t *testing.T) {
+	repo := &mockPullRepository{}
+	svc := NewService(repo, nil, nil, nil, nil, Options{}, nil)
+	job, _ := repo.Create(context.Background(), CreateInput{SystemIDs: []uuid.UUID{uuid.New()}})
+
+	if _, err := svc.GetJobEvents(context.Background(), job.ID, EventFilter{EventType: "deleted"}); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for an unknown event type, got %v", err)
+	}
+	if _, err := svc.GetJobEvents(context.Background(), uuid.New(), EventFilter{}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for an unknown job, got %v", err)
+	}
+	events, err := svc.GetJobEvents(context.Background(), job.ID, EventFilter{})
+	if err != nil || events == nil || len(events) != 0 {
+		t.Errorf("expected an empty event list, got %v, %v", events, err)
+	}
+}
+
+func TestJobProgress_RemoveCounts(t *testing.T) {
+	progress := &jobProgress{}
+
+	// A failed attempt and another system pulled alongside it
+	var failed, other systemCounts
+	progress.addCounts(&failed, systemCounts{totalControls: 2})
+	progress.addCounts(&other, systemCounts{totalControls: 3})
+	progress.addCounts(&failed, systemCounts{completedControls: 1, totalStatements: 4})
+	progress.addCounts(&other, systemCounts{completedControls: 1})
+
+	progress.removeCounts(failed)
+
+	got := progress.snapshot()
+	if got.TotalControls != 3 || got.CompletedControls != 1 || got.TotalStatements != 0 {
+		t.Errorf("expected only the other system's counts, got %d/%d controls and %d statements",
+			got.CompletedControls, got.TotalControls, got.TotalStatements)
+	}
+}
+
+func TestProgress_AppendEvent_Cap(t *testing.T) {
+	var p Progress
+	p.appendEvent(PullEventLog{EventType: EventError, Message: "first error"})
+	for i := 0; i < MaxPullEvents+10; i++ {
+		p.appendEvent(PullEventLog{EventType: EventStatementUpserted, Message: fmt.Sprintf("statement %d", i)})
+	}
+
+	if len(p.Events) != MaxPullEvents {
+		t.Fatalf("expected the log capped at %d events, got %d", MaxPullEvents, len(p.Events))
+	}
+	if p.DroppedEvents != 11 {
+		t.Errorf("expected 11 dropped events, got %d", p.DroppedEvents)
+	}
+	// Upsert events are dropped before errors
+	if p.Events[0].Message != "first error" {
+		t.Errorf("expected the error kept, got %+v", p.Events[0])
+	}
+	if got := p.Events[1].Message; got != "statement 11" {
+		t.Errorf("expected the oldest upsert events dropped, got %q first", got)
+	}
+	if got := p.Events[len(p.Events)-1].Message; got != fmt.Sprintf("statement %d", MaxPullEvents+9) {
+		t.Errorf("expected the newest event last, got %q", got)
+	}
+}
+
+func TestProgress_JSON(t *testing.T) {
+	p := Progress{TotalSystems: 1}
+	p.appendEvent(PullEventLog{SystemName: "HR", ControlID: "AC-1", EventType: EventControlUpserted, Message: "control AC-1 upserted"})
+	p.appendEvent(PullEventLog{SystemName: "HR", EventType: EventWarning, Message: "instance timezone unknown"})
+
+	data, err := json.Marshal(p)
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+	var decoded Progress
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+	if len(decoded.Events) != 2 || decoded.Events[0] != p.Events[0] || decoded.Events[1] != p.Events[1] {
+		t.Errorf("expected events to round-trip, got %+v", decoded.Events)
+	}
+	if got := decoded.Errors(); len(got) != 1 || got[0] != "warning: instance timezone unknown" {
+		t.Errorf("unexpected errors: %v", got)
+	}
+
+	// Progress saved before the event log keeps its errors
+	var legacy Progress
+	if err := json.Unmarshal([]byte(`{"total_systems":2,"errors":["HR: connection refused"]}`), &legacy); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+	if legacy.TotalSystems != 2 || len(legacy.Events) != 1 || legacy.Events[0].EventType != EventError {
+		t.Errorf("expected the legacy error as an error event, got %+v", legacy)
+	}
+	if got := legacy.Errors(); len(got) != 1 || got[0] != "HR: connection refused" {
+		t.Errorf("unexpected errors: %v", got)
+	}
+}
+
+// slowClient holds each control fetch until its context is done, signalling
+// on started when a fetch begins.
+type slowClient struct {
+	servicenow.Client
+	started chan struct{}
+}
+
+func (c *slowClient) FetchControls(ctx context.Context, systemSysID string, config *servicenow.PaginationConfig, onProgress servicenow.ProgressCallback) (*servicenow.PaginatedResult[servicenow.ControlRecord], error) {
+	c.started <- struct{}{}
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func (c *slowClient) GetInstanceTimezone(ctx context.Context) (*time.Location, error) {
+	return time.UTC, nil
+}
+
+func TestService_Shutdown_StopsRunningJobs(t *testing.T) {
+	client := &slowClient{started: make(chan struct{}, 1)}
+	repo := &mockPullRepository{}
+	svc := NewService(repo, &mockSystemRepository{}, nil, nil, staticClientProvider{client: client}, Options{
+		MaxConcurrentJobs: 2,
+		ShutdownTimeout:   2 * time.Second,
+	}, nil)
+
+	job, err := repo.Create(context.Background(), CreateInput{SystemIDs: []uuid.UUID{uuid.New()}})
+	if err != nil {
+		t.Fatalf("failed to create job: %v", err)
+	}
+	svc.dispatch(context.Background())
+	<-client.started
+
+	start := time.Now()
+	if err := svc.Shutdown(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected the job to stop promptly, took %v", elapsed)
+	}
+
+	// The interrupted job is left paused, with its system still to pull
+	done, _ := repo.GetByID(context.Background(), job.ID)
+	if done.Status != JobStatusPaused {
+		t.Errorf("expected job paused, got %s", done.Status)
+	}
+	if len(done.Progress.CompletedSystemIDs) != 0 {
+		t.Errorf("expected the interrupted system not completed, got %v", done.Progress.CompletedSystemIDs)
+	}
+	if repo.lockCount() != 0 {
+		t.Errorf("expected system locks released, got %d", repo.lockCount())
+	}
+
+	// No jobs start after shutdown
+	queued := queuePendingJobs(repo, 1)
+	svc.dispatch(context.Background())
+	if got := repo.status(queued[0]); got != JobStatusPending {
+		t.Errorf("expected queued job left pending, got %s", got)
+	}
+}
+
+func TestService_Shutdown_Timeout(t *testing.T) {
+	// gatedClient ignores cancellation, so the job outlives the timeout
+	client := &gatedClient{proceed: make(chan struct{})}
+	repo := &mockPullRepository{}
+	svc := NewService(repo, &mockSystemRepository{}, nil, nil, staticClientProvider{client: client}, Options{
+		ShutdownTimeout: 50 * time.Millisecond,
+	}, nil)
+
+	job, err := repo.Create(context.Background(), CreateInput{SystemIDs: []uuid.UUID{uuid.New()}})
+	if err != nil {
+		t.Fatalf("failed to create job: %v", err)
+	}
+	svc.dispatch(context.Background())
+	waitFor(t, func() bool { return len(client.fetchedSystems()) == 1 })
+
+	if err := svc.Shutdown(); !errors.Is(err, ErrShutdownTimeout) {
+		t.Errorf("expected ErrShutdownTimeout, got %v", err)
+	}
+
+	close(client.proceed)
+	waitFor(t, func() bool { return repo.status(job.ID) == JobStatusPaused })
+}
@@ -12,12 +12,27 @@ var (
 	// ErrNoStatementsSelected is returned when no statements are selected for push.
 	ErrNoStatementsSelected = errors.New("no statements selected for push")
 
+	// ErrStatementNotFound is returned when a selected statement does not exist.
+	ErrStatementNotFound = errors.New("statement not found")
+
+	// ErrStatementEmpty is returned when a statement has no content to push.
+	ErrStatementEmpty = errors.New("statement has no content to push")
+
 	// ErrStatementNotModified is returned when trying to push a statement that hasn't been modified.
 	ErrStatementNotModified = errors.New("statement has not been modified")
 
 	// ErrStatementHasConflict is returned when trying to push a statement with unresolved conflict.
 	ErrStatementHasConflict = errors.New("statement has unresolved conflict")
 
+	// ErrStatementNotApproved is returned when review is required and a modified statement has not been approved.
+	ErrStatementNotApproved = errors.New("statement changes have not been approved")
+
+	// ErrStatementTypeNotPushable is returned when a statement's type is configured as never pushed.
+	ErrStatementTypeNotPushable = errors.New("statement type is not pushable")
+
+	// ErrStatementReadOnly is returned when a statement belongs to a read-only system.
+	ErrStatementReadOnly = errors.New("statement belongs to a read-only system")
+
 	// ErrNoConnection is returned when no ServiceNow connection is configured.
 	ErrNoConnection = errors.New("no ServiceNow connection configured")
 
@@ -4,6 +4,8 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/controlcrud/backend/internal/infrastructure/email"
 )
 
 // JobStatus represents the state of a push job.
@@ -30,6 +32,12 @@ type Job struct {
 	StartedAt    *time.Time       `json:"started_at,omitempty"`
 	CompletedAt  *time.Time       `json:"completed_at,omitempty"`
 	CreatedAt    time.Time        `json:"created_at"`
+
+	// ConnectionLabel is the ServiceNow connection the job pushes to.
+	ConnectionLabel string `json:"connection_label"`
+
+	// Errors lists statements that still failed after every retry.
+	Errors []string `json:"errors,omitempty"`
 }
 
 // StatementResult represents the result of pushing a single statement.
@@ -38,11 +46,78 @@ type StatementResult struct {
 	Success     bool       `json:"success"`
 	Error       *string    `json:"error,omitempty"`
 	PushedAt    *time.Time `json:"pushed_at,omitempty"`
+	RetryCount  int        `json:"retry_count"`
 }
 
 // StartRequest contains the parameters for starting a push job.
 type StartRequest struct {
-	StatementIDs []uuid.UUID `json:"statement_ids"`
+	StatementIDs    []uuid.UUID `json:"statement_ids"`
+	ConnectionLabel string      `json:"connection_label,omitempty"` // Defaults to the default connection
+}
+
+// DryRunResult reports which statements a push would send, without sending them.
+type DryRunResult struct {
+	Statements     []DryRunStatement `json:"statements"`
+	WouldPushCount int               `json:"would_push_count"`
+	BlockedCount   int               `json:"blocked_count"`
+}
+
+// DryRunStatement is the dry-run outcome for a single statement.
+type DryRunStatement struct {
+	StatementID   uuid.UUID `json:"statement_id"`
+	ControlName   string    `json:"control_name,omitempty"`
+	ContentLength int       `json:"content_length"`
+	WouldPush     bool      `json:"would_push"`
+	BlockReason   string    `json:"block_reason,omitempty"`
+}
+
+// BlockReasonTypeNotPushable is the dry-run block reason for statements
+// whose type is listed in Options.NonPushableTypes.
+const BlockReasonTypeNotPushable = "type_not_pushable"
+
+// BlockReasonReadOnly is the dry-run block reason for statements of
+// read-only systems.
+const BlockReasonReadOnly = "system_read_only"
+
+// Options configures optional push behaviour.
+type Options struct {
+	// ReviewRequired only allows approved statements to be pushed.
+	ReviewRequired bool
+
+	// NonPushableTypes lists statement types that are kept local and never
+	// pushed to ServiceNow.
+	NonPushableTypes []string
+
+	// BulkPushThreshold is the number of statements above which a job is
+	// pushed in a single import set request. Zero disables bulk pushes.
+	BulkPushThreshold int
+
+	// AlertSender emails AlertEmail a list of the failed statements when a
+	// job finishes with failures. Nil disables alerts.
+	AlertSender email.Sender
+	AlertEmail  string
+
+	// Retry controls how often a statement update that failed with a
+	// ServiceNow server error is tried again. The zero value does not retry.
+	Retry PushRetryPolicy
+}
+
+// PushRetryPolicy retries a statement update up to MaxRetries times. The
+// first retry waits InitialDelay and each further retry waits twice as long,
+// up to MaxDelay when it is set.
+type PushRetryPolicy struct {
+	MaxRetries   int
+	InitialDelay time.Duration
+	MaxDelay     time.Duration
+}
+
+// backoff returns the delay before the given retry, counting from 1.
+func (p PushRetryPolicy) backoff(retry int) time.Duration {
+	delay := p.InitialDelay << (retry - 1)
+	if p.MaxDelay > 0 && (delay > p.MaxDelay || delay < p.InitialDelay) {
+		return p.MaxDelay
+	}
+	return delay
 }
 
 // IsPushJobActive returns true if the job is still running.
@@ -2,20 +2,27 @@ package push
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/controlcrud/backend/internal/domain/connection"
+	"github.com/controlcrud/backend/internal/domain/control"
 	"github.com/controlcrud/backend/internal/domain/statement"
+	"github.com/controlcrud/backend/internal/infrastructure/servicenow"
 )
 
 // Service provides business logic for push operations.
 type Service struct {
 	stmtRepo    statement.Repository
+	controlRepo control.Repository
 	connService *connection.Service
+	opts        Options
 	logger      *slog.Logger
 
 	// In-memory job storage (could be replaced with database)
@@ -26,12 +33,19 @@ type Service struct {
 // NewService creates a new push service.
 func NewService(
 	stmtRepo statement.Repository,
+	controlRepo control.Repository,
 	connService *connection.Service,
+	opts Options,
 	logger *slog.Logger,
 ) *Service {
+	if logger == nil {
+		logger = slog.Default()
+	}
 	return &Service{
 		stmtRepo:    stmtRepo,
+		controlRepo: controlRepo,
 		connService: connService,
+		opts:        opts,
 		logger:      logger,
 		jobs:        make(map[uuid.UUID]*Job),
 	}
@@ -43,8 +57,22 @@ func (s *Service) StartPush(ctx context.Context, req StartRequest) (*Job, error)
 		return nil, ErrNoStatementsSelected
 	}
 
+	connectionLabel, err := connection.NormalizeLabel(req.ConnectionLabel)
+	if err != nil {
+		return nil, err
+	}
+
+	// Statements of read-only systems are never pushed
+	readOnly, err := s.stmtRepo.ListReadOnly(ctx, req.StatementIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check read-only statements: %w", err)
+	}
+	if len(readOnly) > 0 {
+		return nil, fmt.Errorf("statement %s: %w", readOnly[0], ErrStatementReadOnly)
+	}
+
 	// Verify we have a ServiceNow connection
-	_, err := s.connService.GetSNClient(ctx)
+	_, err = s.connService.GetSNClientByLabel(ctx, connectionLabel)
 	if err != nil {
 		if err == connection.ErrConnectionNotFound {
 			return nil, ErrNoConnection
@@ -58,11 +86,8 @@ func (s *Service) StartPush(ctx context.Context, req StartRequest) (*Job, error)
 		if err != nil {
 			return nil, fmt.Errorf("statement %s not found: %w", stmtID, err)
 		}
-		if !stmt.IsModified {
-			return nil, fmt.Errorf("statement %s: %w", stmtID, ErrStatementNotModified)
-		}
-		if stmt.SyncStatus == statement.SyncStatusConflict {
-			return nil, fmt.Errorf("statement %s: %w", stmtID, ErrStatementHasConflict)
+		if err := s.checkPushable(stmt); err != nil {
+			return nil, fmt.Errorf("statement %s: %w", stmtID, err)
 		}
 	}
 
@@ -79,6 +104,8 @@ func (s *Service) StartPush(ctx context.Context, req StartRequest) (*Job, error)
 		Failed:       0,
 		StartedAt:    &now,
 		CreatedAt:    now,
+
+		ConnectionLabel: connectionLabel,
 	}
 
 	// Store job
@@ -92,6 +119,83 @@ func (s *Service) StartPush(ctx context.Context, req StartRequest) (*Job, error)
 	return job, nil
 }
 
+// DryRun validates the requested statements the same way StartPush does and
+// reports which would be pushed, without starting a job or contacting ServiceNow.
+func (s *Service) DryRun(ctx context.Context, req StartRequest) (*DryRunResult, error) {
+	if len(req.StatementIDs) == 0 {
+		return nil, ErrNoStatementsSelected
+	}
+
+	result := &DryRunResult{
+		Statements: make([]DryRunStatement, 0, len(req.StatementIDs)),
+	}
+
+	readOnly, err := s.stmtRepo.ListReadOnly(ctx, req.StatementIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check read-only statements: %w", err)
+	}
+
+	for _, stmtID := range req.StatementIDs {
+		entry := DryRunStatement{StatementID: stmtID}
+
+		stmt, err := s.stmtRepo.GetByID(ctx, stmtID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get statement %s: %w", stmtID, err)
+		}
+
+		if stmt != nil {
+			entry.ContentLength = len(stmt.GetContent())
+			if s.controlRepo != nil {
+				ctrl, err := s.controlRepo.GetByID(ctx, stmt.ControlID)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get control for statement %s: %w", stmtID, err)
+				}
+				if ctrl != nil {
+					entry.ControlName = ctrl.ControlName
+				}
+			}
+		}
+
+		if err := s.checkPushable(stmt); errors.Is(err, ErrStatementTypeNotPushable) {
+			entry.BlockReason = BlockReasonTypeNotPushable
+		} else if err != nil {
+			entry.BlockReason = err.Error()
+		} else if slices.Contains(readOnly, stmtID) {
+			entry.BlockReason = BlockReasonReadOnly
+		} else if entry.ContentLength == 0 {
+			entry.BlockReason = ErrStatementEmpty.Error()
+		} else {
+			entry.WouldPush = true
+			result.WouldPushCount++
+		}
+
+		result.Statements = append(result.Statements, entry)
+	}
+
+	result.BlockedCount = len(result.Statements) - result.WouldPushCount
+	return result, nil
+}
+
+// checkPushable returns the reason a statement cannot be pushed, or nil.
+func (s *Service) checkPushable(stmt *statement.Statement) error {
+	if stmt == nil {
+		return ErrStatementNotFound
+	}
+	if slices.Contains(s.opts.NonPushableTypes, stmt.StatementType) {
+		return ErrStatementTypeNotPushable
+	}
+	if !stmt.IsModified {
+		return ErrStatementNotModified
+	}
+	if stmt.SyncStatus == statement.SyncStatusConflict {
+		return ErrStatementHasConflict
+	}
+	if s.opts.ReviewRequired && stmt.ReviewStatus != statement.ReviewStatusApproved {
+		return ErrStatementNotApproved
+	}
+	return nil
+}
+
 // GetJob retrieves a push job by ID.
 func (s *Service) GetJob(ctx context.Context, jobID uuid.UUID) (*Job, error) {
 	s.jobsMu.RLock()
@@ -105,6 +209,20 @@ func (s *Service) GetJob(ctx context.Context, jobID uuid.UUID) (*Job, error) {
 	return job, nil
 }
 
+// ActiveJobCount returns the number of pending or running push jobs.
+func (s *Service) ActiveJobCount() int {
+	s.jobsMu.RLock()
+	defer s.jobsMu.RUnlock()
+
+	count := 0
+	for _, job := range s.jobs {
+		if job.Status == JobStatusPending || job.Status == JobStatusRunning {
+			count++
+		}
+	}
+	return count
+}
+
 // CancelJob cancels a running push job.
 func (s *Service) CancelJob(ctx context.Context, jobID uuid.UUID) error {
 	s.jobsMu.Lock()
@@ -133,7 +251,7 @@ func (s *Service) executePush(job *Job) {
 	s.jobsMu.Unlock()
 
 	// Get ServiceNow client
-	snClient, err := s.connService.GetSNClient(ctx)
+	snClient, err := s.connService.GetSNClientByLabel(ctx, job.ConnectionLabel)
 	if err != nil {
 		s.jobsMu.Lock()
 		job.Status = JobStatusFailed
@@ -146,29 +264,27 @@ func (s *Service) executePush(job *Job) {
 		return
 	}
 
-	// Process each statement
-	for _, stmtID := range job.StatementIDs {
-		// Check if job was cancelled
-		s.jobsMu.RLock()
-		cancelled := job.Status == JobStatusCancelled
-		s.jobsMu.RUnlock()
-		if cancelled {
-			s.logger.Info("push job cancelled", "job_id", job.ID)
-			return
-		}
+	// Large jobs go to ServiceNow in one import set request when the
+	// mapping has an import set table
+	pushed := false
+	if s.opts.BulkPushThreshold > 0 && len(job.StatementIDs) > s.opts.BulkPushThreshold {
+		pushed = s.bulkPush(ctx, job, snClient)
+	}
 
-		result := s.pushStatement(ctx, snClient, stmtID)
+	// Otherwise process each statement
+	if !pushed {
+		for _, stmtID := range job.StatementIDs {
+			// Check if job was cancelled
+			s.jobsMu.RLock()
+			cancelled := job.Status == JobStatusCancelled
+			s.jobsMu.RUnlock()
+			if cancelled {
+				s.logger.Info("push job cancelled", "job_id", job.ID)
+				return
+			}
 
-		// Update job with result
-		s.jobsMu.Lock()
-		job.Results = append(job.Results, result)
-		job.Completed++
-		if result.Success {
-			job.Succeeded++
-		} else {
-			job.Failed++
+			s.recordResult(job, s.pushStatement(ctx, snClient, job.ID, stmtID))
 		}
-		s.jobsMu.Unlock()
 	}
 
 	// Mark job as completed
@@ -189,12 +305,133 @@ func (s *Service) executePush(job *Job) {
 		"total", job.TotalCount,
 		"succeeded", job.Succeeded,
 		"failed", job.Failed)
+
+	if job.Failed > 0 {
+		s.alertFailures(ctx, job)
+	}
+}
+
+// alertFailures emails the alert address a summary of the job's failed
+// statements. Delivery errors are logged and do not affect the job.
+func (s *Service) alertFailures(ctx context.Context, job *Job) {
+	if s.opts.AlertSender == nil {
+		return
+	}
+
+	s.jobsMu.RLock()
+	subject, body := failureAlert(job)
+	s.jobsMu.RUnlock()
+
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+	if err := s.opts.AlertSender.Send(ctx, s.opts.AlertEmail, subject, body); err != nil {
+		s.logger.Warn("failed to send push failure alert", "job_id", job.ID, "error", err)
+	}
+}
+
+// failureAlert formats the subject and body of a push failure alert, listing
+// each failed statement with its error.
+func failureAlert(job *Job) (subject, body string) {
+	subject = fmt.Sprintf("Push job %s: %d of %d statements failed", job.ID, job.Failed, job.TotalCount)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Push job %s to connection %q finished with status %s.\n\n", job.ID, job.ConnectionLabel, job.Status)
+	fmt.Fprintf(&b, "Total: %d\nSucceeded: %d\nFailed: %d\n\n", job.TotalCount, job.Succeeded, job.Failed)
+	b.WriteString("Failed statements:\n")
+	for _, result := range job.Results {
+		if result.Success {
+			continue
+		}
+		msg := "unknown error"
+		if result.Error != nil {
+			msg = *result.Error
+		}
+		fmt.Fprintf(&b, "- %s: %s\n", result.StatementID, msg)
+	}
+	return subject, b.String()
+}
+
+// recordResult adds a statement's push result to the job.
+func (s *Service) recordResult(job *Job, result StatementResult) {
+	s.jobsMu.Lock()
+	defer s.jobsMu.Unlock()
+	job.Results = append(job.Results, result)
+	job.Completed++
+	if result.Success {
+		job.Succeeded++
+	} else {
+		job.Failed++
+	}
+}
+
+// bulkPush pushes all of the job's statements in a single import set
+// request and records a result for each. It returns false, without
+// recording anything, if the connection has no import set table so the
+// caller can push the statements one at a time instead.
+func (s *Service) bulkPush(ctx context.Context, job *Job, snClient interface {
+	BulkPushStatements(ctx context.Context, updates []servicenow.StatementUpdate) ([]servicenow.BulkPushResult, error)
+}) bool {
+	results := make([]StatementResult, len(job.StatementIDs))
+	var updates []servicenow.StatementUpdate
+	var positions []int // Index in results of each update
+
+	for i, stmtID := range job.StatementIDs {
+		stmt, err := s.stmtRepo.GetByID(ctx, stmtID)
+		switch {
+		case err != nil:
+			results[i] = failedResult(stmtID, fmt.Sprintf("failed to get statement: %v", err))
+		case stmt == nil:
+			results[i] = failedResult(stmtID, ErrStatementNotFound.Error())
+		case stmt.GetContent() == "":
+			results[i] = failedResult(stmtID, ErrStatementEmpty.Error())
+		default:
+			updates = append(updates, servicenow.StatementUpdate{SysID: stmt.SNSysID, Content: stmt.GetContent()})
+			positions = append(positions, i)
+		}
+	}
+
+	rows, err := snClient.BulkPushStatements(ctx, updates)
+	if errors.Is(err, servicenow.ErrImportSetNotConfigured) {
+		return false
+	}
+	if err != nil {
+		s.logger.Error("bulk push failed", "job_id", job.ID, "statements", len(updates), "error", err)
+	}
+
+	for n, i := range positions {
+		stmtID := job.StatementIDs[i]
+		switch {
+		case err != nil:
+			results[i] = failedResult(stmtID, fmt.Sprintf("failed to push to ServiceNow: %v", err))
+		case !rows[n].Success:
+			results[i] = failedResult(stmtID, fmt.Sprintf("ServiceNow rejected the update: %s", rows[n].Error))
+		default:
+			if err := s.stmtRepo.MarkAsSynced(ctx, stmtID, job.ID); err != nil {
+				s.logger.Error("failed to mark statement as synced",
+					"statement_id", stmtID,
+					"error", err)
+			}
+			now := time.Now()
+			results[i] = StatementResult{StatementID: stmtID, Success: true, PushedAt: &now}
+		}
+	}
+
+	for _, result := range results {
+		s.recordResult(job, result)
+	}
+	s.logger.Info("bulk pushed statements", "job_id", job.ID, "statements", len(updates))
+	return true
+}
+
+// failedResult returns a failed push result with the given message.
+func failedResult(stmtID uuid.UUID, errMsg string) StatementResult {
+	return StatementResult{StatementID: stmtID, Success: false, Error: &errMsg}
 }
 
 // pushStatement pushes a single statement to ServiceNow.
 func (s *Service) pushStatement(ctx context.Context, snClient interface {
 	UpdateStatement(ctx context.Context, sysID string, content string) error
-}, stmtID uuid.UUID) StatementResult {
+}, jobID, stmtID uuid.UUID) StatementResult {
 	// Get the statement
 	stmt, err := s.stmtRepo.GetByID(ctx, stmtID)
 	if err != nil {
@@ -218,22 +455,27 @@ func (s *Service) pushStatement(ctx context.Context, snClient interface {
 	}
 
 	// Push to ServiceNow
-	err = snClient.UpdateStatement(ctx, stmt.SNSysID, content)
+	retries, err := s.updateWithRetry(ctx, snClient, jobID, stmt.SNSysID, content)
 	if err != nil {
 		errMsg := fmt.Sprintf("failed to push to ServiceNow: %v", err)
 		s.logger.Error("push statement failed",
 			"statement_id", stmtID,
 			"sn_sys_id", stmt.SNSysID,
+			"retries", retries,
 			"error", err)
+		if retries > 0 {
+			s.addJobError(jobID, fmt.Sprintf("statement %s: giving up after %d retries: %v", stmtID, retries, err))
+		}
 		return StatementResult{
 			StatementID: stmtID,
 			Success:     false,
 			Error:       &errMsg,
+			RetryCount:  retries,
 		}
 	}
 
 	// Mark statement as synced
-	err = s.stmtRepo.MarkAsSynced(ctx, stmtID)
+	err = s.stmtRepo.MarkAsSynced(ctx, stmtID, jobID)
 	if err != nil {
 		s.logger.Error("failed to mark statement as synced",
 			"statement_id", stmtID,
@@ -246,5 +488,43 @@ func (s *Service) pushStatement(ctx context.Context, snClient interface {
 		StatementID: stmtID,
 		Success:     true,
 		PushedAt:    &now,
+		RetryCount:  retries,
+	}
+}
+
+// updateWithRetry writes a statement's content to ServiceNow, retrying an
+// update that failed with a server error as configured by the retry policy.
+// Other errors, such as servicenow.ErrAuthFailed or servicenow.ErrNotFound,
+// will not succeed on a retry and are returned at once. It returns the
+// number of retries made and the last error.
+func (s *Service) updateWithRetry(ctx context.Context, snClient interface {
+	UpdateStatement(ctx context.Context, sysID string, content string) error
+}, jobID uuid.UUID, sysID, content string) (int, error) {
+	policy := s.opts.Retry
+	err := snClient.UpdateStatement(ctx, sysID, content)
+	retry := 0
+	for errors.Is(err, servicenow.ErrServerError) && retry < policy.MaxRetries && ctx.Err() == nil {
+		retry++
+		delay := policy.backoff(retry)
+		s.logger.Warn("retrying statement push",
+			"job_id", jobID, "sn_sys_id", sysID, "retry", retry, "delay", delay, "error", err)
+
+		select {
+		case <-ctx.Done():
+			return retry - 1, err
+		case <-time.After(delay):
+		}
+
+		err = snClient.UpdateStatement(ctx, sysID, content)
+	}
+	return retry, err
+}
+
+// addJobError appends a message to the job's errors.
+func (s *Service) addJobError(jobID uuid.UUID, msg string) {
+	s.jobsMu.Lock()
+	defer s.jobsMu.Unlock()
+	if job, ok := s.jobs[jobID]; ok {
+		job.Errors = append(job.Errors, msg)
 	}
 }
@@ -0,0 +1,488 @@
+package push
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/controlcrud/backend/internal/domain/connection"
+	"github.com/controlcrud/backend/internal/domain/control"
+	"github.com/controlcrud/backend/internal/domain/statement"
+	"github.com/controlcrud/backend/internal/infrastructure/servicenow"
+)
+
+// mockStatementRepository implements the statement.Repository methods used
+// by DryRun and by pushes.
+type mockStatementRepository struct {
+	statement.Repository
+	stmts    map[uuid.UUID]*statement.Statement
+	readOnly []uuid.UUID
+	synced   []uuid.UUID
+}
+
+func (m *mockStatementRepository) GetByID(ctx context.Context, id uuid.UUID) (*statement.Statement, error) {
+	return m.stmts[id], nil
+}
+
+func (m *mockStatementRepository) ListReadOnly(ctx context.Context, ids []uuid.UUID) ([]uuid.UUID, error) {
+	var readOnly []uuid.UUID
+	for _, id := range ids {
+		if slices.Contains(m.readOnly, id) {
+			readOnly = append(readOnly, id)
+		}
+	}
+	return readOnly, nil
+}
+
+func (m *mockStatementRepository) MarkAsSynced(ctx context.Context, id, jobID uuid.UUID) error {
+	m.synced = append(m.synced, id)
+	if stmt := m.stmts[id]; stmt != nil {
+		stmt.LastPushBy = &jobID
+	}
+	return nil
+}
+
+// mockControlRepository implements the control.Repository methods used by DryRun.
+type mockControlRepository struct {
+	control.Repository
+	controls map[uuid.UUID]*control.Control
+}
+
+func (m *mockControlRepository) GetByID(ctx context.Context, id uuid.UUID) (*control.Control, error) {
+	return m.controls[id], nil
+}
+
+func TestService_DryRun(t *testing.T) {
+	ctrl := &control.Control{ID: uuid.New(), ControlName: "AC-2 Account Management"}
+
+	ready := &statement.Statement{ID: uuid.New(), ControlID: ctrl.ID, IsModified: true, LocalContent: "Accounts are reviewed.", ReviewStatus: statement.ReviewStatusApproved}
+	unmodified := &statement.Statement{ID: uuid.New(), ControlID: ctrl.ID, RemoteContent: "Remote text."}
+	conflicted := &statement.Statement{ID: uuid.New(), ControlID: ctrl.ID, IsModified: true, LocalContent: "Local text.", SyncStatus: statement.SyncStatusConflict}
+	unapproved := &statement.Statement{ID: uuid.New(), ControlID: ctrl.ID, IsModified: true, LocalContent: "Draft text."}
+	empty := &statement.Statement{ID: uuid.New(), ControlID: ctrl.ID, IsModified: true, ReviewStatus: statement.ReviewStatusApproved}
+	missing := uuid.New()
+
+	stmtRepo := &mockStatementRepository{stmts: map[uuid.UUID]*statement.Statement{}}
+	for _, stmt := range []*statement.Statement{ready, unmodified, conflicted, unapproved, empty} {
+		stmtRepo.stmts[stmt.ID] = stmt
+	}
+	controlRepo := &mockControlRepository{controls: map[uuid.UUID]*control.Control{ctrl.ID: ctrl}}
+
+	// A nil connection service panics if DryRun tries to reach ServiceNow
+	svc := NewService(stmtRepo, controlRepo, nil, Options{ReviewRequired: true}, nil)
+
+	result, err := svc.DryRun(context.Background(), StartRequest{
+		StatementIDs: []uuid.UUID{ready.ID, unmodified.ID, conflicted.ID, unapproved.ID, empty.ID, missing},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []struct {
+		id        uuid.UUID
+		wouldPush bool
+		reason    error
+	}{
+		{ready.ID, true, nil},
+		{unmodified.ID, false, ErrStatementNotModified},
+		{conflicted.ID, false, ErrStatementHasConflict},
+		{unapproved.ID, false, ErrStatementNotApproved},
+		{empty.ID, false, ErrStatementEmpty},
+		{missing, false, ErrStatementNotFound},
+	}
+
+	if len(result.Statements) != len(want) {
+		t.Fatalf("expected %d statements, got %d", len(want), len(result.Statements))
+	}
+	for i, w := range want {
+		got := result.Statements[i]
+		if got.StatementID != w.id {
+			t.Errorf("statement %d: expected ID %s, got %s", i, w.id, got.StatementID)
+		}
+		if got.WouldPush != w.wouldPush {
+			t.Errorf("statement %d: expected WouldPush %v, got %v", i, w.wouldPush, got.WouldPush)
+		}
+		wantReason := ""
+		if w.reason != nil {
+			wantReason = w.reason.Error()
+		}
+		if got.BlockReason != wantReason {
+			t.Errorf("statement %d: expected block reason %q, got %q", i, wantReason, got.BlockReason)
+		}
+	}
+
+	if got := result.Statements[0]; got.ControlName != ctrl.ControlName || got.ContentLength != len(ready.LocalContent) {
+		t.Errorf("unexpected details for pushable statement: %+v", got)
+	}
+	if result.WouldPushCount != 1 || result.BlockedCount != 5 {
+		t.Errorf("expected 1 pushable and 5 blocked, got %d and %d", result.WouldPushCount, result.BlockedCount)
+	}
+}
+
+func TestService_DryRun_ReviewNotRequired(t *testing.T) {
+	stmt := &statement.Statement{ID: uuid.New(), IsModified: true, LocalContent: "Draft text."}
+	stmtRepo := &mockStatementRepository{stmts: map[uuid.UUID]*statement.Statement{stmt.ID: stmt}}
+	svc := NewService(stmtRepo, nil, nil, Options{}, nil)
+
+	result, err := svc.DryRun(context.Background(), StartRequest{StatementIDs: []uuid.UUID{stmt.ID}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.Statements[0].WouldPush {
+		t.Errorf("expected unapproved statement to be pushable, got block reason %q", result.Statements[0].BlockReason)
+	}
+}
+
+func TestService_DryRun_NonPushableTypes(t *testing.T) {
+	evidence := &statement.Statement{ID: uuid.New(), StatementType: "evidence", IsModified: true, LocalContent: "Scan report attached."}
+	implementation := &statement.Statement{ID: uuid.New(), StatementType: "implementation", IsModified: true, LocalContent: "Accounts are reviewed."}
+	stmtRepo := &mockStatementRepository{stmts: map[uuid.UUID]*statement.Statement{
+		evidence.ID:       evidence,
+		implementation.ID: implementation,
+	}}
+	svc := NewService(stmtRepo, nil, nil, Options{NonPushableTypes: []string{"evidence"}}, nil)
+
+	result, err := svc.DryRun(context.Background(), StartRequest{StatementIDs: []uuid.UUID{evidence.ID, implementation.ID}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := result.Statements[0]; got.WouldPush || got.BlockReason != BlockReasonTypeNotPushable {
+		t.Errorf("expected evidence statement blocked as %q, got %+v", BlockReasonTypeNotPushable, got)
+	}
+	if got := result.Statements[1]; !got.WouldPush {
+		t.Errorf("expected implementation statement to be pushable, got block reason %q", got.BlockReason)
+	}
+	if result.WouldPushCount != 1 || result.BlockedCount != 1 {
+		t.Errorf("expected 1 pushable and 1 blocked, got %d and %d", result.WouldPushCount, result.BlockedCount)
+	}
+
+	if err := svc.checkPushable(evidence); !errors.Is(err, ErrStatementTypeNotPushable) {
+		t.Errorf("expected ErrStatementTypeNotPushable, got %v", err)
+	}
+}
+
+func TestService_DryRun_NoStatements(t *testing.T) {
+	svc := NewService(&mockStatementRepository{}, nil, nil, Options{}, nil)
+
+	if _, err := svc.DryRun(context.Background(), StartRequest{}); !errors.Is(err, ErrNoStatementsSelected) {
+		t.Errorf("expected ErrNoStatementsSelected, got %v", err)
+	}
+}
+
+func TestService_StartPush_InvalidConnectionLabel(t *testing.T) {
+	svc := NewService(&mockStatementRepository{}, nil, nil, Options{}, nil)
+
+	_, err := svc.StartPush(context.Background(), StartRequest{
+		StatementIDs:    []uuid.UUID{uuid.New()},
+		ConnectionLabel: "prod staging",
+	})
+	if !errors.Is(err, connection.ErrInvalidLabel) {
+		t.Errorf("expected ErrInvalidLabel, got %v", err)
+	}
+}
+
+func TestService_ImportMode(t *testing.T) {
+	editable := &statement.Statement{ID: uuid.New(), IsModified: true, LocalContent: "Accounts are reviewed."}
+	readOnly := &statement.Statement{ID: uuid.New(), IsModified: true, LocalContent: "Mirrored from ServiceNow."}
+	stmtRepo := &mockStatementRepository{
+		stmts:    map[uuid.UUID]*statement.Statement{editable.ID: editable, readOnly.ID: readOnly},
+		readOnly: []uuid.UUID{readOnly.ID},
+	}
+	// A nil connection service panics if StartPush gets past validation
+	svc := NewService(stmtRepo, nil, nil, Options{}, nil)
+
+	result, err := svc.DryRun(context.Background(), StartRequest{StatementIDs: []uuid.UUID{editable.ID, readOnly.ID}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := result.Statements[0]; !got.WouldPush {
+		t.Errorf("expected editable statement to be pushable, got block reason %q", got.BlockReason)
+	}
+	if got := result.Statements[1]; got.WouldPush || got.BlockReason != BlockReasonReadOnly {
+		t.Errorf("expected read-only statement blocked as %q, got %+v", BlockReasonReadOnly, got)
+	}
+
+	_, err = svc.StartPush(context.Background(), StartRequest{StatementIDs: []uuid.UUID{editable.ID, readOnly.ID}})
+	if !errors.Is(err, ErrStatementReadOnly) {
+		t.Errorf("expected ErrStatementReadOnly, got %v", err)
+	}
+}
+
+// bulkClient records bulk pushes and rejects the sys_ids in reject.
+type bulkClient struct {
+	calls  [][]servicenow.StatementUpdate
+	reject map[string]bool
+	err    error
+}
+
+func (c *bulkClient) BulkPushStatements(ctx context.Context, updates []servicenow.StatementUpdate) ([]servicenow.BulkPushResult, error) {
+	c.calls = append(c.calls, updates)
+	if c.err != nil {
+		return nil, c.err
+	}
+	results := make([]servicenow.BulkPushResult, len(updates))
+	for i, update := range updates {
+		results[i] = servicenow.BulkPushResult{SysID: update.SysID, Success: !c.reject[update.SysID], Status: "updated"}
+		if c.reject[update.SysID] {
+			results[i].Status = "error"
+			results[i].Error = "Target record not found"
+		}
+	}
+	return results, nil
+}
+
+func TestService_BulkPush(t *testing.T) {
+	stmtRepo := &mockStatementRepository{stmts: map[uuid.UUID]*statement.Statement{}}
+	job := &Job{ID: uuid.New()}
+	for i := 0; i < 12; i++ {
+		stmt := &statement.Statement{ID: uuid.New(), SNSysID: fmt.Sprintf("stmt%d", i), IsModified: true, LocalContent: fmt.Sprintf("Statement %d", i)}
+		if i == 5 {
+			stmt.LocalContent = "" // Nothing to push
+		}
+		stmtRepo.stmts[stmt.ID] = stmt
+		job.StatementIDs = append(job.StatementIDs, stmt.ID)
+	}
+	client := &bulkClient{reject: map[string]bool{"stmt7": true}}
+	svc := NewService(stmtRepo, nil, nil, Options{BulkPushThreshold: 10}, nil)
+
+	if !svc.bulkPush(context.Background(), job, client) {
+		t.Fatal("expected bulk push to be used")
+	}
+
+	if len(client.calls) != 1 || len(client.calls[0]) != 11 {
+		t.Fatalf("expected one request with 11 statements, got %d requests", len(client.calls))
+	}
+	if job.Completed != 12 || job.Succeeded != 10 || job.Failed != 2 {
+		t.Errorf("expected 10 succeeded and 2 failed of 12, got %d/%d of %d", job.Succeeded, job.Failed, job.Completed)
+	}
+	for i, result := range job.Results {
+		if result.StatementID != job.StatementIDs[i] {
+			t.Errorf("result %d: expected results in job order", i)
+		}
+		if wantSuccess := i != 5 && i != 7; result.Success != wantSuccess {
+			t.Errorf("result %d: expected success %v, got %+v", i, wantSuccess, result)
+		}
+	}
+	if len(stmtRepo.synced) != 10 {
+		t.Errorf("expected 10 statements marked synced, got %d", len(stmtRepo.synced))
+	}
+	for _, id := range stmtRepo.synced {
+		if got := stmtRepo.stmts[id].LastPushBy; got == nil || *got != job.ID {
+			t.Errorf("statement %s: expected last push by job %s, got %v", id, job.ID, got)
+		}
+	}
+}
+
+// updateClient accepts every single-statement update.
+type updateClient struct{}
+
+func (updateClient) UpdateStatement(ctx context.Context, sysID string, content string) error {
+	return nil
+}
+
+func TestService_PushStatement_RecordsJob(t *testing.T) {
+	stmt := &statement.Statement{ID: uuid.New(), SNSysID: "stmt0", IsModified: true, LocalContent: "Text."}
+	stmtRepo := &mockStatementRepository{stmts: map[uuid.UUID]*statement.Statement{stmt.ID: stmt}}
+	svc := NewService(stmtRepo, nil, nil, Options{}, nil)
+
+	jobID := uuid.New()
+	if result := svc.pushStatement(context.Background(), updateClient{}, jobID, stmt.ID); !result.Success {
+		t.Fatalf("expected push to succeed, got %+v", result)
+	}
+	if stmt.LastPushBy == nil || *stmt.LastPushBy != jobID {
+		t.Errorf("expected last push by job %s, got %v", jobID, stmt.LastPushBy)
+	}
+}
+
+// newFlakyInstance returns a ServiceNow instance that answers the first
+// failures statement updates with 503 and later ones with 200, and a client
+// for it that does not retry by itself.
+func newFlakyInstance(t *testing.T, failures int32) (*servicenow.SNClient, *int32) {
+	t.Helper()
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) <= failures {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"result":{}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	config := servicenow.DefaultConfig(server.URL)
+	config.MaxRetries = 0
+	client, err := servicenow.NewSNClient(config)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	return client, &requests
+}
+
+func TestService_PushStatement_RetriesServerErrors(t *testing.T) {
+	client, requests := newFlakyInstance(t, 2)
+
+	stmt := &statement.Statement{ID: uuid.New(), SNSysID: "stmt0", IsModified: true, LocalContent: "Text."}
+	stmtRepo := &mockStatementRepository{stmts: map[uuid.UUID]*statement.Statement{stmt.ID: stmt}}
+	svc := NewService(stmtRepo, nil, nil, Options{
+		Retry: PushRetryPolicy{MaxRetries: 3, InitialDelay: time.Millisecond, MaxDelay: 2 * time.Millisecond},
+	}, nil)
+
+	result := svc.pushStatement(context.Background(), client, uuid.New(), stmt.ID)
+	if !result.Success {
+		t.Fatalf("expected push to succeed, got error %v", *result.Error)
+	}
+	if result.RetryCount != 2 {
+		t.Errorf("expected retry_count 2, got %d", result.RetryCount)
+	}
+	if got := atomic.LoadInt32(requests); got != 3 {
+		t.Errorf("expected 3 requests, got %d", got)
+	}
+	if !slices.Contains(stmtRepo.synced, stmt.ID) {
+		t.Error("expected statement to be marked synced")
+	}
+}
+
+func TestService_PushStatement_RetriesExhausted(t *testing.T) {
+	client, requests := newFlakyInstance(t, 5)
+
+	stmt := &statement.Statement{ID: uuid.New(), SNSysID: "stmt0", IsModified: true, LocalContent: "Text."}
+	stmtRepo := &mockStatementRepository{stmts: map[uuid.UUID]*statement.Statement{stmt.ID: stmt}}
+	svc := NewService(stmtRepo, nil, nil, Options{
+		Retry: PushRetryPolicy{MaxRetries: 2, InitialDelay: time.Millisecond},
+	}, nil)
+	job := &Job{ID: uuid.New()}
+	svc.jobs[job.ID] = job
+
+	result := svc.pushStatement(context.Background(), client, job.ID, stmt.ID)
+	if result.Success {
+		t.Fatal("expected push to fail")
+	}
+	if result.RetryCount != 2 {
+		t.Errorf("expected retry_count 2, got %d", result.RetryCount)
+	}
+	if got := atomic.LoadInt32(requests); got != 3 {
+		t.Errorf("expected 3 requests, got %d", got)
+	}
+	if len(job.Errors) != 1 || !strings.Contains(job.Errors[0], stmt.ID.String()) {
+		t.Errorf("expected one job error for the statement, got %v", job.Errors)
+	}
+}
+
+// failingClient fails every update with the same error.
+type failingClient struct {
+	err   error
+	calls int
+}
+
+func (c *failingClient) UpdateStatement(ctx context.Context, sysID string, content string) error {
+	c.calls++
+	return c.err
+}
+
+func TestService_PushStatement_NoRetryOnPermanentErrors(t *testing.T) {
+	for _, err := range []error{servicenow.ErrAuthFailed, servicenow.ErrNotFound} {
+		stmt := &statement.Statement{ID: uuid.New(), SNSysID: "stmt0", IsModified: true, LocalContent: "Text."}
+		stmtRepo := &mockStatementRepository{stmts: map[uuid.UUID]*statement.Statement{stmt.ID: stmt}}
+		svc := NewService(stmtRepo, nil, nil, Options{
+			Retry: PushRetryPolicy{MaxRetries: 3, InitialDelay: time.Millisecond},
+		}, nil)
+
+		client := &failingClient{err: err}
+		result := svc.pushStatement(context.Background(), client, uuid.New(), stmt.ID)
+		if result.Success || result.RetryCount != 0 || client.calls != 1 {
+			t.Errorf("%v: expected one failed attempt, got %d calls and %+v", err, client.calls, result)
+		}
+	}
+}
+
+func TestPushRetryPolicy_Backoff(t *testing.T) {
+	policy := PushRetryPolicy{InitialDelay: 100 * time.Millisecond, MaxDelay: 300 * time.Millisecond}
+	for retry, want := range map[int]time.Duration{1: 100 * time.Millisecond, 2: 200 * time.Millisecond, 3: 300 * time.Millisecond, 60: 300 * time.Millisecond} {
+		if got := policy.backoff(retry); got != want {
+			t.Errorf("retry %d: expected %v, got %v", retry, want, got)
+		}
+	}
+}
+
+func TestService_BulkPush_NotConfigured(t *testing.T) {
+	stmt := &statement.Statement{ID: uuid.New(), SNSysID: "stmt0", IsModified: true, LocalContent: "Text."}
+	stmtRepo := &mockStatementRepository{stmts: map[uuid.UUID]*statement.Statement{stmt.ID: stmt}}
+	job := &Job{ID: uuid.New(), StatementIDs: []uuid.UUID{stmt.ID}}
+	svc := NewService(stmtRepo, nil, nil, Options{}, nil)
+
+	if svc.bulkPush(context.Background(), job, &bulkClient{err: servicenow.ErrImportSetNotConfigured}) {
+		t.Fatal("expected fallback to individual pushes")
+	}
+	if len(job.Results) != 0 || len(stmtRepo.synced) != 0 {
+		t.Errorf("expected nothing recorded, got %d results", len(job.Results))
+	}
+}
+
+// mockAlertSender records the emails it is asked to send.
+type mockAlertSender struct {
+	to, subject, body string
+	calls             int
+}
+
+func (m *mockAlertSender) Send(ctx context.Context, to, subject, body string) error {
+	m.calls++
+	m.to, m.subject, m.body = to, subject, body
+	return nil
+}
+
+func TestService_AlertFailures(t *testing.T) {
+	stmtRepo := &mockStatementRepository{stmts: map[uuid.UUID]*statement.Statement{}}
+	job := &Job{ID: uuid.New(), ConnectionLabel: "default", TotalCount: 3, Status: JobStatusCompleted}
+	for i := 0; i < 3; i++ {
+		stmt := &statement.Statement{ID: uuid.New(), SNSysID: fmt.Sprintf("stmt%d", i), IsModified: true, LocalContent: "Text."}
+		if i == 2 {
+			stmt.LocalContent = ""
+		}
+		stmtRepo.stmts[stmt.ID] = stmt
+		job.StatementIDs = append(job.StatementIDs, stmt.ID)
+	}
+	sender := &mockAlertSender{}
+	svc := NewService(stmtRepo, nil, nil, Options{AlertSender: sender, AlertEmail: "admin@example.com"}, nil)
+	svc.bulkPush(context.Background(), job, &bulkClient{reject: map[string]bool{"stmt1": true}})
+
+	svc.alertFailures(context.Background(), job)
+
+	if sender.calls != 1 || sender.to != "admin@example.com" {
+		t.Fatalf("expected one alert to admin@example.com, got %d to %q", sender.calls, sender.to)
+	}
+	if want := fmt.Sprintf("Push job %s: 2 of 3 statements failed", job.ID); sender.subject != want {
+		t.Errorf("expected subject %q, got %q", want, sender.subject)
+	}
+
+	header, list, ok := strings.Cut(sender.body, "Failed statements:\n")
+	if !ok {
+		t.Fatalf("expected a failed statements section, got %q", sender.body)
+	}
+	for _, want := range []string{job.ID.String(), `"default"`, "Succeeded: 1", "Failed: 2"} {
+		if !strings.Contains(header, want) {
+			t.Errorf("expected summary to contain %q, got %q", want, header)
+		}
+	}
+	wantList := fmt.Sprintf("- %s: ServiceNow rejected the update: Target record not found\n- %s: %s\n",
+		job.StatementIDs[1], job.StatementIDs[2], ErrStatementEmpty.Error())
+	if list != wantList {
+		t.Errorf("expected failed statements\n%q\ngot\n%q", wantList, list)
+	}
+}
+
+func TestService_AlertFailures_Disabled(t *testing.T) {
+	svc := NewService(&mockStatementRepository{}, nil, nil, Options{}, nil)
+	// Must not panic without a sender
+	svc.alertFailures(context.Background(), &Job{ID: uuid.New(), Failed: 1})
+}
@@ -0,0 +1,35 @@
+package statement
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/controlcrud/backend/internal/infrastructure/servicenow"
+)
+
+// Attachment is an evidence file uploaded to a statement's ServiceNow record.
+type Attachment struct {
+	ID          uuid.UUID `json:"id"`
+	StatementID uuid.UUID `json:"statement_id"`
+	SNSysID     string    `json:"sn_sys_id"` // sys_id of the ServiceNow attachment
+	FileName    string    `json:"file_name"`
+	UploadedAt  time.Time `json:"uploaded_at"`
+}
+
+// AttachEvidenceInput holds an evidence file to upload for a statement.
+type AttachEvidenceInput struct {
+	StatementID uuid.UUID
+	FileName    string
+	ContentType string
+	Data        io.Reader
+}
+
+// SNClientProvider returns ServiceNow clients for the default connection or
+// a labelled one.
+type SNClientProvider interface {
+	GetSNClient(ctx context.Context) (servicenow.Client, error)
+	GetSNClientByLabel(ctx context.Context, label string) (servicenow.Client, error)
+}
@@ -0,0 +1,174 @@
+package statement
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/microcosm-cc/bluemonday"
+)
+
+// SanitizationMode selects which HTML is kept in locally edited content.
+type SanitizationMode string
+
+const (
+	SanitizationStrict SanitizationMode = "strict" // Strip all HTML tags, keeping their text
+	SanitizationUGC    SanitizationMode = "ugc"    // Keep the formatting tags and safe links of user-generated content
+	SanitizationNone   SanitizationMode = "none"   // Store content as submitted
+)
+
+// IsValid returns true if the mode is a known sanitization mode.
+func (m SanitizationMode) IsValid() bool {
+	switch m {
+	case SanitizationStrict, SanitizationUGC, SanitizationNone:
+		return true
+	}
+	return false
+}
+
+// sanitizePolicies are the bluemonday policies of the modes that change
+// content. Policies are safe for concurrent use once built.
+var sanitizePolicies = map[SanitizationMode]*bluemonday.Policy{
+	SanitizationStrict: bluemonday.StrictPolicy(),
+	SanitizationUGC:    bluemonday.UGCPolicy(),
+}
+
+var (
+	// textEntities undoes the escaping bluemonday applies to characters
+	// that cannot start markup in text, so markdown quotes and
+	// blockquotes survive. '<' and '&' are handled by escapedMarkup.
+	textEntities = strings.NewReplacer("&gt;", ">", "&#39;", "'", "&#34;", `"`)
+
+	// escapedMarkup matches an escaped '<' or '&' with the characters
+	// after it, which decide whether it can be unescaped safely.
+	escapedMarkup = regexp.MustCompile(`&lt;[A-Za-z/!?]?|&amp;[A-Za-z0-9#;]*`)
+
+	// markdownLinkPattern matches the start of a markdown link target with
+	// a scheme that runs script in the browser.
+	markdownLinkPattern = regexp.MustCompile(`(?i)(\]\(\s*<?)\s*(?:javascript|vbscript|data):`)
+)
+
+// NormalizeContent converts CRLF line endings to LF and trims trailing
+// whitespace from every line, so content edited locally and content pulled
+// from ServiceNow compare equal when only their formatting differs.
+func NormalizeContent(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t\r")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ContentEqual returns true if a and b are the same after normalization.
+func ContentEqual(a, b string) bool {
+	return NormalizeContent(a) == NormalizeContent(b)
+}
+
+// Unwrap removes hard line breaks inside paragraphs, as left by text pasted
+// from email or fixed-width documents. A line is joined to the next one with
+// a space when it does not end in '.', '?' or '!' and the next line starts
+// with a lowercase letter. Blank lines, list items and lines that start a
+// sentence are kept on their own line.
+func Unwrap(s string) string {
+	lines := strings.Split(s, "\n")
+	out := lines[:1]
+	for _, next := range lines[1:] {
+		last := &out[len(out)-1]
+		if joinsNextLine(*last, next) {
+			*last = strings.TrimRight(*last, " \t") + " " + strings.TrimLeft(next, " \t")
+			continue
+		}
+		out = append(out, next)
+	}
+	return strings.Join(out, "\n")
+}
+
+// joinsNextLine returns true if next continues the sentence on line.
+func joinsNextLine(line, next string) bool {
+	line = strings.TrimRight(line, " \t\r")
+	if line == "" || strings.ContainsRune(".?!", rune(line[len(line)-1])) {
+		return false
+	}
+	first, _ := utf8.DecodeRuneInString(strings.TrimLeft(next, " \t"))
+	return unicode.IsLower(first)
+}
+
+// Sanitize cleans content according to the mode using its bluemonday
+// policy. Strict mode strips all HTML, keeping the text; ugc mode keeps the
+// formatting and safe links allowed in user-generated content. Elements
+// such as <script> are removed with their content. Plain text such as
+// "A & B" or "x < y" is returned unchanged, but a '<' that could start a
+// tag stays escaped as &lt;. Markdown links to javascript:, vbscript: and
+// data: URLs are neutralized.
+func (m SanitizationMode) Sanitize(input string) (string, error) {
+	if !utf8.ValidString(input) {
+		return "", fmt.Errorf("%w: content is not valid UTF-8", ErrInvalidInput)
+	}
+
+	switch m {
+	case SanitizationNone, "":
+		return input, nil
+	}
+	policy, ok := sanitizePolicies[m]
+	if !ok {
+		return "", fmt.Errorf("%w: unknown sanitization mode %q", ErrInvalidInput, m)
+	}
+
+	cleaned := unescapeText(policy.Sanitize(input))
+
+	// Removing a scheme can expose another one, e.g. "javascript:javascript:"
+	for markdownLinkPattern.MatchString(cleaned) {
+		cleaned = markdownLinkPattern.ReplaceAllString(cleaned, "$1")
+	}
+	return cleaned, nil
+}
+
+// unescapeText unescapes the text between the tags of sanitized HTML.
+// Text never holds a literal '<' or '>', so each '<' starts a tag that ends
+// at the next '>'; attribute values are left escaped.
+func unescapeText(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	for s != "" {
+		lt := strings.IndexByte(s, '<')
+		if lt < 0 {
+			b.WriteString(unescapeSegment(s))
+			break
+		}
+		b.WriteString(unescapeSegment(s[:lt]))
+		s = s[lt:]
+		gt := strings.IndexByte(s, '>')
+		if gt < 0 {
+			b.WriteString(s)
+			break
+		}
+		b.WriteString(s[:gt+1])
+		s = s[gt+1:]
+	}
+	return b.String()
+}
+
+// unescapeSegment unescapes a run of text. "&lt;" becomes '<' unless a tag
+// name, '/', '!' or '?' follows it, and "&amp;" becomes '&' unless the
+// result would read as a character reference such as "&copy". Sanitizing
+// the result again therefore yields the same text.
+func unescapeSegment(s string) string {
+	s = textEntities.Replace(s)
+	return escapedMarkup.ReplaceAllStringFunc(s, func(match string) string {
+		if rest, ok := strings.CutPrefix(match, "&lt;"); ok {
+			if rest != "" {
+				return match
+			}
+			return "<"
+		}
+		rest := strings.TrimPrefix(match, "&amp;")
+		if html.UnescapeString("&"+rest) != "&"+rest {
+			return match
+		}
+		return "&" + rest
+	})
+}
@@ -0,0 +1,205 @@
+package statement
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestSanitizationMode_Sanitize(t *testing.T) {
+	tests := []struct {
+		name  string
+		mode  SanitizationMode
+		input string
+		want  string
+	}{
+		{"plain markdown untouched", SanitizationStrict, "## Access\n\n- Reviewed *quarterly* by [IAM](https://iam.example.com) > 2 times", "## Access\n\n- Reviewed *quarterly* by [IAM](https://iam.example.com) > 2 times"},
+		{"strict strips tags keeps text", SanitizationStrict, "<p>Hello <b>world</b></p>", "Hello world"},
+		{"script removed with content", SanitizationStrict, "a<script>alert(1)</script>b", "ab"},
+		{"unclosed script drops rest", SanitizationUGC, "a<SCRIPT src=x>alert(1)", "a"},
+		{"comment removed", SanitizationStrict, "a<!-- <b>hidden</b> -->b", "ab"},
+		{"lone angle bracket kept", SanitizationStrict, "x < y and 1<2", "x < y and 1<2"},
+		{"ampersand kept", SanitizationStrict, "A & B, AT&T", "A & B, AT&T"},
+		{"escaped tag stays escaped", SanitizationStrict, "&lt;script&gt;alert(1)", "&lt;script>alert(1)"},
+		{"escaped entity stays escaped", SanitizationStrict, "&amp;copy; and &amp;lt;", "&amp;copy; and &amp;lt;"},
+		{"split tags cannot reassemble", SanitizationStrict, "<<b>script>alert(1)<<b>/script>", "&lt;script>alert(1)&lt;/script>"},
+		{"ugc keeps formatting", SanitizationUGC, `<p class="x" onclick="evil()">Hi <strong>there</strong><br/></p>`, "<p>Hi <strong>there</strong><br/></p>"},
+		{"ugc keeps safe link", SanitizationUGC, `<a href="https://example.com/?a=1&amp;b=2" target="_blank">x</a>`, `<a href="https://example.com/?a=1&amp;b=2" rel="nofollow">x</a>`},
+		{"ugc drops script link", SanitizationUGC, `<a href="JavaScript:alert(1)" title="t">x</a>`, `<a title="t">x</a>`},
+		{"ugc drops entity encoded script link", SanitizationUGC, `<a href="javascript&#58;alert(1)">x</a>`, "x"},
+		{"ugc strips unsafe attributes", SanitizationUGC, `<div><img src=x onerror=alert(1)>text</div>`, `<div><img src="x">text</div>`},
+		{"ugc strips unknown tags", SanitizationUGC, `<form action="/x"><input name="q">text</form>`, "text"},
+		{"quotes and blockquotes kept", SanitizationUGC, "> It's \"reviewed\" <em>yearly</em> & logged", "> It's \"reviewed\" <em>yearly</em> & logged"},
+		{"markdown script link neutralized", SanitizationStrict, "[click](javascript:alert(1))", "[click](alert(1))"},
+		{"nested markdown scheme", SanitizationUGC, "[x]( javascript:JavaScript:alert(1))", "[x]( alert(1))"},
+		{"none keeps html", SanitizationNone, "<script>alert(1)</script>", "<script>alert(1)</script>"},
+		{"empty mode keeps html", "", "<b>x</b>", "<b>x</b>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.mode.Sanitize(tt.input)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestSanitizationMode_Sanitize_RoundTrip(t *testing.T) {
+	inputs := []string{
+		"A & B",
+		"x < y && y > z",
+		"Owner: R&D <em>team</em>",
+		"&lt;b&gt; is not bold",
+		"1 &lt; 2",
+	}
+	for _, mode := range []SanitizationMode{SanitizationStrict, SanitizationUGC} {
+		for _, input := range inputs {
+			once, err := mode.Sanitize(input)
+			if err != nil {
+				t.Fatalf("%s %q: unexpected error: %v", mode, input, err)
+			}
+			twice, err := mode.Sanitize(once)
+			if err != nil {
+				t.Fatalf("%s %q: unexpected error: %v", mode, once, err)
+			}
+			if twice != once {
+				t.Errorf("%s: sanitizing %q again changed it to %q", mode, once, twice)
+			}
+		}
+	}
+	if got, _ := SanitizationUGC.Sanitize("A & B"); got != "A & B" {
+		t.Errorf("expected %q unchanged, got %q", "A & B", got)
+	}
+}
+
+func TestSanitizationMode_Sanitize_Errors(t *testing.T) {
+	if _, err := SanitizationUGC.Sanitize("bad \xff byte"); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for invalid UTF-8, got %v", err)
+	}
+	if _, err := SanitizationMode("loose").Sanitize("x"); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for unknown mode, got %v", err)
+	}
+}
+
+func TestUnwrap(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"joins wrapped lines", "Access to production is\nreviewed by the system owner\nevery quarter.", "Access to production is reviewed by the system owner every quarter."},
+		{
+			"keeps paragraphs apart",
+			"Access is reviewed\nquarterly.\n\nAccounts are\ndisabled after 90 days.",
+			"Access is reviewed quarterly.\n\nAccounts are disabled after 90 days.",
+		},
+		{"sentence end keeps break", "Access is reviewed.\nthen logged", "Access is reviewed.\nthen logged"},
+		{"question and exclamation keep break", "Reviewed?\nyes\nDone!\nnext", "Reviewed?\nyes\nDone!\nnext"},
+		{"capitalized line keeps break", "Access is reviewed\nQuarterly", "Access is reviewed\nQuarterly"},
+		{
+			"list items kept",
+			"The owner must:\n- review access\n- disable stale accounts\n1. log the review",
+			"The owner must:\n- review access\n- disable stale accounts\n1. log the review",
+		},
+		{"wrapped list item joined", "- review access for\n  all privileged accounts\n- log it", "- review access for all privileged accounts\n- log it"},
+		{"paragraph form unchanged", "Access is reviewed quarterly.\n\nAccounts are disabled after 90 days.", "Access is reviewed quarterly.\n\nAccounts are disabled after 90 days."},
+		{"trailing spaces dropped at join", "Access is  \nreviewed", "Access is reviewed"},
+		{"empty", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Unwrap(tt.input); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+			if got := Unwrap(tt.want); got != tt.want {
+				t.Errorf("expected unwrapped text to be unchanged, got %q", got)
+			}
+		})
+	}
+}
+
+// FuzzSanitize checks that strict output never contains markup and that
+// sanitizing is idempotent. Run with: go test -fuzz=FuzzSanitize ./internal/domain/statement
+func FuzzSanitize(f *testing.F) {
+	for _, seed := range []string{
+		"plain text",
+		"<p>Hello <b>world</b></p>",
+		"<<b>script>alert(1)<<b>/script>",
+		`<a href="javascript:alert(1)" title='x'>y</a>`,
+		"<!-- c --><style>p{}</style>x",
+		"[x](javascript:javascript:1)",
+		"<a href=/rel>r</a><br/><hr>",
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, input string) {
+		for _, mode := range []SanitizationMode{SanitizationStrict, SanitizationUGC} {
+			got, err := mode.Sanitize(input)
+			if !utf8.ValidString(input) {
+				if err == nil {
+					t.Fatalf("%s: expected error for invalid UTF-8", mode)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("%s: unexpected error: %v", mode, err)
+			}
+			if !utf8.ValidString(got) {
+				t.Fatalf("%s: output is not valid UTF-8: %q", mode, got)
+			}
+			if mode == SanitizationStrict && strings.Contains(got, "<") {
+				t.Fatalf("strict output contains markup: %q", got)
+			}
+			if lower := strings.ToLower(got); strings.Contains(lower, "<script") || strings.Contains(lower, `<a href="javascript:`) {
+				t.Fatalf("%s: output contains script: %q", mode, got)
+			}
+
+			again, err := mode.Sanitize(got)
+			if err != nil || again != got {
+				t.Fatalf("%s: not idempotent: %q -> %q (%v)", mode, got, again, err)
+			}
+		}
+	})
+}
+
+func TestNormalizeContent(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"crlf converted", "a\r\nb\r\n", "a\nb\n"},
+		{"trailing whitespace trimmed", "a  \nb\t\n", "a\nb\n"},
+		{"leading whitespace kept", "  - a\n    - b", "  - a\n    - b"},
+		{"blank lines kept", "a\r\n\r\nb", "a\n\nb"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeContent(tt.input); got != tt.want {
+				t.Errorf("NormalizeContent(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContentEqual_LineEndings(t *testing.T) {
+	// Upsert compares stored and pulled remote content with ContentEqual to
+	// detect conflicts, so a CRLF copy of the same text must not conflict
+	stored := "Access is reviewed.\nBy IAM."
+	pulled := "Access is reviewed. \r\nBy IAM."
+	if !ContentEqual(stored, pulled) {
+		t.Error("expected content differing only in line endings to be equal")
+	}
+	if ContentEqual(stored, "Access is reviewed.\r\nBy HR.") {
+		t.Error("expected different content to be unequal")
+	}
+}
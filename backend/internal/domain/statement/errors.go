@@ -8,4 +8,31 @@ var (
 	ErrInvalidInput   = errors.New("invalid input")
 	ErrControlNotFound = errors.New("control not found")
 	ErrConflict       = errors.New("sync conflict detected")
+	ErrReviewDisabled = errors.New("statement review is not enabled")
+	ErrNotReviewable  = errors.New("statement has no local changes to review")
+	ErrSelfReview     = errors.New("statement changes cannot be reviewed by their author")
+	ErrContentTooShort = errors.New("statement content is too short")
+	ErrContentTooLong  = errors.New("statement content is too long")
+
+	ErrReviewerRequired     = errors.New("statement review requires an identified reviewer")
+	ErrCannotDeleteModified = errors.New("statements with local modifications cannot be deleted without force")
+	ErrSystemReadOnly       = errors.New("statement belongs to a read-only system")
+
+	ErrAttachmentsUnavailable   = errors.New("ServiceNow attachments are not configured")
+	ErrNotInServiceNow          = errors.New("statement has no ServiceNow record")
+	ErrAttachmentUploadFailed   = errors.New("failed to upload attachment to ServiceNow")
+	ErrAttachmentDownloadFailed = errors.New("failed to download attachment from ServiceNow")
+	ErrRePullFailed             = errors.New("failed to re-pull statement from ServiceNow")
+
+	ErrInvalidStatementType  = errors.New("unknown statement type")
+	ErrStatementTypeExists   = errors.New("statement type already exists")
+	ErrStatementTypeInUse    = errors.New("statement type is used by existing statements")
+	ErrStatementTypeNotFound = errors.New("statement type not found")
+
+	ErrInvalidTag  = errors.New("invalid tag")
+	ErrTagNotFound = errors.New("statement does not have the tag")
+
+	ErrTemplateNotFound         = errors.New("statement template not found")
+	ErrInvalidTemplate          = errors.New("invalid statement template")
+	ErrMissingTemplateVariables = errors.New("missing template variables")
 )
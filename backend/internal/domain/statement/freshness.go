@@ -0,0 +1,92 @@
+package statement
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/controlcrud/backend/internal/infrastructure/servicenow"
+)
+
+// freshnessCacheTTL is how long a freshness check is reused for a statement.
+const freshnessCacheTTL = 2 * time.Minute
+
+// Freshness reports whether a statement's ServiceNow record changed after it
+// was last pulled.
+type Freshness struct {
+	// IsStale is nil when ServiceNow could not be asked; Error says why.
+	IsStale          *bool
+	SNUpdatedOn      *time.Time
+	LocalSNUpdatedOn *time.Time
+	Error            string
+}
+
+type cachedFreshness struct {
+	freshness *Freshness
+	expiresAt time.Time
+}
+
+// CheckFreshness compares the statement's ServiceNow sys_updated_on, read
+// from the connection its system was last pulled from, with the value
+// recorded at the last pull. Answers are cached per statement for two
+// minutes; failed checks are not cached.
+func (s *Service) CheckFreshness(ctx context.Context, id uuid.UUID) (*Freshness, error) {
+	stmt, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if stmt.SNSysID == "" {
+		return nil, ErrNotInServiceNow
+	}
+
+	if cached, ok := s.freshness.Load(id); ok {
+		entry := cached.(cachedFreshness)
+		if time.Now().Before(entry.expiresAt) {
+			return entry.freshness, nil
+		}
+	}
+
+	unreachable := &Freshness{LocalSNUpdatedOn: stmt.SNUpdatedOn, Error: "ServiceNow unreachable"}
+	if s.opts.SNClients == nil {
+		return unreachable, nil
+	}
+	sys, err := s.repo.GetSystemRef(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if sys == nil {
+		return nil, ErrNotFound
+	}
+	client, err := s.opts.SNClients.GetSNClientByLabel(ctx, sys.ConnectionLabel)
+	if err != nil {
+		s.logger.Warn("freshness check could not get ServiceNow client", "id", id, "error", err)
+		return unreachable, nil
+	}
+	record, err := client.GetPolicyStatement(ctx, stmt.SNSysID)
+	if err != nil {
+		s.logger.Warn("freshness check could not fetch statement from ServiceNow", "id", id, "error", err)
+		return unreachable, nil
+	}
+
+	loc, err := client.GetInstanceTimezone(ctx)
+	if err != nil {
+		s.logger.Warn("failed to get ServiceNow instance timezone, assuming UTC", "error", err)
+		loc = time.UTC
+	}
+	remote, err := servicenow.ParseSNTime(record.SysUpdatedOn, loc)
+	if err != nil {
+		s.logger.Warn("freshness check got an invalid sys_updated_on", "id", id, "error", err)
+		return &Freshness{LocalSNUpdatedOn: stmt.SNUpdatedOn, Error: "ServiceNow returned an invalid sys_updated_on"}, nil
+	}
+
+	// A statement without a recorded timestamp cannot be shown to be current
+	isStale := stmt.SNUpdatedOn == nil || remote.After(*stmt.SNUpdatedOn)
+	freshness := &Freshness{
+		IsStale:          &isStale,
+		SNUpdatedOn:      &remote,
+		LocalSNUpdatedOn: stmt.SNUpdatedOn,
+	}
+	s.freshness.Store(id, cachedFreshness{freshness: freshness, expiresAt: time.Now().Add(freshnessCacheTTL)})
+	return freshness, nil
+}
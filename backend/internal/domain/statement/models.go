@@ -1,9 +1,15 @@
 package statement
 
 import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/controlcrud/backend/internal/domain/audit"
 )
 
 // SyncStatus represents the synchronization state of a statement.
@@ -16,6 +22,16 @@ const (
 	SyncStatusNew      SyncStatus = "new"      // New local statement
 )
 
+// ReviewStatus represents the review state of a statement's local changes.
+type ReviewStatus string
+
+const (
+	ReviewStatusNone          ReviewStatus = ""               // No local changes to review
+	ReviewStatusPendingReview ReviewStatus = "pending_review" // Awaiting a reviewer decision
+	ReviewStatusApproved      ReviewStatus = "approved"       // Approved for push
+	ReviewStatusRejected      ReviewStatus = "rejected"       // Changes rejected by reviewer
+)
+
 // Statement represents a control implementation statement.
 // In IRM, this maps to sn_compliance_policy_statement.
 // DEMO MODE: Maps from incidents.
@@ -36,15 +52,29 @@ type Statement struct {
 	ModifiedAt   *time.Time `json:"modified_at,omitempty"`
 	ModifiedBy   *uuid.UUID `json:"modified_by,omitempty"`
 
+	// ModifiedByEmail identifies the editor in deployments using
+	// email-based identity. Empty when unknown.
+	ModifiedByEmail string `json:"modified_by_email,omitempty"`
+
 	// Sync status
 	SyncStatus         SyncStatus `json:"sync_status"`
 	ConflictResolvedAt *time.Time `json:"conflict_resolved_at,omitempty"`
 	ConflictResolvedBy *uuid.UUID `json:"conflict_resolved_by,omitempty"`
 
+	// Review workflow
+	ReviewStatus  ReviewStatus `json:"review_status,omitempty"`
+	ReviewedBy    *uuid.UUID   `json:"reviewed_by,omitempty"`
+	ReviewedAt    *time.Time   `json:"reviewed_at,omitempty"`
+	ReviewComment string       `json:"review_comment,omitempty"`
+
 	// Sync metadata
 	SNUpdatedOn *time.Time `json:"sn_updated_on,omitempty"`
 	LastPullAt  *time.Time `json:"last_pull_at,omitempty"`
 	LastPushAt  *time.Time `json:"last_push_at,omitempty"`
+	LastPushBy  *uuid.UUID `json:"last_push_by,omitempty"` // Push job that last pushed the statement
+
+	// Tags are user-defined labels, sorted alphabetically.
+	Tags []string `json:"tags,omitempty"`
 
 	// Audit
 	CreatedAt time.Time `json:"created_at"`
@@ -59,6 +89,33 @@ func (s *Statement) GetContent() string {
 	return s.RemoteContent
 }
 
+// DefaultStatementType is used when ServiceNow does not provide a type.
+const DefaultStatementType = "implementation"
+
+// Type is a registered statement type such as "implementation" or "evidence".
+type Type struct {
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// CreateTypeInput holds data for registering a statement type.
+type CreateTypeInput struct {
+	Name        string
+	Description string
+}
+
+// typeNamePattern restricts type names to lowercase snake_case.
+var typeNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,49}$`)
+
+// Validate validates the CreateTypeInput.
+func (c *CreateTypeInput) Validate() error {
+	if !typeNamePattern.MatchString(c.Name) {
+		return fmt.Errorf("%w: type name must be lowercase snake_case, at most 50 characters", ErrInvalidInput)
+	}
+	return nil
+}
+
 // ListParams holds parameters for listing statements.
 type ListParams struct {
 	ControlID  uuid.UUID  `json:"control_id"`
@@ -67,6 +124,34 @@ type ListParams struct {
 	PageSize   int        `json:"page_size"`
 	SyncStatus SyncStatus `json:"sync_status,omitempty"`
 	Search     string     `json:"search,omitempty"`
+	Tags       []string   `json:"tags,omitempty"` // Only statements carrying every tag
+
+	// SearchTerms is Search parsed by ParseSearch; the service fills it in
+	// and repositories filter on it.
+	SearchTerms []SearchTerm `json:"-"`
+}
+
+// ModifiedListParams holds parameters for listing modified or conflicting
+// statements across controls.
+type ModifiedListParams struct {
+	Page     int
+	PageSize int
+	SystemID *uuid.UUID // Optional; limits results to one system's controls
+}
+
+// withDefaults returns the params with page 1, 20 per page and at most 200
+// per page applied.
+func (p ModifiedListParams) withDefaults() ModifiedListParams {
+	if p.Page < 1 {
+		p.Page = 1
+	}
+	if p.PageSize < 1 {
+		p.PageSize = 20
+	}
+	if p.PageSize > 200 {
+		p.PageSize = 200
+	}
+	return p
 }
 
 // ListResult holds the result of listing statements.
@@ -89,9 +174,70 @@ type UpsertInput struct {
 
 // UpdateInput holds data for updating local content.
 type UpdateInput struct {
-	ID           uuid.UUID
-	LocalContent string
-	ModifiedBy   *uuid.UUID
+	ID              uuid.UUID
+	LocalContent    string
+	ModifiedBy      *uuid.UUID
+	ModifiedByEmail string // Stored as NULL when empty
+	RequireReview   bool   // Set by the service; marks the change as pending review
+}
+
+// ReviewInput holds a reviewer's decision on a modified statement.
+type ReviewInput struct {
+	ID            uuid.UUID
+	Status        ReviewStatus
+	ReviewedBy    *uuid.UUID
+	ReviewerEmail string // Not stored; checked against the editor's email
+	Comment       string
+}
+
+// Options configures optional statement workflow behaviour.
+type Options struct {
+	// ReviewRequired enables the approval workflow for local modifications.
+	ReviewRequired bool
+
+	// ContentLimits bounds the length of locally edited content.
+	ContentLimits ContentLimits
+
+	// Sanitization selects which HTML is kept in locally edited content.
+	// Empty stores content as submitted.
+	Sanitization SanitizationMode
+
+	// UnwrapOnSave joins hard-wrapped lines of locally edited content into
+	// paragraphs before it is stored. See Unwrap.
+	UnwrapOnSave bool
+
+	// Audit records bulk deletions. Nil disables audit events.
+	Audit *audit.Service
+
+	// SNClients and AttachmentTable enable evidence uploads: files are
+	// attached to the statement's record in AttachmentTable. A nil provider
+	// disables AttachEvidence and RePull.
+	SNClients       SNClientProvider
+	AttachmentTable string
+
+	// ConflictWebhook delivers a ConflictEvent to WebhookURL whenever
+	// RePull puts a statement in conflict. Nil disables notifications.
+	ConflictWebhook WebhookSender
+	WebhookURL      string
+}
+
+// ContentLimits bounds the length of statement content. Zero values disable a check.
+type ContentLimits struct {
+	MinWords int // Minimum number of whitespace-separated words
+	MaxChars int // Maximum content size in bytes (UTF-8 encoded)
+}
+
+// Validate checks content against the limits.
+func (l ContentLimits) Validate(content string) error {
+	if l.MinWords > 0 {
+		if words := len(strings.Fields(content)); words < l.MinWords {
+			return fmt.Errorf("%w: %d words, minimum is %d", ErrContentTooShort, words, l.MinWords)
+		}
+	}
+	if l.MaxChars > 0 && len(content) > l.MaxChars {
+		return fmt.Errorf("%w: %d bytes, maximum is %d", ErrContentTooLong, len(content), l.MaxChars)
+	}
+	return nil
 }
 
 // ConflictResolution represents how a conflict was resolved.
@@ -103,10 +249,90 @@ const (
 	ConflictResolutionMerge      ConflictResolution = "merge"
 )
 
+// ConflictStrategy selects how conflicts detected during a pull are handled.
+type ConflictStrategy string
+
+const (
+	ConflictStrategyManual     ConflictStrategy = "manual"      // Leave conflicts for a user to resolve
+	ConflictStrategyKeepLocal  ConflictStrategy = "keep_local"  // Resolve by keeping the local edit
+	ConflictStrategyKeepRemote ConflictStrategy = "keep_remote" // Resolve by accepting the ServiceNow content
+)
+
+// ParseConflictStrategy validates a conflict strategy name.
+func ParseConflictStrategy(value string) (ConflictStrategy, error) {
+	switch strategy := ConflictStrategy(value); strategy {
+	case ConflictStrategyManual, ConflictStrategyKeepLocal, ConflictStrategyKeepRemote:
+		return strategy, nil
+	}
+	return "", fmt.Errorf("invalid conflict strategy %q: must be manual, keep_local or keep_remote", value)
+}
+
+// Resolution returns the conflict resolution applied automatically by the
+// strategy, or false if conflicts must be resolved manually.
+func (c ConflictStrategy) Resolution() (ConflictResolution, bool) {
+	switch c {
+	case ConflictStrategyKeepLocal:
+		return ConflictResolutionKeepLocal, true
+	case ConflictStrategyKeepRemote:
+		return ConflictResolutionKeepRemote, true
+	}
+	return "", false
+}
+
+// SystemRef identifies the system a statement belongs to.
+type SystemRef struct {
+	ID              uuid.UUID
+	Name            string
+	ConnectionLabel string // ServiceNow connection the system was last pulled from
+}
+
+// WebhookSender posts a JSON payload to a webhook URL.
+type WebhookSender interface {
+	Send(ctx context.Context, url string, payload interface{}) error
+}
+
+// ConflictEvent is sent to the conflict webhook when a pull or re-pull
+// finds that a statement changed in ServiceNow while it had local
+// modifications.
+type ConflictEvent struct {
+	StatementID uuid.UUID `json:"statement_id"`
+	ControlID   uuid.UUID `json:"control_id"`
+	SystemName  string    `json:"system_name"`
+	DetectedAt  time.Time `json:"detected_at"`
+}
+
 // ResolveConflictInput holds data for resolving a sync conflict.
 type ResolveConflictInput struct {
 	ID           uuid.UUID
 	Resolution   ConflictResolution
 	MergedContent string // Used when Resolution is ConflictResolutionMerge
 	ResolvedBy   *uuid.UUID
+	RequireReview bool // Set by the service; marks merged content as pending review
+}
+
+// BulkDeleteInput selects statements to delete, either by ID or every
+// statement of a control. Exactly one of StatementIDs and ControlID is set.
+type BulkDeleteInput struct {
+	StatementIDs []uuid.UUID
+	ControlID    *uuid.UUID
+	Force        bool // Also delete statements with local modifications
+}
+
+// Validate checks that exactly one selector is set.
+func (b *BulkDeleteInput) Validate() error {
+	if (len(b.StatementIDs) == 0) == (b.ControlID == nil) {
+		return fmt.Errorf("%w: exactly one of statement_ids and control_id is required", ErrInvalidInput)
+	}
+	return nil
+}
+
+// RevertPreview describes what reverting a statement to its remote content
+// would discard. CharsLost and WordsLost are negative when the remote
+// content is longer than the local content.
+type RevertPreview struct {
+	NoChanges           bool   // The statement has no local changes to revert
+	CurrentLocalContent string
+	RemoteContent       string
+	CharsLost           int // len(local) - len(remote), in bytes
+	WordsLost           int // Word count of local minus word count of remote
 }
@@ -0,0 +1,130 @@
+package statement
+
+import (
+	"context"
+	"html"
+	"math"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+// Readability is the Flesch reading-ease score of a statement's content.
+type Readability struct {
+	Score         float64 // Higher is easier; usually 0 to 100 but not clamped
+	GradeLevel    string  // Empty when the content has no words
+	WordCount     int
+	SentenceCount int
+}
+
+// readabilityGrades maps the lowest score of each Flesch reading-ease band to
+// the school level that can read it, easiest first.
+var readabilityGrades = []struct {
+	minScore float64
+	level    string
+}{
+	{90, "5th_grade"},
+	{80, "6th_grade"},
+	{70, "7th_grade"},
+	{60, "8th_9th_grade"},
+	{50, "10th_12th_grade"},
+	{30, "college"},
+}
+
+// htmlTagPattern matches HTML tags. Text analysis replaces them with a space
+// so text in adjacent elements is not joined into one word.
+var htmlTagPattern = regexp.MustCompile(`<[^>]*>`)
+
+// GetReadability scores the statement's current content.
+func (s *Service) GetReadability(ctx context.Context, id uuid.UUID) (*Readability, error) {
+	stmt, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	readability := ComputeReadability(stmt.GetContent())
+	return &readability, nil
+}
+
+// ComputeReadability scores text with the Flesch reading-ease formula
+// 206.835 - 1.015*(words/sentences) - 84.6*(syllables/words). HTML tags are
+// ignored. A sentence ends at '.', '!' or '?', and trailing text without one
+// counts as a sentence. The score is rounded to one decimal place.
+func ComputeReadability(text string) Readability {
+	text = html.UnescapeString(htmlTagPattern.ReplaceAllString(text, " "))
+
+	var words, sentences, syllables int
+	inSentence := false
+	for _, field := range strings.Fields(text) {
+		word := strings.TrimFunc(field, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+		if word != "" {
+			words++
+			syllables += countSyllables(word)
+			inSentence = true
+		}
+		if inSentence && endsSentence(field) {
+			sentences++
+			inSentence = false
+		}
+	}
+	if inSentence {
+		sentences++
+	}
+	if words == 0 {
+		return Readability{}
+	}
+
+	score := 206.835 - 1.015*(float64(words)/float64(sentences)) - 84.6*(float64(syllables)/float64(words))
+	return Readability{
+		Score:         math.Round(score*10) / 10,
+		GradeLevel:    gradeLevel(score),
+		WordCount:     words,
+		SentenceCount: sentences,
+	}
+}
+
+// endsSentence returns true if field ends with sentence punctuation,
+// possibly followed by closing quotes or brackets.
+func endsSentence(field string) bool {
+	trimmed := strings.TrimRight(field, `"')]”’`)
+	return strings.HasSuffix(trimmed, ".") || strings.HasSuffix(trimmed, "!") || strings.HasSuffix(trimmed, "?")
+}
+
+// gradeLevel returns the school level for a reading-ease score.
+func gradeLevel(score float64) string {
+	for _, grade := range readabilityGrades {
+		if score >= grade.minScore {
+			return grade.level
+		}
+	}
+	return "college_graduate"
+}
+
+// countSyllables estimates the syllables in a word by counting transitions
+// from consonants to vowels, treating 'y' as a vowel. A final silent 'e' is
+// not counted unless it follows a consonant and 'l', as in "table". Every
+// word has at least one syllable.
+func countSyllables(word string) int {
+	word = strings.ToLower(word)
+
+	count := 0
+	prevVowel := false
+	for _, r := range word {
+		vowel := strings.ContainsRune("aeiouy", r)
+		if vowel && !prevVowel {
+			count++
+		}
+		prevVowel = vowel
+	}
+
+	if n := len(word); count > 1 && n > 2 && word[n-1] == 'e' && !strings.ContainsRune("aeiouy", rune(word[n-2])) {
+		if !(word[n-2] == 'l' && !strings.ContainsRune("aeiouy", rune(word[n-3]))) {
+			count--
+		}
+	}
+
+	return max(count, 1)
+}
@@ -0,0 +1,138 @@
+package statement
+
+import "testing"
+
+func TestCountSyllables(t *testing.T) {
+	tests := []struct {
+		word string
+		want int
+	}{
+		{"cat", 1},
+		{"the", 1},
+		{"access", 2},
+		{"review", 2},
+		{"make", 1},
+		{"table", 2},
+		{"system", 2},
+		{"quarterly", 3},
+		{"policy", 3},
+		{"documentation", 5},
+		{"AC-2", 1},
+		{"2024", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			if got := countSyllables(tt.word); got != tt.want {
+				t.Errorf("expected %d syllables, got %d", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestComputeReadability(t *testing.T) {
+	tests := []struct {
+		name          string
+		text          string
+		minScore      float64
+		maxScore      float64
+		gradeLevel    string
+		wordCount     int
+		sentenceCount int
+	}{
+		{
+			name:          "simple sentences",
+			text:          "The cat sat on the mat. The dog ran to the door.",
+			minScore:      100,
+			maxScore:      120,
+			gradeLevel:    "5th_grade",
+			wordCount:     12,
+			sentenceCount: 2,
+		},
+		{
+			name: "plain control statement",
+			text: "Managers review user access every quarter. They remove accounts that are no longer needed. " +
+				"The review is logged in the ticket system.",
+			minScore:      50,
+			maxScore:      80,
+			wordCount:     22,
+			sentenceCount: 3,
+		},
+		{
+			name: "dense compliance language",
+			text: "Organizational information security governance necessitates comprehensive documentation " +
+				"of authorization procedures, accountability mechanisms, and continuous monitoring activities.",
+			maxScore:      0,
+			minScore:      -200,
+			gradeLevel:    "college_graduate",
+			wordCount:     16,
+			sentenceCount: 1,
+		},
+		{
+			name:          "html tags ignored",
+			text:          "<p>The cat sat on the mat.</p><p>The dog ran to the door.</p>",
+			minScore:      100,
+			maxScore:      120,
+			gradeLevel:    "5th_grade",
+			wordCount:     12,
+			sentenceCount: 2,
+		},
+		{
+			name:          "trailing text without punctuation",
+			text:          "Access is reviewed. Logs are kept",
+			minScore:      40,
+			maxScore:      100,
+			wordCount:     6,
+			sentenceCount: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ComputeReadability(tt.text)
+			if got.Score < tt.minScore || got.Score > tt.maxScore {
+				t.Errorf("expected score in [%v, %v], got %v", tt.minScore, tt.maxScore, got.Score)
+			}
+			if tt.gradeLevel != "" && got.GradeLevel != tt.gradeLevel {
+				t.Errorf("expected grade level %q, got %q", tt.gradeLevel, got.GradeLevel)
+			}
+			if got.WordCount != tt.wordCount {
+				t.Errorf("expected %d words, got %d", tt.wordCount, got.WordCount)
+			}
+			if got.SentenceCount != tt.sentenceCount {
+				t.Errorf("expected %d sentences, got %d", tt.sentenceCount, got.SentenceCount)
+			}
+		})
+	}
+}
+
+func TestComputeReadability_Empty(t *testing.T) {
+	for _, text := range []string{"", "   \n", "<p></p>", "-- ..."} {
+		if got := ComputeReadability(text); got != (Readability{}) {
+			t.Errorf("expected zero readability for %q, got %+v", text, got)
+		}
+	}
+}
+
+func TestGradeLevel(t *testing.T) {
+	tests := []struct {
+		score float64
+		want  string
+	}{
+		{116.1, "5th_grade"},
+		{90, "5th_grade"},
+		{85, "6th_grade"},
+		{72.3, "7th_grade"},
+		{65, "8th_9th_grade"},
+		{55, "10th_12th_grade"},
+		{45.2, "college"},
+		{29.9, "college_graduate"},
+		{-40, "college_graduate"},
+	}
+
+	for _, tt := range tests {
+		if got := gradeLevel(tt.score); got != tt.want {
+			t.Errorf("gradeLevel(%v): expected %q, got %q", tt.score, tt.want, got)
+		}
+	}
+}
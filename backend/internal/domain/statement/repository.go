@@ -20,11 +20,11 @@ type Repository interface {
 	// ListByControl retrieves all statements for a control.
 	ListByControl(ctx context.Context, controlID uuid.UUID) ([]Statement, error)
 
-	// ListModified retrieves all statements with local modifications.
-	ListModified(ctx context.Context) ([]Statement, error)
+	// ListModified retrieves statements with local modifications with pagination.
+	ListModified(ctx context.Context, params ModifiedListParams) (*ListResult, error)
 
-	// ListConflicts retrieves all statements with sync conflicts.
-	ListConflicts(ctx context.Context) ([]Statement, error)
+	// ListConflicts retrieves statements with sync conflicts with pagination.
+	ListConflicts(ctx context.Context, params ModifiedListParams) (*ListResult, error)
 
 	// Upsert creates or updates a statement from ServiceNow.
 	// Preserves local modifications and detects conflicts.
@@ -39,12 +39,62 @@ type Repository interface {
 	// ResolveConflict resolves a sync conflict.
 	ResolveConflict(ctx context.Context, input ResolveConflictInput) (*Statement, error)
 
+	// SetReview records a review decision on a modified statement.
+	SetReview(ctx context.Context, input ReviewInput) (*Statement, error)
+
 	// Delete removes a statement.
 	Delete(ctx context.Context, id uuid.UUID) error
 
 	// DeleteByControl removes all statements for a control.
 	DeleteByControl(ctx context.Context, controlID uuid.UUID) error
 
-	// MarkAsSynced marks a statement as synced after push.
-	MarkAsSynced(ctx context.Context, id uuid.UUID) error
+	// DeleteBatch removes the given statements in a single transaction and
+	// returns how many were deleted. Unless force is set it deletes nothing
+	// and returns ErrCannotDeleteModified if any of them has local
+	// modifications.
+	DeleteBatch(ctx context.Context, ids []uuid.UUID, force bool) (int, error)
+
+	// MarkAsSynced marks a statement as synced after it was pushed by the
+	// given push job.
+	MarkAsSynced(ctx context.Context, id, jobID uuid.UUID) error
+
+	// ListReadOnly returns those of the given statements whose control
+	// belongs to a read-only system.
+	ListReadOnly(ctx context.Context, ids []uuid.UUID) ([]uuid.UUID, error)
+
+	// GetSystemRef returns the system a statement belongs to.
+	// Returns nil if the statement does not exist.
+	GetSystemRef(ctx context.Context, id uuid.UUID) (*SystemRef, error)
+
+	// ListTypes retrieves all registered statement types.
+	ListTypes(ctx context.Context) ([]Type, error)
+
+	// CreateType registers a new statement type.
+	// Returns ErrStatementTypeExists if the name is taken.
+	CreateType(ctx context.Context, input CreateTypeInput) (*Type, error)
+
+	// DeleteType removes a statement type.
+	// Returns ErrStatementTypeInUse if statements still reference it.
+	DeleteType(ctx context.Context, name string) error
+
+	// CreateTemplate stores a new statement template.
+	CreateTemplate(ctx context.Context, input CreateTemplateInput) (*Template, error)
+
+	// GetTemplate retrieves a statement template by ID.
+	GetTemplate(ctx context.Context, id uuid.UUID) (*Template, error)
+
+	// CreateAttachment records an evidence file uploaded to ServiceNow.
+	CreateAttachment(ctx context.Context, statementID uuid.UUID, snSysID, fileName string) (*Attachment, error)
+
+	// GetAttachment retrieves an attachment of a statement. Returns nil if
+	// the statement has no attachment with the ID.
+	GetAttachment(ctx context.Context, statementID, attachmentID uuid.UUID) (*Attachment, error)
+
+	// AddTags labels a statement with the given tags, ignoring tags it
+	// already has.
+	AddTags(ctx context.Context, statementID uuid.UUID, tags []string, createdBy *uuid.UUID) error
+
+	// RemoveTag removes a tag from a statement. Returns false if the
+	// statement did not have the tag.
+	RemoveTag(ctx context.Context, statementID uuid.UUID, tag string) (bool, error)
 }
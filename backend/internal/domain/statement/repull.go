@@ -0,0 +1,105 @@
+package statement
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/controlcrud/backend/internal/infrastructure/servicenow"
+)
+
+// RePull refreshes one statement from its ServiceNow record without pulling
+// the whole system, using the connection the system was last pulled from.
+// The remote content is stored as a pull would store it: if the statement
+// has local modifications and the remote content changed, the statement is
+// put in conflict and the conflict webhook is notified.
+//
+// ServiceNow failures are returned wrapped in ErrRePullFailed, keeping the
+// client error, such as servicenow.ErrAuthFailed, in the chain.
+func (s *Service) RePull(ctx context.Context, id uuid.UUID) (*Statement, error) {
+	stmt, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if stmt.SNSysID == "" {
+		return nil, ErrNotInServiceNow
+	}
+	if s.opts.SNClients == nil {
+		return nil, fmt.Errorf("%w: ServiceNow is not configured", ErrRePullFailed)
+	}
+
+	sys, err := s.repo.GetSystemRef(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if sys == nil {
+		return nil, ErrNotFound
+	}
+	client, err := s.opts.SNClients.GetSNClientByLabel(ctx, sys.ConnectionLabel)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrRePullFailed, err)
+	}
+	record, err := client.GetPolicyStatement(ctx, stmt.SNSysID)
+	if errors.Is(err, servicenow.ErrNotFound) {
+		return nil, fmt.Errorf("%w: statement was removed from ServiceNow", ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrRePullFailed, err)
+	}
+
+	var snUpdatedOn *time.Time
+	if record.SysUpdatedOn != "" {
+		loc, err := client.GetInstanceTimezone(ctx)
+		if err != nil {
+			s.logger.Warn("failed to get ServiceNow instance timezone, assuming UTC", "error", err)
+			loc = time.UTC
+		}
+		if t, err := servicenow.ParseSNTime(record.SysUpdatedOn, loc); err == nil {
+			snUpdatedOn = &t
+		}
+	}
+
+	// Statements already in conflict were reported when it was detected
+	wasConflict := stmt.SyncStatus == SyncStatusConflict
+	updated, err := s.repo.Upsert(ctx, UpsertInput{
+		ControlID:     stmt.ControlID,
+		SNSysID:       stmt.SNSysID,
+		StatementType: stmt.StatementType,
+		RemoteContent: record.Content(),
+		SNUpdatedOn:   snUpdatedOn,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.freshness.Delete(id)
+	s.logger.Info("re-pulled statement", "id", id, "connection", sys.ConnectionLabel, "sync_status", updated.SyncStatus)
+
+	if updated.SyncStatus == SyncStatusConflict && !wasConflict {
+		s.publishConflict(ConflictEvent{
+			StatementID: updated.ID,
+			ControlID:   updated.ControlID,
+			SystemName:  sys.Name,
+			DetectedAt:  time.Now().UTC(),
+		})
+	}
+	return updated, nil
+}
+
+// publishConflict sends event to the conflict webhook in the background so
+// that slow receivers and retries do not hold up the request.
+func (s *Service) publishConflict(event ConflictEvent) {
+	if s.opts.ConflictWebhook == nil {
+		return
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		defer cancel()
+		if err := s.opts.ConflictWebhook.Send(ctx, s.opts.WebhookURL, event); err != nil {
+			s.logger.Warn("failed to send conflict webhook", "statement_id", event.StatementID, "error", err)
+		}
+	}()
+}
@@ -0,0 +1,66 @@
+package statement
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MaxSearchTerms is the most terms a statement search may contain.
+const MaxSearchTerms = 10
+
+// SearchTerm is one term of a statement search. Statements match when their
+// content contains Text, or does not contain it when Exclude is set.
+type SearchTerm struct {
+	Text    string
+	Exclude bool
+}
+
+// ParseSearch splits a search string into terms. Words separated by
+// whitespace are separate terms, "double quoted" text is a single phrase
+// term and a leading - excludes the word or phrase, e.g.
+//
+//	"access control" -draft
+//
+// An unterminated quote runs to the end of the input. A search with more
+// than MaxSearchTerms terms is rejected with ErrInvalidInput.
+func ParseSearch(search string) ([]SearchTerm, error) {
+	var terms []SearchTerm
+	rest := strings.TrimSpace(search)
+
+	for rest != "" {
+		var term SearchTerm
+		if strings.HasPrefix(rest, "-") {
+			term.Exclude = true
+			rest = rest[1:]
+		}
+
+		if strings.HasPrefix(rest, `"`) {
+			rest = rest[1:]
+			end := strings.IndexByte(rest, '"')
+			if end < 0 {
+				end = len(rest)
+			}
+			term.Text = strings.TrimSpace(rest[:end])
+			rest = rest[min(end+1, len(rest)):]
+		} else {
+			end := strings.IndexAny(rest, " \t\n\r")
+			if end < 0 {
+				end = len(rest)
+			}
+			term.Text = rest[:end]
+			rest = rest[end:]
+		}
+		rest = strings.TrimSpace(rest)
+
+		// A lone "-" or empty quotes carry no text to match
+		if term.Text == "" {
+			continue
+		}
+		terms = append(terms, term)
+		if len(terms) > MaxSearchTerms {
+			return nil, fmt.Errorf("%w: search is limited to %d terms", ErrInvalidInput, MaxSearchTerms)
+		}
+	}
+
+	return terms, nil
+}
@@ -0,0 +1,87 @@
+package statement
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseSearch(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []SearchTerm
+	}{
+		{"empty", "  ", nil},
+		{"single word", "password", []SearchTerm{{Text: "password"}}},
+		{"phrase only", `"access control policy"`, []SearchTerm{{Text: "access control policy"}}},
+		{"exclusion only", "-draft", []SearchTerm{{Text: "draft", Exclude: true}}},
+		{"excluded phrase", `-"to be determined"`, []SearchTerm{{Text: "to be determined", Exclude: true}}},
+		{"mixed", `mfa "access control" -draft -"not applicable"`, []SearchTerm{
+			{Text: "mfa"},
+			{Text: "access control"},
+			{Text: "draft", Exclude: true},
+			{Text: "not applicable", Exclude: true},
+		}},
+		{"unterminated quote runs to end", `review "quarterly access`, []SearchTerm{{Text: "review"}, {Text: "quarterly access"}}},
+		{"empty terms skipped", `- "" audit`, []SearchTerm{{Text: "audit"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSearch(tt.input)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestParseSearch_TooManyTerms(t *testing.T) {
+	words := strings.Repeat("term ", MaxSearchTerms)
+	if terms, err := ParseSearch(words); err != nil || len(terms) != MaxSearchTerms {
+		t.Fatalf("expected %d terms to be accepted, got %d terms and %v", MaxSearchTerms, len(terms), err)
+	}
+
+	if _, err := ParseSearch(words + `-"one more"`); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for %d terms, got %v", MaxSearchTerms+1, err)
+	}
+}
+
+// listRepository records the params passed to List.
+type listRepository struct {
+	Repository
+	params *ListParams
+}
+
+func (r *listRepository) List(ctx context.Context, params ListParams) (*ListResult, error) {
+	r.params = &params
+	return &ListResult{}, nil
+}
+
+func TestService_ListByControl_ParsesSearch(t *testing.T) {
+	repo := &listRepository{}
+	svc := NewService(repo, Options{}, nil)
+
+	if _, err := svc.ListByControl(context.Background(), ListParams{Search: `"least privilege" -draft`}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []SearchTerm{{Text: "least privilege"}, {Text: "draft", Exclude: true}}
+	if repo.params == nil || !reflect.DeepEqual(repo.params.SearchTerms, want) {
+		t.Errorf("expected search terms %+v, got %+v", want, repo.params)
+	}
+
+	repo.params = nil
+	_, err := svc.ListByControl(context.Background(), ListParams{Search: strings.Repeat("x ", MaxSearchTerms+1)})
+	if !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput, got %v", err)
+	}
+	if repo.params != nil {
+		t.Error("expected repository not to be queried")
+	}
+}
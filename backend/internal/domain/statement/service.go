@@ -2,25 +2,37 @@ package statement
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"log/slog"
+	"strings"
+	"sync"
 
 	"github.com/google/uuid"
+
+	"github.com/controlcrud/backend/internal/domain/audit"
+	"github.com/controlcrud/backend/internal/infrastructure/servicenow"
 )
 
 // Service provides business logic for statement operations.
 type Service struct {
 	repo   Repository
+	opts   Options
 	logger *slog.Logger
+
+	// freshness caches CheckFreshness answers by statement ID.
+	freshness sync.Map
 }
 
 // NewService creates a new statement service.
-func NewService(repo Repository, logger *slog.Logger) *Service {
+func NewService(repo Repository, opts Options, logger *slog.Logger) *Service {
 	if logger == nil {
 		logger = slog.Default()
 	}
 	return &Service{
 		repo:   repo,
+		opts:   opts,
 		logger: logger,
 	}
 }
@@ -37,6 +49,11 @@ func (s *Service) GetByID(ctx context.Context, id uuid.UUID) (*Statement, error)
 	return stmt, nil
 }
 
+// ListAllByControl retrieves every statement of a control.
+func (s *Service) ListAllByControl(ctx context.Context, controlID uuid.UUID) ([]Statement, error) {
+	return s.repo.ListByControl(ctx, controlID)
+}
+
 // ListByControl retrieves statements for a control with pagination.
 func (s *Service) ListByControl(ctx context.Context, params ListParams) (*ListResult, error) {
 	// Set defaults
@@ -46,24 +63,36 @@ func (s *Service) ListByControl(ctx context.Context, params ListParams) (*ListRe
 	if params.PageSize < 1 {
 		params.PageSize = 20
 	}
-	if params.PageSize > 100 {
-		params.PageSize = 100
+	if params.PageSize > 200 {
+		params.PageSize = 200
+	}
+
+	terms, err := ParseSearch(params.Search)
+	if err != nil {
+		return nil, err
+	}
+	params.SearchTerms = terms
+
+	if params.Tags, err = NormalizeTags(params.Tags); err != nil {
+		return nil, err
 	}
 
 	return s.repo.List(ctx, params)
 }
 
-// ListModified retrieves all statements with local modifications.
-func (s *Service) ListModified(ctx context.Context) ([]Statement, error) {
-	return s.repo.ListModified(ctx)
+// ListModified retrieves statements with local modifications with pagination.
+func (s *Service) ListModified(ctx context.Context, params ModifiedListParams) (*ListResult, error) {
+	return s.repo.ListModified(ctx, params.withDefaults())
 }
 
-// ListConflicts retrieves all statements with sync conflicts.
-func (s *Service) ListConflicts(ctx context.Context) ([]Statement, error) {
-	return s.repo.ListConflicts(ctx)
+// ListConflicts retrieves statements with sync conflicts with pagination.
+func (s *Service) ListConflicts(ctx context.Context, params ModifiedListParams) (*ListResult, error) {
+	return s.repo.ListConflicts(ctx, params.withDefaults())
 }
 
-// UpdateLocal updates the local content of a statement.
+// UpdateLocal updates the local content of a statement. Content that matches
+// the current local content after trimming whitespace is not written; content
+// that matches the remote content reverts the statement to synced.
 func (s *Service) UpdateLocal(ctx context.Context, input UpdateInput) (*Statement, error) {
 	// Verify statement exists
 	existing, err := s.repo.GetByID(ctx, input.ID)
@@ -74,6 +103,47 @@ func (s *Service) UpdateLocal(ctx context.Context, input UpdateInput) (*Statemen
 		return nil, ErrNotFound
 	}
 
+	readOnly, err := s.repo.ListReadOnly(ctx, []uuid.UUID{input.ID})
+	if err != nil {
+		return nil, err
+	}
+	if len(readOnly) > 0 {
+		return nil, ErrSystemReadOnly
+	}
+
+	content, err := s.opts.Sanitization.Sanitize(input.LocalContent)
+	if err != nil {
+		return nil, err
+	}
+	content = NormalizeContent(content)
+	if s.opts.UnwrapOnSave {
+		content = Unwrap(content)
+	}
+	input.LocalContent = content
+
+	// Skip writes that would not change the effective content
+	trimmed := strings.TrimSpace(content)
+	if existing.IsModified && trimmed == strings.TrimSpace(NormalizeContent(existing.LocalContent)) {
+		return existing, nil
+	}
+	if trimmed == strings.TrimSpace(NormalizeContent(existing.RemoteContent)) {
+		if !existing.IsModified {
+			return existing, nil
+		}
+		s.logger.Info("statement content matches remote, reverting", "id", input.ID)
+		return s.repo.ResolveConflict(ctx, ResolveConflictInput{
+			ID:         input.ID,
+			Resolution: ConflictResolutionKeepRemote,
+			ResolvedBy: input.ModifiedBy,
+		})
+	}
+
+	if err := s.opts.ContentLimits.Validate(input.LocalContent); err != nil {
+		return nil, err
+	}
+
+	input.RequireReview = s.opts.ReviewRequired
+
 	s.logger.Info("updating statement", "id", input.ID, "has_content", input.LocalContent != "")
 	return s.repo.UpdateLocal(ctx, input)
 }
@@ -97,12 +167,70 @@ func (s *Service) ResolveConflict(ctx context.Context, input ResolveConflictInpu
 		return nil, fmt.Errorf("%w: merged content is required for merge resolution", ErrInvalidInput)
 	}
 
+	input.RequireReview = s.opts.ReviewRequired
+
 	s.logger.Info("resolving conflict", "id", input.ID, "resolution", input.Resolution)
 	return s.repo.ResolveConflict(ctx, input)
 }
 
-// MarkAsSynced marks a statement as synced after push.
-func (s *Service) MarkAsSynced(ctx context.Context, id uuid.UUID) error {
+// Approve approves a statement's local changes for push.
+func (s *Service) Approve(ctx context.Context, id uuid.UUID, reviewedBy *uuid.UUID, reviewerEmail, comment string) (*Statement, error) {
+	return s.review(ctx, ReviewInput{
+		ID:            id,
+		Status:        ReviewStatusApproved,
+		ReviewedBy:    reviewedBy,
+		ReviewerEmail: reviewerEmail,
+		Comment:       comment,
+	})
+}
+
+// Reject rejects a statement's local changes, blocking push until they are revised.
+func (s *Service) Reject(ctx context.Context, id uuid.UUID, reviewedBy *uuid.UUID, reviewerEmail, comment string) (*Statement, error) {
+	return s.review(ctx, ReviewInput{
+		ID:            id,
+		Status:        ReviewStatusRejected,
+		ReviewedBy:    reviewedBy,
+		ReviewerEmail: reviewerEmail,
+		Comment:       comment,
+	})
+}
+
+// review records a review decision after enforcing the four-eyes rules.
+func (s *Service) review(ctx context.Context, input ReviewInput) (*Statement, error) {
+	if !s.opts.ReviewRequired {
+		return nil, ErrReviewDisabled
+	}
+	if input.ReviewedBy == nil && input.ReviewerEmail == "" {
+		return nil, ErrReviewerRequired
+	}
+
+	existing, err := s.repo.GetByID(ctx, input.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil {
+		return nil, ErrNotFound
+	}
+	if !existing.IsModified {
+		return nil, ErrNotReviewable
+	}
+
+	// The author of the change may not review it, whether they are
+	// identified by user ID or by email
+	if input.ReviewedBy != nil && existing.ModifiedBy != nil && *input.ReviewedBy == *existing.ModifiedBy {
+		return nil, ErrSelfReview
+	}
+	if input.ReviewerEmail != "" && strings.EqualFold(input.ReviewerEmail, existing.ModifiedByEmail) {
+		return nil, ErrSelfReview
+	}
+
+	s.logger.Info("reviewing statement", "id", input.ID, "status", input.Status)
+	return s.repo.SetReview(ctx, input)
+}
+
+// MarkAsSynced marks a statement as synced after it was pushed by the given
+// push job.
+func (s *Service) MarkAsSynced(ctx context.Context, id, jobID uuid.UUID) error {
 	existing, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		return err
@@ -111,7 +239,7 @@ func (s *Service) MarkAsSynced(ctx context.Context, id uuid.UUID) error {
 		return ErrNotFound
 	}
 
-	return s.repo.MarkAsSynced(ctx, id)
+	return s.repo.MarkAsSynced(ctx, id, jobID)
 }
 
 // RevertToRemote discards local changes and reverts to remote content.
@@ -134,3 +262,209 @@ func (s *Service) RevertToRemote(ctx context.Context, id uuid.UUID) (*Statement,
 		Resolution: ConflictResolutionKeepRemote,
 	})
 }
+
+// PreviewRevert reports what RevertToRemote would discard without changing
+// the statement.
+func (s *Service) PreviewRevert(ctx context.Context, id uuid.UUID) (*RevertPreview, error) {
+	existing, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !existing.IsModified {
+		return &RevertPreview{NoChanges: true}, nil
+	}
+
+	return &RevertPreview{
+		CurrentLocalContent: existing.LocalContent,
+		RemoteContent:       existing.RemoteContent,
+		CharsLost:           len(existing.LocalContent) - len(existing.RemoteContent),
+		WordsLost:           len(strings.Fields(existing.LocalContent)) - len(strings.Fields(existing.RemoteContent)),
+	}, nil
+}
+
+// BulkDelete removes the selected statements and records the deletion in the
+// audit log. Statements with local modifications are only deleted when
+// input.Force is set; otherwise ErrCannotDeleteModified is returned and
+// nothing is deleted.
+func (s *Service) BulkDelete(ctx context.Context, input BulkDeleteInput) (int, error) {
+	if err := input.Validate(); err != nil {
+		return 0, err
+	}
+
+	ids := input.StatementIDs
+	if input.ControlID != nil {
+		stmts, err := s.repo.ListByControl(ctx, *input.ControlID)
+		if err != nil {
+			return 0, err
+		}
+		ids = make([]uuid.UUID, 0, len(stmts))
+		for _, stmt := range stmts {
+			ids = append(ids, stmt.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	deleted, err := s.repo.DeleteBatch(ctx, ids, input.Force)
+	if err != nil {
+		return 0, err
+	}
+
+	s.logger.Info("bulk deleted statements", "count", deleted, "control_id", input.ControlID, "force", input.Force)
+
+	if s.opts.Audit != nil {
+		event := audit.Event{
+			EventType:  audit.EventTypeStatementDelete,
+			EntityType: "statement",
+			Action:     "bulk_delete",
+			Status:     "success",
+			Details: map[string]interface{}{
+				"deleted_count": deleted,
+				"force":         input.Force,
+			},
+		}
+		if input.ControlID != nil {
+			event.EntityType = "control"
+			event.EntityID = input.ControlID.String()
+		} else {
+			event.Details["statement_ids"] = ids
+		}
+		s.opts.Audit.RecordAsync(event)
+	}
+
+	return deleted, nil
+}
+
+// ListTypes returns all registered statement types.
+func (s *Service) ListTypes(ctx context.Context) ([]Type, error) {
+	return s.repo.ListTypes(ctx)
+}
+
+// CreateType registers a new statement type.
+func (s *Service) CreateType(ctx context.Context, input CreateTypeInput) (*Type, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("creating statement type", "name", input.Name)
+	return s.repo.CreateType(ctx, input)
+}
+
+// DeleteType removes a statement type that no statement uses.
+func (s *Service) DeleteType(ctx context.Context, name string) error {
+	s.logger.Info("deleting statement type", "name", name)
+	return s.repo.DeleteType(ctx, name)
+}
+
+// CreateTemplate stores a new statement template.
+func (s *Service) CreateTemplate(ctx context.Context, input CreateTemplateInput) (*Template, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("creating statement template", "name", input.Name, "variables", input.Variables)
+	return s.repo.CreateTemplate(ctx, input)
+}
+
+// ApplyTemplate renders a template with the given variables and saves the
+// result as the statement's local content.
+func (s *Service) ApplyTemplate(ctx context.Context, input ApplyTemplateInput) (*Statement, error) {
+	tmpl, err := s.repo.GetTemplate(ctx, input.TemplateID)
+	if err != nil {
+		return nil, err
+	}
+	if tmpl == nil {
+		return nil, ErrTemplateNotFound
+	}
+
+	content, err := tmpl.Render(input.Variables, s.opts.ContentLimits.MaxChars)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("applying statement template", "id", input.StatementID, "template_id", input.TemplateID)
+	return s.UpdateLocal(ctx, UpdateInput{
+		ID:              input.StatementID,
+		LocalContent:    content,
+		ModifiedBy:      input.ModifiedBy,
+		ModifiedByEmail: input.ModifiedByEmail,
+	})
+}
+
+// AttachEvidence uploads an evidence file to the statement's ServiceNow
+// record and records the resulting attachment.
+func (s *Service) AttachEvidence(ctx context.Context, input AttachEvidenceInput) (*Attachment, error) {
+	if s.opts.SNClients == nil || s.opts.AttachmentTable == "" {
+		return nil, ErrAttachmentsUnavailable
+	}
+	if input.FileName == "" || input.Data == nil {
+		return nil, fmt.Errorf("%w: file is required", ErrInvalidInput)
+	}
+
+	stmt, err := s.GetByID(ctx, input.StatementID)
+	if err != nil {
+		return nil, err
+	}
+	if stmt.SNSysID == "" {
+		return nil, ErrNotInServiceNow
+	}
+
+	readOnly, err := s.repo.ListReadOnly(ctx, []uuid.UUID{stmt.ID})
+	if err != nil {
+		return nil, err
+	}
+	if len(readOnly) > 0 {
+		return nil, ErrSystemReadOnly
+	}
+
+	client, err := s.opts.SNClients.GetSNClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrAttachmentUploadFailed, err)
+	}
+
+	snSysID, err := client.UploadAttachment(ctx, s.opts.AttachmentTable, stmt.SNSysID, input.FileName, input.ContentType, input.Data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrAttachmentUploadFailed, err)
+	}
+
+	attachment, err := s.repo.CreateAttachment(ctx, stmt.ID, snSysID, input.FileName)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("attached evidence to statement", "id", stmt.ID, "attachment_sys_id", snSysID, "file_name", input.FileName)
+	return attachment, nil
+}
+
+// DownloadEvidence fetches the content of an evidence file attached to the
+// statement from ServiceNow. It returns the attachment record, its content
+// and the content type; the caller must close the content.
+func (s *Service) DownloadEvidence(ctx context.Context, statementID, attachmentID uuid.UUID) (*Attachment, io.ReadCloser, string, error) {
+	if s.opts.SNClients == nil {
+		return nil, nil, "", ErrAttachmentsUnavailable
+	}
+
+	attachment, err := s.repo.GetAttachment(ctx, statementID, attachmentID)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	if attachment == nil {
+		return nil, nil, "", ErrNotFound
+	}
+
+	client, err := s.opts.SNClients.GetSNClient(ctx)
+	if err != nil {
+		return nil, nil, "", fmt.Errorf("%w: %w", ErrAttachmentDownloadFailed, err)
+	}
+
+	content, contentType, err := client.DownloadAttachment(ctx, attachment.SNSysID)
+	if errors.Is(err, servicenow.ErrNotFound) {
+		return nil, nil, "", fmt.Errorf("%w: attachment was removed from ServiceNow", ErrNotFound)
+	}
+	if err != nil {
+		return nil, nil, "", fmt.Errorf("%w: %w", ErrAttachmentDownloadFailed, err)
+	}
+	return attachment, content, contentType, nil
+}
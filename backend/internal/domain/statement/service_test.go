@@ -0,0 +1,417 @@
+package statement
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// mockRepository implements the Repository methods used by UpdateLocal.
+type mockRepository struct {
+	Repository
+	stmt     *Statement
+	readOnly bool
+	updated  *UpdateInput
+	resolved *ResolveConflictInput
+}
+
+func (m *mockRepository) GetByID(ctx context.Context, id uuid.UUID) (*Statement, error) {
+	return m.stmt, nil
+}
+
+func (m *mockRepository) ListReadOnly(ctx context.Context, ids []uuid.UUID) ([]uuid.UUID, error) {
+	if !m.readOnly {
+		return nil, nil
+	}
+	return ids, nil
+}
+
+func (m *mockRepository) SetReview(ctx context.Context, input ReviewInput) (*Statement, error) {
+	reviewed := *m.stmt
+	reviewed.ReviewStatus = input.Status
+	reviewed.ReviewedBy = input.ReviewedBy
+	return &reviewed, nil
+}
+
+func (m *mockRepository) UpdateLocal(ctx context.Context, input UpdateInput) (*Statement, error) {
+	m.updated = &input
+	updated := *m.stmt
+	updated.LocalContent = input.LocalContent
+	updated.IsModified = true
+	return &updated, nil
+}
+
+func (m *mockRepository) ResolveConflict(ctx context.Context, input ResolveConflictInput) (*Statement, error) {
+	m.resolved = &input
+	resolved := *m.stmt
+	resolved.LocalContent = resolved.RemoteContent
+	resolved.IsModified = false
+	resolved.SyncStatus = SyncStatusSynced
+	return &resolved, nil
+}
+
+func TestContentLimits_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		limits  ContentLimits
+		content string
+		wantErr error
+	}{
+		{"no limits", ContentLimits{}, "", nil},
+		{"empty content below min words", ContentLimits{MinWords: 1}, "", ErrContentTooShort},
+		{"whitespace only", ContentLimits{MinWords: 1}, " \t\n ", ErrContentTooShort},
+		{"one below min words", ContentLimits{MinWords: 3}, "access is", ErrContentTooShort},
+		{"exactly min words", ContentLimits{MinWords: 3}, "access is reviewed", nil},
+		{"words split by mixed whitespace", ContentLimits{MinWords: 3}, "access\tis\n\nreviewed", nil},
+		{"exactly max chars", ContentLimits{MaxChars: 5}, "abcde", nil},
+		{"one over max chars", ContentLimits{MaxChars: 5}, "abcdef", ErrContentTooLong},
+		// "é" is two bytes in UTF-8, so three of them are six bytes
+		{"multi-byte within bytes", ContentLimits{MaxChars: 6}, "ééé", nil},
+		{"multi-byte over bytes", ContentLimits{MaxChars: 5}, "ééé", ErrContentTooLong},
+		{"multi-byte words", ContentLimits{MinWords: 2}, "контроль доступа", nil},
+		{"both limits satisfied", ContentLimits{MinWords: 2, MaxChars: 20}, "access reviewed", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.limits.Validate(tt.content)
+			if tt.wantErr == nil && err != nil {
+				t.Errorf("expected no error, got %v", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestService_UpdateLocal_ContentLimits(t *testing.T) {
+	limits := ContentLimits{MinWords: 3, MaxChars: 40}
+
+	tests := []struct {
+		name    string
+		content string
+		wantErr error
+	}{
+		{"too short", "too short", ErrContentTooShort},
+		{"too long", strings.Repeat("word ", 10), ErrContentTooLong},
+		{"within limits", "Access is reviewed quarterly.", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockRepository{stmt: &Statement{ID: uuid.New()}}
+			svc := NewService(repo, Options{ContentLimits: limits}, nil)
+
+			_, err := svc.UpdateLocal(context.Background(), UpdateInput{ID: repo.stmt.ID, LocalContent: tt.content})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected %v, got %v", tt.wantErr, err)
+				}
+				if repo.updated != nil {
+					t.Error("expected repository not to be updated")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if repo.updated == nil || repo.updated.LocalContent != tt.content {
+				t.Error("expected repository to be updated with the content")
+			}
+		})
+	}
+}
+
+func TestService_UpdateLocal_ImportMode(t *testing.T) {
+	tests := []struct {
+		name     string
+		readOnly bool
+		wantErr  error
+	}{
+		{"editable system", false, nil},
+		{"read-only system", true, ErrSystemReadOnly},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockRepository{stmt: &Statement{ID: uuid.New()}, readOnly: tt.readOnly}
+			svc := NewService(repo, Options{}, nil)
+
+			_, err := svc.UpdateLocal(context.Background(), UpdateInput{ID: repo.stmt.ID, LocalContent: "Access is reviewed."})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+			if updated := repo.updated != nil; updated != (tt.wantErr == nil) {
+				t.Errorf("expected repository updated = %v, got %v", tt.wantErr == nil, updated)
+			}
+		})
+	}
+}
+
+func TestService_UpdateLocal_Sanitizes(t *testing.T) {
+	repo := &mockRepository{stmt: &Statement{ID: uuid.New()}}
+	svc := NewService(repo, Options{Sanitization: SanitizationUGC}, nil)
+
+	stmt, err := svc.UpdateLocal(context.Background(), UpdateInput{
+		ID:           repo.stmt.ID,
+		LocalContent: `<p onclick="x()">Access is <b>reviewed</b>.</p><script>alert(1)</script>`,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "<p>Access is <b>reviewed</b>.</p>"; stmt.LocalContent != want {
+		t.Errorf("expected sanitized content %q, got %q", want, stmt.LocalContent)
+	}
+}
+
+func TestService_UpdateLocal_UnwrapOnSave(t *testing.T) {
+	content := "Access is reviewed\nevery quarter."
+	for _, unwrap := range []bool{false, true} {
+		repo := &mockRepository{stmt: &Statement{ID: uuid.New()}}
+		svc := NewService(repo, Options{UnwrapOnSave: unwrap}, nil)
+
+		stmt, err := svc.UpdateLocal(context.Background(), UpdateInput{ID: repo.stmt.ID, LocalContent: content})
+		if err != nil {
+			t.Fatalf("unwrap=%v: unexpected error: %v", unwrap, err)
+		}
+		want := content
+		if unwrap {
+			want = "Access is reviewed every quarter."
+		}
+		if stmt.LocalContent != want {
+			t.Errorf("unwrap=%v: expected %q, got %q", unwrap, want, stmt.LocalContent)
+		}
+	}
+}
+
+func TestParseConflictStrategy(t *testing.T) {
+	for _, valid := range []string{"manual", "keep_local", "keep_remote"} {
+		if _, err := ParseConflictStrategy(valid); err != nil {
+			t.Errorf("expected %q to be valid, got %v", valid, err)
+		}
+	}
+	for _, invalid := range []string{"", "merge", "KEEP_LOCAL"} {
+		if _, err := ParseConflictStrategy(invalid); err == nil {
+			t.Errorf("expected %q to be invalid", invalid)
+		}
+	}
+}
+
+func TestService_PreviewRevert(t *testing.T) {
+	tests := []struct {
+		name string
+		stmt *Statement
+		want RevertPreview
+	}{
+		{
+			name: "modified",
+			stmt: &Statement{IsModified: true, LocalContent: "Access is reviewed every quarter.", RemoteContent: "Access is reviewed."},
+			want: RevertPreview{
+				CurrentLocalContent: "Access is reviewed every quarter.",
+				RemoteContent:       "Access is reviewed.",
+				CharsLost:           14,
+				WordsLost:           2,
+			},
+		},
+		{
+			name: "unmodified",
+			stmt: &Statement{LocalContent: "Stale draft", RemoteContent: "Access is reviewed."},
+			want: RevertPreview{NoChanges: true},
+		},
+		{
+			name: "modified with null local content",
+			stmt: &Statement{IsModified: true, RemoteContent: "Access is reviewed."},
+			want: RevertPreview{RemoteContent: "Access is reviewed.", CharsLost: -19, WordsLost: -3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockRepository{stmt: tt.stmt}
+			svc := NewService(repo, Options{}, nil)
+
+			got, err := svc.PreviewRevert(context.Background(), uuid.New())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if *got != tt.want {
+				t.Errorf("expected %+v, got %+v", tt.want, *got)
+			}
+			if repo.updated != nil {
+				t.Error("expected preview not to modify the statement")
+			}
+		})
+	}
+}
+
+func TestService_PreviewRevert_NotFound(t *testing.T) {
+	svc := NewService(&mockRepository{}, Options{}, nil)
+
+	if _, err := svc.PreviewRevert(context.Background(), uuid.New()); err != ErrNotFound {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+// bulkDeleteRepository stores statements in memory for BulkDelete tests.
+type bulkDeleteRepository struct {
+	Repository
+	stmts map[uuid.UUID]*Statement
+}
+
+func (m *bulkDeleteRepository) ListByControl(ctx context.Context, controlID uuid.UUID) ([]Statement, error) {
+	var result []Statement
+	for _, s := range m.stmts {
+		if s.ControlID == controlID {
+			result = append(result, *s)
+		}
+	}
+	return result, nil
+}
+
+func (m *bulkDeleteRepository) DeleteBatch(ctx context.Context, ids []uuid.UUID, force bool) (int, error) {
+	for _, id := range ids {
+		if s, ok := m.stmts[id]; ok && s.IsModified && !force {
+			return 0, ErrCannotDeleteModified
+		}
+	}
+	deleted := 0
+	for _, id := range ids {
+		if _, ok := m.stmts[id]; ok {
+			delete(m.stmts, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func TestService_BulkDelete(t *testing.T) {
+	controlID := uuid.New()
+	clean, modified, other := uuid.New(), uuid.New(), uuid.New()
+	newRepo := func() *bulkDeleteRepository {
+		return &bulkDeleteRepository{stmts: map[uuid.UUID]*Statement{
+			clean:    {ID: clean, ControlID: controlID},
+			modified: {ID: modified, ControlID: controlID, IsModified: true},
+			other:    {ID: other, ControlID: uuid.New()},
+		}}
+	}
+
+	tests := []struct {
+		name        string
+		input       BulkDeleteInput
+		wantDeleted int
+		wantErr     error
+		wantLeft    int
+	}{
+		{"no selector", BulkDeleteInput{}, 0, ErrInvalidInput, 3},
+		{"both selectors", BulkDeleteInput{StatementIDs: []uuid.UUID{clean}, ControlID: &controlID}, 0, ErrInvalidInput, 3},
+		{"by IDs", BulkDeleteInput{StatementIDs: []uuid.UUID{clean, other}}, 2, nil, 1},
+		{"modified without force", BulkDeleteInput{StatementIDs: []uuid.UUID{clean, modified}}, 0, ErrCannotDeleteModified, 3},
+		{"control without force", BulkDeleteInput{ControlID: &controlID}, 0, ErrCannotDeleteModified, 3},
+		{"control with force", BulkDeleteInput{ControlID: &controlID, Force: true}, 2, nil, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newRepo()
+			svc := NewService(repo, Options{}, nil)
+
+			deleted, err := svc.BulkDelete(context.Background(), tt.input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if deleted != tt.wantDeleted {
+				t.Errorf("expected %d deleted, got %d", tt.wantDeleted, deleted)
+			}
+			if len(repo.stmts) != tt.wantLeft {
+				t.Errorf("expected %d statements left, got %d", tt.wantLeft, len(repo.stmts))
+			}
+		})
+	}
+}
+
+func TestService_UpdateLocal_ChangeDetection(t *testing.T) {
+	modified := func() *Statement {
+		return &Statement{
+			ID:            uuid.New(),
+			IsModified:    true,
+			SyncStatus:    SyncStatusModified,
+			LocalContent:  "Access is reviewed quarterly.\nBy IAM.",
+			RemoteContent: "Access is reviewed.",
+		}
+	}
+
+	tests := []struct {
+		name         string
+		content      string
+		wantWrite    bool
+		wantReverted bool
+	}{
+		{"new content", "Access is reviewed monthly.", true, false},
+		{"same as local", "  Access is reviewed quarterly.\nBy IAM.\n", false, false},
+		{"same as local with CRLF", "Access is reviewed quarterly. \r\nBy IAM.", false, false},
+		{"same as remote", "Access is reviewed. ", false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockRepository{stmt: modified()}
+			svc := NewService(repo, Options{}, nil)
+
+			stmt, err := svc.UpdateLocal(context.Background(), UpdateInput{ID: repo.stmt.ID, LocalContent: tt.content})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if wrote := repo.updated != nil; wrote != tt.wantWrite {
+				t.Errorf("expected write=%v, got %v", tt.wantWrite, wrote)
+			}
+			if reverted := repo.resolved != nil; reverted != tt.wantReverted {
+				t.Errorf("expected revert=%v, got %v", tt.wantReverted, reverted)
+			}
+			if tt.wantReverted && (stmt.IsModified || stmt.SyncStatus != SyncStatusSynced) {
+				t.Errorf("expected synced, unmodified statement, got %+v", stmt)
+			}
+			if !tt.wantWrite && !tt.wantReverted && stmt != repo.stmt {
+				t.Error("expected the existing statement to be returned")
+			}
+		})
+	}
+}
+
+func TestService_Approve_FourEyes(t *testing.T) {
+	author := uuid.New()
+	reviewer := uuid.New()
+
+	tests := []struct {
+		name          string
+		reviewedBy    *uuid.UUID
+		reviewerEmail string
+		wantErr       error
+	}{
+		{"other user", &reviewer, "bob@example.com", nil},
+		{"other email", nil, "bob@example.com", nil},
+		{"author by ID", &author, "", ErrSelfReview},
+		{"author by email", nil, "Alice@Example.com", ErrSelfReview},
+		{"unidentified reviewer", nil, "", ErrReviewerRequired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockRepository{stmt: &Statement{
+				ID:              uuid.New(),
+				IsModified:      true,
+				ModifiedBy:      &author,
+				ModifiedByEmail: "alice@example.com",
+			}}
+			svc := NewService(repo, Options{ReviewRequired: true}, nil)
+
+			_, err := svc.Approve(context.Background(), repo.stmt.ID, tt.reviewedBy, tt.reviewerEmail, "")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package statement
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// DefaultSimilarityThreshold is the lowest similarity score reported when
+// the caller does not choose one.
+const DefaultSimilarityThreshold = 0.8
+
+// similarityPreviewLength is the number of characters of content shown for
+// each similar statement.
+const similarityPreviewLength = 200
+
+// SimilarStatement is a statement whose content resembles another's.
+type SimilarStatement struct {
+	StatementID uuid.UUID
+	Score       float64 // Cosine similarity from 0 to 1
+	Preview     string  // Start of the content as plain text
+}
+
+// FindSimilar returns the other statements of the same control whose content
+// has a TF-IDF cosine similarity of at least threshold with the statement's,
+// most similar first. The control's statements are the corpus the inverse
+// document frequencies are computed from.
+func (s *Service) FindSimilar(ctx context.Context, id uuid.UUID, threshold float64) ([]SimilarStatement, error) {
+	if threshold < 0 || threshold > 1 || math.IsNaN(threshold) {
+		return nil, fmt.Errorf("%w: threshold must be between 0 and 1", ErrInvalidInput)
+	}
+
+	stmt, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	siblings, err := s.repo.ListByControl(ctx, stmt.ControlID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The target is the first document; the list may or may not include it
+	texts := []string{plainText(stmt.GetContent())}
+	var others []Statement
+	for _, sibling := range siblings {
+		if sibling.ID == stmt.ID {
+			continue
+		}
+		others = append(others, sibling)
+		texts = append(texts, plainText(sibling.GetContent()))
+	}
+
+	docs := make([][]string, len(texts))
+	for i, text := range texts {
+		docs[i] = tokenize(text)
+	}
+	vectors := tfidfVectors(docs)
+
+	similar := make([]SimilarStatement, 0)
+	for i, other := range others {
+		score := cosineSimilarity(vectors[0], vectors[i+1])
+		if score < threshold {
+			continue
+		}
+		similar = append(similar, SimilarStatement{
+			StatementID: other.ID,
+			Score:       math.Round(score*1000) / 1000,
+			Preview:     preview(texts[i+1], similarityPreviewLength),
+		})
+	}
+	sort.SliceStable(similar, func(i, j int) bool { return similar[i].Score > similar[j].Score })
+	return similar, nil
+}
+
+// plainText strips HTML tags and entities from content and collapses runs
+// of whitespace.
+func plainText(content string) string {
+	text := html.UnescapeString(htmlTagPattern.ReplaceAllString(content, " "))
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// preview returns the first n characters of text, marking a cut with "...".
+func preview(text string, n int) string {
+	if utf8.RuneCountInString(text) <= n {
+		return text
+	}
+	runes := []rune(text)
+	return strings.TrimRightFunc(string(runes[:n]), unicode.IsSpace) + "..."
+}
+
+// tokenize splits text into lowercase words of letters and digits.
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// tfidfVectors weights each document's terms by term frequency times
+// smoothed inverse document frequency, ln((1+N)/(1+df)) + 1. The smoothing
+// keeps terms found in every document from dropping out entirely, which
+// would make a corpus of two identical documents score zero.
+func tfidfVectors(docs [][]string) []map[string]float64 {
+	df := make(map[string]int)
+	for _, doc := range docs {
+		seen := make(map[string]bool, len(doc))
+		for _, term := range doc {
+			if !seen[term] {
+				seen[term] = true
+				df[term]++
+			}
+		}
+	}
+
+	n := float64(len(docs))
+	vectors := make([]map[string]float64, len(docs))
+	for i, doc := range docs {
+		vector := make(map[string]float64, len(doc))
+		for _, term := range doc {
+			vector[term]++
+		}
+		for term, count := range vector {
+			tf := count / float64(len(doc))
+			idf := math.Log((1+n)/(1+float64(df[term]))) + 1
+			vector[term] = tf * idf
+		}
+		vectors[i] = vector
+	}
+	return vectors
+}
+
+// cosineSimilarity returns the cosine of the angle between two term vectors,
+// or 0 when either is empty.
+func cosineSimilarity(a, b map[string]float64) float64 {
+	var dot, normA, normB float64
+	for term, weight := range a {
+		dot += weight * b[term]
+		normA += weight * weight
+	}
+	for _, weight := range b {
+		normB += weight * weight
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	// Rounding can push identical vectors just past 1
+	return math.Min(dot/(math.Sqrt(normA)*math.Sqrt(normB)), 1)
+}
@@ -0,0 +1,161 @@
+package statement
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestCosineSimilarity_TextPairs(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     string
+		min, max float64
+	}{
+		{
+			name: "identical",
+			a:    "User access is reviewed quarterly by the system owner.",
+			b:    "User access is reviewed quarterly by the system owner.",
+			min:  0.999, max: 1,
+		},
+		{
+			name: "case, punctuation and markup differ",
+			a:    "User access is reviewed quarterly by the system owner.",
+			b:    "<p>USER ACCESS is reviewed, quarterly, by the system owner</p>",
+			min:  0.999, max: 1,
+		},
+		{
+			name: "reworded",
+			a:    "User access is reviewed quarterly by the system owner.",
+			b:    "The system owner reviews user access every quarter.",
+			min:  0.3, max: 0.8,
+		},
+		{
+			name: "unrelated",
+			a:    "User access is reviewed quarterly by the system owner.",
+			b:    "Backups are encrypted and stored offsite for ninety days.",
+			min:  0, max: 0.1,
+		},
+		{
+			name: "empty",
+			a:    "User access is reviewed quarterly.",
+			b:    "",
+			min:  0, max: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vectors := tfidfVectors([][]string{tokenize(plainText(tt.a)), tokenize(plainText(tt.b))})
+			got := cosineSimilarity(vectors[0], vectors[1])
+			if got < tt.min || got > tt.max {
+				t.Errorf("expected similarity in [%v, %v], got %v", tt.min, tt.max, got)
+			}
+		})
+	}
+}
+
+func TestTFIDFVectors_RareTermsWeighMore(t *testing.T) {
+	vectors := tfidfVectors([][]string{
+		{"access", "review"},
+		{"access", "backup"},
+		{"access", "logging"},
+	})
+	if vectors[0]["review"] <= vectors[0]["access"] {
+		t.Errorf("expected a term in one document to outweigh one in all, got %v", vectors[0])
+	}
+}
+
+func TestPreview(t *testing.T) {
+	if got := preview("Short text.", 20); got != "Short text." {
+		t.Errorf("expected short text unchanged, got %q", got)
+	}
+	if got := preview("Access is reviewed every quarter.", 10); got != "Access is..." {
+		t.Errorf("expected cut text, got %q", got)
+	}
+	if got := preview("Zugriffsprüfung", 13); got != "Zugriffsprüfu..." {
+		t.Errorf("expected cut on a character boundary, got %q", got)
+	}
+}
+
+// similarityRepository serves the statements of one control.
+type similarityRepository struct {
+	Repository
+	stmts []Statement
+}
+
+func (m *similarityRepository) GetByID(ctx context.Context, id uuid.UUID) (*Statement, error) {
+	for _, s := range m.stmts {
+		if s.ID == id {
+			return &s, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *similarityRepository) ListByControl(ctx context.Context, controlID uuid.UUID) ([]Statement, error) {
+	var result []Statement
+	for _, s := range m.stmts {
+		if s.ControlID == controlID {
+			result = append(result, s)
+		}
+	}
+	return result, nil
+}
+
+func TestService_FindSimilar(t *testing.T) {
+	controlID := uuid.New()
+	newStmt := func(content string) Statement {
+		return Statement{ID: uuid.New(), ControlID: controlID, RemoteContent: content}
+	}
+	target := newStmt("User access is reviewed quarterly by the system owner.")
+	duplicate := newStmt("User access is reviewed quarterly by the system owner.")
+	nearDuplicate := newStmt("User access is reviewed quarterly by the owner of the system.")
+	unrelated := newStmt("Backups are encrypted and stored offsite for ninety days.")
+	edited := newStmt("Backups are encrypted.")
+	edited.IsModified = true
+	edited.LocalContent = "User access is reviewed quarterly by the system owner and the ISSO."
+	otherControl := Statement{ID: uuid.New(), ControlID: uuid.New(), RemoteContent: target.RemoteContent}
+
+	repo := &similarityRepository{stmts: []Statement{target, unrelated, nearDuplicate, duplicate, edited, otherControl}}
+	svc := NewService(repo, Options{}, nil)
+
+	similar, err := svc.FindSimilar(context.Background(), target.ID, 0.5)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []uuid.UUID{duplicate.ID, nearDuplicate.ID, edited.ID}
+	if len(similar) != len(want) {
+		t.Fatalf("expected %d similar statements, got %+v", len(want), similar)
+	}
+	for i, id := range want {
+		if similar[i].StatementID != id {
+			t.Errorf("result %d: expected %s, got %s (score %v)", i, id, similar[i].StatementID, similar[i].Score)
+		}
+	}
+	if similar[0].Score != 1 {
+		t.Errorf("expected an exact duplicate to score 1, got %v", similar[0].Score)
+	}
+	if !strings.HasPrefix(similar[2].Preview, "User access is reviewed") {
+		t.Errorf("expected preview of the local content, got %q", similar[2].Preview)
+	}
+
+	// The default threshold keeps only close matches
+	similar, err = svc.FindSimilar(context.Background(), target.ID, DefaultSimilarityThreshold)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(similar) == 0 || similar[len(similar)-1].Score < DefaultSimilarityThreshold {
+		t.Errorf("expected only scores above the threshold, got %+v", similar)
+	}
+
+	if _, err := svc.FindSimilar(context.Background(), target.ID, 1.5); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for threshold above 1, got %v", err)
+	}
+	if _, err := svc.FindSimilar(context.Background(), uuid.New(), 0.5); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for unknown statement, got %v", err)
+	}
+}
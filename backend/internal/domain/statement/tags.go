@@ -0,0 +1,97 @@
+package statement
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// Limits on statement tags.
+const (
+	MaxTagLength      = 50
+	MaxTagsPerRequest = 20
+)
+
+// NormalizeTag trims and lowercases a tag. Tags must be 1 to MaxTagLength
+// characters and may not contain "/", which would break tag URLs.
+func NormalizeTag(tag string) (string, error) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if tag == "" {
+		return "", fmt.Errorf("%w: tag must not be empty", ErrInvalidTag)
+	}
+	if utf8.RuneCountInString(tag) > MaxTagLength {
+		return "", fmt.Errorf("%w: tag must be at most %d characters", ErrInvalidTag, MaxTagLength)
+	}
+	if strings.Contains(tag, "/") {
+		return "", fmt.Errorf("%w: tag must not contain \"/\"", ErrInvalidTag)
+	}
+	return tag, nil
+}
+
+// NormalizeTags normalizes each tag and drops duplicates, keeping the first
+// occurrence's position.
+func NormalizeTags(tags []string) ([]string, error) {
+	var normalized []string
+	for _, tag := range tags {
+		tag, err := NormalizeTag(tag)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(normalized, tag) {
+			normalized = append(normalized, tag)
+		}
+	}
+	return normalized, nil
+}
+
+// AddTags labels a statement with the given tags and returns the updated
+// statement. Tags the statement already has are ignored.
+func (s *Service) AddTags(ctx context.Context, id uuid.UUID, tags []string, createdBy *uuid.UUID) (*Statement, error) {
+	if len(tags) == 0 {
+		return nil, fmt.Errorf("%w: at least one tag is required", ErrInvalidTag)
+	}
+	if len(tags) > MaxTagsPerRequest {
+		return nil, fmt.Errorf("%w: at most %d tags can be added at once", ErrInvalidTag, MaxTagsPerRequest)
+	}
+	tags, err := NormalizeTags(tags)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.AddTags(ctx, id, tags, createdBy); err != nil {
+		return nil, err
+	}
+	s.logger.Info("tagged statement", "id", id, "tags", tags)
+	return s.GetByID(ctx, id)
+}
+
+// RemoveTag removes a tag from a statement. Returns ErrTagNotFound if the
+// statement does not have the tag.
+func (s *Service) RemoveTag(ctx context.Context, id uuid.UUID, tag string) error {
+	tag, err := NormalizeTag(tag)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.GetByID(ctx, id); err != nil {
+		return err
+	}
+
+	removed, err := s.repo.RemoveTag(ctx, id, tag)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return ErrTagNotFound
+	}
+	s.logger.Info("untagged statement", "id", id, "tag", tag)
+	return nil
+}
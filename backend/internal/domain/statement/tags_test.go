@@ -0,0 +1,37 @@
+package statement
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeTags(t *testing.T) {
+	tests := []struct {
+		name    string
+		tags    []string
+		want    []string
+		wantErr error
+	}{
+		{"none", nil, nil, nil},
+		{"lowercased and trimmed", []string{" GDPR ", "Hipaa"}, []string{"gdpr", "hipaa"}, nil},
+		{"duplicates dropped", []string{"gdpr", "hipaa", "GDPR"}, []string{"gdpr", "hipaa"}, nil},
+		{"empty", []string{"gdpr", "  "}, nil, ErrInvalidTag},
+		{"slash", []string{"pci/dss"}, nil, ErrInvalidTag},
+		{"at max length", []string{strings.Repeat("a", MaxTagLength)}, []string{strings.Repeat("a", MaxTagLength)}, nil},
+		{"too long", []string{strings.Repeat("a", MaxTagLength+1)}, nil, ErrInvalidTag},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeTags(tt.tags)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package statement
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Template is reusable statement boilerplate with {{variable}} placeholders.
+type Template struct {
+	ID              uuid.UUID  `json:"id"`
+	Name            string     `json:"name"`
+	ContentTemplate string     `json:"content_template"`
+	Variables       []string   `json:"variables"` // Names that must be supplied when rendering
+	CreatedBy       *uuid.UUID `json:"created_by,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+// CreateTemplateInput holds data for creating a statement template.
+type CreateTemplateInput struct {
+	Name            string
+	ContentTemplate string
+	Variables       []string
+	CreatedBy       *uuid.UUID
+}
+
+// Validate validates the CreateTemplateInput. Placeholders used in the
+// content are added to Variables so every one of them is required.
+func (c *CreateTemplateInput) Validate() error {
+	c.Name = strings.TrimSpace(c.Name)
+	if c.Name == "" {
+		return fmt.Errorf("%w: template name is required", ErrInvalidInput)
+	}
+	if strings.TrimSpace(c.ContentTemplate) == "" {
+		return fmt.Errorf("%w: content_template is required", ErrInvalidInput)
+	}
+	for _, v := range c.Variables {
+		if !variableNamePattern.MatchString(v) {
+			return fmt.Errorf("%w: invalid variable name %q", ErrInvalidInput, v)
+		}
+	}
+	if err := checkActions(c.ContentTemplate); err != nil {
+		return err
+	}
+
+	c.Variables = requiredVariables(c.ContentTemplate, c.Variables)
+	return nil
+}
+
+// ApplyTemplateInput holds data for rendering a template into a statement.
+type ApplyTemplateInput struct {
+	StatementID     uuid.UUID
+	TemplateID      uuid.UUID
+	Variables       map[string]string
+	ModifiedBy      *uuid.UUID
+	ModifiedByEmail string
+}
+
+// MissingVariablesError reports template variables that were not supplied.
+type MissingVariablesError struct {
+	Names []string
+}
+
+func (e *MissingVariablesError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrMissingTemplateVariables, strings.Join(e.Names, ", "))
+}
+
+// Is makes errors.Is(err, ErrMissingTemplateVariables) match.
+func (e *MissingVariablesError) Is(target error) bool {
+	return target == ErrMissingTemplateVariables
+}
+
+var (
+	variableNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+	// placeholderPattern matches {{name}} with optional inner whitespace.
+	placeholderPattern = regexp.MustCompile(`{{\s*([A-Za-z_][A-Za-z0-9_]*)\s*}}`)
+)
+
+// Render replaces the template's placeholders with the given values.
+// Returns a *MissingVariablesError listing every required variable that
+// has no value, and ErrContentTooLong once the output would exceed
+// maxBytes. A maxBytes of 0 or less disables the cap.
+func (t *Template) Render(values map[string]string, maxBytes int) (string, error) {
+	var missing []string
+	for _, name := range requiredVariables(t.ContentTemplate, t.Variables) {
+		if _, ok := values[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return "", &MissingVariablesError{Names: missing}
+	}
+
+	// Values are written as-is, never parsed again, so a value containing
+	// {{...}} is not substituted
+	b := cappedBuilder{max: maxBytes}
+	last := 0
+	for _, m := range placeholderPattern.FindAllStringSubmatchIndex(t.ContentTemplate, -1) {
+		if err := b.write(t.ContentTemplate[last:m[0]]); err != nil {
+			return "", err
+		}
+		if err := b.write(values[t.ContentTemplate[m[2]:m[3]]]); err != nil {
+			return "", err
+		}
+		last = m[1]
+	}
+	if err := b.write(t.ContentTemplate[last:]); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// checkActions rejects content using any {{...}} action other than a
+// {{name}} placeholder. Templates are plain text with substitutions, not
+// programs.
+func checkActions(content string) error {
+	rest := placeholderPattern.ReplaceAllString(content, "")
+	if strings.Contains(rest, "{{") || strings.Contains(rest, "}}") {
+		return fmt.Errorf("%w: only {{name}} placeholders are supported", ErrInvalidTemplate)
+	}
+	return nil
+}
+
+// cappedBuilder builds a string of at most max bytes. A max of 0 or less
+// disables the cap.
+type cappedBuilder struct {
+	strings.Builder
+	max int
+}
+
+// write appends s, or returns ErrContentTooLong if that would exceed the cap.
+func (b *cappedBuilder) write(s string) error {
+	if b.max > 0 && b.Len()+len(s) > b.max {
+		return fmt.Errorf("%w: rendered template exceeds %d bytes", ErrContentTooLong, b.max)
+	}
+	b.WriteString(s)
+	return nil
+}
+
+// requiredVariables returns the sorted union of the declared variables and
+// the placeholders used in content.
+func requiredVariables(content string, declared []string) []string {
+	seen := make(map[string]bool, len(declared))
+	for _, v := range declared {
+		seen[v] = true
+	}
+	for _, m := range placeholderPattern.FindAllStringSubmatch(content, -1) {
+		seen[m[1]] = true
+	}
+
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
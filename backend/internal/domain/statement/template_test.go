@@ -0,0 +1,185 @@
+package statement
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestTemplate_Render(t *testing.T) {
+	tmpl := &Template{
+		ContentTemplate: "{{system_name}} access is reviewed by {{ owner }}. {{system_name}} logs are retained.",
+		Variables:       []string{"owner", "system_name"},
+	}
+
+	tests := []struct {
+		name        string
+		values      map[string]string
+		want        string
+		wantMissing []string
+	}{
+		{
+			name:   "all variables",
+			values: map[string]string{"system_name": "ACME", "owner": "Alice"},
+			want:   "ACME access is reviewed by Alice. ACME logs are retained.",
+		},
+		{
+			name:   "extra variables ignored",
+			values: map[string]string{"system_name": "ACME", "owner": "Alice", "unused": "x"},
+			want:   "ACME access is reviewed by Alice. ACME logs are retained.",
+		},
+		{
+			name:   "values are not re-evaluated",
+			values: map[string]string{"system_name": "{{owner}}", "owner": "Alice"},
+			want:   "{{owner}} access is reviewed by Alice. {{owner}} logs are retained.",
+		},
+		{
+			name:        "one missing",
+			values:      map[string]string{"system_name": "ACME"},
+			wantMissing: []string{"owner"},
+		},
+		{
+			name:        "all missing",
+			values:      nil,
+			wantMissing: []string{"owner", "system_name"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tmpl.Render(tt.values, 0)
+			if tt.wantMissing != nil {
+				var missing *MissingVariablesError
+				if !errors.As(err, &missing) {
+					t.Fatalf("expected MissingVariablesError, got %v", err)
+				}
+				if !errors.Is(err, ErrMissingTemplateVariables) {
+					t.Errorf("expected error to match ErrMissingTemplateVariables")
+				}
+				if !reflect.DeepEqual(missing.Names, tt.wantMissing) {
+					t.Errorf("expected missing %v, got %v", tt.wantMissing, missing.Names)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestTemplate_Render_MaxBytes(t *testing.T) {
+	tmpl := &Template{ContentTemplate: "{{a}}{{a}}{{a}}{{a}}"}
+	values := map[string]string{"a": "12345"}
+
+	got, err := tmpl.Render(values, 20)
+	if err != nil {
+		t.Fatalf("unexpected error at the cap: %v", err)
+	}
+	if got != "12345123451234512345" {
+		t.Errorf("unexpected output %q", got)
+	}
+
+	if _, err := tmpl.Render(values, 19); !errors.Is(err, ErrContentTooLong) {
+		t.Errorf("expected ErrContentTooLong over the cap, got %v", err)
+	}
+}
+
+func TestCreateTemplateInput_Validate(t *testing.T) {
+	input := CreateTemplateInput{
+		Name:            " Access review ",
+		ContentTemplate: "Reviewed by {{owner}} for {{system_name}}.",
+		Variables:       []string{"review_period"},
+	}
+	if err := input.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if input.Name != "Access review" {
+		t.Errorf("expected trimmed name, got %q", input.Name)
+	}
+	want := []string{"owner", "review_period", "system_name"}
+	if !reflect.DeepEqual(input.Variables, want) {
+		t.Errorf("expected variables %v, got %v", want, input.Variables)
+	}
+
+	invalid := []struct {
+		name    string
+		input   CreateTemplateInput
+		wantErr error
+	}{
+		{"missing name", CreateTemplateInput{ContentTemplate: "x"}, ErrInvalidInput},
+		{"missing content", CreateTemplateInput{Name: "x"}, ErrInvalidInput},
+		{"bad variable name", CreateTemplateInput{Name: "x", ContentTemplate: "x", Variables: []string{"system name"}}, ErrInvalidInput},
+		{"pipeline action", CreateTemplateInput{Name: "x", ContentTemplate: `{{printf "%s" .}}`}, ErrInvalidTemplate},
+		{"range action", CreateTemplateInput{Name: "x", ContentTemplate: "{{range .}}{{.}}{{end}}"}, ErrInvalidTemplate},
+		{"field access", CreateTemplateInput{Name: "x", ContentTemplate: "{{.Owner}}"}, ErrInvalidTemplate},
+		{"unclosed action", CreateTemplateInput{Name: "x", ContentTemplate: "Reviewed by {{owner"}, ErrInvalidTemplate},
+	}
+	for _, tt := range invalid {
+		if err := tt.input.Validate(); !errors.Is(err, tt.wantErr) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.wantErr, err)
+		}
+	}
+}
+
+// templateRepository adds template lookups to mockRepository.
+type templateRepository struct {
+	mockRepository
+	template *Template
+}
+
+func (m *templateRepository) GetTemplate(ctx context.Context, id uuid.UUID) (*Template, error) {
+	if m.template == nil || m.template.ID != id {
+		return nil, nil
+	}
+	return m.template, nil
+}
+
+func TestService_ApplyTemplate(t *testing.T) {
+	tmpl := &Template{
+		ID:              uuid.New(),
+		ContentTemplate: "{{system_name}} is owned by {{owner}}.",
+		Variables:       []string{"owner", "system_name"},
+	}
+	repo := &templateRepository{
+		mockRepository: mockRepository{stmt: &Statement{ID: uuid.New()}},
+		template:       tmpl,
+	}
+	svc := NewService(repo, Options{}, nil)
+
+	stmt, err := svc.ApplyTemplate(context.Background(), ApplyTemplateInput{
+		StatementID: repo.stmt.ID,
+		TemplateID:  tmpl.ID,
+		Variables:   map[string]string{"system_name": "ACME", "owner": "Alice"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stmt.LocalContent != "ACME is owned by Alice." {
+		t.Errorf("unexpected content %q", stmt.LocalContent)
+	}
+
+	repo.updated = nil
+	_, err = svc.ApplyTemplate(context.Background(), ApplyTemplateInput{
+		StatementID: repo.stmt.ID,
+		TemplateID:  tmpl.ID,
+		Variables:   map[string]string{"system_name": "ACME"},
+	})
+	if !errors.Is(err, ErrMissingTemplateVariables) {
+		t.Fatalf("expected ErrMissingTemplateVariables, got %v", err)
+	}
+	if repo.updated != nil {
+		t.Error("statement must not be updated when variables are missing")
+	}
+
+	_, err = svc.ApplyTemplate(context.Background(), ApplyTemplateInput{StatementID: repo.stmt.ID, TemplateID: uuid.New()})
+	if !errors.Is(err, ErrTemplateNotFound) {
+		t.Errorf("expected ErrTemplateNotFound, got %v", err)
+	}
+}
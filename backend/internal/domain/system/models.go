@@ -1,11 +1,51 @@
 package system
 
 import (
+	"fmt"
 	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
+
+	"github.com/controlcrud/backend/internal/domain/control"
+)
+
+// Column limits for locally editable system fields.
+const (
+	MaxAcronymLength = 50
+	MaxOwnerLength   = 255
+)
+
+// Local lifecycle statuses an operator can assign to systems.
+const (
+	StatusActive         = "active"
+	StatusInactive       = "inactive"
+	StatusDecommissioned = "decommissioned"
+)
+
+// Import modes controlling whether a system's statements can be changed locally.
+const (
+	ImportModeEditable = "editable"
+	ImportModeReadOnly = "read_only"
 )
 
+// IsValidImportMode returns true if mode is a known import mode.
+func IsValidImportMode(mode string) bool {
+	return mode == ImportModeEditable || mode == ImportModeReadOnly
+}
+
+// MaxBatchStatusIDs is the most systems BatchUpdateStatus changes per call.
+const MaxBatchStatusIDs = 50
+
+// IsValidStatus returns true if status is a local lifecycle status.
+func IsValidStatus(status string) bool {
+	switch status {
+	case StatusActive, StatusInactive, StatusDecommissioned:
+		return true
+	}
+	return false
+}
+
 // System represents a system/application that contains controls.
 // In IRM, this maps to a scoped item or business entity.
 // DEMO MODE: Maps from incident categories.
@@ -18,14 +58,23 @@ type System struct {
 	Owner       string     `json:"owner,omitempty"`
 	Status      string     `json:"status"`
 
+	// ConflictStrategy overrides the global pull conflict strategy for this
+	// system's statements. Empty uses the global default.
+	ConflictStrategy string `json:"conflict_resolution_strategy,omitempty"`
+
+	// ImportMode is editable or read_only. Statements of read-only systems
+	// cannot be edited locally or pushed.
+	ImportMode string `json:"import_mode"`
+
 	// Sync metadata
 	SNUpdatedOn *time.Time `json:"sn_updated_on,omitempty"`
 	LastPullAt  *time.Time `json:"last_pull_at,omitempty"`
 	LastPushAt  *time.Time `json:"last_push_at,omitempty"`
 
 	// Audit
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"` // Set when soft-deleted
 }
 
 // SystemWithStats includes additional statistics about the system.
@@ -36,6 +85,58 @@ type SystemWithStats struct {
 	ModifiedCount  int `json:"modified_count"` // Locally modified statements
 }
 
+// RecomputeCountsResult reports how many rows held stale denormalized counts
+// when the counts were rebuilt.
+type RecomputeCountsResult struct {
+	SystemsUpdated  int `json:"systems_updated"`
+	ControlsUpdated int `json:"controls_updated"`
+}
+
+// SystemSummary holds aggregate compliance figures for a system.
+type SystemSummary struct {
+	SystemID             uuid.UUID  `json:"system_id"`
+	Name                 string     `json:"name"`
+	TotalControls        int        `json:"total_controls"`
+	TotalStatements      int        `json:"total_statements"`
+	SyncedCount          int        `json:"synced_count"`
+	ModifiedCount        int        `json:"modified_count"`
+	ConflictCount        int        `json:"conflict_count"`
+	LastPullAt           *time.Time `json:"last_pull_at,omitempty"`
+	CompliancePercentage float64    `json:"compliance_percentage"` // synced / total statements * 100
+}
+
+// ControlFamilyStats holds control and statement counts for one control
+// family of a system.
+type ControlFamilyStats struct {
+	Family             string `json:"family"`
+	TotalControls      int    `json:"total_controls"`
+	SyncedStatements   int    `json:"synced_statements"`
+	ModifiedStatements int    `json:"modified_statements"`
+	ConflictStatements int    `json:"conflict_statements"`
+	CoveragePct        int    `json:"coverage_pct"` // synced statements / total controls * 100, rounded down
+}
+
+// SyncStatusCounts holds statement counts per sync status.
+type SyncStatusCounts struct {
+	Synced   int `json:"synced"`
+	Modified int `json:"modified"`
+	Conflict int `json:"conflict"`
+	Total    int `json:"total"`
+}
+
+// SystemSyncStatus holds the statement sync status counts of one system.
+type SystemSyncStatus struct {
+	SystemID uuid.UUID `json:"system_id"`
+	Name     string    `json:"name"`
+	SyncStatusCounts
+}
+
+// Dashboard aggregates statement sync status across all systems.
+type Dashboard struct {
+	Systems []SystemSyncStatus `json:"systems"`
+	Global  SyncStatusCounts   `json:"global"`
+}
+
 // DiscoveredSystem represents a system found in ServiceNow that may not be imported yet.
 type DiscoveredSystem struct {
 	SNSysID     string `json:"sn_sys_id"`
@@ -45,12 +146,39 @@ type DiscoveredSystem struct {
 	IsImported  bool   `json:"is_imported"` // True if already in local database
 }
 
+// Options configures optional system service behaviour.
+type Options struct {
+	// DiscoveryCacheTTL is how long the last discovered system list may be
+	// served in place of a slow ServiceNow response. Zero disables the
+	// discovery cache.
+	DiscoveryCacheTTL time.Duration
+
+	// DiscoverySlowThreshold is how long discovery waits for ServiceNow
+	// before answering from a fresh cache. Zero means 3 seconds.
+	DiscoverySlowThreshold time.Duration
+}
+
+func (o Options) discoverySlowThreshold() time.Duration {
+	if o.DiscoverySlowThreshold <= 0 {
+		return 3 * time.Second
+	}
+	return o.DiscoverySlowThreshold
+}
+
+// DiscoveryCache is the system list of the last discovery run.
+type DiscoveryCache struct {
+	RunAt   time.Time
+	Systems []DiscoveredSystem
+}
+
 // ListParams holds parameters for listing systems.
 type ListParams struct {
 	Page     int    `json:"page"`
 	PageSize int    `json:"page_size"`
 	Search   string `json:"search,omitempty"`
 	Status   string `json:"status,omitempty"`
+
+	IncludeDeleted bool `json:"include_deleted,omitempty"` // Include soft-deleted systems
 }
 
 // ListResult holds the result of listing systems.
@@ -62,6 +190,31 @@ type ListResult struct {
 	TotalPages int               `json:"total_pages"`
 }
 
+// MetadataPatch holds local edits to a system's descriptive fields.
+// Nil fields are left unchanged. The next pull overwrites these fields
+// with the values from ServiceNow.
+type MetadataPatch struct {
+	Acronym     *string `json:"acronym,omitempty"`
+	Owner       *string `json:"owner,omitempty"`
+	Description *string `json:"description,omitempty"`
+}
+
+// IsEmpty returns true if the patch changes no fields.
+func (p MetadataPatch) IsEmpty() bool {
+	return p.Acronym == nil && p.Owner == nil && p.Description == nil
+}
+
+// Validate checks the patched values fit their columns.
+func (p MetadataPatch) Validate() error {
+	if p.Acronym != nil && utf8.RuneCountInString(*p.Acronym) > MaxAcronymLength {
+		return fmt.Errorf("%w: acronym must be at most %d characters", ErrInvalidInput, MaxAcronymLength)
+	}
+	if p.Owner != nil && utf8.RuneCountInString(*p.Owner) > MaxOwnerLength {
+		return fmt.Errorf("%w: owner must be at most %d characters", ErrInvalidInput, MaxOwnerLength)
+	}
+	return nil
+}
+
 // UpsertInput holds data for creating or updating a system.
 type UpsertInput struct {
 	SNSysID     string
@@ -71,4 +224,29 @@ type UpsertInput struct {
 	Owner       string
 	Status      string
 	SNUpdatedOn *time.Time
+	// ImportMode is applied on insert and on re-import; empty keeps the
+	// existing mode, or editable for new systems.
+	ImportMode string
+}
+
+// ControlComparison is the result of comparing the controls of two systems
+// by control ID. Each list is ordered by control ID.
+type ControlComparison struct {
+	OnlyInLeft      []string            `json:"only_in_left"`
+	OnlyInRight     []string            `json:"only_in_right"`
+	InBothDifferent []ControlDifference `json:"in_both_different"`
+	Identical       []string            `json:"identical"`
+}
+
+// ControlDifference holds the two versions of a control present in both
+// compared systems whose implementation status or name differ.
+type ControlDifference struct {
+	Left  control.Control `json:"left"`
+	Right control.Control `json:"right"`
+}
+
+// controlsDiffer returns true if two controls with the same control ID
+// differ in implementation status or name.
+func controlsDiffer(left, right control.Control) bool {
+	return left.ImplementationStatus != right.ImplementationStatus || left.ControlName != right.ControlName
 }
@@ -26,12 +26,45 @@ type Repository interface {
 	// UpsertBatch creates or updates multiple systems.
 	UpsertBatch(ctx context.Context, inputs []UpsertInput) ([]System, error)
 
-	// Delete removes a system and all its related controls/statements.
+	// GetSummary retrieves aggregate control and statement counts for a system.
+	GetSummary(ctx context.Context, id uuid.UUID) (*SystemSummary, error)
+
+	// GetControlFamilyStats retrieves control and statement counts per
+	// control family of a system, ordered by family. CoveragePct is not set.
+	GetControlFamilyStats(ctx context.Context, systemID uuid.UUID) ([]ControlFamilyStats, error)
+
+	// GetSyncStatusCounts retrieves statement sync status counts for every
+	// system that is not deleted, ordered by name.
+	GetSyncStatusCounts(ctx context.Context) ([]SystemSyncStatus, error)
+
+	// Delete soft-deletes a system, keeping its related controls/statements.
 	Delete(ctx context.Context, id uuid.UUID) error
 
-	// UpdateLastPullAt updates the last pull timestamp.
-	UpdateLastPullAt(ctx context.Context, id uuid.UUID) error
+	// Restore clears the soft-delete marker of a system.
+	Restore(ctx context.Context, id uuid.UUID) (*System, error)
+
+	// SetConflictStrategy sets the system's conflict strategy override; empty clears it.
+	SetConflictStrategy(ctx context.Context, id uuid.UUID, strategy string) (*System, error)
+
+	// UpdateMetadata applies a metadata patch to a system. Only the fields
+	// set in the patch are written; an empty patch is a no-op. Returns
+	// ErrNotFound if the system does not exist or is deleted.
+	UpdateMetadata(ctx context.Context, id uuid.UUID, patch MetadataPatch) error
+
+	// UpdateStatusBatch sets the status of the given systems that exist and
+	// are not deleted, returning the IDs of the updated systems.
+	UpdateStatusBatch(ctx context.Context, ids []uuid.UUID, status string) ([]uuid.UUID, error)
+
+	// UpdateLastPullAt records a completed pull of the system from the
+	// connection with the given label.
+	UpdateLastPullAt(ctx context.Context, id uuid.UUID, connectionLabel string) error
 
 	// GetAllSNSysIDs returns all ServiceNow sys_ids for existing systems.
 	GetAllSNSysIDs(ctx context.Context) ([]string, error)
+
+	// GetDiscoveryCache returns the last cached discovery, or nil if there is none.
+	GetDiscoveryCache(ctx context.Context) (*DiscoveryCache, error)
+
+	// SaveDiscoveryCache replaces the cached discovery with the given systems.
+	SaveDiscoveryCache(ctx context.Context, systems []DiscoveredSystem) error
 }
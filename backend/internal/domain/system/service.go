@@ -4,10 +4,16 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"math"
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
 
+	"github.com/controlcrud/backend/internal/domain/audit"
+	"github.com/controlcrud/backend/internal/domain/control"
+	"github.com/controlcrud/backend/internal/domain/statement"
 	"github.com/controlcrud/backend/internal/infrastructure/servicenow"
 )
 
@@ -19,18 +25,37 @@ type SNClientProvider interface {
 // Service provides business logic for system operations.
 type Service struct {
 	repo           Repository
+	controlRepo    control.Repository
 	snClientGetter SNClientProvider
+	auditService   *audit.Service
+	opts           Options
 	logger         *slog.Logger
+
+	// dashboardCache holds the last computed *Dashboard under dashboardCacheKey.
+	dashboardCache sync.Map
+}
+
+// dashboardCacheTTL is how long an aggregated dashboard is served from memory.
+const dashboardCacheTTL = 30 * time.Second
+
+const dashboardCacheKey = "sync-status"
+
+type cachedDashboard struct {
+	dashboard *Dashboard
+	expiresAt time.Time
 }
 
 // NewService creates a new system service.
-func NewService(repo Repository, snClientGetter SNClientProvider, logger *slog.Logger) *Service {
+func NewService(repo Repository, controlRepo control.Repository, snClientGetter SNClientProvider, auditService *audit.Service, opts Options, logger *slog.Logger) *Service {
 	if logger == nil {
 		logger = slog.Default()
 	}
 	return &Service{
 		repo:           repo,
+		controlRepo:    controlRepo,
 		snClientGetter: snClientGetter,
+		auditService:   auditService,
+		opts:           opts,
 		logger:         logger,
 	}
 }
@@ -44,52 +69,146 @@ func (s *Service) getSNClient(ctx context.Context) (servicenow.Client, error) {
 }
 
 // DiscoverSystems fetches systems from ServiceNow and marks which ones are already imported.
-func (s *Service) DiscoverSystems(ctx context.Context) ([]DiscoveredSystem, error) {
+// When the discovery cache is younger than the configured TTL and ServiceNow
+// does not answer within the slow threshold, the cached list is returned
+// instead; the fetch carries on in the background and refreshes the cache.
+// force always waits for ServiceNow.
+func (s *Service) DiscoverSystems(ctx context.Context, force bool) ([]DiscoveredSystem, error) {
 	snClient, err := s.getSNClient(ctx)
 	if err != nil {
 		return nil, err
 	}
 
+	var cached *DiscoveryCache
+	if !force {
+		cached = s.freshDiscoveryCache(ctx)
+	}
+	if cached == nil {
+		systems, err := s.fetchDiscoveredSystems(ctx, snClient)
+		if err != nil {
+			return nil, err
+		}
+		return s.markImported(ctx, systems)
+	}
+
+	// Detach the fetch from the request so it can still refresh the cache
+	// after a cached answer has been sent.
+	type fetchResult struct {
+		systems []DiscoveredSystem
+		err     error
+	}
+	done := make(chan fetchResult, 1)
+	go func() {
+		systems, err := s.fetchDiscoveredSystems(context.WithoutCancel(ctx), snClient)
+		done <- fetchResult{systems: systems, err: err}
+	}()
+
+	timer := time.NewTimer(s.opts.discoverySlowThreshold())
+	defer timer.Stop()
+
+	select {
+	case result := <-done:
+		if result.err != nil {
+			return nil, result.err
+		}
+		return s.markImported(ctx, result.systems)
+	case <-timer.C:
+		s.logger.Info("ServiceNow is slow, serving cached discovery", "run_at", cached.RunAt)
+		return s.markImported(ctx, cached.Systems)
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// RefreshDiscoveryCache fetches the system list from ServiceNow and stores
+// it in the discovery cache.
+func (s *Service) RefreshDiscoveryCache(ctx context.Context) error {
+	if s.opts.DiscoveryCacheTTL <= 0 {
+		return nil
+	}
+	snClient, err := s.getSNClient(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = s.fetchDiscoveredSystems(ctx, snClient)
+	return err
+}
+
+// freshDiscoveryCache returns the cached discovery if it is younger than
+// the TTL. Read errors are logged and treated as a cache miss.
+func (s *Service) freshDiscoveryCache(ctx context.Context) *DiscoveryCache {
+	if s.opts.DiscoveryCacheTTL <= 0 {
+		return nil
+	}
+	cached, err := s.repo.GetDiscoveryCache(ctx)
+	if err != nil {
+		s.logger.Warn("failed to read discovery cache", "error", err)
+		return nil
+	}
+	if cached == nil || time.Since(cached.RunAt) > s.opts.DiscoveryCacheTTL {
+		return nil
+	}
+	return cached
+}
+
+// fetchDiscoveredSystems fetches the systems from ServiceNow and, when the
+// discovery cache is enabled, stores them in it. IsImported is not set.
+func (s *Service) fetchDiscoveredSystems(ctx context.Context, snClient servicenow.Client) ([]DiscoveredSystem, error) {
 	s.logger.Info("discovering systems from ServiceNow")
 
-	// Fetch systems from ServiceNow
 	result, err := snClient.FetchSystems(ctx, nil, nil)
 	if err != nil {
 		s.logger.Error("failed to fetch systems from ServiceNow", "error", err)
 		return nil, fmt.Errorf("%w: %v", ErrServiceNowError, err)
 	}
 
-	// Get existing system IDs
+	systems := make([]DiscoveredSystem, 0, len(result.Records))
+	for _, record := range result.Records {
+		systems = append(systems, DiscoveredSystem{
+			SNSysID:     record.SysID,
+			Name:        record.Name,
+			Description: record.Description,
+			Owner:       record.Owner,
+		})
+	}
+
+	if s.opts.DiscoveryCacheTTL > 0 {
+		if err := s.repo.SaveDiscoveryCache(ctx, systems); err != nil {
+			// Discovery still succeeded; the cache is only a fallback
+			s.logger.Warn("failed to save discovery cache", "error", err)
+		}
+	}
+	return systems, nil
+}
+
+// markImported returns a copy of systems with IsImported set for the ones
+// already in the local database.
+func (s *Service) markImported(ctx context.Context, systems []DiscoveredSystem) ([]DiscoveredSystem, error) {
 	existingSysIDs, err := s.repo.GetAllSNSysIDs(ctx)
 	if err != nil {
 		s.logger.Error("failed to get existing system IDs", "error", err)
 		return nil, err
 	}
 
-	// Create a map for quick lookup
 	existingMap := make(map[string]bool, len(existingSysIDs))
 	for _, id := range existingSysIDs {
 		existingMap[id] = true
 	}
 
-	// Transform to DiscoveredSystem
-	discovered := make([]DiscoveredSystem, 0, len(result.Records))
-	for _, record := range result.Records {
-		discovered = append(discovered, DiscoveredSystem{
-			SNSysID:     record.SysID,
-			Name:        record.Name,
-			Description: record.Description,
-			Owner:       record.Owner,
-			IsImported:  existingMap[record.SysID],
-		})
+	discovered := make([]DiscoveredSystem, len(systems))
+	for i, d := range systems {
+		d.IsImported = existingMap[d.SNSysID]
+		discovered[i] = d
 	}
 
 	s.logger.Info("discovered systems", "count", len(discovered), "already_imported", len(existingSysIDs))
 	return discovered, nil
 }
 
-// ImportSystems imports selected systems from ServiceNow into the local database.
-func (s *Service) ImportSystems(ctx context.Context, snSysIDs []string) ([]System, error) {
+// ImportSystems imports selected systems from ServiceNow into the local
+// database. importMode is editable or read_only; empty keeps the mode of
+// systems already imported and makes new ones editable.
+func (s *Service) ImportSystems(ctx context.Context, snSysIDs []string, importMode string) ([]System, error) {
 	snClient, err := s.getSNClient(ctx)
 	if err != nil {
 		return nil, err
@@ -98,8 +217,11 @@ func (s *Service) ImportSystems(ctx context.Context, snSysIDs []string) ([]Syste
 	if len(snSysIDs) == 0 {
 		return nil, ErrInvalidInput
 	}
+	if importMode != "" && !IsValidImportMode(importMode) {
+		return nil, fmt.Errorf("%w: unknown import mode %q", ErrInvalidInput, importMode)
+	}
 
-	s.logger.Info("importing systems", "count", len(snSysIDs))
+	s.logger.Info("importing systems", "count", len(snSysIDs), "import_mode", importMode)
 
 	// Fetch all systems from ServiceNow (we'll filter locally)
 	result, err := snClient.FetchSystems(ctx, nil, nil)
@@ -113,6 +235,12 @@ func (s *Service) ImportSystems(ctx context.Context, snSysIDs []string) ([]Syste
 		requestedIDs[id] = true
 	}
 
+	loc, err := snClient.GetInstanceTimezone(ctx)
+	if err != nil {
+		s.logger.Warn("instance timezone unavailable, reading timestamps as UTC", "error", err)
+		loc = time.UTC
+	}
+
 	// Filter and prepare upsert inputs
 	inputs := make([]UpsertInput, 0, len(snSysIDs))
 	for _, record := range result.Records {
@@ -122,7 +250,7 @@ func (s *Service) ImportSystems(ctx context.Context, snSysIDs []string) ([]Syste
 
 		var snUpdatedOn *time.Time
 		if record.SysUpdatedOn != "" {
-			if t, err := time.Parse("2006-01-02 15:04:05", record.SysUpdatedOn); err == nil {
+			if t, err := servicenow.ParseSNTime(record.SysUpdatedOn, loc); err == nil {
 				snUpdatedOn = &t
 			}
 		}
@@ -134,6 +262,7 @@ func (s *Service) ImportSystems(ctx context.Context, snSysIDs []string) ([]Syste
 			Owner:       record.Owner,
 			Status:      record.Status,
 			SNUpdatedOn: snUpdatedOn,
+			ImportMode:  importMode,
 		})
 	}
 
@@ -181,7 +310,178 @@ func (s *Service) GetSystem(ctx context.Context, id uuid.UUID) (*System, error)
 	return system, nil
 }
 
-// DeleteSystem removes a system and all its associated data.
+// GetSummary retrieves the compliance summary for a system.
+func (s *Service) GetSummary(ctx context.Context, id uuid.UUID) (*SystemSummary, error) {
+	summary, err := s.repo.GetSummary(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if summary == nil {
+		return nil, ErrNotFound
+	}
+
+	if summary.TotalStatements > 0 {
+		pct := float64(summary.SyncedCount) / float64(summary.TotalStatements) * 100
+		summary.CompliancePercentage = math.Round(pct*100) / 100
+	}
+
+	return summary, nil
+}
+
+// GetCoverageGaps returns the controls of a system that have no statements,
+// ordered by control ID.
+func (s *Service) GetCoverageGaps(ctx context.Context, id uuid.UUID) ([]control.Control, error) {
+	sys, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if sys == nil {
+		return nil, ErrNotFound
+	}
+
+	gaps, err := s.controlRepo.ListWithoutStatements(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if gaps == nil {
+		gaps = []control.Control{}
+	}
+	return gaps, nil
+}
+
+// CompareControls compares the controls of two systems by control ID,
+// listing the controls found in only one of them, those found in both with
+// a different implementation status or name, and those found in both
+// unchanged.
+func (s *Service) CompareControls(ctx context.Context, leftID, rightID uuid.UUID) (*ControlComparison, error) {
+	left, err := s.listSystemControls(ctx, leftID)
+	if err != nil {
+		return nil, err
+	}
+	right, err := s.listSystemControls(ctx, rightID)
+	if err != nil {
+		return nil, err
+	}
+
+	rightByID := make(map[string]control.Control, len(right))
+	for _, c := range right {
+		rightByID[c.ControlID] = c
+	}
+
+	comparison := &ControlComparison{
+		OnlyInLeft:      []string{},
+		OnlyInRight:     []string{},
+		InBothDifferent: []ControlDifference{},
+		Identical:       []string{},
+	}
+	inLeft := make(map[string]bool, len(left))
+	for _, l := range left {
+		inLeft[l.ControlID] = true
+		r, ok := rightByID[l.ControlID]
+		switch {
+		case !ok:
+			comparison.OnlyInLeft = append(comparison.OnlyInLeft, l.ControlID)
+		case controlsDiffer(l, r):
+			comparison.InBothDifferent = append(comparison.InBothDifferent, ControlDifference{Left: l, Right: r})
+		default:
+			comparison.Identical = append(comparison.Identical, l.ControlID)
+		}
+	}
+	for _, r := range right {
+		if !inLeft[r.ControlID] {
+			comparison.OnlyInRight = append(comparison.OnlyInRight, r.ControlID)
+		}
+	}
+	return comparison, nil
+}
+
+// listSystemControls returns the controls of an existing system ordered by
+// control ID.
+func (s *Service) listSystemControls(ctx context.Context, id uuid.UUID) ([]control.Control, error) {
+	sys, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if sys == nil {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, id)
+	}
+
+	controls, err := s.controlRepo.ListBySystem(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(controls, func(i, j int) bool { return controls[i].ControlID < controls[j].ControlID })
+	return controls, nil
+}
+
+// GetControlFamilyStats returns control and statement counts per control
+// family of a system, ordered by family.
+func (s *Service) GetControlFamilyStats(ctx context.Context, systemID uuid.UUID) ([]ControlFamilyStats, error) {
+	sys, err := s.repo.GetByID(ctx, systemID)
+	if err != nil {
+		return nil, err
+	}
+	if sys == nil {
+		return nil, ErrNotFound
+	}
+
+	stats, err := s.repo.GetControlFamilyStats(ctx, systemID)
+	if err != nil {
+		return nil, err
+	}
+	if stats == nil {
+		stats = []ControlFamilyStats{}
+	}
+
+	for i := range stats {
+		stats[i].CoveragePct = coveragePct(stats[i].SyncedStatements, stats[i].TotalControls)
+	}
+	return stats, nil
+}
+
+// coveragePct returns synced as a percentage of controls, rounded down so
+// that 100 means every control is covered, and capped at 100.
+func coveragePct(synced, controls int) int {
+	if controls == 0 {
+		return 0
+	}
+	return min(synced*100/controls, 100)
+}
+
+// GetDashboard returns statement sync status counts per system and in total.
+// The aggregation is cached in-process for dashboardCacheTTL.
+func (s *Service) GetDashboard(ctx context.Context) (*Dashboard, error) {
+	if v, ok := s.dashboardCache.Load(dashboardCacheKey); ok {
+		if cached := v.(cachedDashboard); time.Now().Before(cached.expiresAt) {
+			return cached.dashboard, nil
+		}
+	}
+
+	systems, err := s.repo.GetSyncStatusCounts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if systems == nil {
+		systems = []SystemSyncStatus{}
+	}
+
+	dashboard := &Dashboard{Systems: systems}
+	for _, sys := range systems {
+		dashboard.Global.Synced += sys.Synced
+		dashboard.Global.Modified += sys.Modified
+		dashboard.Global.Conflict += sys.Conflict
+		dashboard.Global.Total += sys.Total
+	}
+
+	s.dashboardCache.Store(dashboardCacheKey, cachedDashboard{
+		dashboard: dashboard,
+		expiresAt: time.Now().Add(dashboardCacheTTL),
+	})
+	return dashboard, nil
+}
+
+// DeleteSystem soft-deletes a system. Its controls and statements are kept
+// and the system can be restored with RestoreSystem.
 func (s *Service) DeleteSystem(ctx context.Context, id uuid.UUID) error {
 	// Verify system exists
 	system, err := s.repo.GetByID(ctx, id)
@@ -195,3 +495,145 @@ func (s *Service) DeleteSystem(ctx context.Context, id uuid.UUID) error {
 	s.logger.Info("deleting system", "id", id, "name", system.Name)
 	return s.repo.Delete(ctx, id)
 }
+
+// SetConflictStrategy sets the conflict strategy used when pulling the
+// system's statements. An empty strategy reverts to the global default.
+func (s *Service) SetConflictStrategy(ctx context.Context, id uuid.UUID, strategy string) (*System, error) {
+	if strategy != "" {
+		if _, err := statement.ParseConflictStrategy(strategy); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidInput, err)
+		}
+	}
+
+	system, err := s.repo.SetConflictStrategy(ctx, id, strategy)
+	if err != nil {
+		return nil, err
+	}
+	if system == nil {
+		return nil, ErrNotFound
+	}
+
+	s.logger.Info("set system conflict strategy", "id", id, "strategy", strategy)
+	return system, nil
+}
+
+// UpdateMetadata applies local edits to a system's acronym, owner and
+// description and returns the updated system.
+func (s *Service) UpdateMetadata(ctx context.Context, id uuid.UUID, patch MetadataPatch) (*System, error) {
+	if err := patch.Validate(); err != nil {
+		return nil, err
+	}
+
+	current, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if current == nil || current.DeletedAt != nil {
+		return nil, ErrNotFound
+	}
+	before := *current
+
+	if !patch.IsEmpty() {
+		if err := s.repo.UpdateMetadata(ctx, id, patch); err != nil {
+			return nil, err
+		}
+		s.logger.Info("updated system metadata", "id", id)
+	}
+
+	system, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if system == nil || system.DeletedAt != nil {
+		return nil, ErrNotFound
+	}
+
+	s.recordFieldChanges(id, []fieldChange{
+		{"acronym", before.Acronym, system.Acronym},
+		{"owner", before.Owner, system.Owner},
+		{"description", before.Description, system.Description},
+	})
+	return system, nil
+}
+
+// fieldChange is a system field's value before and after an update.
+type fieldChange struct {
+	field    string
+	oldValue string
+	newValue string
+}
+
+// recordFieldChanges emits a field_change audit event for each field whose
+// value changed.
+func (s *Service) recordFieldChanges(id uuid.UUID, changes []fieldChange) {
+	if s.auditService == nil {
+		return
+	}
+	for _, c := range changes {
+		if c.oldValue == c.newValue {
+			continue
+		}
+		s.auditService.RecordAsync(audit.Event{
+			EventType:  audit.EventTypeFieldChange,
+			EntityType: "system",
+			EntityID:   id.String(),
+			Action:     "update_metadata",
+			Status:     "success",
+			Details:    audit.FieldChangeDetails(c.field, c.oldValue, c.newValue),
+		})
+	}
+}
+
+// BatchUpdateStatus sets the status of up to MaxBatchStatusIDs systems at
+// once and returns how many were updated. Unknown and deleted systems are
+// skipped. Each updated system gets its own audit event.
+func (s *Service) BatchUpdateStatus(ctx context.Context, ids []uuid.UUID, status string) (int, error) {
+	if len(ids) == 0 {
+		return 0, fmt.Errorf("%w: at least one system ID is required", ErrInvalidInput)
+	}
+	if len(ids) > MaxBatchStatusIDs {
+		return 0, fmt.Errorf("%w: at most %d systems can be updated at once", ErrInvalidInput, MaxBatchStatusIDs)
+	}
+	if !IsValidStatus(status) {
+		return 0, fmt.Errorf("%w: status must be one of %s, %s or %s",
+			ErrInvalidInput, StatusActive, StatusInactive, StatusDecommissioned)
+	}
+
+	updated, err := s.repo.UpdateStatusBatch(ctx, ids, status)
+	if err != nil {
+		return 0, err
+	}
+
+	s.logger.Info("updated system status", "requested", len(ids), "updated", len(updated), "status", status)
+
+	if s.auditService != nil {
+		for _, id := range updated {
+			s.auditService.RecordAsync(audit.Event{
+				EventType:  audit.EventTypeEdit,
+				EntityType: "system",
+				EntityID:   id.String(),
+				Action:     "update_status",
+				Status:     "success",
+				Details: map[string]interface{}{
+					"new_status": status,
+				},
+			})
+		}
+	}
+
+	return len(updated), nil
+}
+
+// RestoreSystem restores a soft-deleted system.
+func (s *Service) RestoreSystem(ctx context.Context, id uuid.UUID) (*System, error) {
+	system, err := s.repo.Restore(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if system == nil {
+		return nil, ErrNotFound
+	}
+
+	s.logger.Info("restored system", "id", id, "name", system.Name)
+	return system, nil
+}
@@ -0,0 +1,93 @@
+// Package content converts ServiceNow field values to plain text.
+package content
+
+import (
+	"io"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// blockElements start and end a line of text.
+var blockElements = map[atom.Atom]bool{
+	atom.Address: true, atom.Article: true, atom.Blockquote: true, atom.Br: true,
+	atom.Dd: true, atom.Div: true, atom.Dl: true, atom.Dt: true, atom.Figcaption: true,
+	atom.Footer: true, atom.H1: true, atom.H2: true, atom.H3: true, atom.H4: true,
+	atom.H5: true, atom.H6: true, atom.Header: true, atom.Hr: true, atom.Li: true,
+	atom.Ol: true, atom.P: true, atom.Pre: true, atom.Section: true, atom.Table: true,
+	atom.Td: true, atom.Th: true, atom.Tr: true, atom.Ul: true,
+}
+
+// skippedElements are dropped together with their text.
+var skippedElements = map[atom.Atom]bool{
+	atom.Head: true, atom.Iframe: true, atom.Noscript: true, atom.Object: true,
+	atom.Script: true, atom.Style: true, atom.Template: true, atom.Title: true,
+}
+
+// StripHTML returns the human-readable text of an HTML fragment. Only text
+// nodes are kept, with entities decoded; scripts, styles and similar
+// elements are dropped with their content. Block elements such as <p>, <li>
+// and <br> end a line. Whitespace within a line collapses to one space and
+// empty lines are removed. Text without markup is returned unchanged.
+func StripHTML(s string) string {
+	if !strings.ContainsAny(s, "<&") {
+		return s
+	}
+
+	var lines []string
+	var line strings.Builder
+	endLine := func() {
+		if text := strings.Join(strings.Fields(line.String()), " "); text != "" {
+			lines = append(lines, text)
+		}
+		line.Reset()
+	}
+
+	z := html.NewTokenizer(strings.NewReader(s))
+	skipping := atom.Atom(0)
+	for {
+		tt := z.Next()
+		switch tt {
+		case html.ErrorToken:
+			if z.Err() != io.EOF {
+				// The tokenizer only fails on read errors, which a
+				// strings.Reader does not produce
+				return s
+			}
+			endLine()
+			return strings.Join(lines, "\n")
+		case html.TextToken:
+			if skipping == 0 {
+				line.Write(z.Text())
+			}
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, _ := z.TagName()
+			tag := atom.Lookup(name)
+			if skipping != 0 {
+				continue
+			}
+			if skippedElements[tag] {
+				if tt == html.StartTagToken {
+					skipping = tag
+				}
+				continue
+			}
+			if blockElements[tag] {
+				endLine()
+			}
+		case html.EndTagToken:
+			name, _ := z.TagName()
+			tag := atom.Lookup(name)
+			if skipping != 0 {
+				if tag == skipping {
+					skipping = 0
+				}
+				continue
+			}
+			if blockElements[tag] {
+				endLine()
+			}
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package content
+
+import "testing"
+
+func TestStripHTML(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "paragraphs",
+			input: "<p>Access is reviewed quarterly.</p><p>Reviews are  logged.</p>",
+			want:  "Access is reviewed quarterly.\nReviews are logged.",
+		},
+		{
+			name:  "list",
+			input: "<p>Controls:</p>\n<ul>\n  <li>MFA</li>\n  <li>Password <strong>rotation</strong></li>\n</ul>",
+			want:  "Controls:\nMFA\nPassword rotation",
+		},
+		{
+			name:  "line breaks",
+			input: "First line<br>Second line<br/>Third line",
+			want:  "First line\nSecond line\nThird line",
+		},
+		{
+			name:  "inline formatting within words",
+			input: "<p>The <strong>ISSO</strong> approves un<em>scheduled</em> changes.</p>",
+			want:  "The ISSO approves unscheduled changes.",
+		},
+		{
+			name:  "scripts and styles",
+			input: `<style>p { color: red }</style><p>Visible</p><script>alert("<p>hidden</p>")</script><script src="x.js"/>text`,
+			want:  "Visible\ntext",
+		},
+		{
+			name:  "entities",
+			input: "<p>R&amp;D &lt;restricted&gt;&nbsp;area</p>",
+			want:  "R&D <restricted> area",
+		},
+		{
+			name:  "plain text unchanged",
+			input: "Access is reviewed.\n\nReviews are logged.",
+			want:  "Access is reviewed.\n\nReviews are logged.",
+		},
+		{
+			name:  "empty",
+			input: "",
+			want:  "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StripHTML(tt.input); got != tt.want {
+				t.Errorf("StripHTML(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"sync"
 )
 
 // Common errors for crypto operations.
@@ -31,12 +32,63 @@ type CryptoService interface {
 
 // AESCryptoService implements CryptoService using AES-256-GCM.
 type AESCryptoService struct {
+	mu  sync.RWMutex
 	gcm cipher.AEAD
+	key string // base64-encoded key behind gcm
 }
 
 // NewAESCryptoService creates a new AES-256-GCM crypto service.
 // The key must be a base64-encoded 32-byte key.
 func NewAESCryptoService(base64Key string) (*AESCryptoService, error) {
+	gcm, err := newGCM(base64Key)
+	if err != nil {
+		return nil, err
+	}
+	return &AESCryptoService{gcm: gcm, key: base64Key}, nil
+}
+
+// SetKey replaces the key used for subsequent operations, e.g. after the
+// stored data has been re-encrypted by a KeyRotationService.
+func (s *AESCryptoService) SetKey(base64Key string) error {
+	gcm, err := newGCM(base64Key)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	s.gcm, s.key = gcm, base64Key
+	s.mu.Unlock()
+	return nil
+}
+
+// Rotate switches the service to newKey once migrate has moved the stored
+// data from the current key, which it is passed, to newKey. The write lock
+// is held throughout, so nothing is encrypted under the old key while
+// migrate runs and concurrent rotations run one after the other. The key is
+// left unchanged if migrate fails.
+func (s *AESCryptoService) Rotate(newKey string, migrate func(oldKey string) error) error {
+	gcm, err := newGCM(newKey)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := migrate(s.key); err != nil {
+		return err
+	}
+	s.gcm, s.key = gcm, newKey
+	return nil
+}
+
+// aead returns the cipher for the current key.
+func (s *AESCryptoService) aead() cipher.AEAD {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.gcm
+}
+
+// newGCM creates an AES-256-GCM cipher from a base64-encoded 32-byte key.
+func newGCM(base64Key string) (cipher.AEAD, error) {
 	// Decode the base64 key
 	key, err := base64.StdEncoding.DecodeString(base64Key)
 	if err != nil {
@@ -60,35 +112,39 @@ func NewAESCryptoService(base64Key string) (*AESCryptoService, error) {
 		return nil, fmt.Errorf("failed to create GCM mode: %w", err)
 	}
 
-	return &AESCryptoService{gcm: gcm}, nil
+	return gcm, nil
 }
 
 // Encrypt encrypts plaintext using AES-256-GCM.
 // Returns the ciphertext and a randomly generated 12-byte nonce.
 // The nonce must be stored alongside the ciphertext for later decryption.
 func (s *AESCryptoService) Encrypt(plaintext []byte) ([]byte, []byte, error) {
+	gcm := s.aead()
+
 	// Generate a random nonce (12 bytes for GCM)
-	nonce := make([]byte, s.gcm.NonceSize())
+	nonce := make([]byte, gcm.NonceSize())
 	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
 		return nil, nil, fmt.Errorf("failed to generate nonce: %w", err)
 	}
 
 	// Encrypt the plaintext
 	// Seal appends the encrypted data to the first argument (nil = new slice)
-	ciphertext := s.gcm.Seal(nil, nonce, plaintext, nil)
+	ciphertext := gcm.Seal(nil, nonce, plaintext, nil)
 
 	return ciphertext, nonce, nil
 }
 
 // Decrypt decrypts ciphertext using AES-256-GCM with the provided nonce.
 func (s *AESCryptoService) Decrypt(ciphertext []byte, nonce []byte) ([]byte, error) {
+	gcm := s.aead()
+
 	// Validate nonce length
-	if len(nonce) != s.gcm.NonceSize() {
-		return nil, fmt.Errorf("%w: got %d bytes, expected %d", ErrInvalidNonce, len(nonce), s.gcm.NonceSize())
+	if len(nonce) != gcm.NonceSize() {
+		return nil, fmt.Errorf("%w: got %d bytes, expected %d", ErrInvalidNonce, len(nonce), gcm.NonceSize())
 	}
 
 	// Decrypt the ciphertext
-	plaintext, err := s.gcm.Open(nil, nonce, ciphertext, nil)
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %v", ErrDecryptionFailed, err)
 	}
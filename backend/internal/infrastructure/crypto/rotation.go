@@ -0,0 +1,66 @@
+package crypto
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrSameKey is returned when rotating to the key already in use.
+var ErrSameKey = errors.New("new encryption key must differ from the current key")
+
+// ReEncryptFunc decrypts a stored ciphertext and returns it encrypted under
+// another key.
+type ReEncryptFunc func(ciphertext, nonce []byte) (newCiphertext, newNonce []byte, err error)
+
+// CredentialStore rewrites stored connection credentials.
+type CredentialStore interface {
+	// ReEncryptCredentials applies reencrypt to every stored encrypted
+	// password and OAuth client secret and writes the results back in a
+	// single transaction. Any error leaves the stored credentials unchanged.
+	ReEncryptCredentials(ctx context.Context, reencrypt ReEncryptFunc) error
+}
+
+// KeyRotationService moves stored credentials from one encryption key to
+// another.
+type KeyRotationService struct {
+	store  CredentialStore
+	active *AESCryptoService
+}
+
+// NewKeyRotationService creates a key rotation service for the credentials
+// encrypted by active. active is switched to the new key after a successful
+// rotation, so the running process keeps decrypting the rewritten
+// credentials.
+func NewKeyRotationService(store CredentialStore, active *AESCryptoService) *KeyRotationService {
+	return &KeyRotationService{store: store, active: active}
+}
+
+// RotateConnectionCredentials decrypts every connection's password and OAuth
+// client secret with the active key and re-encrypts them with newKey, a
+// base64-encoded 32-byte AES-256 key. The active service is locked for the
+// whole rotation; see AESCryptoService.Rotate.
+func (s *KeyRotationService) RotateConnectionCredentials(ctx context.Context, newKey string) error {
+	return s.active.Rotate(newKey, func(oldKey string) error {
+		if oldKey == newKey {
+			return ErrSameKey
+		}
+
+		oldService, err := NewAESCryptoService(oldKey)
+		if err != nil {
+			return fmt.Errorf("old key: %w", err)
+		}
+		newService, err := NewAESCryptoService(newKey)
+		if err != nil {
+			return fmt.Errorf("new key: %w", err)
+		}
+
+		return s.store.ReEncryptCredentials(ctx, func(ciphertext, nonce []byte) ([]byte, []byte, error) {
+			plaintext, err := oldService.Decrypt(ciphertext, nonce)
+			if err != nil {
+				return nil, nil, err
+			}
+			return newService.Encrypt(plaintext)
+		})
+	})
+}
@@ -0,0 +1,155 @@
+package crypto
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// memoryStore keeps encrypted secrets in memory and only keeps the
+// re-encrypted values when every secret succeeds, like a transaction.
+type memoryStore struct {
+	secrets [][2][]byte // ciphertext, nonce
+}
+
+func (m *memoryStore) ReEncryptCredentials(ctx context.Context, reencrypt ReEncryptFunc) error {
+	updated := make([][2][]byte, len(m.secrets))
+	for i, s := range m.secrets {
+		ciphertext, nonce, err := reencrypt(s[0], s[1])
+		if err != nil {
+			return err
+		}
+		updated[i] = [2][]byte{ciphertext, nonce}
+	}
+	m.secrets = updated
+	return nil
+}
+
+func newStore(t *testing.T, key string, plaintexts ...string) *memoryStore {
+	t.Helper()
+	svc, err := NewAESCryptoService(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	store := &memoryStore{}
+	for _, p := range plaintexts {
+		ciphertext, nonce, err := svc.Encrypt([]byte(p))
+		if err != nil {
+			t.Fatal(err)
+		}
+		store.secrets = append(store.secrets, [2][]byte{ciphertext, nonce})
+	}
+	return store
+}
+
+func TestKeyRotationService_RoundTrip(t *testing.T) {
+	newKey, err := GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	store := newStore(t, testKey, "basic-password", "oauth-secret")
+	active, _ := NewAESCryptoService(testKey)
+
+	svc := NewKeyRotationService(store, active)
+	if err := svc.RotateConnectionCredentials(context.Background(), newKey); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	newService, _ := NewAESCryptoService(newKey)
+	oldService, _ := NewAESCryptoService(testKey)
+	for i, want := range []string{"basic-password", "oauth-secret"} {
+		got, err := newService.Decrypt(store.secrets[i][0], store.secrets[i][1])
+		if err != nil {
+			t.Fatalf("secret %d: decrypt with new key failed: %v", i, err)
+		}
+		if string(got) != want {
+			t.Errorf("secret %d: expected %q, got %q", i, want, got)
+		}
+		if _, err := oldService.Decrypt(store.secrets[i][0], store.secrets[i][1]); err == nil {
+			t.Errorf("secret %d: expected old key to no longer decrypt", i)
+		}
+		if _, err := active.Decrypt(store.secrets[i][0], store.secrets[i][1]); err != nil {
+			t.Errorf("secret %d: expected active service to use the new key: %v", i, err)
+		}
+	}
+
+	// The next rotation starts from the key now in use
+	if err := svc.RotateConnectionCredentials(context.Background(), testKey); err != nil {
+		t.Fatalf("rotating back failed: %v", err)
+	}
+	if _, err := oldService.Decrypt(store.secrets[0][0], store.secrets[0][1]); err != nil {
+		t.Errorf("expected secrets to be encrypted with the original key again: %v", err)
+	}
+}
+
+func TestKeyRotationService_WrongOldKeyLeavesDataUnchanged(t *testing.T) {
+	otherKey, _ := GenerateKey()
+	newKey, _ := GenerateKey()
+	store := newStore(t, testKey, "basic-password")
+	before := append([]byte(nil), store.secrets[0][0]...)
+
+	active, _ := NewAESCryptoService(otherKey)
+
+	svc := NewKeyRotationService(store, active)
+	err := svc.RotateConnectionCredentials(context.Background(), newKey)
+	if !errors.Is(err, ErrDecryptionFailed) {
+		t.Fatalf("expected ErrDecryptionFailed, got %v", err)
+	}
+	if string(store.secrets[0][0]) != string(before) {
+		t.Error("expected stored ciphertext to be unchanged")
+	}
+
+	// A failed rotation keeps the active key
+	other, _ := NewAESCryptoService(otherKey)
+	ciphertext, nonce, _ := active.Encrypt([]byte("x"))
+	if _, err := other.Decrypt(ciphertext, nonce); err != nil {
+		t.Errorf("expected active service to keep its key: %v", err)
+	}
+}
+
+func TestKeyRotationService_InvalidKeys(t *testing.T) {
+	active, _ := NewAESCryptoService(testKey)
+	svc := NewKeyRotationService(&memoryStore{}, active)
+	ctx := context.Background()
+
+	if err := svc.RotateConnectionCredentials(ctx, testKey); err != ErrSameKey {
+		t.Errorf("expected ErrSameKey, got %v", err)
+	}
+	if err := svc.RotateConnectionCredentials(ctx, "not-valid-base64!!!"); !errors.Is(err, ErrInvalidKeyFormat) {
+		t.Errorf("expected ErrInvalidKeyFormat, got %v", err)
+	}
+}
+
+func TestAESCryptoService_Rotate_BlocksEncryption(t *testing.T) {
+	newKey, _ := GenerateKey()
+	active, _ := NewAESCryptoService(testKey)
+
+	type sealed struct{ ciphertext, nonce []byte }
+	done := make(chan sealed, 1)
+	err := active.Rotate(newKey, func(oldKey string) error {
+		if oldKey != testKey {
+			t.Errorf("expected migration from the active key, got %q", oldKey)
+		}
+		go func() {
+			ciphertext, nonce, _ := active.Encrypt([]byte("saved during rotation"))
+			done <- sealed{ciphertext, nonce}
+		}()
+		select {
+		case <-done:
+			t.Error("expected encryption to wait for the rotation")
+		case <-time.After(50 * time.Millisecond):
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The waiting write is encrypted under the new key
+	got := <-done
+	newService, _ := NewAESCryptoService(newKey)
+	if _, err := newService.Decrypt(got.ciphertext, got.nonce); err != nil {
+		t.Errorf("expected data written during rotation to use the new key: %v", err)
+	}
+}
@@ -0,0 +1,19 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+// AnalyzeTable refreshes the planner statistics of table. Integration tests
+// and benchmarks call it after loading rows so the planner sees them before
+// plans are checked or timed.
+func AnalyzeTable(ctx context.Context, db *sql.DB, table string) error {
+	if _, err := db.ExecContext(ctx, "ANALYZE "+pq.QuoteIdentifier(table)); err != nil {
+		return fmt.Errorf("failed to analyze %s: %w", table, err)
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+package database
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/controlcrud/backend/internal/domain/audit"
+)
+
+// Audit buffer limits.
+const (
+	// AuditBatchSize is the most events written by one INSERT. The buffer
+	// flushes as soon as this many events are queued.
+	AuditBatchSize = 1000
+
+	// AuditFlushInterval is how often queued events are flushed when fewer
+	// than AuditBatchSize are waiting.
+	AuditFlushInterval = 500 * time.Millisecond
+
+	// AuditBufferCapacity is the most events queued at once. Events
+	// inserted while the buffer is full are dropped.
+	AuditBufferCapacity = 10 * AuditBatchSize
+)
+
+// AsyncAuditBuffer is an audit.Repository that queues inserted events and
+// writes them in batches with AuditRepository.BulkInsert, saving a database
+// round-trip per event. Reads go straight to the repository, so an event is
+// visible to queries only once its batch is flushed.
+//
+// Run flushes the queue in the background; Flush writes whatever is left
+// and must be called on shutdown.
+type AsyncAuditBuffer struct {
+	*AuditRepository
+
+	logger     *slog.Logger
+	bulkInsert func(ctx context.Context, events []audit.Event) error
+	batchSize  int
+	interval   time.Duration
+	capacity   int
+
+	mu      sync.Mutex
+	pending []audit.Event
+	dropped int
+	full    chan struct{} // Signalled when a batch is ready
+
+	flushMu sync.Mutex // Keeps batches in insertion order
+}
+
+// NewAsyncAuditBuffer creates a buffer writing to repo.
+func NewAsyncAuditBuffer(repo *AuditRepository, logger *slog.Logger) *AsyncAuditBuffer {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &AsyncAuditBuffer{
+		AuditRepository: repo,
+		logger:          logger,
+		bulkInsert:      repo.BulkInsert,
+		batchSize:       AuditBatchSize,
+		interval:        AuditFlushInterval,
+		capacity:        AuditBufferCapacity,
+		full:            make(chan struct{}, 1),
+	}
+}
+
+// Insert queues an event for the next flush. When the buffer is full the
+// event is dropped and a warning logged; no error is returned, as the
+// caller's operation should not fail for want of an audit record.
+func (b *AsyncAuditBuffer) Insert(ctx context.Context, event *audit.Event) error {
+	b.mu.Lock()
+	if len(b.pending) >= b.capacity {
+		b.dropped++
+		dropped := b.dropped
+		b.mu.Unlock()
+		b.logger.Warn("audit buffer full, dropping event",
+			"event_type", event.EventType,
+			"entity_type", event.EntityType,
+			"entity_id", event.EntityID,
+			"dropped", dropped)
+		return nil
+	}
+	b.pending = append(b.pending, *event)
+	ready := len(b.pending) >= b.batchSize
+	b.mu.Unlock()
+
+	if ready {
+		select {
+		case b.full <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+// Run flushes queued events every AuditFlushInterval, or as soon as a full
+// batch is queued, until ctx is cancelled.
+func (b *AsyncAuditBuffer) Run(ctx context.Context) {
+	ticker := time.NewTicker(b.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-b.full:
+		}
+		if err := b.Flush(); err != nil {
+			b.logger.Error("failed to flush audit events", "error", err)
+		}
+	}
+}
+
+// Flush writes all queued events, AuditBatchSize per INSERT. A batch that
+// fails to insert is dropped and its error returned after the remaining
+// batches are written.
+func (b *AsyncAuditBuffer) Flush() error {
+	b.flushMu.Lock()
+	defer b.flushMu.Unlock()
+
+	b.mu.Lock()
+	events := b.pending
+	b.pending = nil
+	b.mu.Unlock()
+
+	var firstErr error
+	for len(events) > 0 {
+		batch := events[:min(len(events), b.batchSize)]
+		events = events[len(batch):]
+
+		if err := b.bulkInsert(context.Background(), batch); err != nil {
+			b.logger.Error("dropping audit events that failed to insert", "count", len(batch), "error", err)
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	return firstErr
+}
@@ -0,0 +1,212 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/controlcrud/backend/internal/domain/audit"
+)
+
+// recordingInserter records the batches passed to BulkInsert.
+type recordingInserter struct {
+	mu      sync.Mutex
+	batches [][]audit.Event
+	err     error
+}
+
+func (r *recordingInserter) bulkInsert(ctx context.Context, events []audit.Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.batches = append(r.batches, append([]audit.Event(nil), events...))
+	return r.err
+}
+
+func (r *recordingInserter) calls() [][]audit.Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([][]audit.Event(nil), r.batches...)
+}
+
+func newTestAuditBuffer(inserter *recordingInserter) *AsyncAuditBuffer {
+	b := NewAsyncAuditBuffer(nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	b.bulkInsert = inserter.bulkInsert
+	return b
+}
+
+func testAuditEvent(i int) *audit.Event {
+	return &audit.Event{
+		ID:         uuid.New(),
+		EventType:  audit.EventTypeEdit,
+		EntityType: "statement",
+		EntityID:   fmt.Sprintf("stmt-%d", i),
+		Action:     "update",
+		Status:     "success",
+		CreatedAt:  time.Now(),
+	}
+}
+
+func TestBuildAuditInsert(t *testing.T) {
+	events := make([]audit.Event, AuditBatchSize)
+	for i := range events {
+		events[i] = *testAuditEvent(i)
+	}
+
+	query, args := buildAuditInsert(events)
+
+	if got := strings.Count(query, "INSERT INTO"); got != 1 {
+		t.Errorf("expected one INSERT statement, got %d", got)
+	}
+	if got := strings.Count(query, "($"); got != AuditBatchSize {
+		t.Errorf("expected %d placeholder groups, got %d", AuditBatchSize, got)
+	}
+	if !strings.Contains(query, "($9991, $9992, $9993, $9994, $9995, $9996, $9997, $9998, $9999, $10000)") {
+		t.Errorf("expected the last row's placeholders, got %s", query[len(query)-100:])
+	}
+	if len(args) != 10*AuditBatchSize {
+		t.Fatalf("expected %d args, got %d", 10*AuditBatchSize, len(args))
+	}
+	if args[13] != "stmt-1" {
+		t.Errorf("expected second row args in column order, got %v", args[10:20])
+	}
+}
+
+func TestAsyncAuditBuffer_FullBatchInOneCall(t *testing.T) {
+	inserter := &recordingInserter{}
+	b := newTestAuditBuffer(inserter)
+	// Only a full batch, not the ticker, may trigger the flush
+	b.interval = time.Hour
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go b.Run(ctx)
+
+	for i := 0; i < AuditBatchSize; i++ {
+		if err := b.Insert(ctx, testAuditEvent(i)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for len(inserter.calls()) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for the batch to flush")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	calls := inserter.calls()
+	if len(calls) != 1 || len(calls[0]) != AuditBatchSize {
+		t.Fatalf("expected %d events in a single call, got %d calls", AuditBatchSize, len(calls))
+	}
+	for i, event := range calls[0] {
+		if want := fmt.Sprintf("stmt-%d", i); event.EntityID != want {
+			t.Fatalf("event %d: expected %s, got %s", i, want, event.EntityID)
+		}
+	}
+}
+
+func TestAsyncAuditBuffer_FlushesOnInterval(t *testing.T) {
+	inserter := &recordingInserter{}
+	b := newTestAuditBuffer(inserter)
+	b.interval = 10 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go b.Run(ctx)
+
+	b.Insert(ctx, testAuditEvent(0))
+	b.Insert(ctx, testAuditEvent(1))
+
+	deadline := time.Now().Add(2 * time.Second)
+	for len(inserter.calls()) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for the interval flush")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if calls := inserter.calls(); len(calls[0]) != 2 {
+		t.Errorf("expected both events in the first flush, got %d", len(calls[0]))
+	}
+}
+
+func TestAsyncAuditBuffer_DropsWhenFull(t *testing.T) {
+	inserter := &recordingInserter{}
+	b := newTestAuditBuffer(inserter)
+	b.capacity = 3
+
+	for i := 0; i < 5; i++ {
+		if err := b.Insert(context.Background(), testAuditEvent(i)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if err := b.Flush(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	calls := inserter.calls()
+	if len(calls) != 1 || len(calls[0]) != 3 {
+		t.Fatalf("expected the first 3 events kept, got %v", calls)
+	}
+	if calls[0][2].EntityID != "stmt-2" {
+		t.Errorf("expected the newest events dropped, got %s last", calls[0][2].EntityID)
+	}
+}
+
+func TestAsyncAuditBuffer_Flush(t *testing.T) {
+	inserter := &recordingInserter{err: errors.New("connection reset")}
+	b := newTestAuditBuffer(inserter)
+
+	for i := 0; i < AuditBatchSize+1; i++ {
+		b.Insert(context.Background(), testAuditEvent(i))
+	}
+	if err := b.Flush(); err == nil {
+		t.Error("expected the insert error returned")
+	}
+
+	calls := inserter.calls()
+	if len(calls) != 2 || len(calls[0]) != AuditBatchSize || len(calls[1]) != 1 {
+		t.Fatalf("expected a full batch and the remainder, got %d calls", len(calls))
+	}
+
+	// Failed batches are not retried
+	if err := b.Flush(); err != nil {
+		t.Errorf("expected an empty flush to succeed, got %v", err)
+	}
+	if got := len(inserter.calls()); got != 2 {
+		t.Errorf("expected no further inserts, got %d calls", got)
+	}
+}
+
+func TestAuditRepository_BulkInsert(t *testing.T) {
+	repo := NewAuditRepository(openTestDB(t), QueryTimeouts{})
+	ctx := context.Background()
+
+	entityID := uuid.NewString()
+	events := make([]audit.Event, AuditBatchSize)
+	for i := range events {
+		events[i] = *testAuditEvent(i)
+		events[i].EntityID = entityID
+	}
+	t.Cleanup(func() { repo.db.ExecContext(ctx, `DELETE FROM audit_events WHERE entity_id = $1`, entityID) })
+
+	if err := repo.BulkInsert(ctx, events); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var count int
+	if err := repo.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM audit_events WHERE entity_id = $1`, entityID).Scan(&count); err != nil {
+		t.Fatalf("failed to count events: %v", err)
+	}
+	if count != AuditBatchSize {
+		t.Errorf("expected %d events, got %d", AuditBatchSize, count)
+	}
+}
@@ -14,16 +14,20 @@ import (
 
 // AuditRepository implements the audit.Repository interface.
 type AuditRepository struct {
-	db *sql.DB
+	db       *sql.DB
+	timeouts QueryTimeouts
 }
 
 // NewAuditRepository creates a new audit repository.
-func NewAuditRepository(db *sql.DB) *AuditRepository {
-	return &AuditRepository{db: db}
+func NewAuditRepository(db *sql.DB, timeouts QueryTimeouts) *AuditRepository {
+	return &AuditRepository{db: db, timeouts: timeouts}
 }
 
 // Insert creates a new audit event.
 func (r *AuditRepository) Insert(ctx context.Context, event *audit.Event) error {
+	ctx, cancel := r.timeouts.singleRow(ctx)
+	defer cancel()
+
 	detailsJSON, err := json.Marshal(event.Details)
 	if err != nil {
 		detailsJSON = []byte("{}")
@@ -53,8 +57,60 @@ func (r *AuditRepository) Insert(ctx context.Context, event *audit.Event) error
 	return nil
 }
 
+// BulkInsert creates many audit events with a single multi-row INSERT.
+func (r *AuditRepository) BulkInsert(ctx context.Context, events []audit.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+	ctx, cancel := r.timeouts.batch(ctx)
+	defer cancel()
+
+	query, args := buildAuditInsert(events)
+	if _, err := r.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to insert %d audit events: %w", len(events), err)
+	}
+	return nil
+}
+
+// buildAuditInsert builds a single INSERT query writing all events.
+func buildAuditInsert(events []audit.Event) (string, []interface{}) {
+	const columns = 10
+	values := make([]string, len(events))
+	args := make([]interface{}, 0, len(events)*columns)
+	for i, event := range events {
+		n := i * columns
+		values[i] = fmt.Sprintf("($%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d)",
+			n+1, n+2, n+3, n+4, n+5, n+6, n+7, n+8, n+9, n+10)
+
+		detailsJSON, err := json.Marshal(event.Details)
+		if err != nil {
+			detailsJSON = []byte("{}")
+		}
+		args = append(args,
+			event.ID,
+			event.EventType,
+			event.EntityType,
+			event.EntityID,
+			event.Action,
+			event.Status,
+			detailsJSON,
+			event.UserEmail,
+			event.IPAddress,
+			event.CreatedAt,
+		)
+	}
+
+	query := `
+		INSERT INTO audit_events (id, event_type, entity_type, entity_id, action, status, details, user_email, ip_address, created_at)
+		VALUES ` + strings.Join(values, ", ")
+	return query, args
+}
+
 // GetByID retrieves an audit event by ID.
 func (r *AuditRepository) GetByID(ctx context.Context, id uuid.UUID) (*audit.Event, error) {
+	ctx, cancel := r.timeouts.singleRow(ctx)
+	defer cancel()
+
 	query := `
 		SELECT id, event_type, entity_type, entity_id, action, status, details, user_email, ip_address, created_at
 		FROM audit_events
@@ -91,66 +147,11 @@ func (r *AuditRepository) GetByID(ctx context.Context, id uuid.UUID) (*audit.Eve
 
 // Query retrieves audit events based on filters.
 func (r *AuditRepository) Query(ctx context.Context, filters audit.QueryFilters) (*audit.QueryResult, error) {
-	// Build WHERE clause
-	var conditions []string
-	var args []interface{}
-	argNum := 1
-
-	if len(filters.EventTypes) > 0 {
-		placeholders := make([]string, len(filters.EventTypes))
-		for i, et := range filters.EventTypes {
-			placeholders[i] = fmt.Sprintf("$%d", argNum)
-			args = append(args, string(et))
-			argNum++
-		}
-		conditions = append(conditions, fmt.Sprintf("event_type IN (%s)", strings.Join(placeholders, ",")))
-	}
+	ctx, cancel := r.timeouts.list(ctx)
+	defer cancel()
 
-	if len(filters.EntityTypes) > 0 {
-		placeholders := make([]string, len(filters.EntityTypes))
-		for i, et := range filters.EntityTypes {
-			placeholders[i] = fmt.Sprintf("$%d", argNum)
-			args = append(args, et)
-			argNum++
-		}
-		conditions = append(conditions, fmt.Sprintf("entity_type IN (%s)", strings.Join(placeholders, ",")))
-	}
-
-	if filters.EntityID != nil && *filters.EntityID != "" {
-		conditions = append(conditions, fmt.Sprintf("entity_id = $%d", argNum))
-		args = append(args, *filters.EntityID)
-		argNum++
-	}
-
-	if filters.Status != nil && *filters.Status != "" {
-		conditions = append(conditions, fmt.Sprintf("status = $%d", argNum))
-		args = append(args, *filters.Status)
-		argNum++
-	}
-
-	if filters.StartDate != nil {
-		conditions = append(conditions, fmt.Sprintf("created_at >= $%d", argNum))
-		args = append(args, *filters.StartDate)
-		argNum++
-	}
-
-	if filters.EndDate != nil {
-		conditions = append(conditions, fmt.Sprintf("created_at <= $%d", argNum))
-		args = append(args, *filters.EndDate)
-		argNum++
-	}
-
-	if filters.Search != nil && *filters.Search != "" {
-		searchPattern := "%" + *filters.Search + "%"
-		conditions = append(conditions, fmt.Sprintf("(user_email ILIKE $%d OR action ILIKE $%d OR entity_id ILIKE $%d)", argNum, argNum+1, argNum+2))
-		args = append(args, searchPattern, searchPattern, searchPattern)
-		argNum += 3
-	}
-
-	whereClause := ""
-	if len(conditions) > 0 {
-		whereClause = "WHERE " + strings.Join(conditions, " AND ")
-	}
+	whereClause, args := buildAuditWhere(filters)
+	argNum := len(args) + 1
 
 	// Count total
 	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM audit_events %s", whereClause)
@@ -217,8 +218,76 @@ func (r *AuditRepository) Query(ctx context.Context, filters audit.QueryFilters)
 	}, nil
 }
 
+// buildAuditWhere returns the WHERE clause and arguments selecting the audit
+// events that match filters. Conditions on indexed columns follow the key
+// order of idx_audit_events_created_entity, entity_type before event_type.
+// The clause is empty when no filter is set.
+func buildAuditWhere(filters audit.QueryFilters) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+	argNum := 1
+
+	if len(filters.EntityTypes) > 0 {
+		placeholders := make([]string, len(filters.EntityTypes))
+		for i, et := range filters.EntityTypes {
+			placeholders[i] = fmt.Sprintf("$%d", argNum)
+			args = append(args, et)
+			argNum++
+		}
+		conditions = append(conditions, fmt.Sprintf("entity_type IN (%s)", strings.Join(placeholders, ",")))
+	}
+
+	if len(filters.EventTypes) > 0 {
+		placeholders := make([]string, len(filters.EventTypes))
+		for i, et := range filters.EventTypes {
+			placeholders[i] = fmt.Sprintf("$%d", argNum)
+			args = append(args, string(et))
+			argNum++
+		}
+		conditions = append(conditions, fmt.Sprintf("event_type IN (%s)", strings.Join(placeholders, ",")))
+	}
+
+	if filters.EntityID != nil && *filters.EntityID != "" {
+		conditions = append(conditions, fmt.Sprintf("entity_id = $%d", argNum))
+		args = append(args, *filters.EntityID)
+		argNum++
+	}
+
+	if filters.Status != nil && *filters.Status != "" {
+		conditions = append(conditions, fmt.Sprintf("status = $%d", argNum))
+		args = append(args, *filters.Status)
+		argNum++
+	}
+
+	if filters.StartDate != nil {
+		conditions = append(conditions, fmt.Sprintf("created_at >= $%d", argNum))
+		args = append(args, *filters.StartDate)
+		argNum++
+	}
+
+	if filters.EndDate != nil {
+		conditions = append(conditions, fmt.Sprintf("created_at <= $%d", argNum))
+		args = append(args, *filters.EndDate)
+		argNum++
+	}
+
+	if filters.Search != nil && *filters.Search != "" {
+		searchPattern := "%" + *filters.Search + "%"
+		conditions = append(conditions, fmt.Sprintf("(user_email ILIKE $%d OR action ILIKE $%d OR entity_id ILIKE $%d)", argNum, argNum+1, argNum+2))
+		args = append(args, searchPattern, searchPattern, searchPattern)
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+	return "WHERE " + strings.Join(conditions, " AND "), args
+}
+
 // GetStats retrieves audit statistics.
 func (r *AuditRepository) GetStats(ctx context.Context) (*audit.Stats, error) {
+	ctx, cancel := r.timeouts.list(ctx)
+	defer cancel()
+
 	stats := &audit.Stats{
 		EventsByType:   make(map[string]int),
 		EventsByStatus: make(map[string]int),
@@ -270,3 +339,40 @@ func (r *AuditRepository) GetStats(ctx context.Context) (*audit.Stats, error) {
 
 	return stats, nil
 }
+
+// Purge deletes audit events older than policy.MaxAgeDays, then deletes the
+// oldest remaining events if more than policy.MaxRows are left.
+func (r *AuditRepository) Purge(ctx context.Context, policy audit.RetentionPolicy) (int64, error) {
+	ctx, cancel := r.timeouts.batch(ctx)
+	defer cancel()
+
+	var deleted int64
+
+	if policy.MaxAgeDays > 0 {
+		result, err := r.db.ExecContext(ctx,
+			"DELETE FROM audit_events WHERE created_at < NOW() - $1 * INTERVAL '1 day'",
+			policy.MaxAgeDays)
+		if err != nil {
+			return deleted, fmt.Errorf("failed to purge expired audit events: %w", err)
+		}
+		n, _ := result.RowsAffected()
+		deleted += n
+	}
+
+	if policy.MaxRows > 0 {
+		result, err := r.db.ExecContext(ctx, `
+			DELETE FROM audit_events
+			WHERE id IN (
+				SELECT id FROM audit_events
+				ORDER BY created_at DESC, id DESC
+				OFFSET $1
+			)`, policy.MaxRows)
+		if err != nil {
+			return deleted, fmt.Errorf("failed to purge excess audit events: %w", err)
+		}
+		n, _ := result.RowsAffected()
+		deleted += n
+	}
+
+	return deleted, nil
+}
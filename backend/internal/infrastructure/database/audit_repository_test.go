@@ -0,0 +1,85 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/controlcrud/backend/internal/domain/audit"
+)
+
+func TestBuildAuditWhere(t *testing.T) {
+	start := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	status := "failure"
+	search := "alice"
+
+	where, args := buildAuditWhere(audit.QueryFilters{
+		EventTypes:  []audit.EventType{audit.EventTypeEdit, audit.EventTypePush},
+		EntityTypes: []string{"statement"},
+		Status:      &status,
+		StartDate:   &start,
+		Search:      &search,
+	})
+
+	wantWhere := "WHERE entity_type IN ($1) AND event_type IN ($2,$3) AND status = $4 AND created_at >= $5" +
+		" AND (user_email ILIKE $6 OR action ILIKE $7 OR entity_id ILIKE $8)"
+	if where != wantWhere {
+		t.Errorf("expected where clause:\n%s\ngot:\n%s", wantWhere, where)
+	}
+	wantArgs := []interface{}{"statement", "edit", "push", "failure", start, "%alice%", "%alice%", "%alice%"}
+	if !reflect.DeepEqual(args, wantArgs) {
+		t.Errorf("expected args %v, got %v", wantArgs, args)
+	}
+}
+
+func TestBuildAuditWhere_NoFilters(t *testing.T) {
+	where, args := buildAuditWhere(audit.QueryFilters{Page: 1, PageSize: 50})
+	if where != "" || len(args) != 0 {
+		t.Errorf("expected no where clause, got %q with %v", where, args)
+	}
+}
+
+// TestAuditRepository_CreatedEntityIndex checks that the first page of the
+// audit log filtered by entity type and date is planned on
+// idx_audit_events_created_entity. It needs TEST_DATABASE_URL.
+func TestAuditRepository_CreatedEntityIndex(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+	prefix := fmt.Sprintf("idx-test-%d-", time.Now().UnixNano())
+
+	// A year of events, one every five minutes, spread over four entity types
+	_, err := db.ExecContext(ctx, `
+		INSERT INTO audit_events (event_type, entity_type, entity_id, action, status, user_email, created_at)
+		SELECT (ARRAY['pull', 'push', 'edit'])[n % 3 + 1],
+		       (ARRAY['statement', 'control', 'system', 'connection'])[n % 4 + 1],
+		       $1 || n, 'update',
+		       CASE WHEN n % 50 = 0 THEN 'failure' ELSE 'success' END,
+		       'user' || n % 20 || '@example.com',
+		       NOW() - n * INTERVAL '5 minutes'
+		FROM generate_series(1, 100000) AS n
+	`, prefix)
+	if err != nil {
+		t.Fatalf("failed to insert audit events: %v", err)
+	}
+	t.Cleanup(func() {
+		db.ExecContext(context.Background(), `DELETE FROM audit_events WHERE entity_id LIKE $1 || '%'`, prefix)
+	})
+	if err := AnalyzeTable(ctx, db, "audit_events"); err != nil {
+		t.Fatalf("failed to analyze: %v", err)
+	}
+
+	start := time.Now().AddDate(0, 0, -30)
+	where, args := buildAuditWhere(audit.QueryFilters{
+		EntityTypes: []string{"statement"},
+		StartDate:   &start,
+	})
+	query := fmt.Sprintf(`SELECT id, status, user_email FROM audit_events %s ORDER BY created_at DESC LIMIT 50`, where)
+
+	plan := explainAnalyze(t, db, query, args...)
+	if !strings.Contains(plan, "idx_audit_events_created_entity") {
+		t.Errorf("expected plan to use idx_audit_events_created_entity, got:\n%s", plan)
+	}
+}
@@ -5,34 +5,43 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/controlcrud/backend/internal/domain/connection"
+	"github.com/controlcrud/backend/internal/infrastructure/crypto"
 	"github.com/google/uuid"
 )
 
 // ConnectionRepository implements connection.Repository using PostgreSQL.
 type ConnectionRepository struct {
-	db *sql.DB
+	db       *sql.DB
+	timeouts QueryTimeouts
 }
 
 // NewConnectionRepository creates a new PostgreSQL connection repository.
-func NewConnectionRepository(db *sql.DB) *ConnectionRepository {
-	return &ConnectionRepository{db: db}
+func NewConnectionRepository(db *sql.DB, timeouts QueryTimeouts) *ConnectionRepository {
+	return &ConnectionRepository{db: db, timeouts: timeouts}
 }
 
-// GetActive retrieves the active connection configuration.
-func (r *ConnectionRepository) GetActive(ctx context.Context) (*connection.Connection, error) {
+// GetByLabel retrieves the connection configuration with the given label.
+func (r *ConnectionRepository) GetByLabel(ctx context.Context, label string) (*connection.Connection, error) {
+	ctx, cancel := r.timeouts.singleRow(ctx)
+	defer cancel()
+
 	query := `
 		SELECT
 			id, instance_url, auth_method,
 			username, password_encrypted, password_nonce,
 			oauth_client_id, oauth_client_secret_encrypted, oauth_client_secret_nonce, oauth_token_url,
 			is_active, last_test_at, last_test_status, last_test_message, last_test_instance_version,
-			created_at, updated_at, created_by, updated_by
+			last_test_response_time_ms,
+			created_at, updated_at, created_by, updated_by,
+			scripted_api_namespace, scripted_api_id, label,
+			client_cert_encrypted, client_cert_nonce, client_key_encrypted, client_key_nonce,
+			scope
 		FROM servicenow_connections
-		WHERE is_active = true
-		LIMIT 1
+		WHERE label = $1
 	`
 
 	var conn connection.Connection
@@ -40,14 +49,20 @@ func (r *ConnectionRepository) GetActive(ctx context.Context) (*connection.Conne
 	var lastTestStatus sql.NullString
 	var lastTestMessage sql.NullString
 	var lastTestInstanceVersion sql.NullString
+	var lastTestResponseTimeMs sql.NullInt64
 	var createdBy, updatedBy sql.NullString
+	var scriptedAPINamespace, scriptedAPIID, scope sql.NullString
 
-	err := r.db.QueryRowContext(ctx, query).Scan(
+	err := r.db.QueryRowContext(ctx, query, label).Scan(
 		&conn.ID, &conn.InstanceURL, &conn.AuthMethod,
 		&conn.Username, &conn.PasswordEncrypted, &conn.PasswordNonce,
 		&conn.OAuthClientID, &conn.OAuthClientSecretEncrypted, &conn.OAuthClientSecretNonce, &conn.OAuthTokenURL,
 		&conn.IsActive, &lastTestAt, &lastTestStatus, &lastTestMessage, &lastTestInstanceVersion,
+		&lastTestResponseTimeMs,
 		&conn.CreatedAt, &conn.UpdatedAt, &createdBy, &updatedBy,
+		&scriptedAPINamespace, &scriptedAPIID, &conn.Label,
+		&conn.ClientTLSCertEncrypted, &conn.ClientTLSCertNonce, &conn.ClientTLSKeyEncrypted, &conn.ClientTLSKeyNonce,
+		&scope,
 	)
 
 	if err != nil {
@@ -69,6 +84,7 @@ func (r *ConnectionRepository) GetActive(ctx context.Context) (*connection.Conne
 	if lastTestInstanceVersion.Valid {
 		conn.LastTestInstanceVersion = lastTestInstanceVersion.String
 	}
+	conn.LastTestResponseTimeMs = lastTestResponseTimeMs.Int64
 	if createdBy.Valid {
 		id, _ := uuid.Parse(createdBy.String)
 		conn.CreatedBy = &id
@@ -77,19 +93,29 @@ func (r *ConnectionRepository) GetActive(ctx context.Context) (*connection.Conne
 		id, _ := uuid.Parse(updatedBy.String)
 		conn.UpdatedBy = &id
 	}
+	conn.ScriptedAPINamespace = scriptedAPINamespace.String
+	conn.ScriptedAPIID = scriptedAPIID.String
+	conn.Scope = scope.String
 
 	return &conn, nil
 }
 
 // GetByID retrieves a connection by its ID.
 func (r *ConnectionRepository) GetByID(ctx context.Context, id uuid.UUID) (*connection.Connection, error) {
+	ctx, cancel := r.timeouts.singleRow(ctx)
+	defer cancel()
+
 	query := `
 		SELECT
 			id, instance_url, auth_method,
 			username, password_encrypted, password_nonce,
 			oauth_client_id, oauth_client_secret_encrypted, oauth_client_secret_nonce, oauth_token_url,
 			is_active, last_test_at, last_test_status, last_test_message, last_test_instance_version,
-			created_at, updated_at, created_by, updated_by
+			last_test_response_time_ms,
+			created_at, updated_at, created_by, updated_by,
+			scripted_api_namespace, scripted_api_id, label,
+			client_cert_encrypted, client_cert_nonce, client_key_encrypted, client_key_nonce,
+			scope
 		FROM servicenow_connections
 		WHERE id = $1
 	`
@@ -99,14 +125,20 @@ func (r *ConnectionRepository) GetByID(ctx context.Context, id uuid.UUID) (*conn
 	var lastTestStatus sql.NullString
 	var lastTestMessage sql.NullString
 	var lastTestInstanceVersion sql.NullString
+	var lastTestResponseTimeMs sql.NullInt64
 	var createdBy, updatedBy sql.NullString
+	var scriptedAPINamespace, scriptedAPIID, scope sql.NullString
 
 	err := r.db.QueryRowContext(ctx, query, id).Scan(
 		&conn.ID, &conn.InstanceURL, &conn.AuthMethod,
 		&conn.Username, &conn.PasswordEncrypted, &conn.PasswordNonce,
 		&conn.OAuthClientID, &conn.OAuthClientSecretEncrypted, &conn.OAuthClientSecretNonce, &conn.OAuthTokenURL,
 		&conn.IsActive, &lastTestAt, &lastTestStatus, &lastTestMessage, &lastTestInstanceVersion,
+		&lastTestResponseTimeMs,
 		&conn.CreatedAt, &conn.UpdatedAt, &createdBy, &updatedBy,
+		&scriptedAPINamespace, &scriptedAPIID, &conn.Label,
+		&conn.ClientTLSCertEncrypted, &conn.ClientTLSCertNonce, &conn.ClientTLSKeyEncrypted, &conn.ClientTLSKeyNonce,
+		&scope,
 	)
 
 	if err != nil {
@@ -128,6 +160,7 @@ func (r *ConnectionRepository) GetByID(ctx context.Context, id uuid.UUID) (*conn
 	if lastTestInstanceVersion.Valid {
 		conn.LastTestInstanceVersion = lastTestInstanceVersion.String
 	}
+	conn.LastTestResponseTimeMs = lastTestResponseTimeMs.Int64
 	if createdBy.Valid {
 		id, _ := uuid.Parse(createdBy.String)
 		conn.CreatedBy = &id
@@ -136,25 +169,37 @@ func (r *ConnectionRepository) GetByID(ctx context.Context, id uuid.UUID) (*conn
 		id, _ := uuid.Parse(updatedBy.String)
 		conn.UpdatedBy = &id
 	}
+	conn.ScriptedAPINamespace = scriptedAPINamespace.String
+	conn.ScriptedAPIID = scriptedAPIID.String
+	conn.Scope = scope.String
 
 	return &conn, nil
 }
 
 // Upsert creates or updates a connection configuration.
 func (r *ConnectionRepository) Upsert(ctx context.Context, conn *connection.Connection) error {
+	ctx, cancel := r.timeouts.singleRow(ctx)
+	defer cancel()
+
 	query := `
 		INSERT INTO servicenow_connections (
 			id, instance_url, auth_method,
 			username, password_encrypted, password_nonce,
 			oauth_client_id, oauth_client_secret_encrypted, oauth_client_secret_nonce, oauth_token_url,
 			is_active, last_test_status,
-			created_at, updated_at, created_by, updated_by
+			created_at, updated_at, created_by, updated_by,
+			scripted_api_namespace, scripted_api_id, label,
+			client_cert_encrypted, client_cert_nonce, client_key_encrypted, client_key_nonce,
+			scope
 		) VALUES (
 			$1, $2, $3,
 			$4, $5, $6,
 			$7, $8, $9, $10,
 			$11, $12,
-			$13, $14, $15, $16
+			$13, $14, $15, $16,
+			NULLIF($17, ''), NULLIF($18, ''), $19,
+			$20, $21, $22, $23,
+			NULLIF($24, '')
 		)
 		ON CONFLICT (id) DO UPDATE SET
 			instance_url = EXCLUDED.instance_url,
@@ -169,7 +214,15 @@ func (r *ConnectionRepository) Upsert(ctx context.Context, conn *connection.Conn
 			is_active = EXCLUDED.is_active,
 			last_test_status = EXCLUDED.last_test_status,
 			updated_at = EXCLUDED.updated_at,
-			updated_by = EXCLUDED.updated_by
+			updated_by = EXCLUDED.updated_by,
+			scripted_api_namespace = EXCLUDED.scripted_api_namespace,
+			scripted_api_id = EXCLUDED.scripted_api_id,
+			label = EXCLUDED.label,
+			client_cert_encrypted = EXCLUDED.client_cert_encrypted,
+			client_cert_nonce = EXCLUDED.client_cert_nonce,
+			client_key_encrypted = EXCLUDED.client_key_encrypted,
+			client_key_nonce = EXCLUDED.client_key_nonce,
+			scope = EXCLUDED.scope
 	`
 
 	now := time.Now()
@@ -184,13 +237,19 @@ func (r *ConnectionRepository) Upsert(ctx context.Context, conn *connection.Conn
 		conn.OAuthClientID, conn.OAuthClientSecretEncrypted, conn.OAuthClientSecretNonce, conn.OAuthTokenURL,
 		conn.IsActive, conn.LastTestStatus,
 		conn.CreatedAt, conn.UpdatedAt, conn.CreatedBy, conn.UpdatedBy,
+		conn.ScriptedAPINamespace, conn.ScriptedAPIID, conn.Label,
+		conn.ClientTLSCertEncrypted, conn.ClientTLSCertNonce, conn.ClientTLSKeyEncrypted, conn.ClientTLSKeyNonce,
+		conn.Scope,
 	)
 
 	return err
 }
 
 // UpdateTestStatus updates the test status for a connection.
-func (r *ConnectionRepository) UpdateTestStatus(ctx context.Context, id uuid.UUID, status connection.ConnectionStatus, message string, version string) error {
+func (r *ConnectionRepository) UpdateTestStatus(ctx context.Context, id uuid.UUID, status connection.ConnectionStatus, message string, version string, responseTimeMs int64) error {
+	ctx, cancel := r.timeouts.singleRow(ctx)
+	defer cancel()
+
 	query := `
 		UPDATE servicenow_connections
 		SET
@@ -198,12 +257,56 @@ func (r *ConnectionRepository) UpdateTestStatus(ctx context.Context, id uuid.UUI
 			last_test_status = $3,
 			last_test_message = $4,
 			last_test_instance_version = $5,
-			updated_at = $6
+			last_test_response_time_ms = $6,
+			updated_at = $7
 		WHERE id = $1
 	`
 
 	now := time.Now()
-	result, err := r.db.ExecContext(ctx, query, id, now, status, message, version, now)
+	result, err := r.db.ExecContext(ctx, query, id, now, status, message, version, responseTimeMs, now)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return connection.ErrConnectionNotFound
+	}
+
+	return nil
+}
+
+// UpdateCredentials updates the encrypted credentials for a connection in place.
+// created_at and created_by are left untouched.
+func (r *ConnectionRepository) UpdateCredentials(ctx context.Context, conn *connection.Connection) error {
+	ctx, cancel := r.timeouts.singleRow(ctx)
+	defer cancel()
+
+	query := `
+		UPDATE servicenow_connections
+		SET
+			username = $2,
+			password_encrypted = $3,
+			password_nonce = $4,
+			oauth_client_secret_encrypted = $5,
+			oauth_client_secret_nonce = $6,
+			last_test_status = $7,
+			updated_at = $8,
+			updated_by = $9
+		WHERE id = $1
+	`
+
+	conn.UpdatedAt = time.Now()
+
+	result, err := r.db.ExecContext(ctx, query,
+		conn.ID,
+		conn.Username, conn.PasswordEncrypted, conn.PasswordNonce,
+		conn.OAuthClientSecretEncrypted, conn.OAuthClientSecretNonce,
+		conn.LastTestStatus, conn.UpdatedAt, conn.UpdatedBy,
+	)
 	if err != nil {
 		return err
 	}
@@ -221,6 +324,9 @@ func (r *ConnectionRepository) UpdateTestStatus(ctx context.Context, id uuid.UUI
 
 // Delete removes a connection configuration.
 func (r *ConnectionRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	ctx, cancel := r.timeouts.singleRow(ctx)
+	defer cancel()
+
 	query := `DELETE FROM servicenow_connections WHERE id = $1`
 
 	result, err := r.db.ExecContext(ctx, query, id)
@@ -239,10 +345,88 @@ func (r *ConnectionRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
-// DeactivateAll deactivates all connection configurations.
-func (r *ConnectionRepository) DeactivateAll(ctx context.Context) error {
-	query := `UPDATE servicenow_connections SET is_active = false, updated_at = $1`
+// ReEncryptCredentials rewrites every connection's encrypted password, OAuth
+// client secret and client TLS certificate pair with reencrypt in a single transaction. The rows are
+// locked until the transaction ends; any error rolls back all changes.
+func (r *ConnectionRepository) ReEncryptCredentials(ctx context.Context, reencrypt crypto.ReEncryptFunc) error {
+	ctx, cancel := r.timeouts.batch(ctx)
+	defer cancel()
 
-	_, err := r.db.ExecContext(ctx, query, time.Now())
-	return err
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	type secrets struct {
+		id                              uuid.UUID
+		password, passwordNonce         []byte
+		clientSecret, clientSecretNonce []byte
+		clientCert, clientCertNonce     []byte
+		clientKey, clientKeyNonce       []byte
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT id, password_encrypted, password_nonce,
+		       oauth_client_secret_encrypted, oauth_client_secret_nonce,
+		       client_cert_encrypted, client_cert_nonce, client_key_encrypted, client_key_nonce
+		FROM servicenow_connections
+		FOR UPDATE
+	`)
+	if err != nil {
+		return err
+	}
+	var conns []secrets
+	for rows.Next() {
+		var c secrets
+		if err := rows.Scan(&c.id, &c.password, &c.passwordNonce, &c.clientSecret, &c.clientSecretNonce,
+			&c.clientCert, &c.clientCertNonce, &c.clientKey, &c.clientKeyNonce); err != nil {
+			rows.Close()
+			return err
+		}
+		conns = append(conns, c)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, c := range conns {
+		if len(c.password) > 0 {
+			if c.password, c.passwordNonce, err = reencrypt(c.password, c.passwordNonce); err != nil {
+				return fmt.Errorf("connection %s password: %w", c.id, err)
+			}
+		}
+		if len(c.clientSecret) > 0 {
+			if c.clientSecret, c.clientSecretNonce, err = reencrypt(c.clientSecret, c.clientSecretNonce); err != nil {
+				return fmt.Errorf("connection %s OAuth client secret: %w", c.id, err)
+			}
+		}
+		if len(c.clientCert) > 0 {
+			if c.clientCert, c.clientCertNonce, err = reencrypt(c.clientCert, c.clientCertNonce); err != nil {
+				return fmt.Errorf("connection %s client TLS certificate: %w", c.id, err)
+			}
+		}
+		if len(c.clientKey) > 0 {
+			if c.clientKey, c.clientKeyNonce, err = reencrypt(c.clientKey, c.clientKeyNonce); err != nil {
+				return fmt.Errorf("connection %s client TLS key: %w", c.id, err)
+			}
+		}
+
+		_, err := tx.ExecContext(ctx, `
+			UPDATE servicenow_connections
+			SET password_encrypted = $2, password_nonce = $3,
+			    oauth_client_secret_encrypted = $4, oauth_client_secret_nonce = $5,
+			    client_cert_encrypted = $6, client_cert_nonce = $7,
+			    client_key_encrypted = $8, client_key_nonce = $9,
+			    updated_at = NOW()
+			WHERE id = $1
+		`, c.id, c.password, c.passwordNonce, c.clientSecret, c.clientSecretNonce,
+			c.clientCert, c.clientCertNonce, c.clientKey, c.clientKeyNonce)
+		if err != nil {
+			return fmt.Errorf("failed to update connection %s: %w", c.id, err)
+		}
+	}
+
+	return tx.Commit()
 }
@@ -13,16 +13,20 @@ import (
 
 // ControlRepository implements control.Repository using PostgreSQL.
 type ControlRepository struct {
-	db *sql.DB
+	db       *sql.DB
+	timeouts QueryTimeouts
 }
 
 // NewControlRepository creates a new control repository.
-func NewControlRepository(db *sql.DB) *ControlRepository {
-	return &ControlRepository{db: db}
+func NewControlRepository(db *sql.DB, timeouts QueryTimeouts) *ControlRepository {
+	return &ControlRepository{db: db, timeouts: timeouts}
 }
 
 // GetByID retrieves a control by its internal ID.
 func (r *ControlRepository) GetByID(ctx context.Context, id uuid.UUID) (*control.Control, error) {
+	ctx, cancel := r.timeouts.singleRow(ctx)
+	defer cancel()
+
 	query := `
 		SELECT id, system_id, sn_sys_id, control_id, control_name, control_family,
 		       description, implementation_status, responsible_role,
@@ -36,6 +40,9 @@ func (r *ControlRepository) GetByID(ctx context.Context, id uuid.UUID) (*control
 
 // GetBySNSysID retrieves a control by system ID and ServiceNow sys_id.
 func (r *ControlRepository) GetBySNSysID(ctx context.Context, systemID uuid.UUID, snSysID string) (*control.Control, error) {
+	ctx, cancel := r.timeouts.singleRow(ctx)
+	defer cancel()
+
 	query := `
 		SELECT id, system_id, sn_sys_id, control_id, control_name, control_family,
 		       description, implementation_status, responsible_role,
@@ -47,8 +54,31 @@ func (r *ControlRepository) GetBySNSysID(ctx context.Context, systemID uuid.UUID
 	return r.scanControl(r.db.QueryRowContext(ctx, query, systemID, snSysID))
 }
 
+// GetByControlIDString retrieves a control of a system by its ServiceNow
+// control ID, ignoring case.
+func (r *ControlRepository) GetByControlIDString(ctx context.Context, systemID uuid.UUID, controlID string) (*control.Control, error) {
+	ctx, cancel := r.timeouts.singleRow(ctx)
+	defer cancel()
+
+	// Control IDs are not unique per system, so pick one deterministically
+	query := `
+		SELECT id, system_id, sn_sys_id, control_id, control_name, control_family,
+		       description, implementation_status, responsible_role,
+		       sn_updated_on, last_pull_at, last_push_at, created_at, updated_at
+		FROM controls
+		WHERE system_id = $1 AND UPPER(control_id) = UPPER($2)
+		ORDER BY created_at, id
+		LIMIT 1
+	`
+
+	return r.scanControl(r.db.QueryRowContext(ctx, query, systemID, controlID))
+}
+
 // List retrieves controls for a system with pagination.
 func (r *ControlRepository) List(ctx context.Context, params control.ListParams) (*control.ListResult, error) {
+	ctx, cancel := r.timeouts.list(ctx)
+	defer cancel()
+
 	var conditions []string
 	var args []interface{}
 	argNum := 1
@@ -57,9 +87,9 @@ func (r *ControlRepository) List(ctx context.Context, params control.ListParams)
 	args = append(args, params.SystemID)
 	argNum++
 
-	if params.ControlFamily != "" {
+	if family := control.NormalizeFamily(params.ControlFamily); family != "" {
 		conditions = append(conditions, fmt.Sprintf("c.control_family = $%d", argNum))
-		args = append(args, params.ControlFamily)
+		args = append(args, family)
 		argNum++
 	}
 
@@ -93,8 +123,7 @@ func (r *ControlRepository) List(ctx context.Context, params control.ListParams)
 		SELECT c.id, c.system_id, c.sn_sys_id, c.control_id, c.control_name, c.control_family,
 		       c.description, c.implementation_status, c.responsible_role,
 		       c.sn_updated_on, c.last_pull_at, c.last_push_at, c.created_at, c.updated_at,
-		       COALESCE((SELECT COUNT(*) FROM statements s WHERE s.control_id = c.id), 0) as statement_count,
-		       COALESCE((SELECT COUNT(*) FROM statements s WHERE s.control_id = c.id AND s.is_modified = true), 0) as modified_count
+		       c.statement_count, c.modified_count
 		FROM controls c
 		%s
 		ORDER BY c.control_id ASC
@@ -149,8 +178,107 @@ func (r *ControlRepository) List(ctx context.Context, params control.ListParams)
 	}, nil
 }
 
+// Search finds controls of all non-deleted systems whose control ID or name
+// contains the query.
+func (r *ControlRepository) Search(ctx context.Context, params control.SearchParams) (*control.SearchResult, error) {
+	ctx, cancel := r.timeouts.list(ctx)
+	defer cancel()
+
+	conditions := []string{"s.deleted_at IS NULL", "(c.control_id ILIKE $1 OR c.control_name ILIKE $1)"}
+	args := []interface{}{"%" + params.Query + "%"}
+	argNum := 2
+
+	if family := control.NormalizeFamily(params.ControlFamily); family != "" {
+		conditions = append(conditions, fmt.Sprintf("c.control_family = $%d", argNum))
+		args = append(args, family)
+		argNum++
+	}
+
+	whereClause := "WHERE " + strings.Join(conditions, " AND ")
+
+	countQuery := fmt.Sprintf(`SELECT COUNT(*) FROM controls c JOIN systems s ON s.id = c.system_id %s`, whereClause)
+	var totalCount int
+	if err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&totalCount); err != nil {
+		return nil, fmt.Errorf("failed to count controls: %w", err)
+	}
+
+	if params.Page < 1 {
+		params.Page = 1
+	}
+	if params.PageSize < 1 {
+		params.PageSize = 20
+	}
+	offset := (params.Page - 1) * params.PageSize
+	totalPages := (totalCount + params.PageSize - 1) / params.PageSize
+
+	query := fmt.Sprintf(`
+		SELECT c.id, c.system_id, c.sn_sys_id, c.control_id, c.control_name, c.control_family,
+		       c.description, c.implementation_status, c.responsible_role,
+		       c.sn_updated_on, c.last_pull_at, c.last_push_at, c.created_at, c.updated_at,
+		       c.statement_count, c.modified_count, s.name
+		FROM controls c
+		JOIN systems s ON s.id = c.system_id
+		%s
+		ORDER BY c.control_id ASC, s.name ASC, c.id ASC
+		LIMIT $%d OFFSET $%d
+	`, whereClause, argNum, argNum+1)
+
+	args = append(args, params.PageSize, offset)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search controls: %w", err)
+	}
+	defer rows.Close()
+
+	entries := make([]control.SearchEntry, 0)
+	for rows.Next() {
+		var e control.SearchEntry
+		var description, responsibleRole sql.NullString
+		var snUpdatedOn, lastPullAt, lastPushAt sql.NullTime
+
+		err := rows.Scan(
+			&e.ID, &e.SystemID, &e.SNSysID, &e.ControlID, &e.ControlName, &e.ControlFamily,
+			&description, &e.ImplementationStatus, &responsibleRole,
+			&snUpdatedOn, &lastPullAt, &lastPushAt, &e.CreatedAt, &e.UpdatedAt,
+			&e.StatementCount, &e.ModifiedCount, &e.SystemName,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan control: %w", err)
+		}
+
+		e.Description = description.String
+		e.ResponsibleRole = responsibleRole.String
+		if snUpdatedOn.Valid {
+			e.SNUpdatedOn = &snUpdatedOn.Time
+		}
+		if lastPullAt.Valid {
+			e.LastPullAt = &lastPullAt.Time
+		}
+		if lastPushAt.Valid {
+			e.LastPushAt = &lastPushAt.Time
+		}
+
+		entries = append(entries, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to search controls: %w", err)
+	}
+
+	return &control.SearchResult{
+		Controls:   entries,
+		TotalCount: totalCount,
+		Page:       params.Page,
+		PageSize:   params.PageSize,
+		TotalPages: totalPages,
+	}, nil
+}
+
 // ListBySystem retrieves all controls for a system.
 func (r *ControlRepository) ListBySystem(ctx context.Context, systemID uuid.UUID) ([]control.Control, error) {
+	ctx, cancel := r.timeouts.list(ctx)
+	defer cancel()
+
 	query := `
 		SELECT id, system_id, sn_sys_id, control_id, control_name, control_family,
 		       description, implementation_status, responsible_role,
@@ -178,8 +306,44 @@ func (r *ControlRepository) ListBySystem(ctx context.Context, systemID uuid.UUID
 	return controls, nil
 }
 
+// ListWithoutStatements retrieves the controls of a system that have no statements.
+func (r *ControlRepository) ListWithoutStatements(ctx context.Context, systemID uuid.UUID) ([]control.Control, error) {
+	ctx, cancel := r.timeouts.list(ctx)
+	defer cancel()
+
+	query := `
+		SELECT c.id, c.system_id, c.sn_sys_id, c.control_id, c.control_name, c.control_family,
+		       c.description, c.implementation_status, c.responsible_role,
+		       c.sn_updated_on, c.last_pull_at, c.last_push_at, c.created_at, c.updated_at
+		FROM controls c
+		LEFT JOIN statements s ON s.control_id = c.id
+		WHERE c.system_id = $1 AND s.id IS NULL
+		ORDER BY c.control_id ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, systemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list controls without statements: %w", err)
+	}
+	defer rows.Close()
+
+	controls := make([]control.Control, 0)
+	for rows.Next() {
+		c, err := r.scanControlFromRows(rows)
+		if err != nil {
+			return nil, err
+		}
+		controls = append(controls, *c)
+	}
+
+	return controls, nil
+}
+
 // Upsert creates or updates a control.
 func (r *ControlRepository) Upsert(ctx context.Context, input control.UpsertInput) (*control.Control, error) {
+	ctx, cancel := r.timeouts.singleRow(ctx)
+	defer cancel()
+
 	query := `
 		INSERT INTO controls (system_id, sn_sys_id, control_id, control_name, control_family,
 		                      description, implementation_status, responsible_role, sn_updated_on, last_pull_at)
@@ -206,13 +370,16 @@ func (r *ControlRepository) Upsert(ctx context.Context, input control.UpsertInpu
 	}
 
 	return r.scanControl(r.db.QueryRowContext(ctx, query,
-		input.SystemID, input.SNSysID, input.ControlID, input.ControlName, input.ControlFamily,
+		input.SystemID, input.SNSysID, input.ControlID, input.ControlName, control.NormalizeFamily(input.ControlFamily),
 		input.Description, status, input.ResponsibleRole, input.SNUpdatedOn,
 	))
 }
 
 // UpsertBatch creates or updates multiple controls.
 func (r *ControlRepository) UpsertBatch(ctx context.Context, inputs []control.UpsertInput) ([]control.Control, error) {
+	ctx, cancel := r.timeouts.batch(ctx)
+	defer cancel()
+
 	if len(inputs) == 0 {
 		return []control.Control{}, nil
 	}
@@ -256,7 +423,7 @@ func (r *ControlRepository) UpsertBatch(ctx context.Context, inputs []control.Up
 		var snUpdatedOn, lastPullAt, lastPushAt sql.NullTime
 
 		err := tx.QueryRowContext(ctx, query,
-			input.SystemID, input.SNSysID, input.ControlID, input.ControlName, input.ControlFamily,
+			input.SystemID, input.SNSysID, input.ControlID, input.ControlName, control.NormalizeFamily(input.ControlFamily),
 			input.Description, status, input.ResponsibleRole, input.SNUpdatedOn,
 		).Scan(
 			&c.ID, &c.SystemID, &c.SNSysID, &c.ControlID, &c.ControlName, &c.ControlFamily,
@@ -289,8 +456,30 @@ func (r *ControlRepository) UpsertBatch(ctx context.Context, inputs []control.Up
 	return controls, nil
 }
 
+// UpdateStatus updates only the implementation status of a control.
+func (r *ControlRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status string) error {
+	ctx, cancel := r.timeouts.singleRow(ctx)
+	defer cancel()
+
+	query := `UPDATE controls SET implementation_status = $2, updated_at = NOW() WHERE id = $1`
+	result, err := r.db.ExecContext(ctx, query, id, status)
+	if err != nil {
+		return fmt.Errorf("failed to update control status: %w", err)
+	}
+
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return control.ErrNotFound
+	}
+
+	return nil
+}
+
 // Delete removes a control and its statements.
 func (r *ControlRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	ctx, cancel := r.timeouts.singleRow(ctx)
+	defer cancel()
+
 	query := `DELETE FROM controls WHERE id = $1`
 	result, err := r.db.ExecContext(ctx, query, id)
 	if err != nil {
@@ -307,6 +496,9 @@ func (r *ControlRepository) Delete(ctx context.Context, id uuid.UUID) error {
 
 // DeleteBySystem removes all controls for a system.
 func (r *ControlRepository) DeleteBySystem(ctx context.Context, systemID uuid.UUID) error {
+	ctx, cancel := r.timeouts.batch(ctx)
+	defer cancel()
+
 	query := `DELETE FROM controls WHERE system_id = $1`
 	_, err := r.db.ExecContext(ctx, query, systemID)
 	if err != nil {
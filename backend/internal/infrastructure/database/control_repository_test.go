@@ -0,0 +1,142 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/controlcrud/backend/internal/domain/control"
+	"github.com/controlcrud/backend/internal/infrastructure/database/migrations"
+)
+
+// TestControlRepository_Search runs against the PostgreSQL database in
+// TEST_DATABASE_URL and is skipped when it is not set.
+func TestControlRepository_Search(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL not set")
+	}
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	defer db.Close()
+	if err := migrations.RunMigrations(db, ""); err != nil {
+		t.Fatalf("failed to run migrations: %v", err)
+	}
+
+	ctx := context.Background()
+	// Control names carry a unique marker so other rows never match
+	marker := "search" + strings.ReplaceAll(uuid.NewString(), "-", "")[:12]
+
+	insertSystem := func(name string) uuid.UUID {
+		t.Helper()
+		var id uuid.UUID
+		err := db.QueryRowContext(ctx,
+			`INSERT INTO systems (sn_sys_id, name) VALUES ($1, $2) RETURNING id`,
+			strings.ReplaceAll(uuid.NewString(), "-", ""), name,
+		).Scan(&id)
+		if err != nil {
+			t.Fatalf("failed to insert system: %v", err)
+		}
+		t.Cleanup(func() { db.ExecContext(ctx, `DELETE FROM systems WHERE id = $1`, id) })
+		return id
+	}
+	insertControl := func(systemID uuid.UUID, controlID, family string) {
+		t.Helper()
+		_, err := db.ExecContext(ctx,
+			`INSERT INTO controls (system_id, sn_sys_id, control_id, control_name, control_family) VALUES ($1, $2, $3, $4, $5)`,
+			systemID, uuid.NewString(), controlID, marker+" "+controlID, family,
+		)
+		if err != nil {
+			t.Fatalf("failed to insert control: %v", err)
+		}
+	}
+
+	alpha := insertSystem("Alpha " + marker)
+	beta := insertSystem("Beta " + marker)
+	deleted := insertSystem("Deleted " + marker)
+	insertControl(alpha, "AC-1", "AC")
+	insertControl(alpha, "SC-7", "SC")
+	insertControl(beta, "AC-1", "AC")
+	insertControl(beta, "AC-2", "AC")
+	insertControl(deleted, "AC-1", "AC")
+	if _, err := db.ExecContext(ctx, `UPDATE systems SET deleted_at = NOW() WHERE id = $1`, deleted); err != nil {
+		t.Fatalf("failed to delete system: %v", err)
+	}
+
+	repo := NewControlRepository(db, QueryTimeouts{})
+
+	result, err := repo.Search(ctx, control.SearchParams{Query: marker, ControlFamily: "ac", Page: 1, PageSize: 2})
+	if err != nil {
+		t.Fatalf("failed to search: %v", err)
+	}
+	if result.TotalCount != 3 || result.TotalPages != 2 {
+		t.Fatalf("expected 3 AC controls over 2 pages, got %d over %d", result.TotalCount, result.TotalPages)
+	}
+	var got []string
+	for _, e := range result.Controls {
+		got = append(got, fmt.Sprintf("%s/%s", e.ControlID, strings.Fields(e.SystemName)[0]))
+		if e.SystemID != alpha && e.SystemID != beta {
+			t.Errorf("unexpected system %s for %s", e.SystemID, e.ControlID)
+		}
+	}
+	if want := "AC-1/Alpha AC-1/Beta"; strings.Join(got, " ") != want {
+		t.Errorf("expected first page %q, got %q", want, strings.Join(got, " "))
+	}
+
+	// Deleted systems are excluded even when their controls match
+	result, err = repo.Search(ctx, control.SearchParams{Query: marker + " AC-1", Page: 1, PageSize: 50})
+	if err != nil {
+		t.Fatalf("failed to search: %v", err)
+	}
+	if result.TotalCount != 2 {
+		t.Errorf("expected AC-1 in the two live systems, got %d", result.TotalCount)
+	}
+	for _, e := range result.Controls {
+		if e.SystemID == deleted {
+			t.Errorf("expected controls of deleted systems to be excluded, got %s", e.SystemName)
+		}
+	}
+}
+
+// TestControlRepository_GetByControlIDString needs TEST_DATABASE_URL.
+func TestControlRepository_GetByControlIDString(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+	controlID := insertTestControl(t, db)
+	repo := NewControlRepository(db, QueryTimeouts{})
+
+	var systemID uuid.UUID
+	if err := db.QueryRowContext(ctx, `SELECT system_id FROM controls WHERE id = $1`, controlID).Scan(&systemID); err != nil {
+		t.Fatalf("failed to get system: %v", err)
+	}
+
+	for _, id := range []string{"AC-1", "ac-1"} {
+		c, err := repo.GetByControlIDString(ctx, systemID, id)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", id, err)
+		}
+		if c == nil || c.ID != controlID {
+			t.Errorf("%s: expected control %s, got %+v", id, controlID, c)
+		}
+	}
+
+	for _, tc := range []struct {
+		systemID  uuid.UUID
+		controlID string
+	}{
+		{systemID, "AC-2"},
+		{uuid.New(), "AC-1"},
+	} {
+		c, err := repo.GetByControlIDString(ctx, tc.systemID, tc.controlID)
+		if err != nil || c != nil {
+			t.Errorf("expected no control for %s in %s, got %+v, %v", tc.controlID, tc.systemID, c, err)
+		}
+	}
+}
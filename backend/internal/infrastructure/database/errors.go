@@ -0,0 +1,23 @@
+package database
+
+import (
+	"errors"
+
+	"github.com/lib/pq"
+)
+
+// PostgreSQL error codes checked by the repositories.
+const (
+	pgForeignKeyViolation pq.ErrorCode = "23503"
+	pgUniqueViolation     pq.ErrorCode = "23505"
+)
+
+// isConstraintViolation reports whether err is a PostgreSQL error with the
+// given code raised by the named constraint.
+func isConstraintViolation(err error, code pq.ErrorCode, constraint string) bool {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return false
+	}
+	return pqErr.Code == code && pqErr.Constraint == constraint
+}
@@ -0,0 +1,37 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/lib/pq"
+)
+
+func TestIsConstraintViolation(t *testing.T) {
+	fkErr := &pq.Error{Code: pgForeignKeyViolation, Constraint: statementTypeFKey}
+
+	tests := []struct {
+		name       string
+		err        error
+		code       pq.ErrorCode
+		constraint string
+		want       bool
+	}{
+		{"matching foreign key violation", fkErr, pgForeignKeyViolation, statementTypeFKey, true},
+		{"wrapped foreign key violation", fmt.Errorf("failed to scan statement: %w", fkErr), pgForeignKeyViolation, statementTypeFKey, true},
+		{"other constraint", &pq.Error{Code: pgForeignKeyViolation, Constraint: "statements_control_id_fkey"}, pgForeignKeyViolation, statementTypeFKey, false},
+		{"other code", &pq.Error{Code: pgUniqueViolation, Constraint: statementTypeFKey}, pgForeignKeyViolation, statementTypeFKey, false},
+		{"unique violation", &pq.Error{Code: pgUniqueViolation, Constraint: statementTypePKey}, pgUniqueViolation, statementTypePKey, true},
+		{"non-postgres error", errors.New("connection refused"), pgForeignKeyViolation, statementTypeFKey, false},
+		{"nil error", nil, pgForeignKeyViolation, statementTypeFKey, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isConstraintViolation(tt.err, tt.code, tt.constraint); got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
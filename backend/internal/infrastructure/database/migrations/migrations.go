@@ -0,0 +1,264 @@
+// Package migrations applies versioned SQL schema migrations.
+//
+// Migrations are SQL files named NNN_description.sql. They are embedded in the
+// binary and applied in version order; each applied version is recorded in the
+// schema_versions table so it runs only once.
+//
+// A migration whose first line is "-- migrate:no-transaction" runs outside a
+// transaction, one statement at a time, for statements such as CREATE INDEX
+// CONCURRENTLY that PostgreSQL refuses to run in a transaction block. Its
+// statements are split on semicolons, so they must not contain any others,
+// and each should be safe to rerun after a partial failure.
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//go:embed migrations/*.sql
+var embedded embed.FS
+
+const createVersionsTable = `
+	CREATE TABLE IF NOT EXISTS schema_versions (
+		version INTEGER PRIMARY KEY,
+		name VARCHAR(255) NOT NULL,
+		applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+	)
+`
+
+// noTransactionDirective marks a migration that must run outside a
+// transaction.
+const noTransactionDirective = "-- migrate:no-transaction"
+
+// advisoryLockKey is the PostgreSQL advisory lock held while a
+// no-transaction migration is applied. It serializes concurrent server
+// instances in place of the schema_versions table lock, which would make
+// CREATE INDEX CONCURRENTLY wait on the locking transaction forever.
+const advisoryLockKey = 0x6d6967726174 // "migrat"
+
+// Migration is a single versioned SQL file.
+type Migration struct {
+	Version int
+	Name    string
+	SQL     string
+
+	// NoTransaction runs each statement on its own, outside a transaction.
+	NoTransaction bool
+}
+
+// RunMigrations applies all pending migrations in version order.
+// If migrationsDir is empty the migrations embedded in the binary are used.
+func RunMigrations(db *sql.DB, migrationsDir string) error {
+	var fsys fs.FS
+	if migrationsDir == "" {
+		sub, err := fs.Sub(embedded, "migrations")
+		if err != nil {
+			return fmt.Errorf("failed to open embedded migrations: %w", err)
+		}
+		fsys = sub
+	} else {
+		fsys = os.DirFS(migrationsDir)
+	}
+
+	migrations, err := Load(fsys)
+	if err != nil {
+		return err
+	}
+
+	return Apply(context.Background(), db, migrations)
+}
+
+// Load reads and orders the migration files in the root of fsys.
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	var migrations []Migration
+	seen := make(map[int]string)
+	for _, entry := range entries {
+		if entry.IsDir() || path.Ext(entry.Name()) != ".sql" {
+			continue
+		}
+
+		version, err := parseVersion(entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		if existing, ok := seen[version]; ok {
+			return nil, fmt.Errorf("duplicate migration version %d: %s and %s", version, existing, entry.Name())
+		}
+		seen[version] = entry.Name()
+
+		content, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", entry.Name(), err)
+		}
+
+		firstLine, _, _ := strings.Cut(string(content), "\n")
+		migrations = append(migrations, Migration{
+			Version:       version,
+			Name:          entry.Name(),
+			SQL:           string(content),
+			NoTransaction: strings.TrimSpace(firstLine) == noTransactionDirective,
+		})
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// Apply runs each migration not yet recorded in schema_versions.
+// Every migration runs in its own transaction together with its version row,
+// so a failed migration leaves no partial schema change behind.
+func Apply(ctx context.Context, db *sql.DB, migrations []Migration) error {
+	if _, err := db.ExecContext(ctx, createVersionsTable); err != nil {
+		return fmt.Errorf("failed to create schema_versions table: %w", err)
+	}
+
+	for _, m := range migrations {
+		applied, err := applyOne(ctx, db, m)
+		if err != nil {
+			return err
+		}
+		if applied {
+			slog.Info("applied migration", "version", m.Version, "name", m.Name)
+		}
+	}
+
+	return nil
+}
+
+// applyOne applies a single migration unless it has already been recorded.
+// The schema_versions lock serializes concurrent server instances.
+func applyOne(ctx context.Context, db *sql.DB, m Migration) (bool, error) {
+	if m.NoTransaction {
+		return applyOneWithoutTransaction(ctx, db, m)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin migration %s: %w", m.Name, err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `LOCK TABLE schema_versions IN EXCLUSIVE MODE`); err != nil {
+		return false, fmt.Errorf("failed to lock schema_versions: %w", err)
+	}
+
+	var exists bool
+	err = tx.QueryRowContext(ctx,
+		`SELECT EXISTS(SELECT 1 FROM schema_versions WHERE version = $1)`, m.Version,
+	).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check migration %s: %w", m.Name, err)
+	}
+	if exists {
+		return false, nil
+	}
+
+	if _, err := tx.ExecContext(ctx, m.SQL); err != nil {
+		return false, fmt.Errorf("migration %s failed: %w", m.Name, err)
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO schema_versions (version, name) VALUES ($1, $2)`, m.Version, m.Name,
+	); err != nil {
+		return false, fmt.Errorf("failed to record migration %s: %w", m.Name, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit migration %s: %w", m.Name, err)
+	}
+
+	return true, nil
+}
+
+// applyOneWithoutTransaction applies a no-transaction migration unless it has
+// already been recorded, holding a session advisory lock on one connection.
+// A failure part way leaves the earlier statements applied and the version
+// unrecorded, so the whole migration runs again on the next start.
+func applyOneWithoutTransaction(ctx context.Context, db *sql.DB, m Migration) (bool, error) {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin migration %s: %w", m.Name, err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, advisoryLockKey); err != nil {
+		return false, fmt.Errorf("failed to lock migrations: %w", err)
+	}
+	defer conn.ExecContext(context.WithoutCancel(ctx), `SELECT pg_advisory_unlock($1)`, advisoryLockKey)
+
+	var exists bool
+	err = conn.QueryRowContext(ctx,
+		`SELECT EXISTS(SELECT 1 FROM schema_versions WHERE version = $1)`, m.Version,
+	).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check migration %s: %w", m.Name, err)
+	}
+	if exists {
+		return false, nil
+	}
+
+	for _, stmt := range splitStatements(m.SQL) {
+		if _, err := conn.ExecContext(ctx, stmt); err != nil {
+			return false, fmt.Errorf("migration %s failed: %w", m.Name, err)
+		}
+	}
+
+	if _, err := conn.ExecContext(ctx,
+		`INSERT INTO schema_versions (version, name) VALUES ($1, $2)`, m.Version, m.Name,
+	); err != nil {
+		return false, fmt.Errorf("failed to record migration %s: %w", m.Name, err)
+	}
+
+	return true, nil
+}
+
+// splitStatements splits migration SQL on semicolons into its statements,
+// dropping comment lines and empty statements. Sent together, the statements
+// would run in one implicit transaction.
+func splitStatements(migrationSQL string) []string {
+	var lines []string
+	for _, line := range strings.Split(migrationSQL, "\n") {
+		if !strings.HasPrefix(strings.TrimSpace(line), "--") {
+			lines = append(lines, line)
+		}
+	}
+
+	var statements []string
+	for _, stmt := range strings.Split(strings.Join(lines, "\n"), ";") {
+		if stmt = strings.TrimSpace(stmt); stmt != "" {
+			statements = append(statements, stmt)
+		}
+	}
+	return statements
+}
+
+// parseVersion extracts the numeric prefix from a file name like 001_initial.sql.
+func parseVersion(name string) (int, error) {
+	prefix, _, ok := strings.Cut(name, "_")
+	if !ok {
+		return 0, fmt.Errorf("invalid migration file name %q: expected NNN_name.sql", name)
+	}
+	version, err := strconv.Atoi(prefix)
+	if err != nil || version <= 0 {
+		return 0, fmt.Errorf("invalid migration file name %q: expected NNN_name.sql", name)
+	}
+	return version, nil
+}
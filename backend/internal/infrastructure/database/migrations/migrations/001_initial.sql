@@ -1,3 +1,87 @@
+-- Migration: Initial schema
+-- Consolidates the schema previously applied via docker-entrypoint-initdb.d:
+--   20260127_001_create_servicenow_connections.sql
+--   20260127_002_create_pull_tables.sql
+--   20260127_003_create_audit_tables.sql
+--   20261015_001_add_statement_review.sql
+-- All statements are idempotent so databases bootstrapped from those files
+-- can adopt the migration runner without manual steps.
+
+-- -----------------------------------------------------------------------------
+-- 20260127_001_create_servicenow_connections.sql
+-- -----------------------------------------------------------------------------
+
+-- Migration: Create ServiceNow Connections Table
+-- Feature: F1 - ServiceNow GRC Connection
+-- Date: 2026-01-27
+
+-- Create auth_method enum type
+DO $$ BEGIN
+    CREATE TYPE auth_method AS ENUM ('basic', 'oauth');
+EXCEPTION
+    WHEN duplicate_object THEN null;
+END $$;
+
+-- Create connection_status enum type
+DO $$ BEGIN
+    CREATE TYPE connection_status AS ENUM ('success', 'failure', 'pending', 'unknown');
+EXCEPTION
+    WHEN duplicate_object THEN null;
+END $$;
+
+-- Create servicenow_connections table
+CREATE TABLE IF NOT EXISTS servicenow_connections (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    instance_url VARCHAR(255) NOT NULL,
+    auth_method auth_method NOT NULL DEFAULT 'basic',
+
+    -- Basic Auth (encrypted)
+    username VARCHAR(255),
+    password_encrypted BYTEA,
+    password_nonce BYTEA,
+
+    -- OAuth (encrypted)
+    oauth_client_id VARCHAR(255),
+    oauth_client_secret_encrypted BYTEA,
+    oauth_client_secret_nonce BYTEA,
+    oauth_token_url VARCHAR(255),
+
+    -- Status tracking
+    is_active BOOLEAN DEFAULT true,
+    last_test_at TIMESTAMPTZ,
+    last_test_status connection_status DEFAULT 'unknown',
+    last_test_message TEXT,
+    last_test_instance_version VARCHAR(50),
+
+    -- Audit
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    created_by UUID,
+    updated_by UUID
+);
+
+-- Create partial unique index alternative (for databases that don't support partial unique constraints)
+-- This ensures only one row can have is_active = true
+CREATE UNIQUE INDEX IF NOT EXISTS idx_connections_single_active
+    ON servicenow_connections (is_active)
+    WHERE is_active = true;
+
+-- Create index on is_active for quick lookups
+CREATE INDEX IF NOT EXISTS idx_connections_active
+    ON servicenow_connections (is_active)
+    WHERE is_active = true;
+
+-- Add comment for documentation
+COMMENT ON TABLE servicenow_connections IS 'Stores ServiceNow GRC connection configuration with encrypted credentials';
+COMMENT ON COLUMN servicenow_connections.password_encrypted IS 'AES-256-GCM encrypted password';
+COMMENT ON COLUMN servicenow_connections.password_nonce IS 'Unique nonce used for password encryption';
+COMMENT ON COLUMN servicenow_connections.oauth_client_secret_encrypted IS 'AES-256-GCM encrypted OAuth client secret';
+COMMENT ON COLUMN servicenow_connections.oauth_client_secret_nonce IS 'Unique nonce used for OAuth secret encryption';
+
+-- -----------------------------------------------------------------------------
+-- 20260127_002_create_pull_tables.sql
+-- -----------------------------------------------------------------------------
+
 -- Migration: Create Pull Tables for Control Package Storage
 -- Feature: F2 - Control Package Pull
 -- Date: 2026-01-27
@@ -224,3 +308,58 @@ CREATE INDEX IF NOT EXISTS idx_pull_jobs_created_at
 
 COMMENT ON TABLE pull_jobs IS 'Tracks background pull job operations';
 COMMENT ON COLUMN pull_jobs.progress IS 'JSON object tracking pull progress for UI display';
+
+-- -----------------------------------------------------------------------------
+-- 20260127_003_create_audit_tables.sql
+-- -----------------------------------------------------------------------------
+
+-- Audit events table for tracking all sync operations
+CREATE TABLE IF NOT EXISTS audit_events (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    event_type VARCHAR(50) NOT NULL,
+    entity_type VARCHAR(50) NOT NULL,
+    entity_id VARCHAR(255) NOT NULL,
+    action VARCHAR(100) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'success',
+    details JSONB DEFAULT '{}',
+    user_email VARCHAR(255),
+    ip_address VARCHAR(45),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Indexes for common query patterns
+CREATE INDEX IF NOT EXISTS idx_audit_event_type ON audit_events(event_type);
+CREATE INDEX IF NOT EXISTS idx_audit_entity ON audit_events(entity_type, entity_id);
+CREATE INDEX IF NOT EXISTS idx_audit_created ON audit_events(created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_status ON audit_events(status);
+CREATE INDEX IF NOT EXISTS idx_audit_user ON audit_events(user_email) WHERE user_email IS NOT NULL;
+
+-- Full-text search on details
+CREATE INDEX IF NOT EXISTS idx_audit_details_gin ON audit_events USING GIN (details);
+
+-- Composite index for common filter combination
+CREATE INDEX IF NOT EXISTS idx_audit_common_filters ON audit_events(event_type, entity_type, created_at DESC);
+
+-- -----------------------------------------------------------------------------
+-- 20261015_001_add_statement_review.sql
+-- -----------------------------------------------------------------------------
+
+-- Statement review workflow (four-eyes approval before push)
+ALTER TABLE statements
+    ADD COLUMN IF NOT EXISTS review_status VARCHAR(20)
+        CHECK (review_status IN ('pending_review', 'approved', 'rejected')),
+    ADD COLUMN IF NOT EXISTS reviewed_by UUID,
+    ADD COLUMN IF NOT EXISTS reviewed_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS review_comment TEXT;
+
+-- Existing local modifications need review before they can be pushed
+UPDATE statements
+SET review_status = 'pending_review'
+WHERE is_modified = true AND review_status IS NULL;
+
+-- Index for finding statements awaiting review
+CREATE INDEX IF NOT EXISTS idx_statements_pending_review
+    ON statements (review_status)
+    WHERE review_status = 'pending_review';
+
+COMMENT ON COLUMN statements.review_status IS 'Review state of local changes: pending_review, approved, rejected (NULL when unmodified)';
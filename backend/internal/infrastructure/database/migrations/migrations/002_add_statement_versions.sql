@@ -0,0 +1,17 @@
+-- Migration: Statement version history
+-- Keeps a snapshot of each local edit so prior content can be inspected or restored.
+
+CREATE TABLE IF NOT EXISTS statement_versions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    statement_id UUID NOT NULL REFERENCES statements(id) ON DELETE CASCADE,
+    version INTEGER NOT NULL,
+    content TEXT NOT NULL,
+    created_by UUID,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- One row per statement version
+CREATE UNIQUE INDEX IF NOT EXISTS idx_statement_versions_statement_version
+    ON statement_versions (statement_id, version);
+
+COMMENT ON TABLE statement_versions IS 'Snapshots of statement local content, one per edit';
@@ -0,0 +1,13 @@
+-- Migration: Soft delete for systems
+-- Deleting a system previously cascaded to its controls and statements.
+-- Systems are now marked deleted instead so they can be restored.
+
+ALTER TABLE systems
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+
+-- Most queries only look at live systems
+CREATE INDEX IF NOT EXISTS idx_systems_not_deleted
+    ON systems (name)
+    WHERE deleted_at IS NULL;
+
+COMMENT ON COLUMN systems.deleted_at IS 'Soft-delete timestamp; NULL for active systems';
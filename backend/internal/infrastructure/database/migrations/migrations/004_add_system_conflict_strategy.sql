@@ -0,0 +1,9 @@
+-- Migration: Per-system conflict resolution strategy
+-- Overrides the global CONFLICT_STRATEGY for statements pulled into a system.
+-- NULL uses the global default.
+
+ALTER TABLE systems
+    ADD COLUMN IF NOT EXISTS conflict_resolution_strategy VARCHAR(20)
+        CHECK (conflict_resolution_strategy IN ('manual', 'keep_local', 'keep_remote'));
+
+COMMENT ON COLUMN systems.conflict_resolution_strategy IS 'Pull conflict strategy override: manual, keep_local or keep_remote; NULL uses the global default';
@@ -0,0 +1,27 @@
+-- Migration: Statement type lookup table
+-- statements.statement_type was free text. Types are now registered in
+-- statement_types and enforced with a foreign key.
+
+CREATE TABLE IF NOT EXISTS statement_types (
+    name VARCHAR(50) PRIMARY KEY,
+    description TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO statement_types (name, description) VALUES
+    ('implementation', 'How the control is implemented'),
+    ('assessment', 'How the control implementation is assessed'),
+    ('test_procedure', 'Steps used to test the control'),
+    ('evidence', 'Evidence that the control is operating')
+ON CONFLICT (name) DO NOTHING;
+
+-- Register any types already in use so existing rows satisfy the foreign key
+INSERT INTO statement_types (name)
+SELECT DISTINCT statement_type FROM statements WHERE statement_type IS NOT NULL
+ON CONFLICT (name) DO NOTHING;
+
+ALTER TABLE statements
+    ADD CONSTRAINT statements_statement_type_fkey
+    FOREIGN KEY (statement_type) REFERENCES statement_types (name);
+
+COMMENT ON TABLE statement_types IS 'Registered statement types; referenced by statements.statement_type';
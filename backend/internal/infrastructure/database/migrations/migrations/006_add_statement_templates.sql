@@ -0,0 +1,15 @@
+-- Migration: Statement templates
+-- Reusable statement boilerplate with {{variable}} placeholders that is
+-- rendered into a statement's local content.
+
+CREATE TABLE IF NOT EXISTS statement_templates (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(255) NOT NULL,
+    content_template TEXT NOT NULL,
+    variables JSONB NOT NULL DEFAULT '[]'::jsonb,
+    created_by UUID,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE statement_templates IS 'Reusable statement content with {{variable}} placeholders';
+COMMENT ON COLUMN statement_templates.variables IS 'JSON array of variable names required to render the template';
@@ -0,0 +1,10 @@
+-- Migration: Scripted REST API settings on connections
+-- Some instances expose GRC data through a Scripted REST API served at
+-- /api/<namespace>/<api_id> instead of the Table API.
+
+ALTER TABLE servicenow_connections
+    ADD COLUMN IF NOT EXISTS scripted_api_namespace VARCHAR(100),
+    ADD COLUMN IF NOT EXISTS scripted_api_id VARCHAR(100);
+
+COMMENT ON COLUMN servicenow_connections.scripted_api_namespace IS 'Scripted REST API namespace, e.g. x_acme_grc';
+COMMENT ON COLUMN servicenow_connections.scripted_api_id IS 'Scripted REST API ID within the namespace';
@@ -0,0 +1,21 @@
+-- Migration: Multiple labelled ServiceNow connections
+-- Connections are told apart by a unique label (e.g. prod, staging) instead
+-- of a single active row. Pull jobs record the connection they pull from.
+
+-- Inactive rows were replaced by a later save and never read again
+DELETE FROM servicenow_connections WHERE is_active IS DISTINCT FROM true;
+
+DROP INDEX IF EXISTS idx_connections_single_active;
+
+ALTER TABLE servicenow_connections
+    ADD COLUMN IF NOT EXISTS label TEXT NOT NULL DEFAULT 'default';
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_connections_label
+    ON servicenow_connections (label);
+
+COMMENT ON COLUMN servicenow_connections.label IS 'Unique connection name, e.g. prod or staging';
+
+ALTER TABLE pull_jobs
+    ADD COLUMN IF NOT EXISTS connection_label TEXT NOT NULL DEFAULT 'default';
+
+COMMENT ON COLUMN pull_jobs.connection_label IS 'Label of the ServiceNow connection the job pulls from';
@@ -0,0 +1,16 @@
+-- Migration: Statement attachments
+-- Evidence files uploaded to a statement's ServiceNow record. The file
+-- itself is stored in ServiceNow; only the attachment sys_id is kept here.
+
+CREATE TABLE IF NOT EXISTS statement_attachments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    statement_id UUID NOT NULL REFERENCES statements(id) ON DELETE CASCADE,
+    sn_sys_id VARCHAR(32) NOT NULL,
+    file_name VARCHAR(255) NOT NULL,
+    uploaded_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_statement_attachments_statement_id ON statement_attachments(statement_id);
+
+COMMENT ON TABLE statement_attachments IS 'Evidence files attached to statements in ServiceNow';
+COMMENT ON COLUMN statement_attachments.sn_sys_id IS 'sys_id of the ServiceNow sys_attachment record';
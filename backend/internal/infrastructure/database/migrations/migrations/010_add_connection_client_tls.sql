@@ -0,0 +1,12 @@
+-- Migration: Client TLS certificates on connections
+-- Some instances require mutual TLS in addition to API credentials. The PEM
+-- certificate and private key are stored encrypted like other credentials.
+
+ALTER TABLE servicenow_connections
+    ADD COLUMN IF NOT EXISTS client_cert_encrypted BYTEA,
+    ADD COLUMN IF NOT EXISTS client_cert_nonce BYTEA,
+    ADD COLUMN IF NOT EXISTS client_key_encrypted BYTEA,
+    ADD COLUMN IF NOT EXISTS client_key_nonce BYTEA;
+
+COMMENT ON COLUMN servicenow_connections.client_cert_encrypted IS 'AES-256-GCM encrypted PEM client certificate for mutual TLS';
+COMMENT ON COLUMN servicenow_connections.client_key_encrypted IS 'AES-256-GCM encrypted PEM private key of the client certificate';
@@ -0,0 +1,8 @@
+-- Migration: Email attribution for statement edits
+-- Deployments with email-based identity have no user UUID to record in
+-- modified_by, so the editor's email is stored alongside it.
+
+ALTER TABLE statements
+    ADD COLUMN IF NOT EXISTS modified_by_email VARCHAR(255);
+
+COMMENT ON COLUMN statements.modified_by_email IS 'Email of the user who last modified the local content, if known';
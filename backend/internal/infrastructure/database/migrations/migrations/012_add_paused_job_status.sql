@@ -0,0 +1,5 @@
+-- Migration: Paused pull jobs
+-- A paused job keeps its progress, including the IDs of the systems already
+-- pulled, and continues with the remaining systems when resumed.
+
+ALTER TYPE job_status ADD VALUE IF NOT EXISTS 'paused';
@@ -0,0 +1,8 @@
+-- Migration: Normalize control families
+-- Families are now stored trimmed and uppercase so that filtering by family
+-- matches regardless of the case ServiceNow used.
+
+UPDATE controls
+SET control_family = UPPER(TRIM(control_family))
+WHERE control_family IS NOT NULL
+  AND control_family <> UPPER(TRIM(control_family));
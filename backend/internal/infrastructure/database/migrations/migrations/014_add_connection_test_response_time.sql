@@ -0,0 +1,5 @@
+-- Migration: Connection test response time
+-- Records how long the last connection test took alongside its status and
+-- message.
+
+ALTER TABLE servicenow_connections ADD COLUMN IF NOT EXISTS last_test_response_time_ms BIGINT;
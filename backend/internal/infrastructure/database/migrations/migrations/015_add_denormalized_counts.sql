@@ -0,0 +1,99 @@
+-- Migration: Denormalized control and statement counts
+-- Systems and controls carry their statement (and control) counts so list
+-- queries no longer run correlated subqueries per row. Triggers keep the
+-- counts current; POST /api/v1/admin/recompute-counts rebuilds them.
+
+ALTER TABLE systems ADD COLUMN IF NOT EXISTS control_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE systems ADD COLUMN IF NOT EXISTS statement_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE systems ADD COLUMN IF NOT EXISTS modified_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE controls ADD COLUMN IF NOT EXISTS statement_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE controls ADD COLUMN IF NOT EXISTS modified_count INTEGER NOT NULL DEFAULT 0;
+
+-- Adds the given deltas to a control and to the system that owns it. When the
+-- control is already gone (a cascading delete) nothing is changed; the
+-- control's own delete trigger has removed its counts from the system.
+CREATE OR REPLACE FUNCTION adjust_statement_counts(p_control_id UUID, p_statements INTEGER, p_modified INTEGER)
+RETURNS void AS $$
+BEGIN
+    UPDATE controls
+    SET statement_count = statement_count + p_statements,
+        modified_count = modified_count + p_modified
+    WHERE id = p_control_id;
+
+    UPDATE systems s
+    SET statement_count = s.statement_count + p_statements,
+        modified_count = s.modified_count + p_modified
+    FROM controls c
+    WHERE c.id = p_control_id AND s.id = c.system_id;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION statements_maintain_counts()
+RETURNS trigger AS $$
+BEGIN
+    IF TG_OP IN ('UPDATE', 'DELETE') THEN
+        PERFORM adjust_statement_counts(OLD.control_id, -1, -(COALESCE(OLD.is_modified, false)::int));
+    END IF;
+    IF TG_OP IN ('INSERT', 'UPDATE') THEN
+        PERFORM adjust_statement_counts(NEW.control_id, 1, COALESCE(NEW.is_modified, false)::int);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION controls_maintain_counts()
+RETURNS trigger AS $$
+BEGIN
+    IF TG_OP IN ('UPDATE', 'DELETE') THEN
+        UPDATE systems
+        SET control_count = control_count - 1,
+            statement_count = statement_count - OLD.statement_count,
+            modified_count = modified_count - OLD.modified_count
+        WHERE id = OLD.system_id;
+    END IF;
+    IF TG_OP IN ('INSERT', 'UPDATE') THEN
+        UPDATE systems
+        SET control_count = control_count + 1,
+            statement_count = statement_count + NEW.statement_count,
+            modified_count = modified_count + NEW.modified_count
+        WHERE id = NEW.system_id;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_statements_counts ON statements;
+CREATE TRIGGER trg_statements_counts
+    AFTER INSERT OR DELETE OR UPDATE OF control_id, is_modified ON statements
+    FOR EACH ROW EXECUTE FUNCTION statements_maintain_counts();
+
+-- Count changes on a control only matter to its system when the control
+-- moves; the statement trigger already updates both rows.
+DROP TRIGGER IF EXISTS trg_controls_counts ON controls;
+CREATE TRIGGER trg_controls_counts
+    AFTER INSERT OR DELETE OR UPDATE OF system_id ON controls
+    FOR EACH ROW EXECUTE FUNCTION controls_maintain_counts();
+
+-- Backfill from the existing rows
+UPDATE controls c
+SET statement_count = COALESCE(st.total, 0),
+    modified_count = COALESCE(st.modified, 0)
+FROM controls c2
+LEFT JOIN (
+    SELECT control_id, COUNT(*) AS total, COUNT(*) FILTER (WHERE is_modified) AS modified
+    FROM statements
+    GROUP BY control_id
+) st ON st.control_id = c2.id
+WHERE c.id = c2.id;
+
+UPDATE systems s
+SET control_count = COALESCE(agg.controls, 0),
+    statement_count = COALESCE(agg.statements, 0),
+    modified_count = COALESCE(agg.modified, 0)
+FROM systems s2
+LEFT JOIN (
+    SELECT system_id, COUNT(*) AS controls, SUM(statement_count) AS statements, SUM(modified_count) AS modified
+    FROM controls
+    GROUP BY system_id
+) agg ON agg.system_id = s2.id
+WHERE s.id = s2.id;
@@ -0,0 +1,11 @@
+-- Migration: Discovery cache
+-- Holds the last system list fetched from ServiceNow so discovery can answer
+-- from it while ServiceNow is slow. The table has at most one row.
+
+CREATE TABLE IF NOT EXISTS discovery_cache (
+    id BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (id),
+    run_at TIMESTAMPTZ NOT NULL,
+    systems_json JSONB NOT NULL
+);
+
+COMMENT ON TABLE discovery_cache IS 'Single-row cache of the last ServiceNow system discovery';
@@ -0,0 +1,14 @@
+-- Migration: Per-system import mode
+-- Read-only systems mirror ServiceNow: their statements cannot be edited
+-- locally or pushed back.
+
+DO $$ BEGIN
+    CREATE TYPE system_import_mode AS ENUM ('editable', 'read_only');
+EXCEPTION
+    WHEN duplicate_object THEN null;
+END $$;
+
+ALTER TABLE systems
+    ADD COLUMN IF NOT EXISTS import_mode system_import_mode NOT NULL DEFAULT 'editable';
+
+COMMENT ON COLUMN systems.import_mode IS 'editable allows local edits and pushes; read_only blocks both';
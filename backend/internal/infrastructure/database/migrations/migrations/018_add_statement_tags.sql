@@ -0,0 +1,15 @@
+-- Migration: Statement tags
+-- Free-form labels users attach to statements, e.g. "gdpr" or "hipaa".
+
+CREATE TABLE IF NOT EXISTS statement_tags (
+    statement_id UUID NOT NULL REFERENCES statements(id) ON DELETE CASCADE,
+    tag TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_by UUID,
+    CONSTRAINT statement_tags_statement_id_tag_key UNIQUE (statement_id, tag)
+);
+
+CREATE INDEX IF NOT EXISTS idx_statement_tags_tag ON statement_tags(tag);
+
+COMMENT ON TABLE statement_tags IS 'User-defined labels on statements';
+COMMENT ON COLUMN statement_tags.tag IS 'Lowercase label, unique per statement';
@@ -0,0 +1,12 @@
+-- Migration: System pull locks
+-- A pull job holds a system's row while it pulls the system, so concurrent
+-- jobs do not pull the same system in parallel. Rows older than 30 minutes
+-- are treated as left behind by a crashed process and may be taken over.
+
+CREATE TABLE IF NOT EXISTS system_pull_locks (
+    system_id UUID PRIMARY KEY REFERENCES systems(id) ON DELETE CASCADE,
+    locked_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    job_id UUID NOT NULL
+);
+
+COMMENT ON TABLE system_pull_locks IS 'Systems currently being pulled and the pull job pulling each';
@@ -0,0 +1,7 @@
+-- Migration: Statement last push job
+-- Records which push job last pushed each statement to ServiceNow, so
+-- auditors can trace a statement's ServiceNow content to a push.
+
+ALTER TABLE statements ADD COLUMN IF NOT EXISTS last_push_by UUID;
+
+COMMENT ON COLUMN statements.last_push_by IS 'ID of the push job that last pushed the statement';
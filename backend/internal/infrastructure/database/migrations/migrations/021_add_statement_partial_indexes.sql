@@ -0,0 +1,21 @@
+-- migrate:no-transaction
+-- Migration: Partial indexes for modified and conflict lists
+-- Replaces the single-column indexes from 001 with partial indexes covering
+-- the ListModified and ListConflicts sort orders. Indexes are built
+-- concurrently so statements stay writable; each is dropped first in case
+-- an interrupted build left it invalid.
+
+DROP INDEX CONCURRENTLY IF EXISTS idx_statements_modified;
+
+CREATE INDEX CONCURRENTLY idx_statements_modified
+    ON statements (control_id, modified_at DESC)
+    WHERE is_modified = true;
+
+DROP INDEX CONCURRENTLY IF EXISTS idx_statements_conflict;
+
+CREATE INDEX CONCURRENTLY idx_statements_conflict
+    ON statements (created_at DESC)
+    WHERE sync_status = 'conflict';
+
+-- Superseded by idx_statements_conflict
+DROP INDEX CONCURRENTLY IF EXISTS idx_statements_conflicts;
@@ -0,0 +1,8 @@
+-- Migration: Application scope on connections
+-- Instances with several application scopes can limit GRC table queries
+-- to the records of one scope.
+
+ALTER TABLE servicenow_connections
+    ADD COLUMN IF NOT EXISTS scope VARCHAR(100);
+
+COMMENT ON COLUMN servicenow_connections.scope IS 'sys_id of the application scope GRC table queries are limited to; NULL queries every scope';
@@ -0,0 +1,17 @@
+-- migrate:no-transaction
+-- Migration: Composite index for the audit log query
+-- The audit log is listed newest first and usually filtered by entity and
+-- event type. Scanning created_at in index order with the type columns in
+-- the key lets the planner stop after one page instead of sorting every
+-- matching row. Status and user email are included so they can be read
+-- from the index. The index is built concurrently so audit events can still
+-- be written, and dropped first in case an interrupted build left it invalid.
+
+DROP INDEX CONCURRENTLY IF EXISTS idx_audit_events_created_entity;
+
+CREATE INDEX CONCURRENTLY idx_audit_events_created_entity
+    ON audit_events (created_at DESC, entity_type, event_type)
+    INCLUDE (status, user_email);
+
+-- Superseded by idx_audit_events_created_entity
+DROP INDEX CONCURRENTLY IF EXISTS idx_audit_created;
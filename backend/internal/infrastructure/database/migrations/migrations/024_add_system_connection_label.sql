@@ -0,0 +1,8 @@
+-- Migration: Connection a system was pulled from
+-- Single-statement re-pulls and freshness checks read from the connection
+-- the system was last pulled from rather than the default one.
+
+ALTER TABLE systems
+    ADD COLUMN IF NOT EXISTS connection_label TEXT NOT NULL DEFAULT 'default';
+
+COMMENT ON COLUMN systems.connection_label IS 'Label of the ServiceNow connection the system was last pulled from';
@@ -0,0 +1,123 @@
+package migrations
+
+import (
+	"io/fs"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoad_OrdersByVersion(t *testing.T) {
+	fsys := fstest.MapFS{
+		"010_add_index.sql":  {Data: []byte("CREATE INDEX x ON y (z);")},
+		"002_add_column.sql": {Data: []byte("ALTER TABLE y ADD COLUMN z TEXT;")},
+		"001_initial.sql":    {Data: []byte("CREATE TABLE y (id INT);")},
+		"README.md":          {Data: []byte("not a migration")},
+	}
+
+	migrations, err := Load(fsys)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(migrations) != 3 {
+		t.Fatalf("expected 3 migrations, got %d", len(migrations))
+	}
+	expected := []int{1, 2, 10}
+	for i, m := range migrations {
+		if m.Version != expected[i] {
+			t.Errorf("migration %d: expected version %d, got %d", i, expected[i], m.Version)
+		}
+	}
+	if migrations[0].SQL != "CREATE TABLE y (id INT);" {
+		t.Errorf("unexpected SQL for %s: %q", migrations[0].Name, migrations[0].SQL)
+	}
+}
+
+func TestLoad_InvalidNames(t *testing.T) {
+	tests := []struct {
+		name string
+		fsys fstest.MapFS
+	}{
+		{"missing prefix", fstest.MapFS{"initial.sql": {Data: []byte("")}}},
+		{"non-numeric prefix", fstest.MapFS{"abc_initial.sql": {Data: []byte("")}}},
+		{"zero version", fstest.MapFS{"000_initial.sql": {Data: []byte("")}}},
+		{"duplicate version", fstest.MapFS{
+			"001_initial.sql": {Data: []byte("")},
+			"1_other.sql":     {Data: []byte("")},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Load(tt.fsys); err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestEmbeddedMigrations(t *testing.T) {
+	sub, err := fs.Sub(embedded, "migrations")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	migrations, err := Load(sub)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(migrations) < 2 {
+		t.Fatalf("expected at least 2 embedded migrations, got %d", len(migrations))
+	}
+	if migrations[0].Name != "001_initial.sql" {
+		t.Errorf("expected first migration 001_initial.sql, got %s", migrations[0].Name)
+	}
+	for i, m := range migrations {
+		if m.Version != i+1 {
+			t.Errorf("expected contiguous versions, got %d at position %d", m.Version, i)
+		}
+	}
+}
+
+func TestLoad_NoTransaction(t *testing.T) {
+	fsys := fstest.MapFS{
+		"001_initial.sql":   {Data: []byte("CREATE TABLE y (id INT);")},
+		"002_add_index.sql": {Data: []byte("-- migrate:no-transaction\nCREATE INDEX CONCURRENTLY x ON y (id);")},
+	}
+
+	migrations, err := Load(fsys)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if migrations[0].NoTransaction {
+		t.Error("expected 001 to run in a transaction")
+	}
+	if !migrations[1].NoTransaction {
+		t.Error("expected 002 to run outside a transaction")
+	}
+}
+
+func TestSplitStatements(t *testing.T) {
+	sql := `-- migrate:no-transaction
+-- Drop first; a failed build leaves an invalid index
+DROP INDEX CONCURRENTLY IF EXISTS x;
+
+CREATE INDEX CONCURRENTLY x
+    ON y (created_at DESC)
+    WHERE status = 'conflict';
+`
+	got := splitStatements(sql)
+	want := []string{
+		"DROP INDEX CONCURRENTLY IF EXISTS x",
+		"CREATE INDEX CONCURRENTLY x\n    ON y (created_at DESC)\n    WHERE status = 'conflict'",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d statements, got %q", len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("statement %d: expected %q, got %q", i, want[i], got[i])
+		}
+	}
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -14,19 +17,22 @@ import (
 
 // PullRepository implements pull.Repository using PostgreSQL.
 type PullRepository struct {
-	db *sql.DB
+	db       *sql.DB
+	timeouts QueryTimeouts
 }
 
 // NewPullRepository creates a new pull repository.
-func NewPullRepository(db *sql.DB) *PullRepository {
-	return &PullRepository{db: db}
+func NewPullRepository(db *sql.DB, timeouts QueryTimeouts) *PullRepository {
+	return &PullRepository{db: db, timeouts: timeouts}
 }
 
 // Create creates a new pull job.
 func (r *PullRepository) Create(ctx context.Context, input pull.CreateInput) (*pull.Job, error) {
+	ctx, cancel := r.timeouts.singleRow(ctx)
+	defer cancel()
+
 	progress := pull.Progress{
-		TotalSystems:    len(input.SystemIDs),
-		Errors:          make([]string, 0),
+		TotalSystems: len(input.SystemIDs),
 	}
 	progressJSON, err := json.Marshal(progress)
 	if err != nil {
@@ -40,11 +46,13 @@ func (r *PullRepository) Create(ctx context.Context, input pull.CreateInput) (*p
 		Progress:  progress,
 		CreatedAt: time.Now(),
 		CreatedBy: input.CreatedBy,
+
+		ConnectionLabel: input.ConnectionLabel,
 	}
 
 	query := `
-		INSERT INTO pull_jobs (id, system_ids, status, progress, created_at, created_by)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		INSERT INTO pull_jobs (id, system_ids, status, progress, created_at, created_by, connection_label)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
 	`
 
 	_, err = r.db.ExecContext(ctx, query,
@@ -54,6 +62,7 @@ func (r *PullRepository) Create(ctx context.Context, input pull.CreateInput) (*p
 		progressJSON,
 		job.CreatedAt,
 		job.CreatedBy,
+		job.ConnectionLabel,
 	)
 	if err != nil {
 		return nil, err
@@ -64,9 +73,12 @@ func (r *PullRepository) Create(ctx context.Context, input pull.CreateInput) (*p
 
 // GetByID retrieves a pull job by ID.
 func (r *PullRepository) GetByID(ctx context.Context, id uuid.UUID) (*pull.Job, error) {
+	ctx, cancel := r.timeouts.singleRow(ctx)
+	defer cancel()
+
 	query := `
 		SELECT id, system_ids, status, progress, error_message,
-		       started_at, completed_at, created_at, created_by
+		       started_at, completed_at, created_at, created_by, connection_label
 		FROM pull_jobs
 		WHERE id = $1
 	`
@@ -88,6 +100,7 @@ func (r *PullRepository) GetByID(ctx context.Context, id uuid.UUID) (*pull.Job,
 		&completedAt,
 		&job.CreatedAt,
 		&createdBy,
+		&job.ConnectionLabel,
 	)
 	if err == sql.ErrNoRows {
 		return nil, nil
@@ -126,6 +139,9 @@ func (r *PullRepository) GetByID(ctx context.Context, id uuid.UUID) (*pull.Job,
 
 // Update updates a pull job's status and progress.
 func (r *PullRepository) Update(ctx context.Context, input pull.UpdateInput) (*pull.Job, error) {
+	ctx, cancel := r.timeouts.singleRow(ctx)
+	defer cancel()
+
 	progressJSON, err := json.Marshal(input.Progress)
 	if err != nil {
 		return nil, err
@@ -147,6 +163,9 @@ func (r *PullRepository) Update(ctx context.Context, input pull.UpdateInput) (*p
 
 // UpdateProgress updates just the progress of a running job.
 func (r *PullRepository) UpdateProgress(ctx context.Context, id uuid.UUID, progress pull.Progress) error {
+	ctx, cancel := r.timeouts.singleRow(ctx)
+	defer cancel()
+
 	progressJSON, err := json.Marshal(progress)
 	if err != nil {
 		return err
@@ -164,6 +183,9 @@ func (r *PullRepository) UpdateProgress(ctx context.Context, id uuid.UUID, progr
 
 // SetStatus sets the job status with optional error message.
 func (r *PullRepository) SetStatus(ctx context.Context, id uuid.UUID, status pull.JobStatus, errorMsg string) error {
+	ctx, cancel := r.timeouts.singleRow(ctx)
+	defer cancel()
+
 	var query string
 	var args []interface{}
 
@@ -195,52 +217,186 @@ func (r *PullRepository) SetStatus(ctx context.Context, id uuid.UUID, status pul
 	return err
 }
 
-// HasActiveJob returns true if there's an active (pending/running) job.
-func (r *PullRepository) HasActiveJob(ctx context.Context) (bool, error) {
+// PausePendingJob sets a pending job to paused. Returns false if the job is
+// no longer pending, for example because a dispatcher claimed it.
+func (r *PullRepository) PausePendingJob(ctx context.Context, id uuid.UUID) (bool, error) {
+	ctx, cancel := r.timeouts.singleRow(ctx)
+	defer cancel()
+
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE pull_jobs
+		SET status = 'paused'
+		WHERE id = $1 AND status = 'pending'
+	`, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to pause pull job: %w", err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n == 1, nil
+}
+
+// ClaimPendingJobs marks up to limit pending jobs as running and returns
+// them oldest first. SKIP LOCKED keeps concurrent dispatchers from claiming
+// the same job.
+func (r *PullRepository) ClaimPendingJobs(ctx context.Context, limit int) ([]pull.Job, error) {
+	ctx, cancel := r.timeouts.list(ctx)
+	defer cancel()
+
 	query := `
-		SELECT EXISTS(
-			SELECT 1 FROM pull_jobs
-			WHERE status IN ('pending', 'running')
+		WITH next AS (
+			SELECT id FROM pull_jobs
+			WHERE status = 'pending'
+			ORDER BY created_at ASC
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
 		)
+		UPDATE pull_jobs
+		SET status = 'running', started_at = NOW()
+		FROM next
+		WHERE pull_jobs.id = next.id
+		RETURNING pull_jobs.id, pull_jobs.system_ids, pull_jobs.status, pull_jobs.progress, pull_jobs.error_message,
+		          pull_jobs.started_at, pull_jobs.completed_at, pull_jobs.created_at, pull_jobs.created_by,
+		          pull_jobs.connection_label
 	`
 
-	var exists bool
-	err := r.db.QueryRowContext(ctx, query).Scan(&exists)
-	return exists, err
+	rows, err := r.db.QueryContext(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim pending pull jobs: %w", err)
+	}
+	defer rows.Close()
+
+	jobs, err := scanPullJobs(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	// UPDATE ... RETURNING does not preserve the CTE order
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].CreatedAt.Before(jobs[j].CreatedAt)
+	})
+	return jobs, nil
 }
 
-// List retrieves pull jobs with optional status filter.
-func (r *PullRepository) List(ctx context.Context, status *pull.JobStatus, limit int) ([]pull.Job, error) {
-	var query string
+// CountActiveJobs returns the number of pending or running jobs.
+func (r *PullRepository) CountActiveJobs(ctx context.Context) (int, error) {
+	ctx, cancel := r.timeouts.list(ctx)
+	defer cancel()
+
+	query := `SELECT COUNT(*) FROM pull_jobs WHERE status IN ('pending', 'running')`
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, query).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count active pull jobs: %w", err)
+	}
+	return count, nil
+}
+
+// systemPullLockTTL is how long a system pull lock is held before another
+// job may take it over, so a lock left by a crashed process does not block
+// the system forever.
+const systemPullLockTTL = 30 * time.Minute
+
+// AcquireSystemLock locks a system for the given job. Returns false if
+// another job holds a lock on the system that has not expired.
+func (r *PullRepository) AcquireSystemLock(ctx context.Context, systemID, jobID uuid.UUID) (bool, error) {
+	ctx, cancel := r.timeouts.singleRow(ctx)
+	defer cancel()
+
+	// Clear an expired lock first; if two jobs race here, the insert below
+	// still lets only one of them in
+	_, err := r.db.ExecContext(ctx, `
+		DELETE FROM system_pull_locks
+		WHERE system_id = $1 AND locked_at < NOW() - $2 * INTERVAL '1 second'
+	`, systemID, systemPullLockTTL.Seconds())
+	if err != nil {
+		return false, fmt.Errorf("failed to clear expired system pull lock: %w", err)
+	}
+
+	result, err := r.db.ExecContext(ctx, `
+		INSERT INTO system_pull_locks (system_id, locked_at, job_id)
+		VALUES ($1, NOW(), $2)
+		ON CONFLICT (system_id) DO NOTHING
+	`, systemID, jobID)
+	if err != nil {
+		return false, fmt.Errorf("failed to acquire system pull lock: %w", err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n == 1, nil
+}
+
+// ReleaseSystemLock releases the job's lock on a system. It does nothing if
+// the lock expired and was taken over by another job.
+func (r *PullRepository) ReleaseSystemLock(ctx context.Context, systemID, jobID uuid.UUID) error {
+	ctx, cancel := r.timeouts.singleRow(ctx)
+	defer cancel()
+
+	_, err := r.db.ExecContext(ctx,
+		`DELETE FROM system_pull_locks WHERE system_id = $1 AND job_id = $2`, systemID, jobID)
+	if err != nil {
+		return fmt.Errorf("failed to release system pull lock: %w", err)
+	}
+	return nil
+}
+
+// List retrieves pull jobs matching the filter, newest first.
+func (r *PullRepository) List(ctx context.Context, filter pull.PullListFilter) ([]pull.Job, error) {
+	ctx, cancel := r.timeouts.list(ctx)
+	defer cancel()
+
+	var conditions []string
 	var args []interface{}
+	argNum := 1
 
-	if status != nil {
-		query = `
-			SELECT id, system_ids, status, progress, error_message,
-			       started_at, completed_at, created_at, created_by
-			FROM pull_jobs
-			WHERE status = $1
-			ORDER BY created_at DESC
-			LIMIT $2
-		`
-		args = []interface{}{*status, limit}
-	} else {
-		query = `
-			SELECT id, system_ids, status, progress, error_message,
-			       started_at, completed_at, created_at, created_by
-			FROM pull_jobs
-			ORDER BY created_at DESC
-			LIMIT $1
-		`
-		args = []interface{}{limit}
+	if filter.Status != nil {
+		conditions = append(conditions, fmt.Sprintf("status = $%d", argNum))
+		args = append(args, *filter.Status)
+		argNum++
+	}
+
+	if filter.SystemID != nil {
+		conditions = append(conditions, fmt.Sprintf("$%d = ANY(system_ids)", argNum))
+		args = append(args, *filter.SystemID)
+		argNum++
+	}
+
+	if filter.Since != nil {
+		conditions = append(conditions, fmt.Sprintf("created_at >= $%d", argNum))
+		args = append(args, *filter.Since)
+		argNum++
 	}
 
+	whereClause := ""
+	if len(conditions) > 0 {
+		whereClause = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	query := fmt.Sprintf(`
+		SELECT id, system_ids, status, progress, error_message,
+		       started_at, completed_at, created_at, created_by, connection_label
+		FROM pull_jobs
+		%s
+		ORDER BY created_at DESC
+		LIMIT $%d
+	`, whereClause, argNum)
+	args = append(args, filter.Limit)
+
 	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
+	return scanPullJobs(rows)
+}
+
+// scanPullJobs scans pull job rows selected in the column order used by List.
+func scanPullJobs(rows *sql.Rows) ([]pull.Job, error) {
 	var jobs []pull.Job
 	for rows.Next() {
 		var job pull.Job
@@ -260,6 +416,7 @@ func (r *PullRepository) List(ctx context.Context, status *pull.JobStatus, limit
 			&completedAt,
 			&job.CreatedAt,
 			&createdBy,
+			&job.ConnectionLabel,
 		)
 		if err != nil {
 			return nil, err
@@ -0,0 +1,101 @@
+package database
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// insertTestSystem creates a system that is deleted when the test ends.
+func insertTestSystem(t *testing.T, repo *PullRepository) uuid.UUID {
+	t.Helper()
+	ctx := context.Background()
+
+	var systemID uuid.UUID
+	err := repo.db.QueryRowContext(ctx,
+		`INSERT INTO systems (sn_sys_id, name) VALUES ($1, 'Lock Test') RETURNING id`,
+		strings.ReplaceAll(uuid.NewString(), "-", ""),
+	).Scan(&systemID)
+	if err != nil {
+		t.Fatalf("failed to insert system: %v", err)
+	}
+	t.Cleanup(func() { repo.db.ExecContext(ctx, `DELETE FROM systems WHERE id = $1`, systemID) })
+	return systemID
+}
+
+func TestPullRepository_SystemLock_ConcurrentAcquire(t *testing.T) {
+	repo := NewPullRepository(openTestDB(t), QueryTimeouts{})
+	systemID := insertTestSystem(t, repo)
+	ctx := context.Background()
+
+	const jobs = 10
+	jobIDs := make([]uuid.UUID, jobs)
+	acquired := make([]bool, jobs)
+	var wg sync.WaitGroup
+	for i := range jobIDs {
+		jobIDs[i] = uuid.New()
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ok, err := repo.AcquireSystemLock(ctx, systemID, jobIDs[i])
+			if err != nil {
+				t.Errorf("job %d: failed to acquire lock: %v", i, err)
+			}
+			acquired[i] = ok
+		}()
+	}
+	wg.Wait()
+
+	holder := -1
+	for i, ok := range acquired {
+		if !ok {
+			continue
+		}
+		if holder >= 0 {
+			t.Fatalf("jobs %d and %d both acquired the lock", holder, i)
+		}
+		holder = i
+	}
+	if holder < 0 {
+		t.Fatal("expected one job to acquire the lock")
+	}
+
+	// Only the holder can release the lock
+	other := jobIDs[(holder+1)%jobs]
+	if err := repo.ReleaseSystemLock(ctx, systemID, other); err != nil {
+		t.Fatalf("failed to release lock: %v", err)
+	}
+	if ok, _ := repo.AcquireSystemLock(ctx, systemID, other); ok {
+		t.Fatal("expected lock to survive a release by another job")
+	}
+
+	if err := repo.ReleaseSystemLock(ctx, systemID, jobIDs[holder]); err != nil {
+		t.Fatalf("failed to release lock: %v", err)
+	}
+	if ok, err := repo.AcquireSystemLock(ctx, systemID, other); err != nil || !ok {
+		t.Fatalf("expected lock free after release, got %v, %v", ok, err)
+	}
+	repo.ReleaseSystemLock(ctx, systemID, other)
+}
+
+func TestPullRepository_SystemLock_Expired(t *testing.T) {
+	repo := NewPullRepository(openTestDB(t), QueryTimeouts{})
+	systemID := insertTestSystem(t, repo)
+	ctx := context.Background()
+
+	crashed, next := uuid.New(), uuid.New()
+	_, err := repo.db.ExecContext(ctx,
+		`INSERT INTO system_pull_locks (system_id, locked_at, job_id) VALUES ($1, NOW() - INTERVAL '31 minutes', $2)`,
+		systemID, crashed)
+	if err != nil {
+		t.Fatalf("failed to insert stale lock: %v", err)
+	}
+
+	if ok, err := repo.AcquireSystemLock(ctx, systemID, next); err != nil || !ok {
+		t.Fatalf("expected expired lock to be taken over, got %v, %v", ok, err)
+	}
+	repo.ReleaseSystemLock(ctx, systemID, next)
+}
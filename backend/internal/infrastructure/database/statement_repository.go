@@ -3,32 +3,47 @@ package database
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 
 	"github.com/controlcrud/backend/internal/domain/statement"
 )
 
+// Constraints on statement types, see migration 005.
+const (
+	statementTypeFKey = "statements_statement_type_fkey"
+	statementTypePKey = "statement_types_pkey"
+)
+
 // StatementRepository implements statement.Repository using PostgreSQL.
 type StatementRepository struct {
-	db *sql.DB
+	db       *sql.DB
+	timeouts QueryTimeouts
 }
 
 // NewStatementRepository creates a new statement repository.
-func NewStatementRepository(db *sql.DB) *StatementRepository {
-	return &StatementRepository{db: db}
+func NewStatementRepository(db *sql.DB, timeouts QueryTimeouts) *StatementRepository {
+	return &StatementRepository{db: db, timeouts: timeouts}
 }
 
 // GetByID retrieves a statement by its internal ID.
 func (r *StatementRepository) GetByID(ctx context.Context, id uuid.UUID) (*statement.Statement, error) {
+	ctx, cancel := r.timeouts.singleRow(ctx)
+	defer cancel()
+
 	query := `
 		SELECT id, control_id, sn_sys_id, statement_type,
-		       remote_content, remote_updated_at, local_content, is_modified, modified_at, modified_by,
+		       remote_content, remote_updated_at, local_content, is_modified, modified_at, modified_by, modified_by_email,
 		       sync_status, conflict_resolved_at, conflict_resolved_by,
-		       sn_updated_on, last_pull_at, last_push_at, created_at, updated_at
+		       review_status, reviewed_by, reviewed_at, review_comment,
+		       sn_updated_on, last_pull_at, last_push_at, last_push_by, created_at, updated_at,
+		       ARRAY(SELECT tag FROM statement_tags WHERE statement_id = statements.id ORDER BY tag)
 		FROM statements
 		WHERE id = $1
 	`
@@ -38,11 +53,16 @@ func (r *StatementRepository) GetByID(ctx context.Context, id uuid.UUID) (*state
 
 // GetBySNSysID retrieves a statement by control ID and ServiceNow sys_id.
 func (r *StatementRepository) GetBySNSysID(ctx context.Context, controlID uuid.UUID, snSysID string) (*statement.Statement, error) {
+	ctx, cancel := r.timeouts.singleRow(ctx)
+	defer cancel()
+
 	query := `
 		SELECT id, control_id, sn_sys_id, statement_type,
-		       remote_content, remote_updated_at, local_content, is_modified, modified_at, modified_by,
+		       remote_content, remote_updated_at, local_content, is_modified, modified_at, modified_by, modified_by_email,
 		       sync_status, conflict_resolved_at, conflict_resolved_by,
-		       sn_updated_on, last_pull_at, last_push_at, created_at, updated_at
+		       review_status, reviewed_by, reviewed_at, review_comment,
+		       sn_updated_on, last_pull_at, last_push_at, last_push_by, created_at, updated_at,
+		       ARRAY(SELECT tag FROM statement_tags WHERE statement_id = statements.id ORDER BY tag)
 		FROM statements
 		WHERE control_id = $1 AND sn_sys_id = $2
 	`
@@ -52,6 +72,9 @@ func (r *StatementRepository) GetBySNSysID(ctx context.Context, controlID uuid.U
 
 // List retrieves statements with pagination. Filters by control_id OR system_id (joins through controls).
 func (r *StatementRepository) List(ctx context.Context, params statement.ListParams) (*statement.ListResult, error) {
+	ctx, cancel := r.timeouts.list(ctx)
+	defer cancel()
+
 	var conditions []string
 	var args []interface{}
 	argNum := 1
@@ -75,9 +98,20 @@ func (r *StatementRepository) List(ctx context.Context, params statement.ListPar
 		argNum++
 	}
 
-	if params.Search != "" {
-		conditions = append(conditions, fmt.Sprintf("(s.remote_content ILIKE $%d OR s.local_content ILIKE $%d)", argNum, argNum))
-		args = append(args, "%"+params.Search+"%")
+	for _, tag := range params.Tags {
+		conditions = append(conditions, fmt.Sprintf("s.id IN (SELECT statement_id FROM statement_tags WHERE tag = $%d)", argNum))
+		args = append(args, tag)
+		argNum++
+	}
+
+	for _, term := range params.SearchTerms {
+		// COALESCE so that a NULL column does not hide a row from exclusions
+		match := fmt.Sprintf("(COALESCE(s.remote_content, '') ILIKE $%d OR COALESCE(s.local_content, '') ILIKE $%d)", argNum, argNum)
+		if term.Exclude {
+			match = "NOT " + match
+		}
+		conditions = append(conditions, match)
+		args = append(args, "%"+term.Text+"%")
 		argNum++
 	}
 
@@ -111,9 +145,11 @@ func (r *StatementRepository) List(ctx context.Context, params statement.ListPar
 	// Fetch statements
 	query := fmt.Sprintf(`
 		SELECT s.id, s.control_id, s.sn_sys_id, s.statement_type,
-		       s.remote_content, s.remote_updated_at, s.local_content, s.is_modified, s.modified_at, s.modified_by,
+		       s.remote_content, s.remote_updated_at, s.local_content, s.is_modified, s.modified_at, s.modified_by, s.modified_by_email,
 		       s.sync_status, s.conflict_resolved_at, s.conflict_resolved_by,
-		       s.sn_updated_on, s.last_pull_at, s.last_push_at, s.created_at, s.updated_at
+		       s.review_status, s.reviewed_by, s.reviewed_at, s.review_comment,
+		       s.sn_updated_on, s.last_pull_at, s.last_push_at, s.last_push_by, s.created_at, s.updated_at,
+		       ARRAY(SELECT tag FROM statement_tags WHERE statement_id = s.id ORDER BY tag)
 		%s
 		%s
 		ORDER BY s.created_at ASC
@@ -148,11 +184,16 @@ func (r *StatementRepository) List(ctx context.Context, params statement.ListPar
 
 // ListByControl retrieves all statements for a control.
 func (r *StatementRepository) ListByControl(ctx context.Context, controlID uuid.UUID) ([]statement.Statement, error) {
+	ctx, cancel := r.timeouts.list(ctx)
+	defer cancel()
+
 	query := `
 		SELECT id, control_id, sn_sys_id, statement_type,
-		       remote_content, remote_updated_at, local_content, is_modified, modified_at, modified_by,
+		       remote_content, remote_updated_at, local_content, is_modified, modified_at, modified_by, modified_by_email,
 		       sync_status, conflict_resolved_at, conflict_resolved_by,
-		       sn_updated_on, last_pull_at, last_push_at, created_at, updated_at
+		       review_status, reviewed_by, reviewed_at, review_comment,
+		       sn_updated_on, last_pull_at, last_push_at, last_push_by, created_at, updated_at,
+		       ARRAY(SELECT tag FROM statement_tags WHERE statement_id = statements.id ORDER BY tag)
 		FROM statements
 		WHERE control_id = $1
 		ORDER BY created_at ASC
@@ -176,51 +217,64 @@ func (r *StatementRepository) ListByControl(ctx context.Context, controlID uuid.
 	return statements, nil
 }
 
-// ListModified retrieves all statements with local modifications.
-func (r *StatementRepository) ListModified(ctx context.Context) ([]statement.Statement, error) {
-	query := `
-		SELECT id, control_id, sn_sys_id, statement_type,
-		       remote_content, remote_updated_at, local_content, is_modified, modified_at, modified_by,
-		       sync_status, conflict_resolved_at, conflict_resolved_by,
-		       sn_updated_on, last_pull_at, last_push_at, created_at, updated_at
-		FROM statements
-		WHERE is_modified = true
-		ORDER BY modified_at DESC
-	`
+// ListModified retrieves statements with local modifications, most recently
+// modified first.
+func (r *StatementRepository) ListModified(ctx context.Context, params statement.ModifiedListParams) (*statement.ListResult, error) {
+	return r.listFiltered(ctx, "s.is_modified = true", "s.modified_at DESC, s.id", params)
+}
 
-	rows, err := r.db.QueryContext(ctx, query)
-	if err != nil {
-		return nil, fmt.Errorf("failed to list modified statements: %w", err)
+// ListConflicts retrieves statements with sync conflicts, newest first.
+func (r *StatementRepository) ListConflicts(ctx context.Context, params statement.ModifiedListParams) (*statement.ListResult, error) {
+	return r.listFiltered(ctx, "s.sync_status = 'conflict'", "s.created_at DESC, s.id", params)
+}
+
+// listFiltered returns a page of statements matching condition, optionally
+// limited to one system's controls.
+func (r *StatementRepository) listFiltered(ctx context.Context, condition, orderBy string, params statement.ModifiedListParams) (*statement.ListResult, error) {
+	ctx, cancel := r.timeouts.list(ctx)
+	defer cancel()
+
+	fromClause := "FROM statements s"
+	whereClause := "WHERE " + condition
+	var args []interface{}
+	if params.SystemID != nil {
+		fromClause = "FROM statements s JOIN controls c ON s.control_id = c.id"
+		whereClause += " AND c.system_id = $1"
+		args = append(args, *params.SystemID)
 	}
-	defer rows.Close()
 
-	statements := make([]statement.Statement, 0)
-	for rows.Next() {
-		s, err := r.scanStatementFromRows(rows)
-		if err != nil {
-			return nil, err
-		}
-		statements = append(statements, *s)
+	var totalCount int
+	countQuery := fmt.Sprintf(`SELECT COUNT(*) %s %s`, fromClause, whereClause)
+	if err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&totalCount); err != nil {
+		return nil, fmt.Errorf("failed to count statements: %w", err)
 	}
 
-	return statements, nil
-}
+	if params.Page < 1 {
+		params.Page = 1
+	}
+	if params.PageSize < 1 {
+		params.PageSize = 20
+	}
+	offset := (params.Page - 1) * params.PageSize
+	totalPages := (totalCount + params.PageSize - 1) / params.PageSize
 
-// ListConflicts retrieves all statements with sync conflicts.
-func (r *StatementRepository) ListConflicts(ctx context.Context) ([]statement.Statement, error) {
-	query := `
-		SELECT id, control_id, sn_sys_id, statement_type,
-		       remote_content, remote_updated_at, local_content, is_modified, modified_at, modified_by,
-		       sync_status, conflict_resolved_at, conflict_resolved_by,
-		       sn_updated_on, last_pull_at, last_push_at, created_at, updated_at
-		FROM statements
-		WHERE sync_status = 'conflict'
-		ORDER BY created_at DESC
-	`
+	query := fmt.Sprintf(`
+		SELECT s.id, s.control_id, s.sn_sys_id, s.statement_type,
+		       s.remote_content, s.remote_updated_at, s.local_content, s.is_modified, s.modified_at, s.modified_by, s.modified_by_email,
+		       s.sync_status, s.conflict_resolved_at, s.conflict_resolved_by,
+		       s.review_status, s.reviewed_by, s.reviewed_at, s.review_comment,
+		       s.sn_updated_on, s.last_pull_at, s.last_push_at, s.last_push_by, s.created_at, s.updated_at,
+		       ARRAY(SELECT tag FROM statement_tags WHERE statement_id = s.id ORDER BY tag)
+		%s
+		%s
+		ORDER BY %s
+		LIMIT $%d OFFSET $%d
+	`, fromClause, whereClause, orderBy, len(args)+1, len(args)+2)
+	args = append(args, params.PageSize, offset)
 
-	rows, err := r.db.QueryContext(ctx, query)
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list conflicts: %w", err)
+		return nil, fmt.Errorf("failed to list statements: %w", err)
 	}
 	defer rows.Close()
 
@@ -233,18 +287,29 @@ func (r *StatementRepository) ListConflicts(ctx context.Context) ([]statement.St
 		statements = append(statements, *s)
 	}
 
-	return statements, nil
+	return &statement.ListResult{
+		Statements: statements,
+		TotalCount: totalCount,
+		Page:       params.Page,
+		PageSize:   params.PageSize,
+		TotalPages: totalPages,
+	}, nil
 }
 
 // Upsert creates or updates a statement from ServiceNow.
 func (r *StatementRepository) Upsert(ctx context.Context, input statement.UpsertInput) (*statement.Statement, error) {
+	ctx, cancel := r.timeouts.singleRow(ctx)
+	defer cancel()
+
+	input.RemoteContent = statement.NormalizeContent(input.RemoteContent)
+
 	// Check if statement exists and has local modifications
 	existing, _ := r.GetBySNSysID(ctx, input.ControlID, input.SNSysID)
 
 	var query string
 	if existing != nil && existing.IsModified {
 		// Detect conflict: if remote content changed while we have local changes
-		if existing.RemoteContent != input.RemoteContent {
+		if !statement.ContentEqual(existing.RemoteContent, input.RemoteContent) {
 			query = `
 				UPDATE statements SET
 					remote_content = $3,
@@ -255,9 +320,11 @@ func (r *StatementRepository) Upsert(ctx context.Context, input statement.Upsert
 					updated_at = NOW()
 				WHERE control_id = $1 AND sn_sys_id = $2
 				RETURNING id, control_id, sn_sys_id, statement_type,
-				          remote_content, remote_updated_at, local_content, is_modified, modified_at, modified_by,
+				          remote_content, remote_updated_at, local_content, is_modified, modified_at, modified_by, modified_by_email,
 				          sync_status, conflict_resolved_at, conflict_resolved_by,
-				          sn_updated_on, last_pull_at, last_push_at, created_at, updated_at
+				          review_status, reviewed_by, reviewed_at, review_comment,
+				          sn_updated_on, last_pull_at, last_push_at, last_push_by, created_at, updated_at,
+				          ARRAY(SELECT tag FROM statement_tags WHERE statement_id = statements.id ORDER BY tag)
 			`
 			return r.scanStatement(r.db.QueryRowContext(ctx, query,
 				input.ControlID, input.SNSysID, input.RemoteContent, time.Now(), input.SNUpdatedOn,
@@ -279,61 +346,267 @@ func (r *StatementRepository) Upsert(ctx context.Context, input statement.Upsert
 			last_pull_at = NOW(),
 			updated_at = NOW()
 		RETURNING id, control_id, sn_sys_id, statement_type,
-		          remote_content, remote_updated_at, local_content, is_modified, modified_at, modified_by,
+		          remote_content, remote_updated_at, local_content, is_modified, modified_at, modified_by, modified_by_email,
 		          sync_status, conflict_resolved_at, conflict_resolved_by,
-		          sn_updated_on, last_pull_at, last_push_at, created_at, updated_at
+		          review_status, reviewed_by, reviewed_at, review_comment,
+		          sn_updated_on, last_pull_at, last_push_at, last_push_by, created_at, updated_at,
+		          ARRAY(SELECT tag FROM statement_tags WHERE statement_id = statements.id ORDER BY tag)
 	`
 
 	stmtType := input.StatementType
 	if stmtType == "" {
-		stmtType = "implementation"
+		stmtType = statement.DefaultStatementType
 	}
 
-	return r.scanStatement(r.db.QueryRowContext(ctx, query,
+	s, err := r.scanStatement(r.db.QueryRowContext(ctx, query,
 		input.ControlID, input.SNSysID, stmtType, input.RemoteContent, input.SNUpdatedOn,
 	))
+	if isConstraintViolation(err, pgForeignKeyViolation, statementTypeFKey) {
+		return nil, fmt.Errorf("%w: %q", statement.ErrInvalidStatementType, stmtType)
+	}
+	return s, err
 }
 
-// UpsertBatch creates or updates multiple statements.
+// statementUpsertBatchSize caps the rows written by one upsert query. Each
+// row takes 5 parameters, well below PostgreSQL's limit of 65535.
+const statementUpsertBatchSize = 500
+
+// statementKey identifies a statement by its control and ServiceNow sys_id.
+type statementKey struct {
+	controlID uuid.UUID
+	snSysID   string
+}
+
+// UpsertBatch creates or updates multiple statements in one transaction,
+// detecting conflicts the same way as Upsert. Statements are written with
+// one multi-row query per statementUpsertBatchSize inputs and returned in
+// input order.
 func (r *StatementRepository) UpsertBatch(ctx context.Context, inputs []statement.UpsertInput) ([]statement.Statement, error) {
+	ctx, cancel := r.timeouts.batch(ctx)
+	defer cancel()
+
 	if len(inputs) == 0 {
 		return []statement.Statement{}, nil
 	}
 
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	upserted := make(map[statementKey]*statement.Statement, len(inputs))
+	for start := 0; start < len(inputs); start += statementUpsertBatchSize {
+		end := min(start+statementUpsertBatchSize, len(inputs))
+		if err := r.upsertChunk(ctx, tx, inputs[start:end], upserted); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
 	statements := make([]statement.Statement, 0, len(inputs))
 	for _, input := range inputs {
-		s, err := r.Upsert(ctx, input)
-		if err != nil {
-			return nil, err
+		s, ok := upserted[statementKey{input.ControlID, input.SNSysID}]
+		if !ok {
+			return nil, fmt.Errorf("statement %s was not upserted", input.SNSysID)
 		}
 		statements = append(statements, *s)
 	}
+	return statements, nil
+}
+
+// upsertChunk writes up to statementUpsertBatchSize statements with a single
+// query and records the resulting rows in upserted.
+func (r *StatementRepository) upsertChunk(ctx context.Context, tx *sql.Tx, inputs []statement.UpsertInput, upserted map[statementKey]*statement.Statement) error {
+	// A statement may only be written once per query; the last input wins as
+	// it would when upserting one at a time
+	latest := make(map[statementKey]statement.UpsertInput, len(inputs))
+	var keys []statementKey
+	for _, input := range inputs {
+		input.RemoteContent = statement.NormalizeContent(input.RemoteContent)
+		key := statementKey{input.ControlID, input.SNSysID}
+		if _, ok := latest[key]; !ok {
+			keys = append(keys, key)
+		}
+		latest[key] = input
+	}
+
+	existing, err := r.getByKeys(ctx, tx, keys)
+	if err != nil {
+		return err
+	}
+
+	// Locally modified statements whose remote content did not change are
+	// left untouched
+	var rows []statement.UpsertInput
+	for _, key := range keys {
+		input := latest[key]
+		if e, ok := existing[key]; ok && e.IsModified && statement.ContentEqual(e.RemoteContent, input.RemoteContent) {
+			upserted[key] = e
+			continue
+		}
+		rows = append(rows, input)
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+
+	query, args := buildStatementUpsert(rows)
+	result, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return upsertBatchError(err, rows)
+	}
+	defer result.Close()
+
+	for result.Next() {
+		s, err := r.scanStatementFromRows(result)
+		if err != nil {
+			return err
+		}
+		upserted[statementKey{s.ControlID, s.SNSysID}] = s
+	}
+	if err := result.Err(); err != nil {
+		return upsertBatchError(err, rows)
+	}
+	return nil
+}
+
+// upsertBatchError maps a failed batch upsert to a domain error where possible.
+func upsertBatchError(err error, inputs []statement.UpsertInput) error {
+	if !isConstraintViolation(err, pgForeignKeyViolation, statementTypeFKey) {
+		return fmt.Errorf("failed to upsert statements: %w", err)
+	}
+	var types []string
+	for _, input := range inputs {
+		if input.StatementType != "" && !slices.Contains(types, input.StatementType) {
+			types = append(types, input.StatementType)
+		}
+	}
+	return fmt.Errorf("%w: one of %q", statement.ErrInvalidStatementType, types)
+}
+
+// getByKeys retrieves the statements with the given keys that exist.
+func (r *StatementRepository) getByKeys(ctx context.Context, tx *sql.Tx, keys []statementKey) (map[statementKey]*statement.Statement, error) {
+	controlIDs := make([]uuid.UUID, len(keys))
+	snSysIDs := make([]string, len(keys))
+	for i, key := range keys {
+		controlIDs[i] = key.controlID
+		snSysIDs[i] = key.snSysID
+	}
+
+	query := `
+		SELECT id, control_id, sn_sys_id, statement_type,
+		       remote_content, remote_updated_at, local_content, is_modified, modified_at, modified_by, modified_by_email,
+		       sync_status, conflict_resolved_at, conflict_resolved_by,
+		       review_status, reviewed_by, reviewed_at, review_comment,
+		       sn_updated_on, last_pull_at, last_push_at, last_push_by, created_at, updated_at,
+		       ARRAY(SELECT tag FROM statement_tags WHERE statement_id = statements.id ORDER BY tag)
+		FROM statements
+		WHERE (control_id, sn_sys_id) IN (SELECT * FROM unnest($1::uuid[], $2::text[]))
+	`
 
+	rows, err := tx.QueryContext(ctx, query, pq.Array(controlIDs), pq.Array(snSysIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get statements: %w", err)
+	}
+	defer rows.Close()
+
+	statements := make(map[statementKey]*statement.Statement, len(keys))
+	for rows.Next() {
+		s, err := r.scanStatementFromRows(rows)
+		if err != nil {
+			return nil, err
+		}
+		statements[statementKey{s.ControlID, s.SNSysID}] = s
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get statements: %w", err)
+	}
 	return statements, nil
 }
 
+// buildStatementUpsert builds a single INSERT ... ON CONFLICT query writing
+// all inputs. Existing statements with local modifications are marked as
+// conflicts, as in Upsert. The rows are returned through a CTE so their tags
+// can be selected alongside.
+func buildStatementUpsert(inputs []statement.UpsertInput) (string, []interface{}) {
+	const columns = 5
+	values := make([]string, len(inputs))
+	args := make([]interface{}, 0, len(inputs)*columns)
+	for i, input := range inputs {
+		n := i * columns
+		values[i] = fmt.Sprintf("($%d, $%d, $%d, $%d, NOW(), $%d, NOW())", n+1, n+2, n+3, n+4, n+5)
+
+		stmtType := input.StatementType
+		if stmtType == "" {
+			stmtType = statement.DefaultStatementType
+		}
+		args = append(args, input.ControlID, input.SNSysID, stmtType, input.RemoteContent, input.SNUpdatedOn)
+	}
+
+	query := `
+		WITH upserted AS (
+			INSERT INTO statements (control_id, sn_sys_id, statement_type, remote_content, remote_updated_at, sn_updated_on, last_pull_at)
+			VALUES ` + strings.Join(values, ", ") + `
+			ON CONFLICT (control_id, sn_sys_id)
+			DO UPDATE SET
+				remote_content = EXCLUDED.remote_content,
+				remote_updated_at = NOW(),
+				sn_updated_on = EXCLUDED.sn_updated_on,
+				sync_status = CASE WHEN statements.is_modified THEN 'conflict' ELSE statements.sync_status END,
+				last_pull_at = NOW(),
+				updated_at = NOW()
+			RETURNING *
+		)
+		SELECT id, control_id, sn_sys_id, statement_type,
+		       remote_content, remote_updated_at, local_content, is_modified, modified_at, modified_by, modified_by_email,
+		       sync_status, conflict_resolved_at, conflict_resolved_by,
+		       review_status, reviewed_by, reviewed_at, review_comment,
+		       sn_updated_on, last_pull_at, last_push_at, last_push_by, created_at, updated_at,
+		       ARRAY(SELECT tag FROM statement_tags WHERE statement_id = upserted.id ORDER BY tag)
+		FROM upserted
+	`
+	return query, args
+}
+
 // UpdateLocal updates the local content of a statement.
 func (r *StatementRepository) UpdateLocal(ctx context.Context, input statement.UpdateInput) (*statement.Statement, error) {
+	ctx, cancel := r.timeouts.singleRow(ctx)
+	defer cancel()
+
 	query := `
 		UPDATE statements SET
 			local_content = $2,
 			is_modified = true,
 			modified_at = NOW(),
 			modified_by = $3,
+			modified_by_email = NULLIF($5, ''),
 			sync_status = 'modified',
+			review_status = CASE WHEN $4 THEN 'pending_review' ELSE NULL END,
+			reviewed_by = NULL,
+			reviewed_at = NULL,
+			review_comment = NULL,
 			updated_at = NOW()
 		WHERE id = $1
 		RETURNING id, control_id, sn_sys_id, statement_type,
-		          remote_content, remote_updated_at, local_content, is_modified, modified_at, modified_by,
+		          remote_content, remote_updated_at, local_content, is_modified, modified_at, modified_by, modified_by_email,
 		          sync_status, conflict_resolved_at, conflict_resolved_by,
-		          sn_updated_on, last_pull_at, last_push_at, created_at, updated_at
+		          review_status, reviewed_by, reviewed_at, review_comment,
+		          sn_updated_on, last_pull_at, last_push_at, last_push_by, created_at, updated_at,
+		          ARRAY(SELECT tag FROM statement_tags WHERE statement_id = statements.id ORDER BY tag)
 	`
 
-	return r.scanStatement(r.db.QueryRowContext(ctx, query, input.ID, input.LocalContent, input.ModifiedBy))
+	return r.scanStatement(r.db.QueryRowContext(ctx, query, input.ID, input.LocalContent, input.ModifiedBy, input.RequireReview, input.ModifiedByEmail))
 }
 
 // ResolveConflict resolves a sync conflict.
 func (r *StatementRepository) ResolveConflict(ctx context.Context, input statement.ResolveConflictInput) (*statement.Statement, error) {
+	ctx, cancel := r.timeouts.singleRow(ctx)
+	defer cancel()
+
 	var query string
 	var args []interface{}
 
@@ -347,9 +620,11 @@ func (r *StatementRepository) ResolveConflict(ctx context.Context, input stateme
 				updated_at = NOW()
 			WHERE id = $1
 			RETURNING id, control_id, sn_sys_id, statement_type,
-			          remote_content, remote_updated_at, local_content, is_modified, modified_at, modified_by,
+			          remote_content, remote_updated_at, local_content, is_modified, modified_at, modified_by, modified_by_email,
 			          sync_status, conflict_resolved_at, conflict_resolved_by,
-			          sn_updated_on, last_pull_at, last_push_at, created_at, updated_at
+			          review_status, reviewed_by, reviewed_at, review_comment,
+			          sn_updated_on, last_pull_at, last_push_at, last_push_by, created_at, updated_at,
+			          ARRAY(SELECT tag FROM statement_tags WHERE statement_id = statements.id ORDER BY tag)
 		`
 		args = []interface{}{input.ID, input.ResolvedBy}
 
@@ -359,14 +634,17 @@ func (r *StatementRepository) ResolveConflict(ctx context.Context, input stateme
 				local_content = remote_content,
 				is_modified = false,
 				sync_status = 'synced',
+				review_status = NULL,
 				conflict_resolved_at = NOW(),
 				conflict_resolved_by = $2,
 				updated_at = NOW()
 			WHERE id = $1
 			RETURNING id, control_id, sn_sys_id, statement_type,
-			          remote_content, remote_updated_at, local_content, is_modified, modified_at, modified_by,
+			          remote_content, remote_updated_at, local_content, is_modified, modified_at, modified_by, modified_by_email,
 			          sync_status, conflict_resolved_at, conflict_resolved_by,
-			          sn_updated_on, last_pull_at, last_push_at, created_at, updated_at
+			          review_status, reviewed_by, reviewed_at, review_comment,
+			          sn_updated_on, last_pull_at, last_push_at, last_push_by, created_at, updated_at,
+			          ARRAY(SELECT tag FROM statement_tags WHERE statement_id = statements.id ORDER BY tag)
 		`
 		args = []interface{}{input.ID, input.ResolvedBy}
 
@@ -376,16 +654,22 @@ func (r *StatementRepository) ResolveConflict(ctx context.Context, input stateme
 				local_content = $2,
 				is_modified = true,
 				sync_status = 'modified',
+				review_status = CASE WHEN $4 THEN 'pending_review' ELSE NULL END,
+				reviewed_by = NULL,
+				reviewed_at = NULL,
+				review_comment = NULL,
 				conflict_resolved_at = NOW(),
 				conflict_resolved_by = $3,
 				updated_at = NOW()
 			WHERE id = $1
 			RETURNING id, control_id, sn_sys_id, statement_type,
-			          remote_content, remote_updated_at, local_content, is_modified, modified_at, modified_by,
+			          remote_content, remote_updated_at, local_content, is_modified, modified_at, modified_by, modified_by_email,
 			          sync_status, conflict_resolved_at, conflict_resolved_by,
-			          sn_updated_on, last_pull_at, last_push_at, created_at, updated_at
+			          review_status, reviewed_by, reviewed_at, review_comment,
+			          sn_updated_on, last_pull_at, last_push_at, last_push_by, created_at, updated_at,
+			          ARRAY(SELECT tag FROM statement_tags WHERE statement_id = statements.id ORDER BY tag)
 		`
-		args = []interface{}{input.ID, input.MergedContent, input.ResolvedBy}
+		args = []interface{}{input.ID, input.MergedContent, input.ResolvedBy, input.RequireReview}
 
 	default:
 		return nil, fmt.Errorf("invalid conflict resolution: %s", input.Resolution)
@@ -394,8 +678,35 @@ func (r *StatementRepository) ResolveConflict(ctx context.Context, input stateme
 	return r.scanStatement(r.db.QueryRowContext(ctx, query, args...))
 }
 
+// SetReview records a review decision on a modified statement.
+func (r *StatementRepository) SetReview(ctx context.Context, input statement.ReviewInput) (*statement.Statement, error) {
+	ctx, cancel := r.timeouts.singleRow(ctx)
+	defer cancel()
+
+	query := `
+		UPDATE statements SET
+			review_status = $2,
+			reviewed_by = $3,
+			reviewed_at = NOW(),
+			review_comment = NULLIF($4, ''),
+			updated_at = NOW()
+		WHERE id = $1 AND is_modified = true
+		RETURNING id, control_id, sn_sys_id, statement_type,
+		          remote_content, remote_updated_at, local_content, is_modified, modified_at, modified_by, modified_by_email,
+		          sync_status, conflict_resolved_at, conflict_resolved_by,
+		          review_status, reviewed_by, reviewed_at, review_comment,
+		          sn_updated_on, last_pull_at, last_push_at, last_push_by, created_at, updated_at,
+		          ARRAY(SELECT tag FROM statement_tags WHERE statement_id = statements.id ORDER BY tag)
+	`
+
+	return r.scanStatement(r.db.QueryRowContext(ctx, query, input.ID, input.Status, input.ReviewedBy, input.Comment))
+}
+
 // Delete removes a statement.
 func (r *StatementRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	ctx, cancel := r.timeouts.singleRow(ctx)
+	defer cancel()
+
 	query := `DELETE FROM statements WHERE id = $1`
 	result, err := r.db.ExecContext(ctx, query, id)
 	if err != nil {
@@ -412,6 +723,9 @@ func (r *StatementRepository) Delete(ctx context.Context, id uuid.UUID) error {
 
 // DeleteByControl removes all statements for a control.
 func (r *StatementRepository) DeleteByControl(ctx context.Context, controlID uuid.UUID) error {
+	ctx, cancel := r.timeouts.batch(ctx)
+	defer cancel()
+
 	query := `DELETE FROM statements WHERE control_id = $1`
 	_, err := r.db.ExecContext(ctx, query, controlID)
 	if err != nil {
@@ -420,36 +734,346 @@ func (r *StatementRepository) DeleteByControl(ctx context.Context, controlID uui
 	return nil
 }
 
-// MarkAsSynced marks a statement as synced after push.
-func (r *StatementRepository) MarkAsSynced(ctx context.Context, id uuid.UUID) error {
+// DeleteBatch removes the given statements in a single transaction. Unless
+// force is set, the rows are locked and checked for local modifications first.
+func (r *StatementRepository) DeleteBatch(ctx context.Context, ids []uuid.UUID, force bool) (int, error) {
+	ctx, cancel := r.timeouts.batch(ctx)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if !force {
+		var modified bool
+		err := tx.QueryRowContext(ctx, `
+			SELECT COALESCE(bool_or(is_modified), false)
+			FROM (SELECT is_modified FROM statements WHERE id = ANY($1) FOR UPDATE) s
+		`, pq.Array(ids)).Scan(&modified)
+		if err != nil {
+			return 0, fmt.Errorf("failed to check statement modifications: %w", err)
+		}
+		if modified {
+			return 0, statement.ErrCannotDeleteModified
+		}
+	}
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM statements WHERE id = ANY($1)`, pq.Array(ids))
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete statements: %w", err)
+	}
+	deleted, _ := result.RowsAffected()
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return int(deleted), nil
+}
+
+// MarkAsSynced marks a statement as synced after it was pushed by the given
+// push job.
+func (r *StatementRepository) MarkAsSynced(ctx context.Context, id, jobID uuid.UUID) error {
+	ctx, cancel := r.timeouts.singleRow(ctx)
+	defer cancel()
+
 	query := `
 		UPDATE statements SET
 			is_modified = false,
 			sync_status = 'synced',
+			review_status = NULL,
 			last_push_at = NOW(),
+			last_push_by = $2,
 			updated_at = NOW()
 		WHERE id = $1
 	`
-	_, err := r.db.ExecContext(ctx, query, id)
+	_, err := r.db.ExecContext(ctx, query, id, jobID)
 	if err != nil {
 		return fmt.Errorf("failed to mark as synced: %w", err)
 	}
 	return nil
 }
 
+// ListReadOnly returns those of the given statements whose control belongs
+// to a read-only system.
+func (r *StatementRepository) ListReadOnly(ctx context.Context, ids []uuid.UUID) ([]uuid.UUID, error) {
+	ctx, cancel := r.timeouts.list(ctx)
+	defer cancel()
+
+	query := `
+		SELECT st.id
+		FROM statements st
+		JOIN controls c ON c.id = st.control_id
+		JOIN systems s ON s.id = c.system_id
+		WHERE st.id = ANY($1) AND s.import_mode = 'read_only'
+	`
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list read-only statements: %w", err)
+	}
+	defer rows.Close()
+
+	var readOnly []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan statement id: %w", err)
+		}
+		readOnly = append(readOnly, id)
+	}
+	return readOnly, rows.Err()
+}
+
+// GetSystemRef returns the system the statement's control belongs to.
+func (r *StatementRepository) GetSystemRef(ctx context.Context, id uuid.UUID) (*statement.SystemRef, error) {
+	ctx, cancel := r.timeouts.singleRow(ctx)
+	defer cancel()
+
+	query := `
+		SELECT s.id, s.name, s.connection_label
+		FROM statements st
+		JOIN controls c ON c.id = st.control_id
+		JOIN systems s ON s.id = c.system_id
+		WHERE st.id = $1
+	`
+	var ref statement.SystemRef
+	err := r.db.QueryRowContext(ctx, query, id).Scan(&ref.ID, &ref.Name, &ref.ConnectionLabel)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get statement system: %w", err)
+	}
+	return &ref, nil
+}
+
 // Helper functions
 
+// ListTypes retrieves all registered statement types.
+func (r *StatementRepository) ListTypes(ctx context.Context) ([]statement.Type, error) {
+	ctx, cancel := r.timeouts.list(ctx)
+	defer cancel()
+
+	query := `SELECT name, description, created_at FROM statement_types ORDER BY name ASC`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list statement types: %w", err)
+	}
+	defer rows.Close()
+
+	types := make([]statement.Type, 0)
+	for rows.Next() {
+		var t statement.Type
+		var description sql.NullString
+		if err := rows.Scan(&t.Name, &description, &t.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan statement type: %w", err)
+		}
+		t.Description = description.String
+		types = append(types, t)
+	}
+
+	return types, rows.Err()
+}
+
+// CreateType registers a new statement type.
+func (r *StatementRepository) CreateType(ctx context.Context, input statement.CreateTypeInput) (*statement.Type, error) {
+	ctx, cancel := r.timeouts.singleRow(ctx)
+	defer cancel()
+
+	query := `
+		INSERT INTO statement_types (name, description)
+		VALUES ($1, NULLIF($2, ''))
+		RETURNING name, description, created_at
+	`
+
+	var t statement.Type
+	var description sql.NullString
+	err := r.db.QueryRowContext(ctx, query, input.Name, input.Description).Scan(&t.Name, &description, &t.CreatedAt)
+	if isConstraintViolation(err, pgUniqueViolation, statementTypePKey) {
+		return nil, statement.ErrStatementTypeExists
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create statement type: %w", err)
+	}
+	t.Description = description.String
+
+	return &t, nil
+}
+
+// DeleteType removes a statement type. The foreign key from statements
+// prevents deleting a type that is still in use.
+func (r *StatementRepository) DeleteType(ctx context.Context, name string) error {
+	ctx, cancel := r.timeouts.singleRow(ctx)
+	defer cancel()
+
+	result, err := r.db.ExecContext(ctx, `DELETE FROM statement_types WHERE name = $1`, name)
+	if isConstraintViolation(err, pgForeignKeyViolation, statementTypeFKey) {
+		return statement.ErrStatementTypeInUse
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete statement type: %w", err)
+	}
+
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return statement.ErrStatementTypeNotFound
+	}
+
+	return nil
+}
+
+// CreateTemplate stores a new statement template.
+func (r *StatementRepository) CreateTemplate(ctx context.Context, input statement.CreateTemplateInput) (*statement.Template, error) {
+	ctx, cancel := r.timeouts.singleRow(ctx)
+	defer cancel()
+
+	variablesJSON, err := json.Marshal(input.Variables)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal template variables: %w", err)
+	}
+
+	query := `
+		INSERT INTO statement_templates (name, content_template, variables, created_by)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, name, content_template, variables, created_by, created_at
+	`
+
+	return r.scanTemplate(r.db.QueryRowContext(ctx, query, input.Name, input.ContentTemplate, variablesJSON, input.CreatedBy))
+}
+
+// GetTemplate retrieves a statement template by ID.
+func (r *StatementRepository) GetTemplate(ctx context.Context, id uuid.UUID) (*statement.Template, error) {
+	ctx, cancel := r.timeouts.singleRow(ctx)
+	defer cancel()
+
+	query := `
+		SELECT id, name, content_template, variables, created_by, created_at
+		FROM statement_templates
+		WHERE id = $1
+	`
+
+	return r.scanTemplate(r.db.QueryRowContext(ctx, query, id))
+}
+
+// CreateAttachment records an evidence file uploaded to ServiceNow.
+func (r *StatementRepository) CreateAttachment(ctx context.Context, statementID uuid.UUID, snSysID, fileName string) (*statement.Attachment, error) {
+	ctx, cancel := r.timeouts.singleRow(ctx)
+	defer cancel()
+
+	query := `
+		INSERT INTO statement_attachments (statement_id, sn_sys_id, file_name)
+		VALUES ($1, $2, $3)
+		RETURNING id, statement_id, sn_sys_id, file_name, uploaded_at
+	`
+
+	var a statement.Attachment
+	err := r.db.QueryRowContext(ctx, query, statementID, snSysID, fileName).
+		Scan(&a.ID, &a.StatementID, &a.SNSysID, &a.FileName, &a.UploadedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create statement attachment: %w", err)
+	}
+	return &a, nil
+}
+
+// GetAttachment retrieves an attachment of a statement.
+func (r *StatementRepository) GetAttachment(ctx context.Context, statementID, attachmentID uuid.UUID) (*statement.Attachment, error) {
+	ctx, cancel := r.timeouts.singleRow(ctx)
+	defer cancel()
+
+	query := `
+		SELECT id, statement_id, sn_sys_id, file_name, uploaded_at
+		FROM statement_attachments
+		WHERE id = $1 AND statement_id = $2
+	`
+
+	var a statement.Attachment
+	err := r.db.QueryRowContext(ctx, query, attachmentID, statementID).
+		Scan(&a.ID, &a.StatementID, &a.SNSysID, &a.FileName, &a.UploadedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get statement attachment: %w", err)
+	}
+	return &a, nil
+}
+
+// AddTags labels a statement with the given tags. Tags it already has are
+// left unchanged.
+func (r *StatementRepository) AddTags(ctx context.Context, statementID uuid.UUID, tags []string, createdBy *uuid.UUID) error {
+	ctx, cancel := r.timeouts.singleRow(ctx)
+	defer cancel()
+
+	query := `
+		INSERT INTO statement_tags (statement_id, tag, created_by)
+		SELECT $1, tag, $3 FROM unnest($2::text[]) AS tag
+		ON CONFLICT (statement_id, tag) DO NOTHING
+	`
+	if _, err := r.db.ExecContext(ctx, query, statementID, pq.Array(tags), createdBy); err != nil {
+		return fmt.Errorf("failed to add statement tags: %w", err)
+	}
+	return nil
+}
+
+// RemoveTag removes a tag from a statement. Returns false if the statement
+// did not have the tag.
+func (r *StatementRepository) RemoveTag(ctx context.Context, statementID uuid.UUID, tag string) (bool, error) {
+	ctx, cancel := r.timeouts.singleRow(ctx)
+	defer cancel()
+
+	result, err := r.db.ExecContext(ctx, `DELETE FROM statement_tags WHERE statement_id = $1 AND tag = $2`, statementID, tag)
+	if err != nil {
+		return false, fmt.Errorf("failed to remove statement tag: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to remove statement tag: %w", err)
+	}
+	return rows > 0, nil
+}
+
+func (r *StatementRepository) scanTemplate(row *sql.Row) (*statement.Template, error) {
+	var t statement.Template
+	var variablesJSON []byte
+	var createdBy sql.NullString
+
+	err := row.Scan(&t.ID, &t.Name, &t.ContentTemplate, &variablesJSON, &createdBy, &t.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan statement template: %w", err)
+	}
+	if err := json.Unmarshal(variablesJSON, &t.Variables); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal template variables: %w", err)
+	}
+	if createdBy.Valid {
+		if id, err := uuid.Parse(createdBy.String); err == nil {
+			t.CreatedBy = &id
+		}
+	}
+
+	return &t, nil
+}
+
 func (r *StatementRepository) scanStatement(row *sql.Row) (*statement.Statement, error) {
 	var s statement.Statement
 	var remoteContent, localContent sql.NullString
 	var remoteUpdatedAt, modifiedAt, conflictResolvedAt, snUpdatedOn, lastPullAt, lastPushAt sql.NullTime
-	var modifiedBy, conflictResolvedBy sql.NullString
+	var modifiedBy, modifiedByEmail, conflictResolvedBy, lastPushBy sql.NullString
+	var reviewStatus, reviewedBy, reviewComment sql.NullString
+	var reviewedAt sql.NullTime
 
 	err := row.Scan(
 		&s.ID, &s.ControlID, &s.SNSysID, &s.StatementType,
-		&remoteContent, &remoteUpdatedAt, &localContent, &s.IsModified, &modifiedAt, &modifiedBy,
+		&remoteContent, &remoteUpdatedAt, &localContent, &s.IsModified, &modifiedAt, &modifiedBy, &modifiedByEmail,
 		&s.SyncStatus, &conflictResolvedAt, &conflictResolvedBy,
-		&snUpdatedOn, &lastPullAt, &lastPushAt, &s.CreatedAt, &s.UpdatedAt,
+		&reviewStatus, &reviewedBy, &reviewedAt, &reviewComment,
+		&snUpdatedOn, &lastPullAt, &lastPushAt, &lastPushBy, &s.CreatedAt, &s.UpdatedAt,
+		pq.Array(&s.Tags),
 	)
 	if err == sql.ErrNoRows {
 		return nil, nil
@@ -471,6 +1095,7 @@ func (r *StatementRepository) scanStatement(row *sql.Row) (*statement.Statement,
 			s.ModifiedBy = &id
 		}
 	}
+	s.ModifiedByEmail = modifiedByEmail.String
 	if conflictResolvedAt.Valid {
 		s.ConflictResolvedAt = &conflictResolvedAt.Time
 	}
@@ -479,6 +1104,18 @@ func (r *StatementRepository) scanStatement(row *sql.Row) (*statement.Statement,
 			s.ConflictResolvedBy = &id
 		}
 	}
+	if reviewStatus.Valid {
+		s.ReviewStatus = statement.ReviewStatus(reviewStatus.String)
+	}
+	if reviewedBy.Valid {
+		if id, err := uuid.Parse(reviewedBy.String); err == nil {
+			s.ReviewedBy = &id
+		}
+	}
+	if reviewedAt.Valid {
+		s.ReviewedAt = &reviewedAt.Time
+	}
+	s.ReviewComment = reviewComment.String
 	if snUpdatedOn.Valid {
 		s.SNUpdatedOn = &snUpdatedOn.Time
 	}
@@ -488,6 +1125,11 @@ func (r *StatementRepository) scanStatement(row *sql.Row) (*statement.Statement,
 	if lastPushAt.Valid {
 		s.LastPushAt = &lastPushAt.Time
 	}
+	if lastPushBy.Valid {
+		if id, err := uuid.Parse(lastPushBy.String); err == nil {
+			s.LastPushBy = &id
+		}
+	}
 
 	return &s, nil
 }
@@ -496,13 +1138,17 @@ func (r *StatementRepository) scanStatementFromRows(rows *sql.Rows) (*statement.
 	var s statement.Statement
 	var remoteContent, localContent sql.NullString
 	var remoteUpdatedAt, modifiedAt, conflictResolvedAt, snUpdatedOn, lastPullAt, lastPushAt sql.NullTime
-	var modifiedBy, conflictResolvedBy sql.NullString
+	var modifiedBy, modifiedByEmail, conflictResolvedBy, lastPushBy sql.NullString
+	var reviewStatus, reviewedBy, reviewComment sql.NullString
+	var reviewedAt sql.NullTime
 
 	err := rows.Scan(
 		&s.ID, &s.ControlID, &s.SNSysID, &s.StatementType,
-		&remoteContent, &remoteUpdatedAt, &localContent, &s.IsModified, &modifiedAt, &modifiedBy,
+		&remoteContent, &remoteUpdatedAt, &localContent, &s.IsModified, &modifiedAt, &modifiedBy, &modifiedByEmail,
 		&s.SyncStatus, &conflictResolvedAt, &conflictResolvedBy,
-		&snUpdatedOn, &lastPullAt, &lastPushAt, &s.CreatedAt, &s.UpdatedAt,
+		&reviewStatus, &reviewedBy, &reviewedAt, &reviewComment,
+		&snUpdatedOn, &lastPullAt, &lastPushAt, &lastPushBy, &s.CreatedAt, &s.UpdatedAt,
+		pq.Array(&s.Tags),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to scan statement: %w", err)
@@ -521,6 +1167,7 @@ func (r *StatementRepository) scanStatementFromRows(rows *sql.Rows) (*statement.
 			s.ModifiedBy = &id
 		}
 	}
+	s.ModifiedByEmail = modifiedByEmail.String
 	if conflictResolvedAt.Valid {
 		s.ConflictResolvedAt = &conflictResolvedAt.Time
 	}
@@ -529,6 +1176,18 @@ func (r *StatementRepository) scanStatementFromRows(rows *sql.Rows) (*statement.
 			s.ConflictResolvedBy = &id
 		}
 	}
+	if reviewStatus.Valid {
+		s.ReviewStatus = statement.ReviewStatus(reviewStatus.String)
+	}
+	if reviewedBy.Valid {
+		if id, err := uuid.Parse(reviewedBy.String); err == nil {
+			s.ReviewedBy = &id
+		}
+	}
+	if reviewedAt.Valid {
+		s.ReviewedAt = &reviewedAt.Time
+	}
+	s.ReviewComment = reviewComment.String
 	if snUpdatedOn.Valid {
 		s.SNUpdatedOn = &snUpdatedOn.Time
 	}
@@ -538,6 +1197,11 @@ func (r *StatementRepository) scanStatementFromRows(rows *sql.Rows) (*statement.
 	if lastPushAt.Valid {
 		s.LastPushAt = &lastPushAt.Time
 	}
+	if lastPushBy.Valid {
+		if id, err := uuid.Parse(lastPushBy.String); err == nil {
+			s.LastPushBy = &id
+		}
+	}
 
 	return &s, nil
 }
@@ -0,0 +1,287 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/controlcrud/backend/internal/domain/statement"
+	"github.com/controlcrud/backend/internal/infrastructure/database/migrations"
+)
+
+func TestBuildStatementUpsert(t *testing.T) {
+	controlID := uuid.New()
+	inputs := []statement.UpsertInput{
+		{ControlID: controlID, SNSysID: "a", StatementType: "evidence", RemoteContent: "A"},
+		{ControlID: controlID, SNSysID: "b", RemoteContent: "B"},
+	}
+
+	query, args := buildStatementUpsert(inputs)
+
+	if !strings.Contains(query, "VALUES ($1, $2, $3, $4, NOW(), $5, NOW()), ($6, $7, $8, $9, NOW(), $10, NOW())") {
+		t.Errorf("expected one placeholder group per input, got %s", query)
+	}
+	if len(args) != 10 {
+		t.Fatalf("expected 10 args, got %d", len(args))
+	}
+	if args[2] != "evidence" || args[7] != statement.DefaultStatementType {
+		t.Errorf("expected statement types evidence and default, got %v and %v", args[2], args[7])
+	}
+	if args[6] != "b" || args[8] != "B" {
+		t.Errorf("expected second row args in column order, got %v", args[5:])
+	}
+}
+
+// openTestDB opens and migrates the PostgreSQL database in
+// TEST_DATABASE_URL, skipping the test when it is not set.
+func openTestDB(tb testing.TB) *sql.DB {
+	tb.Helper()
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		tb.Skip("TEST_DATABASE_URL not set")
+	}
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		tb.Fatalf("failed to open database: %v", err)
+	}
+	tb.Cleanup(func() { db.Close() })
+	if err := migrations.RunMigrations(db, ""); err != nil {
+		tb.Fatalf("failed to run migrations: %v", err)
+	}
+	return db
+}
+
+// insertTestControl creates a system with one control and returns the
+// control's ID. Both are deleted when the test ends.
+func insertTestControl(tb testing.TB, db *sql.DB) uuid.UUID {
+	tb.Helper()
+	ctx := context.Background()
+
+	var systemID, controlID uuid.UUID
+	err := db.QueryRowContext(ctx,
+		`INSERT INTO systems (sn_sys_id, name) VALUES ($1, 'Upsert Test') RETURNING id`,
+		strings.ReplaceAll(uuid.NewString(), "-", ""),
+	).Scan(&systemID)
+	if err != nil {
+		tb.Fatalf("failed to insert system: %v", err)
+	}
+	tb.Cleanup(func() { db.ExecContext(ctx, `DELETE FROM systems WHERE id = $1`, systemID) })
+
+	err = db.QueryRowContext(ctx,
+		`INSERT INTO controls (system_id, sn_sys_id, control_id, control_name) VALUES ($1, 'ctl', 'AC-1', 'Control') RETURNING id`,
+		systemID,
+	).Scan(&controlID)
+	if err != nil {
+		tb.Fatalf("failed to insert control: %v", err)
+	}
+	return controlID
+}
+
+func upsertInputs(controlID uuid.UUID, n int, content string) []statement.UpsertInput {
+	inputs := make([]statement.UpsertInput, n)
+	for i := range inputs {
+		inputs[i] = statement.UpsertInput{
+			ControlID:     controlID,
+			SNSysID:       fmt.Sprintf("stmt%d", i),
+			RemoteContent: fmt.Sprintf("%s %d", content, i),
+		}
+	}
+	return inputs
+}
+
+// TestStatementRepository_UpsertBatch runs against the PostgreSQL database
+// in TEST_DATABASE_URL and is skipped when it is not set.
+func TestStatementRepository_UpsertBatch(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+	controlID := insertTestControl(t, db)
+	repo := NewStatementRepository(db, QueryTimeouts{})
+
+	// More inputs than fit in one query
+	n := statementUpsertBatchSize + 3
+	created, err := repo.UpsertBatch(ctx, upsertInputs(controlID, n, "Original"))
+	if err != nil {
+		t.Fatalf("failed to insert: %v", err)
+	}
+	if len(created) != n {
+		t.Fatalf("expected %d statements, got %d", n, len(created))
+	}
+	for i, s := range created {
+		if want := fmt.Sprintf("stmt%d", i); s.SNSysID != want {
+			t.Fatalf("statement %d: expected %s in input order, got %s", i, want, s.SNSysID)
+		}
+	}
+
+	// stmt0 is edited locally and changes remotely; stmt1 is edited locally
+	// but unchanged remotely
+	for _, id := range []uuid.UUID{created[0].ID, created[1].ID} {
+		if _, err := db.ExecContext(ctx,
+			`UPDATE statements SET local_content = 'Local', is_modified = true, sync_status = 'modified' WHERE id = $1`, id,
+		); err != nil {
+			t.Fatalf("failed to modify statement: %v", err)
+		}
+	}
+	inputs := upsertInputs(controlID, 3, "Updated")
+	inputs[1].RemoteContent = "Original 1  " // Trailing whitespace is ignored
+	inputs = append(inputs, statement.UpsertInput{ControlID: controlID, SNSysID: "stmt2", RemoteContent: "Updated twice"})
+
+	updated, err := repo.UpsertBatch(ctx, inputs)
+	if err != nil {
+		t.Fatalf("failed to update: %v", err)
+	}
+	if len(updated) != len(inputs) {
+		t.Fatalf("expected %d statements, got %d", len(inputs), len(updated))
+	}
+	if s := updated[0]; s.SyncStatus != statement.SyncStatusConflict || s.RemoteContent != "Updated 0" || s.LocalContent != "Local" {
+		t.Errorf("expected stmt0 in conflict with new remote content, got %s %q", s.SyncStatus, s.RemoteContent)
+	}
+	if s := updated[1]; s.SyncStatus != statement.SyncStatusModified || !s.LastPullAt.Equal(*created[1].LastPullAt) {
+		t.Errorf("expected stmt1 untouched, got %s pulled at %v", s.SyncStatus, s.LastPullAt)
+	}
+	if updated[2].RemoteContent != "Updated twice" || updated[3].ID != updated[2].ID {
+		t.Errorf("expected the last input for stmt2 to win, got %q", updated[2].RemoteContent)
+	}
+
+	_, err = repo.UpsertBatch(ctx, []statement.UpsertInput{{ControlID: controlID, SNSysID: "x", StatementType: "no_such_type"}})
+	if !errors.Is(err, statement.ErrInvalidStatementType) {
+		t.Errorf("expected ErrInvalidStatementType, got %v", err)
+	}
+}
+
+// TestStatementRepository_MarkAsSynced needs TEST_DATABASE_URL.
+func TestStatementRepository_MarkAsSynced(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+	repo := NewStatementRepository(db, QueryTimeouts{})
+
+	created, err := repo.UpsertBatch(ctx, upsertInputs(insertTestControl(t, db), 1, "Original"))
+	if err != nil {
+		t.Fatalf("failed to insert: %v", err)
+	}
+	if s := created[0]; s.LastPushAt != nil || s.LastPushBy != nil {
+		t.Fatalf("expected a new statement never pushed, got %v by %v", s.LastPushAt, s.LastPushBy)
+	}
+
+	jobID := uuid.New()
+	if err := repo.MarkAsSynced(ctx, created[0].ID, jobID); err != nil {
+		t.Fatalf("failed to mark as synced: %v", err)
+	}
+	s, err := repo.GetByID(ctx, created[0].ID)
+	if err != nil {
+		t.Fatalf("failed to get statement: %v", err)
+	}
+	if s.LastPushAt == nil || s.LastPushBy == nil || *s.LastPushBy != jobID {
+		t.Errorf("expected last push by job %s, got %v by %v", jobID, s.LastPushAt, s.LastPushBy)
+	}
+	if s.SyncStatus != statement.SyncStatusSynced {
+		t.Errorf("expected synced, got %s", s.SyncStatus)
+	}
+}
+
+// BenchmarkStatementUpsert compares upserting 200 statements one at a time
+// with a single UpsertBatch call. It needs TEST_DATABASE_URL.
+func BenchmarkStatementUpsert(b *testing.B) {
+	db := openTestDB(b)
+	ctx := context.Background()
+	repo := NewStatementRepository(db, QueryTimeouts{})
+
+	b.Run("Upsert", func(b *testing.B) {
+		inputs := upsertInputs(insertTestControl(b, db), 200, "Statement")
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			for _, input := range inputs {
+				if _, err := repo.Upsert(ctx, input); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+
+	b.Run("UpsertBatch", func(b *testing.B) {
+		inputs := upsertInputs(insertTestControl(b, db), 200, "Statement")
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if _, err := repo.UpsertBatch(ctx, inputs); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+// explainAnalyze runs query under EXPLAIN ANALYZE and returns the plan.
+func explainAnalyze(tb testing.TB, db *sql.DB, query string, args ...interface{}) string {
+	tb.Helper()
+	rows, err := db.QueryContext(context.Background(), "EXPLAIN ANALYZE "+query, args...)
+	if err != nil {
+		tb.Fatalf("failed to explain query: %v", err)
+	}
+	defer rows.Close()
+
+	var plan []string
+	for rows.Next() {
+		var line string
+		if err := rows.Scan(&line); err != nil {
+			tb.Fatalf("failed to read plan: %v", err)
+		}
+		plan = append(plan, line)
+	}
+	if err := rows.Err(); err != nil {
+		tb.Fatalf("failed to read plan: %v", err)
+	}
+	return strings.Join(plan, "\n")
+}
+
+// TestStatementRepository_PartialIndexes checks that the modified and
+// conflict lists are planned on their partial indexes once the table holds
+// mostly synced statements. It needs TEST_DATABASE_URL.
+func TestStatementRepository_PartialIndexes(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+	controlID := insertTestControl(t, db)
+
+	_, err := db.ExecContext(ctx, `
+		INSERT INTO statements (control_id, sn_sys_id, remote_content, is_modified, modified_at, sync_status)
+		SELECT $1, 'idx' || n, 'Statement ' || n,
+		       n % 500 = 0, CASE WHEN n % 500 = 0 THEN NOW() END,
+		       CASE WHEN n % 1000 = 0 THEN 'conflict' WHEN n % 500 = 0 THEN 'modified' ELSE 'synced' END::sync_status
+		FROM generate_series(1, 20000) AS n
+	`, controlID)
+	if err != nil {
+		t.Fatalf("failed to insert statements: %v", err)
+	}
+	if err := AnalyzeTable(ctx, db, "statements"); err != nil {
+		t.Fatalf("failed to analyze: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		index string
+	}{
+		{
+			name:  "modified",
+			query: `SELECT s.id FROM statements s WHERE s.is_modified = true ORDER BY s.modified_at DESC, s.id LIMIT 20`,
+			index: "idx_statements_modified",
+		},
+		{
+			name:  "conflict",
+			query: `SELECT s.id FROM statements s WHERE s.sync_status = 'conflict' ORDER BY s.created_at DESC, s.id LIMIT 20`,
+			index: "idx_statements_conflict",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := explainAnalyze(t, db, tt.query)
+			if !strings.Contains(plan, tt.index) {
+				t.Errorf("expected plan to use %s, got:\n%s", tt.index, plan)
+			}
+		})
+	}
+}
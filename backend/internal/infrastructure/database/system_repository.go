@@ -3,41 +3,49 @@ package database
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 
 	"github.com/controlcrud/backend/internal/domain/system"
 )
 
 // SystemRepository implements system.Repository using PostgreSQL.
 type SystemRepository struct {
-	db *sql.DB
+	db       *sql.DB
+	timeouts QueryTimeouts
 }
 
 // NewSystemRepository creates a new system repository.
-func NewSystemRepository(db *sql.DB) *SystemRepository {
-	return &SystemRepository{db: db}
+func NewSystemRepository(db *sql.DB, timeouts QueryTimeouts) *SystemRepository {
+	return &SystemRepository{db: db, timeouts: timeouts}
 }
 
 // GetByID retrieves a system by its internal ID.
 func (r *SystemRepository) GetByID(ctx context.Context, id uuid.UUID) (*system.System, error) {
+	ctx, cancel := r.timeouts.singleRow(ctx)
+	defer cancel()
+
 	query := `
 		SELECT id, sn_sys_id, name, description, acronym, owner, status,
-		       sn_updated_on, last_pull_at, last_push_at, created_at, updated_at
+		       sn_updated_on, last_pull_at, last_push_at, created_at, updated_at, deleted_at,
+		       conflict_resolution_strategy, import_mode
 		FROM systems
-		WHERE id = $1
+		WHERE id = $1 AND deleted_at IS NULL
 	`
 
 	var s system.System
-	var description, acronym, owner sql.NullString
-	var snUpdatedOn, lastPullAt, lastPushAt sql.NullTime
+	var description, acronym, owner, conflictStrategy sql.NullString
+	var snUpdatedOn, lastPullAt, lastPushAt, deletedAt sql.NullTime
 
 	err := r.db.QueryRowContext(ctx, query, id).Scan(
 		&s.ID, &s.SNSysID, &s.Name, &description, &acronym, &owner, &s.Status,
-		&snUpdatedOn, &lastPullAt, &lastPushAt, &s.CreatedAt, &s.UpdatedAt,
+		&snUpdatedOn, &lastPullAt, &lastPushAt, &s.CreatedAt, &s.UpdatedAt, &deletedAt, &conflictStrategy, &s.ImportMode,
 	)
 	if err == sql.ErrNoRows {
 		return nil, nil
@@ -49,6 +57,7 @@ func (r *SystemRepository) GetByID(ctx context.Context, id uuid.UUID) (*system.S
 	s.Description = description.String
 	s.Acronym = acronym.String
 	s.Owner = owner.String
+	s.ConflictStrategy = conflictStrategy.String
 	if snUpdatedOn.Valid {
 		s.SNUpdatedOn = &snUpdatedOn.Time
 	}
@@ -58,26 +67,33 @@ func (r *SystemRepository) GetByID(ctx context.Context, id uuid.UUID) (*system.S
 	if lastPushAt.Valid {
 		s.LastPushAt = &lastPushAt.Time
 	}
+	if deletedAt.Valid {
+		s.DeletedAt = &deletedAt.Time
+	}
 
 	return &s, nil
 }
 
 // GetBySNSysID retrieves a system by its ServiceNow sys_id.
 func (r *SystemRepository) GetBySNSysID(ctx context.Context, snSysID string) (*system.System, error) {
+	ctx, cancel := r.timeouts.singleRow(ctx)
+	defer cancel()
+
 	query := `
 		SELECT id, sn_sys_id, name, description, acronym, owner, status,
-		       sn_updated_on, last_pull_at, last_push_at, created_at, updated_at
+		       sn_updated_on, last_pull_at, last_push_at, created_at, updated_at, deleted_at,
+		       conflict_resolution_strategy, import_mode
 		FROM systems
-		WHERE sn_sys_id = $1
+		WHERE sn_sys_id = $1 AND deleted_at IS NULL
 	`
 
 	var s system.System
-	var description, acronym, owner sql.NullString
-	var snUpdatedOn, lastPullAt, lastPushAt sql.NullTime
+	var description, acronym, owner, conflictStrategy sql.NullString
+	var snUpdatedOn, lastPullAt, lastPushAt, deletedAt sql.NullTime
 
 	err := r.db.QueryRowContext(ctx, query, snSysID).Scan(
 		&s.ID, &s.SNSysID, &s.Name, &description, &acronym, &owner, &s.Status,
-		&snUpdatedOn, &lastPullAt, &lastPushAt, &s.CreatedAt, &s.UpdatedAt,
+		&snUpdatedOn, &lastPullAt, &lastPushAt, &s.CreatedAt, &s.UpdatedAt, &deletedAt, &conflictStrategy, &s.ImportMode,
 	)
 	if err == sql.ErrNoRows {
 		return nil, nil
@@ -89,6 +105,7 @@ func (r *SystemRepository) GetBySNSysID(ctx context.Context, snSysID string) (*s
 	s.Description = description.String
 	s.Acronym = acronym.String
 	s.Owner = owner.String
+	s.ConflictStrategy = conflictStrategy.String
 	if snUpdatedOn.Valid {
 		s.SNUpdatedOn = &snUpdatedOn.Time
 	}
@@ -98,17 +115,27 @@ func (r *SystemRepository) GetBySNSysID(ctx context.Context, snSysID string) (*s
 	if lastPushAt.Valid {
 		s.LastPushAt = &lastPushAt.Time
 	}
+	if deletedAt.Valid {
+		s.DeletedAt = &deletedAt.Time
+	}
 
 	return &s, nil
 }
 
 // List retrieves systems with pagination and optional filters.
 func (r *SystemRepository) List(ctx context.Context, params system.ListParams) (*system.ListResult, error) {
+	ctx, cancel := r.timeouts.list(ctx)
+	defer cancel()
+
 	// Build query with filters
 	var conditions []string
 	var args []interface{}
 	argNum := 1
 
+	if !params.IncludeDeleted {
+		conditions = append(conditions, "s.deleted_at IS NULL")
+	}
+
 	if params.Status != "" {
 		conditions = append(conditions, fmt.Sprintf("s.status = $%d", argNum))
 		args = append(args, params.Status)
@@ -140,14 +167,8 @@ func (r *SystemRepository) List(ctx context.Context, params system.ListParams) (
 	// Fetch systems with stats
 	query := fmt.Sprintf(`
 		SELECT s.id, s.sn_sys_id, s.name, s.description, s.acronym, s.owner, s.status,
-		       s.sn_updated_on, s.last_pull_at, s.last_push_at, s.created_at, s.updated_at,
-		       COALESCE((SELECT COUNT(*) FROM controls c WHERE c.system_id = s.id), 0) as control_count,
-		       COALESCE((SELECT COUNT(*) FROM statements st
-		                 JOIN controls c ON st.control_id = c.id
-		                 WHERE c.system_id = s.id), 0) as statement_count,
-		       COALESCE((SELECT COUNT(*) FROM statements st
-		                 JOIN controls c ON st.control_id = c.id
-		                 WHERE c.system_id = s.id AND st.is_modified = true), 0) as modified_count
+		       s.sn_updated_on, s.last_pull_at, s.last_push_at, s.created_at, s.updated_at, s.deleted_at, s.conflict_resolution_strategy, s.import_mode,
+		       s.control_count, s.statement_count, s.modified_count
 		FROM systems s
 		%s
 		ORDER BY s.name ASC
@@ -165,12 +186,12 @@ func (r *SystemRepository) List(ctx context.Context, params system.ListParams) (
 	systems := make([]system.SystemWithStats, 0)
 	for rows.Next() {
 		var s system.SystemWithStats
-		var description, acronym, owner sql.NullString
-		var snUpdatedOn, lastPullAt, lastPushAt sql.NullTime
+		var description, acronym, owner, conflictStrategy sql.NullString
+		var snUpdatedOn, lastPullAt, lastPushAt, deletedAt sql.NullTime
 
 		err := rows.Scan(
 			&s.ID, &s.SNSysID, &s.Name, &description, &acronym, &owner, &s.Status,
-			&snUpdatedOn, &lastPullAt, &lastPushAt, &s.CreatedAt, &s.UpdatedAt,
+			&snUpdatedOn, &lastPullAt, &lastPushAt, &s.CreatedAt, &s.UpdatedAt, &deletedAt, &conflictStrategy, &s.ImportMode,
 			&s.ControlCount, &s.StatementCount, &s.ModifiedCount,
 		)
 		if err != nil {
@@ -180,6 +201,7 @@ func (r *SystemRepository) List(ctx context.Context, params system.ListParams) (
 		s.Description = description.String
 		s.Acronym = acronym.String
 		s.Owner = owner.String
+		s.ConflictStrategy = conflictStrategy.String
 		if snUpdatedOn.Valid {
 			s.SNUpdatedOn = &snUpdatedOn.Time
 		}
@@ -189,6 +211,9 @@ func (r *SystemRepository) List(ctx context.Context, params system.ListParams) (
 		if lastPushAt.Valid {
 			s.LastPushAt = &lastPushAt.Time
 		}
+		if deletedAt.Valid {
+			s.DeletedAt = &deletedAt.Time
+		}
 
 		systems = append(systems, s)
 	}
@@ -204,10 +229,15 @@ func (r *SystemRepository) List(ctx context.Context, params system.ListParams) (
 
 // ListAll retrieves all systems without pagination.
 func (r *SystemRepository) ListAll(ctx context.Context) ([]system.System, error) {
+	ctx, cancel := r.timeouts.list(ctx)
+	defer cancel()
+
 	query := `
 		SELECT id, sn_sys_id, name, description, acronym, owner, status,
-		       sn_updated_on, last_pull_at, last_push_at, created_at, updated_at
+		       sn_updated_on, last_pull_at, last_push_at, created_at, updated_at, deleted_at,
+		       conflict_resolution_strategy, import_mode
 		FROM systems
+		WHERE deleted_at IS NULL
 		ORDER BY name ASC
 	`
 
@@ -220,12 +250,12 @@ func (r *SystemRepository) ListAll(ctx context.Context) ([]system.System, error)
 	systems := make([]system.System, 0)
 	for rows.Next() {
 		var s system.System
-		var description, acronym, owner sql.NullString
-		var snUpdatedOn, lastPullAt, lastPushAt sql.NullTime
+		var description, acronym, owner, conflictStrategy sql.NullString
+		var snUpdatedOn, lastPullAt, lastPushAt, deletedAt sql.NullTime
 
 		err := rows.Scan(
 			&s.ID, &s.SNSysID, &s.Name, &description, &acronym, &owner, &s.Status,
-			&snUpdatedOn, &lastPullAt, &lastPushAt, &s.CreatedAt, &s.UpdatedAt,
+			&snUpdatedOn, &lastPullAt, &lastPushAt, &s.CreatedAt, &s.UpdatedAt, &deletedAt, &conflictStrategy, &s.ImportMode,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan system: %w", err)
@@ -234,6 +264,7 @@ func (r *SystemRepository) ListAll(ctx context.Context) ([]system.System, error)
 		s.Description = description.String
 		s.Acronym = acronym.String
 		s.Owner = owner.String
+		s.ConflictStrategy = conflictStrategy.String
 		if snUpdatedOn.Valid {
 			s.SNUpdatedOn = &snUpdatedOn.Time
 		}
@@ -243,6 +274,9 @@ func (r *SystemRepository) ListAll(ctx context.Context) ([]system.System, error)
 		if lastPushAt.Valid {
 			s.LastPushAt = &lastPushAt.Time
 		}
+		if deletedAt.Valid {
+			s.DeletedAt = &deletedAt.Time
+		}
 
 		systems = append(systems, s)
 	}
@@ -252,9 +286,12 @@ func (r *SystemRepository) ListAll(ctx context.Context) ([]system.System, error)
 
 // Upsert creates or updates a system based on sn_sys_id.
 func (r *SystemRepository) Upsert(ctx context.Context, input system.UpsertInput) (*system.System, error) {
+	ctx, cancel := r.timeouts.singleRow(ctx)
+	defer cancel()
+
 	query := `
-		INSERT INTO systems (sn_sys_id, name, description, acronym, owner, status, sn_updated_on, last_pull_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, NOW())
+		INSERT INTO systems (sn_sys_id, name, description, acronym, owner, status, sn_updated_on, last_pull_at, import_mode)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NOW(), COALESCE($8::system_import_mode, 'editable'))
 		ON CONFLICT (sn_sys_id)
 		DO UPDATE SET
 			name = EXCLUDED.name,
@@ -264,9 +301,12 @@ func (r *SystemRepository) Upsert(ctx context.Context, input system.UpsertInput)
 			status = EXCLUDED.status,
 			sn_updated_on = EXCLUDED.sn_updated_on,
 			last_pull_at = NOW(),
-			updated_at = NOW()
+			updated_at = NOW(),
+			deleted_at = NULL,
+			import_mode = COALESCE($8::system_import_mode, systems.import_mode)
 		RETURNING id, sn_sys_id, name, description, acronym, owner, status,
-		          sn_updated_on, last_pull_at, last_push_at, created_at, updated_at
+		          sn_updated_on, last_pull_at, last_push_at, created_at, updated_at, deleted_at,
+		          conflict_resolution_strategy, import_mode
 	`
 
 	status := input.Status
@@ -275,14 +315,14 @@ func (r *SystemRepository) Upsert(ctx context.Context, input system.UpsertInput)
 	}
 
 	var s system.System
-	var description, acronym, owner sql.NullString
-	var snUpdatedOn, lastPullAt, lastPushAt sql.NullTime
+	var description, acronym, owner, conflictStrategy sql.NullString
+	var snUpdatedOn, lastPullAt, lastPushAt, deletedAt sql.NullTime
 
 	err := r.db.QueryRowContext(ctx, query,
-		input.SNSysID, input.Name, input.Description, input.Acronym, input.Owner, status, input.SNUpdatedOn,
+		input.SNSysID, input.Name, input.Description, input.Acronym, input.Owner, status, input.SNUpdatedOn, sql.NullString{String: input.ImportMode, Valid: input.ImportMode != ""},
 	).Scan(
 		&s.ID, &s.SNSysID, &s.Name, &description, &acronym, &owner, &s.Status,
-		&snUpdatedOn, &lastPullAt, &lastPushAt, &s.CreatedAt, &s.UpdatedAt,
+		&snUpdatedOn, &lastPullAt, &lastPushAt, &s.CreatedAt, &s.UpdatedAt, &deletedAt, &conflictStrategy, &s.ImportMode,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to upsert system: %w", err)
@@ -291,6 +331,7 @@ func (r *SystemRepository) Upsert(ctx context.Context, input system.UpsertInput)
 	s.Description = description.String
 	s.Acronym = acronym.String
 	s.Owner = owner.String
+	s.ConflictStrategy = conflictStrategy.String
 	if snUpdatedOn.Valid {
 		s.SNUpdatedOn = &snUpdatedOn.Time
 	}
@@ -300,12 +341,18 @@ func (r *SystemRepository) Upsert(ctx context.Context, input system.UpsertInput)
 	if lastPushAt.Valid {
 		s.LastPushAt = &lastPushAt.Time
 	}
+	if deletedAt.Valid {
+		s.DeletedAt = &deletedAt.Time
+	}
 
 	return &s, nil
 }
 
 // UpsertBatch creates or updates multiple systems.
 func (r *SystemRepository) UpsertBatch(ctx context.Context, inputs []system.UpsertInput) ([]system.System, error) {
+	ctx, cancel := r.timeouts.batch(ctx)
+	defer cancel()
+
 	if len(inputs) == 0 {
 		return []system.System{}, nil
 	}
@@ -321,8 +368,8 @@ func (r *SystemRepository) UpsertBatch(ctx context.Context, inputs []system.Upse
 
 	for _, input := range inputs {
 		query := `
-			INSERT INTO systems (sn_sys_id, name, description, acronym, owner, status, sn_updated_on, last_pull_at)
-			VALUES ($1, $2, $3, $4, $5, $6, $7, NOW())
+			INSERT INTO systems (sn_sys_id, name, description, acronym, owner, status, sn_updated_on, last_pull_at, import_mode)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, NOW(), COALESCE($8::system_import_mode, 'editable'))
 			ON CONFLICT (sn_sys_id)
 			DO UPDATE SET
 				name = EXCLUDED.name,
@@ -332,9 +379,12 @@ func (r *SystemRepository) UpsertBatch(ctx context.Context, inputs []system.Upse
 				status = EXCLUDED.status,
 				sn_updated_on = EXCLUDED.sn_updated_on,
 				last_pull_at = NOW(),
-				updated_at = NOW()
+				updated_at = NOW(),
+				deleted_at = NULL,
+				import_mode = COALESCE($8::system_import_mode, systems.import_mode)
 			RETURNING id, sn_sys_id, name, description, acronym, owner, status,
-			          sn_updated_on, last_pull_at, last_push_at, created_at, updated_at
+			          sn_updated_on, last_pull_at, last_push_at, created_at, updated_at, deleted_at,
+			          conflict_resolution_strategy, import_mode
 		`
 
 		status := input.Status
@@ -343,14 +393,14 @@ func (r *SystemRepository) UpsertBatch(ctx context.Context, inputs []system.Upse
 		}
 
 		var s system.System
-		var description, acronym, owner sql.NullString
-		var snUpdatedOn, lastPullAt, lastPushAt sql.NullTime
+		var description, acronym, owner, conflictStrategy sql.NullString
+		var snUpdatedOn, lastPullAt, lastPushAt, deletedAt sql.NullTime
 
 		err := tx.QueryRowContext(ctx, query,
-			input.SNSysID, input.Name, input.Description, input.Acronym, input.Owner, status, input.SNUpdatedOn,
+			input.SNSysID, input.Name, input.Description, input.Acronym, input.Owner, status, input.SNUpdatedOn, sql.NullString{String: input.ImportMode, Valid: input.ImportMode != ""},
 		).Scan(
 			&s.ID, &s.SNSysID, &s.Name, &description, &acronym, &owner, &s.Status,
-			&snUpdatedOn, &lastPullAt, &lastPushAt, &s.CreatedAt, &s.UpdatedAt,
+			&snUpdatedOn, &lastPullAt, &lastPushAt, &s.CreatedAt, &s.UpdatedAt, &deletedAt, &conflictStrategy, &s.ImportMode,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to upsert system %s: %w", input.SNSysID, err)
@@ -359,6 +409,7 @@ func (r *SystemRepository) UpsertBatch(ctx context.Context, inputs []system.Upse
 		s.Description = description.String
 		s.Acronym = acronym.String
 		s.Owner = owner.String
+		s.ConflictStrategy = conflictStrategy.String
 		if snUpdatedOn.Valid {
 			s.SNUpdatedOn = &snUpdatedOn.Time
 		}
@@ -368,6 +419,9 @@ func (r *SystemRepository) UpsertBatch(ctx context.Context, inputs []system.Upse
 		if lastPushAt.Valid {
 			s.LastPushAt = &lastPushAt.Time
 		}
+		if deletedAt.Valid {
+			s.DeletedAt = &deletedAt.Time
+		}
 
 		systems = append(systems, s)
 	}
@@ -379,10 +433,130 @@ func (r *SystemRepository) UpsertBatch(ctx context.Context, inputs []system.Upse
 	return systems, nil
 }
 
-// Delete removes a system and all its related controls/statements.
+// GetSummary retrieves aggregate control and statement counts for a system in a single query.
+func (r *SystemRepository) GetSummary(ctx context.Context, id uuid.UUID) (*system.SystemSummary, error) {
+	ctx, cancel := r.timeouts.list(ctx)
+	defer cancel()
+
+	query := `
+		SELECT s.id, s.name, s.last_pull_at,
+		       COUNT(DISTINCT c.id) AS total_controls,
+		       COUNT(st.id) AS total_statements,
+		       COUNT(st.id) FILTER (WHERE st.sync_status = 'synced') AS synced_count,
+		       COUNT(st.id) FILTER (WHERE st.sync_status = 'modified') AS modified_count,
+		       COUNT(st.id) FILTER (WHERE st.sync_status = 'conflict') AS conflict_count
+		FROM systems s
+		LEFT JOIN controls c ON c.system_id = s.id
+		LEFT JOIN statements st ON st.control_id = c.id
+		WHERE s.id = $1 AND s.deleted_at IS NULL
+		GROUP BY s.id, s.name, s.last_pull_at
+	`
+
+	var summary system.SystemSummary
+	var lastPullAt sql.NullTime
+
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&summary.SystemID, &summary.Name, &lastPullAt,
+		&summary.TotalControls, &summary.TotalStatements,
+		&summary.SyncedCount, &summary.ModifiedCount, &summary.ConflictCount,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get system summary: %w", err)
+	}
+
+	if lastPullAt.Valid {
+		summary.LastPullAt = &lastPullAt.Time
+	}
+
+	return &summary, nil
+}
+
+// GetControlFamilyStats retrieves control and statement counts per control
+// family of a system, ordered by family. Controls without a family are
+// grouped under an empty family.
+func (r *SystemRepository) GetControlFamilyStats(ctx context.Context, systemID uuid.UUID) ([]system.ControlFamilyStats, error) {
+	ctx, cancel := r.timeouts.list(ctx)
+	defer cancel()
+
+	query := `
+		SELECT COALESCE(c.control_family, '') AS family,
+		       COUNT(DISTINCT c.id) AS total_controls,
+		       COUNT(st.id) FILTER (WHERE st.sync_status = 'synced') AS synced,
+		       COUNT(st.id) FILTER (WHERE st.sync_status = 'modified') AS modified,
+		       COUNT(st.id) FILTER (WHERE st.sync_status = 'conflict') AS conflict
+		FROM controls c
+		LEFT JOIN statements st ON st.control_id = c.id
+		WHERE c.system_id = $1
+		GROUP BY COALESCE(c.control_family, '')
+		ORDER BY family
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, systemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get control family stats: %w", err)
+	}
+	defer rows.Close()
+
+	var results []system.ControlFamilyStats
+	for rows.Next() {
+		var s system.ControlFamilyStats
+		if err := rows.Scan(&s.Family, &s.TotalControls, &s.SyncedStatements, &s.ModifiedStatements, &s.ConflictStatements); err != nil {
+			return nil, fmt.Errorf("failed to scan control family stats: %w", err)
+		}
+		results = append(results, s)
+	}
+
+	return results, rows.Err()
+}
+
+// GetSyncStatusCounts retrieves statement sync status counts for every
+// system that is not deleted, ordered by name.
+func (r *SystemRepository) GetSyncStatusCounts(ctx context.Context) ([]system.SystemSyncStatus, error) {
+	ctx, cancel := r.timeouts.list(ctx)
+	defer cancel()
+
+	query := `
+		SELECT s.id, s.name,
+		       COUNT(st.id) FILTER (WHERE st.sync_status = 'synced') AS synced,
+		       COUNT(st.id) FILTER (WHERE st.sync_status = 'modified') AS modified,
+		       COUNT(st.id) FILTER (WHERE st.sync_status = 'conflict') AS conflict,
+		       COUNT(st.id) AS total
+		FROM systems s
+		LEFT JOIN controls c ON c.system_id = s.id
+		LEFT JOIN statements st ON st.control_id = c.id
+		WHERE s.deleted_at IS NULL
+		GROUP BY s.id, s.name
+		ORDER BY s.name
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sync status counts: %w", err)
+	}
+	defer rows.Close()
+
+	var results []system.SystemSyncStatus
+	for rows.Next() {
+		var s system.SystemSyncStatus
+		if err := rows.Scan(&s.SystemID, &s.Name, &s.Synced, &s.Modified, &s.Conflict, &s.Total); err != nil {
+			return nil, fmt.Errorf("failed to scan sync status counts: %w", err)
+		}
+		results = append(results, s)
+	}
+
+	return results, rows.Err()
+}
+
+// Delete soft-deletes a system. Its controls and statements are kept so the
+// system can be restored.
 func (r *SystemRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	// CASCADE will handle controls and statements
-	query := `DELETE FROM systems WHERE id = $1`
+	ctx, cancel := r.timeouts.singleRow(ctx)
+	defer cancel()
+
+	query := `UPDATE systems SET deleted_at = NOW(), updated_at = NOW() WHERE id = $1 AND deleted_at IS NULL`
 	result, err := r.db.ExecContext(ctx, query, id)
 	if err != nil {
 		return fmt.Errorf("failed to delete system: %w", err)
@@ -396,10 +570,276 @@ func (r *SystemRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
-// UpdateLastPullAt updates the last pull timestamp.
-func (r *SystemRepository) UpdateLastPullAt(ctx context.Context, id uuid.UUID) error {
-	query := `UPDATE systems SET last_pull_at = $1, updated_at = $1 WHERE id = $2`
-	_, err := r.db.ExecContext(ctx, query, time.Now(), id)
+// systemMetadataColumns are the columns UpdateMetadata may write. Column
+// names are never taken from input outside this list.
+var systemMetadataColumns = map[string]bool{
+	"acronym":     true,
+	"owner":       true,
+	"description": true,
+}
+
+// UpdateMetadata applies a metadata patch to a system.
+func (r *SystemRepository) UpdateMetadata(ctx context.Context, id uuid.UUID, patch system.MetadataPatch) error {
+	ctx, cancel := r.timeouts.singleRow(ctx)
+	defer cancel()
+
+	updates := map[string]interface{}{}
+	if patch.Acronym != nil {
+		updates["acronym"] = *patch.Acronym
+	}
+	if patch.Owner != nil {
+		updates["owner"] = *patch.Owner
+	}
+	if patch.Description != nil {
+		updates["description"] = *patch.Description
+	}
+
+	query, args, err := buildMetadataUpdate(id, updates)
+	if err != nil || query == "" {
+		return err
+	}
+
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to update system metadata: %w", err)
+	}
+
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return system.ErrNotFound
+	}
+
+	return nil
+}
+
+// UpdateStatusBatch sets the status of the given non-deleted systems in a
+// single statement.
+func (r *SystemRepository) UpdateStatusBatch(ctx context.Context, ids []uuid.UUID, status string) ([]uuid.UUID, error) {
+	ctx, cancel := r.timeouts.batch(ctx)
+	defer cancel()
+
+	query := `
+		UPDATE systems SET status = $1, updated_at = NOW()
+		WHERE id = ANY($2) AND deleted_at IS NULL
+		RETURNING id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, status, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("failed to update system status: %w", err)
+	}
+	defer rows.Close()
+
+	updated := make([]uuid.UUID, 0, len(ids))
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan system id: %w", err)
+		}
+		updated = append(updated, id)
+	}
+	return updated, rows.Err()
+}
+
+// buildMetadataUpdate builds the UPDATE statement for the given column
+// values. Columns are sorted so the same patch always yields the same
+// query. It returns an empty query when there is nothing to update.
+func buildMetadataUpdate(id uuid.UUID, updates map[string]interface{}) (string, []interface{}, error) {
+	if len(updates) == 0 {
+		return "", nil, nil
+	}
+
+	columns := make([]string, 0, len(updates))
+	for column := range updates {
+		if !systemMetadataColumns[column] {
+			return "", nil, fmt.Errorf("column %q is not updatable", column)
+		}
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+
+	args := []interface{}{id}
+	sets := make([]string, 0, len(columns)+1)
+	for _, column := range columns {
+		args = append(args, updates[column])
+		sets = append(sets, fmt.Sprintf("%s = NULLIF($%d, '')", column, len(args)))
+	}
+	sets = append(sets, "updated_at = NOW()")
+
+	query := fmt.Sprintf(`UPDATE systems SET %s WHERE id = $1 AND deleted_at IS NULL`, strings.Join(sets, ", "))
+	return query, args, nil
+}
+
+// Restore clears the soft-delete marker of a system.
+func (r *SystemRepository) Restore(ctx context.Context, id uuid.UUID) (*system.System, error) {
+	ctx, cancel := r.timeouts.singleRow(ctx)
+	defer cancel()
+
+	query := `
+		UPDATE systems
+		SET deleted_at = NULL, updated_at = NOW()
+		WHERE id = $1
+		RETURNING id, sn_sys_id, name, description, acronym, owner, status,
+		          sn_updated_on, last_pull_at, last_push_at, created_at, updated_at, deleted_at,
+		          conflict_resolution_strategy, import_mode
+	`
+
+	var s system.System
+	var description, acronym, owner, conflictStrategy sql.NullString
+	var snUpdatedOn, lastPullAt, lastPushAt, deletedAt sql.NullTime
+
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&s.ID, &s.SNSysID, &s.Name, &description, &acronym, &owner, &s.Status,
+		&snUpdatedOn, &lastPullAt, &lastPushAt, &s.CreatedAt, &s.UpdatedAt, &deletedAt, &conflictStrategy, &s.ImportMode,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore system: %w", err)
+	}
+
+	s.Description = description.String
+	s.Acronym = acronym.String
+	s.Owner = owner.String
+	s.ConflictStrategy = conflictStrategy.String
+	if snUpdatedOn.Valid {
+		s.SNUpdatedOn = &snUpdatedOn.Time
+	}
+	if lastPullAt.Valid {
+		s.LastPullAt = &lastPullAt.Time
+	}
+	if lastPushAt.Valid {
+		s.LastPushAt = &lastPushAt.Time
+	}
+
+	return &s, nil
+}
+
+// SetConflictStrategy sets the system's conflict strategy override; empty stores NULL.
+func (r *SystemRepository) SetConflictStrategy(ctx context.Context, id uuid.UUID, strategy string) (*system.System, error) {
+	ctx, cancel := r.timeouts.singleRow(ctx)
+	defer cancel()
+
+	query := `
+		UPDATE systems
+		SET conflict_resolution_strategy = NULLIF($2, ''), updated_at = NOW()
+		WHERE id = $1 AND deleted_at IS NULL
+		RETURNING id, sn_sys_id, name, description, acronym, owner, status,
+		          sn_updated_on, last_pull_at, last_push_at, created_at, updated_at, deleted_at,
+		          conflict_resolution_strategy, import_mode
+	`
+
+	var s system.System
+	var description, acronym, owner, conflictStrategy sql.NullString
+	var snUpdatedOn, lastPullAt, lastPushAt, deletedAt sql.NullTime
+
+	err := r.db.QueryRowContext(ctx, query, id, strategy).Scan(
+		&s.ID, &s.SNSysID, &s.Name, &description, &acronym, &owner, &s.Status,
+		&snUpdatedOn, &lastPullAt, &lastPushAt, &s.CreatedAt, &s.UpdatedAt, &deletedAt, &conflictStrategy, &s.ImportMode,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to set system conflict strategy: %w", err)
+	}
+
+	s.Description = description.String
+	s.Acronym = acronym.String
+	s.Owner = owner.String
+	s.ConflictStrategy = conflictStrategy.String
+	if snUpdatedOn.Valid {
+		s.SNUpdatedOn = &snUpdatedOn.Time
+	}
+	if lastPullAt.Valid {
+		s.LastPullAt = &lastPullAt.Time
+	}
+	if lastPushAt.Valid {
+		s.LastPushAt = &lastPushAt.Time
+	}
+
+	return &s, nil
+}
+
+// RecomputeCounts rebuilds the denormalized statement and control counts of
+// every control and system from the underlying rows. It returns how many
+// controls and systems held a stale count.
+func (r *SystemRepository) RecomputeCounts(ctx context.Context) (*system.RecomputeCountsResult, error) {
+	ctx, cancel := r.timeouts.batch(ctx)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Block writers so triggers cannot change counts mid-rebuild
+	if _, err := tx.ExecContext(ctx, `LOCK TABLE systems, controls, statements IN SHARE MODE`); err != nil {
+		return nil, fmt.Errorf("failed to lock tables: %w", err)
+	}
+
+	controlsQuery := `
+		UPDATE controls c
+		SET statement_count = actual.total, modified_count = actual.modified
+		FROM (
+			SELECT c2.id,
+			       COUNT(st.id) AS total,
+			       COUNT(st.id) FILTER (WHERE st.is_modified) AS modified
+			FROM controls c2
+			LEFT JOIN statements st ON st.control_id = c2.id
+			GROUP BY c2.id
+		) actual
+		WHERE c.id = actual.id
+		  AND (c.statement_count, c.modified_count) IS DISTINCT FROM (actual.total, actual.modified)
+	`
+	result, err := tx.ExecContext(ctx, controlsQuery)
+	if err != nil {
+		return nil, fmt.Errorf("failed to recompute control counts: %w", err)
+	}
+	controls, _ := result.RowsAffected()
+
+	systemsQuery := `
+		UPDATE systems s
+		SET control_count = actual.controls, statement_count = actual.statements, modified_count = actual.modified
+		FROM (
+			SELECT s2.id,
+			       COUNT(c.id) AS controls,
+			       COALESCE(SUM(c.statement_count), 0) AS statements,
+			       COALESCE(SUM(c.modified_count), 0) AS modified
+			FROM systems s2
+			LEFT JOIN controls c ON c.system_id = s2.id
+			GROUP BY s2.id
+		) actual
+		WHERE s.id = actual.id
+		  AND (s.control_count, s.statement_count, s.modified_count)
+		      IS DISTINCT FROM (actual.controls, actual.statements, actual.modified)
+	`
+	result, err = tx.ExecContext(ctx, systemsQuery)
+	if err != nil {
+		return nil, fmt.Errorf("failed to recompute system counts: %w", err)
+	}
+	systems, _ := result.RowsAffected()
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return &system.RecomputeCountsResult{
+		ControlsUpdated: int(controls),
+		SystemsUpdated:  int(systems),
+	}, nil
+}
+
+// UpdateLastPullAt updates the last pull timestamp and the connection
+// pulled from.
+func (r *SystemRepository) UpdateLastPullAt(ctx context.Context, id uuid.UUID, connectionLabel string) error {
+	ctx, cancel := r.timeouts.singleRow(ctx)
+	defer cancel()
+
+	query := `UPDATE systems SET last_pull_at = $1, updated_at = $1, connection_label = $2 WHERE id = $3`
+	_, err := r.db.ExecContext(ctx, query, time.Now(), connectionLabel, id)
 	if err != nil {
 		return fmt.Errorf("failed to update last_pull_at: %w", err)
 	}
@@ -408,7 +848,10 @@ func (r *SystemRepository) UpdateLastPullAt(ctx context.Context, id uuid.UUID) e
 
 // GetAllSNSysIDs returns all ServiceNow sys_ids for existing systems.
 func (r *SystemRepository) GetAllSNSysIDs(ctx context.Context) ([]string, error) {
-	query := `SELECT sn_sys_id FROM systems`
+	ctx, cancel := r.timeouts.list(ctx)
+	defer cancel()
+
+	query := `SELECT sn_sys_id FROM systems WHERE deleted_at IS NULL`
 
 	rows, err := r.db.QueryContext(ctx, query)
 	if err != nil {
@@ -427,3 +870,45 @@ func (r *SystemRepository) GetAllSNSysIDs(ctx context.Context) ([]string, error)
 
 	return ids, nil
 }
+
+// GetDiscoveryCache returns the last cached discovery, or nil if there is none.
+func (r *SystemRepository) GetDiscoveryCache(ctx context.Context) (*system.DiscoveryCache, error) {
+	ctx, cancel := r.timeouts.singleRow(ctx)
+	defer cancel()
+
+	var cache system.DiscoveryCache
+	var systemsJSON []byte
+	err := r.db.QueryRowContext(ctx, `SELECT run_at, systems_json FROM discovery_cache`).Scan(&cache.RunAt, &systemsJSON)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get discovery cache: %w", err)
+	}
+
+	if err := json.Unmarshal(systemsJSON, &cache.Systems); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal discovery cache: %w", err)
+	}
+	return &cache, nil
+}
+
+// SaveDiscoveryCache replaces the cached discovery with the given systems.
+func (r *SystemRepository) SaveDiscoveryCache(ctx context.Context, systems []system.DiscoveredSystem) error {
+	ctx, cancel := r.timeouts.singleRow(ctx)
+	defer cancel()
+
+	systemsJSON, err := json.Marshal(systems)
+	if err != nil {
+		return fmt.Errorf("failed to marshal discovery cache: %w", err)
+	}
+
+	query := `
+		INSERT INTO discovery_cache (id, run_at, systems_json)
+		VALUES (TRUE, $1, $2)
+		ON CONFLICT (id) DO UPDATE SET run_at = EXCLUDED.run_at, systems_json = EXCLUDED.systems_json
+	`
+	if _, err := r.db.ExecContext(ctx, query, time.Now(), systemsJSON); err != nil {
+		return fmt.Errorf("failed to save discovery cache: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/controlcrud/backend/internal/infrastructure/database/migrations"
+)
+
+func TestBuildMetadataUpdate(t *testing.T) {
+	id := uuid.New()
+
+	tests := []struct {
+		name      string
+		updates   map[string]interface{}
+		wantQuery string
+		wantArgs  []interface{}
+		wantErr   bool
+	}{
+		{
+			name:      "single field",
+			updates:   map[string]interface{}{"owner": "HR"},
+			wantQuery: "UPDATE systems SET owner = NULLIF($2, ''), updated_at = NOW() WHERE id = $1 AND deleted_at IS NULL",
+			wantArgs:  []interface{}{id, "HR"},
+		},
+		{
+			name:      "fields in column order",
+			updates:   map[string]interface{}{"owner": "HR", "acronym": "PAY", "description": ""},
+			wantQuery: "UPDATE systems SET acronym = NULLIF($2, ''), description = NULLIF($3, ''), owner = NULLIF($4, ''), updated_at = NOW() WHERE id = $1 AND deleted_at IS NULL",
+			wantArgs:  []interface{}{id, "PAY", "", "HR"},
+		},
+		{
+			name:    "empty patch",
+			updates: map[string]interface{}{},
+		},
+		{
+			name:    "column outside whitelist",
+			updates: map[string]interface{}{"name = 'x', status": "active"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, args, err := buildMetadataUpdate(id, tt.updates)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if query != tt.wantQuery {
+				t.Errorf("query = %q, want %q", query, tt.wantQuery)
+			}
+			if len(args) != len(tt.wantArgs) {
+				t.Fatalf("args = %v, want %v", args, tt.wantArgs)
+			}
+			for i := range args {
+				if args[i] != tt.wantArgs[i] {
+					t.Errorf("arg %d = %v, want %v", i, args[i], tt.wantArgs[i])
+				}
+			}
+		})
+	}
+}
+
+// TestDenormalizedCounts runs against the PostgreSQL database in
+// TEST_DATABASE_URL and is skipped when it is not set.
+func TestDenormalizedCounts(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL not set")
+	}
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	defer db.Close()
+	if err := migrations.RunMigrations(db, ""); err != nil {
+		t.Fatalf("failed to run migrations: %v", err)
+	}
+
+	ctx := context.Background()
+	exec := func(query string, args ...interface{}) {
+		t.Helper()
+		if _, err := db.ExecContext(ctx, query, args...); err != nil {
+			t.Fatalf("%s: %v", query, err)
+		}
+	}
+
+	var systemID uuid.UUID
+	snSysID := strings.ReplaceAll(uuid.NewString(), "-", "")
+	err = db.QueryRowContext(ctx,
+		`INSERT INTO systems (sn_sys_id, name) VALUES ($1, 'Counts Test') RETURNING id`, snSysID,
+	).Scan(&systemID)
+	if err != nil {
+		t.Fatalf("failed to insert system: %v", err)
+	}
+	defer db.ExecContext(ctx, `DELETE FROM systems WHERE id = $1`, systemID)
+
+	controlIDs := make([]uuid.UUID, 3)
+	for i := range controlIDs {
+		err := db.QueryRowContext(ctx,
+			`INSERT INTO controls (system_id, sn_sys_id, control_id, control_name) VALUES ($1, $2, $3, 'Control') RETURNING id`,
+			systemID, fmt.Sprintf("ctl%d", i), fmt.Sprintf("AC-%d", i+1),
+		).Scan(&controlIDs[i])
+		if err != nil {
+			t.Fatalf("failed to insert control: %v", err)
+		}
+		for j := 0; j <= i; j++ {
+			exec(`INSERT INTO statements (control_id, sn_sys_id, is_modified) VALUES ($1, $2, $3)`,
+				controlIDs[i], fmt.Sprintf("stmt%d", j), j%2 == 1)
+		}
+	}
+
+	// Flip, delete, and cascade-delete rows
+	exec(`UPDATE statements SET is_modified = true WHERE control_id = $1 AND sn_sys_id = 'stmt0'`, controlIDs[2])
+	exec(`DELETE FROM statements WHERE control_id = $1 AND sn_sys_id = 'stmt1'`, controlIDs[2])
+	exec(`DELETE FROM controls WHERE id = $1`, controlIDs[1])
+	exec(`INSERT INTO statements (control_id, sn_sys_id, is_modified) VALUES ($1, 'stmt9', true)`, controlIDs[0])
+
+	assertCountsMatch := func() {
+		t.Helper()
+		var mismatched int
+		err := db.QueryRowContext(ctx, `
+			SELECT COUNT(*) FROM controls c
+			WHERE c.system_id = $1
+			  AND (c.statement_count, c.modified_count) IS DISTINCT FROM (
+			      SELECT COUNT(*), COUNT(*) FILTER (WHERE is_modified) FROM statements WHERE control_id = c.id)
+		`, systemID).Scan(&mismatched)
+		if err != nil {
+			t.Fatalf("failed to compare control counts: %v", err)
+		}
+		if mismatched != 0 {
+			t.Errorf("expected control counts to match rows, %d controls differ", mismatched)
+		}
+
+		var controls, statements, modified int
+		err = db.QueryRowContext(ctx,
+			`SELECT control_count, statement_count, modified_count FROM systems WHERE id = $1`, systemID,
+		).Scan(&controls, &statements, &modified)
+		if err != nil {
+			t.Fatalf("failed to read system counts: %v", err)
+		}
+		// AC-1: stmt0, stmt9 (modified); AC-3: stmt0 (modified), stmt2
+		if controls != 2 || statements != 4 || modified != 2 {
+			t.Errorf("expected 2 controls, 4 statements, 2 modified; got %d, %d, %d", controls, statements, modified)
+		}
+	}
+	assertCountsMatch()
+
+	// Corrupt the counts and repair them
+	exec(`UPDATE systems SET statement_count = 99 WHERE id = $1`, systemID)
+	exec(`UPDATE controls SET modified_count = 42 WHERE id = $1`, controlIDs[0])
+
+	result, err := NewSystemRepository(db, QueryTimeouts{}).RecomputeCounts(ctx)
+	if err != nil {
+		t.Fatalf("failed to recompute counts: %v", err)
+	}
+	if result.SystemsUpdated < 1 || result.ControlsUpdated < 1 {
+		t.Errorf("expected the corrupted rows to be repaired, got %+v", result)
+	}
+	assertCountsMatch()
+}
@@ -0,0 +1,34 @@
+package database
+
+import (
+	"context"
+	"time"
+)
+
+// QueryTimeouts bounds how long each kind of repository operation may hold
+// a connection. Zero disables the bound for that kind.
+type QueryTimeouts struct {
+	SingleRow time.Duration // Lookups and writes of a single row
+	List      time.Duration // Paginated lists and aggregations
+	Batch     time.Duration // Batch upserts and other multi-row writes
+}
+
+func (t QueryTimeouts) singleRow(ctx context.Context) (context.Context, context.CancelFunc) {
+	return withTimeout(ctx, t.SingleRow)
+}
+
+func (t QueryTimeouts) list(ctx context.Context) (context.Context, context.CancelFunc) {
+	return withTimeout(ctx, t.List)
+}
+
+func (t QueryTimeouts) batch(ctx context.Context) (context.Context, context.CancelFunc) {
+	return withTimeout(ctx, t.Batch)
+}
+
+// withTimeout bounds ctx by d, leaving it unbounded when d is not positive.
+func withTimeout(ctx context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	if d <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, d)
+}
@@ -0,0 +1,75 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+	"time"
+
+	_ "github.com/lib/pq"
+)
+
+func TestQueryTimeouts(t *testing.T) {
+	timeouts := QueryTimeouts{SingleRow: 5 * time.Second, List: 30 * time.Second}
+
+	tests := []struct {
+		name   string
+		bound  func(context.Context) (context.Context, context.CancelFunc)
+		want   time.Duration
+		wantOK bool
+	}{
+		{"single row", timeouts.singleRow, 5 * time.Second, true},
+		{"list", timeouts.list, 30 * time.Second, true},
+		{"zero is unbounded", timeouts.batch, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := tt.bound(context.Background())
+			defer cancel()
+
+			deadline, ok := ctx.Deadline()
+			if ok != tt.wantOK {
+				t.Fatalf("expected deadline set %v, got %v", tt.wantOK, ok)
+			}
+			if ok {
+				if remaining := time.Until(deadline); remaining > tt.want || remaining < tt.want-time.Second {
+					t.Errorf("expected deadline about %s away, got %s", tt.want, remaining)
+				}
+			}
+		})
+	}
+}
+
+// TestQueryTimeouts_SlowQuery runs against the PostgreSQL database in
+// TEST_DATABASE_URL and is skipped when it is not set.
+func TestQueryTimeouts_SlowQuery(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL not set")
+	}
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	repo := NewStatementRepository(db, QueryTimeouts{List: 200 * time.Millisecond})
+	ctx, cancel := repo.timeouts.list(context.Background())
+	defer cancel()
+
+	start := time.Now()
+	_, err = repo.db.ExecContext(ctx, "SELECT pg_sleep(10)")
+	elapsed := time.Since(start)
+
+	if err == nil {
+		t.Fatal("expected slow query to be cancelled")
+	}
+	if ctx.Err() != context.DeadlineExceeded {
+		t.Errorf("expected the deadline to have passed, got %v", ctx.Err())
+	}
+	if elapsed > 2*time.Second {
+		t.Errorf("expected query to stop near the 200ms timeout, took %s", elapsed)
+	}
+}
@@ -0,0 +1,82 @@
+// Package email sends plain-text notification emails.
+package email
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Sender delivers a plain-text email to a single recipient.
+type Sender interface {
+	Send(ctx context.Context, to, subject, body string) error
+}
+
+// SMTPSender sends email through an SMTP server. The connection is upgraded
+// with STARTTLS when the server offers it.
+type SMTPSender struct {
+	addr string
+	host string
+	auth smtp.Auth
+	from string
+}
+
+// NewSMTPSender creates a sender for the SMTP server at host:port. Mail is
+// sent from username, or from a no-reply address at host when username is
+// empty, in which case no authentication is attempted.
+func NewSMTPSender(host string, port int, username, password string) *SMTPSender {
+	s := &SMTPSender{
+		addr: net.JoinHostPort(host, strconv.Itoa(port)),
+		host: host,
+		from: username,
+	}
+	if username != "" {
+		s.auth = smtp.PlainAuth("", username, password, host)
+	} else {
+		s.from = "no-reply@" + host
+	}
+	return s
+}
+
+// Send delivers the message. The SMTP exchange is abandoned when ctx is done.
+func (s *SMTPSender) Send(ctx context.Context, to, subject, body string) error {
+	msg := buildMessage(s.from, to, subject, body)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- smtp.SendMail(s.addr, s.auth, s.from, []string{to}, msg)
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			return fmt.Errorf("failed to send email: %w", err)
+		}
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// buildMessage formats an RFC 5322 message with CRLF line endings.
+func buildMessage(from, to, subject, body string) []byte {
+	var b strings.Builder
+	b.WriteString("From: " + from + "\r\n")
+	b.WriteString("To: " + to + "\r\n")
+	b.WriteString("Subject: " + stripNewlines(subject) + "\r\n")
+	b.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(strings.ReplaceAll(body, "\r\n", "\n"), "\n", "\r\n"))
+	return []byte(b.String())
+}
+
+// stripNewlines keeps header values on one line so they cannot inject headers.
+func stripNewlines(s string) string {
+	return strings.NewReplacer("\r", " ", "\n", " ").Replace(s)
+}
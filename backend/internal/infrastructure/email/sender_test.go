@@ -0,0 +1,141 @@
+package email
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strings"
+	"testing"
+)
+
+// smtpMessage is a message received by the fake SMTP server.
+type smtpMessage struct {
+	from string
+	to   []string
+	data string
+}
+
+// startSMTPServer runs a minimal SMTP server that accepts one message and
+// sends it on the returned channel.
+func startSMTPServer(t *testing.T) (host string, port int, messages <-chan smtpMessage) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	ch := make(chan smtpMessage, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		r := bufio.NewReader(conn)
+		reply := func(line string) { conn.Write([]byte(line + "\r\n")) }
+		reply("220 localhost ESMTP")
+
+		var msg smtpMessage
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			cmd := strings.TrimRight(line, "\r\n")
+			switch verb := strings.ToUpper(strings.SplitN(cmd, " ", 2)[0]); verb {
+			case "EHLO", "HELO":
+				reply("250 localhost")
+			case "MAIL":
+				msg.from = strings.Trim(strings.TrimPrefix(cmd, "MAIL FROM:"), "<>")
+				reply("250 OK")
+			case "RCPT":
+				msg.to = append(msg.to, strings.Trim(strings.TrimPrefix(cmd, "RCPT TO:"), "<>"))
+				reply("250 OK")
+			case "DATA":
+				reply("354 End data with <CR><LF>.<CR><LF>")
+				var data strings.Builder
+				for {
+					line, err := r.ReadString('\n')
+					if err != nil {
+						return
+					}
+					if line == ".\r\n" {
+						break
+					}
+					data.WriteString(line)
+				}
+				msg.data = data.String()
+				reply("250 OK")
+				ch <- msg
+			case "QUIT":
+				reply("221 Bye")
+				return
+			default:
+				reply("250 OK")
+			}
+		}
+	}()
+
+	addr := ln.Addr().(*net.TCPAddr)
+	return addr.IP.String(), addr.Port, ch
+}
+
+func TestSMTPSender_Send(t *testing.T) {
+	host, port, messages := startSMTPServer(t)
+	sender := NewSMTPSender(host, port, "", "")
+
+	body := "Failed statements:\n- one\n- two\n"
+	err := sender.Send(context.Background(), "admin@example.com", "Push job failed", body)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	msg := <-messages
+	if msg.from != "no-reply@"+host {
+		t.Errorf("unexpected envelope sender %q", msg.from)
+	}
+	if len(msg.to) != 1 || msg.to[0] != "admin@example.com" {
+		t.Errorf("unexpected recipients %v", msg.to)
+	}
+
+	headers, gotBody, ok := strings.Cut(msg.data, "\r\n\r\n")
+	if !ok {
+		t.Fatalf("expected headers and body separated by a blank line, got %q", msg.data)
+	}
+	for _, want := range []string{
+		"To: admin@example.com",
+		"Subject: Push job failed",
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=UTF-8",
+	} {
+		if !strings.Contains(headers+"\r\n", want+"\r\n") {
+			t.Errorf("expected header %q in %q", want, headers)
+		}
+	}
+	if want := "Failed statements:\r\n- one\r\n- two\r\n"; gotBody != want {
+		t.Errorf("expected body %q, got %q", want, gotBody)
+	}
+}
+
+func TestSMTPSender_Send_Unreachable(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	port := ln.Addr().(*net.TCPAddr).Port
+	ln.Close()
+
+	sender := NewSMTPSender("127.0.0.1", port, "", "")
+	if err := sender.Send(context.Background(), "admin@example.com", "s", "b"); err == nil {
+		t.Fatal("expected error for unreachable server")
+	}
+}
+
+func TestBuildMessage_StripsHeaderNewlines(t *testing.T) {
+	msg := string(buildMessage("a@example.com", "b@example.com", "Hi\r\nBcc: evil@example.com", "body"))
+	if strings.Contains(msg, "\r\nBcc:") {
+		t.Errorf("expected subject newlines to be removed, got %q", msg)
+	}
+}
@@ -0,0 +1,129 @@
+package servicenow
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// attachmentResponse is the Attachment API response for an uploaded file.
+type attachmentResponse struct {
+	Result struct {
+		SysID string `json:"sys_id"`
+	} `json:"result"`
+}
+
+// UploadAttachment attaches a file to a ServiceNow record using the
+// Attachment API and returns the sys_id of the new attachment. The request
+// body is streamed from data, so the upload is not retried.
+func (c *SNClient) UploadAttachment(ctx context.Context, tableName, sysID, fileName string, contentType string, data io.Reader) (string, error) {
+	query := url.Values{}
+	query.Set("table_name", tableName)
+	query.Set("table_sys_id", sysID)
+	query.Set("file_name", fileName)
+	endpoint := fmt.Sprintf("%s/api/now/attachment/file?%s", c.config.InstanceURL, query.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, data)
+	if err != nil {
+		return "", fmt.Errorf("%w: failed to create request: %v", ErrConnectionFailed, err)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", contentType)
+
+	if c.auth != nil {
+		if err := c.auth.ApplyAuth(req); err != nil {
+			return "", fmt.Errorf("failed to apply auth: %w", err)
+		}
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrConnectionFailed, err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkResponseStatus(resp); err != nil {
+		return "", err
+	}
+
+	var result attachmentResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidResponse, err)
+	}
+	if result.Result.SysID == "" {
+		return "", fmt.Errorf("%w: attachment sys_id missing", ErrInvalidResponse)
+	}
+
+	return result.Result.SysID, nil
+}
+
+// DownloadAttachment fetches the content of an attachment using the
+// Attachment API. It returns the response body unread, with the content
+// type ServiceNow reports; the caller must close it.
+//
+// The client's Timeout only bounds the wait for the response headers.
+// Reading the body is bounded by ctx, so large files can be streamed for
+// as long as the caller allows.
+func (c *SNClient) DownloadAttachment(ctx context.Context, sysID string) (io.ReadCloser, string, error) {
+	endpoint := fmt.Sprintf("%s/api/now/attachment/%s/file", c.config.InstanceURL, url.PathEscape(sysID))
+
+	ctx, cancel := context.WithCancel(ctx)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		cancel()
+		return nil, "", fmt.Errorf("%w: failed to create request: %v", ErrConnectionFailed, err)
+	}
+	req.Header.Set("Accept", "*/*")
+
+	if c.auth != nil {
+		if err := c.auth.ApplyAuth(req); err != nil {
+			cancel()
+			return nil, "", fmt.Errorf("failed to apply auth: %w", err)
+		}
+	}
+
+	headerTimeout := func() bool { return true }
+	if c.config.Timeout > 0 {
+		timer := time.AfterFunc(c.config.Timeout, cancel)
+		headerTimeout = timer.Stop
+	}
+	resp, err := c.streamClient.Do(req)
+	if !headerTimeout() {
+		if err == nil {
+			resp.Body.Close()
+		}
+		cancel()
+		return nil, "", fmt.Errorf("%w: no response within %s", ErrTimeout, c.config.Timeout)
+	}
+	if err != nil {
+		cancel()
+		return nil, "", fmt.Errorf("%w: %w", ErrConnectionFailed, err)
+	}
+	if err := checkResponseStatus(resp); err != nil {
+		resp.Body.Close()
+		cancel()
+		return nil, "", err
+	}
+
+	contentType := resp.Header.Get("Content-Type")
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	return &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}, contentType, nil
+}
+
+// cancelOnClose releases a request's context when its body is closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
@@ -0,0 +1,132 @@
+package servicenow
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSNClient_UploadAttachment(t *testing.T) {
+	var gotQuery, gotType, gotBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/now/attachment/file" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		gotQuery = r.URL.RawQuery
+		gotType = r.Header.Get("Content-Type")
+		body, _ := io.ReadAll(r.Body)
+		gotBody = string(body)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"result":{"sys_id":"att123","file_name":"evidence.pdf"}}`))
+	}))
+	defer server.Close()
+
+	client, err := NewSNClient(DefaultConfig(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	sysID, err := client.UploadAttachment(context.Background(), "incident", "stmt456", "access review.pdf", "application/pdf", strings.NewReader("%PDF-1.7"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sysID != "att123" {
+		t.Errorf("expected sys_id att123, got %q", sysID)
+	}
+	if want := "file_name=access+review.pdf&table_name=incident&table_sys_id=stmt456"; gotQuery != want {
+		t.Errorf("expected query %q, got %q", want, gotQuery)
+	}
+	if gotType != "application/pdf" || gotBody != "%PDF-1.7" {
+		t.Errorf("expected PDF body, got %q with content type %q", gotBody, gotType)
+	}
+}
+
+func TestSNClient_UploadAttachment_AuthFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	client, err := NewSNClient(DefaultConfig(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	_, err = client.UploadAttachment(context.Background(), "incident", "stmt456", "a.txt", "text/plain", strings.NewReader("x"))
+	if !errors.Is(err, ErrAuthFailed) {
+		t.Errorf("expected ErrAuthFailed, got %v", err)
+	}
+}
+
+func TestSNClient_DownloadAttachment(t *testing.T) {
+	pdf := []byte("%PDF-1.7\n1 0 obj <<>> endobj\n%%EOF\n")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/api/now/attachment/att123/file" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/pdf")
+		w.Write(pdf)
+	}))
+	defer server.Close()
+
+	client, err := NewSNClient(DefaultConfig(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	content, contentType, err := client.DownloadAttachment(context.Background(), "att123")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer content.Close()
+	got, _ := io.ReadAll(content)
+	if string(got) != string(pdf) {
+		t.Errorf("expected PDF bytes, got %q", got)
+	}
+	if contentType != "application/pdf" {
+		t.Errorf("expected application/pdf, got %q", contentType)
+	}
+}
+
+func TestSNClient_DownloadAttachment_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client, err := NewSNClient(DefaultConfig(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	if _, _, err := client.DownloadAttachment(context.Background(), "gone"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestSNClient_DownloadAttachment_HeaderTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(time.Second):
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+
+	config := DefaultConfig(server.URL)
+	config.Timeout = 50 * time.Millisecond
+	client, err := NewSNClient(config)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	if _, _, err := client.DownloadAttachment(context.Background(), "slow"); !errors.Is(err, ErrTimeout) {
+		t.Errorf("expected ErrTimeout waiting for headers, got %v", err)
+	}
+}
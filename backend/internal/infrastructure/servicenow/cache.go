@@ -0,0 +1,57 @@
+package servicenow
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultCacheTTL is how long cached Table API responses are reused when the
+// client config does not set a TTL.
+const DefaultCacheTTL = 60 * time.Second
+
+// Cache stores raw ServiceNow responses so repeated reads within the TTL do
+// not reach the instance.
+type Cache interface {
+	// Get returns the cached value for key, if present and not expired.
+	Get(key string) ([]byte, bool)
+
+	// Set stores value under key until ttl has elapsed.
+	Set(key string, value []byte, ttl time.Duration)
+}
+
+// cacheEntry is a cached value with its expiry time.
+type cacheEntry struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+// MemoryCache is an in-process Cache. Expired entries are dropped when they
+// are next read.
+type MemoryCache struct {
+	entries sync.Map // map[string]*cacheEntry
+	now     func() time.Time
+}
+
+// NewMemoryCache creates an empty in-memory cache.
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{now: time.Now}
+}
+
+// Get returns the cached value for key, if present and not expired.
+func (c *MemoryCache) Get(key string) ([]byte, bool) {
+	v, ok := c.entries.Load(key)
+	if !ok {
+		return nil, false
+	}
+	entry := v.(*cacheEntry)
+	if !c.now().Before(entry.expiresAt) {
+		c.entries.CompareAndDelete(key, v)
+		return nil, false
+	}
+	return entry.value, true
+}
+
+// Set stores value under key until ttl has elapsed.
+func (c *MemoryCache) Set(key string, value []byte, ttl time.Duration) {
+	c.entries.Store(key, &cacheEntry{value: value, expiresAt: c.now().Add(ttl)})
+}
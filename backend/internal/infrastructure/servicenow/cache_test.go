@@ -0,0 +1,128 @@
+package servicenow
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMemoryCache_Expiry(t *testing.T) {
+	now := time.Now()
+	cache := NewMemoryCache()
+	cache.now = func() time.Time { return now }
+
+	cache.Set("key", []byte("value"), time.Minute)
+	if got, ok := cache.Get("key"); !ok || string(got) != "value" {
+		t.Fatalf("expected cached value, got %q (found=%v)", got, ok)
+	}
+
+	now = now.Add(time.Minute)
+	if _, ok := cache.Get("key"); ok {
+		t.Error("expected entry to expire after its TTL")
+	}
+	if _, ok := cache.Get("missing"); ok {
+		t.Error("expected miss for unknown key")
+	}
+}
+
+func TestFetchAllPages_UsesCache(t *testing.T) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Total-Count", "2")
+		w.Write([]byte(`{"result":[{"sys_id":"a"},{"sys_id":"b"}]}`))
+	}))
+	defer server.Close()
+
+	now := time.Now()
+	cache := NewMemoryCache()
+	cache.now = func() time.Time { return now }
+
+	config := DefaultConfig(server.URL)
+	config.MaxRetries = 0
+	config.Cache = cache
+	config.CacheTTL = 30 * time.Second
+	client, err := NewSNClient(config)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	endpoint := server.URL + "/api/now/table/sys_choice"
+	fetch := func(query map[string]string) *PaginatedResult[map[string]interface{}] {
+		t.Helper()
+		pagination := DefaultPaginationConfig()
+		pagination.Cacheable = true
+		result, err := FetchAllPages[map[string]interface{}](context.Background(), client, endpoint, query, pagination, nil)
+		if err != nil {
+			t.Fatalf("fetch failed: %v", err)
+		}
+		return result
+	}
+
+	query := map[string]string{"sysparm_query": "element=category"}
+	fetch(query)
+	result := fetch(query)
+	if got := atomic.LoadInt32(&hits); got != 1 {
+		t.Fatalf("expected 1 request within TTL, got %d", got)
+	}
+	if len(result.Records) != 2 || result.TotalCount != 2 {
+		t.Errorf("expected cached result with 2 records, got %d records (total %d)", len(result.Records), result.TotalCount)
+	}
+
+	fetch(map[string]string{"sysparm_query": "element=subcategory"})
+	if got := atomic.LoadInt32(&hits); got != 2 {
+		t.Errorf("expected a different query to bypass the cache, got %d requests", got)
+	}
+
+	now = now.Add(config.CacheTTL)
+	fetch(query)
+	if got := atomic.LoadInt32(&hits); got != 3 {
+		t.Errorf("expected a new request after the TTL, got %d requests", got)
+	}
+}
+
+func TestSNClient_Cache_OnlySystems(t *testing.T) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"result":[]}`))
+	}))
+	defer server.Close()
+
+	config := DefaultConfig(server.URL)
+	config.MaxRetries = 0
+	config.Cache = NewMemoryCache()
+	client, err := NewSNClient(config)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		if _, err := client.FetchSystems(ctx, nil, nil); err != nil {
+			t.Fatalf("FetchSystems: %v", err)
+		}
+	}
+	if got := atomic.LoadInt32(&hits); got != 1 {
+		t.Fatalf("expected the systems list to be cached, got %d requests", got)
+	}
+
+	// Controls and statements are written by push, so pulls always read
+	// them from the instance
+	for i := 0; i < 2; i++ {
+		if _, err := client.FetchControls(ctx, "sys1", nil, nil); err != nil {
+			t.Fatalf("FetchControls: %v", err)
+		}
+		if _, err := client.FetchStatements(ctx, "ctrl1", nil, nil); err != nil {
+			t.Fatalf("FetchStatements: %v", err)
+		}
+	}
+	if got := atomic.LoadInt32(&hits); got != 5 {
+		t.Errorf("expected controls and statements to bypass the cache, got %d requests", got)
+	}
+}
@@ -0,0 +1,159 @@
+package servicenow
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without contacting ServiceNow while the circuit
+// breaker is open after repeated failures.
+var ErrCircuitOpen = errors.New("ServiceNow circuit breaker is open")
+
+// Circuit breaker defaults.
+const (
+	DefaultCircuitFailureThreshold = 5
+	DefaultCircuitCooldown         = 60 * time.Second
+)
+
+// CircuitState is the state of a circuit breaker.
+type CircuitState string
+
+const (
+	CircuitClosed   CircuitState = "closed"
+	CircuitOpen     CircuitState = "open"
+	CircuitHalfOpen CircuitState = "half-open"
+)
+
+// CircuitBreaker stops requests to an unreachable ServiceNow instance. After
+// a run of consecutive failures it opens and rejects requests with
+// ErrCircuitOpen until the cooldown has passed. It then lets a single probe
+// request through: success closes the circuit, failure opens it again.
+//
+// Transport errors and 5xx responses count as failures; any other response
+// shows the instance is reachable and resets the count.
+type CircuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mu       sync.Mutex
+	failures int
+	open     bool
+	openedAt time.Time
+	probing  bool // A half-open probe request is in flight
+}
+
+// NewCircuitBreaker creates a closed circuit breaker that opens after
+// threshold consecutive failures and stays open for cooldown. Non-positive
+// values use the defaults.
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	if threshold <= 0 {
+		threshold = DefaultCircuitFailureThreshold
+	}
+	if cooldown <= 0 {
+		cooldown = DefaultCircuitCooldown
+	}
+	return &CircuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		now:       time.Now,
+	}
+}
+
+// State returns the current state of the circuit.
+func (b *CircuitBreaker) State() CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state()
+}
+
+func (b *CircuitBreaker) state() CircuitState {
+	if !b.open {
+		return CircuitClosed
+	}
+	if b.now().Sub(b.openedAt) >= b.cooldown {
+		return CircuitHalfOpen
+	}
+	return CircuitOpen
+}
+
+// Allow reports whether a request may be sent. It returns ErrCircuitOpen
+// while the circuit is open or a half-open probe is already in flight.
+func (b *CircuitBreaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state() {
+	case CircuitOpen:
+		return ErrCircuitOpen
+	case CircuitHalfOpen:
+		if b.probing {
+			return ErrCircuitOpen
+		}
+		b.probing = true
+	}
+	return nil
+}
+
+// RecordSuccess closes the circuit and resets the failure count.
+func (b *CircuitBreaker) RecordSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures = 0
+	b.open = false
+	b.probing = false
+}
+
+// RecordFailure counts a failed request, opening the circuit once the
+// threshold is reached or when a half-open probe fails.
+func (b *CircuitBreaker) RecordFailure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	if b.probing || b.failures >= b.threshold {
+		b.open = true
+		b.openedAt = b.now()
+	}
+	b.probing = false
+}
+
+// releaseProbe ends a half-open probe without recording an outcome, letting
+// the next request probe instead.
+func (b *CircuitBreaker) releaseProbe() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+}
+
+// circuitTransport guards an http.RoundTripper with a circuit breaker.
+type circuitTransport struct {
+	base    http.RoundTripper
+	breaker *CircuitBreaker
+}
+
+// RoundTrip sends the request unless the circuit is open and records the
+// outcome. Requests cancelled by the caller are not counted; timeouts are.
+func (t *circuitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.breaker.Allow(); err != nil {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, err
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	switch {
+	case err != nil && errors.Is(req.Context().Err(), context.Canceled):
+		t.breaker.releaseProbe()
+	case err != nil, resp.StatusCode >= 500:
+		t.breaker.RecordFailure()
+	default:
+		t.breaker.RecordSuccess()
+	}
+	return resp, err
+}
@@ -0,0 +1,86 @@
+package servicenow
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker_OpensAndRecovers(t *testing.T) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&hits, 1) <= 5 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"result":[{"name":"glide.buildtag","value":"glide-vancouver"}]}`))
+	}))
+	defer server.Close()
+
+	now := time.Now()
+	breaker := NewCircuitBreaker(5, time.Minute)
+	breaker.now = func() time.Time { return now }
+
+	config := DefaultConfig(server.URL)
+	config.MaxRetries = 0
+	config.CircuitBreaker = breaker
+	client, err := NewSNClient(config)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	ctx := context.Background()
+
+	for i := 0; i < 5; i++ {
+		if _, err := client.TestConnection(ctx); err == nil {
+			t.Fatalf("request %d: expected server error", i+1)
+		}
+	}
+	if state := breaker.State(); state != CircuitOpen {
+		t.Fatalf("expected circuit open after 5 failures, got %s", state)
+	}
+
+	if _, err := client.TestConnection(ctx); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("expected ErrCircuitOpen, got %v", err)
+	}
+	if got := atomic.LoadInt32(&hits); got != 5 {
+		t.Errorf("expected no request while open, got %d requests", got)
+	}
+
+	now = now.Add(time.Minute)
+	if state := breaker.State(); state != CircuitHalfOpen {
+		t.Fatalf("expected circuit half-open after cooldown, got %s", state)
+	}
+	if _, err := client.TestConnection(ctx); err != nil {
+		t.Fatalf("expected probe to succeed, got %v", err)
+	}
+	if state := breaker.State(); state != CircuitClosed {
+		t.Errorf("expected circuit closed after successful probe, got %s", state)
+	}
+}
+
+func TestCircuitBreaker_FailedProbeReopens(t *testing.T) {
+	now := time.Now()
+	breaker := NewCircuitBreaker(2, time.Minute)
+	breaker.now = func() time.Time { return now }
+
+	breaker.RecordFailure()
+	breaker.RecordFailure()
+	now = now.Add(time.Minute)
+
+	if err := breaker.Allow(); err != nil {
+		t.Fatalf("expected probe to be allowed, got %v", err)
+	}
+	if err := breaker.Allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("expected a second request to wait for the probe, got %v", err)
+	}
+
+	breaker.RecordFailure()
+	if state := breaker.State(); state != CircuitOpen {
+		t.Errorf("expected failed probe to reopen the circuit, got %s", state)
+	}
+}
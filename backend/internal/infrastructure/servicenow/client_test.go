@@ -316,4 +316,7 @@ func TestDefaultConfig(t *testing.T) {
 	if config.MaxRetries != 3 {
 		t.Errorf("expected 3 retries, got %d", config.MaxRetries)
 	}
+	if !config.StripHTMLOnPull {
+		t.Error("expected HTML to be stripped on pull by default")
+	}
 }
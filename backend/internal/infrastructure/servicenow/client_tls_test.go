@@ -0,0 +1,111 @@
+package servicenow
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// newClientCertificate returns a self-signed client certificate and a pool
+// trusting it, for servers that verify client certificates.
+func newClientCertificate(t *testing.T) (tls.Certificate, *x509.CertPool) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "controlcrud-client"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	leaf, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("failed to parse certificate: %v", err)
+	}
+
+	pool := x509.NewCertPool()
+	pool.AddCert(leaf)
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key, Leaf: leaf}, pool
+}
+
+// newMutualTLSServer starts a server that requires a client certificate
+// signed by clientCAs.
+func newMutualTLSServer(t *testing.T, clientCAs *x509.CertPool) *httptest.Server {
+	t.Helper()
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"result":[{"name":"glide.buildtag","value":"glide-vancouver"}]}`))
+	}))
+	server.TLS = &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  clientCAs,
+	}
+	server.StartTLS()
+	return server
+}
+
+// trustServer makes the client accept the test server's self-signed certificate.
+func trustServer(t *testing.T, client *SNClient, server *httptest.Server) {
+	t.Helper()
+	transport := client.httpClient.Transport.(*http.Transport)
+	if transport.TLSClientConfig == nil {
+		transport.TLSClientConfig = &tls.Config{}
+	}
+	transport.TLSClientConfig.RootCAs = server.Client().Transport.(*http.Transport).TLSClientConfig.RootCAs
+}
+
+func TestSNClient_ClientCertificate(t *testing.T) {
+	cert, pool := newClientCertificate(t)
+	server := newMutualTLSServer(t, pool)
+	defer server.Close()
+
+	tests := []struct {
+		name    string
+		cert    *tls.Certificate
+		wantErr bool
+	}{
+		{"with client certificate", &cert, false},
+		{"without client certificate", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig(server.URL)
+			config.MaxRetries = 0
+			config.ClientCertificate = tt.cert
+			client, err := NewSNClient(config)
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+			trustServer(t, client, server)
+
+			_, err = client.TestConnection(context.Background())
+			if tt.wantErr && err == nil {
+				t.Fatal("expected handshake to fail without a client certificate")
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("expected mutual TLS request to succeed, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package servicenow
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// gzipTransport asks ServiceNow for gzip-compressed responses and
+// compresses JSON request bodies. Setting Accept-Encoding ourselves turns
+// off http.Transport's transparent decompression, so responses are
+// decompressed here.
+type gzipTransport struct {
+	base http.RoundTripper
+}
+
+// RoundTrip sends the request with Accept-Encoding: gzip, compressing the
+// body of JSON POST requests, and decompresses a gzip-encoded response.
+// Other bodies, such as OAuth token forms and attachment uploads, are sent
+// as they are.
+func (t *gzipTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	out := req.Clone(req.Context())
+	out.Header.Set("Accept-Encoding", "gzip")
+
+	if req.Method == http.MethodPost && req.Body != nil && req.Body != http.NoBody && isJSON(req.Header.Get("Content-Type")) {
+		compressed, err := gzipBody(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		out.Body = io.NopCloser(bytes.NewReader(compressed))
+		out.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(compressed)), nil
+		}
+		out.ContentLength = int64(len(compressed))
+		out.Header.Set("Content-Encoding", "gzip")
+	}
+
+	resp, err := t.base.RoundTrip(out)
+	if err != nil {
+		return nil, err
+	}
+	if strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		resp.Body = &gzipReadCloser{body: resp.Body}
+		resp.Header.Del("Content-Encoding")
+		resp.Header.Del("Content-Length")
+		resp.ContentLength = -1
+		resp.Uncompressed = true
+	}
+	return resp, nil
+}
+
+// isJSON returns true if contentType is a JSON media type.
+func isJSON(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && mediaType == "application/json"
+}
+
+// gzipBody reads and closes body and returns its gzip-compressed bytes.
+func gzipBody(body io.ReadCloser) ([]byte, error) {
+	defer body.Close()
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := io.Copy(zw, body); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// gzipReadCloser decompresses a response body. The gzip reader is created
+// on the first Read so responses without a body, such as 204s, do not fail
+// on a missing gzip header.
+type gzipReadCloser struct {
+	body io.ReadCloser
+	zr   *gzip.Reader
+	err  error
+}
+
+func (g *gzipReadCloser) Read(p []byte) (int, error) {
+	if g.zr == nil && g.err == nil {
+		g.zr, g.err = gzip.NewReader(g.body)
+	}
+	if g.err != nil {
+		return 0, g.err
+	}
+	return g.zr.Read(p)
+}
+
+func (g *gzipReadCloser) Close() error {
+	return g.body.Close()
+}
@@ -0,0 +1,132 @@
+package servicenow
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// writeGzipJSON writes v as a gzip-compressed JSON response.
+func writeGzipJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Encoding", "gzip")
+	w.WriteHeader(status)
+	zw := gzip.NewWriter(w)
+	json.NewEncoder(zw).Encode(v)
+	zw.Close()
+}
+
+func newGzipClient(t *testing.T, handler http.HandlerFunc, useGzip bool) *SNClient {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	mapping := DemoTableMapping()
+	mapping.ImportSetTable = "u_statement_import"
+	config := DefaultConfig(server.URL)
+	config.TableMapping = mapping
+	config.MaxRetries = 0
+	config.UseGzip = useGzip
+	client, err := NewSNClient(config)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	return client
+}
+
+func TestSNClient_Gzip_Response(t *testing.T) {
+	client := newGzipClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Accept-Encoding"); got != "gzip" {
+			t.Errorf("expected Accept-Encoding gzip, got %q", got)
+		}
+		writeGzipJSON(w, http.StatusOK, map[string]interface{}{
+			"result": []map[string]string{{"name": "glide.buildtag", "value": "glide-vancouver"}},
+		})
+	}, true)
+
+	result, err := client.TestConnection(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.InstanceInfo.BuildTag != "glide-vancouver" {
+		t.Errorf("expected decompressed build tag, got %+v", result)
+	}
+}
+
+func TestSNClient_Gzip_RequestBody(t *testing.T) {
+	client := newGzipClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Content-Encoding"); got != "gzip" {
+			t.Errorf("expected Content-Encoding gzip, got %q", got)
+		}
+		zr, err := gzip.NewReader(r.Body)
+		if err != nil {
+			t.Errorf("expected a gzip request body: %v", err)
+			return
+		}
+		var body importSetRequest
+		if err := json.NewDecoder(zr).Decode(&body); err != nil {
+			t.Errorf("failed to decode import set body: %v", err)
+			return
+		}
+
+		var result []map[string]string
+		for _, record := range body.Records {
+			result = append(result, map[string]string{"sys_id": record["sys_id"], "status": "updated"})
+		}
+		writeGzipJSON(w, http.StatusCreated, map[string]interface{}{"result": result})
+	}, true)
+
+	results, err := client.BulkPushStatements(context.Background(), []StatementUpdate{
+		{SysID: "stmt0", Content: strings.Repeat("Access is reviewed. ", 100)},
+		{SysID: "stmt1", Content: "Logs are kept."},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 2 || !results[0].Success || results[1].SysID != "stmt1" {
+		t.Errorf("unexpected results %+v", results)
+	}
+}
+
+func TestSNClient_Gzip_Disabled(t *testing.T) {
+	client := newGzipClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Encoding") != "" {
+			t.Errorf("expected an uncompressed request, got Content-Encoding %q", r.Header.Get("Content-Encoding"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"result":[{"name":"glide.buildtag","value":"glide-vancouver"}]}`))
+	}, false)
+
+	if _, err := client.TestConnection(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestGzipTransport_LeavesOtherBodies(t *testing.T) {
+	var got string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Encoding") != "" {
+			t.Errorf("expected form body sent uncompressed, got Content-Encoding %q", r.Header.Get("Content-Encoding"))
+		}
+		body, _ := io.ReadAll(r.Body)
+		got = string(body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodPost, server.URL, strings.NewReader("grant_type=password"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := (&http.Client{Transport: &gzipTransport{base: http.DefaultTransport}}).Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+	if got != "grant_type=password" {
+		t.Errorf("unexpected body %q", got)
+	}
+}
@@ -0,0 +1,145 @@
+package servicenow
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrImportSetNotConfigured is returned by BulkPushStatements when the
+// table mapping names no import set table.
+var ErrImportSetNotConfigured = errors.New("no import set table configured")
+
+// StatementUpdate is the new content for one statement in a bulk push.
+type StatementUpdate struct {
+	SysID   string
+	Content string
+}
+
+// BulkPushResult is the outcome of one row of a bulk push, in the order of
+// the updates sent.
+type BulkPushResult struct {
+	SysID   string
+	Success bool
+	Status  string // Import set row status, e.g. "updated" or "error"
+	Error   string // Status message for failed rows
+}
+
+// importSetRequest is the body posted to the Import Set API.
+type importSetRequest struct {
+	Records []map[string]string `json:"records"`
+}
+
+// importSetResponse is the import_set_run result with one entry per row.
+type importSetResponse struct {
+	ImportSet string `json:"import_set"`
+	Result    []struct {
+		SysID         string `json:"sys_id"`
+		Status        string `json:"status"`
+		StatusMessage string `json:"status_message"`
+		ErrorMessage  string `json:"error_message"`
+	} `json:"result"`
+}
+
+// importRowSucceeded reports whether an import set row status means the
+// target record holds the pushed content. An "inserted" row created a new
+// record instead of updating the statement's, so it counts as a failure.
+func importRowSucceeded(status string) bool {
+	switch status {
+	case "updated", "ignored":
+		return true
+	}
+	return false
+}
+
+// importRowError describes why a failed import set row did not update the
+// statement's record.
+func importRowError(status, errorMessage, statusMessage string) string {
+	if status == "inserted" {
+		return "import set row inserted a new record instead of updating the statement; the transform map must coalesce on sys_id"
+	}
+	if errorMessage != "" {
+		return errorMessage
+	}
+	if statusMessage != "" {
+		return statusMessage
+	}
+	return "import set row " + status
+}
+
+// BulkPushStatements writes many statements in a single request to the
+// Import Set API (POST /api/now/import/{table}), using the mapping's
+// ImportSetTable. Each update becomes a row holding the statement's sys_id
+// and the mapping's content field; the table's transform map must coalesce
+// on sys_id. Row failures are reported in the results rather than as an
+// error. The request is not retried, since rows may already have been
+// transformed.
+func (c *SNClient) BulkPushStatements(ctx context.Context, updates []StatementUpdate) ([]BulkPushResult, error) {
+	if c.mapping.ImportSetTable == "" {
+		return nil, ErrImportSetNotConfigured
+	}
+	if len(updates) == 0 {
+		return []BulkPushResult{}, nil
+	}
+
+	records := make([]map[string]string, len(updates))
+	for i, update := range updates {
+		records[i] = map[string]string{
+			"sys_id":               update.SysID,
+			c.mapping.ContentField: update.Content,
+		}
+	}
+	payload, err := json.Marshal(importSetRequest{Records: records})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("%s/api/now/import/%s", c.config.InstanceURL, c.mapping.ImportSetTable)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %v", ErrConnectionFailed, err)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+
+	if c.auth != nil {
+		if err := c.auth.ApplyAuth(req); err != nil {
+			return nil, fmt.Errorf("failed to apply auth: %w", err)
+		}
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrConnectionFailed, err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkResponseStatus(resp); err != nil {
+		return nil, err
+	}
+
+	var run importSetResponse
+	if err := json.NewDecoder(resp.Body).Decode(&run); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidResponse, err)
+	}
+	if len(run.Result) != len(updates) {
+		return nil, fmt.Errorf("%w: import set %s returned %d results for %d rows",
+			ErrInvalidResponse, run.ImportSet, len(run.Result), len(updates))
+	}
+
+	results := make([]BulkPushResult, len(updates))
+	for i, row := range run.Result {
+		results[i] = BulkPushResult{
+			SysID:   updates[i].SysID,
+			Success: importRowSucceeded(row.Status),
+			Status:  row.Status,
+		}
+		if !results[i].Success {
+			results[i].Error = importRowError(row.Status, row.ErrorMessage, row.StatusMessage)
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,152 @@
+package servicenow
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func newImportSetClient(t *testing.T, url string) *SNClient {
+	t.Helper()
+	mapping := DemoTableMapping()
+	mapping.ImportSetTable = "u_statement_import"
+	config := DefaultConfig(url)
+	config.TableMapping = mapping
+	client, err := NewSNClient(config)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	return client
+}
+
+func TestSNClient_BulkPushStatements(t *testing.T) {
+	var requests int
+	var records []map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Method != http.MethodPost || r.URL.Path != "/api/now/import/u_statement_import" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var body importSetRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("failed to decode import set body: %v", err)
+		}
+		records = body.Records
+
+		// Every row is updated except the second, which fails to transform
+		var result []map[string]string
+		for i, record := range body.Records {
+			row := map[string]string{"sys_id": record["sys_id"], "status": "updated", "table": "incident"}
+			if i == 1 {
+				row["status"] = "error"
+				row["error_message"] = "Target record not found"
+			}
+			result = append(result, row)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"import_set":    "ISET0010001",
+			"staging_table": "u_statement_import",
+			"result":        result,
+		})
+	}))
+	defer server.Close()
+
+	var updates []StatementUpdate
+	for i := 0; i < 12; i++ {
+		updates = append(updates, StatementUpdate{SysID: fmt.Sprintf("stmt%d", i), Content: fmt.Sprintf("Statement %d", i)})
+	}
+
+	results, err := newImportSetClient(t, server.URL).BulkPushStatements(context.Background(), updates)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if requests != 1 {
+		t.Errorf("expected a single import set request, got %d", requests)
+	}
+	if len(records) != len(updates) {
+		t.Fatalf("expected %d rows, got %d", len(updates), len(records))
+	}
+	if records[3]["sys_id"] != "stmt3" || records[3]["short_description"] != "Statement 3" {
+		t.Errorf("unexpected row %v", records[3])
+	}
+
+	if len(results) != len(updates) {
+		t.Fatalf("expected %d results, got %d", len(updates), len(results))
+	}
+	for i, result := range results {
+		if result.SysID != updates[i].SysID {
+			t.Errorf("result %d: expected sys_id %s, got %s", i, updates[i].SysID, result.SysID)
+		}
+		if wantSuccess := i != 1; result.Success != wantSuccess {
+			t.Errorf("result %d: expected success %v, got %+v", i, wantSuccess, result)
+		}
+	}
+	if results[1].Error != "Target record not found" {
+		t.Errorf("expected row error message, got %q", results[1].Error)
+	}
+}
+
+func TestSNClient_BulkPushStatements_InsertedRowFails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"import_set":"ISET0010003","result":[` +
+			`{"sys_id":"new0","status":"inserted","table":"incident"},` +
+			`{"sys_id":"stmt1","status":"ignored","table":"incident"}]}`))
+	}))
+	defer server.Close()
+
+	results, err := newImportSetClient(t, server.URL).BulkPushStatements(context.Background(), []StatementUpdate{
+		{SysID: "stmt0", Content: "a"},
+		{SysID: "stmt1", Content: "b"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if results[0].Success {
+		t.Errorf("expected an inserted row to fail, got %+v", results[0])
+	}
+	if !strings.Contains(results[0].Error, "coalesce on sys_id") {
+		t.Errorf("expected the error to explain the missing coalesce, got %q", results[0].Error)
+	}
+	if !results[1].Success {
+		t.Errorf("expected an ignored row to succeed, got %+v", results[1])
+	}
+}
+
+func TestSNClient_BulkPushStatements_ResultCountMismatch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"import_set":"ISET0010002","result":[{"sys_id":"stmt0","status":"updated"}]}`))
+	}))
+	defer server.Close()
+
+	_, err := newImportSetClient(t, server.URL).BulkPushStatements(context.Background(), []StatementUpdate{
+		{SysID: "stmt0", Content: "a"},
+		{SysID: "stmt1", Content: "b"},
+	})
+	if !errors.Is(err, ErrInvalidResponse) {
+		t.Errorf("expected ErrInvalidResponse, got %v", err)
+	}
+}
+
+func TestSNClient_BulkPushStatements_NotConfigured(t *testing.T) {
+	client, err := NewSNClient(DefaultConfig("https://dev.service-now.com"))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	_, err = client.BulkPushStatements(context.Background(), []StatementUpdate{{SysID: "stmt0", Content: "a"}})
+	if !errors.Is(err, ErrImportSetNotConfigured) {
+		t.Errorf("expected ErrImportSetNotConfigured, got %v", err)
+	}
+}
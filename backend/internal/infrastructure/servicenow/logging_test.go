@@ -0,0 +1,69 @@
+package servicenow
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/controlcrud/backend/internal/config"
+)
+
+func TestFetchAllPages_DebugLog(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Total-Count", "1")
+		json.NewEncoder(w).Encode(TableAPIResponse[map[string]string]{
+			Result: []map[string]string{{"sys_id": "1"}},
+		})
+	}))
+	defer server.Close()
+
+	client, err := NewSNClient(&ClientConfig{InstanceURL: server.URL, Timeout: 5 * time.Second})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	fetch := func(level string) string {
+		t.Helper()
+		t.Setenv("LOG_LEVEL", level)
+		t.Setenv("LOG_FORMAT", "json")
+
+		// SetDefault also redirects the log package, so restore both
+		defer func(logger *slog.Logger, w io.Writer, flags int) {
+			slog.SetDefault(logger)
+			log.SetOutput(w)
+			log.SetFlags(flags)
+		}(slog.Default(), log.Writer(), log.Flags())
+
+		var buf bytes.Buffer
+		slog.SetDefault(config.LoadLogging().NewLogger(&buf))
+
+		if _, err := FetchAllPages[map[string]string](context.Background(), client, server.URL+"/api/now/table/test", nil, nil, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return buf.String()
+	}
+
+	out := fetch("debug")
+	var entry map[string]interface{}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(out)), &entry); err != nil {
+		t.Fatalf("expected one JSON log line, got %q: %v", out, err)
+	}
+	if entry["level"] != "DEBUG" || entry["msg"] != "fetched ServiceNow page" {
+		t.Errorf("expected page debug message, got %v", entry)
+	}
+	if entry["page"] != float64(1) || entry["records"] != float64(1) {
+		t.Errorf("expected page 1 with 1 record, got %v", entry)
+	}
+
+	if out := fetch("info"); out != "" {
+		t.Errorf("expected no output at info level, got %q", out)
+	}
+}
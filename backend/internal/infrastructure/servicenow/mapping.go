@@ -0,0 +1,105 @@
+package servicenow
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// TableMapping describes which ServiceNow tables and fields back systems,
+// controls and statements. The demo mapping targets the incident table;
+// IRM deployments supply their own mapping via SN_TABLE_MAPPING_FILE.
+type TableMapping struct {
+	// SystemsTable and SystemsQuery select the records treated as systems.
+	SystemsTable string `json:"systems_table"`
+	SystemsQuery string `json:"systems_query"`
+
+	// ControlsTable and ControlsQuery select the records treated as controls.
+	ControlsTable string `json:"controls_table"`
+	ControlsQuery string `json:"controls_query"`
+
+	// StatementsTable and StatementsQuery select policy/implementation statements.
+	StatementsTable string `json:"statements_table"`
+	StatementsQuery string `json:"statements_query"`
+
+	// NumberField holds the human-readable record number.
+	NumberField string `json:"number_field"`
+
+	// ContentField holds the statement text; it is read on pull and written on push.
+	ContentField string `json:"content_field"`
+
+	// DescriptionField holds additional statement text appended on pull.
+	DescriptionField string `json:"description_field"`
+
+	// ControlFamilyField holds the control family (or its demo stand-in).
+	ControlFamilyField string `json:"control_family_field"`
+
+	// ResponsibleRoleField holds the name of the group responsible for a
+	// control. Reference fields need a dot-walked name such as
+	// "assignment_group.name". Optional; the demo mapping has no equivalent.
+	ResponsibleRoleField string `json:"responsible_role_field"`
+
+	// ImportSetTable is the staging table used to push many statements in
+	// one request. Its transform map must coalesce on sys_id and copy the
+	// content field to StatementsTable. Optional; without it statements are
+	// pushed one at a time.
+	ImportSetTable string `json:"import_set_table"`
+}
+
+// DemoTableMapping returns the built-in mapping for the incident-based demo mode.
+func DemoTableMapping() *TableMapping {
+	return &TableMapping{
+		SystemsTable:       "sys_choice",
+		SystemsQuery:       "name=incident^element=category^inactive=false",
+		ControlsTable:      "sys_choice",
+		ControlsQuery:      "name=incident^element=priority^inactive=false",
+		StatementsTable:    "incident",
+		StatementsQuery:    "active=true",
+		NumberField:        "number",
+		ContentField:       "short_description",
+		DescriptionField:   "description",
+		ControlFamilyField: "priority",
+	}
+}
+
+// LoadTableMapping loads a mapping from a JSON file.
+// Fields omitted from the file keep their demo defaults.
+// An empty path returns the demo mapping.
+func LoadTableMapping(path string) (*TableMapping, error) {
+	mapping := DemoTableMapping()
+	if path == "" {
+		return mapping, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read table mapping file: %w", err)
+	}
+
+	if err := json.Unmarshal(data, mapping); err != nil {
+		return nil, fmt.Errorf("failed to parse table mapping file: %w", err)
+	}
+
+	if err := mapping.Validate(); err != nil {
+		return nil, err
+	}
+
+	return mapping, nil
+}
+
+// Validate checks that all tables and required fields are named.
+func (m *TableMapping) Validate() error {
+	required := map[string]string{
+		"systems_table":    m.SystemsTable,
+		"controls_table":   m.ControlsTable,
+		"statements_table": m.StatementsTable,
+		"number_field":     m.NumberField,
+		"content_field":    m.ContentField,
+	}
+	for name, value := range required {
+		if value == "" {
+			return fmt.Errorf("table mapping: %s is required", name)
+		}
+	}
+	return nil
+}
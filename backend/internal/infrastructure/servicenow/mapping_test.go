@@ -0,0 +1,266 @@
+package servicenow
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testMappingJSON = `{
+	"systems_table": "sn_grc_business_entity",
+	"systems_query": "active=true",
+	"controls_table": "sn_compliance_control",
+	"controls_query": "",
+	"statements_table": "sn_compliance_policy_statement",
+	"statements_query": "state=published",
+	"number_field": "u_number",
+	"content_field": "u_implementation_statement",
+	"description_field": "",
+	"control_family_field": "u_control_family"
+}`
+
+func writeMappingFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "mapping.json")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write mapping file: %v", err)
+	}
+	return path
+}
+
+func newMappedTestClient(t *testing.T, serverURL string, mapping *TableMapping) *SNClient {
+	t.Helper()
+	config := DefaultConfig(serverURL)
+	config.MaxRetries = 0
+	config.TableMapping = mapping
+	client, err := NewSNClient(config)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	return client
+}
+
+func TestLoadTableMapping_CustomFile(t *testing.T) {
+	mapping, err := LoadTableMapping(writeMappingFile(t, testMappingJSON))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if mapping.StatementsTable != "sn_compliance_policy_statement" {
+		t.Errorf("expected statements table 'sn_compliance_policy_statement', got '%s'", mapping.StatementsTable)
+	}
+	if mapping.ContentField != "u_implementation_statement" {
+		t.Errorf("expected content field 'u_implementation_statement', got '%s'", mapping.ContentField)
+	}
+	if mapping.ControlFamilyField != "u_control_family" {
+		t.Errorf("expected control family field 'u_control_family', got '%s'", mapping.ControlFamilyField)
+	}
+	if mapping.DescriptionField != "" {
+		t.Errorf("expected description field to be cleared, got '%s'", mapping.DescriptionField)
+	}
+}
+
+func TestLoadTableMapping_PartialFileKeepsDefaults(t *testing.T) {
+	mapping, err := LoadTableMapping(writeMappingFile(t, `{"statements_table": "u_statements"}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	demo := DemoTableMapping()
+	if mapping.StatementsTable != "u_statements" {
+		t.Errorf("expected statements table 'u_statements', got '%s'", mapping.StatementsTable)
+	}
+	if mapping.ContentField != demo.ContentField {
+		t.Errorf("expected default content field '%s', got '%s'", demo.ContentField, mapping.ContentField)
+	}
+	if mapping.SystemsQuery != demo.SystemsQuery {
+		t.Errorf("expected default systems query '%s', got '%s'", demo.SystemsQuery, mapping.SystemsQuery)
+	}
+}
+
+func TestLoadTableMapping_EmptyPathUsesDemo(t *testing.T) {
+	mapping, err := LoadTableMapping("")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if *mapping != *DemoTableMapping() {
+		t.Errorf("expected demo mapping, got %+v", mapping)
+	}
+}
+
+func TestLoadTableMapping_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+	}{
+		{"missing file", filepath.Join(t.TempDir(), "missing.json")},
+		{"invalid json", writeMappingFile(t, `{"statements_table":`)},
+		{"empty required field", writeMappingFile(t, `{"content_field": ""}`)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := LoadTableMapping(tt.path); err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestNewSNClient_DefaultsToDemoMapping(t *testing.T) {
+	client, err := NewSNClient(&ClientConfig{InstanceURL: "https://instance.service-now.com"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if *client.mapping != *DemoTableMapping() {
+		t.Errorf("expected demo mapping, got %+v", client.mapping)
+	}
+}
+
+func TestFetchStatements_UsesMapping(t *testing.T) {
+	mapping, err := LoadTableMapping(writeMappingFile(t, testMappingJSON))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var gotPath, gotQuery, gotFields string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotQuery = r.URL.Query().Get("sysparm_query")
+		gotFields = r.URL.Query().Get("sysparm_fields")
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"result": []map[string]string{
+				{"sys_id": "abc123", "u_number": "PS0001", "u_implementation_statement": "Access is reviewed quarterly."},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := newMappedTestClient(t, server.URL, mapping)
+	result, err := client.FetchStatements(context.Background(), "ctrl-1", nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if gotPath != "/api/now/table/sn_compliance_policy_statement" {
+		t.Errorf("expected mapped statements table path, got '%s'", gotPath)
+	}
+	if gotQuery != "state=published" {
+		t.Errorf("expected query 'state=published', got '%s'", gotQuery)
+	}
+	if gotFields != "sys_id,u_number,u_implementation_statement,sys_updated_on" {
+		t.Errorf("unexpected fields '%s'", gotFields)
+	}
+	if len(result.Records) != 1 || result.Records[0].Content != "Access is reviewed quarterly." {
+		t.Errorf("expected content read from mapped field, got %+v", result.Records)
+	}
+}
+
+func TestGetPolicyStatements_UsesMapping(t *testing.T) {
+	mapping, err := LoadTableMapping(writeMappingFile(t, testMappingJSON))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var gotPath, gotQuery, gotOrder string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotQuery = r.URL.Query().Get("sysparm_query")
+		gotOrder = r.URL.Query().Get("sysparm_order_by")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"result": []}`))
+	}))
+	defer server.Close()
+
+	client := newMappedTestClient(t, server.URL, mapping)
+	if _, err := client.GetPolicyStatements(context.Background(), &PolicyStatementParams{Query: "AC-2"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if gotPath != "/api/now/table/sn_compliance_policy_statement" {
+		t.Errorf("expected mapped statements table path, got '%s'", gotPath)
+	}
+	expected := "u_numberLIKEAC-2^ORu_implementation_statementLIKEAC-2^state=published"
+	if gotQuery != expected {
+		t.Errorf("expected query '%s', got '%s'", expected, gotQuery)
+	}
+	if gotOrder != "u_number" {
+		t.Errorf("expected order by 'u_number', got '%s'", gotOrder)
+	}
+}
+
+func TestGetPolicyStatements_ReadsMappedFields(t *testing.T) {
+	mapping, err := LoadTableMapping(writeMappingFile(t, testMappingJSON))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"result": [{
+			"sys_id": "stmt1",
+			"u_number": "PS-1",
+			"u_implementation_statement": "Accounts are reviewed quarterly",
+			"short_description": "unmapped",
+			"u_control_family": "AC",
+			"state": "published"
+		}]}`))
+	}))
+	defer server.Close()
+
+	client := newMappedTestClient(t, server.URL, mapping)
+	result, err := client.GetPolicyStatements(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Records) != 1 {
+		t.Fatalf("expected 1 record, got %d", len(result.Records))
+	}
+	record := result.Records[0]
+	if record.SysID != "stmt1" || record.Number != "PS-1" || record.State != "published" {
+		t.Errorf("unexpected record identity: %+v", record)
+	}
+	if record.ShortDescription != "Accounts are reviewed quarterly" {
+		t.Errorf("expected content read from mapped field, got '%s'", record.ShortDescription)
+	}
+	if record.ControlFamily != "AC" {
+		t.Errorf("expected control family read from mapped field, got '%s'", record.ControlFamily)
+	}
+}
+
+func TestUpdateStatement_UsesMappedContentField(t *testing.T) {
+	mapping, err := LoadTableMapping(writeMappingFile(t, testMappingJSON))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var gotPath string
+	var payload map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		json.NewDecoder(r.Body).Decode(&payload)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"result": {}}`))
+	}))
+	defer server.Close()
+
+	client := newMappedTestClient(t, server.URL, mapping)
+	if err := client.UpdateStatement(context.Background(), "abc123", "Updated text"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if gotPath != "/api/now/table/sn_compliance_policy_statement/abc123" {
+		t.Errorf("unexpected path '%s'", gotPath)
+	}
+	if payload["u_implementation_statement"] != "Updated text" {
+		t.Errorf("expected content in mapped field, got %+v", payload)
+	}
+	if _, ok := payload["short_description"]; ok {
+		t.Error("expected demo content field not to be written")
+	}
+}
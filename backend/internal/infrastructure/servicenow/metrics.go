@@ -0,0 +1,79 @@
+package servicenow
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Metrics counts the requests a client sends to ServiceNow. It may be shared
+// by clients for the same instance and is safe for concurrent use.
+type Metrics struct {
+	mu            sync.Mutex
+	totalRequests int64
+	totalErrors   int64
+	rateLimitHits int64
+	totalLatency  time.Duration
+}
+
+// MetricsSnapshot is a point-in-time copy of Metrics.
+type MetricsSnapshot struct {
+	TotalRequests    int64
+	TotalErrors      int64 // Transport errors and 4xx/5xx responses
+	RateLimitHits    int64 // 429 responses
+	AverageLatencyMs float64
+}
+
+// NewMetrics creates empty metrics.
+func NewMetrics() *Metrics {
+	return &Metrics{}
+}
+
+// record counts one request that took latency and ended with resp or err.
+func (m *Metrics) record(resp *http.Response, err error, latency time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.totalRequests++
+	m.totalLatency += latency
+	switch {
+	case err != nil:
+		m.totalErrors++
+	case resp.StatusCode == http.StatusTooManyRequests:
+		m.rateLimitHits++
+		m.totalErrors++
+	case resp.StatusCode >= 400:
+		m.totalErrors++
+	}
+}
+
+// Snapshot returns the current counts.
+func (m *Metrics) Snapshot() MetricsSnapshot {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	snapshot := MetricsSnapshot{
+		TotalRequests: m.totalRequests,
+		TotalErrors:   m.totalErrors,
+		RateLimitHits: m.rateLimitHits,
+	}
+	if m.totalRequests > 0 {
+		snapshot.AverageLatencyMs = float64(m.totalLatency.Microseconds()) / 1000 / float64(m.totalRequests)
+	}
+	return snapshot
+}
+
+// metricsTransport records every request sent through an http.RoundTripper.
+type metricsTransport struct {
+	base    http.RoundTripper
+	metrics *Metrics
+}
+
+// RoundTrip sends the request and records its outcome and latency. Latency
+// is measured to the response headers; reading the body is not included.
+func (t *metricsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	t.metrics.record(resp, err, time.Since(start))
+	return resp, err
+}
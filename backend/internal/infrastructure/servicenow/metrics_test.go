@@ -0,0 +1,81 @@
+package servicenow
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newMetricsClient(t *testing.T, handler http.HandlerFunc) (*SNClient, *Metrics) {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	metrics := NewMetrics()
+	config := DefaultConfig(server.URL)
+	config.MaxRetries = 0
+	config.Metrics = metrics
+	client, err := NewSNClient(config)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	return client, metrics
+}
+
+func TestMetrics_Success(t *testing.T) {
+	client, metrics := newMetricsClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"result":[{"name":"glide.buildtag","value":"glide-vancouver"}]}`))
+	})
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.TestConnection(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	got := metrics.Snapshot()
+	if got.TotalRequests != 2 {
+		t.Errorf("expected 2 requests, got %d", got.TotalRequests)
+	}
+	if got.TotalErrors != 0 || got.RateLimitHits != 0 {
+		t.Errorf("expected no errors, got %+v", got)
+	}
+	if got.AverageLatencyMs <= 0 {
+		t.Errorf("expected a positive average latency, got %v", got.AverageLatencyMs)
+	}
+}
+
+func TestMetrics_RateLimited(t *testing.T) {
+	client, metrics := newMetricsClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	if _, err := client.TestConnection(context.Background()); !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("expected ErrRateLimited, got %v", err)
+	}
+
+	got := metrics.Snapshot()
+	if got.RateLimitHits != 1 || got.TotalErrors != 1 || got.TotalRequests != 1 {
+		t.Errorf("expected one rate-limited request, got %+v", got)
+	}
+}
+
+func TestMetrics_NotCountedWhileCircuitOpen(t *testing.T) {
+	client, metrics := newMetricsClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	breaker := NewCircuitBreaker(1, 0)
+	client.httpClient.Transport = &circuitTransport{base: client.httpClient.Transport, breaker: breaker}
+
+	client.TestConnection(context.Background())
+	if _, err := client.TestConnection(context.Background()); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected ErrCircuitOpen, got %v", err)
+	}
+
+	if got := metrics.Snapshot(); got.TotalRequests != 1 || got.TotalErrors != 1 {
+		t.Errorf("expected only the request that reached the instance, got %+v", got)
+	}
+}
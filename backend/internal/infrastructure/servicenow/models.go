@@ -105,21 +105,33 @@ type TestConnectionResult struct {
 //
 // See: 0xcc/docs/INCIDENT_TO_IRM_MIGRATION.md for complete migration guide
 // =============================================================================
+//
+// Number, ShortDescription, Description and ControlFamily are read from the
+// fields named by the client's TableMapping.
 type PolicyStatementRecord struct {
 	SysID            string `json:"sys_id"`
-	Number           string `json:"number"`
+	Number           string `json:"number"`            // TableMapping.NumberField
 	Name             string `json:"name"`              // IRM: populated | DEMO: empty (use ShortDescription)
-	ShortDescription string `json:"short_description"` // Both: populated
-	Description      string `json:"description"`       // Both: populated
+	ShortDescription string `json:"short_description"` // TableMapping.ContentField
+	Description      string `json:"description"`       // TableMapping.DescriptionField
 	State            string `json:"state"`             // IRM: "draft","active" | DEMO: "1","2","3"
 	Category         string `json:"category"`          // Both: populated (different values)
-	ControlFamily    string `json:"u_control_family"`  // IRM: real value | DEMO: empty
+	ControlFamily    string `json:"control_family"`    // TableMapping.ControlFamilyField
 	Priority         string `json:"priority"`          // DEMO ONLY: used as ControlFamily fallback (remove for IRM)
 	Active           string `json:"active"`            // Both: "true"/"false" or "1"/"0"
 	SysCreatedOn     string `json:"sys_created_on"`    // Both: timestamp
 	SysUpdatedOn     string `json:"sys_updated_on"`    // Both: timestamp
 }
 
+// Content returns the statement text as FetchStatements builds it: the short
+// description followed by the description, when there is one.
+func (r *PolicyStatementRecord) Content() string {
+	if r.Description == "" {
+		return r.ShortDescription
+	}
+	return r.ShortDescription + "\n\n" + r.Description
+}
+
 // PolicyStatementParams contains parameters for fetching policy statements.
 type PolicyStatementParams struct {
 	Limit    int
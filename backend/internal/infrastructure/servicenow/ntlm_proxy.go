@@ -0,0 +1,201 @@
+package servicenow
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Azure/go-ntlmssp"
+	"golang.org/x/net/http/httpproxy"
+)
+
+// ErrProxyAuthFailed is returned when a proxy rejects NTLM credentials.
+var ErrProxyAuthFailed = errors.New("proxy authentication failed")
+
+// ntlmProxy tunnels connections through a forward proxy that requires NTLM
+// authentication. The NTLM handshake authenticates the TCP connection
+// rather than a request, so each CONNECT tunnel is opened and authenticated
+// on a connection dialed here and then handed to the transport.
+type ntlmProxy struct {
+	proxyURL *url.URL
+	username string
+	password string
+	domain   string
+
+	// proxyFor returns the proxy for a target, or nil for targets matched by
+	// NO_PROXY
+	proxyFor func(*url.URL) (*url.URL, error)
+	dialer   *net.Dialer
+}
+
+// newNTLMProxy creates the tunnel dialer for the configured NTLM proxy.
+func newNTLMProxy(config *ClientConfig) (*ntlmProxy, error) {
+	proxyURL, err := url.Parse(config.NTLMProxyURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid NTLM proxy URL: %w", err)
+	}
+	if proxyURL.Scheme != "http" {
+		return nil, fmt.Errorf("invalid NTLM proxy URL: unsupported scheme %q", proxyURL.Scheme)
+	}
+	if proxyURL.Host == "" {
+		return nil, fmt.Errorf("invalid NTLM proxy URL: host is required")
+	}
+	if config.NTLMProxyUsername == "" {
+		return nil, errors.New("NTLM proxy username is required")
+	}
+
+	proxyConfig := httpproxy.Config{
+		HTTPProxy:  config.NTLMProxyURL,
+		HTTPSProxy: config.NTLMProxyURL,
+		NoProxy:    noProxyFromEnvironment(),
+	}
+	return &ntlmProxy{
+		proxyURL: proxyURL,
+		username: config.NTLMProxyUsername,
+		password: config.NTLMProxyPassword,
+		domain:   config.NTLMProxyDomain,
+		proxyFor: proxyConfig.ProxyFunc(),
+		dialer:   &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second},
+	}, nil
+}
+
+// noProxyFromEnvironment returns NO_PROXY, or no_proxy if it is unset.
+func noProxyFromEnvironment() string {
+	if v := os.Getenv("NO_PROXY"); v != "" {
+		return v
+	}
+	return os.Getenv("no_proxy")
+}
+
+// DialContext connects to addr through an authenticated proxy tunnel, or
+// directly when NO_PROXY excludes it. It is used as the transport's
+// DialContext; TLS to the instance runs inside the tunnel.
+func (p *ntlmProxy) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	proxyURL, err := p.proxyFor(&url.URL{Scheme: "https", Host: addr})
+	if err != nil {
+		return nil, err
+	}
+	if proxyURL == nil {
+		return p.dialer.DialContext(ctx, network, addr)
+	}
+
+	conn, err := p.dialer.DialContext(ctx, "tcp", canonicalProxyAddr(p.proxyURL))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to proxy: %w", err)
+	}
+
+	// Bound the handshake by the context; the deadline is cleared after
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	if err := p.connect(conn, addr); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	conn.SetDeadline(time.Time{})
+	return conn, nil
+}
+
+// connect opens a CONNECT tunnel to addr on conn, answering the proxy's NTLM
+// challenge: NEGOTIATE, then CHALLENGE from the proxy, then AUTHENTICATE.
+func (p *ntlmProxy) connect(conn net.Conn, addr string) error {
+	br := bufio.NewReader(conn)
+
+	negotiate, err := ntlmssp.NewNegotiateMessage(p.domain, "")
+	if err != nil {
+		return fmt.Errorf("failed to create NTLM negotiate message: %w", err)
+	}
+	resp, err := p.sendConnect(conn, br, addr, negotiate)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode == http.StatusOK {
+		return nil // The proxy did not ask for credentials
+	}
+	if resp.StatusCode != http.StatusProxyAuthRequired {
+		return fmt.Errorf("%w: proxy returned %s", ErrConnectionFailed, resp.Status)
+	}
+
+	challenge, err := ntlmChallenge(resp.Header)
+	if err != nil {
+		return err
+	}
+	authenticate, err := ntlmssp.ProcessChallenge(challenge, p.username, p.password, p.domain != "")
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrProxyAuthFailed, err)
+	}
+
+	resp, err = p.sendConnect(conn, br, addr, authenticate)
+	if err != nil {
+		return err
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusProxyAuthRequired:
+		return ErrProxyAuthFailed
+	default:
+		return fmt.Errorf("%w: proxy returned %s", ErrConnectionFailed, resp.Status)
+	}
+}
+
+// sendConnect sends a CONNECT request for addr carrying an NTLM message and
+// reads the proxy's response, discarding its body so the connection can be
+// reused for the next step of the handshake.
+func (p *ntlmProxy) sendConnect(conn net.Conn, br *bufio.Reader, addr string, message []byte) (*http.Response, error) {
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: addr},
+		Host:   addr,
+		Header: http.Header{
+			"Proxy-Authorization": {"NTLM " + base64.StdEncoding.EncodeToString(message)},
+			"Proxy-Connection":    {"Keep-Alive"},
+		},
+	}
+	if err := req.Write(conn); err != nil {
+		return nil, fmt.Errorf("failed to write CONNECT request: %w", err)
+	}
+
+	resp, err := http.ReadResponse(br, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CONNECT response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}
+	return resp, nil
+}
+
+// ntlmChallenge extracts the CHALLENGE message from a 407 response.
+func ntlmChallenge(header http.Header) ([]byte, error) {
+	for _, value := range header.Values("Proxy-Authenticate") {
+		scheme, data, ok := strings.Cut(value, " ")
+		if !ok || !strings.EqualFold(scheme, "NTLM") {
+			continue
+		}
+		challenge, err := base64.StdEncoding.DecodeString(strings.TrimSpace(data))
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid NTLM challenge: %v", ErrProxyAuthFailed, err)
+		}
+		return challenge, nil
+	}
+	return nil, fmt.Errorf("%w: proxy does not offer NTLM authentication", ErrProxyAuthFailed)
+}
+
+// canonicalProxyAddr returns the proxy's host:port, defaulting to port 80.
+func canonicalProxyAddr(proxyURL *url.URL) string {
+	if proxyURL.Port() != "" {
+		return proxyURL.Host
+	}
+	return net.JoinHostPort(proxyURL.Hostname(), "80")
+}
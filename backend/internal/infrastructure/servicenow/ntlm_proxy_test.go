@@ -0,0 +1,274 @@
+package servicenow
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"unicode/utf16"
+)
+
+// ntlmMessage parses a base64 NTLM message from a Proxy-Authorization header
+// and returns it with its message type, or type 0 if it is not NTLM.
+func ntlmMessage(header string) ([]byte, uint32) {
+	data, ok := strings.CutPrefix(header, "NTLM ")
+	if !ok {
+		return nil, 0
+	}
+	msg, err := base64.StdEncoding.DecodeString(data)
+	if err != nil || len(msg) < 12 || !bytes.Equal(msg[:8], []byte("NTLMSSP\x00")) {
+		return nil, 0
+	}
+	return msg, binary.LittleEndian.Uint32(msg[8:12])
+}
+
+// ntlmChallengeMessage builds an NTLMv2 CHALLENGE message for target.
+func ntlmChallengeMessage(target string) []byte {
+	var name []byte
+	for _, r := range utf16.Encode([]rune(target)) {
+		name = binary.LittleEndian.AppendUint16(name, r)
+	}
+	targetInfo := []byte{0, 0, 0, 0} // MsvAvEOL
+
+	const headerLen = 48
+	var b bytes.Buffer
+	b.WriteString("NTLMSSP\x00")
+	binary.Write(&b, binary.LittleEndian, uint32(2))
+	binary.Write(&b, binary.LittleEndian, []uint16{uint16(len(name)), uint16(len(name))})
+	binary.Write(&b, binary.LittleEndian, uint32(headerLen))
+	// UNICODE | NTLM | TARGET_INFO | EXTENDED_SESSIONSECURITY
+	binary.Write(&b, binary.LittleEndian, uint32(0x00000001|0x00000200|0x00800000|0x00080000))
+	b.WriteString("challnge") // Server challenge
+	b.Write(make([]byte, 8))  // Reserved
+	binary.Write(&b, binary.LittleEndian, []uint16{uint16(len(targetInfo)), uint16(len(targetInfo))})
+	binary.Write(&b, binary.LittleEndian, uint32(headerLen+len(name)))
+	b.Write(name)
+	b.Write(targetInfo)
+	return b.Bytes()
+}
+
+// ntlmUserName returns the user name from an AUTHENTICATE message.
+func ntlmUserName(msg []byte) string {
+	length := binary.LittleEndian.Uint16(msg[36:38])
+	offset := binary.LittleEndian.Uint32(msg[40:44])
+	raw := msg[offset : offset+uint32(length)]
+	units := make([]uint16, len(raw)/2)
+	for i := range units {
+		units[i] = binary.LittleEndian.Uint16(raw[2*i:])
+	}
+	return string(utf16.Decode(units))
+}
+
+// ntlmProxyServer is a proxy that only opens CONNECT tunnels after an NTLM
+// handshake on the same connection. Requests sent through a tunnel are
+// answered by the proxy itself.
+type ntlmProxyServer struct {
+	listener net.Listener
+
+	mu        sync.Mutex
+	steps     []uint32 // NTLM message types received, in order
+	user      string
+	tunnel    string // CONNECT target of the authenticated tunnel
+	forwarded string // Request line received through the tunnel
+}
+
+func newNTLMProxyServer(t *testing.T) *ntlmProxyServer {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	p := &ntlmProxyServer{listener: ln}
+	t.Cleanup(func() { ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go p.serve(conn)
+		}
+	}()
+	return p
+}
+
+func (p *ntlmProxyServer) serve(conn net.Conn) {
+	defer conn.Close()
+	br := bufio.NewReader(conn)
+	for {
+		req, err := http.ReadRequest(br)
+		if err != nil || req.Method != http.MethodConnect {
+			return
+		}
+		msg, msgType := ntlmMessage(req.Header.Get("Proxy-Authorization"))
+
+		p.mu.Lock()
+		p.steps = append(p.steps, msgType)
+		p.mu.Unlock()
+
+		switch msgType {
+		case 1:
+			challenge := base64.StdEncoding.EncodeToString(ntlmChallengeMessage("CORP"))
+			conn.Write([]byte("HTTP/1.1 407 Proxy Authentication Required\r\n" +
+				"Proxy-Authenticate: NTLM " + challenge + "\r\n" +
+				"Content-Length: 0\r\n\r\n"))
+		case 3:
+			p.mu.Lock()
+			p.user = ntlmUserName(msg)
+			p.tunnel = req.Host
+			p.mu.Unlock()
+			conn.Write([]byte("HTTP/1.1 200 Connection established\r\n\r\n"))
+			p.serveTunnel(conn, br)
+			return
+		default:
+			conn.Write([]byte("HTTP/1.1 407 Proxy Authentication Required\r\n" +
+				"Proxy-Authenticate: NTLM\r\n" +
+				"Content-Length: 0\r\n\r\n"))
+		}
+	}
+}
+
+// serveTunnel answers the request sent through an established tunnel as if
+// it were the ServiceNow instance.
+func (p *ntlmProxyServer) serveTunnel(conn net.Conn, br *bufio.Reader) {
+	req, err := http.ReadRequest(br)
+	if err != nil {
+		return
+	}
+	p.mu.Lock()
+	p.forwarded = req.Method + " " + req.Host + req.URL.Path
+	p.mu.Unlock()
+
+	body := `{"result":[{"name":"glide.buildtag","value":"glide-vancouver"}]}`
+	resp := &http.Response{
+		StatusCode:    http.StatusOK,
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": {"application/json"}},
+		ContentLength: int64(len(body)),
+		Body:          io.NopCloser(strings.NewReader(body)),
+	}
+	resp.Write(conn)
+}
+
+func newNTLMProxyClient(t *testing.T, proxy *ntlmProxyServer, password string) *SNClient {
+	t.Helper()
+	config := DefaultConfig("http://acme.service-now.invalid")
+	config.MaxRetries = 0
+	config.NTLMProxyURL = "http://" + proxy.listener.Addr().String()
+	config.NTLMProxyUsername = "svc-grc"
+	config.NTLMProxyPassword = password
+	config.NTLMProxyDomain = "CORP"
+	client, err := NewSNClient(config)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	return client
+}
+
+func TestSNClient_NTLMProxy(t *testing.T) {
+	t.Setenv("NO_PROXY", "")
+	proxy := newNTLMProxyServer(t)
+	client := newNTLMProxyClient(t, proxy, "s3cret")
+
+	if _, err := client.TestConnection(context.Background()); err != nil {
+		t.Fatalf("expected request to pass through the proxy, got %v", err)
+	}
+
+	proxy.mu.Lock()
+	defer proxy.mu.Unlock()
+	if len(proxy.steps) != 2 || proxy.steps[0] != 1 || proxy.steps[1] != 3 {
+		t.Errorf("expected NEGOTIATE then AUTHENTICATE, got message types %v", proxy.steps)
+	}
+	if proxy.user != "svc-grc" {
+		t.Errorf("expected user svc-grc, got %q", proxy.user)
+	}
+	if proxy.tunnel != "acme.service-now.invalid:80" {
+		t.Errorf("expected tunnel to the instance, got %q", proxy.tunnel)
+	}
+	if want := "GET acme.service-now.invalid/api/now/table/sys_properties"; proxy.forwarded != want {
+		t.Errorf("expected forwarded request %q, got %q", want, proxy.forwarded)
+	}
+}
+
+func TestSNClient_NTLMProxy_NoProxy(t *testing.T) {
+	t.Setenv("NO_PROXY", "acme.service-now.invalid")
+	proxy := newNTLMProxyServer(t)
+	client := newNTLMProxyClient(t, proxy, "s3cret")
+
+	// The instance does not resolve, so a direct dial fails
+	if _, err := client.TestConnection(context.Background()); err == nil {
+		t.Fatal("expected direct connection to fail")
+	}
+	proxy.mu.Lock()
+	defer proxy.mu.Unlock()
+	if len(proxy.steps) != 0 {
+		t.Errorf("expected NO_PROXY target to bypass the proxy, got %v", proxy.steps)
+	}
+}
+
+func TestNTLMProxy_Rejected(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer ln.Close()
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		br := bufio.NewReader(conn)
+		for {
+			if _, err := http.ReadRequest(br); err != nil {
+				return
+			}
+			conn.Write([]byte("HTTP/1.1 407 Proxy Authentication Required\r\n" +
+				"Proxy-Authenticate: NTLM " + base64.StdEncoding.EncodeToString(ntlmChallengeMessage("CORP")) + "\r\n" +
+				"Content-Length: 0\r\n\r\n"))
+		}
+	}()
+
+	proxy, err := newNTLMProxy(&ClientConfig{
+		NTLMProxyURL:      "http://" + ln.Addr().String(),
+		NTLMProxyUsername: "svc-grc",
+		NTLMProxyPassword: "wrong",
+	})
+	if err != nil {
+		t.Fatalf("failed to create proxy dialer: %v", err)
+	}
+	if _, err := proxy.DialContext(context.Background(), "tcp", "acme.service-now.invalid:443"); !errors.Is(err, ErrProxyAuthFailed) {
+		t.Errorf("expected ErrProxyAuthFailed, got %v", err)
+	}
+}
+
+func TestNewSNClient_InvalidNTLMProxy(t *testing.T) {
+	tests := []struct {
+		name     string
+		proxyURL string
+		username string
+	}{
+		{"https scheme", "https://proxy.corp:8080", "svc"},
+		{"missing host", "http://", "svc"},
+		{"missing username", "http://proxy.corp:8080", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig("https://acme.service-now.com")
+			config.NTLMProxyURL = tt.proxyURL
+			config.NTLMProxyUsername = tt.username
+			if _, err := NewSNClient(config); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
@@ -0,0 +1,235 @@
+package servicenow
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// tokenExpiryMargin is how long before its expiry a token is refreshed, so
+// a request never goes out with a token about to expire.
+const tokenExpiryMargin = 30 * time.Second
+
+// tokenCacheFileMode keeps cached tokens readable only by the owner.
+const tokenCacheFileMode = os.FileMode(0600)
+
+// tokenRequestTimeout bounds a token request when no HTTP client is set.
+const tokenRequestTimeout = 30 * time.Second
+
+// TokenData is an OAuth access token and its expiry.
+type TokenData struct {
+	AccessToken string    `json:"access_token"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+// validAt returns true if the token can still be used at the given time.
+// A token without an expiry is always valid.
+func (t *TokenData) validAt(now time.Time) bool {
+	if t == nil || t.AccessToken == "" {
+		return false
+	}
+	return t.ExpiresAt.IsZero() || now.Add(tokenExpiryMargin).Before(t.ExpiresAt)
+}
+
+// TokenCache persists OAuth access tokens across restarts.
+type TokenCache interface {
+	// Load returns the cached token, or nil if none is cached.
+	Load() (*TokenData, error)
+
+	// Save stores a token, replacing any cached token.
+	Save(token *TokenData) error
+}
+
+// FileTokenCache stores a token as JSON in a file readable only by the
+// owner.
+type FileTokenCache struct {
+	path string
+}
+
+// NewFileTokenCache creates a token cache backed by the file at path.
+func NewFileTokenCache(path string) *FileTokenCache {
+	return &FileTokenCache{path: path}
+}
+
+// Load reads the cached token. A missing file is not an error.
+func (c *FileTokenCache) Load() (*TokenData, error) {
+	data, err := os.ReadFile(c.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token cache: %w", err)
+	}
+
+	var token TokenData
+	if err := json.Unmarshal(data, &token); err != nil {
+		return nil, fmt.Errorf("failed to parse token cache: %w", err)
+	}
+	return &token, nil
+}
+
+// Save writes the token to a temporary file and renames it into place so
+// a crash never leaves a partly written cache.
+func (c *FileTokenCache) Save(token *TokenData) error {
+	data, err := json.Marshal(token)
+	if err != nil {
+		return fmt.Errorf("failed to encode token: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(c.path), filepath.Base(c.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write token cache: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write token cache: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write token cache: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), tokenCacheFileMode); err != nil {
+		return fmt.Errorf("failed to write token cache: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), c.path); err != nil {
+		return fmt.Errorf("failed to write token cache: %w", err)
+	}
+	return nil
+}
+
+// OAuthProvider provides OAuth 2.0 authentication using the client
+// credentials grant. Tokens are fetched on first use and refreshed shortly
+// before they expire.
+type OAuthProvider struct {
+	ClientID     string
+	ClientSecret string
+	TokenURL     string
+
+	// Cache persists tokens so a restart reuses a still valid token instead
+	// of fetching a new one. Nil keeps tokens in memory only.
+	Cache TokenCache
+
+	// HTTPClient sends token requests. SNClient.SetAuth sets it to the
+	// client's own HTTP client when nil; otherwise nil uses a client with a
+	// 30 second timeout.
+	HTTPClient *http.Client
+
+	mu          sync.Mutex
+	accessToken string
+	expiresAt   time.Time
+	cacheLoaded bool
+	now         func() time.Time
+}
+
+// tokenResponse is the OAuth token endpoint response.
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+// ApplyAuth applies OAuth Bearer token to the request, fetching a new
+// token first if there is no valid one.
+func (p *OAuthProvider) ApplyAuth(req *http.Request) error {
+	token, err := p.token(req)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	return nil
+}
+
+// Type returns "oauth".
+func (p *OAuthProvider) Type() string {
+	return "oauth"
+}
+
+// token returns a valid access token from memory, the cache or the token
+// endpoint, in that order.
+func (p *OAuthProvider) token(req *http.Request) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	if p.now != nil {
+		now = p.now()
+	}
+
+	current := &TokenData{AccessToken: p.accessToken, ExpiresAt: p.expiresAt}
+	if current.validAt(now) {
+		return p.accessToken, nil
+	}
+
+	if p.Cache != nil && !p.cacheLoaded {
+		p.cacheLoaded = true
+		// An unreadable cache only costs a token request
+		if cached, err := p.Cache.Load(); err == nil && cached.validAt(now) {
+			p.accessToken, p.expiresAt = cached.AccessToken, cached.ExpiresAt
+			return p.accessToken, nil
+		}
+	}
+
+	fetched, err := p.fetchToken(req, now)
+	if err != nil {
+		return "", err
+	}
+	p.accessToken, p.expiresAt = fetched.AccessToken, fetched.ExpiresAt
+
+	if p.Cache != nil {
+		// Failing to persist the token does not affect this request
+		_ = p.Cache.Save(fetched)
+	}
+	return p.accessToken, nil
+}
+
+// fetchToken requests a new token with the client credentials grant.
+func (p *OAuthProvider) fetchToken(req *http.Request, now time.Time) (*TokenData, error) {
+	form := url.Values{}
+	form.Set("grant_type", "client_credentials")
+	form.Set("client_id", p.ClientID)
+	form.Set("client_secret", p.ClientSecret)
+
+	tokenReq, err := http.NewRequestWithContext(req.Context(), http.MethodPost, p.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create token request: %v", ErrAuthFailed, err)
+	}
+	tokenReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	tokenReq.Header.Set("Accept", "application/json")
+
+	client := p.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: tokenRequestTimeout}
+	}
+	resp, err := client.Do(tokenReq)
+	if err != nil {
+		return nil, fmt.Errorf("%w: token request failed: %w", ErrConnectionFailed, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		io.Copy(io.Discard, resp.Body)
+		return nil, fmt.Errorf("%w: token endpoint returned status %d", ErrAuthFailed, resp.StatusCode)
+	}
+
+	var body tokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidResponse, err)
+	}
+	if body.AccessToken == "" {
+		return nil, fmt.Errorf("%w: token response has no access_token", ErrInvalidResponse)
+	}
+
+	token := &TokenData{AccessToken: body.AccessToken}
+	if body.ExpiresIn > 0 {
+		token.ExpiresAt = now.Add(time.Duration(body.ExpiresIn) * time.Second)
+	}
+	return token, nil
+}
@@ -0,0 +1,151 @@
+package servicenow
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// memoryTokenCache is an in-memory TokenCache standing in for the file system.
+type memoryTokenCache struct {
+	token *TokenData
+	saves int
+}
+
+func (c *memoryTokenCache) Load() (*TokenData, error) {
+	return c.token, nil
+}
+
+func (c *memoryTokenCache) Save(token *TokenData) error {
+	c.token = token
+	c.saves++
+	return nil
+}
+
+func newTokenServer(t *testing.T, hits *int32) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(hits, 1)
+		if err := r.ParseForm(); err != nil || r.PostForm.Get("grant_type") != "client_credentials" || r.PostForm.Get("client_secret") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token":"fetched-` + strconv.Itoa(int(n)) + `","token_type":"Bearer","expires_in":1800}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func applyAuth(t *testing.T, p *OAuthProvider) string {
+	t.Helper()
+	req, _ := http.NewRequest(http.MethodGet, "https://example.com", nil)
+	if err := p.ApplyAuth(req); err != nil {
+		t.Fatalf("ApplyAuth failed: %v", err)
+	}
+	return req.Header.Get("Authorization")
+}
+
+func TestOAuthProvider_UsesValidCachedToken(t *testing.T) {
+	var hits int32
+	server := newTokenServer(t, &hits)
+	now := time.Now()
+	cache := &memoryTokenCache{token: &TokenData{AccessToken: "cached", ExpiresAt: now.Add(time.Hour)}}
+
+	p := &OAuthProvider{ClientID: "client", ClientSecret: "secret", TokenURL: server.URL, Cache: cache}
+	p.now = func() time.Time { return now }
+
+	if got := applyAuth(t, p); got != "Bearer cached" {
+		t.Errorf("expected cached token, got %q", got)
+	}
+	if hits := atomic.LoadInt32(&hits); hits != 0 {
+		t.Errorf("expected no token request, got %d", hits)
+	}
+}
+
+func TestOAuthProvider_FetchesWhenCacheExpired(t *testing.T) {
+	var hits int32
+	server := newTokenServer(t, &hits)
+	now := time.Now()
+	cache := &memoryTokenCache{token: &TokenData{AccessToken: "stale", ExpiresAt: now.Add(10 * time.Second)}}
+
+	p := &OAuthProvider{ClientID: "client", ClientSecret: "secret", TokenURL: server.URL, Cache: cache}
+	p.now = func() time.Time { return now }
+
+	if got := applyAuth(t, p); got != "Bearer fetched-1" {
+		t.Errorf("expected fetched token, got %q", got)
+	}
+	if cache.saves != 1 || cache.token.AccessToken != "fetched-1" || !cache.token.ExpiresAt.Equal(now.Add(30*time.Minute)) {
+		t.Errorf("expected fetched token to be cached, got %+v after %d saves", cache.token, cache.saves)
+	}
+
+	// The token is reused until shortly before it expires
+	now = now.Add(29 * time.Minute)
+	if got := applyAuth(t, p); got != "Bearer fetched-1" {
+		t.Errorf("expected token reuse, got %q", got)
+	}
+	now = now.Add(45 * time.Second)
+	if got := applyAuth(t, p); got != "Bearer fetched-2" {
+		t.Errorf("expected refreshed token, got %q", got)
+	}
+	if hits := atomic.LoadInt32(&hits); hits != 2 {
+		t.Errorf("expected 2 token requests, got %d", hits)
+	}
+}
+
+func TestOAuthProvider_TokenRequestRejected(t *testing.T) {
+	var hits int32
+	server := newTokenServer(t, &hits)
+	p := &OAuthProvider{ClientID: "client", ClientSecret: "wrong", TokenURL: server.URL}
+
+	req, _ := http.NewRequest(http.MethodGet, "https://example.com", nil)
+	if err := p.ApplyAuth(req); err == nil {
+		t.Fatal("expected error for rejected credentials")
+	}
+	if req.Header.Get("Authorization") != "" {
+		t.Error("expected no Authorization header")
+	}
+}
+
+func TestFileTokenCache(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token.json")
+	cache := NewFileTokenCache(path)
+
+	token, err := cache.Load()
+	if err != nil || token != nil {
+		t.Fatalf("expected no token before save, got %+v, %v", token, err)
+	}
+
+	expiresAt := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	if err := cache.Save(&TokenData{AccessToken: "abc", ExpiresAt: expiresAt}); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("cache file missing: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("expected mode 0600, got %o", perm)
+	}
+
+	token, err = cache.Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if token.AccessToken != "abc" || !token.ExpiresAt.Equal(expiresAt) {
+		t.Errorf("unexpected token: %+v", token)
+	}
+
+	if err := os.WriteFile(path, []byte("not json"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cache.Load(); err == nil {
+		t.Error("expected error for corrupt cache file")
+	}
+}
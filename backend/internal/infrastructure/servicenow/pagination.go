@@ -3,12 +3,17 @@ package servicenow
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"math"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
+
+	"github.com/controlcrud/backend/internal/infrastructure/content"
 )
 
 // PaginationConfig holds configuration for paginated requests.
@@ -18,6 +23,11 @@ type PaginationConfig struct {
 	RetryDelay     time.Duration // Initial delay between retries
 	MaxRetryDelay  time.Duration // Maximum delay for exponential backoff
 	RateLimitDelay time.Duration // Delay when rate limited
+
+	// Cacheable serves pages from the client's Cache. Only reads whose
+	// records are not written back by this client may set it, so a pull
+	// after a push never sees content cached before the push.
+	Cacheable bool
 }
 
 // DefaultPaginationConfig returns sensible defaults.
@@ -96,39 +106,15 @@ func FetchAllPages[T any](
 		q.Set("sysparm_limit", strconv.Itoa(config.PageSize))
 		req.URL.RawQuery = q.Encode()
 
-		// Apply authentication
-		if client.auth != nil {
-			if err := client.auth.ApplyAuth(req); err != nil {
-				return result, fmt.Errorf("failed to apply auth: %w", err)
-			}
-		}
-
-		// Execute request with retries and rate limit handling
-		resp, err := executeWithRetry(ctx, client, req, config)
+		page, err := fetchPage(ctx, client, req, config)
 		if err != nil {
 			result.Errors = append(result.Errors, err)
 			return result, err
 		}
-		defer resp.Body.Close()
-
-		// Check for errors
-		if err := checkResponseError(resp); err != nil {
-			result.Errors = append(result.Errors, err)
-			return result, err
-		}
-
-		// Parse total count from header (ServiceNow returns this)
-		if totalHeader := resp.Header.Get("X-Total-Count"); totalHeader != "" {
-			if total, err := strconv.Atoi(totalHeader); err == nil {
-				result.TotalCount = total
-			}
-		}
-
-		// Parse response body
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return result, fmt.Errorf("%w: failed to read response: %v", ErrInvalidResponse, err)
+		if page.TotalCount > 0 {
+			result.TotalCount = page.TotalCount
 		}
+		body := page.Body
 
 		var tableResponse TableAPIResponse[T]
 		if err := json.Unmarshal(body, &tableResponse); err != nil {
@@ -139,6 +125,12 @@ func FetchAllPages[T any](
 		result.Records = append(result.Records, tableResponse.Result...)
 		result.PagesFetched++
 		pageNum++
+		slog.DebugContext(ctx, "fetched ServiceNow page",
+			"endpoint", endpoint,
+			"page", pageNum,
+			"offset", offset,
+			"records", len(tableResponse.Result),
+			"total", result.TotalCount)
 
 		// Call progress callback
 		if onProgress != nil {
@@ -164,6 +156,76 @@ func FetchAllPages[T any](
 	return result, nil
 }
 
+// cachedPage is a Table API page as stored in the client's cache.
+type cachedPage struct {
+	TotalCount int             `json:"total_count"` // X-Total-Count header; 0 when absent
+	Body       json.RawMessage `json:"body"`
+}
+
+// fetchPage returns a single Table API page, served from the client's cache
+// when config is cacheable and a fresh copy of the same URL is stored there.
+func fetchPage(ctx context.Context, client *SNClient, req *http.Request, config *PaginationConfig) (*cachedPage, error) {
+	cache := client.config.Cache
+	if !config.Cacheable {
+		cache = nil
+	}
+	cacheKey := req.URL.String()
+	if cache != nil {
+		if data, ok := cache.Get(cacheKey); ok {
+			var page cachedPage
+			if err := json.Unmarshal(data, &page); err == nil {
+				return &page, nil
+			}
+		}
+	}
+
+	// Apply authentication
+	if client.auth != nil {
+		if err := client.auth.ApplyAuth(req); err != nil {
+			return nil, fmt.Errorf("failed to apply auth: %w", err)
+		}
+	}
+
+	// Execute request with retries and rate limit handling
+	resp, err := executeWithRetry(ctx, client, req, config)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Check for errors
+	if err := checkResponseError(resp); err != nil {
+		return nil, err
+	}
+
+	page := &cachedPage{}
+
+	// Parse total count from header (ServiceNow returns this)
+	if totalHeader := resp.Header.Get("X-Total-Count"); totalHeader != "" {
+		if total, err := strconv.Atoi(totalHeader); err == nil {
+			page.TotalCount = total
+		}
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to read response: %v", ErrInvalidResponse, err)
+	}
+	page.Body = body
+
+	if cache != nil && json.Valid(body) {
+		ttl := client.config.CacheTTL
+		if ttl <= 0 {
+			ttl = DefaultCacheTTL
+		}
+		if data, err := json.Marshal(page); err == nil {
+			cache.Set(cacheKey, data, ttl)
+		}
+	}
+
+	return page, nil
+}
+
 // executeWithRetry executes a request with exponential backoff and rate limit handling.
 func executeWithRetry(
 	ctx context.Context,
@@ -182,7 +244,19 @@ func executeWithRetry(
 		default:
 		}
 
+		// A request body is consumed by each attempt; rewind it for retries
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("%w: failed to rewind request body: %v", ErrConnectionFailed, err)
+			}
+			req.Body = body
+		}
+
 		resp, err := client.httpClient.Do(req)
+		if errors.Is(err, ErrCircuitOpen) {
+			return nil, fmt.Errorf("%w: %w", ErrConnectionFailed, err)
+		}
 		if err != nil {
 			lastErr = fmt.Errorf("%w: %v", ErrConnectionFailed, err)
 			time.Sleep(delay)
@@ -276,15 +350,25 @@ type SystemRecord struct {
 func (c *SNClient) FetchSystems(ctx context.Context, config *PaginationConfig, onProgress ProgressCallback) (*PaginatedResult[SystemRecord], error) {
 	// DEMO: Using incident categories as mock systems
 	// IRM: Would use cmdb_ci_service or sn_grc_business_entity table
-	endpoint := fmt.Sprintf("%s/api/now/table/sys_choice", c.config.InstanceURL)
+	endpoint := fmt.Sprintf("%s/api/now/table/%s", c.config.InstanceURL, c.mapping.SystemsTable)
 
 	query := map[string]string{
-		"sysparm_query":  "name=incident^element=category^inactive=false",
 		"sysparm_fields": "sys_id,label,value,sys_updated_on",
 	}
+	if encoded := c.scopedQuery(c.mapping.SystemsQuery); encoded != "" {
+		query["sysparm_query"] = encoded
+	}
+
+	// The systems list is read-only to this client, so repeated discovery
+	// can be served from the cache
+	cacheable := DefaultPaginationConfig()
+	if config != nil {
+		*cacheable = *config
+	}
+	cacheable.Cacheable = true
 
 	// Fetch choices which represent our "systems" in demo mode
-	choiceResult, err := FetchAllPages[map[string]interface{}](ctx, c, endpoint, query, config, onProgress)
+	choiceResult, err := FetchAllPages[map[string]interface{}](ctx, c, endpoint, query, cacheable, onProgress)
 	if err != nil {
 		return nil, err
 	}
@@ -325,6 +409,8 @@ type ControlRecord struct {
 	ControlFamily      string `json:"control_family,omitempty"`
 	ImplementationStatus string `json:"implementation_status,omitempty"`
 	SysUpdatedOn       string `json:"sys_updated_on,omitempty"`
+
+	ResponsibleRole string `json:"responsible_role,omitempty"`
 }
 
 // FetchControls fetches controls for a system from ServiceNow.
@@ -332,11 +418,17 @@ type ControlRecord struct {
 func (c *SNClient) FetchControls(ctx context.Context, systemSysID string, config *PaginationConfig, onProgress ProgressCallback) (*PaginatedResult[ControlRecord], error) {
 	// DEMO: Using priorities as mock controls
 	// IRM: Would use sn_compliance_control table with system filter
-	endpoint := fmt.Sprintf("%s/api/now/table/sys_choice", c.config.InstanceURL)
+	endpoint := fmt.Sprintf("%s/api/now/table/%s", c.config.InstanceURL, c.mapping.ControlsTable)
 
+	fields := "sys_id,label,value,sys_updated_on"
+	if c.mapping.ResponsibleRoleField != "" {
+		fields += "," + c.mapping.ResponsibleRoleField
+	}
 	query := map[string]string{
-		"sysparm_query":  "name=incident^element=priority^inactive=false",
-		"sysparm_fields": "sys_id,label,value,sys_updated_on",
+		"sysparm_fields": fields,
+	}
+	if encoded := c.scopedQuery(c.mapping.ControlsQuery); encoded != "" {
+		query["sysparm_query"] = encoded
 	}
 
 	choiceResult, err := FetchAllPages[map[string]interface{}](ctx, c, endpoint, query, config, onProgress)
@@ -366,6 +458,10 @@ func (c *SNClient) FetchControls(ctx context.Context, systemSysID string, config
 		label, _ := choice["label"].(string)
 		value, _ := choice["value"].(string)
 		updatedOn, _ := choice["sys_updated_on"].(string)
+		var role string
+		if c.mapping.ResponsibleRoleField != "" {
+			role, _ = choice[c.mapping.ResponsibleRoleField].(string)
+		}
 
 		family := familyMap[value]
 		if family == "" {
@@ -380,6 +476,8 @@ func (c *SNClient) FetchControls(ctx context.Context, systemSysID string, config
 			ControlFamily: family,
 			ImplementationStatus: "not_assessed",
 			SysUpdatedOn:  updatedOn,
+
+			ResponsibleRole: role,
 		})
 	}
 
@@ -402,13 +500,21 @@ type StatementRecord struct {
 func (c *SNClient) FetchStatements(ctx context.Context, controlSysID string, config *PaginationConfig, onProgress ProgressCallback) (*PaginatedResult[StatementRecord], error) {
 	// DEMO: Using incidents as mock statements
 	// IRM: Would use sn_compliance_policy_statement table
-	endpoint := fmt.Sprintf("%s/api/now/table/incident", c.config.InstanceURL)
+	endpoint := fmt.Sprintf("%s/api/now/table/%s", c.config.InstanceURL, c.mapping.StatementsTable)
+
+	fields := []string{"sys_id", c.mapping.NumberField, c.mapping.ContentField}
+	if c.mapping.DescriptionField != "" {
+		fields = append(fields, c.mapping.DescriptionField)
+	}
+	fields = append(fields, "sys_updated_on")
 
 	query := map[string]string{
-		"sysparm_query":  "active=true",
-		"sysparm_fields": "sys_id,number,short_description,description,sys_updated_on",
+		"sysparm_fields": strings.Join(fields, ","),
 		"sysparm_limit":  strconv.Itoa(int(math.Min(float64(DefaultPaginationConfig().PageSize), 20))), // Limit for demo
 	}
+	if encoded := c.scopedQuery(c.mapping.StatementsQuery); encoded != "" {
+		query["sysparm_query"] = encoded
+	}
 
 	incidentResult, err := FetchAllPages[map[string]interface{}](ctx, c, endpoint, query, config, onProgress)
 	if err != nil {
@@ -425,21 +531,29 @@ func (c *SNClient) FetchStatements(ctx context.Context, controlSysID string, con
 
 	for _, incident := range incidentResult.Records {
 		sysID, _ := incident["sys_id"].(string)
-		number, _ := incident["number"].(string)
-		shortDesc, _ := incident["short_description"].(string)
-		desc, _ := incident["description"].(string)
+		number, _ := incident[c.mapping.NumberField].(string)
+		shortDesc, _ := incident[c.mapping.ContentField].(string)
+		var desc string
+		if c.mapping.DescriptionField != "" {
+			desc, _ = incident[c.mapping.DescriptionField].(string)
+		}
 		updatedOn, _ := incident["sys_updated_on"].(string)
 
-		content := shortDesc
+		if c.config.StripHTMLOnPull {
+			shortDesc = content.StripHTML(shortDesc)
+			desc = content.StripHTML(desc)
+		}
+
+		text := shortDesc
 		if desc != "" {
-			content = fmt.Sprintf("%s\n\n%s", shortDesc, desc)
+			text = fmt.Sprintf("%s\n\n%s", shortDesc, desc)
 		}
 
 		result.Records = append(result.Records, StatementRecord{
 			SysID:         sysID,
 			Number:        number,
 			Name:          shortDesc,
-			Content:       content,
+			Content:       text,
 			StatementType: "implementation",
 			SysUpdatedOn:  updatedOn,
 		})
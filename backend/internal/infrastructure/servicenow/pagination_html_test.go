@@ -0,0 +1,76 @@
+package servicenow
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// htmlStatementJSON is a statement whose demo mapping fields hold HTML.
+const htmlStatementJSON = `{"sys_id":"abc123","number":"INC0001",` +
+	`"short_description":"<strong>Access</strong> review",` +
+	`"description":"<p>Access is reviewed:</p><ul><li>quarterly</li><li>on role change</li></ul><script>track()</script>",` +
+	`"sys_updated_on":"2026-03-01 10:00:00"}`
+
+func newHTMLStatementServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/api/now/table/incident/abc123" {
+			w.Write([]byte(`{"result":` + htmlStatementJSON + `}`))
+			return
+		}
+		w.Write([]byte(`{"result":[` + htmlStatementJSON + `]}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestFetchStatements_StripsHTML(t *testing.T) {
+	server := newHTMLStatementServer(t)
+	client, err := NewSNClient(DefaultConfig(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	result, err := client.FetchStatements(context.Background(), "ctrl-1", nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "Access review\n\nAccess is reviewed:\nquarterly\non role change"
+	if len(result.Records) != 1 || result.Records[0].Content != want {
+		t.Fatalf("expected content %q, got %+v", want, result.Records)
+	}
+	if result.Records[0].Name != "Access review" {
+		t.Errorf("expected name without HTML, got %q", result.Records[0].Name)
+	}
+
+	// A re-pull of the same record reads the same content
+	record, err := client.GetPolicyStatement(context.Background(), "abc123")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := record.Content(); got != want {
+		t.Errorf("expected single record content %q, got %q", want, got)
+	}
+}
+
+func TestFetchStatements_KeepsHTMLWhenDisabled(t *testing.T) {
+	server := newHTMLStatementServer(t)
+	config := DefaultConfig(server.URL)
+	config.StripHTMLOnPull = false
+	client, err := NewSNClient(config)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	result, err := client.FetchStatements(context.Background(), "ctrl-1", nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "<strong>Access</strong> review\n\n<p>Access is reviewed:</p><ul><li>quarterly</li><li>on role change</li></ul><script>track()</script>"
+	if len(result.Records) != 1 || result.Records[0].Content != want {
+		t.Errorf("expected content as returned, got %+v", result.Records)
+	}
+}
@@ -3,45 +3,38 @@ package servicenow
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/controlcrud/backend/internal/infrastructure/content"
 )
 
 // =============================================================================
-// DEMO MODE CONFIGURATION
+// TABLE MAPPING
 // =============================================================================
-// The following constants control which ServiceNow table is queried.
-// Currently using 'incident' table because IRM (Integrated Risk Management)
-// is not installed on the dev instance.
+// The table and field names queried below come from the client's TableMapping.
+// The built-in demo mapping uses the 'incident' table because IRM (Integrated
+// Risk Management) is not installed on the dev instance.
 //
 // TO SWITCH TO IRM:
-// 1. Change policyStatementTable to "sn_compliance_policy_statement"
-// 2. Update policyStatementFields to include IRM-specific fields (name, u_control_family)
-// 3. Remove "priority" from fields (incident-specific)
-// 4. Update transformPolicyStatement in domain/controls/service.go to remove fallbacks
-// 5. Update frontend ControlCard.tsx state mappings for IRM states
+// 1. Provide a mapping file via SN_TABLE_MAPPING_FILE with statements_table
+//    "sn_compliance_policy_statement" and IRM field names (e.g. u_control_family)
+// 2. Update transformPolicyStatement in domain/controls/service.go to remove fallbacks
+// 3. Update frontend ControlCard.tsx state mappings for IRM states
 //
 // See: 0xcc/docs/INCIDENT_TO_IRM_MIGRATION.md for complete migration guide
 // =============================================================================
 
-const (
-	// policyStatementTable is the ServiceNow table to query for policy statements.
-	// DEMO: "incident" - Change to "sn_compliance_policy_statement" for IRM
-	policyStatementTable = "incident"
-
-	// policyStatementFieldsDemo are fields available on the incident table (demo mode)
-	// DEMO: These are incident fields - IRM would use: sys_id,number,name,short_description,description,state,category,u_control_family,active,sys_created_on,sys_updated_on
-)
-
 // GetPolicyStatements fetches policy statements from ServiceNow.
-// DEMO MODE: Currently using 'incident' table. See constants above to switch to IRM.
+// Queries the statements table from the client's TableMapping.
 func (c *SNClient) GetPolicyStatements(ctx context.Context, params *PolicyStatementParams) (*PolicyStatementResponse, error) {
 	// Build the endpoint URL using the configured table
-	endpoint := fmt.Sprintf("%s/api/now/table/%s", c.config.InstanceURL, policyStatementTable)
+	endpoint := fmt.Sprintf("%s/api/now/table/%s", c.config.InstanceURL, c.mapping.StatementsTable)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
 	if err != nil {
@@ -71,12 +64,16 @@ func (c *SNClient) GetPolicyStatements(ctx context.Context, params *PolicyStatem
 	}
 	q.Set("sysparm_offset", strconv.Itoa(offset))
 
-	// Fields to return (using incident fields for demo)
-	fields := []string{
-		"sys_id", "number", "short_description", "description",
-		"state", "category", "priority", "active",
-		"sys_created_on", "sys_updated_on",
+	// Fields to return
+	fields := []string{"sys_id", c.mapping.NumberField, c.mapping.ContentField}
+	if c.mapping.DescriptionField != "" {
+		fields = append(fields, c.mapping.DescriptionField)
+	}
+	fields = append(fields, "state", "category")
+	if c.mapping.ControlFamilyField != "" {
+		fields = append(fields, c.mapping.ControlFamilyField)
 	}
+	fields = append(fields, "active", "sys_created_on", "sys_updated_on")
 	if params != nil && len(params.Fields) > 0 {
 		fields = params.Fields
 	}
@@ -85,21 +82,23 @@ func (c *SNClient) GetPolicyStatements(ctx context.Context, params *PolicyStatem
 	// Build query string for search/filter
 	var queryParts []string
 	if params != nil && params.Query != "" {
-		// Search by number or short_description (case-insensitive contains)
-		searchQuery := fmt.Sprintf("numberLIKE%s^ORshort_descriptionLIKE%s",
-			params.Query, params.Query)
+		// Search by number or content (case-insensitive contains)
+		searchQuery := fmt.Sprintf("%sLIKE%s^OR%sLIKE%s",
+			c.mapping.NumberField, params.Query, c.mapping.ContentField, params.Query)
 		queryParts = append(queryParts, searchQuery)
 	}
 
-	// Only active records by default
-	queryParts = append(queryParts, "active=true")
+	// Base filter from the table mapping (active records in demo mode)
+	if c.mapping.StatementsQuery != "" {
+		queryParts = append(queryParts, c.mapping.StatementsQuery)
+	}
 
-	if len(queryParts) > 0 {
-		q.Set("sysparm_query", strings.Join(queryParts, "^"))
+	if encoded := c.scopedQuery(strings.Join(queryParts, "^")); encoded != "" {
+		q.Set("sysparm_query", encoded)
 	}
 
 	// Ordering
-	orderBy := "number"
+	orderBy := c.mapping.NumberField
 	if params != nil && params.OrderBy != "" {
 		orderBy = params.OrderBy
 	}
@@ -129,7 +128,7 @@ func (c *SNClient) GetPolicyStatements(ctx context.Context, params *PolicyStatem
 	var lastErr error
 	for attempt := 0; attempt <= c.config.MaxRetries; attempt++ {
 		resp, lastErr = c.httpClient.Do(req)
-		if lastErr == nil && resp.StatusCode < 500 {
+		if lastErr == nil && resp.StatusCode < 500 || errors.Is(lastErr, ErrCircuitOpen) {
 			break
 		}
 		if attempt < c.config.MaxRetries {
@@ -138,7 +137,7 @@ func (c *SNClient) GetPolicyStatements(ctx context.Context, params *PolicyStatem
 	}
 
 	if lastErr != nil {
-		return nil, fmt.Errorf("%w: %v", ErrConnectionFailed, lastErr)
+		return nil, fmt.Errorf("%w: %w", ErrConnectionFailed, lastErr)
 	}
 	defer resp.Body.Close()
 
@@ -153,10 +152,14 @@ func (c *SNClient) GetPolicyStatements(ctx context.Context, params *PolicyStatem
 		return nil, fmt.Errorf("%w: failed to read response: %v", ErrInvalidResponse, err)
 	}
 
-	var tableResponse TableAPIResponse[PolicyStatementRecord]
+	var tableResponse TableAPIResponse[map[string]interface{}]
 	if err := json.Unmarshal(body, &tableResponse); err != nil {
 		return nil, fmt.Errorf("%w: failed to parse response: %v", ErrInvalidResponse, err)
 	}
+	records := make([]PolicyStatementRecord, 0, len(tableResponse.Result))
+	for _, record := range tableResponse.Result {
+		records = append(records, c.policyStatementFromRecord(record))
+	}
 
 	// Get total count from header
 	totalCount := len(tableResponse.Result)
@@ -167,15 +170,15 @@ func (c *SNClient) GetPolicyStatements(ctx context.Context, params *PolicyStatem
 	}
 
 	return &PolicyStatementResponse{
-		Records:    tableResponse.Result,
+		Records:    records,
 		TotalCount: totalCount,
 	}, nil
 }
 
-// GetPolicyStatement fetches a single policy statement by sys_id.
-// DEMO MODE: Currently using 'incident' table. See constants above to switch to IRM.
+// GetPolicyStatement fetches a single policy statement by sys_id. HTML in
+// its descriptions is converted to text as by FetchStatements.
 func (c *SNClient) GetPolicyStatement(ctx context.Context, sysID string) (*PolicyStatementRecord, error) {
-	endpoint := fmt.Sprintf("%s/api/now/table/%s/%s", c.config.InstanceURL, policyStatementTable, sysID)
+	endpoint := fmt.Sprintf("%s/api/now/table/%s/%s", c.config.InstanceURL, c.mapping.StatementsTable, sysID)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
 	if err != nil {
@@ -198,7 +201,7 @@ func (c *SNClient) GetPolicyStatement(ctx context.Context, sysID string) (*Polic
 	var lastErr error
 	for attempt := 0; attempt <= c.config.MaxRetries; attempt++ {
 		resp, lastErr = c.httpClient.Do(req)
-		if lastErr == nil && resp.StatusCode < 500 {
+		if lastErr == nil && resp.StatusCode < 500 || errors.Is(lastErr, ErrCircuitOpen) {
 			break
 		}
 		if attempt < c.config.MaxRetries {
@@ -207,7 +210,7 @@ func (c *SNClient) GetPolicyStatement(ctx context.Context, sysID string) (*Polic
 	}
 
 	if lastErr != nil {
-		return nil, fmt.Errorf("%w: %v", ErrConnectionFailed, lastErr)
+		return nil, fmt.Errorf("%w: %w", ErrConnectionFailed, lastErr)
 	}
 	defer resp.Body.Close()
 
@@ -224,13 +227,46 @@ func (c *SNClient) GetPolicyStatement(ctx context.Context, sysID string) (*Polic
 
 	// Single record response has different structure
 	var singleResponse struct {
-		Result PolicyStatementRecord `json:"result"`
+		Result map[string]interface{} `json:"result"`
 	}
 	if err := json.Unmarshal(body, &singleResponse); err != nil {
 		return nil, fmt.Errorf("%w: failed to parse response: %v", ErrInvalidResponse, err)
 	}
 
-	return &singleResponse.Result, nil
+	// Match the content FetchStatements pulls
+	record := c.policyStatementFromRecord(singleResponse.Result)
+	if c.config.StripHTMLOnPull {
+		record.ShortDescription = content.StripHTML(record.ShortDescription)
+		record.Description = content.StripHTML(record.Description)
+	}
+	return &record, nil
+}
+
+// policyStatementFromRecord reads a policy statement from a table record.
+// The number, content, description and control family come from the
+// fields named by the client's TableMapping, as in FetchStatements.
+func (c *SNClient) policyStatementFromRecord(record map[string]interface{}) PolicyStatementRecord {
+	field := func(name string) string {
+		if name == "" {
+			return ""
+		}
+		value, _ := record[name].(string)
+		return value
+	}
+	return PolicyStatementRecord{
+		SysID:            field("sys_id"),
+		Number:           field(c.mapping.NumberField),
+		Name:             field("name"),
+		ShortDescription: field(c.mapping.ContentField),
+		Description:      field(c.mapping.DescriptionField),
+		State:            field("state"),
+		Category:         field("category"),
+		ControlFamily:    field(c.mapping.ControlFamilyField),
+		Priority:         field("priority"),
+		Active:           field("active"),
+		SysCreatedOn:     field("sys_created_on"),
+		SysUpdatedOn:     field("sys_updated_on"),
+	}
 }
 
 // checkResponseStatus checks HTTP response status and returns appropriate error.
@@ -253,15 +289,13 @@ func checkResponseStatus(resp *http.Response) error {
 }
 
 // UpdateStatement updates a statement in ServiceNow.
-// DEMO MODE: Updates the incident's short_description field.
-// FOR IRM: Would update sn_compliance_policy_statement.u_implementation_statement
+// Writes the mapping's content field (short_description in demo mode,
+// e.g. u_implementation_statement for IRM).
 func (c *SNClient) UpdateStatement(ctx context.Context, sysID string, content string) error {
-	endpoint := fmt.Sprintf("%s/api/now/table/%s/%s", c.config.InstanceURL, policyStatementTable, sysID)
+	endpoint := fmt.Sprintf("%s/api/now/table/%s/%s", c.config.InstanceURL, c.mapping.StatementsTable, sysID)
 
-	// Build the payload - using short_description for demo (incident table)
-	// FOR IRM: Change to "u_implementation_statement" or appropriate field
 	payload := map[string]string{
-		"short_description": content,
+		c.mapping.ContentField: content,
 	}
 
 	payloadBytes, err := json.Marshal(payload)
@@ -290,7 +324,7 @@ func (c *SNClient) UpdateStatement(ctx context.Context, sysID string, content st
 	var lastErr error
 	for attempt := 0; attempt <= c.config.MaxRetries; attempt++ {
 		resp, lastErr = c.httpClient.Do(req)
-		if lastErr == nil && resp.StatusCode < 500 {
+		if lastErr == nil && resp.StatusCode < 500 || errors.Is(lastErr, ErrCircuitOpen) {
 			break
 		}
 		if attempt < c.config.MaxRetries {
@@ -299,7 +333,7 @@ func (c *SNClient) UpdateStatement(ctx context.Context, sysID string, content st
 	}
 
 	if lastErr != nil {
-		return fmt.Errorf("%w: %v", ErrConnectionFailed, lastErr)
+		return fmt.Errorf("%w: %w", ErrConnectionFailed, lastErr)
 	}
 	defer resp.Body.Close()
 
@@ -0,0 +1,32 @@
+package servicenow
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// proxyFunc returns the transport proxy selector for a configured proxy URL.
+// An empty URL uses the standard proxy environment variables.
+func proxyFunc(rawURL string) (func(*http.Request) (*url.URL, error), error) {
+	if rawURL == "" {
+		return http.ProxyFromEnvironment, nil
+	}
+
+	proxyURL, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy URL: %w", err)
+	}
+	switch proxyURL.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, fmt.Errorf("invalid proxy URL: unsupported scheme %q", proxyURL.Scheme)
+	}
+	if proxyURL.Host == "" {
+		return nil, fmt.Errorf("invalid proxy URL: host is required")
+	}
+
+	// url.Parse stores user:pass from the URL in proxyURL.User (a url.User
+	// value), which the transport sends to the proxy as Proxy-Authorization.
+	return http.ProxyURL(proxyURL), nil
+}
@@ -0,0 +1,62 @@
+package servicenow
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+// newForwardProxy returns a server that answers proxied requests itself,
+// recording the target URL and Proxy-Authorization header.
+func newForwardProxy(t *testing.T, gotURL, gotAuth *string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*gotURL = r.URL.String()
+		*gotAuth = r.Header.Get("Proxy-Authorization")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"result":[{"name":"glide.buildtag","value":"glide-vancouver"}]}`))
+	}))
+}
+
+func TestSNClient_ProxyURL(t *testing.T) {
+	var gotURL, gotAuth string
+	proxy := newForwardProxy(t, &gotURL, &gotAuth)
+	defer proxy.Close()
+
+	proxyURL, _ := url.Parse(proxy.URL)
+	proxyURL.User = url.UserPassword("proxyuser", "p@ss")
+
+	config := DefaultConfig("http://acme.service-now.invalid")
+	config.MaxRetries = 0
+	config.ProxyURL = proxyURL.String()
+	client, err := NewSNClient(config)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	if _, err := client.TestConnection(context.Background()); err != nil {
+		t.Fatalf("expected request to pass through proxy, got %v", err)
+	}
+
+	target, err := url.Parse(gotURL)
+	if err != nil || target.Host != "acme.service-now.invalid" || target.Path != "/api/now/table/sys_properties" {
+		t.Errorf("expected proxied request for the instance, got %q", gotURL)
+	}
+	wantAuth := "Basic " + base64.StdEncoding.EncodeToString([]byte("proxyuser:p@ss"))
+	if gotAuth != wantAuth {
+		t.Errorf("expected Proxy-Authorization %q, got %q", wantAuth, gotAuth)
+	}
+}
+
+func TestNewSNClient_InvalidProxyURL(t *testing.T) {
+	for _, proxyURL := range []string{"ftp://proxy:21", "http://", "http://[::1"} {
+		config := DefaultConfig("https://acme.service-now.com")
+		config.ProxyURL = proxyURL
+		if _, err := NewSNClient(config); err == nil {
+			t.Errorf("expected error for proxy URL %q", proxyURL)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package servicenow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// QueryBuilder builds a ServiceNow encoded query for the sysparm_query
+// parameter. Conditions are joined with ^, which ServiceNow treats as AND.
+// Each method returns a new builder, so a partially built query can be
+// shared and extended safely.
+//
+//	NewQuery().Equal("active", "true").Like("name", "NIST").Build()
+type QueryBuilder struct {
+	conditions []string
+}
+
+// NewQuery returns an empty query.
+func NewQuery() QueryBuilder {
+	return QueryBuilder{}
+}
+
+// Equal adds a field=value condition.
+func (q QueryBuilder) Equal(field, value string) QueryBuilder {
+	return q.with(field, "=", value)
+}
+
+// NotEqual adds a field!=value condition.
+func (q QueryBuilder) NotEqual(field, value string) QueryBuilder {
+	return q.with(field, "!=", value)
+}
+
+// GreaterThan adds a field>value condition. Dates use ServiceNow's
+// "2006-01-02 15:04:05" format.
+func (q QueryBuilder) GreaterThan(field, value string) QueryBuilder {
+	return q.with(field, ">", value)
+}
+
+// LessThan adds a field<value condition.
+func (q QueryBuilder) LessThan(field, value string) QueryBuilder {
+	return q.with(field, "<", value)
+}
+
+// Like adds a condition matching records whose field contains value.
+func (q QueryBuilder) Like(field, value string) QueryBuilder {
+	return q.with(field, "LIKE", value)
+}
+
+// Raw adds an already encoded query, such as a filter from the table
+// mapping, as a single condition. It is not escaped.
+func (q QueryBuilder) Raw(encoded string) QueryBuilder {
+	if encoded == "" {
+		return q
+	}
+	return q.append(encoded)
+}
+
+// IsEmpty returns true if the query has no conditions.
+func (q QueryBuilder) IsEmpty() bool {
+	return len(q.conditions) == 0
+}
+
+// Build returns the encoded query. The result is the raw sysparm_query
+// value; it is URL-encoded when the request is built.
+func (q QueryBuilder) Build() string {
+	return strings.Join(q.conditions, "^")
+}
+
+// String returns the encoded query.
+func (q QueryBuilder) String() string {
+	return q.Build()
+}
+
+func (q QueryBuilder) with(field, operator, value string) QueryBuilder {
+	return q.append(field + operator + escapeQueryValue(value))
+}
+
+// append adds a condition without modifying the builder's backing array,
+// which may be shared with other builders.
+func (q QueryBuilder) append(condition string) QueryBuilder {
+	conditions := make([]string, len(q.conditions), len(q.conditions)+1)
+	copy(conditions, q.conditions)
+	return QueryBuilder{conditions: append(conditions, condition)}
+}
+
+// escapeQueryValue escapes the ^ separator so a value cannot add
+// conditions of its own. ServiceNow reads ^^ as a literal caret.
+func escapeQueryValue(value string) string {
+	return strings.ReplaceAll(value, "^", "^^")
+}
+
+// FetchWithQuery fetches all records of a table matching the query. Only
+// the listed fields are returned, or every field when fields is empty.
+func (c *SNClient) FetchWithQuery(ctx context.Context, table string, query QueryBuilder, fields []string, config *PaginationConfig) (*PaginatedResult[map[string]interface{}], error) {
+	if table == "" {
+		return nil, errors.New("table name is required")
+	}
+	endpoint := fmt.Sprintf("%s/api/now/table/%s", c.config.InstanceURL, table)
+
+	params := map[string]string{}
+	if !query.IsEmpty() {
+		params["sysparm_query"] = query.Build()
+	}
+	if len(fields) > 0 {
+		params["sysparm_fields"] = strings.Join(fields, ",")
+	}
+
+	return FetchAllPages[map[string]interface{}](ctx, c, endpoint, params, config, nil)
+}
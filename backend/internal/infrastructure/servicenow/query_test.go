@@ -0,0 +1,89 @@
+package servicenow
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestQueryBuilder_Build(t *testing.T) {
+	tests := []struct {
+		name  string
+		query QueryBuilder
+		want  string
+	}{
+		{"empty", NewQuery(), ""},
+		{"single condition", NewQuery().Equal("active", "true"), "active=true"},
+		{
+			"and conditions",
+			NewQuery().Equal("active", "true").GreaterThan("sys_updated_on", "2024-01-01 00:00:00").Like("name", "NIST"),
+			"active=true^sys_updated_on>2024-01-01 00:00:00^nameLIKENIST",
+		},
+		{"other operators", NewQuery().NotEqual("state", "7").LessThan("priority", "3"), "state!=7^priority<3"},
+		{"caret escaped", NewQuery().Like("name", "a^ORactive=false"), "nameLIKEa^^ORactive=false"},
+		{"raw kept", NewQuery().Raw("active=true^ORstate=1").Equal("x", "y"), "active=true^ORstate=1^x=y"},
+		{"empty raw ignored", NewQuery().Raw(""), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.query.Build(); got != tt.want {
+				t.Errorf("Build() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueryBuilder_SharedBase(t *testing.T) {
+	base := NewQuery().Equal("active", "true")
+	a := base.Equal("name", "a")
+	b := base.Equal("name", "b")
+
+	if base.Build() != "active=true" {
+		t.Errorf("base modified: %q", base.Build())
+	}
+	if a.Build() != "active=true^name=a" || b.Build() != "active=true^name=b" {
+		t.Errorf("derived queries interfere: %q, %q", a.Build(), b.Build())
+	}
+}
+
+func TestFetchWithQuery(t *testing.T) {
+	var gotQuery, gotFields, gotPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotQuery = r.URL.Query().Get("sysparm_query")
+		gotFields = r.URL.Query().Get("sysparm_fields")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"result":[{"sys_id":"abc","name":"NIST & Co"}]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewSNClient(DefaultConfig(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	query := NewQuery().Equal("active", "true").Like("name", "NIST & Co")
+	result, err := client.FetchWithQuery(context.Background(), "sn_compliance_control", query, []string{"sys_id", "name"}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if gotPath != "/api/now/table/sn_compliance_control" {
+		t.Errorf("unexpected path: %s", gotPath)
+	}
+	if gotQuery != "active=true^nameLIKENIST & Co" {
+		t.Errorf("unexpected sysparm_query: %q", gotQuery)
+	}
+	if gotFields != "sys_id,name" {
+		t.Errorf("unexpected sysparm_fields: %q", gotFields)
+	}
+	if len(result.Records) != 1 || result.Records[0]["sys_id"] != "abc" {
+		t.Errorf("unexpected records: %+v", result.Records)
+	}
+
+	if _, err := client.FetchWithQuery(context.Background(), "", NewQuery(), nil, nil); err == nil {
+		t.Error("expected error for empty table name")
+	}
+}
@@ -0,0 +1,44 @@
+package servicenow
+
+import (
+	"context"
+	"fmt"
+)
+
+// scopesTable is the ServiceNow table of application scopes.
+const scopesTable = "sys_scope"
+
+// ScopeRecord is an application scope of the instance. Its SysID is the
+// value ClientConfig.Scope expects.
+type ScopeRecord struct {
+	SysID     string `json:"sys_id"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"` // The scope field, e.g. x_acme_grc
+}
+
+// ListScopes returns the application scopes of the instance from the
+// sys_scope table. The configured Scope does not apply.
+func (c *SNClient) ListScopes(ctx context.Context) ([]ScopeRecord, error) {
+	result, err := c.FetchWithQuery(ctx, scopesTable, NewQuery(), []string{"sys_id", "name", "scope"}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("fetch scopes: %w", err)
+	}
+
+	scopes := make([]ScopeRecord, 0, len(result.Records))
+	for _, record := range result.Records {
+		sysID, _ := record["sys_id"].(string)
+		name, _ := record["name"].(string)
+		namespace, _ := record["scope"].(string)
+		scopes = append(scopes, ScopeRecord{SysID: sysID, Name: name, Namespace: namespace})
+	}
+	return scopes, nil
+}
+
+// scopedQuery adds the configured scope filter to an encoded query of a
+// mapped GRC table. Platform tables such as sys_properties are not scoped.
+func (c *SNClient) scopedQuery(encoded string) string {
+	if c.config.Scope == "" {
+		return encoded
+	}
+	return NewQuery().Raw(encoded).Equal("sys_scope", c.config.Scope).Build()
+}
@@ -0,0 +1,130 @@
+package servicenow
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// newQueryRecordingServer records the sysparm_query of each table request
+// in order and returns an empty page.
+func newQueryRecordingServer(t *testing.T) (*httptest.Server, *[]string) {
+	t.Helper()
+	var mu sync.Mutex
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		queries = append(queries, r.URL.Query().Get("sysparm_query"))
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Total-Count", "0")
+		w.Write([]byte(`{"result":[]}`))
+	}))
+	t.Cleanup(server.Close)
+	return server, &queries
+}
+
+func fetchMappedTables(t *testing.T, client *SNClient) {
+	t.Helper()
+	ctx := context.Background()
+	if _, err := client.FetchSystems(ctx, nil, nil); err != nil {
+		t.Fatalf("FetchSystems: %v", err)
+	}
+	if _, err := client.FetchControls(ctx, "sys1", nil, nil); err != nil {
+		t.Fatalf("FetchControls: %v", err)
+	}
+	if _, err := client.FetchStatements(ctx, "ctrl1", nil, nil); err != nil {
+		t.Fatalf("FetchStatements: %v", err)
+	}
+}
+
+func TestSNClient_Scope_AppendedToTableQueries(t *testing.T) {
+	server, queries := newQueryRecordingServer(t)
+
+	config := DefaultConfig(server.URL)
+	config.Scope = "a1b2c3d4e5f6"
+	client, err := NewSNClient(config)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	fetchMappedTables(t, client)
+
+	mapping := DemoTableMapping()
+	want := []string{
+		mapping.SystemsQuery + "^sys_scope=a1b2c3d4e5f6",
+		mapping.ControlsQuery + "^sys_scope=a1b2c3d4e5f6",
+		mapping.StatementsQuery + "^sys_scope=a1b2c3d4e5f6",
+	}
+	if len(*queries) != len(want) {
+		t.Fatalf("expected %d requests, got %v", len(want), *queries)
+	}
+	for i, got := range *queries {
+		if got != want[i] {
+			t.Errorf("request %d: expected sysparm_query %q, got %q", i, want[i], got)
+		}
+	}
+}
+
+func TestSNClient_Scope_NotSet(t *testing.T) {
+	server, queries := newQueryRecordingServer(t)
+
+	client, err := NewSNClient(DefaultConfig(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	fetchMappedTables(t, client)
+
+	if len(*queries) == 0 {
+		t.Fatal("expected table requests")
+	}
+	for i, got := range *queries {
+		if strings.Contains(got, "sys_scope") {
+			t.Errorf("request %d: expected no scope filter, got %q", i, got)
+		}
+	}
+}
+
+func TestSNClient_ListScopes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/now/table/sys_scope" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("sysparm_fields"); got != "sys_id,name,scope" {
+			t.Errorf("unexpected sysparm_fields: %q", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"result":[
+			{"sys_id":"global","name":"Global","scope":"global"},
+			{"sys_id":"a1b2c3","name":"Acme GRC","scope":"x_acme_grc"}
+		]}`))
+	}))
+	defer server.Close()
+
+	// The configured scope does not filter the scope list itself
+	config := DefaultConfig(server.URL)
+	config.Scope = "a1b2c3"
+	client, err := NewSNClient(config)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	scopes, err := client.ListScopes(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []ScopeRecord{
+		{SysID: "global", Name: "Global", Namespace: "global"},
+		{SysID: "a1b2c3", Name: "Acme GRC", Namespace: "x_acme_grc"},
+	}
+	if len(scopes) != len(want) {
+		t.Fatalf("expected %d scopes, got %v", len(want), scopes)
+	}
+	for i := range want {
+		if scopes[i] != want[i] {
+			t.Errorf("scope %d: expected %+v, got %+v", i, want[i], scopes[i])
+		}
+	}
+}
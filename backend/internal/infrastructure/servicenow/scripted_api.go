@@ -0,0 +1,87 @@
+package servicenow
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// ErrScriptedAPINotConfigured is returned when a scripted REST API call is
+// made without a namespace and API ID.
+var ErrScriptedAPINotConfigured = errors.New("scripted REST API namespace and API ID are not configured")
+
+// CallScriptedAPI calls a Scripted REST API resource at
+// <instance_url>/api/<namespace>/<api_id>/<path> and returns the raw JSON
+// response body. A nil body sends no request body. Retries and rate limits
+// are handled the same way as Table API requests.
+func (c *SNClient) CallScriptedAPI(ctx context.Context, path string, method string, body interface{}) (json.RawMessage, error) {
+	if c.config.ScriptedAPINamespace == "" || c.config.ScriptedAPIID == "" {
+		return nil, ErrScriptedAPINotConfigured
+	}
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	endpoint := fmt.Sprintf("%s/api/%s/%s",
+		strings.TrimRight(c.config.InstanceURL, "/"),
+		url.PathEscape(c.config.ScriptedAPINamespace),
+		url.PathEscape(c.config.ScriptedAPIID),
+	)
+	if path = strings.Trim(path, "/"); path != "" {
+		endpoint += "/" + path
+	}
+
+	var reqBody io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal request body: %w", err)
+		}
+		reqBody = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, strings.ToUpper(method), endpoint, reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %v", ErrConnectionFailed, err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	if c.auth != nil {
+		if err := c.auth.ApplyAuth(req); err != nil {
+			return nil, fmt.Errorf("failed to apply auth: %w", err)
+		}
+	}
+
+	resp, err := executeWithRetry(ctx, c, req, DefaultPaginationConfig())
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Scripted resources may answer with any 2xx status, not just 200
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, checkResponseError(resp)
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to read response: %v", ErrInvalidResponse, err)
+	}
+	if len(bytes.TrimSpace(respBody)) == 0 {
+		return nil, nil
+	}
+	if !json.Valid(respBody) {
+		return nil, fmt.Errorf("%w: response is not JSON", ErrInvalidResponse)
+	}
+
+	return json.RawMessage(respBody), nil
+}
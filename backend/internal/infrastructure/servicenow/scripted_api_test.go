@@ -0,0 +1,120 @@
+package servicenow
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func newScriptedAPIClient(t *testing.T, url string) *SNClient {
+	t.Helper()
+	config := DefaultConfig(url)
+	config.ScriptedAPINamespace = "x_acme_grc"
+	config.ScriptedAPIID = "statements"
+	client, err := NewSNClient(config)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	client.SetAuth(&BasicAuthProvider{Username: "admin", Password: "secret"})
+	return client
+}
+
+func TestSNClient_CallScriptedAPI(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/x_acme_grc/statements/v1/export" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if r.Method != http.MethodPost {
+			t.Errorf("expected POST, got %s", r.Method)
+		}
+		if user, pass, ok := r.BasicAuth(); !ok || user != "admin" || pass != "secret" {
+			t.Error("expected basic auth to be applied")
+		}
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != `{"system":"ACME"}` {
+			t.Errorf("unexpected body %s", body)
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"result":{"exported":3}}`))
+	}))
+	defer server.Close()
+
+	client := newScriptedAPIClient(t, server.URL)
+	raw, err := client.CallScriptedAPI(context.Background(), "/v1/export/", "post", map[string]string{"system": "ACME"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var resp struct {
+		Result struct {
+			Exported int `json:"exported"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(raw, &resp); err != nil {
+		t.Fatalf("failed to decode raw response: %v", err)
+	}
+	if resp.Result.Exported != 3 {
+		t.Errorf("expected 3 exported, got %d", resp.Result.Exported)
+	}
+}
+
+func TestSNClient_CallScriptedAPI_RetriesWithBody(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != `{"ping":true}` {
+			t.Errorf("attempt %d: unexpected body %q", calls.Load()+1, body)
+		}
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	client := newScriptedAPIClient(t, server.URL)
+	if _, err := client.CallScriptedAPI(context.Background(), "ping", http.MethodPost, map[string]bool{"ping": true}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls.Load() != 2 {
+		t.Errorf("expected 2 attempts, got %d", calls.Load())
+	}
+}
+
+func TestSNClient_CallScriptedAPI_Errors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/x_acme_grc/statements/missing":
+			w.WriteHeader(http.StatusNotFound)
+		case "/api/x_acme_grc/statements/html":
+			w.Write([]byte(`<html>login</html>`))
+		case "/api/x_acme_grc/statements/empty":
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	client := newScriptedAPIClient(t, server.URL)
+	ctx := context.Background()
+
+	if _, err := client.CallScriptedAPI(ctx, "missing", "", nil); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+	if _, err := client.CallScriptedAPI(ctx, "html", "", nil); !errors.Is(err, ErrInvalidResponse) {
+		t.Errorf("expected ErrInvalidResponse, got %v", err)
+	}
+	if raw, err := client.CallScriptedAPI(ctx, "empty", "", nil); err != nil || raw != nil {
+		t.Errorf("expected empty result, got %q, %v", raw, err)
+	}
+
+	unconfigured, _ := NewSNClient(DefaultConfig(server.URL))
+	if _, err := unconfigured.CallScriptedAPI(ctx, "ping", "", nil); !errors.Is(err, ErrScriptedAPINotConfigured) {
+		t.Errorf("expected ErrScriptedAPINotConfigured, got %v", err)
+	}
+}
@@ -0,0 +1,115 @@
+package servicenow
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// instanceTimezoneProperty is the system property holding the instance's
+// UTC offset, e.g. "-05:00", or an IANA zone name such as "America/New_York".
+const instanceTimezoneProperty = "glide.sys.utc_offset"
+
+// snTimeLayout is the layout of ServiceNow date-time fields.
+const snTimeLayout = "2006-01-02 15:04:05"
+
+// timezoneCache holds the instance timezone once it has been read.
+type timezoneCache struct {
+	mu  sync.Mutex
+	loc *time.Location
+}
+
+// GetInstanceTimezone returns the timezone ServiceNow reports timestamps in,
+// read from the glide.sys.utc_offset system property. Instances without the
+// property are treated as UTC. The result is cached for the client's
+// lifetime; failed lookups are retried on the next call.
+func (c *SNClient) GetInstanceTimezone(ctx context.Context) (*time.Location, error) {
+	// Hold the lock while fetching so concurrent callers share one request
+	c.timezone.mu.Lock()
+	defer c.timezone.mu.Unlock()
+
+	if c.timezone.loc != nil {
+		return c.timezone.loc, nil
+	}
+
+	result, err := c.FetchWithQuery(ctx, "sys_properties", NewQuery().Equal("name", instanceTimezoneProperty), []string{"name", "value"}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("fetch instance timezone: %w", err)
+	}
+
+	loc := time.UTC
+	for _, record := range result.Records {
+		value, _ := record["value"].(string)
+		if strings.TrimSpace(value) == "" {
+			continue
+		}
+		if loc, err = parseTimezone(value); err != nil {
+			return nil, err
+		}
+		break
+	}
+
+	c.timezone.loc = loc
+	return loc, nil
+}
+
+// parseTimezone interprets a glide.sys.utc_offset value: an IANA zone name,
+// or a UTC offset such as "+05:30", "-0500" or "-5".
+func parseTimezone(value string) (*time.Location, error) {
+	value = strings.TrimSpace(value)
+	if value == "UTC" || value == "GMT" {
+		return time.UTC, nil
+	}
+	if value[0] != '+' && value[0] != '-' {
+		loc, err := time.LoadLocation(value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: unknown instance timezone %q", ErrInvalidResponse, value)
+		}
+		return loc, nil
+	}
+
+	sign := 1
+	if value[0] == '-' {
+		sign = -1
+	}
+	hoursPart, minutesPart, hasMinutes := strings.Cut(value[1:], ":")
+	if !hasMinutes && len(hoursPart) == 4 {
+		hoursPart, minutesPart = hoursPart[:2], hoursPart[2:]
+	}
+	hours, err := strconv.Atoi(hoursPart)
+	if err != nil || hours > 14 {
+		return nil, fmt.Errorf("%w: invalid instance UTC offset %q", ErrInvalidResponse, value)
+	}
+	minutes := 0
+	if minutesPart != "" {
+		minutes, err = strconv.Atoi(minutesPart)
+		if err != nil || minutes > 59 {
+			return nil, fmt.Errorf("%w: invalid instance UTC offset %q", ErrInvalidResponse, value)
+		}
+	}
+
+	offset := sign * (hours*3600 + minutes*60)
+	if offset == 0 {
+		return time.UTC, nil
+	}
+	return time.FixedZone("UTC"+value, offset), nil
+}
+
+// ParseSNTime parses a ServiceNow timestamp. Timestamps without a zone, in
+// ServiceNow's "2006-01-02 15:04:05" format, are read in loc; a nil loc
+// means UTC. RFC 3339 timestamps carry their own zone.
+func ParseSNTime(ts string, loc *time.Location) (time.Time, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	if t, err := time.ParseInLocation(snTimeLayout, ts, loc); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, ts); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("unable to parse ServiceNow time %q", ts)
+}
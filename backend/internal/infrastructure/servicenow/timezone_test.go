@@ -0,0 +1,124 @@
+package servicenow
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// timezoneServer serves value as the glide.sys.utc_offset property; an
+// empty value serves no property at all.
+func timezoneServer(t *testing.T, value string, hits *int32) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(hits, 1)
+		if r.URL.Path != "/api/now/table/sys_properties" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("sysparm_query"); got != "name=glide.sys.utc_offset" {
+			t.Errorf("unexpected sysparm_query: %q", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if value == "" {
+			w.Write([]byte(`{"result":[]}`))
+			return
+		}
+		w.Write([]byte(`{"result":[{"name":"glide.sys.utc_offset","value":"` + value + `"}]}`))
+	}))
+}
+
+func TestGetInstanceTimezone(t *testing.T) {
+	// 2026-01-15 10:30:00 on the instance clock
+	const snTime = "2026-01-15 10:30:00"
+
+	tests := []struct {
+		name    string
+		value   string
+		wantUTC time.Time
+	}{
+		{"UTC instance", "", time.Date(2026, 1, 15, 10, 30, 0, 0, time.UTC)},
+		{"explicit UTC offset", "+00:00", time.Date(2026, 1, 15, 10, 30, 0, 0, time.UTC)},
+		{"EST instance", "-05:00", time.Date(2026, 1, 15, 15, 30, 0, 0, time.UTC)},
+		{"EST compact offset", "-0500", time.Date(2026, 1, 15, 15, 30, 0, 0, time.UTC)},
+		{"IST instance", "+05:30", time.Date(2026, 1, 15, 5, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var hits int32
+			server := timezoneServer(t, tt.value, &hits)
+			defer server.Close()
+
+			client, err := NewSNClient(DefaultConfig(server.URL))
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			loc, err := client.GetInstanceTimezone(context.Background())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got, err := ParseSNTime(snTime, loc)
+			if err != nil {
+				t.Fatalf("unexpected parse error: %v", err)
+			}
+			if !got.Equal(tt.wantUTC) {
+				t.Errorf("expected %s, got %s", tt.wantUTC, got.UTC())
+			}
+
+			// The timezone is cached
+			if _, err := client.GetInstanceTimezone(context.Background()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := atomic.LoadInt32(&hits); got != 1 {
+				t.Errorf("expected cached timezone, got %d requests", got)
+			}
+		})
+	}
+}
+
+func TestGetInstanceTimezone_InvalidValue(t *testing.T) {
+	var hits int32
+	server := timezoneServer(t, "+99:00", &hits)
+	defer server.Close()
+
+	client, err := NewSNClient(DefaultConfig(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	if _, err := client.GetInstanceTimezone(context.Background()); err == nil {
+		t.Error("expected error for invalid offset")
+	}
+}
+
+func TestParseSNTime(t *testing.T) {
+	ist := time.FixedZone("IST", 5*3600+30*60)
+
+	tests := []struct {
+		name    string
+		ts      string
+		loc     *time.Location
+		want    time.Time
+		wantErr bool
+	}{
+		{"nil location is UTC", "2026-01-15 10:30:00", nil, time.Date(2026, 1, 15, 10, 30, 0, 0, time.UTC), false},
+		{"instance zone applied", "2026-01-15 10:30:00", ist, time.Date(2026, 1, 15, 5, 0, 0, 0, time.UTC), false},
+		{"RFC 3339 keeps its zone", "2026-01-15T10:30:00Z", ist, time.Date(2026, 1, 15, 10, 30, 0, 0, time.UTC), false},
+		{"invalid", "15/01/2026", nil, time.Time{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSNTime(tt.ts, tt.loc)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("expected %s, got %s", tt.want, got.UTC())
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package servicenow
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// UserGroupsCacheTTL is how long a client reuses the user group list.
+const UserGroupsCacheTTL = 5 * time.Minute
+
+// userGroupsTable is the ServiceNow table of user groups.
+const userGroupsTable = "sys_user_group"
+
+// userGroupCache holds the user group names last fetched by a client, and
+// the groups looked up by sys_id.
+type userGroupCache struct {
+	mu        sync.Mutex
+	names     []string
+	fetchedAt time.Time
+
+	byIDMu sync.Mutex
+	byID   map[string]cachedUserGroup
+}
+
+// UserGroup is a ServiceNow user group.
+type UserGroup struct {
+	SysID string `json:"sys_id"`
+	Name  string `json:"name"`
+}
+
+// cachedUserGroup is a user group with the time it was fetched.
+type cachedUserGroup struct {
+	group     UserGroup
+	fetchedAt time.Time
+}
+
+// GetUserGroups returns the names of the active user groups from the
+// sys_user_group table. The list is cached for UserGroupsCacheTTL.
+func (c *SNClient) GetUserGroups(ctx context.Context) ([]string, error) {
+	// Hold the lock while fetching so concurrent callers share one request
+	c.userGroups.mu.Lock()
+	defer c.userGroups.mu.Unlock()
+
+	if c.userGroups.names != nil && c.now().Sub(c.userGroups.fetchedAt) < UserGroupsCacheTTL {
+		return append([]string(nil), c.userGroups.names...), nil
+	}
+
+	result, err := c.FetchWithQuery(ctx, userGroupsTable, NewQuery().Equal("active", "true"), []string{"name"}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("fetch user groups: %w", err)
+	}
+
+	names := make([]string, 0, len(result.Records))
+	for _, record := range result.Records {
+		if name, _ := record["name"].(string); name != "" {
+			names = append(names, name)
+		}
+	}
+
+	c.userGroups.names = names
+	c.userGroups.fetchedAt = c.now()
+	return append([]string(nil), names...), nil
+}
+
+// GetUserGroupByID returns the user group with the given sys_id, or
+// ErrNotFound if there is none. Groups found are cached for
+// UserGroupsCacheTTL; missing groups are looked up again on the next call.
+func (c *SNClient) GetUserGroupByID(ctx context.Context, sysID string) (*UserGroup, error) {
+	c.userGroups.byIDMu.Lock()
+	defer c.userGroups.byIDMu.Unlock()
+
+	if cached, ok := c.userGroups.byID[sysID]; ok && c.now().Sub(cached.fetchedAt) < UserGroupsCacheTTL {
+		group := cached.group
+		return &group, nil
+	}
+
+	result, err := c.FetchWithQuery(ctx, userGroupsTable, NewQuery().Equal("sys_id", sysID), []string{"sys_id", "name"}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("fetch user group %s: %w", sysID, err)
+	}
+	if len(result.Records) == 0 {
+		return nil, fmt.Errorf("user group %s: %w", sysID, ErrNotFound)
+	}
+
+	name, _ := result.Records[0]["name"].(string)
+	group := UserGroup{SysID: sysID, Name: name}
+	if c.userGroups.byID == nil {
+		c.userGroups.byID = make(map[string]cachedUserGroup)
+	}
+	c.userGroups.byID[sysID] = cachedUserGroup{group: group, fetchedAt: c.now()}
+	return &group, nil
+}
@@ -0,0 +1,119 @@
+package servicenow
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGetUserGroups_Cache(t *testing.T) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		if r.URL.Path != "/api/now/table/sys_user_group" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("sysparm_query"); got != "active=true" {
+			t.Errorf("unexpected sysparm_query: %q", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"result":[{"name":"Security Operations"},{"name":"Network Team"},{"name":""}]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewSNClient(DefaultConfig(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	now := time.Now()
+	client.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	// Cache miss fetches the groups
+	groups, err := client.GetUserGroups(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(groups) != 2 || groups[0] != "Security Operations" || groups[1] != "Network Team" {
+		t.Errorf("unexpected groups: %v", groups)
+	}
+
+	// Cache hit within the TTL
+	now = now.Add(UserGroupsCacheTTL - time.Second)
+	groups[0] = "modified by caller"
+	groups, err = client.GetUserGroups(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := atomic.LoadInt32(&hits); got != 1 {
+		t.Errorf("expected cached groups, got %d requests", got)
+	}
+	if groups[0] != "Security Operations" {
+		t.Errorf("cached groups modified through returned slice: %v", groups)
+	}
+
+	// Expired cache fetches again
+	now = now.Add(time.Second)
+	if _, err := client.GetUserGroups(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := atomic.LoadInt32(&hits); got != 2 {
+		t.Errorf("expected refetch after TTL, got %d requests", got)
+	}
+}
+
+func TestGetUserGroupByID(t *testing.T) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		if r.URL.Path != "/api/now/table/sys_user_group" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("sysparm_query") == "sys_id=a1b2c3" {
+			w.Write([]byte(`{"result":[{"sys_id":"a1b2c3","name":"Security Operations"}]}`))
+			return
+		}
+		w.Write([]byte(`{"result":[]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewSNClient(DefaultConfig(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	now := time.Now()
+	client.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	group, err := client.GetUserGroupByID(ctx, "a1b2c3")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if *group != (UserGroup{SysID: "a1b2c3", Name: "Security Operations"}) {
+		t.Errorf("unexpected group: %+v", group)
+	}
+
+	// Found groups are cached within the TTL
+	now = now.Add(UserGroupsCacheTTL - time.Second)
+	if _, err := client.GetUserGroupByID(ctx, "a1b2c3"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := atomic.LoadInt32(&hits); got != 1 {
+		t.Errorf("expected cached group, got %d requests", got)
+	}
+
+	// Missing groups are not cached
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetUserGroupByID(ctx, "ffffff"); !errors.Is(err, ErrNotFound) {
+			t.Fatalf("expected ErrNotFound, got %v", err)
+		}
+	}
+	if got := atomic.LoadInt32(&hits); got != 3 {
+		t.Errorf("expected each missing lookup to query, got %d requests", got)
+	}
+}
@@ -0,0 +1,109 @@
+// Package webhook delivers signed JSON notifications to external systems.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// SignatureHeader carries the HMAC-SHA256 signature of the request body,
+// formatted as "sha256=<hex digest>".
+const SignatureHeader = "X-Signature-256"
+
+// ErrDeliveryFailed is returned when the receiver rejects a webhook or does
+// not accept it within the retry budget.
+var ErrDeliveryFailed = errors.New("webhook delivery failed")
+
+// Client posts JSON payloads to webhook URLs, signing each body with a
+// shared secret so receivers can verify its origin.
+type Client struct {
+	secret     []byte
+	httpClient *http.Client
+	maxRetries int
+	baseDelay  time.Duration // Delay before the first retry; doubles on each retry
+}
+
+// NewClient creates a webhook client signing payloads with secret. Failed
+// deliveries are retried 3 times with exponential backoff.
+func NewClient(secret string) *Client {
+	return &Client{
+		secret:     []byte(secret),
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		maxRetries: 3,
+		baseDelay:  time.Second,
+	}
+}
+
+// Send posts payload as JSON to url. Network errors, 429 and 5xx responses
+// are retried; other non-2xx responses fail immediately.
+func (c *Client) Send(ctx context.Context, url string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+	signature := Sign(c.secret, body)
+
+	var lastErr error
+	for attempt := 0; attempt <= c.maxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(c.baseDelay << (attempt - 1)):
+			}
+		}
+
+		var retry bool
+		retry, lastErr = c.post(ctx, url, body, signature)
+		if lastErr == nil {
+			return nil
+		}
+		if !retry {
+			return lastErr
+		}
+	}
+	return fmt.Errorf("%w after %d attempts: %w", ErrDeliveryFailed, c.maxRetries+1, lastErr)
+}
+
+// post makes a single delivery attempt and reports whether a failure is
+// worth retrying.
+func (c *Client) post(ctx context.Context, url string, body []byte, signature string) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(SignatureHeader, signature)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return ctx.Err() == nil, fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return false, nil
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return true, fmt.Errorf("%w: status %d", ErrDeliveryFailed, resp.StatusCode)
+	default:
+		return false, fmt.Errorf("%w: status %d", ErrDeliveryFailed, resp.StatusCode)
+	}
+}
+
+// Sign returns the SignatureHeader value for body.
+func Sign(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,100 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestClient(secret string) *Client {
+	c := NewClient(secret)
+	c.baseDelay = time.Millisecond
+	return c
+}
+
+func TestClient_Send(t *testing.T) {
+	var body []byte
+	var signature, contentType string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		signature = r.Header.Get(SignatureHeader)
+		contentType = r.Header.Get("Content-Type")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	payload := map[string]string{"event": "conflict"}
+	if err := newTestClient("s3cret").Send(context.Background(), server.URL, payload); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if string(body) != `{"event":"conflict"}` {
+		t.Errorf("unexpected body %s", body)
+	}
+	if contentType != "application/json" {
+		t.Errorf("expected JSON content type, got %q", contentType)
+	}
+	if want := Sign([]byte("s3cret"), body); signature != want {
+		t.Errorf("expected signature %q, got %q", want, signature)
+	}
+	if signature == Sign([]byte("other"), body) {
+		t.Error("expected signature to depend on the secret")
+	}
+}
+
+func TestClient_Send_RetriesServerErrors(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	if err := newTestClient("s3cret").Send(context.Background(), server.URL, struct{}{}); err != nil {
+		t.Fatalf("expected delivery to succeed on retry, got %v", err)
+	}
+	if got := attempts.Load(); got != 3 {
+		t.Errorf("expected 3 attempts, got %d", got)
+	}
+}
+
+func TestClient_Send_GivesUp(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	err := newTestClient("s3cret").Send(context.Background(), server.URL, struct{}{})
+	if !errors.Is(err, ErrDeliveryFailed) {
+		t.Fatalf("expected ErrDeliveryFailed, got %v", err)
+	}
+	if got := attempts.Load(); got != 4 {
+		t.Errorf("expected initial attempt plus 3 retries, got %d", got)
+	}
+}
+
+func TestClient_Send_ClientErrorNotRetried(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	if err := newTestClient("s3cret").Send(context.Background(), server.URL, struct{}{}); !errors.Is(err, ErrDeliveryFailed) {
+		t.Fatalf("expected ErrDeliveryFailed, got %v", err)
+	}
+	if got := attempts.Load(); got != 1 {
+		t.Errorf("expected a single attempt, got %d", got)
+	}
+}
@@ -0,0 +1,959 @@
+// Internal service contract mirroring the system, statement and pull domain
+// services so they can be called from a separate process.
+//
+// Go stubs are generated with:
+//
+//   protoc --go_out=. --go_opt=paths=source_relative \
+//          --go-grpc_out=. --go-grpc_opt=paths=source_relative \
+//          proto/autogrc.proto
+//
+// UUIDs are carried as strings and timestamps as google.protobuf.Timestamp.
+// Every call must carry "authorization: Bearer <token>" metadata holding the
+// same HS256 token the HTTP API accepts.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: proto/autogrc.proto
+
+package autogrcpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	SystemService_DiscoverSystems_FullMethodName     = "/autogrc.v1.SystemService/DiscoverSystems"
+	SystemService_ImportSystems_FullMethodName       = "/autogrc.v1.SystemService/ImportSystems"
+	SystemService_ListSystems_FullMethodName         = "/autogrc.v1.SystemService/ListSystems"
+	SystemService_GetSystem_FullMethodName           = "/autogrc.v1.SystemService/GetSystem"
+	SystemService_GetSummary_FullMethodName          = "/autogrc.v1.SystemService/GetSummary"
+	SystemService_DeleteSystem_FullMethodName        = "/autogrc.v1.SystemService/DeleteSystem"
+	SystemService_RestoreSystem_FullMethodName       = "/autogrc.v1.SystemService/RestoreSystem"
+	SystemService_SetConflictStrategy_FullMethodName = "/autogrc.v1.SystemService/SetConflictStrategy"
+)
+
+// SystemServiceClient is the client API for SystemService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// SystemService mirrors system.Service.
+type SystemServiceClient interface {
+	DiscoverSystems(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*DiscoverSystemsResponse, error)
+	ImportSystems(ctx context.Context, in *ImportSystemsRequest, opts ...grpc.CallOption) (*ImportSystemsResponse, error)
+	ListSystems(ctx context.Context, in *ListSystemsRequest, opts ...grpc.CallOption) (*ListSystemsResponse, error)
+	GetSystem(ctx context.Context, in *GetByIDRequest, opts ...grpc.CallOption) (*System, error)
+	GetSummary(ctx context.Context, in *GetByIDRequest, opts ...grpc.CallOption) (*SystemSummary, error)
+	DeleteSystem(ctx context.Context, in *GetByIDRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RestoreSystem(ctx context.Context, in *GetByIDRequest, opts ...grpc.CallOption) (*System, error)
+	SetConflictStrategy(ctx context.Context, in *SetConflictStrategyRequest, opts ...grpc.CallOption) (*System, error)
+}
+
+type systemServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSystemServiceClient(cc grpc.ClientConnInterface) SystemServiceClient {
+	return &systemServiceClient{cc}
+}
+
+func (c *systemServiceClient) DiscoverSystems(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*DiscoverSystemsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DiscoverSystemsResponse)
+	err := c.cc.Invoke(ctx, SystemService_DiscoverSystems_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *systemServiceClient) ImportSystems(ctx context.Context, in *ImportSystemsRequest, opts ...grpc.CallOption) (*ImportSystemsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImportSystemsResponse)
+	err := c.cc.Invoke(ctx, SystemService_ImportSystems_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *systemServiceClient) ListSystems(ctx context.Context, in *ListSystemsRequest, opts ...grpc.CallOption) (*ListSystemsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSystemsResponse)
+	err := c.cc.Invoke(ctx, SystemService_ListSystems_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *systemServiceClient) GetSystem(ctx context.Context, in *GetByIDRequest, opts ...grpc.CallOption) (*System, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(System)
+	err := c.cc.Invoke(ctx, SystemService_GetSystem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *systemServiceClient) GetSummary(ctx context.Context, in *GetByIDRequest, opts ...grpc.CallOption) (*SystemSummary, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SystemSummary)
+	err := c.cc.Invoke(ctx, SystemService_GetSummary_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *systemServiceClient) DeleteSystem(ctx context.Context, in *GetByIDRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, SystemService_DeleteSystem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *systemServiceClient) RestoreSystem(ctx context.Context, in *GetByIDRequest, opts ...grpc.CallOption) (*System, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(System)
+	err := c.cc.Invoke(ctx, SystemService_RestoreSystem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *systemServiceClient) SetConflictStrategy(ctx context.Context, in *SetConflictStrategyRequest, opts ...grpc.CallOption) (*System, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(System)
+	err := c.cc.Invoke(ctx, SystemService_SetConflictStrategy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SystemServiceServer is the server API for SystemService service.
+// All implementations must embed UnimplementedSystemServiceServer
+// for forward compatibility.
+//
+// SystemService mirrors system.Service.
+type SystemServiceServer interface {
+	DiscoverSystems(context.Context, *emptypb.Empty) (*DiscoverSystemsResponse, error)
+	ImportSystems(context.Context, *ImportSystemsRequest) (*ImportSystemsResponse, error)
+	ListSystems(context.Context, *ListSystemsRequest) (*ListSystemsResponse, error)
+	GetSystem(context.Context, *GetByIDRequest) (*System, error)
+	GetSummary(context.Context, *GetByIDRequest) (*SystemSummary, error)
+	DeleteSystem(context.Context, *GetByIDRequest) (*emptypb.Empty, error)
+	RestoreSystem(context.Context, *GetByIDRequest) (*System, error)
+	SetConflictStrategy(context.Context, *SetConflictStrategyRequest) (*System, error)
+	mustEmbedUnimplementedSystemServiceServer()
+}
+
+// UnimplementedSystemServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedSystemServiceServer struct{}
+
+func (UnimplementedSystemServiceServer) DiscoverSystems(context.Context, *emptypb.Empty) (*DiscoverSystemsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DiscoverSystems not implemented")
+}
+func (UnimplementedSystemServiceServer) ImportSystems(context.Context, *ImportSystemsRequest) (*ImportSystemsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportSystems not implemented")
+}
+func (UnimplementedSystemServiceServer) ListSystems(context.Context, *ListSystemsRequest) (*ListSystemsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSystems not implemented")
+}
+func (UnimplementedSystemServiceServer) GetSystem(context.Context, *GetByIDRequest) (*System, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSystem not implemented")
+}
+func (UnimplementedSystemServiceServer) GetSummary(context.Context, *GetByIDRequest) (*SystemSummary, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSummary not implemented")
+}
+func (UnimplementedSystemServiceServer) DeleteSystem(context.Context, *GetByIDRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSystem not implemented")
+}
+func (UnimplementedSystemServiceServer) RestoreSystem(context.Context, *GetByIDRequest) (*System, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreSystem not implemented")
+}
+func (UnimplementedSystemServiceServer) SetConflictStrategy(context.Context, *SetConflictStrategyRequest) (*System, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetConflictStrategy not implemented")
+}
+func (UnimplementedSystemServiceServer) mustEmbedUnimplementedSystemServiceServer() {}
+func (UnimplementedSystemServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeSystemServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SystemServiceServer will
+// result in compilation errors.
+type UnsafeSystemServiceServer interface {
+	mustEmbedUnimplementedSystemServiceServer()
+}
+
+func RegisterSystemServiceServer(s grpc.ServiceRegistrar, srv SystemServiceServer) {
+	// If the following call pancis, it indicates UnimplementedSystemServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&SystemService_ServiceDesc, srv)
+}
+
+func _SystemService_DiscoverSystems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemServiceServer).DiscoverSystems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SystemService_DiscoverSystems_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemServiceServer).DiscoverSystems(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SystemService_ImportSystems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportSystemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemServiceServer).ImportSystems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SystemService_ImportSystems_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemServiceServer).ImportSystems(ctx, req.(*ImportSystemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SystemService_ListSystems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSystemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemServiceServer).ListSystems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SystemService_ListSystems_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemServiceServer).ListSystems(ctx, req.(*ListSystemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SystemService_GetSystem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetByIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemServiceServer).GetSystem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SystemService_GetSystem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemServiceServer).GetSystem(ctx, req.(*GetByIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SystemService_GetSummary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetByIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemServiceServer).GetSummary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SystemService_GetSummary_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemServiceServer).GetSummary(ctx, req.(*GetByIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SystemService_DeleteSystem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetByIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemServiceServer).DeleteSystem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SystemService_DeleteSystem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemServiceServer).DeleteSystem(ctx, req.(*GetByIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SystemService_RestoreSystem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetByIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemServiceServer).RestoreSystem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SystemService_RestoreSystem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemServiceServer).RestoreSystem(ctx, req.(*GetByIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SystemService_SetConflictStrategy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetConflictStrategyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemServiceServer).SetConflictStrategy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SystemService_SetConflictStrategy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemServiceServer).SetConflictStrategy(ctx, req.(*SetConflictStrategyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SystemService_ServiceDesc is the grpc.ServiceDesc for SystemService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SystemService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "autogrc.v1.SystemService",
+	HandlerType: (*SystemServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "DiscoverSystems",
+			Handler:    _SystemService_DiscoverSystems_Handler,
+		},
+		{
+			MethodName: "ImportSystems",
+			Handler:    _SystemService_ImportSystems_Handler,
+		},
+		{
+			MethodName: "ListSystems",
+			Handler:    _SystemService_ListSystems_Handler,
+		},
+		{
+			MethodName: "GetSystem",
+			Handler:    _SystemService_GetSystem_Handler,
+		},
+		{
+			MethodName: "GetSummary",
+			Handler:    _SystemService_GetSummary_Handler,
+		},
+		{
+			MethodName: "DeleteSystem",
+			Handler:    _SystemService_DeleteSystem_Handler,
+		},
+		{
+			MethodName: "RestoreSystem",
+			Handler:    _SystemService_RestoreSystem_Handler,
+		},
+		{
+			MethodName: "SetConflictStrategy",
+			Handler:    _SystemService_SetConflictStrategy_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/autogrc.proto",
+}
+
+const (
+	StatementService_GetStatement_FullMethodName    = "/autogrc.v1.StatementService/GetStatement"
+	StatementService_ListStatements_FullMethodName  = "/autogrc.v1.StatementService/ListStatements"
+	StatementService_ListModified_FullMethodName    = "/autogrc.v1.StatementService/ListModified"
+	StatementService_ListConflicts_FullMethodName   = "/autogrc.v1.StatementService/ListConflicts"
+	StatementService_UpdateLocal_FullMethodName     = "/autogrc.v1.StatementService/UpdateLocal"
+	StatementService_ResolveConflict_FullMethodName = "/autogrc.v1.StatementService/ResolveConflict"
+	StatementService_RevertToRemote_FullMethodName  = "/autogrc.v1.StatementService/RevertToRemote"
+)
+
+// StatementServiceClient is the client API for StatementService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// StatementService mirrors statement.Service.
+type StatementServiceClient interface {
+	GetStatement(ctx context.Context, in *GetByIDRequest, opts ...grpc.CallOption) (*Statement, error)
+	ListStatements(ctx context.Context, in *ListStatementsRequest, opts ...grpc.CallOption) (*ListStatementsResponse, error)
+	ListModified(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*StatementList, error)
+	ListConflicts(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*StatementList, error)
+	UpdateLocal(ctx context.Context, in *UpdateLocalRequest, opts ...grpc.CallOption) (*Statement, error)
+	ResolveConflict(ctx context.Context, in *ResolveConflictRequest, opts ...grpc.CallOption) (*Statement, error)
+	RevertToRemote(ctx context.Context, in *GetByIDRequest, opts ...grpc.CallOption) (*Statement, error)
+}
+
+type statementServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewStatementServiceClient(cc grpc.ClientConnInterface) StatementServiceClient {
+	return &statementServiceClient{cc}
+}
+
+func (c *statementServiceClient) GetStatement(ctx context.Context, in *GetByIDRequest, opts ...grpc.CallOption) (*Statement, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Statement)
+	err := c.cc.Invoke(ctx, StatementService_GetStatement_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *statementServiceClient) ListStatements(ctx context.Context, in *ListStatementsRequest, opts ...grpc.CallOption) (*ListStatementsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListStatementsResponse)
+	err := c.cc.Invoke(ctx, StatementService_ListStatements_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *statementServiceClient) ListModified(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*StatementList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StatementList)
+	err := c.cc.Invoke(ctx, StatementService_ListModified_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *statementServiceClient) ListConflicts(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*StatementList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StatementList)
+	err := c.cc.Invoke(ctx, StatementService_ListConflicts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *statementServiceClient) UpdateLocal(ctx context.Context, in *UpdateLocalRequest, opts ...grpc.CallOption) (*Statement, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Statement)
+	err := c.cc.Invoke(ctx, StatementService_UpdateLocal_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *statementServiceClient) ResolveConflict(ctx context.Context, in *ResolveConflictRequest, opts ...grpc.CallOption) (*Statement, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Statement)
+	err := c.cc.Invoke(ctx, StatementService_ResolveConflict_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *statementServiceClient) RevertToRemote(ctx context.Context, in *GetByIDRequest, opts ...grpc.CallOption) (*Statement, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Statement)
+	err := c.cc.Invoke(ctx, StatementService_RevertToRemote_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// StatementServiceServer is the server API for StatementService service.
+// All implementations must embed UnimplementedStatementServiceServer
+// for forward compatibility.
+//
+// StatementService mirrors statement.Service.
+type StatementServiceServer interface {
+	GetStatement(context.Context, *GetByIDRequest) (*Statement, error)
+	ListStatements(context.Context, *ListStatementsRequest) (*ListStatementsResponse, error)
+	ListModified(context.Context, *emptypb.Empty) (*StatementList, error)
+	ListConflicts(context.Context, *emptypb.Empty) (*StatementList, error)
+	UpdateLocal(context.Context, *UpdateLocalRequest) (*Statement, error)
+	ResolveConflict(context.Context, *ResolveConflictRequest) (*Statement, error)
+	RevertToRemote(context.Context, *GetByIDRequest) (*Statement, error)
+	mustEmbedUnimplementedStatementServiceServer()
+}
+
+// UnimplementedStatementServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedStatementServiceServer struct{}
+
+func (UnimplementedStatementServiceServer) GetStatement(context.Context, *GetByIDRequest) (*Statement, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStatement not implemented")
+}
+func (UnimplementedStatementServiceServer) ListStatements(context.Context, *ListStatementsRequest) (*ListStatementsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListStatements not implemented")
+}
+func (UnimplementedStatementServiceServer) ListModified(context.Context, *emptypb.Empty) (*StatementList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListModified not implemented")
+}
+func (UnimplementedStatementServiceServer) ListConflicts(context.Context, *emptypb.Empty) (*StatementList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListConflicts not implemented")
+}
+func (UnimplementedStatementServiceServer) UpdateLocal(context.Context, *UpdateLocalRequest) (*Statement, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateLocal not implemented")
+}
+func (UnimplementedStatementServiceServer) ResolveConflict(context.Context, *ResolveConflictRequest) (*Statement, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResolveConflict not implemented")
+}
+func (UnimplementedStatementServiceServer) RevertToRemote(context.Context, *GetByIDRequest) (*Statement, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevertToRemote not implemented")
+}
+func (UnimplementedStatementServiceServer) mustEmbedUnimplementedStatementServiceServer() {}
+func (UnimplementedStatementServiceServer) testEmbeddedByValue()                          {}
+
+// UnsafeStatementServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to StatementServiceServer will
+// result in compilation errors.
+type UnsafeStatementServiceServer interface {
+	mustEmbedUnimplementedStatementServiceServer()
+}
+
+func RegisterStatementServiceServer(s grpc.ServiceRegistrar, srv StatementServiceServer) {
+	// If the following call pancis, it indicates UnimplementedStatementServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&StatementService_ServiceDesc, srv)
+}
+
+func _StatementService_GetStatement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetByIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatementServiceServer).GetStatement(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StatementService_GetStatement_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatementServiceServer).GetStatement(ctx, req.(*GetByIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StatementService_ListStatements_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListStatementsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatementServiceServer).ListStatements(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StatementService_ListStatements_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatementServiceServer).ListStatements(ctx, req.(*ListStatementsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StatementService_ListModified_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatementServiceServer).ListModified(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StatementService_ListModified_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatementServiceServer).ListModified(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StatementService_ListConflicts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatementServiceServer).ListConflicts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StatementService_ListConflicts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatementServiceServer).ListConflicts(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StatementService_UpdateLocal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateLocalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatementServiceServer).UpdateLocal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StatementService_UpdateLocal_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatementServiceServer).UpdateLocal(ctx, req.(*UpdateLocalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StatementService_ResolveConflict_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolveConflictRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatementServiceServer).ResolveConflict(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StatementService_ResolveConflict_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatementServiceServer).ResolveConflict(ctx, req.(*ResolveConflictRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StatementService_RevertToRemote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetByIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatementServiceServer).RevertToRemote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StatementService_RevertToRemote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatementServiceServer).RevertToRemote(ctx, req.(*GetByIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// StatementService_ServiceDesc is the grpc.ServiceDesc for StatementService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var StatementService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "autogrc.v1.StatementService",
+	HandlerType: (*StatementServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetStatement",
+			Handler:    _StatementService_GetStatement_Handler,
+		},
+		{
+			MethodName: "ListStatements",
+			Handler:    _StatementService_ListStatements_Handler,
+		},
+		{
+			MethodName: "ListModified",
+			Handler:    _StatementService_ListModified_Handler,
+		},
+		{
+			MethodName: "ListConflicts",
+			Handler:    _StatementService_ListConflicts_Handler,
+		},
+		{
+			MethodName: "UpdateLocal",
+			Handler:    _StatementService_UpdateLocal_Handler,
+		},
+		{
+			MethodName: "ResolveConflict",
+			Handler:    _StatementService_ResolveConflict_Handler,
+		},
+		{
+			MethodName: "RevertToRemote",
+			Handler:    _StatementService_RevertToRemote_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/autogrc.proto",
+}
+
+const (
+	PullService_StartPull_FullMethodName = "/autogrc.v1.PullService/StartPull"
+	PullService_GetJob_FullMethodName    = "/autogrc.v1.PullService/GetJob"
+	PullService_ListJobs_FullMethodName  = "/autogrc.v1.PullService/ListJobs"
+	PullService_CancelJob_FullMethodName = "/autogrc.v1.PullService/CancelJob"
+)
+
+// PullServiceClient is the client API for PullService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// PullService mirrors pull.Service.
+type PullServiceClient interface {
+	StartPull(ctx context.Context, in *StartPullRequest, opts ...grpc.CallOption) (*PullJob, error)
+	GetJob(ctx context.Context, in *GetByIDRequest, opts ...grpc.CallOption) (*PullJob, error)
+	ListJobs(ctx context.Context, in *ListPullJobsRequest, opts ...grpc.CallOption) (*ListPullJobsResponse, error)
+	CancelJob(ctx context.Context, in *GetByIDRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type pullServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPullServiceClient(cc grpc.ClientConnInterface) PullServiceClient {
+	return &pullServiceClient{cc}
+}
+
+func (c *pullServiceClient) StartPull(ctx context.Context, in *StartPullRequest, opts ...grpc.CallOption) (*PullJob, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PullJob)
+	err := c.cc.Invoke(ctx, PullService_StartPull_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pullServiceClient) GetJob(ctx context.Context, in *GetByIDRequest, opts ...grpc.CallOption) (*PullJob, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PullJob)
+	err := c.cc.Invoke(ctx, PullService_GetJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pullServiceClient) ListJobs(ctx context.Context, in *ListPullJobsRequest, opts ...grpc.CallOption) (*ListPullJobsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPullJobsResponse)
+	err := c.cc.Invoke(ctx, PullService_ListJobs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pullServiceClient) CancelJob(ctx context.Context, in *GetByIDRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, PullService_CancelJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PullServiceServer is the server API for PullService service.
+// All implementations must embed UnimplementedPullServiceServer
+// for forward compatibility.
+//
+// PullService mirrors pull.Service.
+type PullServiceServer interface {
+	StartPull(context.Context, *StartPullRequest) (*PullJob, error)
+	GetJob(context.Context, *GetByIDRequest) (*PullJob, error)
+	ListJobs(context.Context, *ListPullJobsRequest) (*ListPullJobsResponse, error)
+	CancelJob(context.Context, *GetByIDRequest) (*emptypb.Empty, error)
+	mustEmbedUnimplementedPullServiceServer()
+}
+
+// UnimplementedPullServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedPullServiceServer struct{}
+
+func (UnimplementedPullServiceServer) StartPull(context.Context, *StartPullRequest) (*PullJob, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartPull not implemented")
+}
+func (UnimplementedPullServiceServer) GetJob(context.Context, *GetByIDRequest) (*PullJob, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetJob not implemented")
+}
+func (UnimplementedPullServiceServer) ListJobs(context.Context, *ListPullJobsRequest) (*ListPullJobsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListJobs not implemented")
+}
+func (UnimplementedPullServiceServer) CancelJob(context.Context, *GetByIDRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelJob not implemented")
+}
+func (UnimplementedPullServiceServer) mustEmbedUnimplementedPullServiceServer() {}
+func (UnimplementedPullServiceServer) testEmbeddedByValue()                     {}
+
+// UnsafePullServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PullServiceServer will
+// result in compilation errors.
+type UnsafePullServiceServer interface {
+	mustEmbedUnimplementedPullServiceServer()
+}
+
+func RegisterPullServiceServer(s grpc.ServiceRegistrar, srv PullServiceServer) {
+	// If the following call pancis, it indicates UnimplementedPullServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&PullService_ServiceDesc, srv)
+}
+
+func _PullService_StartPull_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartPullRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PullServiceServer).StartPull(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PullService_StartPull_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PullServiceServer).StartPull(ctx, req.(*StartPullRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PullService_GetJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetByIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PullServiceServer).GetJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PullService_GetJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PullServiceServer).GetJob(ctx, req.(*GetByIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PullService_ListJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPullJobsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PullServiceServer).ListJobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PullService_ListJobs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PullServiceServer).ListJobs(ctx, req.(*ListPullJobsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PullService_CancelJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetByIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PullServiceServer).CancelJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PullService_CancelJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PullServiceServer).CancelJob(ctx, req.(*GetByIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PullService_ServiceDesc is the grpc.ServiceDesc for PullService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PullService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "autogrc.v1.PullService",
+	HandlerType: (*PullServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "StartPull",
+			Handler:    _PullService_StartPull_Handler,
+		},
+		{
+			MethodName: "GetJob",
+			Handler:    _PullService_GetJob_Handler,
+		},
+		{
+			MethodName: "ListJobs",
+			Handler:    _PullService_ListJobs_Handler,
+		},
+		{
+			MethodName: "CancelJob",
+			Handler:    _PullService_CancelJob_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/autogrc.proto",
+}